@@ -0,0 +1,103 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// BatchError is returned by batch calls when some of the requests failed. It holds the errors of the failed
+// requests, keyed by the index of the request in the batch.
+type BatchError struct {
+	Errors map[int]error
+}
+
+func (e *BatchError) Error() string {
+	indexes := make([]int, 0, len(e.Errors))
+
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+
+	sort.Ints(indexes)
+
+	msgs := make([]string, 0, len(indexes))
+
+	for _, i := range indexes {
+		msgs = append(msgs, fmt.Sprintf("request %d: %s", i, e.Errors[i]))
+	}
+
+	return fmt.Sprintf("%d batch request(s) failed: %s", len(indexes), strings.Join(msgs, "; "))
+}
+
+// NewBatchError returns a *BatchError that holds the errors of the provided requests, or nil if none of the
+// requests failed.
+func NewBatchError(b []gethrpc.BatchElem) error {
+	errs := make(map[int]error)
+
+	for i, elem := range b {
+		if elem.Error != nil {
+			errs[i] = elem.Error
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &BatchError{Errors: errs}
+}
+
+// BatchCallWithSize sends the given requests as JSON-RPC batch requests that hold at most batchSize requests each.
+// All requests are sent in a single batch request if batchSize is not positive.
+//
+// Errors specific to a request are reported through the Error field of the corresponding BatchElem, see
+// NewBatchError.
+func BatchCallWithSize(c Client, b []gethrpc.BatchElem, batchSize int) error {
+	ctx := context.Background()
+
+	return BatchCallWithSizeContext(ctx, c, b, batchSize)
+}
+
+// BatchCallWithSizeContext is like BatchCallWithSize, the batch requests are bound to the provided context.
+//
+// The batch requests are sent sequentially and the error of the first batch request that fails is returned,
+// in which case the remaining requests are not sent.
+func BatchCallWithSizeContext(ctx context.Context, c Client, b []gethrpc.BatchElem, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = len(b)
+	}
+
+	for start := 0; start < len(b); start += batchSize {
+		end := start + batchSize
+
+		if end > len(b) {
+			end = len(b)
+		}
+
+		if err := c.BatchCallContext(ctx, b[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
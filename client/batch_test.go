@@ -0,0 +1,131 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client/mocks"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var errTestFailure = errors.New("test failure")
+
+type testService struct{}
+
+func (testService) Echo(s string) string {
+	return s
+}
+
+func (testService) Fail() error {
+	return errTestFailure
+}
+
+func TestBatchCallWithSize_Transports(t *testing.T) {
+	wsSrv := rpcmocksrv.New()
+	assert.NoError(t, wsSrv.RegisterName("test", testService{}))
+
+	httpRPCSrv := gethrpc.NewServer()
+	assert.NoError(t, httpRPCSrv.RegisterName("test", testService{}))
+
+	httpSrv := httptest.NewServer(httpRPCSrv)
+	defer httpSrv.Close()
+
+	for _, url := range []string{wsSrv.URL, httpSrv.URL} {
+		t.Run(url, func(t *testing.T) {
+			cl, err := Connect(url)
+			assert.NoError(t, err)
+			defer cl.Close()
+
+			res := make([]string, 5)
+			batch := make([]gethrpc.BatchElem, len(res))
+
+			for i := range batch {
+				batch[i] = gethrpc.BatchElem{Method: "test_echo", Args: []interface{}{url}, Result: &res[i]}
+
+				if i%2 == 1 {
+					batch[i].Method = "test_fail"
+					batch[i].Args = nil
+				}
+			}
+
+			assert.NoError(t, BatchCallWithSize(cl, batch, 2))
+			assert.Equal(t, []string{url, "", url, "", url}, res)
+
+			var batchErr *BatchError
+			assert.ErrorAs(t, NewBatchError(batch), &batchErr)
+			assert.Len(t, batchErr.Errors, 2)
+			assert.EqualError(t, batchErr.Errors[1], errTestFailure.Error())
+			assert.EqualError(t, batchErr.Errors[3], errTestFailure.Error())
+		})
+	}
+}
+
+func TestBatchCallWithSize(t *testing.T) {
+	batch := make([]gethrpc.BatchElem, 5)
+
+	tests := []struct {
+		batchSize     int
+		expectedSizes []int
+	}{
+		{batchSize: 2, expectedSizes: []int{2, 2, 1}},
+		{batchSize: 5, expectedSizes: []int{5}},
+		{batchSize: 10, expectedSizes: []int{5}},
+		{batchSize: 0, expectedSizes: []int{5}},
+	}
+
+	for _, test := range tests {
+		cl := mocks.NewClient(t)
+
+		for _, size := range test.expectedSizes {
+			size := size
+
+			cl.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []gethrpc.BatchElem) bool {
+				return len(b) == size
+			})).Return(nil).Once()
+		}
+
+		assert.NoError(t, BatchCallWithSize(cl, batch, test.batchSize))
+	}
+
+	assert.NoError(t, BatchCallWithSize(mocks.NewClient(t), nil, 2))
+}
+
+func TestBatchCallWithSizeContext_Error(t *testing.T) {
+	ctx := context.Background()
+
+	cl := mocks.NewClient(t)
+	cl.On("BatchCallContext", ctx, mock.Anything).Return(errTestFailure).Once()
+
+	err := BatchCallWithSizeContext(ctx, cl, make([]gethrpc.BatchElem, 5), 2)
+	assert.ErrorIs(t, err, errTestFailure)
+}
+
+func TestNewBatchError(t *testing.T) {
+	assert.NoError(t, NewBatchError(nil))
+	assert.NoError(t, NewBatchError([]gethrpc.BatchElem{{}, {}}))
+
+	err := NewBatchError([]gethrpc.BatchElem{{}, {Error: errTestFailure}, {Error: errTestFailure}})
+	assert.Equal(t, &BatchError{Errors: map[int]error{1: errTestFailure, 2: errTestFailure}}, err)
+	assert.EqualError(t, err, "2 batch request(s) failed: request 1: test failure; request 2: test failure")
+}
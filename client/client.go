@@ -39,6 +39,13 @@ type Client interface {
 		args ...interface{},
 	) error
 
+	// BatchCall sends all given requests as a single JSON-RPC batch request and waits for the server to return
+	// a response for all of them. Errors specific to a request are reported through the Error field of the
+	// corresponding BatchElem.
+	BatchCall(b []gethrpc.BatchElem) error
+
+	BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error
+
 	Subscribe(
 		ctx context.Context,
 		namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
@@ -80,6 +87,22 @@ func Connect(url string) (Client, error) {
 	return &cc, nil
 }
 
+// UnsubscribeOnDone calls unsubscribe once the provided context is done, unless the subscription ends first. It is
+// used to tie the lifetime of a subscription to a context.
+func UnsubscribeOnDone(ctx context.Context, sub *gethrpc.ClientSubscription, unsubscribe func()) {
+	if ctx.Done() == nil {
+		return
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			unsubscribe()
+		case <-sub.Done():
+		}
+	}()
+}
+
 func CallWithBlockHash(c Client, target interface{}, method string, blockHash *types.Hash, args ...interface{}) error {
 	ctx := context.Background()
 
@@ -0,0 +1,236 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// CallInvoker sends a call, it is called by an Interceptor to continue the call.
+type CallInvoker func(ctx context.Context, result interface{}, method string, args ...interface{}) error
+
+// SubscribeInvoker creates a subscription, it is called by an Interceptor to continue the subscription.
+type SubscribeInvoker func(ctx context.Context, req SubscribeRequest) (*gethrpc.ClientSubscription, error)
+
+// BatchInvoker sends a batch of calls, it is called by an Interceptor to continue the batch call.
+type BatchInvoker func(ctx context.Context, b []gethrpc.BatchElem) error
+
+// SubscribeRequest holds the arguments of a Subscribe call.
+type SubscribeRequest struct {
+	Namespace                string
+	SubscribeMethodSuffix    string
+	UnsubscribeMethodSuffix  string
+	NotificationMethodSuffix string
+	Channel                  interface{}
+	Args                     []interface{}
+}
+
+// Method returns the RPC method that is called to create the subscription.
+func (r SubscribeRequest) Method() string {
+	return r.Namespace + methodSeparator + r.SubscribeMethodSuffix
+}
+
+// getBatchElemError returns the error of a call sent in a batch call that returned the provided error.
+func getBatchElemError(elem gethrpc.BatchElem, err error) error {
+	if err != nil {
+		return err
+	}
+
+	return elem.Error
+}
+
+// getBatchError returns the provided error of a batch call, or the joined errors of its calls if it is nil.
+func getBatchError(b []gethrpc.BatchElem, err error) error {
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0, len(b))
+
+	for _, elem := range b {
+		errs = append(errs, elem.Error)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Interceptor observes or modifies the calls, batch calls and subscriptions of a Client.
+//
+// An Interceptor must call the provided invoker to continue the call, batch call or subscription, it can return
+// without calling it to prevent the request from being sent.
+type Interceptor interface {
+	InterceptCall(ctx context.Context, result interface{}, method string, invoker CallInvoker, args ...interface{}) error
+
+	InterceptBatch(ctx context.Context, b []gethrpc.BatchElem, invoker BatchInvoker) error
+
+	InterceptSubscribe(
+		ctx context.Context,
+		req SubscribeRequest,
+		invoker SubscribeInvoker,
+	) (*gethrpc.ClientSubscription, error)
+}
+
+// InterceptorFuncs is an Interceptor built from functions, a nil function continues the call, batch call or
+// subscription unchanged.
+type InterceptorFuncs struct {
+	Call func(ctx context.Context, result interface{}, method string, invoker CallInvoker, args ...interface{}) error
+
+	Batch func(ctx context.Context, b []gethrpc.BatchElem, invoker BatchInvoker) error
+
+	Subscribe func(
+		ctx context.Context,
+		req SubscribeRequest,
+		invoker SubscribeInvoker,
+	) (*gethrpc.ClientSubscription, error)
+}
+
+func (f InterceptorFuncs) InterceptCall(
+	ctx context.Context,
+	result interface{},
+	method string,
+	invoker CallInvoker,
+	args ...interface{},
+) error {
+	if f.Call == nil {
+		return invoker(ctx, result, method, args...)
+	}
+
+	return f.Call(ctx, result, method, invoker, args...)
+}
+
+func (f InterceptorFuncs) InterceptBatch(ctx context.Context, b []gethrpc.BatchElem, invoker BatchInvoker) error {
+	if f.Batch == nil {
+		return invoker(ctx, b)
+	}
+
+	return f.Batch(ctx, b, invoker)
+}
+
+func (f InterceptorFuncs) InterceptSubscribe(
+	ctx context.Context,
+	req SubscribeRequest,
+	invoker SubscribeInvoker,
+) (*gethrpc.ClientSubscription, error) {
+	if f.Subscribe == nil {
+		return invoker(ctx, req)
+	}
+
+	return f.Subscribe(ctx, req, invoker)
+}
+
+// interceptedClient is a Client that passes its calls, batch calls and subscriptions through a chain of interceptors.
+type interceptedClient struct {
+	Client
+
+	interceptors []Interceptor
+}
+
+// NewInterceptedClient returns a Client that passes the calls, batch calls and subscriptions of the provided Client
+// through the provided interceptors. The first interceptor is the outermost one, it is the first to see a request
+// and the last to see its result.
+func NewInterceptedClient(c Client, interceptors ...Interceptor) Client {
+	return &interceptedClient{
+		Client:       c,
+		interceptors: interceptors,
+	}
+}
+
+func (c *interceptedClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *interceptedClient) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	return c.getCallInvoker(0)(ctx, result, method, args...)
+}
+
+func (c *interceptedClient) BatchCall(b []gethrpc.BatchElem) error {
+	return c.BatchCallContext(context.Background(), b)
+}
+
+func (c *interceptedClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	return c.getBatchInvoker(0)(ctx, b)
+}
+
+func (c *interceptedClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	req := SubscribeRequest{
+		Namespace:                namespace,
+		SubscribeMethodSuffix:    subscribeMethodSuffix,
+		UnsubscribeMethodSuffix:  unsubscribeMethodSuffix,
+		NotificationMethodSuffix: notificationMethodSuffix,
+		Channel:                  channel,
+		Args:                     args,
+	}
+
+	return c.getSubscribeInvoker(0)(ctx, req)
+}
+
+// getCallInvoker returns the invoker that continues a call from the interceptor at the provided index.
+func (c *interceptedClient) getCallInvoker(index int) CallInvoker {
+	if index == len(c.interceptors) {
+		return c.Client.CallContext
+	}
+
+	return func(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+		return c.interceptors[index].InterceptCall(ctx, result, method, c.getCallInvoker(index+1), args...)
+	}
+}
+
+// getBatchInvoker returns the invoker that continues a batch call from the interceptor at the provided index.
+func (c *interceptedClient) getBatchInvoker(index int) BatchInvoker {
+	if index == len(c.interceptors) {
+		return c.Client.BatchCallContext
+	}
+
+	return func(ctx context.Context, b []gethrpc.BatchElem) error {
+		return c.interceptors[index].InterceptBatch(ctx, b, c.getBatchInvoker(index+1))
+	}
+}
+
+// getSubscribeInvoker returns the invoker that continues a subscription from the interceptor at the provided index.
+func (c *interceptedClient) getSubscribeInvoker(index int) SubscribeInvoker {
+	if index == len(c.interceptors) {
+		return func(ctx context.Context, req SubscribeRequest) (*gethrpc.ClientSubscription, error) {
+			return c.Client.Subscribe(
+				ctx,
+				req.Namespace,
+				req.SubscribeMethodSuffix,
+				req.UnsubscribeMethodSuffix,
+				req.NotificationMethodSuffix,
+				req.Channel,
+				req.Args...,
+			)
+		}
+	}
+
+	return func(ctx context.Context, req SubscribeRequest) (*gethrpc.ClientSubscription, error) {
+		return c.interceptors[index].InterceptSubscribe(ctx, req, c.getSubscribeInvoker(index+1))
+	}
+}
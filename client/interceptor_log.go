@@ -0,0 +1,110 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// NewLogInterceptor returns an Interceptor that logs the calls, batch calls and subscriptions of a Client to the
+// provided logger.
+//
+// Successful requests and the end of subscriptions are logged at the debug level, failed requests at the error
+// level. The records hold the method, the duration of the request and its error, if any. A batch call is logged
+// with one record for each of its calls, holding the duration of the whole batch.
+func NewLogInterceptor(logger *slog.Logger) Interceptor {
+	return InterceptorFuncs{
+		Call: func(
+			ctx context.Context,
+			result interface{},
+			method string,
+			invoker CallInvoker,
+			args ...interface{},
+		) error {
+			start := time.Now()
+
+			err := invoker(ctx, result, method, args...)
+
+			logRequest(ctx, logger, "RPC call", method, time.Since(start), err)
+
+			return err
+		},
+		Batch: func(ctx context.Context, b []gethrpc.BatchElem, invoker BatchInvoker) error {
+			start := time.Now()
+
+			err := invoker(ctx, b)
+
+			duration := time.Since(start)
+
+			for _, elem := range b {
+				logRequest(ctx, logger, "RPC batch call", elem.Method, duration, getBatchElemError(elem, err))
+			}
+
+			return err
+		},
+		Subscribe: func(
+			ctx context.Context,
+			req SubscribeRequest,
+			invoker SubscribeInvoker,
+		) (*gethrpc.ClientSubscription, error) {
+			start := time.Now()
+
+			sub, err := invoker(ctx, req)
+
+			logRequest(ctx, logger, "RPC subscribe", req.Method(), time.Since(start), err)
+
+			if err != nil {
+				return nil, err
+			}
+
+			go func() {
+				<-sub.Done()
+
+				logger.LogAttrs(
+					context.Background(),
+					slog.LevelDebug,
+					"RPC subscription ended",
+					slog.String("method", req.Method()),
+					slog.Duration("duration", time.Since(start)),
+				)
+			}()
+
+			return sub, nil
+		},
+	}
+}
+
+func logRequest(ctx context.Context, logger *slog.Logger, msg, method string, duration time.Duration, err error) {
+	if err != nil {
+		logger.LogAttrs(
+			ctx,
+			slog.LevelError,
+			msg,
+			slog.String("method", method),
+			slog.Duration("duration", duration),
+			slog.Any("error", err),
+		)
+
+		return
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, msg, slog.String("method", method), slog.Duration("duration", duration))
+}
@@ -0,0 +1,309 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// MetricsRecorder records the metrics of the calls and subscriptions of a Client.
+//
+// It is implemented by Metrics, and can be implemented on top of other metrics libraries, such as the Prometheus
+// client.
+type MetricsRecorder interface {
+	// ObserveRequest records a call or the creation of a subscription.
+	ObserveRequest(method string, duration time.Duration, err error)
+
+	// SubscriptionStarted records the start of a subscription created with the provided method.
+	SubscriptionStarted(method string)
+
+	// SubscriptionEnded records the end of a subscription created with the provided method.
+	SubscriptionEnded(method string)
+}
+
+// NewMetricsInterceptor returns an Interceptor that records the metrics of the calls, batch calls and subscriptions
+// of a Client with the provided recorder. Each call of a batch call is recorded with the duration of the whole batch.
+func NewMetricsInterceptor(recorder MetricsRecorder) Interceptor {
+	return InterceptorFuncs{
+		Call: func(
+			ctx context.Context,
+			result interface{},
+			method string,
+			invoker CallInvoker,
+			args ...interface{},
+		) error {
+			start := time.Now()
+
+			err := invoker(ctx, result, method, args...)
+
+			recorder.ObserveRequest(method, time.Since(start), err)
+
+			return err
+		},
+		Batch: func(ctx context.Context, b []gethrpc.BatchElem, invoker BatchInvoker) error {
+			start := time.Now()
+
+			err := invoker(ctx, b)
+
+			duration := time.Since(start)
+
+			for _, elem := range b {
+				recorder.ObserveRequest(elem.Method, duration, getBatchElemError(elem, err))
+			}
+
+			return err
+		},
+		Subscribe: func(
+			ctx context.Context,
+			req SubscribeRequest,
+			invoker SubscribeInvoker,
+		) (*gethrpc.ClientSubscription, error) {
+			start := time.Now()
+
+			sub, err := invoker(ctx, req)
+
+			recorder.ObserveRequest(req.Method(), time.Since(start), err)
+
+			if err != nil {
+				return nil, err
+			}
+
+			recorder.SubscriptionStarted(req.Method())
+
+			go func() {
+				<-sub.Done()
+
+				recorder.SubscriptionEnded(req.Method())
+			}()
+
+			return sub, nil
+		},
+	}
+}
+
+const (
+	// DefaultMetricsNamespace is the default prefix of the metric names of Metrics.
+	DefaultMetricsNamespace = "gsrpc"
+)
+
+// DefaultLatencyBuckets are the default upper bounds, in seconds, of the request duration histogram of Metrics.
+var DefaultLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type metricsOptions struct {
+	namespace      string
+	latencyBuckets []float64
+}
+
+// MetricsOption is used for configuring Metrics.
+type MetricsOption func(opts *metricsOptions)
+
+// WithMetricsNamespace sets the prefix of the metric names.
+func WithMetricsNamespace(namespace string) MetricsOption {
+	return func(opts *metricsOptions) {
+		opts.namespace = namespace
+	}
+}
+
+// WithLatencyBuckets sets the upper bounds, in seconds, of the request duration histogram.
+func WithLatencyBuckets(buckets ...float64) MetricsOption {
+	return func(opts *metricsOptions) {
+		opts.latencyBuckets = buckets
+	}
+}
+
+// Metrics is a MetricsRecorder that keeps the metrics in memory and exposes them in the Prometheus text format.
+//
+// It records the following metrics, labeled by RPC method:
+//   - <namespace>_client_request_duration_seconds, a histogram of the request durations
+//   - <namespace>_client_request_errors_total, a counter of the failed requests
+//   - <namespace>_client_active_subscriptions, a gauge of the active subscriptions
+type Metrics struct {
+	opts metricsOptions
+
+	mu                  sync.Mutex
+	durations           map[string]*histogram
+	errors              map[string]uint64
+	activeSubscriptions map[string]int64
+}
+
+// NewMetrics creates a new Metrics.
+func NewMetrics(opts ...MetricsOption) *Metrics {
+	metricsOpts := metricsOptions{
+		namespace:      DefaultMetricsNamespace,
+		latencyBuckets: DefaultLatencyBuckets,
+	}
+
+	for _, opt := range opts {
+		opt(&metricsOpts)
+	}
+
+	buckets := append([]float64(nil), metricsOpts.latencyBuckets...)
+	sort.Float64s(buckets)
+
+	metricsOpts.latencyBuckets = buckets
+
+	return &Metrics{
+		opts:                metricsOpts,
+		durations:           make(map[string]*histogram),
+		errors:              make(map[string]uint64),
+		activeSubscriptions: make(map[string]int64),
+	}
+}
+
+func (m *Metrics) ObserveRequest(method string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h, ok := m.durations[method]
+
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.opts.latencyBuckets))}
+
+		m.durations[method] = h
+	}
+
+	h.observe(m.opts.latencyBuckets, duration.Seconds())
+
+	if err != nil {
+		m.errors[method]++
+	}
+}
+
+func (m *Metrics) SubscriptionStarted(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.activeSubscriptions[method]++
+}
+
+func (m *Metrics) SubscriptionEnded(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.activeSubscriptions[method]--
+}
+
+// Write writes the metrics to the provided writer in the Prometheus text format.
+func (m *Metrics) Write(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+
+	name := m.opts.namespace + "_client_request_duration_seconds"
+
+	writeMetricHeader(bw, name, "histogram", "Duration of the RPC requests in seconds.")
+
+	for _, method := range getSortedKeys(m.durations) {
+		h := m.durations[method]
+
+		for i, bound := range m.opts.latencyBuckets {
+			fmt.Fprintf(
+				bw,
+				"%s_bucket{method=\"%s\",le=\"%s\"} %d\n",
+				name,
+				escapeLabelValue(method),
+				formatFloat(bound),
+				h.counts[i],
+			)
+		}
+
+		fmt.Fprintf(bw, "%s_bucket{method=\"%s\",le=\"+Inf\"} %d\n", name, escapeLabelValue(method), h.count)
+		fmt.Fprintf(bw, "%s_sum{method=\"%s\"} %s\n", name, escapeLabelValue(method), formatFloat(h.sum))
+		fmt.Fprintf(bw, "%s_count{method=\"%s\"} %d\n", name, escapeLabelValue(method), h.count)
+	}
+
+	name = m.opts.namespace + "_client_request_errors_total"
+
+	writeMetricHeader(bw, name, "counter", "Number of failed RPC requests.")
+
+	for _, method := range getSortedKeys(m.errors) {
+		fmt.Fprintf(bw, "%s{method=\"%s\"} %d\n", name, escapeLabelValue(method), m.errors[method])
+	}
+
+	name = m.opts.namespace + "_client_active_subscriptions"
+
+	writeMetricHeader(bw, name, "gauge", "Number of active RPC subscriptions.")
+
+	for _, method := range getSortedKeys(m.activeSubscriptions) {
+		fmt.Fprintf(bw, "%s{method=\"%s\"} %d\n", name, escapeLabelValue(method), m.activeSubscriptions[method])
+	}
+
+	return bw.Flush()
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format, so that they can be scraped by Prometheus.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	_ = m.Write(w)
+}
+
+type histogram struct {
+	// counts holds the cumulative number of observations of each bucket.
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (h *histogram) observe(buckets []float64, value float64) {
+	for i, bound := range buckets {
+		if value <= bound {
+			h.counts[i]++
+		}
+	}
+
+	h.count++
+	h.sum += value
+}
+
+func writeMetricHeader(w io.Writer, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func getSortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
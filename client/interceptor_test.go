@@ -0,0 +1,480 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/stretchr/testify/assert"
+)
+
+// subscriptionService serves subscriptions that never send notifications.
+type subscriptionService struct{}
+
+func (subscriptionService) Subscribe() string {
+	return "0x1"
+}
+
+func (subscriptionService) Unsubscribe(string) bool {
+	return true
+}
+
+func newInterceptorTestClient(t *testing.T, interceptors ...Interceptor) Client {
+	s := rpcmocksrv.New()
+	assert.NoError(t, s.RegisterName("test", testService{}))
+	assert.NoError(t, s.RegisterName("sub", subscriptionService{}))
+
+	c, err := Connect(s.URL)
+	assert.NoError(t, err)
+
+	t.Cleanup(c.Close)
+
+	return NewInterceptedClient(c, interceptors...)
+}
+
+func TestInterceptedClient_Chain(t *testing.T) {
+	var calls []string
+
+	newInterceptor := func(name string) Interceptor {
+		return InterceptorFuncs{
+			Call: func(
+				ctx context.Context,
+				result interface{},
+				method string,
+				invoker CallInvoker,
+				args ...interface{},
+			) error {
+				calls = append(calls, name+" "+method)
+
+				err := invoker(ctx, result, method, args...)
+
+				calls = append(calls, name+" done")
+
+				return err
+			},
+			Subscribe: func(
+				ctx context.Context,
+				req SubscribeRequest,
+				invoker SubscribeInvoker,
+			) (*gethrpc.ClientSubscription, error) {
+				calls = append(calls, name+" "+req.Method())
+
+				return invoker(ctx, req)
+			},
+		}
+	}
+
+	// The interceptor can change the arguments of the call.
+	replaceArgs := InterceptorFuncs{
+		Call: func(
+			ctx context.Context,
+			result interface{},
+			method string,
+			invoker CallInvoker,
+			_ ...interface{},
+		) error {
+			return invoker(ctx, result, method, "intercepted")
+		},
+	}
+
+	cl := newInterceptorTestClient(t, newInterceptor("first"), newInterceptor("second"), replaceArgs)
+
+	var res string
+	assert.NoError(t, cl.Call(&res, "test_echo", "hello"))
+	assert.Equal(t, "intercepted", res)
+
+	sub, err := cl.Subscribe(context.Background(), "sub", "subscribe", "unsubscribe", "notify", make(chan string))
+	assert.NoError(t, err)
+	sub.Unsubscribe()
+
+	assert.Equal(t, []string{
+		"first test_echo",
+		"second test_echo",
+		"second done",
+		"first done",
+		"first sub_subscribe",
+		"second sub_subscribe",
+	}, calls)
+}
+
+func TestInterceptedClient_BatchChain(t *testing.T) {
+	var calls []string
+
+	newInterceptor := func(name string) Interceptor {
+		return InterceptorFuncs{
+			Batch: func(ctx context.Context, b []gethrpc.BatchElem, invoker BatchInvoker) error {
+				calls = append(calls, name+" "+b[0].Method)
+
+				err := invoker(ctx, b)
+
+				calls = append(calls, name+" done")
+
+				return err
+			},
+		}
+	}
+
+	// The interceptor can change the calls of the batch.
+	replaceArgs := InterceptorFuncs{
+		Batch: func(ctx context.Context, b []gethrpc.BatchElem, invoker BatchInvoker) error {
+			for i := range b {
+				b[i].Args = []interface{}{"intercepted"}
+			}
+
+			return invoker(ctx, b)
+		},
+	}
+
+	cl := newInterceptorTestClient(t, newInterceptor("first"), newInterceptor("second"), replaceArgs)
+
+	var res1, res2 string
+
+	assert.NoError(t, cl.BatchCall([]gethrpc.BatchElem{
+		{Method: "test_echo", Args: []interface{}{"hello"}, Result: &res1},
+		{Method: "test_echo", Args: []interface{}{"world"}, Result: &res2},
+	}))
+	assert.Equal(t, "intercepted", res1)
+	assert.Equal(t, "intercepted", res2)
+
+	assert.Equal(t, []string{
+		"first test_echo",
+		"second test_echo",
+		"second done",
+		"first done",
+	}, calls)
+}
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) getRecords(t *testing.T) []map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var records []map[string]interface{}
+
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var record map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+
+		delete(record, "time")
+		delete(record, "duration")
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestLogInterceptor(t *testing.T) {
+	var buf syncBuffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	cl := newInterceptorTestClient(t, NewLogInterceptor(logger))
+
+	var res string
+	assert.NoError(t, cl.Call(&res, "test_echo", "hello"))
+	assert.Error(t, cl.Call(&res, "test_fail"))
+
+	sub, err := cl.Subscribe(context.Background(), "sub", "subscribe", "unsubscribe", "notify", make(chan string))
+	assert.NoError(t, err)
+	sub.Unsubscribe()
+
+	assert.Eventually(t, func() bool {
+		return len(buf.getRecords(t)) == 4
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "DEBUG", "msg": "RPC call", "method": "test_echo"},
+		{"level": "ERROR", "msg": "RPC call", "method": "test_fail", "error": errTestFailure.Error()},
+		{"level": "DEBUG", "msg": "RPC subscribe", "method": "sub_subscribe"},
+		{"level": "DEBUG", "msg": "RPC subscription ended", "method": "sub_subscribe"},
+	}, buf.getRecords(t))
+}
+
+func TestLogInterceptor_Batch(t *testing.T) {
+	var buf syncBuffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	cl := newInterceptorTestClient(t, NewLogInterceptor(logger))
+
+	var res string
+
+	assert.NoError(t, cl.BatchCall([]gethrpc.BatchElem{
+		{Method: "test_echo", Args: []interface{}{"hello"}, Result: &res},
+		{Method: "test_fail"},
+	}))
+
+	assert.Equal(t, []map[string]interface{}{
+		{"level": "DEBUG", "msg": "RPC batch call", "method": "test_echo"},
+		{"level": "ERROR", "msg": "RPC batch call", "method": "test_fail", "error": errTestFailure.Error()},
+	}, buf.getRecords(t))
+}
+
+func TestMetricsInterceptor(t *testing.T) {
+	metrics := NewMetrics(WithMetricsNamespace("test"), WithLatencyBuckets(60, 10))
+
+	cl := newInterceptorTestClient(t, NewMetricsInterceptor(metrics))
+
+	var res string
+	assert.NoError(t, cl.Call(&res, "test_echo", "hello"))
+	assert.NoError(t, cl.Call(&res, "test_echo", "hello"))
+	assert.Error(t, cl.Call(&res, "test_fail"))
+
+	sub1, err := cl.Subscribe(context.Background(), "sub", "subscribe", "unsubscribe", "notify", make(chan string))
+	assert.NoError(t, err)
+
+	sub2, err := cl.Subscribe(context.Background(), "sub", "subscribe", "unsubscribe", "notify", make(chan string))
+	assert.NoError(t, err)
+
+	sub1.Unsubscribe()
+
+	assert.Eventually(t, func() bool {
+		var buf bytes.Buffer
+		assert.NoError(t, metrics.Write(&buf))
+
+		return strings.Contains(buf.String(), `test_client_active_subscriptions{method="sub_subscribe"} 1`)
+	}, time.Second, time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	// The sums of the durations vary between runs.
+	var lines []string
+
+	for _, line := range strings.Split(rec.Body.String(), "\n") {
+		if !strings.Contains(line, "_sum{") {
+			lines = append(lines, line)
+		}
+	}
+
+	assert.Equal(t, `# HELP test_client_request_duration_seconds Duration of the RPC requests in seconds.
+# TYPE test_client_request_duration_seconds histogram
+test_client_request_duration_seconds_bucket{method="sub_subscribe",le="10"} 2
+test_client_request_duration_seconds_bucket{method="sub_subscribe",le="60"} 2
+test_client_request_duration_seconds_bucket{method="sub_subscribe",le="+Inf"} 2
+test_client_request_duration_seconds_count{method="sub_subscribe"} 2
+test_client_request_duration_seconds_bucket{method="test_echo",le="10"} 2
+test_client_request_duration_seconds_bucket{method="test_echo",le="60"} 2
+test_client_request_duration_seconds_bucket{method="test_echo",le="+Inf"} 2
+test_client_request_duration_seconds_count{method="test_echo"} 2
+test_client_request_duration_seconds_bucket{method="test_fail",le="10"} 1
+test_client_request_duration_seconds_bucket{method="test_fail",le="60"} 1
+test_client_request_duration_seconds_bucket{method="test_fail",le="+Inf"} 1
+test_client_request_duration_seconds_count{method="test_fail"} 1
+# HELP test_client_request_errors_total Number of failed RPC requests.
+# TYPE test_client_request_errors_total counter
+test_client_request_errors_total{method="test_fail"} 1
+# HELP test_client_active_subscriptions Number of active RPC subscriptions.
+# TYPE test_client_active_subscriptions gauge
+test_client_active_subscriptions{method="sub_subscribe"} 1
+`, strings.Join(lines, "\n"))
+
+	sub2.Unsubscribe()
+}
+
+func TestMetricsInterceptor_Batch(t *testing.T) {
+	metrics := NewMetrics(WithMetricsNamespace("test"))
+
+	cl := newInterceptorTestClient(t, NewMetricsInterceptor(metrics))
+
+	var res1, res2 string
+
+	assert.NoError(t, cl.BatchCall([]gethrpc.BatchElem{
+		{Method: "test_echo", Args: []interface{}{"hello"}, Result: &res1},
+		{Method: "test_echo", Args: []interface{}{"world"}, Result: &res2},
+		{Method: "test_fail"},
+	}))
+
+	var buf bytes.Buffer
+	assert.NoError(t, metrics.Write(&buf))
+
+	assert.Contains(t, buf.String(), `test_client_request_duration_seconds_count{method="test_echo"} 2`)
+	assert.Contains(t, buf.String(), `test_client_request_duration_seconds_count{method="test_fail"} 1`)
+	assert.Contains(t, buf.String(), `test_client_request_errors_total{method="test_fail"} 1`)
+	assert.NotContains(t, buf.String(), `test_client_request_errors_total{method="test_echo"}`)
+}
+
+func TestMetrics_Histogram(t *testing.T) {
+	metrics := NewMetrics(WithLatencyBuckets(0.1, 1))
+
+	metrics.ObserveRequest("chain_getBlock", 50*time.Millisecond, nil)
+	metrics.ObserveRequest("chain_getBlock", 500*time.Millisecond, nil)
+	metrics.ObserveRequest("chain_getBlock", 5*time.Second, errTestFailure)
+	metrics.ObserveRequest(`a"b`, time.Second, nil)
+
+	var buf bytes.Buffer
+	assert.NoError(t, metrics.Write(&buf))
+
+	assert.Contains(t, buf.String(), `gsrpc_client_request_duration_seconds_bucket{method="chain_getBlock",le="0.1"} 1
+gsrpc_client_request_duration_seconds_bucket{method="chain_getBlock",le="1"} 2
+gsrpc_client_request_duration_seconds_bucket{method="chain_getBlock",le="+Inf"} 3
+gsrpc_client_request_duration_seconds_sum{method="chain_getBlock"} 5.55
+gsrpc_client_request_duration_seconds_count{method="chain_getBlock"} 3
+`)
+	assert.Contains(t, buf.String(), `gsrpc_client_request_errors_total{method="chain_getBlock"} 1`)
+	assert.Contains(t, buf.String(), `gsrpc_client_request_duration_seconds_bucket{method="a\"b",le="1"} 1`)
+}
+
+type testSpan struct {
+	name       string
+	attributes map[string]string
+	err        error
+	ended      bool
+}
+
+func (s *testSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+type testSpanKey struct{}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, spanName string, attributes map[string]string) (context.Context, Span) {
+	span := &testSpan{name: spanName, attributes: attributes}
+
+	t.spans = append(t.spans, span)
+
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func TestTraceInterceptor(t *testing.T) {
+	tracer := &testTracer{}
+
+	// The context of the span is passed to the next interceptors.
+	var spanCtx []bool
+
+	checkCtx := InterceptorFuncs{
+		Call: func(
+			ctx context.Context,
+			result interface{},
+			method string,
+			invoker CallInvoker,
+			args ...interface{},
+		) error {
+			spanCtx = append(spanCtx, ctx.Value(testSpanKey{}) != nil)
+
+			return invoker(ctx, result, method, args...)
+		},
+	}
+
+	cl := newInterceptorTestClient(t, NewTraceInterceptor(tracer), checkCtx)
+
+	var res string
+	assert.NoError(t, cl.Call(&res, "test_echo", "hello"))
+	assert.Error(t, cl.Call(&res, "test_fail"))
+
+	sub, err := cl.Subscribe(context.Background(), "sub", "subscribe", "unsubscribe", "notify", make(chan string))
+	assert.NoError(t, err)
+	sub.Unsubscribe()
+
+	assert.Equal(t, []bool{true, true}, spanCtx)
+	assert.Len(t, tracer.spans, 3)
+
+	for i, method := range []string{"test_echo", "test_fail", "sub_subscribe"} {
+		span := tracer.spans[i]
+
+		assert.Equal(t, method, span.name)
+		assert.Equal(t, map[string]string{
+			TraceAttributeRPCSystem:  "jsonrpc",
+			TraceAttributeRPCService: strings.Split(method, "_")[0],
+			TraceAttributeRPCMethod:  method,
+		}, span.attributes)
+		assert.True(t, span.ended)
+	}
+
+	assert.NoError(t, tracer.spans[0].err)
+	assert.EqualError(t, tracer.spans[1].err, errTestFailure.Error())
+	assert.NoError(t, tracer.spans[2].err)
+}
+
+func TestTraceInterceptor_Batch(t *testing.T) {
+	tracer := &testTracer{}
+
+	// The context of the span is passed to the next interceptors.
+	var spanCtx []bool
+
+	checkCtx := InterceptorFuncs{
+		Batch: func(ctx context.Context, b []gethrpc.BatchElem, invoker BatchInvoker) error {
+			spanCtx = append(spanCtx, ctx.Value(testSpanKey{}) != nil)
+
+			return invoker(ctx, b)
+		},
+	}
+
+	cl := newInterceptorTestClient(t, NewTraceInterceptor(tracer), checkCtx)
+
+	var res string
+
+	assert.NoError(t, cl.BatchCall([]gethrpc.BatchElem{
+		{Method: "test_echo", Args: []interface{}{"hello"}, Result: &res},
+	}))
+	assert.NoError(t, cl.BatchCall([]gethrpc.BatchElem{
+		{Method: "test_echo", Args: []interface{}{"hello"}, Result: &res},
+		{Method: "test_fail"},
+	}))
+
+	assert.Equal(t, []bool{true, true}, spanCtx)
+	assert.Len(t, tracer.spans, 2)
+
+	for i, methods := range []string{"test_echo", "test_echo,test_fail"} {
+		span := tracer.spans[i]
+
+		assert.Equal(t, "batch", span.name)
+		assert.Equal(t, map[string]string{
+			TraceAttributeRPCSystem:       "jsonrpc",
+			TraceAttributeRPCBatchMethods: methods,
+		}, span.attributes)
+		assert.True(t, span.ended)
+	}
+
+	assert.NoError(t, tracer.spans[0].err)
+	assert.EqualError(t, tracer.spans[1].err, errTestFailure.Error())
+}
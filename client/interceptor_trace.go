@@ -0,0 +1,155 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// Tracer starts the spans of the calls and subscriptions of a Client.
+//
+// It matches the shape of an OpenTelemetry tracer, which can be adapted with:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string, attrs map[string]string) (context.Context, client.Span) {
+//		kvs := make([]attribute.KeyValue, 0, len(attrs))
+//		for k, v := range attrs {
+//			kvs = append(kvs, attribute.String(k, v))
+//		}
+//		ctx, span := t.Tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(kvs...))
+//		return ctx, otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) RecordError(err error) {
+//		s.Span.RecordError(err)
+//		s.Span.SetStatus(codes.Error, err.Error())
+//	}
+//
+//	func (s otelSpan) End() { s.Span.End() }
+type Tracer interface {
+	// Start starts a span with the provided name and attributes, and returns a context holding the span.
+	Start(ctx context.Context, spanName string, attributes map[string]string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// RecordError marks the span as failed with the provided error.
+	RecordError(err error)
+
+	// End ends the span.
+	End()
+}
+
+const (
+	// TraceAttributeRPCSystem is the span attribute holding the RPC system, which is always jsonrpc.
+	TraceAttributeRPCSystem = "rpc.system"
+	// TraceAttributeRPCService is the span attribute holding the namespace of the RPC method, for example chain.
+	TraceAttributeRPCService = "rpc.service"
+	// TraceAttributeRPCMethod is the span attribute holding the RPC method, for example chain_getBlock.
+	TraceAttributeRPCMethod = "rpc.method"
+	// TraceAttributeRPCBatchMethods is the span attribute holding the comma separated RPC methods of a batch call.
+	TraceAttributeRPCBatchMethods = "rpc.batch.methods"
+
+	// traceSpanNameBatch is the name of the spans of batch calls.
+	traceSpanNameBatch = "batch"
+)
+
+// NewTraceInterceptor returns an Interceptor that creates a span with the provided tracer for each call, each batch
+// call and each subscription of a Client. The span of a subscription ends once the subscription is created.
+//
+// The spans are named after the RPC method and follow the OpenTelemetry semantic conventions for RPC spans. The
+// spans of batch calls are named batch and hold the methods of the batch in the rpc.batch.methods attribute, they
+// record the error of the batch call or the errors of its calls.
+func NewTraceInterceptor(tracer Tracer) Interceptor {
+	return InterceptorFuncs{
+		Call: func(
+			ctx context.Context,
+			result interface{},
+			method string,
+			invoker CallInvoker,
+			args ...interface{},
+		) error {
+			ctx, span := tracer.Start(ctx, method, getTraceAttributes(method))
+			defer span.End()
+
+			err := invoker(ctx, result, method, args...)
+
+			if err != nil {
+				span.RecordError(err)
+			}
+
+			return err
+		},
+		Batch: func(ctx context.Context, b []gethrpc.BatchElem, invoker BatchInvoker) error {
+			ctx, span := tracer.Start(ctx, traceSpanNameBatch, getBatchTraceAttributes(b))
+			defer span.End()
+
+			err := invoker(ctx, b)
+
+			if batchErr := getBatchError(b, err); batchErr != nil {
+				span.RecordError(batchErr)
+			}
+
+			return err
+		},
+		Subscribe: func(
+			ctx context.Context,
+			req SubscribeRequest,
+			invoker SubscribeInvoker,
+		) (*gethrpc.ClientSubscription, error) {
+			ctx, span := tracer.Start(ctx, req.Method(), getTraceAttributes(req.Method()))
+			defer span.End()
+
+			sub, err := invoker(ctx, req)
+
+			if err != nil {
+				span.RecordError(err)
+			}
+
+			return sub, err
+		},
+	}
+}
+
+func getTraceAttributes(method string) map[string]string {
+	namespace, _, _ := strings.Cut(method, methodSeparator)
+
+	return map[string]string{
+		TraceAttributeRPCSystem:  "jsonrpc",
+		TraceAttributeRPCService: namespace,
+		TraceAttributeRPCMethod:  method,
+	}
+}
+
+func getBatchTraceAttributes(b []gethrpc.BatchElem) map[string]string {
+	methods := make([]string, 0, len(b))
+
+	for _, elem := range b {
+		methods = append(methods, elem.Method)
+	}
+
+	return map[string]string{
+		TraceAttributeRPCSystem:       "jsonrpc",
+		TraceAttributeRPCBatchMethods: strings.Join(methods, ","),
+	}
+}
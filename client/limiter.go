@@ -0,0 +1,573 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// Priority is the priority of a request that waits for a rate limit or for a free in-flight slot of a limited client.
+// Requests with a higher priority are sent first.
+type Priority int
+
+const (
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+	PriorityHigh
+)
+
+const (
+	// DefaultMaxRetries is the default number of times a request is retried after a 429 Too Many Requests response.
+	DefaultMaxRetries = 3
+	// DefaultRetryAfter is the default time to wait after a 429 Too Many Requests response without a valid
+	// Retry-After header.
+	DefaultRetryAfter = time.Second
+
+	methodSeparator = "_"
+)
+
+type rateLimit struct {
+	ratePerSecond float64
+	burst         int
+}
+
+type limiterOptions struct {
+	globalRateLimit *rateLimit
+	rateLimits      map[string]rateLimit
+	maxInFlight     int
+	priorities      map[string]Priority
+	maxRetries      int
+	retryAfter      time.Duration
+}
+
+// LimiterOption is used for configuring a client created by NewLimitedClient.
+type LimiterOption func(opts *limiterOptions)
+
+// WithRateLimit limits the requests of a method, such as chain_getBlock, or of a namespace, such as chain, to
+// ratePerSecond requests per second, with bursts of up to burst requests. A rate that is not positive removes the
+// limit.
+//
+// Every request of a batch counts towards the limit. A batch that is larger than the burst is sent once the burst is
+// available, and the following requests wait until the excess is refilled.
+func WithRateLimit(methodOrNamespace string, ratePerSecond float64, burst int) LimiterOption {
+	return func(opts *limiterOptions) {
+		opts.rateLimits[methodOrNamespace] = rateLimit{ratePerSecond, burst}
+	}
+}
+
+// WithGlobalRateLimit limits all requests to ratePerSecond requests per second, with bursts of up to burst requests.
+// It applies in addition to the limits of methods and namespaces.
+func WithGlobalRateLimit(ratePerSecond float64, burst int) LimiterOption {
+	return func(opts *limiterOptions) {
+		opts.globalRateLimit = &rateLimit{ratePerSecond, burst}
+	}
+}
+
+// WithMaxInFlight limits the number of requests that are sent concurrently, a batch request counts as one request.
+func WithMaxInFlight(maxInFlight int) LimiterOption {
+	return func(opts *limiterOptions) {
+		opts.maxInFlight = maxInFlight
+	}
+}
+
+// WithPriority sets the priority of the requests of a method or of a namespace. The requests of the author
+// namespace have PriorityHigh by default, other requests have PriorityNormal.
+func WithPriority(methodOrNamespace string, priority Priority) LimiterOption {
+	return func(opts *limiterOptions) {
+		opts.priorities[methodOrNamespace] = priority
+	}
+}
+
+// WithMaxRetries sets the number of times a request is retried after a 429 Too Many Requests response.
+func WithMaxRetries(maxRetries int) LimiterOption {
+	return func(opts *limiterOptions) {
+		opts.maxRetries = maxRetries
+	}
+}
+
+// WithDefaultRetryAfter sets the time to wait after a 429 Too Many Requests response without a valid Retry-After
+// header.
+func WithDefaultRetryAfter(retryAfter time.Duration) LimiterOption {
+	return func(opts *limiterOptions) {
+		opts.retryAfter = retryAfter
+	}
+}
+
+type requestPriorityKey struct{}
+
+// WithRequestPriority returns a context that sets the priority of the requests made with it by a limited client,
+// overriding the priority of their methods.
+func WithRequestPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, requestPriorityKey{}, priority)
+}
+
+// limitedClient is a Client that applies rate limits, a maximum number of in-flight requests and request priorities
+// to the requests of the wrapped Client.
+type limitedClient struct {
+	Client
+
+	opts limiterOptions
+
+	// now returns the current time, it is replaced in tests.
+	now func() time.Time
+
+	mu          sync.Mutex
+	buckets     map[string]*tokenBucket
+	waiters     []*waiter
+	seq         uint64
+	inFlight    int
+	pausedUntil time.Time
+	timer       *time.Timer
+}
+
+// NewLimitedClient returns a Client that limits the requests sent by the provided Client.
+//
+// Requests that exceed a rate limit or the maximum number of in-flight requests wait, ordered by their priority,
+// until they can be sent or until their context is done. Requests that receive a 429 Too Many Requests response
+// pause all requests for the time found in the Retry-After header, and are retried.
+//
+// Subscriptions are limited when they are created, and do not count as in-flight requests afterwards.
+func NewLimitedClient(c Client, opts ...LimiterOption) Client {
+	limiterOpts := limiterOptions{
+		rateLimits: make(map[string]rateLimit),
+		priorities: map[string]Priority{
+			"author": PriorityHigh,
+		},
+		maxRetries: DefaultMaxRetries,
+		retryAfter: DefaultRetryAfter,
+	}
+
+	for _, opt := range opts {
+		opt(&limiterOpts)
+	}
+
+	return &limitedClient{
+		Client:  c,
+		opts:    limiterOpts,
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+func (l *limitedClient) Call(result interface{}, method string, args ...interface{}) error {
+	return l.CallContext(context.Background(), result, method, args...)
+}
+
+func (l *limitedClient) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	return l.do(ctx, []string{method}, func() error {
+		return l.Client.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (l *limitedClient) BatchCall(b []gethrpc.BatchElem) error {
+	return l.BatchCallContext(context.Background(), b)
+}
+
+func (l *limitedClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	methods := make([]string, 0, len(b))
+
+	for _, elem := range b {
+		methods = append(methods, elem.Method)
+	}
+
+	return l.do(ctx, methods, func() error {
+		return l.Client.BatchCallContext(ctx, b)
+	})
+}
+
+func (l *limitedClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	var sub *gethrpc.ClientSubscription
+
+	err := l.do(ctx, []string{namespace + methodSeparator + subscribeMethodSuffix}, func() error {
+		var err error
+
+		sub, err = l.Client.Subscribe(
+			ctx,
+			namespace,
+			subscribeMethodSuffix,
+			unsubscribeMethodSuffix,
+			notificationMethodSuffix,
+			channel,
+			args...,
+		)
+
+		return err
+	})
+
+	return sub, err
+}
+
+func (l *limitedClient) Close() {
+	l.mu.Lock()
+
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+
+	l.mu.Unlock()
+
+	l.Client.Close()
+}
+
+// do sends a request for the provided methods once it is allowed to, and retries it after 429 responses.
+func (l *limitedClient) do(ctx context.Context, methods []string, send func() error) error {
+	for attempt := 0; ; attempt++ {
+		if err := l.acquire(ctx, methods); err != nil {
+			return err
+		}
+
+		err := send()
+
+		l.release()
+
+		retryAfter, ok := l.getRetryAfter(err)
+
+		if !ok || attempt >= l.opts.maxRetries {
+			return err
+		}
+
+		l.pause(retryAfter)
+	}
+}
+
+// waiter is a request that waits until it is allowed to be sent.
+type waiter struct {
+	priority Priority
+	seq      uint64
+
+	// tokens holds the number of tokens that are needed from each bucket.
+	tokens map[*tokenBucket]float64
+
+	ready chan struct{}
+}
+
+func (l *limitedClient) acquire(ctx context.Context, methods []string) error {
+	w := &waiter{
+		priority: l.getPriority(ctx, methods),
+		tokens:   make(map[*tokenBucket]float64),
+		ready:    make(chan struct{}),
+	}
+
+	l.mu.Lock()
+
+	for _, method := range methods {
+		for _, bucket := range l.getBuckets(method) {
+			w.tokens[bucket]++
+		}
+	}
+
+	l.seq++
+	w.seq = l.seq
+
+	l.waiters = append(l.waiters, w)
+
+	sort.SliceStable(l.waiters, func(i, j int) bool {
+		if l.waiters[i].priority != l.waiters[j].priority {
+			return l.waiters[i].priority > l.waiters[j].priority
+		}
+
+		return l.waiters[i].seq < l.waiters[j].seq
+	})
+
+	l.dispatch()
+
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		select {
+		case <-w.ready:
+			// The request was allowed while the context was done, so its in-flight slot is freed.
+			l.inFlight--
+			l.dispatch()
+		default:
+			l.removeWaiter(w)
+		}
+
+		return ctx.Err()
+	}
+}
+
+func (l *limitedClient) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	l.dispatch()
+}
+
+func (l *limitedClient) pause(retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if pausedUntil := l.now().Add(retryAfter); pausedUntil.After(l.pausedUntil) {
+		l.pausedUntil = pausedUntil
+	}
+}
+
+// dispatch allows the waiting requests to be sent, in order of priority, if there is a free in-flight slot and
+// their rate limits allow it. It schedules another dispatch when the next token is available.
+//
+// It must be called with the mutex held.
+func (l *limitedClient) dispatch() {
+	now := l.now()
+
+	if now.Before(l.pausedUntil) {
+		l.schedule(l.pausedUntil.Sub(now))
+		return
+	}
+
+	nextDispatch := time.Duration(math.MaxInt64)
+
+	for i := 0; i < len(l.waiters); {
+		if l.opts.maxInFlight > 0 && l.inFlight >= l.opts.maxInFlight {
+			return
+		}
+
+		w := l.waiters[i]
+
+		if wait := getTokensWait(w.tokens, now); wait > 0 {
+			if wait < nextDispatch {
+				nextDispatch = wait
+			}
+
+			i++
+
+			continue
+		}
+
+		for bucket, tokens := range w.tokens {
+			bucket.take(tokens)
+		}
+
+		l.inFlight++
+		l.waiters = append(l.waiters[:i], l.waiters[i+1:]...)
+
+		close(w.ready)
+	}
+
+	if len(l.waiters) > 0 && nextDispatch != time.Duration(math.MaxInt64) {
+		l.schedule(nextDispatch)
+	}
+}
+
+func (l *limitedClient) schedule(wait time.Duration) {
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+
+	l.timer = time.AfterFunc(wait, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.dispatch()
+	})
+}
+
+func (l *limitedClient) removeWaiter(w *waiter) {
+	for i, waiter := range l.waiters {
+		if waiter == w {
+			l.waiters = append(l.waiters[:i], l.waiters[i+1:]...)
+			break
+		}
+	}
+
+	l.dispatch()
+}
+
+// getBuckets returns the token buckets of the global, namespace and method rate limits that apply to a method.
+//
+// It must be called with the mutex held.
+func (l *limitedClient) getBuckets(method string) []*tokenBucket {
+	var buckets []*tokenBucket
+
+	if l.opts.globalRateLimit != nil && l.opts.globalRateLimit.ratePerSecond > 0 {
+		buckets = append(buckets, l.getBucket("", *l.opts.globalRateLimit))
+	}
+
+	for _, key := range getLimiterKeys(method) {
+		if limit, ok := l.opts.rateLimits[key]; ok && limit.ratePerSecond > 0 {
+			buckets = append(buckets, l.getBucket(key, limit))
+		}
+	}
+
+	return buckets
+}
+
+func (l *limitedClient) getBucket(key string, limit rateLimit) *tokenBucket {
+	bucket, ok := l.buckets[key]
+
+	if !ok {
+		bucket = newTokenBucket(limit, l.now())
+
+		l.buckets[key] = bucket
+	}
+
+	return bucket
+}
+
+// getPriority returns the priority set in the context, or the highest priority of the provided methods.
+func (l *limitedClient) getPriority(ctx context.Context, methods []string) Priority {
+	if priority, ok := ctx.Value(requestPriorityKey{}).(Priority); ok {
+		return priority
+	}
+
+	res := Priority(math.MinInt)
+
+	for _, method := range methods {
+		priority := PriorityNormal
+
+		// The priority of the method takes precedence over the one of the namespace.
+		for _, key := range getLimiterKeys(method) {
+			if p, ok := l.opts.priorities[key]; ok {
+				priority = p
+			}
+		}
+
+		if priority > res {
+			res = priority
+		}
+	}
+
+	if len(methods) == 0 {
+		return PriorityNormal
+	}
+
+	return res
+}
+
+// getRetryAfter returns the time to wait before retrying a request that failed with the provided error, if the
+// error is a 429 Too Many Requests response.
+func (l *limitedClient) getRetryAfter(err error) (time.Duration, bool) {
+	var httpErr gethrpc.HTTPError
+
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(strings.TrimSpace(httpErr.RetryAfter)); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(httpErr.RetryAfter); err == nil {
+		if retryAfter := date.Sub(l.now()); retryAfter > 0 {
+			return retryAfter, true
+		}
+
+		return 0, true
+	}
+
+	return l.opts.retryAfter, true
+}
+
+// getLimiterKeys returns the namespace and the method, which are the keys of the rate limits and priorities
+// that can apply to a method.
+func getLimiterKeys(method string) []string {
+	namespace, _, found := strings.Cut(method, methodSeparator)
+
+	if !found {
+		return []string{method}
+	}
+
+	return []string{namespace, method}
+}
+
+// tokenBucket holds tokens that are refilled at a fixed rate, up to the burst size. Taking more tokens than the bucket
+// holds puts it into debt, which is repaid by the refills before further tokens are available.
+type tokenBucket struct {
+	ratePerSecond float64
+	burst         float64
+
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(limit rateLimit, now time.Time) *tokenBucket {
+	burst := math.Max(float64(limit.burst), 1)
+
+	return &tokenBucket{
+		ratePerSecond: limit.ratePerSecond,
+		burst:         burst,
+		tokens:        burst,
+		lastRefill:    now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.lastRefill).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.ratePerSecond)
+		b.lastRefill = now
+	}
+}
+
+// wait returns the time until the bucket holds the provided number of tokens, which is capped to the burst size so
+// that requests for more tokens than the burst can be sent at all.
+func (b *tokenBucket) wait(tokens float64, now time.Time) time.Duration {
+	b.refill(now)
+
+	missing := math.Min(tokens, b.burst) - b.tokens
+
+	if missing <= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(missing / b.ratePerSecond * float64(time.Second)))
+}
+
+// take charges the full number of tokens, see tokenBucket.
+func (b *tokenBucket) take(tokens float64) {
+	b.tokens -= tokens
+}
+
+// getTokensWait returns the time until all buckets hold the tokens needed by a request.
+func getTokensWait(tokens map[*tokenBucket]float64, now time.Time) time.Duration {
+	var res time.Duration
+
+	for bucket, n := range tokens {
+		if wait := bucket.wait(n, now); wait > res {
+			res = wait
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,330 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client/mocks"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingClient is a Client whose calls wait until they are released, and which records the order of the calls.
+type blockingClient struct {
+	mocks.Client
+
+	release chan struct{}
+
+	mu       sync.Mutex
+	methods  []string
+	inFlight int32
+	max      int32
+}
+
+func newBlockingClient() *blockingClient {
+	return &blockingClient{release: make(chan struct{})}
+}
+
+func (c *blockingClient) CallContext(_ context.Context, _ interface{}, method string, _ ...interface{}) error {
+	c.mu.Lock()
+	c.methods = append(c.methods, method)
+	c.mu.Unlock()
+
+	inFlight := atomic.AddInt32(&c.inFlight, 1)
+	defer atomic.AddInt32(&c.inFlight, -1)
+
+	for {
+		max := atomic.LoadInt32(&c.max)
+
+		if inFlight <= max || atomic.CompareAndSwapInt32(&c.max, max, inFlight) {
+			break
+		}
+	}
+
+	<-c.release
+
+	return nil
+}
+
+func (c *blockingClient) getMethods() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.methods...)
+}
+
+func TestLimitedClient_RateLimit(t *testing.T) {
+	c := newBlockingClient()
+	close(c.release)
+
+	cl := NewLimitedClient(c, WithRateLimit("chain", 20, 1), WithRateLimit("state_getStorage", 0, 1))
+
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, cl.Call(nil, "chain_getBlock"))
+	}
+
+	// The first call uses the burst, the two others wait for a token each.
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	start = time.Now()
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, cl.Call(nil, "state_getStorage"))
+	}
+
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestLimitedClient_BatchRateLimit(t *testing.T) {
+	c := &mocks.Client{}
+	c.On("BatchCallContext", context.Background(), make([]gethrpc.BatchElem, 3)).Return(nil)
+
+	cl := NewLimitedClient(c, WithGlobalRateLimit(20, 3))
+
+	start := time.Now()
+
+	assert.NoError(t, cl.BatchCall(make([]gethrpc.BatchElem, 3)))
+	assert.NoError(t, cl.BatchCall(make([]gethrpc.BatchElem, 3)))
+
+	// The second batch waits until 3 tokens are available.
+	assert.GreaterOrEqual(t, time.Since(start), 140*time.Millisecond)
+}
+
+func TestLimitedClient_BatchRateLimitAboveBurst(t *testing.T) {
+	c := &mocks.Client{}
+	c.On("BatchCallContext", context.Background(), make([]gethrpc.BatchElem, 5)).Return(nil)
+	c.On("CallContext", context.Background(), nil, "chain_getBlock").Return(nil)
+
+	cl := NewLimitedClient(c, WithGlobalRateLimit(20, 2))
+
+	start := time.Now()
+
+	// The batch is sent with the burst, but is charged 5 tokens.
+	assert.NoError(t, cl.BatchCall(make([]gethrpc.BatchElem, 5)))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// The bucket holds -3 tokens, so the call waits until 4 tokens are refilled.
+	assert.NoError(t, cl.Call(nil, "chain_getBlock"))
+	assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+
+	bucket := newTokenBucket(rateLimit{ratePerSecond: 10, burst: 2}, now)
+
+	assert.Equal(t, time.Duration(0), bucket.wait(5, now))
+
+	bucket.take(5)
+
+	assert.Equal(t, -3.0, bucket.tokens)
+	assert.Equal(t, 400*time.Millisecond, bucket.wait(1, now))
+	assert.Equal(t, 500*time.Millisecond, bucket.wait(5, now))
+	assert.Equal(t, time.Duration(0), bucket.wait(1, now.Add(400*time.Millisecond)))
+}
+
+func TestLimitedClient_MaxInFlight(t *testing.T) {
+	c := newBlockingClient()
+
+	cl := NewLimitedClient(c, WithMaxInFlight(2))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, cl.Call(nil, "chain_getBlock"))
+		}()
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(c.getMethods()) == 2
+	}, time.Second, time.Millisecond)
+
+	close(c.release)
+	wg.Wait()
+
+	assert.Len(t, c.getMethods(), 10)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&c.max))
+}
+
+func TestLimitedClient_Priority(t *testing.T) {
+	c := newBlockingClient()
+
+	cl := NewLimitedClient(c, WithMaxInFlight(1), WithPriority("state", PriorityLow))
+
+	var wg sync.WaitGroup
+
+	call := func(ctx context.Context, method string) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, cl.CallContext(ctx, nil, method))
+		}()
+
+		// Wait until the call is queued, so that the calls are queued in order.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	call(context.Background(), "chain_getBlock")
+	call(context.Background(), "state_getStorage")
+	call(context.Background(), "chain_getHeader")
+	call(context.Background(), "author_submitExtrinsic")
+	call(WithRequestPriority(context.Background(), PriorityHigh), "state_getMetadata")
+
+	close(c.release)
+	wg.Wait()
+
+	assert.Equal(t, []string{
+		"chain_getBlock",
+		"author_submitExtrinsic",
+		"state_getMetadata",
+		"chain_getHeader",
+		"state_getStorage",
+	}, c.getMethods())
+}
+
+func TestLimitedClient_ContextDone(t *testing.T) {
+	c := newBlockingClient()
+
+	cl := NewLimitedClient(c, WithMaxInFlight(1))
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		assert.NoError(t, cl.Call(nil, "chain_getBlock"))
+	}()
+
+	assert.Eventually(t, func() bool {
+		return len(c.getMethods()) == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, cl.CallContext(ctx, nil, "chain_getHeader"), context.DeadlineExceeded)
+
+	close(c.release)
+	<-done
+
+	// The cancelled call does not hold a slot.
+	assert.NoError(t, cl.Call(nil, "chain_getFinalizedHead"))
+	assert.Equal(t, []string{"chain_getBlock", "chain_getFinalizedHead"}, c.getMethods())
+}
+
+func TestLimitedClient_TooManyRequests(t *testing.T) {
+	rpcSrv := gethrpc.NewServer()
+	assert.NoError(t, rpcSrv.RegisterName("test", testService{}))
+
+	var (
+		requests      int32
+		limitRequests int32
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= atomic.LoadInt32(&limitRequests) {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+
+			return
+		}
+
+		rpcSrv.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	c, err := Connect(srv.URL)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	cl := NewLimitedClient(c, WithMaxRetries(2))
+
+	atomic.StoreInt32(&limitRequests, 2)
+
+	var res string
+	assert.NoError(t, cl.Call(&res, "test_echo", "hello"))
+	assert.Equal(t, "hello", res)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	atomic.StoreInt32(&requests, 0)
+	atomic.StoreInt32(&limitRequests, 3)
+
+	var httpErr gethrpc.HTTPError
+	assert.ErrorAs(t, cl.Call(&res, "test_echo", "hello"), &httpErr)
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+	assert.Equal(t, "0", httpErr.RetryAfter)
+	assert.Equal(t, "slow down\n", string(httpErr.Body))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestLimitedClient_getRetryAfter(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cl := NewLimitedClient(&mocks.Client{}, WithDefaultRetryAfter(5*time.Second)).(*limitedClient)
+	cl.now = func() time.Time {
+		return now
+	}
+
+	tests := []struct {
+		err        error
+		retryAfter time.Duration
+		ok         bool
+	}{
+		{errTestFailure, 0, false},
+		{gethrpc.HTTPError{StatusCode: http.StatusInternalServerError, RetryAfter: "1"}, 0, false},
+		{gethrpc.HTTPError{StatusCode: http.StatusTooManyRequests, RetryAfter: "2"}, 2 * time.Second, true},
+		{gethrpc.HTTPError{StatusCode: http.StatusTooManyRequests}, 5 * time.Second, true},
+		{
+			gethrpc.HTTPError{
+				StatusCode: http.StatusTooManyRequests,
+				RetryAfter: now.Add(time.Minute).Format(http.TimeFormat),
+			},
+			time.Minute,
+			true,
+		},
+		{
+			gethrpc.HTTPError{
+				StatusCode: http.StatusTooManyRequests,
+				RetryAfter: now.Add(-time.Minute).Format(http.TimeFormat),
+			},
+			0,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		retryAfter, ok := cl.getRetryAfter(test.err)
+		assert.Equal(t, test.retryAfter, retryAfter)
+		assert.Equal(t, test.ok, ok)
+	}
+}
@@ -21,12 +21,18 @@ import (
 	"time"
 )
 
+// DefaultBatchSize is the default maximum number of requests that are sent in a single JSON-RPC batch request
+const DefaultBatchSize = 100
+
 type Config struct {
 	RPCURL string
 
 	// Timeouts
 	DialTimeout      time.Duration
 	SubscribeTimeout time.Duration
+
+	// BatchSize is the maximum number of requests that are sent in a single JSON-RPC batch request
+	BatchSize int
 }
 
 // DefaultConfig returns the default config. Default values can be overwritten with env variables, most importantly
@@ -36,6 +42,7 @@ func Default() Config {
 		RPCURL:           extractDefaultRPCURL(),
 		DialTimeout:      10 * time.Second,
 		SubscribeTimeout: 5 * time.Second,
+		BatchSize:        DefaultBatchSize,
 	}
 }
 
@@ -37,6 +37,7 @@ import (
 
 const (
 	maxRequestContentLength = 1024 * 1024 * 5
+	maxErrorBodySize        = 1024 * 4
 	contentType             = "application/json"
 )
 
@@ -128,19 +129,11 @@ func DialHTTP(endpoint string) (*Client, error) {
 func (c *Client) sendHTTP(ctx context.Context, op *requestOp, msg interface{}) error {
 	hc := c.writeConn.(*httpConn)
 	respBody, err := hc.doRequest(ctx, msg)
-	if respBody != nil {
-		defer respBody.Close()
-	}
-
 	if err != nil {
-		if respBody != nil {
-			buf := new(bytes.Buffer)
-			if _, err2 := buf.ReadFrom(respBody); err2 == nil {
-				return fmt.Errorf("%v %v", err, buf.String())
-			}
-		}
 		return err
 	}
+	defer respBody.Close()
+
 	var respmsg jsonrpcMessage
 	if err := json.NewDecoder(respBody).Decode(&respmsg); err != nil {
 		return err
@@ -180,11 +173,38 @@ func (hc *httpConn) doRequest(ctx context.Context, msg interface{}) (io.ReadClos
 		return nil, err
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return resp.Body, errors.New(resp.Status)
+		defer resp.Body.Close()
+
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+		return nil, HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       body,
+			RetryAfter: resp.Header.Get("Retry-After"),
+		}
 	}
 	return resp.Body, nil
 }
 
+// HTTPError is returned by client calls when the HTTP response status code is not 2xx.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+
+	// RetryAfter holds the Retry-After header of the response, which is usually set for
+	// 429 Too Many Requests and 503 Service Unavailable responses.
+	RetryAfter string
+}
+
+func (err HTTPError) Error() string {
+	if len(err.Body) == 0 {
+		return err.Status
+	}
+	return fmt.Sprintf("%v %s", err.Status, err.Body)
+}
+
 // httpServerConn turns a HTTP connection into a Conn.
 type httpServerConn struct {
 	io.Reader
@@ -246,6 +246,13 @@ func (sub *ClientSubscription) Err() <-chan error {
 	return sub.err
 }
 
+// Done returns a channel that is closed when the subscription has ended, either because
+// Unsubscribe was called or because of an error. Unlike Err, it can be used by any number
+// of observers without consuming the subscription error.
+func (sub *ClientSubscription) Done() <-chan struct{} {
+	return sub.quit
+}
+
 // Unsubscribe unsubscribes the notification and closes the error channel.
 // It can safely be called more than once.
 func (sub *ClientSubscription) Unsubscribe() {
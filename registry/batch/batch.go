@@ -0,0 +1,146 @@
+package batch
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+)
+
+const (
+	itemCompletedEventName            = "Utility.ItemCompleted"
+	itemFailedEventName               = "Utility.ItemFailed"
+	batchInterruptedEventName         = "Utility.BatchInterrupted"
+	batchCompletedEventName           = "Utility.BatchCompleted"
+	batchCompletedWithErrorsEventName = "Utility.BatchCompletedWithErrors"
+
+	indexFieldName = "index"
+	errorFieldName = "error"
+)
+
+// ItemResult holds the outcome of a call that was included in a batch.
+type ItemResult struct {
+	// Index is the index of the call in the items that were used for creating the batch.
+	Index int
+
+	// Executed is false if the call was not executed, either because a previous call interrupted the batch,
+	// or because the batch failed as a whole, as it happens with Utility.batch_all.
+	Executed bool
+
+	Success bool
+
+	// DispatchError holds the dispatch error of the call, if it failed.
+	DispatchError *registry.DispatchError
+
+	// Events holds the events emitted by the call.
+	Events []*parser.Event
+}
+
+// GetItemResults maps the Utility events emitted by a batch extrinsic to the calls of the batch.
+//
+// The provided events are expected to be the ones emitted during the application of the extrinsic,
+// such as the ones returned by the extrinsic submitter. Batches nested in the batched calls are not supported.
+func GetItemResults(
+	batch *extrinsic.Batch,
+	events []*parser.Event,
+	errorRegistry registry.ErrorRegistry,
+) ([]*ItemResult, error) {
+	if batch == nil {
+		return nil, ErrNilBatch
+	}
+
+	results := make([]*ItemResult, 0, len(batch.ItemIndices))
+
+	for _, itemIndex := range batch.ItemIndices {
+		results = append(results, &ItemResult{
+			Index: itemIndex,
+		})
+	}
+
+	var (
+		position    int
+		callEvents  []*parser.Event
+		batchEnding bool
+	)
+
+	for _, event := range events {
+		switch event.Name {
+		case itemCompletedEventName:
+			if position >= len(results) {
+				return nil, ErrUnexpectedItemEvent.WithMsg("item completed at position '%d'", position)
+			}
+
+			results[position].Executed = true
+			results[position].Success = true
+			results[position].Events = callEvents
+
+			callEvents = nil
+			position++
+		case itemFailedEventName:
+			if position >= len(results) {
+				return nil, ErrUnexpectedItemEvent.WithMsg("item failed at position '%d'", position)
+			}
+
+			dispatchError, err := getDispatchError(event, errorRegistry)
+
+			if err != nil {
+				return nil, err
+			}
+
+			results[position].Executed = true
+			results[position].DispatchError = dispatchError
+			results[position].Events = callEvents
+
+			callEvents = nil
+			position++
+		case batchInterruptedEventName:
+			index, err := registry.GetDecodedFieldAsType[types.U32](event.Fields, registry.FieldNamePredicate(indexFieldName))
+
+			if err != nil {
+				return nil, ErrItemIndexDecoding.Wrap(err)
+			}
+
+			if int(index) != position || position >= len(results) {
+				return nil, ErrUnexpectedItemEvent.WithMsg("batch interrupted at index '%d', position '%d'", index, position)
+			}
+
+			dispatchError, err := getDispatchError(event, errorRegistry)
+
+			if err != nil {
+				return nil, err
+			}
+
+			results[position].Executed = true
+			results[position].DispatchError = dispatchError
+			results[position].Events = callEvents
+
+			batchEnding = true
+		case batchCompletedEventName, batchCompletedWithErrorsEventName:
+			batchEnding = true
+		default:
+			callEvents = append(callEvents, event)
+		}
+
+		if batchEnding {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+func getDispatchError(event *parser.Event, errorRegistry registry.ErrorRegistry) (*registry.DispatchError, error) {
+	dispatchError, err := registry.GetDecodedFieldAsDispatchError(event.Fields, registry.FieldNamePredicate(errorFieldName))
+
+	if err != nil {
+		return nil, ErrDispatchErrorDecoding.Wrap(err)
+	}
+
+	res, err := errorRegistry.DecodeDispatchError(dispatchError)
+
+	if err != nil {
+		return nil, ErrDispatchErrorDecoding.Wrap(err)
+	}
+
+	return res, nil
+}
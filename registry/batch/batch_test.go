@@ -0,0 +1,217 @@
+package batch
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetItemResults_Batch(t *testing.T) {
+	meta := getTestMetadata(t)
+	eventRegistry := getTestEventRegistry(t, meta)
+	errorRegistry := getTestErrorRegistry(t, meta)
+
+	batch := &extrinsic.Batch{
+		Mode:        extrinsic.BatchModeBatch,
+		ItemIndices: []int{3, 4, 5},
+	}
+
+	remarkedEvent := newTestEvent(t, eventRegistry, "System.Remarked", make([]byte, 64))
+
+	events := []*parser.Event{
+		remarkedEvent,
+		newTestEvent(t, eventRegistry, "Utility.ItemCompleted", nil),
+		newTestEvent(t, eventRegistry, "Utility.BatchInterrupted", []byte{
+			1, 0, 0, 0, // Index
+			3, // Dispatch error - Module
+			byte(getPalletIndex(t, meta, "Balances")), // Module index
+			2, 0, 0, 0, // Error index
+		}),
+		newTestEvent(t, eventRegistry, "System.ExtrinsicSuccess", make([]byte, 20)),
+	}
+
+	res, err := GetItemResults(batch, events, errorRegistry)
+	assert.NoError(t, err)
+	assert.Len(t, res, 3)
+
+	assert.Equal(t, 3, res[0].Index)
+	assert.True(t, res[0].Executed)
+	assert.True(t, res[0].Success)
+	assert.Nil(t, res[0].DispatchError)
+	assert.Equal(t, []*parser.Event{remarkedEvent}, res[0].Events)
+
+	assert.Equal(t, 4, res[1].Index)
+	assert.True(t, res[1].Executed)
+	assert.False(t, res[1].Success)
+	assert.Equal(t, "Balances.InsufficientBalance", res[1].DispatchError.ModuleError.Name)
+	assert.Empty(t, res[1].Events)
+
+	assert.Equal(t, 5, res[2].Index)
+	assert.False(t, res[2].Executed)
+	assert.False(t, res[2].Success)
+}
+
+func TestGetItemResults_ForceBatch(t *testing.T) {
+	meta := getTestMetadata(t)
+	eventRegistry := getTestEventRegistry(t, meta)
+	errorRegistry := getTestErrorRegistry(t, meta)
+
+	batch := &extrinsic.Batch{
+		Mode:        extrinsic.BatchModeForceBatch,
+		ItemIndices: []int{0, 1, 2},
+	}
+
+	events := []*parser.Event{
+		newTestEvent(t, eventRegistry, "Utility.ItemFailed", []byte{2}),
+		newTestEvent(t, eventRegistry, "Utility.ItemCompleted", nil),
+		newTestEvent(t, eventRegistry, "Utility.ItemFailed", []byte{7, 0}),
+		newTestEvent(t, eventRegistry, "Utility.BatchCompletedWithErrors", nil),
+		newTestEvent(t, eventRegistry, "System.ExtrinsicSuccess", make([]byte, 20)),
+	}
+
+	res, err := GetItemResults(batch, events, errorRegistry)
+	assert.NoError(t, err)
+	assert.Len(t, res, 3)
+
+	for i, itemRes := range res {
+		assert.Equal(t, i, itemRes.Index)
+		assert.True(t, itemRes.Executed)
+		assert.Empty(t, itemRes.Events)
+	}
+
+	assert.False(t, res[0].Success)
+	assert.True(t, res[0].DispatchError.Raw.IsBadOrigin)
+	assert.True(t, res[1].Success)
+	assert.False(t, res[2].Success)
+	assert.True(t, res[2].DispatchError.Raw.IsToken)
+}
+
+func TestGetItemResults_BatchAllFailed(t *testing.T) {
+	meta := getTestMetadata(t)
+	eventRegistry := getTestEventRegistry(t, meta)
+
+	batch := &extrinsic.Batch{
+		Mode:        extrinsic.BatchModeBatchAll,
+		ItemIndices: []int{0, 1},
+	}
+
+	events := []*parser.Event{
+		newTestEvent(t, eventRegistry, "System.ExtrinsicFailed", []byte{2, 0, 0, 0, 0}),
+	}
+
+	res, err := GetItemResults(batch, events, getTestErrorRegistry(t, meta))
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+
+	for _, itemRes := range res {
+		assert.False(t, itemRes.Executed)
+		assert.False(t, itemRes.Success)
+	}
+}
+
+func TestGetItemResults_Errors(t *testing.T) {
+	meta := getTestMetadata(t)
+	eventRegistry := getTestEventRegistry(t, meta)
+	errorRegistry := getTestErrorRegistry(t, meta)
+
+	res, err := GetItemResults(nil, nil, errorRegistry)
+	assert.ErrorIs(t, err, ErrNilBatch)
+	assert.Nil(t, res)
+
+	batch := &extrinsic.Batch{
+		ItemIndices: []int{0},
+	}
+
+	res, err = GetItemResults(batch, []*parser.Event{
+		newTestEvent(t, eventRegistry, "Utility.ItemCompleted", nil),
+		newTestEvent(t, eventRegistry, "Utility.ItemCompleted", nil),
+	}, errorRegistry)
+	assert.ErrorIs(t, err, ErrUnexpectedItemEvent)
+	assert.Nil(t, res)
+
+	res, err = GetItemResults(batch, []*parser.Event{
+		newTestEvent(t, eventRegistry, "Utility.BatchInterrupted", []byte{1, 0, 0, 0, 2}),
+	}, errorRegistry)
+	assert.ErrorIs(t, err, ErrUnexpectedItemEvent)
+	assert.Nil(t, res)
+
+	res, err = GetItemResults(batch, []*parser.Event{
+		newTestEvent(t, eventRegistry, "Utility.ItemFailed", []byte{3, 255, 0, 0, 0, 0}),
+	}, errorRegistry)
+	assert.ErrorIs(t, err, ErrDispatchErrorDecoding)
+	assert.Nil(t, res)
+
+	res, err = GetItemResults(batch, []*parser.Event{
+		{Name: "Utility.BatchInterrupted"},
+	}, errorRegistry)
+	assert.ErrorIs(t, err, ErrItemIndexDecoding)
+	assert.Nil(t, res)
+}
+
+func newTestEvent(
+	t *testing.T,
+	eventRegistry registry.EventRegistry,
+	eventName string,
+	eventData []byte,
+) *parser.Event {
+	for eventID, eventDecoder := range eventRegistry {
+		if eventDecoder.Name != eventName {
+			continue
+		}
+
+		eventFields, err := eventDecoder.Decode(scale.NewDecoder(bytes.NewReader(eventData)))
+		assert.NoError(t, err)
+
+		return &parser.Event{
+			Name:    eventName,
+			Fields:  eventFields,
+			EventID: eventID,
+		}
+	}
+
+	t.Fatalf("event %s not found", eventName)
+
+	return nil
+}
+
+func getTestEventRegistry(t *testing.T, meta *types.Metadata) registry.EventRegistry {
+	eventRegistry, err := registry.NewFactory().CreateEventRegistry(meta)
+	assert.NoError(t, err)
+
+	return eventRegistry
+}
+
+func getTestErrorRegistry(t *testing.T, meta *types.Metadata) registry.ErrorRegistry {
+	errorRegistry, err := registry.NewFactory().CreateErrorRegistry(meta)
+	assert.NoError(t, err)
+
+	return errorRegistry
+}
+
+func getTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
+
+func getPalletIndex(t *testing.T, meta *types.Metadata, palletName string) types.U8 {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) == palletName {
+			return pallet.Index
+		}
+	}
+
+	t.Fatalf("pallet %s not found", palletName)
+
+	return 0
+}
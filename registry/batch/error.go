@@ -0,0 +1,10 @@
+package batch
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrNilBatch              = libErr.Error("nil batch")
+	ErrUnexpectedItemEvent   = libErr.Error("unexpected item event")
+	ErrItemIndexDecoding     = libErr.Error("item index decoding")
+	ErrDispatchErrorDecoding = libErr.Error("dispatch error decoding")
+)
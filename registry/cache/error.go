@@ -0,0 +1,19 @@
+package cache
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrRuntimeVersionRetrieval    = libErr.Error("runtime version retrieval")
+	ErrMetadataRetrieval          = libErr.Error("metadata retrieval")
+	ErrMetadataEncoding           = libErr.Error("metadata encoding")
+	ErrMetadataDecoding           = libErr.Error("metadata decoding")
+	ErrMetadataNotFound           = libErr.Error("metadata not found")
+	ErrStoredMetadataNotFound     = libErr.Error("stored metadata not found")
+	ErrStoredMetadataLoading      = libErr.Error("stored metadata loading")
+	ErrStoredMetadataSaving       = libErr.Error("stored metadata saving")
+	ErrEventRegistryCreation      = libErr.Error("event registry creation")
+	ErrCallRegistryCreation       = libErr.Error("call registry creation")
+	ErrErrorRegistryCreation      = libErr.Error("error registry creation")
+	ErrExtrinsicDecoderCreation   = libErr.Error("extrinsic decoder creation")
+	ErrMetadataStorageDirCreation = libErr.Error("metadata storage directory creation")
+)
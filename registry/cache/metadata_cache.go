@@ -0,0 +1,415 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+//go:generate mockery --name MetadataCache --structname MetadataCacheMock --filename metadata_cache_mock.go --inpackage
+
+// MetadataCache is the interface used for retrieving the metadata, and the registries created from it,
+// for a particular runtime spec version.
+//
+// Implementations are safe for concurrent use, which allows blocks from different runtime versions
+// to be decoded in parallel without refetching the metadata.
+type MetadataCache interface {
+	// GetEntry returns the Entry for the spec version that was active at the provided block.
+	GetEntry(blockHash types.Hash) (*Entry, error)
+	// GetEntryCtx is like GetEntry, the RPC requests are bound to the provided context.
+	GetEntryCtx(ctx context.Context, blockHash types.Hash) (*Entry, error)
+	// GetEntryForBlock returns the Entry for the spec version that was active at the provided block, using the
+	// SpecVersionLookup, if any, to avoid resolving the runtime version of the block.
+	GetEntryForBlock(blockNumber uint64, blockHash types.Hash) (*Entry, error)
+	// GetEntryForBlockCtx is like GetEntryForBlock, the RPC requests are bound to the provided context.
+	GetEntryForBlockCtx(ctx context.Context, blockNumber uint64, blockHash types.Hash) (*Entry, error)
+	// GetLatestEntry returns the Entry for the latest spec version.
+	GetLatestEntry() (*Entry, error)
+	// GetLatestEntryCtx is like GetLatestEntry, the RPC requests are bound to the provided context.
+	GetLatestEntryCtx(ctx context.Context) (*Entry, error)
+	// GetEntryBySpecVersion returns the Entry for the provided spec version, if it was previously cached
+	// or persisted.
+	GetEntryBySpecVersion(specVersion types.U32) (*Entry, error)
+	// AddMetadata adds the provided metadata to the cache, if there is no entry for the spec version yet.
+	AddMetadata(specVersion types.U32, meta *types.Metadata) (*Entry, error)
+}
+
+// Option is the type used for configuring a MetadataCache.
+type Option func(c *metadataCache)
+
+// WithStorage sets the Storage used for persisting the raw metadata.
+//
+// The storage is checked before fetching metadata from the chain and every fetched metadata is stored in it.
+func WithStorage(storage Storage) Option {
+	return func(c *metadataCache) {
+		c.storage = storage
+	}
+}
+
+// WithSpecVersionLookup sets the SpecVersionLookup used when retrieving the Entry of a block.
+func WithSpecVersionLookup(specVersionLookup SpecVersionLookup) Option {
+	return func(c *metadataCache) {
+		c.specVersionLookup = specVersionLookup
+	}
+}
+
+// SpecVersionLookup is the interface used for resolving the spec version of a block number without
+// any RPC calls, see upgrade.SpecVersionMap.
+type SpecVersionLookup interface {
+	GetSpecVersion(blockNumber uint64) (types.U32, bool)
+}
+
+// metadataCache implements the MetadataCache interface.
+type metadataCache struct {
+	stateRPC state.State
+
+	registryFactory registry.Factory
+	// factoryMu guards the registry factory since it keeps internal state while creating registries.
+	factoryMu sync.Mutex
+
+	storage Storage
+
+	specVersionLookup SpecVersionLookup
+
+	mu    sync.Mutex
+	items map[types.U32]*cacheItem
+}
+
+// cacheItem holds the result of loading an Entry, done is closed once the loading is finished.
+type cacheItem struct {
+	done  chan struct{}
+	entry *Entry
+	err   error
+}
+
+// NewMetadataCache creates a new MetadataCache.
+func NewMetadataCache(stateRPC state.State, registryFactory registry.Factory, opts ...Option) MetadataCache {
+	c := &metadataCache{
+		stateRPC:        stateRPC,
+		registryFactory: registryFactory,
+		items:           make(map[types.U32]*cacheItem),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// GetEntry resolves the spec version at the provided block via state_getRuntimeVersion and returns its Entry.
+//
+// The metadata is only retrieved from the chain if it's neither cached nor persisted.
+func (c *metadataCache) GetEntry(blockHash types.Hash) (*Entry, error) {
+	return c.GetEntryCtx(context.Background(), blockHash)
+}
+
+// GetEntryCtx is like GetEntry, the RPC requests are bound to the provided context.
+func (c *metadataCache) GetEntryCtx(ctx context.Context, blockHash types.Hash) (*Entry, error) {
+	runtimeVersion, err := c.stateRPC.GetRuntimeVersionCtx(ctx, blockHash)
+
+	if err != nil {
+		return nil, ErrRuntimeVersionRetrieval.Wrap(err)
+	}
+
+	return c.getEntry(ctx, runtimeVersion.SpecVersion, func() (*types.Metadata, error) {
+		return c.stateRPC.GetMetadataCtx(ctx, blockHash)
+	})
+}
+
+// GetEntryForBlock returns the Entry for the spec version that was active at the provided block.
+//
+// The spec version is retrieved from the SpecVersionLookup, if the block is covered by it, otherwise the
+// runtime version of the block is resolved, see GetEntry.
+func (c *metadataCache) GetEntryForBlock(blockNumber uint64, blockHash types.Hash) (*Entry, error) {
+	return c.GetEntryForBlockCtx(context.Background(), blockNumber, blockHash)
+}
+
+// GetEntryForBlockCtx is like GetEntryForBlock, the RPC requests are bound to the provided context.
+func (c *metadataCache) GetEntryForBlockCtx(
+	ctx context.Context,
+	blockNumber uint64,
+	blockHash types.Hash,
+) (*Entry, error) {
+	if c.specVersionLookup == nil {
+		return c.GetEntryCtx(ctx, blockHash)
+	}
+
+	specVersion, ok := c.specVersionLookup.GetSpecVersion(blockNumber)
+
+	if !ok {
+		return c.GetEntryCtx(ctx, blockHash)
+	}
+
+	return c.getEntry(ctx, specVersion, func() (*types.Metadata, error) {
+		return c.stateRPC.GetMetadataCtx(ctx, blockHash)
+	})
+}
+
+// GetLatestEntry returns the Entry for the latest spec version.
+func (c *metadataCache) GetLatestEntry() (*Entry, error) {
+	return c.GetLatestEntryCtx(context.Background())
+}
+
+// GetLatestEntryCtx is like GetLatestEntry, the RPC requests are bound to the provided context.
+func (c *metadataCache) GetLatestEntryCtx(ctx context.Context) (*Entry, error) {
+	runtimeVersion, err := c.stateRPC.GetRuntimeVersionLatestCtx(ctx)
+
+	if err != nil {
+		return nil, ErrRuntimeVersionRetrieval.Wrap(err)
+	}
+
+	return c.getEntry(ctx, runtimeVersion.SpecVersion, func() (*types.Metadata, error) {
+		return c.stateRPC.GetMetadataLatestCtx(ctx)
+	})
+}
+
+// GetEntryBySpecVersion returns the Entry for the provided spec version.
+//
+// ErrMetadataNotFound is returned if the spec version is neither cached nor persisted.
+func (c *metadataCache) GetEntryBySpecVersion(specVersion types.U32) (*Entry, error) {
+	return c.getEntry(context.Background(), specVersion, nil)
+}
+
+// AddMetadata adds the metadata for the provided spec version. The existing entry is returned
+// if the spec version is already cached.
+func (c *metadataCache) AddMetadata(specVersion types.U32, meta *types.Metadata) (*Entry, error) {
+	return c.getEntry(context.Background(), specVersion, func() (*types.Metadata, error) {
+		return meta, nil
+	})
+}
+
+// getEntry returns the cached Entry for the spec version or loads it.
+//
+// Only one caller loads a particular spec version, the others wait for the result. Failed loads are not cached,
+// callers that were waiting on one will attempt the load themselves. Waiting stops once the context is done.
+func (c *metadataCache) getEntry(
+	ctx context.Context,
+	specVersion types.U32,
+	fetchFn func() (*types.Metadata, error),
+) (*Entry, error) {
+	for {
+		c.mu.Lock()
+
+		item, ok := c.items[specVersion]
+
+		if !ok {
+			item = &cacheItem{done: make(chan struct{})}
+
+			c.items[specVersion] = item
+
+			c.mu.Unlock()
+
+			item.entry, item.err = c.loadEntry(specVersion, fetchFn)
+
+			if item.err != nil {
+				c.mu.Lock()
+				delete(c.items, specVersion)
+				c.mu.Unlock()
+			}
+
+			close(item.done)
+
+			return item.entry, item.err
+		}
+
+		c.mu.Unlock()
+
+		select {
+		case <-item.done:
+		case <-ctx.Done():
+			return nil, ErrMetadataRetrieval.Wrap(ctx.Err())
+		}
+
+		if item.err == nil {
+			return item.entry, nil
+		}
+	}
+}
+
+// loadEntry creates an Entry using the persisted metadata, if any, or the metadata returned by fetchFn.
+func (c *metadataCache) loadEntry(specVersion types.U32, fetchFn func() (*types.Metadata, error)) (*Entry, error) {
+	meta, err := c.loadStoredMetadata(specVersion)
+
+	if err == nil {
+		return c.newEntry(specVersion, meta), nil
+	}
+
+	if fetchFn == nil {
+		return nil, ErrMetadataNotFound.Wrap(err)
+	}
+
+	meta, err = fetchFn()
+
+	if err != nil {
+		return nil, ErrMetadataRetrieval.Wrap(err)
+	}
+
+	if err := c.storeMetadata(specVersion, meta); err != nil {
+		return nil, err
+	}
+
+	return c.newEntry(specVersion, meta), nil
+}
+
+func (c *metadataCache) loadStoredMetadata(specVersion types.U32) (*types.Metadata, error) {
+	if c.storage == nil {
+		return nil, ErrStoredMetadataNotFound
+	}
+
+	rawMetadata, err := c.storage.Load(specVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var meta types.Metadata
+
+	if err := codec.Decode(rawMetadata, &meta); err != nil {
+		return nil, ErrMetadataDecoding.Wrap(err)
+	}
+
+	return &meta, nil
+}
+
+func (c *metadataCache) storeMetadata(specVersion types.U32, meta *types.Metadata) error {
+	if c.storage == nil {
+		return nil
+	}
+
+	rawMetadata, err := codec.Encode(meta)
+
+	if err != nil {
+		return ErrMetadataEncoding.Wrap(err)
+	}
+
+	if err := c.storage.Store(specVersion, rawMetadata); err != nil {
+		return ErrStoredMetadataSaving.Wrap(err)
+	}
+
+	return nil
+}
+
+func (c *metadataCache) newEntry(specVersion types.U32, meta *types.Metadata) *Entry {
+	return &Entry{
+		SpecVersion: specVersion,
+		Metadata:    meta,
+		cache:       c,
+	}
+}
+
+// Entry holds the metadata of a spec version and lazily creates the registries that are based on it.
+//
+// The Metadata can also be used when creating extrinsics for the spec version, see extrinsic.Extrinsic.Sign.
+type Entry struct {
+	SpecVersion types.U32
+	Metadata    *types.Metadata
+
+	cache *metadataCache
+
+	mu               sync.Mutex
+	callRegistry     registry.CallRegistry
+	errorRegistry    registry.ErrorRegistry
+	eventRegistry    registry.EventRegistry
+	extrinsicDecoder *registry.ExtrinsicDecoder
+}
+
+// GetCallRegistry returns the registry.CallRegistry for the spec version.
+func (e *Entry) GetCallRegistry() (registry.CallRegistry, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.callRegistry != nil {
+		return e.callRegistry, nil
+	}
+
+	callRegistry, err := withFactory(e.cache, func(factory registry.Factory) (registry.CallRegistry, error) {
+		return factory.CreateCallRegistry(e.Metadata)
+	})
+
+	if err != nil {
+		return nil, ErrCallRegistryCreation.Wrap(err)
+	}
+
+	e.callRegistry = callRegistry
+
+	return callRegistry, nil
+}
+
+// GetErrorRegistry returns the registry.ErrorRegistry for the spec version.
+func (e *Entry) GetErrorRegistry() (registry.ErrorRegistry, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.errorRegistry != nil {
+		return e.errorRegistry, nil
+	}
+
+	errorRegistry, err := withFactory(e.cache, func(factory registry.Factory) (registry.ErrorRegistry, error) {
+		return factory.CreateErrorRegistry(e.Metadata)
+	})
+
+	if err != nil {
+		return nil, ErrErrorRegistryCreation.Wrap(err)
+	}
+
+	e.errorRegistry = errorRegistry
+
+	return errorRegistry, nil
+}
+
+// GetEventRegistry returns the registry.EventRegistry for the spec version.
+func (e *Entry) GetEventRegistry() (registry.EventRegistry, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.eventRegistry != nil {
+		return e.eventRegistry, nil
+	}
+
+	eventRegistry, err := withFactory(e.cache, func(factory registry.Factory) (registry.EventRegistry, error) {
+		return factory.CreateEventRegistry(e.Metadata)
+	})
+
+	if err != nil {
+		return nil, ErrEventRegistryCreation.Wrap(err)
+	}
+
+	e.eventRegistry = eventRegistry
+
+	return eventRegistry, nil
+}
+
+// GetExtrinsicDecoder returns the registry.ExtrinsicDecoder for the spec version.
+func (e *Entry) GetExtrinsicDecoder() (*registry.ExtrinsicDecoder, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.extrinsicDecoder != nil {
+		return e.extrinsicDecoder, nil
+	}
+
+	extrinsicDecoder, err := withFactory(e.cache, func(factory registry.Factory) (*registry.ExtrinsicDecoder, error) {
+		return factory.CreateExtrinsicDecoder(e.Metadata)
+	})
+
+	if err != nil {
+		return nil, ErrExtrinsicDecoderCreation.Wrap(err)
+	}
+
+	e.extrinsicDecoder = extrinsicDecoder
+
+	return extrinsicDecoder, nil
+}
+
+// withFactory executes the provided function while holding the lock of the registry factory.
+func withFactory[T any](c *metadataCache, fn func(factory registry.Factory) (T, error)) (T, error) {
+	c.factoryMu.Lock()
+	defer c.factoryMu.Unlock()
+
+	return fn(c.registryFactory)
+}
@@ -0,0 +1,384 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMetadataCache_GetEntry(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	metadataCache := NewMetadataCache(stateRPCMock, registryFactoryMock)
+
+	blockHash1 := types.NewHash([]byte{1})
+	blockHash2 := types.NewHash([]byte{2})
+	blockHash3 := types.NewHash([]byte{3})
+
+	meta1 := &types.Metadata{Version: 14}
+	meta2 := &types.Metadata{Version: 15}
+
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash1).
+		Return(&types.RuntimeVersion{SpecVersion: 1}, nil).
+		Once()
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash1).
+		Return(meta1, nil).
+		Once()
+
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash2).
+		Return(&types.RuntimeVersion{SpecVersion: 1}, nil).
+		Once()
+
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash3).
+		Return(&types.RuntimeVersion{SpecVersion: 2}, nil).
+		Once()
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash3).
+		Return(meta2, nil).
+		Once()
+
+	entry1, err := metadataCache.GetEntry(blockHash1)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(1), entry1.SpecVersion)
+	assert.Equal(t, meta1, entry1.Metadata)
+
+	entry2, err := metadataCache.GetEntry(blockHash2)
+	assert.NoError(t, err)
+	assert.Same(t, entry1, entry2)
+
+	entry3, err := metadataCache.GetEntry(blockHash3)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(2), entry3.SpecVersion)
+	assert.Equal(t, meta2, entry3.Metadata)
+
+	entry, err := metadataCache.GetEntryBySpecVersion(2)
+	assert.NoError(t, err)
+	assert.Same(t, entry3, entry)
+
+	eventRegistry := registry.EventRegistry{}
+
+	registryFactoryMock.On("CreateEventRegistry", meta1).
+		Return(eventRegistry, nil).
+		Once()
+
+	res, err := entry1.GetEventRegistry()
+	assert.NoError(t, err)
+	assert.Equal(t, eventRegistry, res)
+
+	res, err = entry2.GetEventRegistry()
+	assert.NoError(t, err)
+	assert.Equal(t, eventRegistry, res)
+
+	extrinsicDecoder := &registry.ExtrinsicDecoder{}
+
+	registryFactoryMock.On("CreateExtrinsicDecoder", meta2).
+		Return(extrinsicDecoder, nil).
+		Once()
+
+	decoder, err := entry3.GetExtrinsicDecoder()
+	assert.NoError(t, err)
+	assert.Same(t, extrinsicDecoder, decoder)
+
+	registryFactoryError := errors.New("error")
+
+	registryFactoryMock.On("CreateCallRegistry", meta2).
+		Return(nil, registryFactoryError).
+		Once()
+
+	callRegistry, err := entry3.GetCallRegistry()
+	assert.ErrorIs(t, err, ErrCallRegistryCreation)
+	assert.Nil(t, callRegistry)
+
+	registryFactoryMock.On("CreateCallRegistry", meta2).
+		Return(registry.CallRegistry{}, nil).
+		Once()
+
+	callRegistry, err = entry3.GetCallRegistry()
+	assert.NoError(t, err)
+	assert.NotNil(t, callRegistry)
+}
+
+func TestMetadataCache_GetEntry_Errors(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	metadataCache := NewMetadataCache(stateRPCMock, registryFactoryMock)
+
+	blockHash := types.NewHash([]byte{1})
+
+	runtimeVersionError := errors.New("error")
+
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash).
+		Return(nil, runtimeVersionError).
+		Once()
+
+	res, err := metadataCache.GetEntry(blockHash)
+	assert.ErrorIs(t, err, ErrRuntimeVersionRetrieval)
+	assert.Nil(t, res)
+
+	metadataError := errors.New("error")
+
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash).
+		Return(&types.RuntimeVersion{SpecVersion: 1}, nil).
+		Twice()
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
+		Return(nil, metadataError).
+		Once()
+
+	res, err = metadataCache.GetEntry(blockHash)
+	assert.ErrorIs(t, err, ErrMetadataRetrieval)
+	assert.Nil(t, res)
+
+	res, err = metadataCache.GetEntryBySpecVersion(1)
+	assert.ErrorIs(t, err, ErrMetadataNotFound)
+	assert.Nil(t, res)
+
+	meta := &types.Metadata{}
+
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
+		Return(meta, nil).
+		Once()
+
+	res, err = metadataCache.GetEntry(blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, meta, res.Metadata)
+}
+
+func TestMetadataCache_GetLatestEntry(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	metadataCache := NewMetadataCache(stateRPCMock, registryFactoryMock)
+
+	meta := &types.Metadata{}
+
+	stateRPCMock.On("GetRuntimeVersionLatestCtx", mock.Anything).
+		Return(&types.RuntimeVersion{SpecVersion: 3}, nil).
+		Twice()
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
+		Return(meta, nil).
+		Once()
+
+	res, err := metadataCache.GetLatestEntry()
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(3), res.SpecVersion)
+	assert.Equal(t, meta, res.Metadata)
+
+	entry, err := metadataCache.GetLatestEntry()
+	assert.NoError(t, err)
+	assert.Same(t, res, entry)
+
+	runtimeVersionError := errors.New("error")
+
+	stateRPCMock.On("GetRuntimeVersionLatestCtx", mock.Anything).
+		Return(nil, runtimeVersionError).
+		Once()
+
+	entry, err = metadataCache.GetLatestEntry()
+	assert.ErrorIs(t, err, ErrRuntimeVersionRetrieval)
+	assert.Nil(t, entry)
+}
+
+func TestMetadataCache_AddMetadata(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	metadataCache := NewMetadataCache(stateRPCMock, registryFactoryMock)
+
+	meta := &types.Metadata{Version: 14}
+
+	res, err := metadataCache.AddMetadata(1, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, meta, res.Metadata)
+
+	entry, err := metadataCache.AddMetadata(1, &types.Metadata{})
+	assert.NoError(t, err)
+	assert.Same(t, res, entry)
+
+	entry, err = metadataCache.GetEntryBySpecVersion(1)
+	assert.NoError(t, err)
+	assert.Same(t, res, entry)
+}
+
+func TestMetadataCache_Concurrency(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	metadataCache := NewMetadataCache(stateRPCMock, registryFactoryMock)
+
+	blockHash := types.NewHash([]byte{1})
+
+	meta := &types.Metadata{}
+
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash).
+		Return(&types.RuntimeVersion{SpecVersion: 1}, nil)
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
+		Return(meta, nil).
+		Once()
+
+	eventRegistry := registry.EventRegistry{}
+
+	registryFactoryMock.On("CreateEventRegistry", meta).
+		Return(eventRegistry, nil).
+		Once()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			entry, err := metadataCache.GetEntry(blockHash)
+			assert.NoError(t, err)
+
+			res, err := entry.GetEventRegistry()
+			assert.NoError(t, err)
+			assert.Equal(t, eventRegistry, res)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestMetadataCache_Storage(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+	storageMock := NewStorageMock(t)
+
+	metadataCache := NewMetadataCache(stateRPCMock, registryFactoryMock, WithStorage(storageMock))
+
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	rawMetadata, err := codec.Encode(meta)
+	assert.NoError(t, err)
+
+	blockHash := types.NewHash([]byte{1})
+
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash).
+		Return(&types.RuntimeVersion{SpecVersion: 1}, nil).
+		Once()
+	storageMock.On("Load", types.U32(1)).
+		Return(nil, ErrStoredMetadataNotFound).
+		Once()
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
+		Return(&meta, nil).
+		Once()
+	storageMock.On("Store", types.U32(1), rawMetadata).
+		Return(nil).
+		Once()
+
+	res, err := metadataCache.GetEntry(blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, &meta, res.Metadata)
+
+	// A new cache loads the persisted metadata without retrieving it from the chain.
+	metadataCache = NewMetadataCache(stateRPCMock, registryFactoryMock, WithStorage(storageMock))
+
+	storageMock.On("Load", types.U32(1)).
+		Return(rawMetadata, nil).
+		Once()
+
+	res, err = metadataCache.GetEntryBySpecVersion(1)
+	assert.NoError(t, err)
+	assert.Equal(t, &meta, res.Metadata)
+
+	storageError := errors.New("error")
+
+	storageMock.On("Load", types.U32(2)).
+		Return(nil, ErrStoredMetadataNotFound).
+		Once()
+	storageMock.On("Store", types.U32(2), rawMetadata).
+		Return(storageError).
+		Once()
+
+	res, err = metadataCache.AddMetadata(2, &meta)
+	assert.ErrorIs(t, err, ErrStoredMetadataSaving)
+	assert.Nil(t, res)
+
+	storageMock.On("Load", types.U32(3)).
+		Return([]byte{0}, nil).
+		Once()
+
+	res, err = metadataCache.GetEntryBySpecVersion(3)
+	assert.ErrorIs(t, err, ErrMetadataNotFound)
+	assert.ErrorIs(t, err, ErrMetadataDecoding)
+	assert.Nil(t, res)
+}
+
+type testSpecVersionLookup map[uint64]types.U32
+
+func (l testSpecVersionLookup) GetSpecVersion(blockNumber uint64) (types.U32, bool) {
+	specVersion, ok := l[blockNumber]
+
+	return specVersion, ok
+}
+
+func TestMetadataCache_GetEntryForBlock(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	metadataCache := NewMetadataCache(
+		stateRPCMock,
+		registryFactoryMock,
+		WithSpecVersionLookup(testSpecVersionLookup{1: 1, 2: 1}),
+	)
+
+	blockHash1 := types.NewHash([]byte{1})
+	blockHash2 := types.NewHash([]byte{2})
+	blockHash3 := types.NewHash([]byte{3})
+
+	meta1 := &types.Metadata{Version: 14}
+	meta2 := &types.Metadata{Version: 15}
+
+	// The spec version of blocks 1 and 2 is provided by the lookup.
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash1).
+		Return(meta1, nil).
+		Once()
+
+	entry1, err := metadataCache.GetEntryForBlock(1, blockHash1)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(1), entry1.SpecVersion)
+	assert.Equal(t, meta1, entry1.Metadata)
+
+	entry2, err := metadataCache.GetEntryForBlock(2, blockHash2)
+	assert.NoError(t, err)
+	assert.Same(t, entry1, entry2)
+
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash3).
+		Return(&types.RuntimeVersion{SpecVersion: 2}, nil).
+		Once()
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash3).
+		Return(meta2, nil).
+		Once()
+
+	entry3, err := metadataCache.GetEntryForBlock(3, blockHash3)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(2), entry3.SpecVersion)
+	assert.Equal(t, meta2, entry3.Metadata)
+
+	// The runtime version is always resolved if there's no lookup.
+	metadataCache = NewMetadataCache(stateRPCMock, registryFactoryMock)
+
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash1).
+		Return(&types.RuntimeVersion{SpecVersion: 1}, nil).
+		Once()
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash1).
+		Return(meta1, nil).
+		Once()
+
+	entry1, err = metadataCache.GetEntryForBlock(1, blockHash1)
+	assert.NoError(t, err)
+	assert.Equal(t, meta1, entry1.Metadata)
+}
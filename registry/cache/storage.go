@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+//go:generate mockery --name Storage --structname StorageMock --filename storage_mock.go --inpackage
+
+// Storage is the interface used for persisting the raw, SCALE encoded, metadata of a runtime spec version.
+//
+// Implementations are expected to return ErrStoredMetadataNotFound if there is no metadata stored
+// for the requested spec version.
+type Storage interface {
+	Load(specVersion types.U32) ([]byte, error)
+	Store(specVersion types.U32, rawMetadata []byte) error
+}
+
+const (
+	metadataFileNameFormat = "metadata_%d.scale"
+	metadataFilePerm       = 0o644
+	metadataDirPerm        = 0o755
+)
+
+// fileStorage implements Storage by keeping one file per spec version in a directory.
+type fileStorage struct {
+	dir string
+}
+
+// NewFileStorage creates a new Storage that keeps the raw metadata in the provided directory.
+func NewFileStorage(dir string) Storage {
+	return &fileStorage{dir: dir}
+}
+
+// Load reads the raw metadata for the provided spec version.
+func (f *fileStorage) Load(specVersion types.U32) ([]byte, error) {
+	b, err := os.ReadFile(f.getFilePath(specVersion))
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrStoredMetadataNotFound
+		}
+
+		return nil, ErrStoredMetadataLoading.Wrap(err)
+	}
+
+	return b, nil
+}
+
+// Store writes the raw metadata for the provided spec version.
+//
+// The metadata is first written to a temporary file which is then renamed, so that concurrent readers
+// never observe a partially written file.
+func (f *fileStorage) Store(specVersion types.U32, rawMetadata []byte) error {
+	if err := os.MkdirAll(f.dir, metadataDirPerm); err != nil {
+		return ErrMetadataStorageDirCreation.Wrap(err)
+	}
+
+	tmpFile, err := os.CreateTemp(f.dir, fmt.Sprintf(metadataFileNameFormat, specVersion)+".*")
+
+	if err != nil {
+		return ErrStoredMetadataSaving.Wrap(err)
+	}
+
+	defer os.Remove(tmpFile.Name()) //nolint:errcheck
+
+	if _, err := tmpFile.Write(rawMetadata); err != nil {
+		_ = tmpFile.Close()
+
+		return ErrStoredMetadataSaving.Wrap(err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return ErrStoredMetadataSaving.Wrap(err)
+	}
+
+	if err := os.Chmod(tmpFile.Name(), metadataFilePerm); err != nil {
+		return ErrStoredMetadataSaving.Wrap(err)
+	}
+
+	if err := os.Rename(tmpFile.Name(), f.getFilePath(specVersion)); err != nil {
+		return ErrStoredMetadataSaving.Wrap(err)
+	}
+
+	return nil
+}
+
+func (f *fileStorage) getFilePath(specVersion types.U32) string {
+	return filepath.Join(f.dir, fmt.Sprintf(metadataFileNameFormat, specVersion))
+}
@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStorage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "metadata")
+
+	storage := NewFileStorage(dir)
+
+	res, err := storage.Load(1)
+	assert.ErrorIs(t, err, ErrStoredMetadataNotFound)
+	assert.Nil(t, res)
+
+	rawMetadata := []byte{1, 2, 3}
+
+	err = storage.Store(1, rawMetadata)
+	assert.NoError(t, err)
+
+	res, err = storage.Load(1)
+	assert.NoError(t, err)
+	assert.Equal(t, rawMetadata, res)
+
+	err = storage.Store(1, []byte{4})
+	assert.NoError(t, err)
+
+	res, err = storage.Load(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{4}, res)
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "metadata_1.scale", entries[0].Name())
+}
+
+func TestFileStorage_Errors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+
+	err := os.WriteFile(file, nil, 0o600)
+	assert.NoError(t, err)
+
+	storage := NewFileStorage(file)
+
+	err = storage.Store(1, []byte{1})
+	assert.ErrorIs(t, err, ErrMetadataStorageDirCreation)
+
+	dir := t.TempDir()
+
+	err = os.Mkdir(filepath.Join(dir, "metadata_1.scale"), 0o700)
+	assert.NoError(t, err)
+
+	storage = NewFileStorage(dir)
+
+	res, err := storage.Load(1)
+	assert.ErrorIs(t, err, ErrStoredMetadataLoading)
+	assert.Nil(t, res)
+}
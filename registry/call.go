@@ -0,0 +1,197 @@
+package registry
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// CallDecoder is the FieldDecoder used for the RuntimeCall type, which is the variant that holds the call
+// variant of each pallet.
+//
+// It decodes a call into a DecodedCall, which allows identifying calls that are nested in other calls, such as
+// the calls of Utility.batch_all or Proxy.proxy.
+type CallDecoder struct {
+	VariantDecoder *VariantDecoder
+}
+
+func (c *CallDecoder) Decode(decoder *scale.Decoder) (any, error) {
+	if c.VariantDecoder == nil {
+		return nil, ErrCallVariantDecoderNotFound
+	}
+
+	value, err := c.VariantDecoder.Decode(decoder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	palletVariant, ok := value.(*DecodedVariant)
+
+	if !ok || len(palletVariant.Fields) != 1 {
+		return nil, ErrUnexpectedCallValue.WithMsg("expected pallet variant, got %T", value)
+	}
+
+	callVariant, ok := palletVariant.Fields[0].Value.(*DecodedVariant)
+
+	if !ok {
+		return nil, ErrUnexpectedCallValue.WithMsg("expected call variant, got %T", palletVariant.Fields[0].Value)
+	}
+
+	return &DecodedCall{
+		CallIndex: types.CallIndex{
+			SectionIndex: palletVariant.Index,
+			MethodIndex:  callVariant.Index,
+		},
+		PalletName:  palletVariant.Name,
+		CallName:    callVariant.Name,
+		Fields:      callVariant.Fields,
+		LookupIndex: palletVariant.Fields[0].LookupIndex,
+	}, nil
+}
+
+// DecodedCall is the value returned when a call is decoded.
+type DecodedCall struct {
+	CallIndex  types.CallIndex
+	PalletName string
+	CallName   string
+
+	// Fields holds the decoded arguments of the call.
+	Fields DecodedFields
+
+	// LookupIndex is the lookup index of the call variant of the pallet.
+	LookupIndex int64
+}
+
+// Name returns the full name of the call, in the format <Pallet>.<Call>.
+func (d *DecodedCall) Name() string {
+	return d.PalletName + fieldSeparator + d.CallName
+}
+
+// Is returns true if the call has the provided full name, in the format <Pallet>.<Call>.
+func (d *DecodedCall) Is(callName string) bool {
+	return d != nil && d.Name() == callName
+}
+
+func (d DecodedCall) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(d.CallIndex); err != nil {
+		return err
+	}
+
+	for _, field := range d.Fields {
+		if err := field.Encode(encoder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CallWalkFn is the func called for each call that is found by WalkCalls, where parents holds the calls that
+// wrap the call, starting with the outermost one. The walk is stopped if the func returns false.
+//
+// The parents slice is not modified after the func returns, so it can be retained.
+type CallWalkFn func(call *DecodedCall, parents []*DecodedCall) bool
+
+// WalkCalls calls the provided func for all calls found in the provided decoded value, in depth-first order,
+// including calls nested in other calls, such as the ones of Utility.batch, Proxy.proxy, Multisig.as_multi,
+// Sudo.sudo or Scheduler.schedule.
+//
+// It returns false if the walk was stopped by the func.
+func WalkCalls(value any, walkFn CallWalkFn) bool {
+	return walkCalls(value, nil, walkFn)
+}
+
+func walkCalls(value any, parents []*DecodedCall, walkFn CallWalkFn) bool {
+	switch v := value.(type) {
+	case *DecodedCall:
+		if !walkFn(v, parents) {
+			return false
+		}
+
+		// The capacity is limited so that appending to the parents of sibling calls allocates a new array.
+		return walkCalls(v.Fields, append(parents[:len(parents):len(parents)], v), walkFn)
+	case *DecodedVariant:
+		return walkCalls(v.Fields, parents, walkFn)
+	case DecodedFields:
+		for _, field := range v {
+			if !walkCalls(field.Value, parents, walkFn) {
+				return false
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if !walkCalls(item, parents, walkFn) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// FindCalls returns all calls with the provided full name, in the format <Pallet>.<Call>,
+// that are found in the provided decoded value.
+func FindCalls(value any, callName string) []*DecodedCall {
+	var calls []*DecodedCall
+
+	WalkCalls(value, func(call *DecodedCall, _ []*DecodedCall) bool {
+		if call.Is(callName) {
+			calls = append(calls, call)
+		}
+
+		return true
+	})
+
+	return calls
+}
+
+// GetCall returns the DecodedCall of the extrinsic.
+func (d DecodedExtrinsic) GetCall() (*DecodedCall, error) {
+	return GetDecodedFieldAsType[*DecodedCall](
+		d.DecodedFields,
+		func(_ int, field *DecodedField) bool {
+			return field.Name == ExtrinsicCallName
+		},
+	)
+}
+
+// WalkCalls calls the provided func for the call of the extrinsic and all the calls nested in it.
+//
+// See WalkCalls for more details.
+func (d DecodedExtrinsic) WalkCalls(walkFn CallWalkFn) bool {
+	return WalkCalls(d.DecodedFields, walkFn)
+}
+
+// IsRuntimeCallType returns true if the provided variant type definition is the RuntimeCall type, where each
+// variant holds the call variant of the pallet with the same index.
+func IsRuntimeCallType(meta *types.Metadata, typeDef types.Si1TypeDef) bool {
+	variants := typeDef.Variant.Variants
+
+	if len(variants) == 0 {
+		return false
+	}
+
+	for _, variant := range variants {
+		if len(variant.Fields) != 1 {
+			return false
+		}
+
+		if !isPalletCallsType(meta, variant.Index, variant.Fields[0].Type) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isPalletCallsType(meta *types.Metadata, palletIndex types.U8, lookupID types.Si1LookupTypeID) bool {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Index != palletIndex {
+			continue
+		}
+
+		return pallet.HasCalls && pallet.Calls.Type.Int64() == lookupID.Int64()
+	}
+
+	return false
+}
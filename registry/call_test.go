@@ -0,0 +1,268 @@
+package registry
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testAlice   = types.AccountID{1}
+	testBob     = types.AccountID{2}
+	testCharlie = types.AccountID{3}
+)
+
+// encodeTestCall returns the encoded call with the provided name and encoded arguments.
+func encodeTestCall(t *testing.T, meta *types.Metadata, callName string, args ...[]byte) []byte {
+	callIndex, err := meta.FindCallIndex(callName)
+	assert.NoError(t, err)
+
+	return append([]byte{callIndex.SectionIndex, callIndex.MethodIndex}, bytes.Join(args, nil)...)
+}
+
+func encodeTestValue(t *testing.T, value any) []byte {
+	b, err := codec.Encode(value)
+	assert.NoError(t, err)
+
+	return b
+}
+
+// getTestNestedCallExtrinsic returns an unsigned extrinsic with the following call:
+//
+//	Proxy.proxy
+//	└── Multisig.as_multi_threshold_1
+//	    └── Utility.batch_all
+//	        ├── System.remark
+//	        └── Balances.transfer_keep_alive
+func getTestNestedCallExtrinsic(t *testing.T, meta *types.Metadata) []byte {
+	remark := encodeTestCall(t, meta, "System.remark", encodeTestValue(t, []byte{1, 2}))
+
+	transfer := encodeTestCall(
+		t,
+		meta,
+		"Balances.transfer_keep_alive",
+		encodeTestValue(t, types.MultiAddress{IsID: true, AsID: testCharlie}),
+		encodeTestValue(t, types.NewUCompactFromUInt(1_000)),
+	)
+
+	batch := encodeTestCall(
+		t,
+		meta,
+		"Utility.batch_all",
+		encodeTestValue(t, types.NewUCompactFromUInt(2)),
+		remark,
+		transfer,
+	)
+
+	multisig := encodeTestCall(
+		t,
+		meta,
+		"Multisig.as_multi_threshold_1",
+		encodeTestValue(t, []types.AccountID{testBob}),
+		batch,
+	)
+
+	proxy := encodeTestCall(
+		t,
+		meta,
+		"Proxy.proxy",
+		encodeTestValue(t, types.MultiAddress{IsID: true, AsID: testAlice}),
+		[]byte{0},
+		multisig,
+	)
+
+	extrinsic := append([]byte{4}, proxy...)
+
+	return append(encodeTestValue(t, types.NewUCompactFromUInt(uint64(len(extrinsic)))), extrinsic...)
+}
+
+func getTestCentrifugeExtrinsicDecoder(t *testing.T) (*types.Metadata, *ExtrinsicDecoder) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	extrinsicDecoder, err := NewFactory().CreateExtrinsicDecoder(&meta)
+	assert.NoError(t, err)
+
+	return &meta, extrinsicDecoder
+}
+
+func TestCallDecoder_NestedCalls(t *testing.T) {
+	meta, extrinsicDecoder := getTestCentrifugeExtrinsicDecoder(t)
+
+	encodedExtrinsic := getTestNestedCallExtrinsic(t, meta)
+
+	decodedExtrinsic, err := extrinsicDecoder.Decode(scale.NewDecoder(bytes.NewReader(encodedExtrinsic)))
+	assert.NoError(t, err)
+
+	call, err := decodedExtrinsic.GetCall()
+	assert.NoError(t, err)
+	assert.Equal(t, "Proxy.proxy", call.Name())
+	assert.Equal(t, decodedExtrinsic.CallIndex, call.CallIndex)
+
+	type visitedCall struct {
+		name    string
+		parents []string
+	}
+
+	var visitedCalls []visitedCall
+
+	completed := decodedExtrinsic.WalkCalls(func(call *DecodedCall, parents []*DecodedCall) bool {
+		parentNames := make([]string, 0, len(parents))
+
+		for _, parent := range parents {
+			parentNames = append(parentNames, parent.Name())
+		}
+
+		visitedCalls = append(visitedCalls, visitedCall{call.Name(), parentNames})
+
+		return true
+	})
+	assert.True(t, completed)
+
+	assert.Equal(
+		t,
+		[]visitedCall{
+			{"Proxy.proxy", []string{}},
+			{"Multisig.as_multi_threshold_1", []string{"Proxy.proxy"}},
+			{"Utility.batch_all", []string{"Proxy.proxy", "Multisig.as_multi_threshold_1"}},
+			{"System.remark", []string{"Proxy.proxy", "Multisig.as_multi_threshold_1", "Utility.batch_all"}},
+			{"Balances.transfer_keep_alive", []string{"Proxy.proxy", "Multisig.as_multi_threshold_1", "Utility.batch_all"}},
+		},
+		visitedCalls,
+	)
+
+	transfers := FindCalls(decodedExtrinsic.DecodedFields, "Balances.transfer_keep_alive")
+	assert.Len(t, transfers, 1)
+
+	transferCallIndex, err := meta.FindCallIndex("Balances.transfer_keep_alive")
+	assert.NoError(t, err)
+
+	assert.Equal(t, transferCallIndex, transfers[0].CallIndex)
+	assert.Equal(t, "Balances", transfers[0].PalletName)
+	assert.Equal(t, "transfer_keep_alive", transfers[0].CallName)
+
+	dest, err := GetDecodedFieldAsType[*DecodedVariant](
+		transfers[0].Fields,
+		func(_ int, field *DecodedField) bool {
+			return strings.HasSuffix(field.Name, "dest")
+		},
+	)
+	assert.NoError(t, err)
+	assert.True(t, dest.Is("Id"))
+
+	var transfer struct {
+		Value uint64
+	}
+
+	err = Unmarshal(transfers[0].Fields, &transfer)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1_000), transfer.Value)
+
+	remarks := FindCalls(call, "System.remark")
+	assert.Len(t, remarks, 1)
+
+	// The decoded call is encoded back into the original call.
+	assert.Equal(
+		t,
+		encodeTestCall(t, meta, "System.remark", encodeTestValue(t, []byte{1, 2})),
+		encodeTestValue(t, remarks[0]),
+	)
+}
+
+func TestWalkCalls_Stop(t *testing.T) {
+	meta, extrinsicDecoder := getTestCentrifugeExtrinsicDecoder(t)
+
+	encodedExtrinsic := getTestNestedCallExtrinsic(t, meta)
+
+	decodedExtrinsic, err := extrinsicDecoder.Decode(scale.NewDecoder(bytes.NewReader(encodedExtrinsic)))
+	assert.NoError(t, err)
+
+	var visitedCalls []string
+
+	completed := decodedExtrinsic.WalkCalls(func(call *DecodedCall, _ []*DecodedCall) bool {
+		visitedCalls = append(visitedCalls, call.Name())
+
+		return !call.Is("Utility.batch_all")
+	})
+	assert.False(t, completed)
+	assert.Equal(t, []string{"Proxy.proxy", "Multisig.as_multi_threshold_1", "Utility.batch_all"}, visitedCalls)
+
+	assert.Empty(t, FindCalls(DecodedFields{{Name: "value", Value: types.U8(1)}}, "System.remark"))
+}
+
+func TestCallDecoder_Errors(t *testing.T) {
+	decoder := scale.NewDecoder(bytes.NewReader([]byte{0, 0}))
+
+	res, err := (&CallDecoder{}).Decode(decoder)
+	assert.ErrorIs(t, err, ErrCallVariantDecoderNotFound)
+	assert.Nil(t, res)
+
+	callDecoder := &CallDecoder{
+		VariantDecoder: &VariantDecoder{
+			FieldDecoderMap: map[byte]FieldDecoder{
+				0: &NoopDecoder{},
+			},
+			VariantNameMap: map[byte]string{
+				0: "System",
+			},
+		},
+	}
+
+	res, err = callDecoder.Decode(decoder)
+	assert.ErrorIs(t, err, ErrUnexpectedCallValue)
+	assert.Nil(t, res)
+
+	res, err = callDecoder.Decode(scale.NewDecoder(bytes.NewReader(nil)))
+	assert.ErrorIs(t, err, ErrVariantByteDecoding)
+	assert.Nil(t, res)
+}
+
+func TestDecodedExtrinsic_GetCall_Error(t *testing.T) {
+	decodedExtrinsic := DecodedExtrinsic{
+		DecodedFields: DecodedFields{
+			{Name: ExtrinsicCallName, Value: &DecodedVariant{Name: "System"}},
+		},
+	}
+
+	res, err := decodedExtrinsic.GetCall()
+	assert.ErrorIs(t, err, ErrDecodedFieldValueTypeMismatch)
+	assert.Nil(t, res)
+}
+
+func TestUnmarshalValue_DecodedCall(t *testing.T) {
+	call := &DecodedCall{
+		PalletName: "Balances",
+		CallName:   "transfer_keep_alive",
+		Fields: DecodedFields{
+			{Name: "value", Value: types.NewUCompact(big.NewInt(1_000))},
+		},
+	}
+
+	var callName string
+
+	err := UnmarshalValue(call, &callName)
+	assert.NoError(t, err)
+	assert.Equal(t, "Balances.transfer_keep_alive", callName)
+
+	var transfer *struct {
+		Value uint64
+	}
+
+	err = UnmarshalValue(call, &transfer)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1_000), transfer.Value)
+
+	var i int
+
+	err = UnmarshalValue(call, &i)
+	assert.ErrorIs(t, err, ErrUnmarshalTypeMismatch)
+}
@@ -0,0 +1,97 @@
+// Command gsrpc-codegen generates Go packages from the metadata of a runtime, using codegen.Generator.
+//
+// The metadata is either read from a file, which holds the metadata as hex or as raw SCALE bytes,
+// or retrieved from a node. It can be used in a go:generate directive as follows:
+//
+//	go run github.com/centrifuge/go-substrate-rpc-client/v4/registry/codegen/cmd/gsrpc-codegen \
+//		-url wss://rpc.polkadot.io -out ./polkadot -import example.com/project/polkadot
+package main
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/codegen"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+func main() {
+	metadataPath := flag.String("metadata", "", "path of a file holding the metadata, either as hex or as raw bytes")
+	url := flag.String("url", "", "URL of the node used for retrieving the latest metadata")
+	outDir := flag.String("out", ".", "output directory of the generated packages")
+	importPath := flag.String("import", "", "import path of the output directory")
+
+	flag.Parse()
+
+	if *importPath == "" || (*metadataPath == "") == (*url == "") {
+		flag.Usage()
+
+		os.Exit(2)
+	}
+
+	meta, err := getMetadata(*metadataPath, *url)
+
+	if err != nil {
+		log.Fatalf("Couldn't get metadata: %s", err)
+	}
+
+	files, err := codegen.NewGenerator(*importPath).Generate(meta)
+
+	if err != nil {
+		log.Fatalf("Couldn't generate code: %s", err)
+	}
+
+	for filePath, source := range files {
+		fullPath := filepath.Join(*outDir, filepath.FromSlash(filePath))
+
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+			log.Fatalf("Couldn't create directory: %s", err)
+		}
+
+		if err := os.WriteFile(fullPath, source, 0o644); err != nil { //nolint:gosec
+			log.Fatalf("Couldn't write file: %s", err)
+		}
+	}
+
+	log.Printf("Generated %d files in %s", len(files), *outDir)
+}
+
+func getMetadata(metadataPath, url string) (*types.Metadata, error) {
+	if url != "" {
+		cl, err := client.Connect(url)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return state.NewState(cl).GetMetadataLatest()
+	}
+
+	b, err := os.ReadFile(metadataPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var meta types.Metadata
+
+	b = bytes.TrimSpace(b)
+
+	if bytes.HasPrefix(b, []byte("0x")) {
+		err = codec.DecodeFromHex(string(b), &meta)
+	} else {
+		err = codec.Decode(b, &meta)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &meta, nil
+}
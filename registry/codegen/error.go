@@ -0,0 +1,22 @@
+package codegen
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrMetadataVersionNotSupported = libErr.Error("metadata version not supported")
+	ErrTypeNotFound                = libErr.Error("type not found")
+	ErrTypeNotSupported            = libErr.Error("type not supported")
+	ErrTypeNotVariant              = libErr.Error("type not a variant")
+	ErrRuntimeCallTypeNotFound     = libErr.Error("runtime call type not found")
+	ErrPalletGeneration            = libErr.Error("pallet generation")
+	ErrRuntimeTypesGeneration      = libErr.Error("runtime types generation")
+	ErrSourceFormatting            = libErr.Error("source formatting")
+	ErrStorageHashersMismatch      = libErr.Error("storage hashers mismatch")
+	ErrStorageKeyEncoding          = libErr.Error("storage key encoding")
+	ErrStorageHashing              = libErr.Error("storage hashing")
+	ErrStorageRetrieval            = libErr.Error("storage retrieval")
+	ErrValueDecoding               = libErr.Error("value decoding")
+	ErrCallEncoding                = libErr.Error("call encoding")
+	ErrVariantIndexNotSupported    = libErr.Error("variant index not supported")
+	ErrVariantNotSet               = libErr.Error("variant not set")
+)
@@ -0,0 +1,75 @@
+package codegen
+
+import (
+	"path"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Generator is the interface used for generating Go packages from the metadata of a runtime.
+//
+// The generated code consists of:
+//   - the runtime package, which holds the types of the runtime, such as RuntimeCall and RuntimeEvent;
+//   - a package for each pallet, which holds the call constructors, event structs, storage accessors, constants
+//     and errors of the pallet.
+//
+// All generated types implement the scale.Encodeable and scale.Decodeable interfaces.
+type Generator interface {
+	// Generate returns the generated files, mapped by their paths relative to the output directory.
+	Generate(meta *types.Metadata) (map[string][]byte, error)
+}
+
+type generator struct {
+	importPath string
+}
+
+// NewGenerator creates a new Generator, where the import path is the import path of the output directory
+// and is used for importing the generated runtime package in the pallet packages.
+func NewGenerator(importPath string) Generator {
+	return &generator{
+		importPath: importPath,
+	}
+}
+
+func (g *generator) Generate(meta *types.Metadata) (map[string][]byte, error) {
+	registry, err := newTypeRegistry(meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	runtimeImportPath := path.Join(g.importPath, runtimePackageName)
+
+	runtimeTypes, err := registry.generateRuntimeTypes(runtimeImportPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string][]byte{
+		path.Join(runtimePackageName, runtimeTypesFileName): runtimeTypes,
+	}
+
+	packageNames := newNameSet(runtimePackageName)
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		palletGenerator := &palletGenerator{
+			registry:          registry,
+			pallet:            pallet,
+			packageName:       packageNames.add(packageName(string(pallet.Name))),
+			runtimeImportPath: runtimeImportPath,
+		}
+
+		palletFiles, err := palletGenerator.generate()
+
+		if err != nil {
+			return nil, err
+		}
+
+		for fileName, source := range palletFiles {
+			res[path.Join(palletGenerator.packageName, fileName)] = source
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,186 @@
+package codegen
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testImportPath = "example.com/generated"
+
+	codegenPackagePath = "github.com/centrifuge/go-substrate-rpc-client/v4/registry/codegen"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	var tests = []struct {
+		Chain       string
+		MetadataHex string
+		Pallets     []string
+	}{
+		{
+			Chain:       "centrifuge",
+			MetadataHex: test.CentrifugeMetadataHex,
+			Pallets:     []string{"system", "balances", "ormltokens", "proxy"},
+		},
+		{
+			Chain:       "polkadot",
+			MetadataHex: test.PolkadotMetadataHex,
+			Pallets:     []string{"system", "balances", "democracy", "xcmpallet"},
+		},
+		{
+			Chain:       "acala",
+			MetadataHex: test.AcalaMetaHex,
+			Pallets:     []string{"system", "balances", "tokens"},
+		},
+		{
+			Chain:       "statemint",
+			MetadataHex: test.StatemintMetaHex,
+			Pallets:     []string{"system", "balances", "assets", "utility"},
+		},
+		{
+			Chain:       "moonbeam",
+			MetadataHex: test.MoonbeamMetaHex,
+			Pallets:     []string{"system", "balances", "ethereum"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Chain, func(t *testing.T) {
+			meta := getTestMetadata(t, test.MetadataHex)
+
+			generator := NewGenerator(testImportPath)
+
+			res, err := generator.Generate(meta)
+			assert.NoError(t, err)
+			assert.Contains(t, res, "runtime/types.go")
+
+			for _, pallet := range test.Pallets {
+				assert.Contains(t, res, path.Join(pallet, "pallet.go"))
+			}
+
+			for filePath, source := range res {
+				formattedSource, err := format.Source(source)
+				assert.NoError(t, err, filePath)
+				assert.Equal(t, string(formattedSource), string(source), filePath)
+				assert.True(t, bytes.HasPrefix(source, []byte(generatedCodeHeader)), filePath)
+			}
+
+			// The generated code does not depend on map iteration order.
+			secondRes, err := generator.Generate(meta)
+			assert.NoError(t, err)
+			assert.Equal(t, res, secondRes)
+		})
+	}
+}
+
+func TestGenerator_Generate_Content(t *testing.T) {
+	meta := getTestMetadata(t, test.CentrifugeMetadataHex)
+
+	res, err := NewGenerator(testImportPath).Generate(meta)
+	assert.NoError(t, err)
+
+	balancesCalls := string(res["balances/calls.go"])
+
+	assert.Contains(t, balancesCalls, "package balances")
+	assert.Contains(t, balancesCalls, `"example.com/generated/runtime"`)
+	assert.Contains(
+		t,
+		balancesCalls,
+		"func NewTransferKeepAliveCall(dest runtime.MultiAddress, value types.UCompact) runtime.RuntimeCall {",
+	)
+
+	systemStorage := string(res["system/storage.go"])
+
+	assert.Contains(t, systemStorage, "func AccountStorageKey(key0 types.AccountID) (types.StorageKey, error) {")
+
+	runtimeTypes := string(res["runtime/types.go"])
+
+	assert.Contains(t, runtimeTypes, "type RuntimeCall struct {")
+	assert.Contains(t, runtimeTypes, "type RuntimeEvent struct {")
+
+	// Recursive types are broken by pointers.
+	assert.Regexp(t, `\tAsProxy\s+\*PalletProxyPalletCall\n`, runtimeTypes)
+}
+
+func TestGenerator_Generate_MetadataVersionNotSupported(t *testing.T) {
+	res, err := NewGenerator(testImportPath).Generate(&types.Metadata{Version: 13})
+	assert.ErrorIs(t, err, ErrMetadataVersionNotSupported)
+	assert.Nil(t, res)
+}
+
+func TestGenerator_Generate_RuntimeCallTypeNotFound(t *testing.T) {
+	res, err := NewGenerator(testImportPath).Generate(&types.Metadata{Version: 14})
+	assert.ErrorIs(t, err, ErrRuntimeCallTypeNotFound)
+	assert.Nil(t, res)
+}
+
+// TestGenerator_Generate_Compile generates the packages for the Statemint metadata inside this module and runs
+// the tests found in testdata against them.
+func TestGenerator_Generate_Compile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping compilation of generated code in short mode")
+	}
+
+	goBinary, err := exec.LookPath("go")
+
+	if err != nil {
+		t.Skip("go binary not found")
+	}
+
+	// Directories prefixed with an underscore are ignored by the go tool when using patterns such as './...'.
+	outDir, err := os.MkdirTemp(".", "_generated")
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(outDir)
+
+	importPath := path.Join(codegenPackagePath, filepath.Base(outDir))
+
+	res, err := NewGenerator(importPath).Generate(getTestMetadata(t, test.StatemintMetaHex))
+	assert.NoError(t, err)
+
+	for filePath, source := range res {
+		fullPath := filepath.Join(outDir, filepath.FromSlash(filePath))
+
+		assert.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		assert.NoError(t, os.WriteFile(fullPath, source, 0o600))
+	}
+
+	tmpl, err := template.ParseFiles(filepath.Join("testdata", "generated_test.go.tmpl"))
+	assert.NoError(t, err)
+
+	var checkSource bytes.Buffer
+
+	err = tmpl.Execute(&checkSource, struct{ ImportPath string }{importPath})
+	assert.NoError(t, err)
+
+	checkDir := filepath.Join(outDir, "check")
+
+	assert.NoError(t, os.MkdirAll(checkDir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(checkDir, "generated_test.go"), checkSource.Bytes(), 0o600))
+
+	cmd := exec.Command(goBinary, "test", "./"+filepath.ToSlash(checkDir))
+
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, strings.TrimSpace(string(output)))
+}
+
+func getTestMetadata(t *testing.T, metadataHex string) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(metadataHex, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
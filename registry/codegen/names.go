@@ -0,0 +1,160 @@
+package codegen
+
+import (
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// initialisms holds the words that are fully capitalized in exported Go identifiers.
+var initialisms = map[string]string{
+	"id":  "ID",
+	"ids": "IDs",
+}
+
+// exportedName converts the provided Rust identifier, which is either in snake case or in camel case,
+// into an exported Go identifier.
+func exportedName(name string) string {
+	var sb strings.Builder
+
+	for _, word := range splitWords(name) {
+		if initialism, ok := initialisms[strings.ToLower(word)]; ok {
+			sb.WriteString(initialism)
+
+			continue
+		}
+
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+
+		sb.WriteString(string(runes))
+	}
+
+	res := sb.String()
+
+	if res == "" || !unicode.IsLetter([]rune(res)[0]) {
+		return "X" + res
+	}
+
+	return res
+}
+
+// splitWords splits the provided identifier into words, at separators, at the start of upper case letters
+// that follow lower case letters and at the boundaries between letters and digits.
+func splitWords(name string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+
+	for _, r := range name {
+		if isNameSeparator(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+
+			continue
+		}
+
+		if len(word) > 0 {
+			last := word[len(word)-1]
+
+			isBoundary := (unicode.IsUpper(r) && !unicode.IsUpper(last)) ||
+				(unicode.IsDigit(r) != unicode.IsDigit(last))
+
+			if isBoundary {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+
+		word = append(word, r)
+	}
+
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	return words
+}
+
+// unexportedName converts the provided Rust identifier into an unexported Go identifier, which is also not a
+// Go keyword.
+func unexportedName(name string) string {
+	words := splitWords(name)
+
+	if len(words) == 0 {
+		return "arg"
+	}
+
+	res := strings.ToLower(words[0])
+
+	if len(words) > 1 {
+		res += exportedName(strings.Join(words[1:], "_"))
+	}
+
+	if !unicode.IsLetter([]rune(res)[0]) {
+		return "arg" + res
+	}
+
+	if token.IsKeyword(res) {
+		return res + "Arg"
+	}
+
+	return res
+}
+
+// packageName converts the provided pallet name into a Go package name.
+func packageName(palletName string) string {
+	var sb strings.Builder
+
+	for _, r := range strings.ToLower(palletName) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+
+	res := sb.String()
+
+	if res == "" || token.IsKeyword(res) || res == runtimePackageName || !unicode.IsLetter([]rune(res)[0]) {
+		return "pallet" + res
+	}
+
+	return res
+}
+
+func isNameSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// nameSet is used for ensuring that the names declared in the same scope are unique.
+type nameSet map[string]struct{}
+
+func newNameSet(reserved ...string) nameSet {
+	s := make(nameSet)
+
+	for _, name := range reserved {
+		s[name] = struct{}{}
+	}
+
+	return s
+}
+
+// add adds the provided name to the set, adding a numeric suffix to it if the name is already taken.
+func (s nameSet) add(name string) string {
+	res := name
+
+	for i := 1; ; i++ {
+		if _, ok := s[res]; !ok {
+			break
+		}
+
+		res = name + strconv.Itoa(i)
+	}
+
+	s[res] = struct{}{}
+
+	return res
+}
@@ -0,0 +1,65 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"transfer_keep_alive":  "TransferKeepAlive",
+		"as_multi_threshold_1": "AsMultiThreshold1",
+		"CurrencyId":           "CurrencyID",
+		"PalletId":             "PalletID",
+		"asset_ids":            "AssetIDs",
+		"AccountId32":          "AccountID32",
+		"H256":                 "H256",
+		"1":                    "X1",
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, exportedName(name), name)
+	}
+}
+
+func TestUnexportedName(t *testing.T) {
+	tests := map[string]string{
+		"force_proxy_type": "forceProxyType",
+		"CurrencyId":       "currencyID",
+		"type":             "typeArg",
+		"func":             "funcArg",
+		"0":                "arg0",
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, unexportedName(name), name)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := map[string]string{
+		"Balances":       "balances",
+		"OrmlTokens":     "ormltokens",
+		"XcmpQueue":      "xcmpqueue",
+		"Runtime":        "palletruntime",
+		"Import":         "palletimport",
+		"2FA":            "pallet2fa",
+		"":               "pallet",
+		"Parachain-Info": "parachaininfo",
+	}
+
+	for palletName, expected := range tests {
+		assert.Equal(t, expected, packageName(palletName), palletName)
+	}
+}
+
+func TestNameSet(t *testing.T) {
+	names := newNameSet("Encode")
+
+	assert.Equal(t, "Encode1", names.add("Encode"))
+	assert.Equal(t, "Call", names.add("Call"))
+	assert.Equal(t, "Call1", names.add("Call"))
+	assert.Equal(t, "Call2", names.add("Call"))
+	assert.Equal(t, "Call11", names.add("Call1"))
+}
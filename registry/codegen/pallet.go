@@ -0,0 +1,533 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	palletFileName    = "pallet.go"
+	callsFileName     = "calls.go"
+	eventsFileName    = "events.go"
+	storageFileName   = "storage.go"
+	constantsFileName = "constants.go"
+	errorsFileName    = "errors.go"
+)
+
+// palletGenerator generates the package of a pallet, which holds its call constructors, event structs,
+// storage accessors, constants and errors.
+type palletGenerator struct {
+	registry          *typeRegistry
+	pallet            types.PalletMetadataV14
+	packageName       string
+	runtimeImportPath string
+}
+
+// generate returns the generated files of the pallet package, mapped by their file names.
+func (g *palletGenerator) generate() (map[string][]byte, error) {
+	fileFns := map[string]func(file *sourceFile) error{
+		palletFileName:    g.printPallet,
+		callsFileName:     g.printCalls,
+		eventsFileName:    g.printEvents,
+		storageFileName:   g.printStorage,
+		constantsFileName: g.printConstants,
+		errorsFileName:    g.printErrors,
+	}
+
+	res := make(map[string][]byte)
+
+	for fileName, fileFn := range fileFns {
+		file := newSourceFile(g.packageName, g.runtimeImportPath)
+
+		if err := fileFn(file); err != nil {
+			return nil, ErrPalletGeneration.WithMsg("pallet '%s', file '%s'", g.pallet.Name, fileName).Wrap(err)
+		}
+
+		if file.body.Len() == 0 {
+			continue
+		}
+
+		source, err := file.source()
+
+		if err != nil {
+			return nil, ErrPalletGeneration.WithMsg("pallet '%s', file '%s'", g.pallet.Name, fileName).Wrap(err)
+		}
+
+		res[fileName] = source
+	}
+
+	return res, nil
+}
+
+func (g *palletGenerator) printPallet(file *sourceFile) error {
+	file.packageDoc = fmt.Sprintf("Package %s holds the generated types of the %s pallet.", g.packageName, g.pallet.Name)
+
+	file.printf("const (\n")
+	file.printf("\t// PalletName is the name of the pallet in the runtime.\n")
+	file.printf("\tPalletName = %q\n\n", g.pallet.Name)
+	file.printf("\t// PalletIndex is the index of the pallet in the runtime.\n")
+	file.printf("\tPalletIndex = %d\n", g.pallet.Index)
+	file.printf(")\n")
+
+	return nil
+}
+
+// printCalls writes a constructor for each call of the pallet, which returns the call wrapped in the
+// RuntimeCall type, so that it can be encoded as is or nested in other calls.
+func (g *palletGenerator) printCalls(file *sourceFile) error {
+	if !g.pallet.HasCalls {
+		return nil
+	}
+
+	callsTypeID := g.pallet.Calls.Type.Int64()
+
+	runtimeCallVariants, err := g.registry.getVariantFields(file, g.registry.runtimeCallID)
+
+	if err != nil {
+		return err
+	}
+
+	var palletCall *variantFields
+
+	for i := range runtimeCallVariants {
+		if runtimeCallVariants[i].variant.Index == g.pallet.Index {
+			palletCall = &runtimeCallVariants[i]
+		}
+	}
+
+	if palletCall == nil || len(palletCall.fields) != 1 {
+		return ErrRuntimeCallTypeNotFound.WithMsg("pallet index %d", g.pallet.Index)
+	}
+
+	calls, err := g.registry.getVariantFields(file, callsTypeID)
+
+	if err != nil {
+		return err
+	}
+
+	runtimeCallExpr, err := g.registry.typeExpr(file, g.registry.runtimeCallID)
+
+	if err != nil {
+		return err
+	}
+
+	callsTypeExpr, err := g.registry.typeExpr(file, callsTypeID)
+
+	if err != nil {
+		return err
+	}
+
+	funcNames := newNameSet()
+
+	for _, call := range calls {
+		funcName := funcNames.add("New" + exportedName(string(call.variant.Name)) + "Call")
+
+		file.printf("// %s creates the %s.%s call.\n", funcName, g.pallet.Name, call.variant.Name)
+		printItemDocs(file, call.variant.Docs)
+
+		params := make([]string, 0, len(call.fields))
+
+		for _, field := range call.fields {
+			params = append(params, field.param+" "+field.typeExpr)
+		}
+
+		file.printf("func %s(%s) %s {\n", funcName, strings.Join(params, ", "), runtimeCallExpr)
+
+		palletCallExpr := callsTypeExpr + "{"
+
+		if palletCall.fields[0].pointer {
+			palletCallExpr = "&" + palletCallExpr
+		}
+
+		file.printf("\treturn %s{\n", runtimeCallExpr)
+		file.printf("\t\t%s: true,\n", palletCall.isField)
+		file.printf("\t\t%s: %s\n", palletCall.fields[0].name, palletCallExpr)
+		file.printf("\t\t\t%s: true,\n", call.isField)
+
+		for _, field := range call.fields {
+			value := field.param
+
+			if field.pointer {
+				value = "&" + value
+			}
+
+			file.printf("\t\t\t%s: %s,\n", field.name, value)
+		}
+
+		file.printf("\t\t},\n")
+		file.printf("\t}\n")
+		file.printf("}\n\n")
+	}
+
+	return nil
+}
+
+// printEvents writes a struct for each event of the pallet, which has the same layout as the event structs of the
+// types package, so that it can be used with types.EventRecordsRaw.DecodeEventRecords.
+func (g *palletGenerator) printEvents(file *sourceFile) error {
+	if !g.pallet.HasEvents {
+		return nil
+	}
+
+	events, err := g.registry.getVariants(g.pallet.Events.Type.Int64())
+
+	if err != nil {
+		return err
+	}
+
+	file.use(typesImportPath)
+	file.use(scaleImportPath)
+
+	typeNames := newNameSet()
+
+	for _, event := range events {
+		typeName := typeNames.add(exportedName(string(event.Name)) + "Event")
+
+		fields, err := g.registry.getStructFields(
+			file,
+			-1,
+			event.Fields,
+			"",
+			newNameSet(encodeMethodName, decodeMethodName, "Phase", "Topics"),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		file.printf("// %s is emitted as the %s.%s event.\n", typeName, g.pallet.Name, event.Name)
+		printItemDocs(file, event.Docs)
+		file.printf("type %s struct {\n", typeName)
+		file.printf("\tPhase types.Phase\n")
+		printStructFields(file, fields)
+		file.printf("\tTopics []types.Hash\n")
+		file.printf("}\n\n")
+
+		file.printf("func (e *%s) Decode(decoder scale.Decoder) error {\n", typeName)
+		file.printf("\tif err := decoder.Decode(&e.Phase); err != nil {\n\t\treturn err\n\t}\n\n")
+		printDecodeFields(file, "\t", "e", fields)
+		file.printf("\treturn decoder.Decode(&e.Topics)\n")
+		file.printf("}\n\n")
+
+		file.printf("func (e %s) Encode(encoder scale.Encoder) error {\n", typeName)
+		file.printf("\tif err := encoder.Encode(e.Phase); err != nil {\n\t\treturn err\n\t}\n\n")
+		printEncodeFields(file, "\t", "e", fields)
+		file.printf("\treturn encoder.Encode(e.Topics)\n")
+		file.printf("}\n\n")
+	}
+
+	return nil
+}
+
+// printStorage writes a func for creating the storage key and a func for retrieving the value of each
+// storage entry of the pallet.
+func (g *palletGenerator) printStorage(file *sourceFile) error {
+	if !g.pallet.HasStorage {
+		return nil
+	}
+
+	file.use(typesImportPath)
+	file.use(stateImportPath)
+	file.use(codegenImportPath)
+
+	funcNames := newNameSet()
+
+	for _, entry := range g.pallet.Storage.Items {
+		keyFuncName := funcNames.add(exportedName(string(entry.Name)) + "StorageKey")
+		getFuncName := funcNames.add("Get" + exportedName(string(entry.Name)) + "Storage")
+
+		keys, hashers, valueTypeID, err := g.getStorageKeys(file, entry)
+
+		if err != nil {
+			return err
+		}
+
+		valueExpr, err := g.registry.typeExpr(file, valueTypeID)
+
+		if err != nil {
+			return err
+		}
+
+		params := make([]string, 0, len(keys))
+		args := make([]string, 0, len(keys))
+
+		for _, key := range keys {
+			params = append(params, key.param+" "+key.typeExpr)
+			args = append(args, key.param)
+		}
+
+		file.printf(
+			"// %s returns the storage key of %s.%s.\n",
+			keyFuncName,
+			g.pallet.Storage.Prefix,
+			entry.Name,
+		)
+		file.printf("func %s(%s) (types.StorageKey, error) {\n", keyFuncName, strings.Join(params, ", "))
+		file.printf(
+			"\treturn codegen.CreateStorageKey(%q, %q, []types.StorageHasherV10{%s}%s)\n",
+			g.pallet.Storage.Prefix,
+			entry.Name,
+			strings.Join(hashers, ", "),
+			strings.Join(append([]string{""}, args...), ", "),
+		)
+		file.printf("}\n\n")
+
+		getParams := append([]string{"stateRPC state.State", "blockHash types.Hash"}, params...)
+
+		file.printf(
+			"// %s returns the value of %s.%s at the provided block.\n",
+			getFuncName,
+			g.pallet.Storage.Prefix,
+			entry.Name,
+		)
+		printItemDocs(file, entry.Documentation)
+
+		if entry.Modifier.IsOptional {
+			file.printf("//\n// The returned bool is false if the storage entry is not set.\n")
+			file.printf(
+				"func %s(%s) (value %s, ok bool, err error) {\n",
+				getFuncName,
+				strings.Join(getParams, ", "),
+				valueExpr,
+			)
+			file.printf("\tkey, err := %s(%s)\n\n", keyFuncName, strings.Join(args, ", "))
+			file.printf("\tif err != nil {\n\t\treturn value, false, err\n\t}\n\n")
+			file.printf("\treturn codegen.GetStorage[%s](stateRPC, key, blockHash)\n", valueExpr)
+			file.printf("}\n\n")
+
+			continue
+		}
+
+		file.printf("//\n// The default value of the storage entry is returned if it is not set.\n")
+		file.printf(
+			"func %s(%s) (value %s, err error) {\n",
+			getFuncName,
+			strings.Join(getParams, ", "),
+			valueExpr,
+		)
+		file.printf("\tkey, err := %s(%s)\n\n", keyFuncName, strings.Join(args, ", "))
+		file.printf("\tif err != nil {\n\t\treturn value, err\n\t}\n\n")
+		file.printf(
+			"\treturn codegen.GetStorageOrDefault[%s](stateRPC, key, blockHash, %q)\n",
+			valueExpr,
+			codec.HexEncodeToString(entry.Fallback),
+		)
+		file.printf("}\n\n")
+	}
+
+	return nil
+}
+
+// getStorageKeys returns the keys and the hasher expressions of the provided storage entry, together with
+// the type ID of its value.
+//
+// Maps with multiple hashers have a tuple key, in which case a key is returned for each item of the tuple.
+func (g *palletGenerator) getStorageKeys(
+	file *sourceFile,
+	entry types.StorageEntryMetadataV14,
+) ([]structField, []string, int64, error) {
+	if entry.IsPlain() {
+		return nil, nil, entry.Type.AsPlainType.Int64(), nil
+	}
+
+	mapType := entry.Type.AsMap
+
+	hashers := make([]string, 0, len(mapType.Hashers))
+
+	for _, hasher := range mapType.Hashers {
+		hashers = append(hashers, getHasherExpr(hasher))
+	}
+
+	keyTypeIDs := []int64{mapType.Key.Int64()}
+
+	if len(mapType.Hashers) > 1 {
+		keyType, err := g.registry.getType(mapType.Key.Int64())
+
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		if !keyType.Def.IsTuple || len(keyType.Def.Tuple) != len(mapType.Hashers) {
+			return nil, nil, 0, ErrStorageHashersMismatch.WithMsg(
+				"storage entry '%s' has %d hashers",
+				entry.Name,
+				len(mapType.Hashers),
+			)
+		}
+
+		keyTypeIDs = keyTypeIDs[:0]
+
+		for _, item := range keyType.Def.Tuple {
+			keyTypeIDs = append(keyTypeIDs, item.Int64())
+		}
+	}
+
+	keys := make([]structField, 0, len(keyTypeIDs))
+
+	for i, keyTypeID := range keyTypeIDs {
+		typeExpr, err := g.registry.typeExpr(file, keyTypeID)
+
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		keys = append(keys, structField{
+			typeExpr: typeExpr,
+			param:    fmt.Sprintf("key%d", i),
+		})
+	}
+
+	return keys, hashers, mapType.Value.Int64(), nil
+}
+
+func getHasherExpr(hasher types.StorageHasherV10) string {
+	switch {
+	case hasher.IsBlake2_128:
+		return "{IsBlake2_128: true}"
+	case hasher.IsBlake2_256:
+		return "{IsBlake2_256: true}"
+	case hasher.IsBlake2_128Concat:
+		return "{IsBlake2_128Concat: true}"
+	case hasher.IsTwox128:
+		return "{IsTwox128: true}"
+	case hasher.IsTwox256:
+		return "{IsTwox256: true}"
+	case hasher.IsTwox64Concat:
+		return "{IsTwox64Concat: true}"
+	default:
+		return "{IsIdentity: true}"
+	}
+}
+
+// printConstants writes a func that returns the decoded value of each constant of the pallet.
+func (g *palletGenerator) printConstants(file *sourceFile) error {
+	if len(g.pallet.Constants) == 0 {
+		return nil
+	}
+
+	file.use(codegenImportPath)
+
+	funcNames := newNameSet()
+
+	for _, constant := range g.pallet.Constants {
+		funcName := funcNames.add(exportedName(string(constant.Name)) + "Constant")
+
+		valueExpr, err := g.registry.typeExpr(file, constant.Type.Int64())
+
+		if err != nil {
+			return err
+		}
+
+		file.printf("// %s returns the value of the %s.%s constant.\n", funcName, g.pallet.Name, constant.Name)
+		printItemDocs(file, constant.Docs)
+		file.printf("func %s() (%s, error) {\n", funcName, valueExpr)
+		file.printf(
+			"\treturn codegen.DecodeValue[%s](%q)\n",
+			valueExpr,
+			codec.HexEncodeToString(constant.Value),
+		)
+		file.printf("}\n\n")
+	}
+
+	return nil
+}
+
+// printErrors writes the Error enum of the pallet. If any of the errors has fields, the Error type is an alias
+// for the generated runtime type instead.
+func (g *palletGenerator) printErrors(file *sourceFile) error {
+	if !g.pallet.HasErrors {
+		return nil
+	}
+
+	errorsTypeID := g.pallet.Errors.Type.Int64()
+
+	errorVariants, err := g.registry.getVariants(errorsTypeID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, errorVariant := range errorVariants {
+		if len(errorVariant.Fields) == 0 {
+			continue
+		}
+
+		errorsTypeExpr, err := g.registry.typeExpr(file, errorsTypeID)
+
+		if err != nil {
+			return err
+		}
+
+		file.printf("// Error is the error enum of the %s pallet.\n", g.pallet.Name)
+		file.printf("type Error = %s\n", errorsTypeExpr)
+
+		return nil
+	}
+
+	file.use(scaleImportPath)
+	file.use(fmtImportPath)
+
+	file.printf("// Error is the error enum of the %s pallet, which is found in the first byte of the\n", g.pallet.Name)
+	file.printf("// error of a types.ModuleError that has the index of the pallet.\n")
+	file.printf("type Error uint8\n\n")
+
+	constNames := newNameSet()
+	errorNames := make([]string, 0, len(errorVariants))
+
+	file.printf("const (\n")
+
+	for i, errorVariant := range errorVariants {
+		constName := constNames.add(exportedName(string(errorVariant.Name)) + "Error")
+		errorNames = append(errorNames, constName)
+
+		if i > 0 {
+			file.printf("\n")
+		}
+
+		file.printf("\t// %s is the %s.%s error.\n", constName, g.pallet.Name, errorVariant.Name)
+		printItemDocs(file, errorVariant.Docs, "\t")
+		file.printf("\t%s Error = %d\n", constName, errorVariant.Index)
+	}
+
+	file.printf(")\n\n")
+
+	file.printf("// Error returns the full name of the error, in the format <Pallet>.<Error>.\n")
+	file.printf("func (e Error) Error() string {\n")
+	file.printf("\tswitch e {\n")
+
+	for i, errorVariant := range errorVariants {
+		file.printf("\tcase %s:\n", errorNames[i])
+		file.printf("\t\treturn %q\n", fmt.Sprintf("%s.%s", g.pallet.Name, errorVariant.Name))
+	}
+
+	file.printf("\tdefault:\n")
+	file.printf("\t\treturn fmt.Sprintf(\"%s.Error(%%d)\", uint8(e))\n", g.pallet.Name)
+	file.printf("\t}\n")
+	file.printf("}\n\n")
+
+	file.printf("func (e *Error) Decode(decoder scale.Decoder) error {\n")
+	file.printf("\tb, err := decoder.ReadOneByte()\n\n")
+	file.printf("\tif err != nil {\n\t\treturn err\n\t}\n\n")
+	file.printf("\t*e = Error(b)\n\n")
+	file.printf("\treturn nil\n")
+	file.printf("}\n\n")
+
+	file.printf("func (e Error) Encode(encoder scale.Encoder) error {\n")
+	file.printf("\treturn encoder.PushByte(byte(e))\n")
+	file.printf("}\n")
+
+	return nil
+}
+
+// printItemDocs writes the docs of a pallet item, separated from the generated first line of the comment.
+func printItemDocs(file *sourceFile, docs []types.Text, indent ...string) {
+	if len(docs) == 0 {
+		return
+	}
+
+	file.printf("%s//\n", strings.Join(indent, ""))
+	file.printDocs(strings.Join(indent, ""), textsToStrings(docs))
+}
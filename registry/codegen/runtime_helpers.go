@@ -0,0 +1,112 @@
+package codegen
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/xxhash"
+)
+
+// The funcs below are used by the generated pallet packages.
+
+// CreateStorageKey creates the storage key for the provided storage prefix and method, where each key is
+// encoded and then hashed with the hasher found at the same position.
+func CreateStorageKey(
+	prefix string,
+	method string,
+	hashers []types.StorageHasherV10,
+	keys ...any,
+) (types.StorageKey, error) {
+	if len(hashers) != len(keys) {
+		return nil, ErrStorageHashersMismatch.WithMsg("expected %d keys, got %d", len(hashers), len(keys))
+	}
+
+	storageKey := append(xxhash.New128([]byte(prefix)).Sum(nil), xxhash.New128([]byte(method)).Sum(nil)...)
+
+	for i, key := range keys {
+		encodedKey, err := codec.Encode(key)
+
+		if err != nil {
+			return nil, ErrStorageKeyEncoding.WithMsg("key #%d", i).Wrap(err)
+		}
+
+		hasher, err := hashers[i].HashFunc()
+
+		if err != nil {
+			return nil, ErrStorageHashing.WithMsg("key #%d", i).Wrap(err)
+		}
+
+		if _, err := hasher.Write(encodedKey); err != nil {
+			return nil, ErrStorageHashing.WithMsg("key #%d", i).Wrap(err)
+		}
+
+		storageKey = append(storageKey, hasher.Sum(nil)...)
+	}
+
+	return storageKey, nil
+}
+
+// GetStorage retrieves and decodes the storage value for the provided key at the provided block.
+//
+// The returned bool is false if the storage is not set.
+func GetStorage[T any](stateRPC state.State, key types.StorageKey, blockHash types.Hash) (T, bool, error) {
+	var value T
+
+	ok, err := stateRPC.GetStorage(key, &value, blockHash)
+
+	if err != nil {
+		return value, false, ErrStorageRetrieval.Wrap(err)
+	}
+
+	return value, ok, nil
+}
+
+// GetStorageOrDefault retrieves and decodes the storage value for the provided key at the provided block,
+// returning the provided hex encoded default value if the storage is not set.
+func GetStorageOrDefault[T any](
+	stateRPC state.State,
+	key types.StorageKey,
+	blockHash types.Hash,
+	defaultValueHex string,
+) (T, error) {
+	value, ok, err := GetStorage[T](stateRPC, key, blockHash)
+
+	if err != nil || ok {
+		return value, err
+	}
+
+	return DecodeValue[T](defaultValueHex)
+}
+
+// DecodeValue decodes the provided hex encoded value, such as the value of a constant.
+func DecodeValue[T any](valueHex string) (T, error) {
+	var value T
+
+	if err := codec.DecodeFromHex(valueHex, &value); err != nil {
+		return value, ErrValueDecoding.Wrap(err)
+	}
+
+	return value, nil
+}
+
+// NewCall returns the types.Call for the provided call, such as the RuntimeCall returned by a generated call
+// constructor, so that it can be used with types.NewExtrinsic.
+func NewCall(call any) (types.Call, error) {
+	encodedCall, err := codec.Encode(call)
+
+	if err != nil {
+		return types.Call{}, ErrCallEncoding.Wrap(err)
+	}
+
+	if len(encodedCall) < 2 {
+		return types.Call{}, ErrCallEncoding.WithMsg("expected call index, got %d bytes", len(encodedCall))
+	}
+
+	return types.Call{
+		CallIndex: types.CallIndex{
+			SectionIndex: encodedCall[0],
+			MethodIndex:  encodedCall[1],
+		},
+		Args: encodedCall[2:],
+	}, nil
+}
@@ -0,0 +1,192 @@
+package codegen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateStorageKey(t *testing.T) {
+	meta := getTestMetadata(t, test.CentrifugeMetadataHex)
+
+	accountID := types.AccountID{1, 2, 3}
+
+	encodedAccountID, err := codec.Encode(accountID)
+	assert.NoError(t, err)
+
+	res, err := CreateStorageKey(
+		"System",
+		"Account",
+		[]types.StorageHasherV10{{IsBlake2_128Concat: true}},
+		accountID,
+	)
+	assert.NoError(t, err)
+
+	expectedStorageKey, err := types.CreateStorageKey(meta, "System", "Account", encodedAccountID)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStorageKey, res)
+
+	// The native currency ID is encoded as the index of its variant.
+	nativeCurrencyID := types.U8(0)
+
+	res, err = CreateStorageKey(
+		"OrmlTokens",
+		"Accounts",
+		[]types.StorageHasherV10{{IsBlake2_128Concat: true}, {IsTwox64Concat: true}},
+		accountID,
+		nativeCurrencyID,
+	)
+	assert.NoError(t, err)
+
+	expectedStorageKey, err = types.CreateStorageKey(meta, "OrmlTokens", "Accounts", encodedAccountID, []byte{0})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStorageKey, res)
+}
+
+func TestCreateStorageKey_HashersMismatch(t *testing.T) {
+	res, err := CreateStorageKey("System", "Account", []types.StorageHasherV10{{IsBlake2_128Concat: true}})
+	assert.ErrorIs(t, err, ErrStorageHashersMismatch)
+	assert.Nil(t, res)
+}
+
+func TestCreateStorageKey_KeyEncodingError(t *testing.T) {
+	var accountID *types.AccountID
+
+	res, err := CreateStorageKey(
+		"System",
+		"Account",
+		[]types.StorageHasherV10{{IsBlake2_128Concat: true}},
+		accountID,
+	)
+	assert.ErrorIs(t, err, ErrStorageKeyEncoding)
+	assert.Nil(t, res)
+}
+
+func TestCreateStorageKey_HashingError(t *testing.T) {
+	res, err := CreateStorageKey("System", "Account", []types.StorageHasherV10{{}}, types.AccountID{1})
+	assert.ErrorIs(t, err, ErrStorageHashing)
+	assert.Nil(t, res)
+}
+
+func TestGetStorage(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	key := types.StorageKey{1, 2, 3}
+	blockHash := types.Hash{4, 5, 6}
+
+	stateRPCMock.On("GetStorage", key, mock.Anything, blockHash).
+		Run(func(args mock.Arguments) {
+			target := args.Get(1).(*types.U32)
+
+			*target = 11
+		}).
+		Return(true, nil).
+		Once()
+
+	res, ok, err := GetStorage[types.U32](stateRPCMock, key, blockHash)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(11), res)
+
+	stateRPCMock.On("GetStorage", key, mock.Anything, blockHash).
+		Return(false, nil).
+		Once()
+
+	res, ok, err = GetStorage[types.U32](stateRPCMock, key, blockHash)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, types.U32(0), res)
+}
+
+func TestGetStorage_RetrievalError(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	key := types.StorageKey{1, 2, 3}
+	blockHash := types.Hash{4, 5, 6}
+
+	stateRPCMock.On("GetStorage", key, mock.Anything, blockHash).
+		Return(false, errors.New("error")).
+		Once()
+
+	res, ok, err := GetStorage[types.U32](stateRPCMock, key, blockHash)
+	assert.ErrorIs(t, err, ErrStorageRetrieval)
+	assert.False(t, ok)
+	assert.Equal(t, types.U32(0), res)
+}
+
+func TestGetStorageOrDefault(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	key := types.StorageKey{1, 2, 3}
+	blockHash := types.Hash{4, 5, 6}
+
+	stateRPCMock.On("GetStorage", key, mock.Anything, blockHash).
+		Run(func(args mock.Arguments) {
+			target := args.Get(1).(*types.U32)
+
+			*target = 11
+		}).
+		Return(true, nil).
+		Once()
+
+	res, err := GetStorageOrDefault[types.U32](stateRPCMock, key, blockHash, "0x16000000")
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(11), res)
+
+	stateRPCMock.On("GetStorage", key, mock.Anything, blockHash).
+		Return(false, nil).
+		Once()
+
+	res, err = GetStorageOrDefault[types.U32](stateRPCMock, key, blockHash, "0x16000000")
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(22), res)
+
+	stateRPCMock.On("GetStorage", key, mock.Anything, blockHash).
+		Return(false, errors.New("error")).
+		Once()
+
+	res, err = GetStorageOrDefault[types.U32](stateRPCMock, key, blockHash, "0x16000000")
+	assert.ErrorIs(t, err, ErrStorageRetrieval)
+	assert.Equal(t, types.U32(0), res)
+}
+
+func TestDecodeValue(t *testing.T) {
+	res, err := DecodeValue[types.U32]("0x16000000")
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(22), res)
+
+	res, err = DecodeValue[types.U32]("0x16")
+	assert.ErrorIs(t, err, ErrValueDecoding)
+	assert.Equal(t, types.U32(0), res)
+}
+
+func TestNewCall(t *testing.T) {
+	res, err := NewCall([3]byte{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		types.Call{
+			CallIndex: types.CallIndex{SectionIndex: 1, MethodIndex: 2},
+			Args:      types.Args{3},
+		},
+		res,
+	)
+}
+
+func TestNewCall_EncodingError(t *testing.T) {
+	var call *types.U32
+
+	res, err := NewCall(call)
+	assert.ErrorIs(t, err, ErrCallEncoding)
+	assert.Equal(t, types.Call{}, res)
+
+	res, err = NewCall(types.U8(1))
+	assert.ErrorIs(t, err, ErrCallEncoding)
+	assert.Equal(t, types.Call{}, res)
+}
@@ -0,0 +1,308 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	runtimeTypesFileName = "types.go"
+
+	encodeMethodName = "Encode"
+	decodeMethodName = "Decode"
+)
+
+// structField holds the details of a field that is generated in a struct.
+type structField struct {
+	name     string
+	typeExpr string
+
+	// pointer is true if the field is generated as a pointer in order to break a recursive type.
+	pointer bool
+
+	// param is the name used for the field when it is a func parameter.
+	param string
+}
+
+// getStructFields returns the struct fields for the provided portable fields.
+//
+// The names of the fields are prefixed with the provided prefix and added to the provided name set. Fields that
+// contain the owner type by value are generated as pointers, a negative owner ID disables this.
+func (r *typeRegistry) getStructFields(
+	file *sourceFile,
+	ownerID int64,
+	fields []types.Si1Field,
+	prefix string,
+	names nameSet,
+) ([]structField, error) {
+	res := make([]structField, 0, len(fields))
+
+	// The names of the imported packages are reserved so that the parameters do not shadow them.
+	params := newNameSet(runtimePackageName, "types", "scale", "state", "codegen", "fmt")
+
+	for i, field := range fields {
+		typeExpr, err := r.typeExpr(file, field.Type.Int64())
+
+		if err != nil {
+			return nil, err
+		}
+
+		baseName := fmt.Sprintf("Field%d", i)
+		paramName := fmt.Sprintf("arg%d", i)
+
+		switch {
+		case field.HasName:
+			baseName = exportedName(string(field.Name))
+			paramName = unexportedName(string(field.Name))
+		case len(fields) == 1 && prefix != "":
+			baseName = ""
+		}
+
+		res = append(res, structField{
+			name:     names.add(prefix + baseName),
+			typeExpr: typeExpr,
+			pointer:  ownerID >= 0 && r.containsByValue(field.Type.Int64(), ownerID),
+			param:    params.add(paramName),
+		})
+	}
+
+	return res, nil
+}
+
+// printStructFields writes the declarations of the provided fields.
+func printStructFields(file *sourceFile, fields []structField) {
+	for _, field := range fields {
+		if field.pointer {
+			file.printf("\t%s *%s\n", field.name, field.typeExpr)
+
+			continue
+		}
+
+		file.printf("\t%s %s\n", field.name, field.typeExpr)
+	}
+}
+
+// printDecodeFields writes the decoding of the provided fields of the receiver.
+func printDecodeFields(file *sourceFile, indent string, receiver string, fields []structField) {
+	for _, field := range fields {
+		target := "&" + receiver + "." + field.name
+
+		if field.pointer {
+			file.printf("%s%s.%s = new(%s)\n\n", indent, receiver, field.name, field.typeExpr)
+
+			target = receiver + "." + field.name
+		}
+
+		file.printf("%sif err := decoder.Decode(%s); err != nil {\n", indent, target)
+		file.printf("%s\treturn err\n", indent)
+		file.printf("%s}\n\n", indent)
+	}
+}
+
+// printEncodeFields writes the encoding of the provided fields of the receiver.
+func printEncodeFields(file *sourceFile, indent string, receiver string, fields []structField) {
+	for _, field := range fields {
+		file.printf("%sif err := encoder.Encode(%s.%s); err != nil {\n", indent, receiver, field.name)
+		file.printf("%s\treturn err\n", indent)
+		file.printf("%s}\n\n", indent)
+	}
+}
+
+// generateRuntimeTypes generates the runtime package, which holds the named types of the runtime.
+func (r *typeRegistry) generateRuntimeTypes(runtimeImportPath string) ([]byte, error) {
+	file := newSourceFile(runtimePackageName, runtimeImportPath)
+	file.packageDoc = "Package runtime holds the types of the runtime that are shared by the pallet packages."
+
+	for _, typeID := range r.getNamedTypeIDs() {
+		t := r.lookup[typeID]
+
+		file.printf("// %s is the Go type of %s.\n", r.names[typeID], r.getTypeDescription(typeID))
+
+		if len(t.Docs) > 0 {
+			file.printf("//\n")
+			file.printDocs("", textsToStrings(t.Docs))
+		}
+
+		var err error
+
+		switch {
+		case t.Def.IsComposite:
+			err = r.printComposite(file, typeID, t.Def.Composite)
+		case t.Def.IsVariant:
+			err = r.printVariant(file, typeID, t.Def.Variant)
+		}
+
+		if err != nil {
+			return nil, ErrRuntimeTypesGeneration.WithMsg("type '%s'", r.names[typeID]).Wrap(err)
+		}
+	}
+
+	return file.source()
+}
+
+func (r *typeRegistry) getTypeDescription(typeID int64) string {
+	switch {
+	case typeID == r.runtimeCallID:
+		return "the outer call enum of the runtime"
+	case typeID == r.runtimeEventID:
+		return "the outer event enum of the runtime"
+	}
+
+	path := getPath(r.lookup[typeID])
+
+	if path == "" {
+		return fmt.Sprintf("type #%d", typeID)
+	}
+
+	return path
+}
+
+func (r *typeRegistry) printComposite(file *sourceFile, typeID int64, composite types.Si1TypeDefComposite) error {
+	name := r.names[typeID]
+
+	fields, err := r.getStructFields(
+		file,
+		typeID,
+		composite.Fields,
+		"",
+		newNameSet(encodeMethodName, decodeMethodName),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	file.use(scaleImportPath)
+
+	file.printf("type %s struct {\n", name)
+	printStructFields(file, fields)
+	file.printf("}\n\n")
+
+	file.printf("func (t *%s) Decode(decoder scale.Decoder) error {\n", name)
+	printDecodeFields(file, "\t", "t", fields)
+	file.printf("\treturn nil\n}\n\n")
+
+	file.printf("func (t %s) Encode(encoder scale.Encoder) error {\n", name)
+	printEncodeFields(file, "\t", "t", fields)
+	file.printf("\treturn nil\n}\n\n")
+
+	return nil
+}
+
+// variantFields holds the struct fields that are generated for a variant.
+type variantFields struct {
+	variant types.Si1Variant
+	isField string
+	fields  []structField
+}
+
+// getVariantFields returns the struct fields of each variant, where each variant is represented by an Is<Variant>
+// bool field and an As<Variant> field for each of its fields.
+func (r *typeRegistry) getVariantFields(file *sourceFile, typeID int64) ([]variantFields, error) {
+	t, err := r.getType(typeID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.Def.IsVariant {
+		return nil, ErrTypeNotVariant.WithMsg("type ID %d", typeID)
+	}
+
+	names := newNameSet(encodeMethodName, decodeMethodName)
+
+	// The Is<Variant> fields are added first so that their names do not depend on the names of the As fields.
+	isFields := make([]string, 0, len(t.Def.Variant.Variants))
+
+	for _, variant := range t.Def.Variant.Variants {
+		isFields = append(isFields, names.add("Is"+exportedName(string(variant.Name))))
+	}
+
+	res := make([]variantFields, 0, len(t.Def.Variant.Variants))
+
+	for i, variant := range t.Def.Variant.Variants {
+		fields, err := r.getStructFields(file, typeID, variant.Fields, "As"+exportedName(string(variant.Name)), names)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, variantFields{
+			variant: variant,
+			isField: isFields[i],
+			fields:  fields,
+		})
+	}
+
+	return res, nil
+}
+
+func (r *typeRegistry) printVariant(file *sourceFile, typeID int64, variant types.Si1TypeDefVariant) error {
+	name := r.names[typeID]
+
+	variants, err := r.getVariantFields(file, typeID)
+
+	if err != nil {
+		return err
+	}
+
+	file.use(scaleImportPath)
+	file.use(codegenImportPath)
+
+	file.printf("type %s struct {\n", name)
+
+	for _, v := range variants {
+		file.printf("\t%s bool\n", v.isField)
+		printStructFields(file, v.fields)
+	}
+
+	file.printf("}\n\n")
+
+	file.printf("func (t *%s) Decode(decoder scale.Decoder) error {\n", name)
+
+	if len(variant.Variants) == 0 {
+		file.printf("\treturn codegen.ErrVariantIndexNotSupported.WithMsg(\"%s has no variants\")\n}\n\n", name)
+	} else {
+		file.printf("\tb, err := decoder.ReadOneByte()\n\n")
+		file.printf("\tif err != nil {\n\t\treturn err\n\t}\n\n")
+		file.printf("\tswitch b {\n")
+
+		for _, v := range variants {
+			file.printf("\tcase %d:\n", v.variant.Index)
+			file.printf("\t\tt.%s = true\n\n", v.isField)
+			printDecodeFields(file, "\t\t", "t", v.fields)
+			file.printf("\t\treturn nil\n")
+		}
+
+		file.printf("\tdefault:\n")
+		file.printf("\t\treturn codegen.ErrVariantIndexNotSupported.WithMsg(\"index %%d of %s\", b)\n", name)
+		file.printf("\t}\n}\n\n")
+	}
+
+	file.printf("func (t %s) Encode(encoder scale.Encoder) error {\n", name)
+	file.printf("\tswitch {\n")
+
+	for _, v := range variants {
+		file.printf("\tcase t.%s:\n", v.isField)
+		file.printf("\t\tif err := encoder.PushByte(%d); err != nil {\n\t\t\treturn err\n\t\t}\n\n", v.variant.Index)
+		printEncodeFields(file, "\t\t", "t", v.fields)
+		file.printf("\t\treturn nil\n")
+	}
+
+	file.printf("\tdefault:\n")
+	file.printf("\t\treturn codegen.ErrVariantNotSet.WithMsg(\"%s\")\n", name)
+	file.printf("\t}\n}\n\n")
+
+	return nil
+}
+
+func textsToStrings(texts []types.Text) []string {
+	res := make([]string, 0, len(texts))
+
+	for _, text := range texts {
+		res = append(res, string(text))
+	}
+
+	return res
+}
@@ -0,0 +1,135 @@
+package codegen
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+const (
+	generatedCodeHeader = "// Code generated by gsrpc-codegen. DO NOT EDIT."
+
+	typesImportPath   = "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	scaleImportPath   = "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	stateImportPath   = "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	codegenImportPath = "github.com/centrifuge/go-substrate-rpc-client/v4/registry/codegen"
+	fmtImportPath     = "fmt"
+)
+
+// sourceFile is used for writing the source of a generated Go file, while keeping track of its imports.
+type sourceFile struct {
+	packageName string
+	packageDoc  string
+
+	// runtimeImportPath is the import path of the generated runtime package.
+	runtimeImportPath string
+
+	imports map[string]struct{}
+	body    strings.Builder
+}
+
+func newSourceFile(packageName, runtimeImportPath string) *sourceFile {
+	return &sourceFile{
+		packageName:       packageName,
+		runtimeImportPath: runtimeImportPath,
+		imports:           make(map[string]struct{}),
+	}
+}
+
+// use adds the provided import path to the imports of the file.
+func (f *sourceFile) use(importPath string) {
+	f.imports[importPath] = struct{}{}
+}
+
+func (f *sourceFile) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(&f.body, format, args...)
+}
+
+// printDocs writes the provided docs as a comment, using the provided indentation.
+func (f *sourceFile) printDocs(indent string, docs []string) {
+	var lines []string
+
+	for _, doc := range docs {
+		lines = append(lines, strings.Split(doc, "\n")...)
+	}
+
+	for _, doc := range lines {
+		doc = strings.TrimRight(doc, " \t")
+
+		if doc == "" {
+			f.printf("%s//\n", indent)
+
+			continue
+		}
+
+		if !strings.HasPrefix(doc, " ") {
+			doc = " " + doc
+		}
+
+		f.printf("%s//%s\n", indent, doc)
+	}
+}
+
+// source returns the formatted source of the file.
+func (f *sourceFile) source() ([]byte, error) {
+	var sb strings.Builder
+
+	sb.WriteString(generatedCodeHeader + "\n\n")
+
+	if f.packageDoc != "" {
+		sb.WriteString("// " + f.packageDoc + "\n")
+	}
+
+	sb.WriteString("package " + f.packageName + "\n\n")
+
+	var stdImportPaths, importPaths []string
+
+	for importPath := range f.imports {
+		if strings.Contains(importPath, ".") {
+			importPaths = append(importPaths, importPath)
+
+			continue
+		}
+
+		stdImportPaths = append(stdImportPaths, importPath)
+	}
+
+	sort.Strings(stdImportPaths)
+	sort.Strings(importPaths)
+
+	if len(f.imports) > 0 {
+		sb.WriteString("import (\n")
+
+		for _, importPath := range stdImportPaths {
+			sb.WriteString(fmt.Sprintf("\t%q\n", importPath))
+		}
+
+		if len(stdImportPaths) > 0 && len(importPaths) > 0 {
+			sb.WriteString("\n")
+		}
+
+		for _, importPath := range importPaths {
+			sb.WriteString(fmt.Sprintf("\t%q\n", importPath))
+		}
+
+		sb.WriteString(")\n\n")
+	}
+
+	sb.WriteString(f.body.String())
+
+	res, err := format.Source([]byte(sb.String()))
+
+	if err != nil {
+		return nil, ErrSourceFormatting.Wrap(err)
+	}
+
+	// Reformatting the lists found in the docs can require a second pass before the result is stable.
+	res, err = format.Source(res)
+
+	if err != nil {
+		return nil, ErrSourceFormatting.Wrap(err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,542 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	runtimePackageName = "runtime"
+
+	runtimeCallTypeName  = "RuntimeCall"
+	runtimeEventTypeName = "RuntimeEvent"
+
+	optionTypePath = "Option"
+	pathSeparator  = "."
+)
+
+// wellKnownTypes maps the path of a portable type to the GSRPC type that is used instead of a generated one.
+var wellKnownTypes = map[string]string{
+	"sp_core.crypto.AccountId32": "types.AccountID",
+	"primitive_types.H160":       "types.H160",
+	"primitive_types.H256":       "types.Hash",
+}
+
+// primitiveTypes maps a portable primitive type to its GSRPC type.
+var primitiveTypes = map[byte]string{
+	types.IsBool: "types.Bool",
+	types.IsChar: "types.U32",
+	types.IsStr:  "types.Text",
+	types.IsU8:   "types.U8",
+	types.IsU16:  "types.U16",
+	types.IsU32:  "types.U32",
+	types.IsU64:  "types.U64",
+	types.IsU128: "types.U128",
+	types.IsU256: "types.U256",
+	types.IsI8:   "types.I8",
+	types.IsI16:  "types.I16",
+	types.IsI32:  "types.I32",
+	types.IsI64:  "types.I64",
+	types.IsI128: "types.I128",
+	types.IsI256: "types.I256",
+}
+
+// typeRegistry holds the portable types of the metadata together with the names of the Go types that are generated
+// for them.
+//
+// Composites and variants are generated as named types in the runtime package, except for:
+//   - well known types, which are mapped to the according GSRPC types;
+//   - composites with a single unnamed field, such as BoundedVec or Perbill, which are replaced by their field;
+//   - Option, which is mapped to types.Option.
+type typeRegistry struct {
+	meta   *types.Metadata
+	lookup map[int64]*types.Si1Type
+
+	// names holds the names of the types that are generated in the runtime package.
+	names map[int64]string
+
+	runtimeCallID  int64
+	runtimeEventID int64
+
+	// reverseValueEdges holds the types that contain each type by value.
+	reverseValueEdges map[int64][]int64
+
+	// valueReachCache holds the types that contain each type by value, either directly or indirectly.
+	valueReachCache map[int64]map[int64]bool
+}
+
+func newTypeRegistry(meta *types.Metadata) (*typeRegistry, error) {
+	if meta.Version != 14 {
+		return nil, ErrMetadataVersionNotSupported.WithMsg("version %d", meta.Version)
+	}
+
+	r := &typeRegistry{
+		meta:            meta,
+		lookup:          make(map[int64]*types.Si1Type),
+		names:           make(map[int64]string),
+		runtimeCallID:   -1,
+		runtimeEventID:  -1,
+		valueReachCache: make(map[int64]map[int64]bool),
+	}
+
+	for _, portableType := range meta.AsMetadataV14.Lookup.Types {
+		portableType := portableType
+
+		r.lookup[portableType.ID.Int64()] = &portableType.Type
+	}
+
+	r.runtimeCallID = r.findOuterEnum(func(pallet types.PalletMetadataV14) (bool, int64) {
+		return pallet.HasCalls, pallet.Calls.Type.Int64()
+	})
+
+	r.runtimeEventID = r.findOuterEnum(func(pallet types.PalletMetadataV14) (bool, int64) {
+		return pallet.HasEvents, pallet.Events.Type.Int64()
+	})
+
+	if r.runtimeCallID < 0 {
+		return nil, ErrRuntimeCallTypeNotFound
+	}
+
+	r.assignNames()
+	r.storeReverseValueEdges()
+
+	return r, nil
+}
+
+// getType returns the portable type with the provided ID.
+func (r *typeRegistry) getType(typeID int64) (*types.Si1Type, error) {
+	t, ok := r.lookup[typeID]
+
+	if !ok {
+		return nil, ErrTypeNotFound.WithMsg("type ID %d", typeID)
+	}
+
+	return t, nil
+}
+
+// getVariants returns the variants of the variant type with the provided ID.
+func (r *typeRegistry) getVariants(typeID int64) ([]types.Si1Variant, error) {
+	t, err := r.getType(typeID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.Def.IsVariant {
+		return nil, ErrTypeNotVariant.WithMsg("type ID %d", typeID)
+	}
+
+	return t.Def.Variant.Variants, nil
+}
+
+// findOuterEnum returns the ID of the variant type where each variant holds the type of the pallet with the
+// same index, as returned by the provided func, e.g. the RuntimeCall or RuntimeEvent types.
+func (r *typeRegistry) findOuterEnum(palletTypeFn func(pallet types.PalletMetadataV14) (bool, int64)) int64 {
+	palletTypes := make(map[types.U8]int64)
+
+	for _, pallet := range r.meta.AsMetadataV14.Pallets {
+		if ok, typeID := palletTypeFn(pallet); ok {
+			palletTypes[pallet.Index] = typeID
+		}
+	}
+
+	if len(palletTypes) == 0 {
+		return -1
+	}
+
+	for _, portableType := range r.meta.AsMetadataV14.Lookup.Types {
+		def := portableType.Type.Def
+
+		if !def.IsVariant || len(def.Variant.Variants) != len(palletTypes) {
+			continue
+		}
+
+		isOuterEnum := true
+
+		for _, variant := range def.Variant.Variants {
+			palletType, ok := palletTypes[variant.Index]
+
+			if !ok || len(variant.Fields) != 1 || variant.Fields[0].Type.Int64() != palletType {
+				isOuterEnum = false
+
+				break
+			}
+		}
+
+		if isOuterEnum {
+			return portableType.ID.Int64()
+		}
+	}
+
+	return -1
+}
+
+func getPath(t *types.Si1Type) string {
+	path := make([]string, 0, len(t.Path))
+
+	for _, segment := range t.Path {
+		path = append(path, string(segment))
+	}
+
+	return strings.Join(path, pathSeparator)
+}
+
+func isOption(t *types.Si1Type) bool {
+	return t.Def.IsVariant && getPath(t) == optionTypePath
+}
+
+func isNewType(t *types.Si1Type) bool {
+	return t.Def.IsComposite && len(t.Def.Composite.Fields) == 1 && !t.Def.Composite.Fields[0].HasName
+}
+
+func isWellKnown(t *types.Si1Type) bool {
+	_, ok := wellKnownTypes[getPath(t)]
+
+	return ok
+}
+
+// isNamed returns true if a named type is generated in the runtime package for the provided type.
+func isNamed(t *types.Si1Type) bool {
+	switch {
+	case t.Def.IsComposite:
+		return !isWellKnown(t) && !isNewType(t)
+	case t.Def.IsVariant:
+		return !isOption(t)
+	default:
+		return false
+	}
+}
+
+// assignNames assigns a unique name to each type that is generated in the runtime package.
+//
+// The name of a type is based on the last segment of its path. If multiple types have the same name, more segments
+// of their paths are used until the names are unique, or, if the paths are identical, the type IDs are appended.
+func (r *typeRegistry) assignNames() {
+	reservedNames := make(map[string]bool)
+
+	if r.runtimeCallID >= 0 {
+		r.names[r.runtimeCallID] = runtimeCallTypeName
+		reservedNames[runtimeCallTypeName] = true
+	}
+
+	if r.runtimeEventID >= 0 {
+		r.names[r.runtimeEventID] = runtimeEventTypeName
+		reservedNames[runtimeEventTypeName] = true
+	}
+
+	var typeIDs []int64
+
+	for typeID, t := range r.lookup {
+		if _, ok := r.names[typeID]; ok || !isNamed(t) {
+			continue
+		}
+
+		typeIDs = append(typeIDs, typeID)
+	}
+
+	sort.Slice(typeIDs, func(i, j int) bool {
+		return typeIDs[i] < typeIDs[j]
+	})
+
+	depths := make(map[int64]int)
+
+	for _, typeID := range typeIDs {
+		depths[typeID] = 1
+	}
+
+	for {
+		nameGroups := r.getNameGroups(typeIDs, depths)
+
+		changed := false
+
+		for name, group := range nameGroups {
+			if len(group) == 1 && !reservedNames[name] {
+				continue
+			}
+
+			for _, typeID := range group {
+				if depths[typeID] < len(r.lookup[typeID].Path) {
+					depths[typeID]++
+					changed = true
+				}
+			}
+		}
+
+		if !changed {
+			r.storeNames(typeIDs, nameGroups, reservedNames)
+
+			return
+		}
+	}
+}
+
+// storeNames stores the names of the provided name groups, appending the type IDs to the names that are not unique.
+func (r *typeRegistry) storeNames(typeIDs []int64, nameGroups map[string][]int64, reservedNames map[string]bool) {
+	candidates := make(map[int64]string)
+
+	for name, group := range nameGroups {
+		for _, typeID := range group {
+			if len(group) == 1 && !reservedNames[name] {
+				candidates[typeID] = name
+
+				continue
+			}
+
+			candidates[typeID] = name + strconv.FormatInt(typeID, 10)
+		}
+	}
+
+	names := newNameSet()
+
+	for name := range reservedNames {
+		names.add(name)
+	}
+
+	for _, typeID := range typeIDs {
+		r.names[typeID] = names.add(candidates[typeID])
+	}
+}
+
+func (r *typeRegistry) getNameGroups(typeIDs []int64, depths map[int64]int) map[string][]int64 {
+	nameGroups := make(map[string][]int64)
+
+	for _, typeID := range typeIDs {
+		path := r.lookup[typeID].Path
+
+		name := "Type"
+
+		if len(path) > 0 {
+			segments := make([]string, 0, depths[typeID])
+
+			for _, segment := range path[len(path)-depths[typeID]:] {
+				segments = append(segments, string(segment))
+			}
+
+			name = exportedName(strings.Join(segments, "_"))
+		}
+
+		nameGroups[name] = append(nameGroups[name], typeID)
+	}
+
+	return nameGroups
+}
+
+// getValueEdges returns the IDs of the types that are contained by value in the provided type, which means that
+// sequences are excluded since their items are stored in a slice.
+func getValueEdges(t *types.Si1Type) []int64 {
+	var res []int64
+
+	switch {
+	case t.Def.IsComposite:
+		for _, field := range t.Def.Composite.Fields {
+			res = append(res, field.Type.Int64())
+		}
+	case t.Def.IsVariant:
+		for _, variant := range t.Def.Variant.Variants {
+			for _, field := range variant.Fields {
+				res = append(res, field.Type.Int64())
+			}
+		}
+	case t.Def.IsArray:
+		res = append(res, t.Def.Array.Type.Int64())
+	case t.Def.IsTuple:
+		for _, item := range t.Def.Tuple {
+			res = append(res, item.Int64())
+		}
+	}
+
+	return res
+}
+
+func (r *typeRegistry) storeReverseValueEdges() {
+	r.reverseValueEdges = make(map[int64][]int64)
+
+	for typeID, t := range r.lookup {
+		if isWellKnown(t) {
+			continue
+		}
+
+		for _, edge := range getValueEdges(t) {
+			r.reverseValueEdges[edge] = append(r.reverseValueEdges[edge], typeID)
+		}
+	}
+}
+
+// containsByValue returns true if the provided type contains the target type by value, either directly or
+// via other types.
+//
+// A field of a named type is generated as a pointer if its type contains the named type by value, since
+// Go does not allow recursive types of infinite size.
+func (r *typeRegistry) containsByValue(typeID int64, targetID int64) bool {
+	containers, ok := r.valueReachCache[targetID]
+
+	if !ok {
+		containers = make(map[int64]bool)
+
+		queue := []int64{targetID}
+
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+
+			for _, container := range r.reverseValueEdges[current] {
+				if containers[container] {
+					continue
+				}
+
+				containers[container] = true
+
+				queue = append(queue, container)
+			}
+		}
+
+		r.valueReachCache[targetID] = containers
+	}
+
+	return typeID == targetID || containers[typeID]
+}
+
+// typeExpr returns the Go type expression for the provided type, as used in the provided file.
+func (r *typeRegistry) typeExpr(file *sourceFile, typeID int64) (string, error) {
+	t, err := r.getType(typeID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if name, ok := r.names[typeID]; ok {
+		if file.packageName == runtimePackageName {
+			return name, nil
+		}
+
+		file.use(file.runtimeImportPath)
+
+		return runtimePackageName + pathSeparator + name, nil
+	}
+
+	def := t.Def
+
+	switch {
+	case def.IsComposite:
+		if wellKnownType, ok := wellKnownTypes[getPath(t)]; ok {
+			file.use(typesImportPath)
+
+			return wellKnownType, nil
+		}
+
+		// Composites with a single unnamed field are replaced by their field.
+		return r.typeExpr(file, def.Composite.Fields[0].Type.Int64())
+	case def.IsVariant:
+		return r.optionTypeExpr(file, t)
+	case def.IsSequence:
+		if r.isU8(def.Sequence.Type.Int64()) {
+			file.use(typesImportPath)
+
+			return "types.Bytes", nil
+		}
+
+		itemExpr, err := r.typeExpr(file, def.Sequence.Type.Int64())
+
+		if err != nil {
+			return "", err
+		}
+
+		return "[]" + itemExpr, nil
+	case def.IsArray:
+		if r.isU8(def.Array.Type.Int64()) {
+			return fmt.Sprintf("[%d]byte", def.Array.Len), nil
+		}
+
+		itemExpr, err := r.typeExpr(file, def.Array.Type.Int64())
+
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("[%d]%s", def.Array.Len, itemExpr), nil
+	case def.IsTuple:
+		return r.tupleTypeExpr(file, def.Tuple)
+	case def.IsPrimitive:
+		primitiveType, ok := primitiveTypes[byte(def.Primitive.Si0TypeDefPrimitive)]
+
+		if !ok {
+			return "", ErrTypeNotSupported.WithMsg("primitive type %d", def.Primitive.Si0TypeDefPrimitive)
+		}
+
+		file.use(typesImportPath)
+
+		return primitiveType, nil
+	case def.IsCompact:
+		file.use(typesImportPath)
+
+		return "types.UCompact", nil
+	case def.IsBitSequence:
+		file.use(typesImportPath)
+
+		return "types.BitVec", nil
+	default:
+		return "", ErrTypeNotSupported.WithMsg("type ID %d", typeID)
+	}
+}
+
+func (r *typeRegistry) optionTypeExpr(file *sourceFile, t *types.Si1Type) (string, error) {
+	for _, variant := range t.Def.Variant.Variants {
+		if len(variant.Fields) != 1 {
+			continue
+		}
+
+		valueExpr, err := r.typeExpr(file, variant.Fields[0].Type.Int64())
+
+		if err != nil {
+			return "", err
+		}
+
+		file.use(typesImportPath)
+
+		return "types.Option[" + valueExpr + "]", nil
+	}
+
+	return "", ErrTypeNotSupported.WithMsg("option without value")
+}
+
+// tupleTypeExpr returns an anonymous struct with a field for each item of the tuple.
+func (r *typeRegistry) tupleTypeExpr(file *sourceFile, tuple types.Si1TypeDefTuple) (string, error) {
+	fields := make([]string, 0, len(tuple))
+
+	for i, item := range tuple {
+		itemExpr, err := r.typeExpr(file, item.Int64())
+
+		if err != nil {
+			return "", err
+		}
+
+		fields = append(fields, fmt.Sprintf("Field%d %s", i, itemExpr))
+	}
+
+	return "struct{ " + strings.Join(fields, "; ") + " }", nil
+}
+
+func (r *typeRegistry) isU8(typeID int64) bool {
+	t, ok := r.lookup[typeID]
+
+	return ok && t.Def.IsPrimitive && byte(t.Def.Primitive.Si0TypeDefPrimitive) == types.IsU8
+}
+
+// getNamedTypeIDs returns the IDs of the types that are generated in the runtime package, sorted by their names.
+func (r *typeRegistry) getNamedTypeIDs() []int64 {
+	typeIDs := make([]int64, 0, len(r.names))
+
+	for typeID := range r.names {
+		typeIDs = append(typeIDs, typeID)
+	}
+
+	sort.Slice(typeIDs, func(i, j int) bool {
+		return r.names[typeIDs[i]] < r.names[typeIDs[j]]
+	})
+
+	return typeIDs
+}
@@ -0,0 +1,85 @@
+package compat
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Binding binds a static Go type to an item of the metadata, so that the Go type can be checked against the shape
+// of the item in a new metadata version.
+type Binding struct {
+	Target Target
+
+	// Pallet is the name of the pallet that holds the item, it is empty for types.
+	Pallet string
+	// Name is the name of the item, it is the path of the type for types and empty for errors.
+	Name string
+
+	// GoType is the Go type that is bound to the item.
+	GoType reflect.Type
+}
+
+// NewCallBinding binds the provided value, a struct that holds the arguments of the call in order, to a call.
+func NewCallBinding(pallet, call string, value any) Binding {
+	return newBinding(TargetCall, pallet, call, value)
+}
+
+// NewEventBinding binds the provided value, a struct that holds the fields of the event in order, to an event.
+//
+// A leading types.Phase field and a trailing []types.Hash field, which hold the phase and the topics of the
+// event record, are ignored.
+func NewEventBinding(pallet, event string, value any) Binding {
+	return newBinding(TargetEvent, pallet, event, value)
+}
+
+// NewErrorBinding binds the provided value to the error enum of a pallet.
+func NewErrorBinding(pallet string, value any) Binding {
+	return newBinding(TargetError, pallet, "", value)
+}
+
+// NewStorageBinding binds the provided value to the value of a storage entry.
+func NewStorageBinding(pallet, entry string, value any) Binding {
+	return newBinding(TargetStorage, pallet, entry, value)
+}
+
+// NewConstantBinding binds the provided value to a constant.
+func NewConstantBinding(pallet, constant string, value any) Binding {
+	return newBinding(TargetConstant, pallet, constant, value)
+}
+
+// NewTypeBinding binds the provided value to the type with the provided path, such as
+// sp_runtime::multiaddress::MultiAddress.
+func NewTypeBinding(path string, value any) Binding {
+	return newBinding(TargetType, "", path, value)
+}
+
+func newBinding(target Target, pallet, name string, value any) Binding {
+	return Binding{
+		Target: target,
+		Pallet: pallet,
+		Name:   name,
+		GoType: reflect.TypeOf(value),
+	}
+}
+
+// QualifiedName returns the name of the bound item prefixed by the name of its pallet, such as Balances.transfer.
+func (b Binding) QualifiedName() string {
+	return Change{Pallet: b.Pallet, Name: b.Name}.QualifiedName()
+}
+
+// Mismatch holds the differences found between the Go type of a binding and the bound item.
+type Mismatch struct {
+	Binding Binding
+	Details []string
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf(
+		"%s %s does not match %s (%s)",
+		m.Binding.Target,
+		m.Binding.QualifiedName(),
+		m.Binding.GoType,
+		strings.Join(m.Details, "; "),
+	)
+}
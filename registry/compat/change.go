@@ -0,0 +1,127 @@
+package compat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangeKind is the kind of change found between two metadata versions.
+type ChangeKind string
+
+const (
+	// ChangeKindAdded is used for items that are only found in the new metadata.
+	ChangeKindAdded ChangeKind = "added"
+	// ChangeKindRemoved is used for items that are only found in the old metadata.
+	ChangeKindRemoved ChangeKind = "removed"
+	// ChangeKindFieldChanged is used for calls, events and errors whose fields changed shape.
+	ChangeKindFieldChanged ChangeKind = "field changed"
+	// ChangeKindIndexChanged is used for pallets, calls, events and errors whose index changed.
+	ChangeKindIndexChanged ChangeKind = "index changed"
+	// ChangeKindHasherChanged is used for storage entries whose key hashers changed.
+	ChangeKindHasherChanged ChangeKind = "hasher changed"
+	// ChangeKindTypeChanged is used for storage entries, constants and types whose type changed shape.
+	ChangeKindTypeChanged ChangeKind = "type changed"
+)
+
+// Target is the kind of metadata item that a change refers to.
+type Target string
+
+const (
+	TargetPallet   Target = "pallet"
+	TargetCall     Target = "call"
+	TargetEvent    Target = "event"
+	TargetError    Target = "error"
+	TargetStorage  Target = "storage"
+	TargetConstant Target = "constant"
+	TargetType     Target = "type"
+)
+
+// Change holds the details of a change found between two metadata versions.
+type Change struct {
+	Kind   ChangeKind
+	Target Target
+
+	// Pallet is the name of the pallet that holds the item, it is empty for types.
+	Pallet string
+	// Name is the name of the item, it is the path of the type for types and empty for pallets.
+	Name string
+
+	// Details describes the differences found at the portable type level.
+	Details []string
+}
+
+// QualifiedName returns the name of the item prefixed by the name of its pallet, such as Balances.transfer.
+func (c Change) QualifiedName() string {
+	switch {
+	case c.Pallet == "":
+		return c.Name
+	case c.Name == "":
+		return c.Pallet
+	default:
+		return c.Pallet + "." + c.Name
+	}
+}
+
+func (c Change) String() string {
+	res := fmt.Sprintf("%s %s: %s", c.Target, c.QualifiedName(), c.Kind)
+
+	if len(c.Details) == 0 {
+		return res
+	}
+
+	return res + " (" + strings.Join(c.Details, "; ") + ")"
+}
+
+// IsBreaking returns true if the change can break code that relies on the old metadata.
+func (c Change) IsBreaking() bool {
+	return c.Kind != ChangeKindAdded
+}
+
+// Report holds the changes found between two metadata versions.
+type Report struct {
+	Changes []Change
+}
+
+// IsCompatible returns true if the report does not hold any breaking changes.
+func (r *Report) IsCompatible() bool {
+	return len(r.BreakingChanges()) == 0
+}
+
+// BreakingChanges returns the changes that can break code that relies on the old metadata.
+func (r *Report) BreakingChanges() []Change {
+	var res []Change
+
+	for _, change := range r.Changes {
+		if change.IsBreaking() {
+			res = append(res, change)
+		}
+	}
+
+	return res
+}
+
+// Filter returns the changes of the provided kind for the provided target.
+func (r *Report) Filter(kind ChangeKind, target Target) []Change {
+	var res []Change
+
+	for _, change := range r.Changes {
+		if change.Kind == kind && change.Target == target {
+			res = append(res, change)
+		}
+	}
+
+	return res
+}
+
+// Find returns the changes for the item with the provided qualified name, such as Balances.transfer.
+func (r *Report) Find(target Target, qualifiedName string) []Change {
+	var res []Change
+
+	for _, change := range r.Changes {
+		if change.Target == target && change.QualifiedName() == qualifiedName {
+			res = append(res, change)
+		}
+	}
+
+	return res
+}
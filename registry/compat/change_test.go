@@ -0,0 +1,60 @@
+package compat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChange_String(t *testing.T) {
+	change := Change{
+		Kind:    ChangeKindFieldChanged,
+		Target:  TargetCall,
+		Pallet:  "Balances",
+		Name:    "transfer",
+		Details: []string{"value: changed from u32 to u64", "dest: renamed to target"},
+	}
+
+	assert.Equal(
+		t,
+		"call Balances.transfer: field changed (value: changed from u32 to u64; dest: renamed to target)",
+		change.String(),
+	)
+
+	change = Change{
+		Kind:   ChangeKindRemoved,
+		Target: TargetPallet,
+		Pallet: "Balances",
+	}
+
+	assert.Equal(t, "pallet Balances: removed", change.String())
+
+	change = Change{
+		Kind:   ChangeKindAdded,
+		Target: TargetType,
+		Name:   "sp_runtime::DispatchError",
+	}
+
+	assert.Equal(t, "type sp_runtime::DispatchError: added", change.String())
+}
+
+func TestReport(t *testing.T) {
+	added := Change{Kind: ChangeKindAdded, Target: TargetCall, Pallet: "Balances", Name: "transfer_all"}
+	removed := Change{Kind: ChangeKindRemoved, Target: TargetCall, Pallet: "Balances", Name: "transfer"}
+	hasherChanged := Change{Kind: ChangeKindHasherChanged, Target: TargetStorage, Pallet: "System", Name: "Account"}
+	typeChanged := Change{Kind: ChangeKindTypeChanged, Target: TargetStorage, Pallet: "System", Name: "Account"}
+
+	report := &Report{Changes: []Change{added}}
+
+	assert.True(t, report.IsCompatible())
+	assert.Empty(t, report.BreakingChanges())
+
+	report = &Report{Changes: []Change{added, removed, hasherChanged, typeChanged}}
+
+	assert.False(t, report.IsCompatible())
+	assert.Equal(t, []Change{removed, hasherChanged, typeChanged}, report.BreakingChanges())
+	assert.Equal(t, []Change{removed}, report.Filter(ChangeKindRemoved, TargetCall))
+	assert.Empty(t, report.Filter(ChangeKindRemoved, TargetStorage))
+	assert.Equal(t, []Change{hasherChanged, typeChanged}, report.Find(TargetStorage, "System.Account"))
+	assert.Empty(t, report.Find(TargetCall, "System.Account"))
+}
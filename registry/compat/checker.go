@@ -0,0 +1,653 @@
+package compat
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+//go:generate mockery --name Checker --structname CheckerMock --filename checker_mock.go --inpackage
+
+// Checker is the interface used for checking the compatibility of code that relies on a metadata version,
+// such as before a runtime upgrade.
+type Checker interface {
+	// Diff returns the changes of the pallets and types found between the old and the new metadata.
+	Diff(oldMeta, newMeta *types.Metadata) (*Report, error)
+	// CheckBindings returns the bindings whose Go types do not match the provided metadata.
+	CheckBindings(meta *types.Metadata, bindings ...Binding) ([]Mismatch, error)
+}
+
+// checker implements the Checker interface.
+type checker struct{}
+
+// NewChecker creates a new Checker.
+func NewChecker() Checker {
+	return &checker{}
+}
+
+// Diff returns the changes of the pallets and types found between the old and the new metadata.
+//
+// Calls, events, errors, storage entries and constants are matched by name within their pallet, and named types
+// are matched by their path. Types that share a path, such as the instances of a generic type, are only compared
+// as part of the items that use them.
+func (c *checker) Diff(oldMeta, newMeta *types.Metadata) (*Report, error) {
+	oldLookup, err := getLookup(oldMeta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	newLookup, err := getLookup(newMeta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d := &metadataDiff{
+		oldLookup:  oldLookup,
+		newLookup:  newLookup,
+		comparator: newTypeComparator(oldLookup, newLookup),
+		report:     &Report{},
+	}
+
+	if err := d.diffPallets(oldMeta.AsMetadataV14.Pallets, newMeta.AsMetadataV14.Pallets); err != nil {
+		return nil, err
+	}
+
+	if err := d.diffTypes(); err != nil {
+		return nil, err
+	}
+
+	return d.report, nil
+}
+
+// CheckBindings returns the bindings whose Go types do not match the provided metadata.
+//
+// Bindings whose item is not found in the metadata are returned as mismatches as well.
+func (c *checker) CheckBindings(meta *types.Metadata, bindings ...Binding) ([]Mismatch, error) {
+	lookup, err := getLookup(meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	goTypeChecker := newGoTypeChecker(lookup)
+	pathTypes := getUniquePathTypes(lookup)
+
+	var res []Mismatch
+
+	for _, binding := range bindings {
+		if binding.GoType == nil {
+			return nil, ErrInvalidBinding.WithMsg("%s %s has no Go type", binding.Target, binding.QualifiedName())
+		}
+
+		diffs, err := checkBinding(meta, lookup, pathTypes, goTypeChecker, binding)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(diffs) > 0 {
+			res = append(res, Mismatch{
+				Binding: binding,
+				Details: diffsToStrings(diffs),
+			})
+		}
+	}
+
+	return res, nil
+}
+
+func checkBinding(
+	meta *types.Metadata,
+	lookup map[int64]*types.Si1Type,
+	pathTypes map[string]int64,
+	goTypeChecker *goTypeChecker,
+	binding Binding,
+) ([]typeDiff, error) {
+	notFound := []typeDiff{{message: fmt.Sprintf("%s not found in metadata", binding.Target)}}
+
+	if binding.Target == TargetType {
+		typeID, ok := pathTypes[binding.Name]
+
+		if !ok {
+			return notFound, nil
+		}
+
+		return goTypeChecker.check(binding.GoType, typeID)
+	}
+
+	pallet, ok := findPallet(meta, binding.Pallet)
+
+	if !ok {
+		return []typeDiff{{message: "pallet not found in metadata"}}, nil
+	}
+
+	switch binding.Target {
+	case TargetCall, TargetEvent:
+		hasItems, typeID := pallet.HasCalls, pallet.Calls.Type
+
+		if binding.Target == TargetEvent {
+			hasItems, typeID = pallet.HasEvents, pallet.Events.Type
+		}
+
+		variants, err := getPalletVariants(lookup, hasItems, typeID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, variant := range variants {
+			if string(variant.Name) != binding.Name {
+				continue
+			}
+
+			goFields, ok := getGoFields(binding.GoType)
+
+			if binding.GoType.Kind() != reflect.Struct || !ok {
+				return nil, ErrInvalidBinding.WithMsg("%s is not a struct with exported fields", binding.GoType)
+			}
+
+			if binding.Target == TargetEvent {
+				goFields = getEventGoFields(goFields)
+			}
+
+			return goTypeChecker.checkFields(binding.GoType, goFields, variant.Fields)
+		}
+
+		return notFound, nil
+	case TargetError:
+		if !pallet.HasErrors {
+			return notFound, nil
+		}
+
+		return goTypeChecker.check(binding.GoType, pallet.Errors.Type.Int64())
+	case TargetStorage:
+		for _, entry := range pallet.Storage.Items {
+			if string(entry.Name) != binding.Name {
+				continue
+			}
+
+			if entry.Type.IsMap {
+				return goTypeChecker.check(binding.GoType, entry.Type.AsMap.Value.Int64())
+			}
+
+			return goTypeChecker.check(binding.GoType, entry.Type.AsPlainType.Int64())
+		}
+
+		return notFound, nil
+	case TargetConstant:
+		for _, constant := range pallet.Constants {
+			if string(constant.Name) == binding.Name {
+				return goTypeChecker.check(binding.GoType, constant.Type.Int64())
+			}
+		}
+
+		return notFound, nil
+	default:
+		return nil, ErrInvalidBinding.WithMsg("target '%s' not supported", binding.Target)
+	}
+}
+
+func findPallet(meta *types.Metadata, palletName string) (types.PalletMetadataV14, bool) {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) == palletName {
+			return pallet, true
+		}
+	}
+
+	return types.PalletMetadataV14{}, false
+}
+
+// metadataDiff holds the state used while diffing two metadata versions.
+type metadataDiff struct {
+	oldLookup  map[int64]*types.Si1Type
+	newLookup  map[int64]*types.Si1Type
+	comparator *typeComparator
+	report     *Report
+}
+
+func (d *metadataDiff) addChange(kind ChangeKind, target Target, pallet, name string, details ...string) {
+	d.report.Changes = append(d.report.Changes, Change{
+		Kind:    kind,
+		Target:  target,
+		Pallet:  pallet,
+		Name:    name,
+		Details: details,
+	})
+}
+
+func (d *metadataDiff) diffPallets(oldPallets, newPallets []types.PalletMetadataV14) error {
+	oldPalletsByName := make(map[string]types.PalletMetadataV14, len(oldPallets))
+
+	for _, pallet := range oldPallets {
+		oldPalletsByName[string(pallet.Name)] = pallet
+	}
+
+	newPalletNames := make(map[string]struct{}, len(newPallets))
+
+	for _, newPallet := range newPallets {
+		name := string(newPallet.Name)
+
+		newPalletNames[name] = struct{}{}
+
+		oldPallet, ok := oldPalletsByName[name]
+
+		if !ok {
+			d.addChange(ChangeKindAdded, TargetPallet, name, "")
+
+			continue
+		}
+
+		if oldPallet.Index != newPallet.Index {
+			d.addChange(
+				ChangeKindIndexChanged,
+				TargetPallet,
+				name,
+				"",
+				fmt.Sprintf("index changed from %d to %d", oldPallet.Index, newPallet.Index),
+			)
+		}
+
+		if err := d.diffPallet(oldPallet, newPallet); err != nil {
+			return err
+		}
+	}
+
+	for _, oldPallet := range oldPallets {
+		if _, ok := newPalletNames[string(oldPallet.Name)]; !ok {
+			d.addChange(ChangeKindRemoved, TargetPallet, string(oldPallet.Name), "")
+		}
+	}
+
+	return nil
+}
+
+func (d *metadataDiff) diffPallet(oldPallet, newPallet types.PalletMetadataV14) error {
+	palletName := string(newPallet.Name)
+
+	variantItems := []struct {
+		target  Target
+		hasOld  bool
+		oldType types.Si1LookupTypeID
+		hasNew  bool
+		newType types.Si1LookupTypeID
+	}{
+		{TargetCall, oldPallet.HasCalls, oldPallet.Calls.Type, newPallet.HasCalls, newPallet.Calls.Type},
+		{TargetEvent, oldPallet.HasEvents, oldPallet.Events.Type, newPallet.HasEvents, newPallet.Events.Type},
+		{TargetError, oldPallet.HasErrors, oldPallet.Errors.Type, newPallet.HasErrors, newPallet.Errors.Type},
+	}
+
+	for _, item := range variantItems {
+		oldVariants, err := getPalletVariants(d.oldLookup, item.hasOld, item.oldType)
+
+		if err != nil {
+			return err
+		}
+
+		newVariants, err := getPalletVariants(d.newLookup, item.hasNew, item.newType)
+
+		if err != nil {
+			return err
+		}
+
+		if err := d.diffVariantItems(item.target, palletName, oldVariants, newVariants); err != nil {
+			return err
+		}
+	}
+
+	if err := d.diffStorage(palletName, oldPallet.Storage.Items, newPallet.Storage.Items); err != nil {
+		return err
+	}
+
+	return d.diffConstants(palletName, oldPallet.Constants, newPallet.Constants)
+}
+
+// getPalletVariants returns the variants of the call, event or error enum of a pallet, where each variant is an item.
+func getPalletVariants(
+	lookup map[int64]*types.Si1Type,
+	hasItems bool,
+	typeID types.Si1LookupTypeID,
+) ([]types.Si1Variant, error) {
+	if !hasItems {
+		return nil, nil
+	}
+
+	t, err := getType(lookup, typeID.Int64())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.Def.IsVariant {
+		return nil, ErrTypeNotVariant.WithMsg("type ID %d", typeID.Int64())
+	}
+
+	return t.Def.Variant.Variants, nil
+}
+
+// diffVariantItems diffs the calls, events or errors of a pallet.
+func (d *metadataDiff) diffVariantItems(
+	target Target,
+	palletName string,
+	oldVariants []types.Si1Variant,
+	newVariants []types.Si1Variant,
+) error {
+	oldVariantsByName := make(map[string]types.Si1Variant, len(oldVariants))
+
+	for _, variant := range oldVariants {
+		oldVariantsByName[string(variant.Name)] = variant
+	}
+
+	newVariantNames := make(map[string]struct{}, len(newVariants))
+
+	for _, newVariant := range newVariants {
+		name := string(newVariant.Name)
+
+		newVariantNames[name] = struct{}{}
+
+		oldVariant, ok := oldVariantsByName[name]
+
+		if !ok {
+			d.addChange(ChangeKindAdded, target, palletName, name)
+
+			continue
+		}
+
+		if oldVariant.Index != newVariant.Index {
+			d.addChange(
+				ChangeKindIndexChanged,
+				target,
+				palletName,
+				name,
+				fmt.Sprintf("index changed from %d to %d", oldVariant.Index, newVariant.Index),
+			)
+		}
+
+		diffs, err := d.comparator.compareFields(oldVariant.Fields, newVariant.Fields)
+
+		if err != nil {
+			return err
+		}
+
+		if len(diffs) > 0 {
+			d.addChange(ChangeKindFieldChanged, target, palletName, name, diffsToStrings(diffs)...)
+		}
+	}
+
+	for _, oldVariant := range oldVariants {
+		if _, ok := newVariantNames[string(oldVariant.Name)]; !ok {
+			d.addChange(ChangeKindRemoved, target, palletName, string(oldVariant.Name))
+		}
+	}
+
+	return nil
+}
+
+func (d *metadataDiff) diffStorage(palletName string, oldEntries, newEntries []types.StorageEntryMetadataV14) error {
+	oldEntriesByName := make(map[string]types.StorageEntryMetadataV14, len(oldEntries))
+
+	for _, entry := range oldEntries {
+		oldEntriesByName[string(entry.Name)] = entry
+	}
+
+	newEntryNames := make(map[string]struct{}, len(newEntries))
+
+	for _, newEntry := range newEntries {
+		name := string(newEntry.Name)
+
+		newEntryNames[name] = struct{}{}
+
+		oldEntry, ok := oldEntriesByName[name]
+
+		if !ok {
+			d.addChange(ChangeKindAdded, TargetStorage, palletName, name)
+
+			continue
+		}
+
+		if err := d.diffStorageEntry(palletName, oldEntry, newEntry); err != nil {
+			return err
+		}
+	}
+
+	for _, oldEntry := range oldEntries {
+		if _, ok := newEntryNames[string(oldEntry.Name)]; !ok {
+			d.addChange(ChangeKindRemoved, TargetStorage, palletName, string(oldEntry.Name))
+		}
+	}
+
+	return nil
+}
+
+func (d *metadataDiff) diffStorageEntry(palletName string, oldEntry, newEntry types.StorageEntryMetadataV14) error {
+	name := string(newEntry.Name)
+
+	var diffs []typeDiff
+
+	oldModifier := getModifierName(oldEntry.Modifier)
+	newModifier := getModifierName(newEntry.Modifier)
+
+	if oldModifier != newModifier {
+		diffs = append(diffs, typeDiff{message: fmt.Sprintf("modifier changed from %s to %s", oldModifier, newModifier)})
+	}
+
+	oldType, newType := oldEntry.Type, newEntry.Type
+
+	switch {
+	case oldType.IsPlainType && newType.IsPlainType:
+		valueDiffs, err := d.comparator.compare(oldType.AsPlainType.Int64(), newType.AsPlainType.Int64())
+
+		if err != nil {
+			return err
+		}
+
+		diffs = append(diffs, prefixDiffs("value", valueDiffs)...)
+	case oldType.IsMap && newType.IsMap:
+		oldHashers := getHasherNames(oldType.AsMap.Hashers)
+		newHashers := getHasherNames(newType.AsMap.Hashers)
+
+		if oldHashers != newHashers {
+			d.addChange(
+				ChangeKindHasherChanged,
+				TargetStorage,
+				palletName,
+				name,
+				fmt.Sprintf("hashers changed from %s to %s", oldHashers, newHashers),
+			)
+		}
+
+		keyDiffs, err := d.comparator.compare(oldType.AsMap.Key.Int64(), newType.AsMap.Key.Int64())
+
+		if err != nil {
+			return err
+		}
+
+		valueDiffs, err := d.comparator.compare(oldType.AsMap.Value.Int64(), newType.AsMap.Value.Int64())
+
+		if err != nil {
+			return err
+		}
+
+		diffs = append(diffs, prefixDiffs("key", keyDiffs)...)
+		diffs = append(diffs, prefixDiffs("value", valueDiffs)...)
+	default:
+		diffs = append(diffs, typeDiff{
+			message: fmt.Sprintf("changed from %s to %s", getStorageKind(oldType), getStorageKind(newType)),
+		})
+	}
+
+	if len(diffs) > 0 {
+		d.addChange(ChangeKindTypeChanged, TargetStorage, palletName, name, diffsToStrings(diffs)...)
+	}
+
+	return nil
+}
+
+func (d *metadataDiff) diffConstants(palletName string, oldConstants, newConstants []types.ConstantMetadataV14) error {
+	oldConstantsByName := make(map[string]types.ConstantMetadataV14, len(oldConstants))
+
+	for _, constant := range oldConstants {
+		oldConstantsByName[string(constant.Name)] = constant
+	}
+
+	newConstantNames := make(map[string]struct{}, len(newConstants))
+
+	for _, newConstant := range newConstants {
+		name := string(newConstant.Name)
+
+		newConstantNames[name] = struct{}{}
+
+		oldConstant, ok := oldConstantsByName[name]
+
+		if !ok {
+			d.addChange(ChangeKindAdded, TargetConstant, palletName, name)
+
+			continue
+		}
+
+		diffs, err := d.comparator.compare(oldConstant.Type.Int64(), newConstant.Type.Int64())
+
+		if err != nil {
+			return err
+		}
+
+		if len(diffs) > 0 {
+			d.addChange(ChangeKindTypeChanged, TargetConstant, palletName, name, diffsToStrings(diffs)...)
+		}
+	}
+
+	for _, oldConstant := range oldConstants {
+		if _, ok := newConstantNames[string(oldConstant.Name)]; !ok {
+			d.addChange(ChangeKindRemoved, TargetConstant, palletName, string(oldConstant.Name))
+		}
+	}
+
+	return nil
+}
+
+// diffTypes diffs the named types whose path is unique in both metadata versions.
+func (d *metadataDiff) diffTypes() error {
+	oldTypes := getUniquePathTypes(d.oldLookup)
+	newTypes := getUniquePathTypes(d.newLookup)
+
+	paths := make([]string, 0, len(oldTypes)+len(newTypes))
+
+	for path := range oldTypes {
+		paths = append(paths, path)
+	}
+
+	for path := range newTypes {
+		if _, ok := oldTypes[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		oldTypeID, inOld := oldTypes[path]
+		newTypeID, inNew := newTypes[path]
+
+		switch {
+		case !inOld:
+			d.addChange(ChangeKindAdded, TargetType, "", path)
+		case !inNew:
+			d.addChange(ChangeKindRemoved, TargetType, "", path)
+		default:
+			diffs, err := d.comparator.compare(oldTypeID, newTypeID)
+
+			if err != nil {
+				return err
+			}
+
+			if len(diffs) > 0 {
+				d.addChange(ChangeKindTypeChanged, TargetType, "", path, diffsToStrings(diffs)...)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getUniquePathTypes returns the IDs of the types that have a path which is not shared with other types,
+// mapped by their paths.
+func getUniquePathTypes(lookup map[int64]*types.Si1Type) map[string]int64 {
+	res := make(map[string]int64)
+	duplicates := make(map[string]struct{})
+
+	for typeID, t := range lookup {
+		path := getPath(t)
+
+		if path == "" {
+			continue
+		}
+
+		if _, ok := res[path]; ok {
+			duplicates[path] = struct{}{}
+		}
+
+		res[path] = typeID
+	}
+
+	for path := range duplicates {
+		delete(res, path)
+	}
+
+	return res
+}
+
+func getModifierName(modifier types.StorageFunctionModifierV0) string {
+	switch {
+	case modifier.IsOptional:
+		return "Optional"
+	case modifier.IsDefault:
+		return "Default"
+	case modifier.IsRequired:
+		return "Required"
+	default:
+		return "unknown"
+	}
+}
+
+func getStorageKind(entryType types.StorageEntryTypeV14) string {
+	if entryType.IsMap {
+		return fmt.Sprintf("map with hashers %s", getHasherNames(entryType.AsMap.Hashers))
+	}
+
+	return "plain"
+}
+
+func getHasherNames(hashers []types.StorageHasherV10) string {
+	names := make([]string, 0, len(hashers))
+
+	for _, hasher := range hashers {
+		names = append(names, getHasherName(hasher))
+	}
+
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+func getHasherName(hasher types.StorageHasherV10) string {
+	switch {
+	case hasher.IsBlake2_128:
+		return "Blake2_128"
+	case hasher.IsBlake2_256:
+		return "Blake2_256"
+	case hasher.IsBlake2_128Concat:
+		return "Blake2_128Concat"
+	case hasher.IsTwox128:
+		return "Twox128"
+	case hasher.IsTwox256:
+		return "Twox256"
+	case hasher.IsTwox64Concat:
+		return "Twox64Concat"
+	case hasher.IsIdentity:
+		return "Identity"
+	default:
+		return "unknown"
+	}
+}
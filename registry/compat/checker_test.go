@@ -0,0 +1,517 @@
+package compat
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Diff_SameMetadata(t *testing.T) {
+	var tests = []struct {
+		Chain       string
+		MetadataHex string
+	}{
+		{
+			Chain:       "centrifuge",
+			MetadataHex: test.CentrifugeMetadataHex,
+		},
+		{
+			Chain:       "polkadot",
+			MetadataHex: test.PolkadotMetadataHex,
+		},
+		{
+			Chain:       "acala",
+			MetadataHex: test.AcalaMetaHex,
+		},
+		{
+			Chain:       "statemint",
+			MetadataHex: test.StatemintMetaHex,
+		},
+		{
+			Chain:       "moonbeam",
+			MetadataHex: test.MoonbeamMetaHex,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Chain, func(t *testing.T) {
+			report, err := NewChecker().Diff(
+				getTestMetadata(t, test.MetadataHex),
+				getTestMetadata(t, test.MetadataHex),
+			)
+			assert.NoError(t, err)
+			assert.Empty(t, report.Changes)
+			assert.True(t, report.IsCompatible())
+		})
+	}
+}
+
+func TestChecker_Diff_DifferentChains(t *testing.T) {
+	report, err := NewChecker().Diff(
+		getTestMetadata(t, test.PolkadotMetadataHex),
+		getTestMetadata(t, test.StatemintMetaHex),
+	)
+	assert.NoError(t, err)
+	assert.False(t, report.IsCompatible())
+
+	assert.Equal(
+		t,
+		[]Change{
+			{
+				Kind:    ChangeKindIndexChanged,
+				Target:  TargetPallet,
+				Pallet:  "Balances",
+				Details: []string{"index changed from 5 to 10"},
+			},
+		},
+		report.Find(TargetPallet, "Balances"),
+	)
+
+	assert.Equal(
+		t,
+		[]Change{
+			{
+				Kind:   ChangeKindRemoved,
+				Target: TargetPallet,
+				Pallet: "Staking",
+			},
+		},
+		report.Find(TargetPallet, "Staking"),
+	)
+
+	assert.Equal(
+		t,
+		[]Change{
+			{
+				Kind:   ChangeKindAdded,
+				Target: TargetPallet,
+				Pallet: "Assets",
+			},
+		},
+		report.Find(TargetPallet, "Assets"),
+	)
+
+	proxyTypeChanges := report.Find(TargetCall, "Proxy.add_proxy")
+
+	assert.Len(t, proxyTypeChanges, 1)
+	assert.Equal(t, ChangeKindFieldChanged, proxyTypeChanges[0].Kind)
+	assert.Contains(t, proxyTypeChanges[0].Details, "proxy_type.Staking: variant removed")
+	assert.Contains(t, proxyTypeChanges[0].Details, "proxy_type.CancelProxy: index changed from 6 to 2")
+	assert.Contains(t, proxyTypeChanges[0].Details, "proxy_type.Assets: variant added")
+
+	// Calls that only use types with the same shape are not reported.
+	assert.Empty(t, report.Find(TargetCall, "Balances.transfer_keep_alive"))
+	assert.Empty(t, report.Find(TargetStorage, "System.Account"))
+}
+
+func TestChecker_Diff_Changes(t *testing.T) {
+	oldMeta := getTestMetadata(t, test.CentrifugeMetadataHex)
+	newMeta := getTestMetadata(t, test.CentrifugeMetadataHex)
+
+	u32TypeID := getPrimitiveTypeID(t, newMeta, types.IsU32)
+	u64TypeID := getPrimitiveTypeID(t, newMeta, types.IsU64)
+
+	balances := getPallet(t, newMeta, "Balances")
+	balances.Index = 100
+
+	// Calls
+	balancesCalls := getVariants(t, newMeta, balances.Calls.Type)
+
+	var calls []types.Si1Variant
+
+	for _, call := range *balancesCalls {
+		switch call.Name {
+		case "transfer_allow_death":
+			continue
+		case "transfer_keep_alive":
+			call.Fields = append([]types.Si1Field{}, call.Fields...)
+			call.Fields[1].Type = types.NewSi1LookupTypeIDFromUInt(uint64(u32TypeID))
+		case "transfer_all":
+			call.Index = 100
+		}
+
+		calls = append(calls, call)
+	}
+
+	calls = append(calls, types.Si1Variant{Name: "mint", Index: 101})
+
+	*balancesCalls = calls
+
+	// Storage
+	system := getPallet(t, newMeta, "System")
+
+	for i, entry := range system.Storage.Items {
+		switch entry.Name {
+		case "Account":
+			system.Storage.Items[i].Type.AsMap.Hashers = []types.StorageHasherV10{{IsTwox64Concat: true}}
+		case "Number":
+			system.Storage.Items[i].Type.AsPlainType = types.NewSi1LookupTypeIDFromUInt(uint64(u64TypeID))
+			system.Storage.Items[i].Modifier = types.StorageFunctionModifierV0{IsOptional: true}
+		}
+	}
+
+	// Constants
+	for i, constant := range system.Constants {
+		if constant.Name == "BlockHashCount" {
+			system.Constants[i].Type = types.NewSi1LookupTypeIDFromUInt(uint64(u64TypeID))
+		}
+	}
+
+	system.Constants = append(system.Constants[:0:0], system.Constants[1:]...)
+
+	report, err := NewChecker().Diff(oldMeta, newMeta)
+	assert.NoError(t, err)
+	assert.False(t, report.IsCompatible())
+
+	assert.Equal(
+		t,
+		[]Change{
+			{
+				Kind:    ChangeKindIndexChanged,
+				Target:  TargetPallet,
+				Pallet:  "Balances",
+				Details: []string{"index changed from 20 to 100"},
+			},
+		},
+		report.Find(TargetPallet, "Balances"),
+	)
+
+	assert.Equal(
+		t,
+		[]Change{{Kind: ChangeKindRemoved, Target: TargetCall, Pallet: "Balances", Name: "transfer_allow_death"}},
+		report.Find(TargetCall, "Balances.transfer_allow_death"),
+	)
+
+	assert.Equal(
+		t,
+		[]Change{{Kind: ChangeKindAdded, Target: TargetCall, Pallet: "Balances", Name: "mint"}},
+		report.Find(TargetCall, "Balances.mint"),
+	)
+
+	assert.Equal(
+		t,
+		[]Change{
+			{
+				Kind:    ChangeKindFieldChanged,
+				Target:  TargetCall,
+				Pallet:  "Balances",
+				Name:    "transfer_keep_alive",
+				Details: []string{"value: changed from Compact<u128> to u32"},
+			},
+		},
+		report.Find(TargetCall, "Balances.transfer_keep_alive"),
+	)
+
+	assert.Equal(
+		t,
+		[]Change{
+			{
+				Kind:    ChangeKindIndexChanged,
+				Target:  TargetCall,
+				Pallet:  "Balances",
+				Name:    "transfer_all",
+				Details: []string{"index changed from 4 to 100"},
+			},
+		},
+		report.Find(TargetCall, "Balances.transfer_all"),
+	)
+
+	assert.Equal(
+		t,
+		[]Change{
+			{
+				Kind:    ChangeKindHasherChanged,
+				Target:  TargetStorage,
+				Pallet:  "System",
+				Name:    "Account",
+				Details: []string{"hashers changed from [Blake2_128Concat] to [Twox64Concat]"},
+			},
+		},
+		report.Find(TargetStorage, "System.Account"),
+	)
+
+	assert.Equal(
+		t,
+		[]Change{
+			{
+				Kind:   ChangeKindTypeChanged,
+				Target: TargetStorage,
+				Pallet: "System",
+				Name:   "Number",
+				Details: []string{
+					"modifier changed from Default to Optional",
+					"value: changed from u32 to u64",
+				},
+			},
+		},
+		report.Find(TargetStorage, "System.Number"),
+	)
+
+	assert.Equal(
+		t,
+		[]Change{
+			{
+				Kind:    ChangeKindTypeChanged,
+				Target:  TargetConstant,
+				Pallet:  "System",
+				Name:    "BlockHashCount",
+				Details: []string{"changed from u32 to u64"},
+			},
+		},
+		report.Find(TargetConstant, "System.BlockHashCount"),
+	)
+
+	assert.Equal(
+		t,
+		[]Change{{Kind: ChangeKindRemoved, Target: TargetConstant, Pallet: "System", Name: "BlockWeights"}},
+		report.Find(TargetConstant, "System.BlockWeights"),
+	)
+
+	// The call enum of the pallet is reported as a type change as well.
+	callTypeChanges := report.Find(TargetType, "pallet_balances::pallet::Call")
+
+	assert.Len(t, callTypeChanges, 1)
+	assert.Equal(t, ChangeKindTypeChanged, callTypeChanges[0].Kind)
+	assert.Contains(t, callTypeChanges[0].Details, "transfer_allow_death: variant removed")
+	assert.Contains(t, callTypeChanges[0].Details, "transfer_keep_alive.value: changed from Compact<u128> to u32")
+	assert.Contains(t, callTypeChanges[0].Details, "mint: variant added")
+}
+
+func TestChecker_Diff_MetadataVersionNotSupported(t *testing.T) {
+	meta := getTestMetadata(t, test.CentrifugeMetadataHex)
+
+	report, err := NewChecker().Diff(&types.Metadata{Version: 13}, meta)
+	assert.ErrorIs(t, err, ErrMetadataVersionNotSupported)
+	assert.Nil(t, report)
+
+	report, err = NewChecker().Diff(meta, &types.Metadata{Version: 13})
+	assert.ErrorIs(t, err, ErrMetadataVersionNotSupported)
+	assert.Nil(t, report)
+}
+
+func TestChecker_Diff_TypeNotFound(t *testing.T) {
+	oldMeta := getTestMetadata(t, test.CentrifugeMetadataHex)
+	newMeta := getTestMetadata(t, test.CentrifugeMetadataHex)
+
+	system := getPallet(t, newMeta, "System")
+	system.Constants[0].Type = types.NewSi1LookupTypeIDFromUInt(1_000_000)
+
+	report, err := NewChecker().Diff(oldMeta, newMeta)
+	assert.ErrorIs(t, err, ErrTypeNotFound)
+	assert.Nil(t, report)
+}
+
+func TestChecker_Diff_TypeNotVariant(t *testing.T) {
+	oldMeta := getTestMetadata(t, test.CentrifugeMetadataHex)
+	newMeta := getTestMetadata(t, test.CentrifugeMetadataHex)
+
+	system := getPallet(t, newMeta, "System")
+	system.Calls.Type = types.NewSi1LookupTypeIDFromUInt(uint64(getPrimitiveTypeID(t, newMeta, types.IsU32)))
+
+	report, err := NewChecker().Diff(oldMeta, newMeta)
+	assert.ErrorIs(t, err, ErrTypeNotVariant)
+	assert.Nil(t, report)
+}
+
+type testTransferKeepAliveArgs struct {
+	Dest  testMultiAddress
+	Value types.UCompact
+}
+
+type testInvalidTransferKeepAliveArgs struct {
+	Dest  types.AccountID
+	Value types.U128
+	Extra types.U8
+}
+
+type testProxyDefinition struct {
+	Delegate  types.AccountID
+	ProxyType testProxyType
+	Delay     types.U32
+}
+
+type testProxyType uint8
+
+type testMultiAddress struct {
+	IsID        bool
+	AsID        types.AccountID
+	IsIndex     bool
+	AsIndex     types.UCompact
+	IsRaw       bool
+	AsRaw       types.Bytes
+	IsAddress32 bool
+	AsAddress32 [32]byte
+	IsAddress20 bool
+	AsAddress20 [20]byte
+}
+
+type testOutdatedMultiAddress struct {
+	IsID      bool
+	AsID      types.AccountID
+	IsRaw     bool
+	AsRaw     [32]byte
+	IsAddress bool
+	AsAddress types.H160
+}
+
+func TestChecker_CheckBindings(t *testing.T) {
+	meta := getTestMetadata(t, test.CentrifugeMetadataHex)
+
+	mismatches, err := NewChecker().CheckBindings(
+		meta,
+		NewCallBinding("Balances", "transfer_keep_alive", testTransferKeepAliveArgs{}),
+		NewEventBinding("Balances", "Endowed", types.EventBalancesEndowed{}),
+		NewEventBinding("Balances", "Transfer", types.EventBalancesTransfer{}),
+		NewStorageBinding("System", "Account", types.AccountInfo{}),
+		NewStorageBinding("System", "LastRuntimeUpgrade", types.LastRuntimeUpgradeInfo{}),
+		NewStorageBinding("Balances", "TotalIssuance", types.U128{}),
+		NewConstantBinding("System", "BlockHashCount", types.U32(0)),
+		NewConstantBinding("System", "SS58Prefix", types.U16(0)),
+		NewTypeBinding("frame_system::Phase", types.Phase{}),
+		NewTypeBinding("sp_runtime::multiaddress::MultiAddress", testMultiAddress{}),
+		NewTypeBinding("pallet_proxy::ProxyDefinition", testProxyDefinition{}),
+		NewTypeBinding("sp_core::crypto::AccountId32", types.AccountID{}),
+		NewErrorBinding("Balances", types.U8(0)),
+	)
+	assert.NoError(t, err)
+	assert.Empty(t, mismatches)
+}
+
+func TestChecker_CheckBindings_Mismatches(t *testing.T) {
+	meta := getTestMetadata(t, test.CentrifugeMetadataHex)
+
+	callBinding := NewCallBinding("Balances", "transfer_keep_alive", testInvalidTransferKeepAliveArgs{})
+	constantBinding := NewConstantBinding("System", "BlockHashCount", types.U64(0))
+	typeBinding := NewTypeBinding("sp_runtime::multiaddress::MultiAddress", testOutdatedMultiAddress{})
+	callNotFoundBinding := NewCallBinding("Balances", "mint", testTransferKeepAliveArgs{})
+	palletNotFoundBinding := NewStorageBinding("Staking", "Ledger", types.U128{})
+	typeNotFoundBinding := NewTypeBinding("sp_runtime::multiaddress::Address", testMultiAddress{})
+
+	mismatches, err := NewChecker().CheckBindings(
+		meta,
+		callBinding,
+		constantBinding,
+		typeBinding,
+		callNotFoundBinding,
+		palletNotFoundBinding,
+		typeNotFoundBinding,
+	)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]Mismatch{
+			{
+				Binding: callBinding,
+				Details: []string{
+					"expected fields {dest: sp_runtime::multiaddress::MultiAddress, value: Compact<u128>}, " +
+						"got 3 fields in compat.testInvalidTransferKeepAliveArgs",
+				},
+			},
+			{
+				Binding: constantBinding,
+				Details: []string{"expected u32, got types.U64"},
+			},
+			{
+				Binding: typeBinding,
+				Details: []string{
+					"Index: variant not found in compat.testOutdatedMultiAddress",
+					"Raw.0: expected Vec<u8>, got [32]uint8",
+					"Address32: variant not found in compat.testOutdatedMultiAddress",
+					"Address20: variant not found in compat.testOutdatedMultiAddress",
+					"Address: variant not found in metadata",
+				},
+			},
+			{
+				Binding: callNotFoundBinding,
+				Details: []string{"call not found in metadata"},
+			},
+			{
+				Binding: palletNotFoundBinding,
+				Details: []string{"pallet not found in metadata"},
+			},
+			{
+				Binding: typeNotFoundBinding,
+				Details: []string{"type not found in metadata"},
+			},
+		},
+		mismatches,
+	)
+
+	assert.Equal(
+		t,
+		"constant System.BlockHashCount does not match types.U64 (expected u32, got types.U64)",
+		mismatches[1].String(),
+	)
+}
+
+func TestChecker_CheckBindings_InvalidBinding(t *testing.T) {
+	meta := getTestMetadata(t, test.CentrifugeMetadataHex)
+
+	mismatches, err := NewChecker().CheckBindings(meta, NewTypeBinding("frame_system::Phase", nil))
+	assert.ErrorIs(t, err, ErrInvalidBinding)
+	assert.Nil(t, mismatches)
+
+	mismatches, err = NewChecker().CheckBindings(meta, NewCallBinding("Balances", "transfer_all", types.U8(0)))
+	assert.ErrorIs(t, err, ErrInvalidBinding)
+	assert.Nil(t, mismatches)
+
+	mismatches, err = NewChecker().CheckBindings(
+		meta,
+		Binding{Target: TargetPallet, Pallet: "Balances", GoType: reflect.TypeOf(types.U8(0))},
+	)
+	assert.ErrorIs(t, err, ErrInvalidBinding)
+	assert.Nil(t, mismatches)
+}
+
+func TestChecker_CheckBindings_MetadataVersionNotSupported(t *testing.T) {
+	mismatches, err := NewChecker().CheckBindings(&types.Metadata{Version: 13})
+	assert.ErrorIs(t, err, ErrMetadataVersionNotSupported)
+	assert.Nil(t, mismatches)
+}
+
+func getTestMetadata(t *testing.T, metadataHex string) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(metadataHex, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
+
+func getPallet(t *testing.T, meta *types.Metadata, palletName string) *types.PalletMetadataV14 {
+	for i, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) == palletName {
+			return &meta.AsMetadataV14.Pallets[i]
+		}
+	}
+
+	t.Fatalf("pallet %s not found", palletName)
+
+	return nil
+}
+
+func getVariants(t *testing.T, meta *types.Metadata, typeID types.Si1LookupTypeID) *[]types.Si1Variant {
+	typ, ok := meta.AsMetadataV14.EfficientLookup[typeID.Int64()]
+
+	if !ok || !typ.Def.IsVariant {
+		t.Fatalf("variant type %d not found", typeID.Int64())
+	}
+
+	return &typ.Def.Variant.Variants
+}
+
+func getPrimitiveTypeID(t *testing.T, meta *types.Metadata, primitive types.Si0TypeDefPrimitive) int64 {
+	for typeID, typ := range meta.AsMetadataV14.EfficientLookup {
+		if typ.Def.IsPrimitive && typ.Def.Primitive.Si0TypeDefPrimitive == primitive {
+			return typeID
+		}
+	}
+
+	t.Fatalf("primitive type %d not found", primitive)
+
+	return 0
+}
@@ -0,0 +1,118 @@
+// Command gsrpc-compat reports the changes found between two metadata versions using compat.Checker, such as
+// before a runtime upgrade.
+//
+// Each metadata version is either read from a file, which holds the metadata as hex or as raw SCALE bytes,
+// or retrieved from a node when a URL is provided. The command exits with status 1 if breaking changes are found:
+//
+//	go run github.com/centrifuge/go-substrate-rpc-client/v4/registry/compat/cmd/gsrpc-compat \
+//		-old wss://rpc.polkadot.io -new ./new_metadata.hex
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/compat"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var urlPrefixes = []string{"ws://", "wss://", "http://", "https://"}
+
+func main() {
+	oldSource := flag.String("old", "", "file path or node URL of the old metadata")
+	newSource := flag.String("new", "", "file path or node URL of the new metadata")
+	breakingOnly := flag.Bool("breaking", false, "only report breaking changes")
+
+	flag.Parse()
+
+	if *oldSource == "" || *newSource == "" {
+		flag.Usage()
+
+		os.Exit(2)
+	}
+
+	oldMeta, err := getMetadata(*oldSource)
+
+	if err != nil {
+		log.Fatalf("Couldn't get old metadata: %s", err)
+	}
+
+	newMeta, err := getMetadata(*newSource)
+
+	if err != nil {
+		log.Fatalf("Couldn't get new metadata: %s", err)
+	}
+
+	report, err := compat.NewChecker().Diff(oldMeta, newMeta)
+
+	if err != nil {
+		log.Fatalf("Couldn't diff metadata: %s", err)
+	}
+
+	changes := report.Changes
+
+	if *breakingOnly {
+		changes = report.BreakingChanges()
+	}
+
+	for _, change := range changes {
+		fmt.Printf("%s %s: %s\n", change.Target, change.QualifiedName(), change.Kind)
+
+		for _, detail := range change.Details {
+			fmt.Printf("\t%s\n", detail)
+		}
+	}
+
+	breakingChanges := len(report.BreakingChanges())
+
+	fmt.Printf("%d changes, %d breaking\n", len(report.Changes), breakingChanges)
+
+	if breakingChanges > 0 {
+		os.Exit(1)
+	}
+}
+
+func getMetadata(source string) (*types.Metadata, error) {
+	for _, prefix := range urlPrefixes {
+		if !strings.HasPrefix(source, prefix) {
+			continue
+		}
+
+		cl, err := client.Connect(source)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return state.NewState(cl).GetMetadataLatest()
+	}
+
+	b, err := os.ReadFile(source)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var meta types.Metadata
+
+	b = bytes.TrimSpace(b)
+
+	if bytes.HasPrefix(b, []byte("0x")) {
+		err = codec.DecodeFromHex(string(b), &meta)
+	} else {
+		err = codec.Decode(b, &meta)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &meta, nil
+}
@@ -0,0 +1,10 @@
+package compat
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrMetadataVersionNotSupported = libErr.Error("metadata version not supported")
+	ErrTypeNotFound                = libErr.Error("type not found")
+	ErrTypeNotVariant              = libErr.Error("type not variant")
+	ErrInvalidBinding              = libErr.Error("invalid binding")
+)
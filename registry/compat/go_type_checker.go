@@ -0,0 +1,466 @@
+package compat
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	optionTypeName = "Option"
+	unwrapMethod   = "Unwrap"
+
+	variantFieldPrefix = "Is"
+)
+
+// knownGoTypes holds the Go types whose encoding is not derived from their fields, along with a func that returns
+// true if the provided portable type definition matches them.
+var knownGoTypes = map[reflect.Type]func(def types.Si1TypeDef) bool{
+	reflect.TypeOf(types.UCompact{}): func(def types.Si1TypeDef) bool {
+		return def.IsCompact
+	},
+	reflect.TypeOf(types.U128{}): isPrimitive(types.IsU128),
+	reflect.TypeOf(types.I128{}): isPrimitive(types.IsI128),
+	reflect.TypeOf(types.U256{}): isPrimitive(types.IsU256),
+	reflect.TypeOf(types.I256{}): isPrimitive(types.IsI256),
+	reflect.TypeOf(types.BitVec{}): func(def types.Si1TypeDef) bool {
+		return def.IsBitSequence
+	},
+	reflect.TypeOf(types.Null(0)): func(def types.Si1TypeDef) bool {
+		return (def.IsTuple && len(def.Tuple) == 0) || (def.IsComposite && len(def.Composite.Fields) == 0)
+	},
+}
+
+func isPrimitive(primitive types.Si0TypeDefPrimitive) func(def types.Si1TypeDef) bool {
+	return func(def types.Si1TypeDef) bool {
+		return def.IsPrimitive && def.Primitive.Si0TypeDefPrimitive == primitive
+	}
+}
+
+// primitiveKinds holds the reflect kinds that are encoded like the portable primitives.
+var primitiveKinds = map[types.Si0TypeDefPrimitive][]reflect.Kind{
+	types.IsBool: {reflect.Bool},
+	types.IsChar: {reflect.Int32, reflect.Uint32},
+	types.IsStr:  {reflect.String},
+	types.IsU8:   {reflect.Uint8},
+	types.IsU16:  {reflect.Uint16},
+	types.IsU32:  {reflect.Uint32},
+	types.IsU64:  {reflect.Uint64},
+	types.IsI8:   {reflect.Int8},
+	types.IsI16:  {reflect.Int16},
+	types.IsI32:  {reflect.Int32},
+	types.IsI64:  {reflect.Int64},
+}
+
+var (
+	phaseType  = reflect.TypeOf(types.Phase{})
+	topicsType = reflect.TypeOf([]types.Hash{})
+)
+
+type goTypePair struct {
+	goType reflect.Type
+	typeID int64
+}
+
+// goTypeChecker checks whether Go types are encoded like portable types.
+//
+// Go types are expected to follow the conventions of the types package, where enums are structs that hold an
+// Is<Variant> bool field for each variant, followed by the fields of the variant. Structs that have unexported
+// fields, and therefore a custom encoding, can not be checked and are considered to match.
+type goTypeChecker struct {
+	lookup map[int64]*types.Si1Type
+
+	cache      map[goTypePair][]typeDiff
+	inProgress map[goTypePair]struct{}
+}
+
+func newGoTypeChecker(lookup map[int64]*types.Si1Type) *goTypeChecker {
+	return &goTypeChecker{
+		lookup:     lookup,
+		cache:      make(map[goTypePair][]typeDiff),
+		inProgress: make(map[goTypePair]struct{}),
+	}
+}
+
+// check returns the differences found between the Go type and the portable type.
+func (c *goTypeChecker) check(goType reflect.Type, typeID int64) ([]typeDiff, error) {
+	for goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+
+	pair := goTypePair{goType, typeID}
+
+	if diffs, ok := c.cache[pair]; ok {
+		return diffs, nil
+	}
+
+	if _, ok := c.inProgress[pair]; ok {
+		return nil, nil
+	}
+
+	c.inProgress[pair] = struct{}{}
+
+	defer delete(c.inProgress, pair)
+
+	t, err := getType(c.lookup, typeID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := c.checkType(goType, typeID, t)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache[pair] = diffs
+
+	return diffs, nil
+}
+
+//nolint:funlen
+func (c *goTypeChecker) checkType(goType reflect.Type, typeID int64, t *types.Si1Type) ([]typeDiff, error) {
+	mismatch := []typeDiff{
+		{
+			message: fmt.Sprintf("expected %s, got %s", describeType(c.lookup, t), goType),
+		},
+	}
+
+	def := t.Def
+
+	if matches, ok := knownGoTypes[goType]; ok {
+		if matches(def) {
+			return nil, nil
+		}
+
+		// Types with a single field, such as AccountId32, are encoded like their field.
+		if def.IsComposite && len(def.Composite.Fields) == 1 {
+			return c.check(goType, def.Composite.Fields[0].Type.Int64())
+		}
+
+		return mismatch, nil
+	}
+
+	if valueType, ok := getOptionValueType(goType); ok && isOption(t) {
+		return c.checkOption(goType, valueType, t)
+	}
+
+	goFields, ok := getGoFields(goType)
+
+	if !ok {
+		return nil, nil
+	}
+
+	switch {
+	case def.IsComposite && len(def.Composite.Fields) == 1 && goType.Kind() != reflect.Struct:
+		return c.check(goType, def.Composite.Fields[0].Type.Int64())
+	case !def.IsComposite && !def.IsVariant && !def.IsTuple && len(goFields) == 1:
+		// Go structs with a single field, such as types.Si1LookupTypeID, are encoded like their field.
+		return c.check(goFields[0].Type, typeID)
+	}
+
+	switch {
+	case def.IsComposite:
+		if goType.Kind() != reflect.Struct {
+			return mismatch, nil
+		}
+
+		return c.checkFields(goType, goFields, def.Composite.Fields)
+	case def.IsVariant:
+		return c.checkVariants(goType, goFields, def.Variant.Variants, mismatch)
+	case def.IsSequence:
+		switch {
+		case goType.Kind() == reflect.Slice:
+			diffs, err := c.check(goType.Elem(), def.Sequence.Type.Int64())
+
+			return prefixDiffs("[]", diffs), err
+		case goType.Kind() == reflect.String && isPrimitive(types.IsU8)(c.getDef(def.Sequence.Type.Int64())):
+			return nil, nil
+		default:
+			return mismatch, nil
+		}
+	case def.IsArray:
+		if goType.Kind() != reflect.Array || goType.Len() != int(def.Array.Len) {
+			return mismatch, nil
+		}
+
+		diffs, err := c.check(goType.Elem(), def.Array.Type.Int64())
+
+		return prefixDiffs("[]", diffs), err
+	case def.IsTuple:
+		if goType.Kind() != reflect.Struct || len(goFields) != len(def.Tuple) {
+			return mismatch, nil
+		}
+
+		var res []typeDiff
+
+		for i, goField := range goFields {
+			diffs, err := c.check(goField.Type, def.Tuple[i].Int64())
+
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, prefixDiffs(fmt.Sprint(i), diffs)...)
+		}
+
+		return res, nil
+	case def.IsPrimitive:
+		for _, kind := range primitiveKinds[def.Primitive.Si0TypeDefPrimitive] {
+			if goType.Kind() == kind {
+				return nil, nil
+			}
+		}
+
+		return mismatch, nil
+	case def.IsCompact, def.IsBitSequence:
+		return mismatch, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (c *goTypeChecker) getDef(typeID int64) types.Si1TypeDef {
+	t, ok := c.lookup[typeID]
+
+	if !ok {
+		return types.Si1TypeDef{}
+	}
+
+	return t.Def
+}
+
+func (c *goTypeChecker) checkOption(goType, valueType reflect.Type, t *types.Si1Type) ([]typeDiff, error) {
+	for _, variant := range t.Def.Variant.Variants {
+		if len(variant.Fields) == 1 {
+			return c.check(valueType, variant.Fields[0].Type.Int64())
+		}
+	}
+
+	return nil, ErrTypeNotVariant.WithMsg("option type %s has no value", goType)
+}
+
+// checkFields checks the fields of a Go struct against the provided portable fields, which are matched by position.
+func (c *goTypeChecker) checkFields(
+	goType reflect.Type,
+	goFields []reflect.StructField,
+	fields []types.Si1Field,
+) ([]typeDiff, error) {
+	if len(goFields) != len(fields) {
+		return []typeDiff{
+			{
+				message: fmt.Sprintf(
+					"expected fields %s, got %d fields in %s",
+					describeFields(c.lookup, fields),
+					len(goFields),
+					goType,
+				),
+			},
+		}, nil
+	}
+
+	var res []typeDiff
+
+	for i, goField := range goFields {
+		diffs, err := c.check(goField.Type, fields[i].Type.Int64())
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, prefixDiffs(getFieldName(i, fields[i]), diffs)...)
+	}
+
+	return res, nil
+}
+
+// goVariant holds the fields of a Go struct that represent a variant.
+type goVariant struct {
+	name   string
+	fields []reflect.StructField
+}
+
+// checkVariants checks the variants of a Go enum against the provided portable variants, which are matched by name.
+func (c *goTypeChecker) checkVariants(
+	goType reflect.Type,
+	goFields []reflect.StructField,
+	variants []types.Si1Variant,
+	mismatch []typeDiff,
+) ([]typeDiff, error) {
+	// Enums without fields can be represented by an integer that holds the index of the variant.
+	if goType.Kind() == reflect.Uint8 && !hasVariantFields(variants) {
+		return nil, nil
+	}
+
+	goVariants, ok := getGoVariants(goType, goFields)
+
+	if !ok {
+		return mismatch, nil
+	}
+
+	var res []typeDiff
+
+	matchedGoVariants := make(map[string]struct{}, len(goVariants))
+
+	for _, variant := range variants {
+		name := string(variant.Name)
+
+		goVariant, ok := goVariants[normalizeName(name)]
+
+		if !ok {
+			res = append(res, typeDiff{path: name, message: fmt.Sprintf("variant not found in %s", goType)})
+
+			continue
+		}
+
+		matchedGoVariants[goVariant.name] = struct{}{}
+
+		diffs, err := c.checkFields(goType, goVariant.fields, variant.Fields)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, prefixDiffs(name, diffs)...)
+	}
+
+	for _, goField := range goFields {
+		name, ok := getGoVariantName(goField)
+
+		if !ok {
+			continue
+		}
+
+		if _, ok := matchedGoVariants[name]; !ok {
+			res = append(res, typeDiff{path: name, message: "variant not found in metadata"})
+		}
+	}
+
+	return res, nil
+}
+
+func hasVariantFields(variants []types.Si1Variant) bool {
+	for _, variant := range variants {
+		if len(variant.Fields) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getGoVariants returns the variants of a Go enum, mapped by their normalized names.
+func getGoVariants(goType reflect.Type, goFields []reflect.StructField) (map[string]*goVariant, bool) {
+	if goType.Kind() != reflect.Struct || len(goFields) == 0 {
+		return nil, false
+	}
+
+	res := make(map[string]*goVariant)
+
+	var current *goVariant
+
+	for _, goField := range goFields {
+		if name, ok := getGoVariantName(goField); ok {
+			current = &goVariant{name: name}
+
+			res[normalizeName(name)] = current
+
+			continue
+		}
+
+		if current == nil {
+			return nil, false
+		}
+
+		current.fields = append(current.fields, goField)
+	}
+
+	return res, true
+}
+
+func getGoVariantName(goField reflect.StructField) (string, bool) {
+	if goField.Type.Kind() != reflect.Bool || !strings.HasPrefix(goField.Name, variantFieldPrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(goField.Name, variantFieldPrefix), true
+}
+
+// normalizeName returns the lower case letters and digits of the provided name, so that Go and Rust names,
+// such as AsID and Id, can be matched.
+func normalizeName(name string) string {
+	var sb strings.Builder
+
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	return sb.String()
+}
+
+// getGoFields returns the fields of the provided Go type that are encoded, or false if the type is a struct
+// that can not be checked.
+func getGoFields(goType reflect.Type) ([]reflect.StructField, bool) {
+	if goType.Kind() != reflect.Struct {
+		return nil, true
+	}
+
+	var res []reflect.StructField
+
+	for i := 0; i < goType.NumField(); i++ {
+		field := goType.Field(i)
+
+		if tag, ok := field.Tag.Lookup("scale"); ok && tag == "-" {
+			continue
+		}
+
+		if !field.IsExported() {
+			return nil, false
+		}
+
+		res = append(res, field)
+	}
+
+	return res, true
+}
+
+// getOptionValueType returns the value type of the provided Go option, which is detected using the Unwrap method
+// of the options found in the types package.
+func getOptionValueType(goType reflect.Type) (reflect.Type, bool) {
+	method, ok := reflect.PointerTo(goType).MethodByName(unwrapMethod)
+
+	if !ok {
+		return nil, false
+	}
+
+	// The receiver is the first input.
+	if method.Type.NumIn() != 1 || method.Type.NumOut() != 2 || method.Type.Out(0).Kind() != reflect.Bool {
+		return nil, false
+	}
+
+	return method.Type.Out(1), true
+}
+
+func isOption(t *types.Si1Type) bool {
+	return t.Def.IsVariant && len(t.Path) > 0 && string(t.Path[len(t.Path)-1]) == optionTypeName
+}
+
+// getEventGoFields returns the fields of an event struct, without the fields that hold the phase and the topics
+// of the event record.
+func getEventGoFields(goFields []reflect.StructField) []reflect.StructField {
+	if len(goFields) > 0 && goFields[0].Type == phaseType {
+		goFields = goFields[1:]
+	}
+
+	if len(goFields) > 0 && goFields[len(goFields)-1].Type == topicsType {
+		goFields = goFields[:len(goFields)-1]
+	}
+
+	return goFields
+}
@@ -0,0 +1,320 @@
+package compat
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testU32TypeID = iota
+	testOptionTypeID
+	testEmptyTupleTypeID
+	testBytesTypeID
+	testCompositeTypeID
+	testFieldlessEnumTypeID
+	testCompactTypeID
+	testBitSequenceTypeID
+	testNewTypeTypeID
+	testRecursiveTypeID
+	testU8TypeID
+)
+
+func getTestLookup() map[int64]*types.Si1Type {
+	typeID := func(id int) types.Si1LookupTypeID {
+		return types.NewSi1LookupTypeIDFromUInt(uint64(id))
+	}
+
+	return map[int64]*types.Si1Type{
+		testU32TypeID: {
+			Def: types.Si1TypeDef{
+				IsPrimitive: true,
+				Primitive:   types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: types.IsU32},
+			},
+		},
+		testOptionTypeID: {
+			Path: types.Si1Path{"Option"},
+			Def: types.Si1TypeDef{
+				IsVariant: true,
+				Variant: types.Si1TypeDefVariant{
+					Variants: []types.Si1Variant{
+						{Name: "None", Index: 0},
+						{Name: "Some", Index: 1, Fields: []types.Si1Field{{Type: typeID(testU32TypeID)}}},
+					},
+				},
+			},
+		},
+		testEmptyTupleTypeID: {
+			Def: types.Si1TypeDef{
+				IsTuple: true,
+			},
+		},
+		testBytesTypeID: {
+			Def: types.Si1TypeDef{
+				IsSequence: true,
+				Sequence:   types.Si1TypeDefSequence{Type: typeID(testU8TypeID)},
+			},
+		},
+		testCompositeTypeID: {
+			Path: types.Si1Path{"pallet_test", "Composite"},
+			Def: types.Si1TypeDef{
+				IsComposite: true,
+				Composite: types.Si1TypeDefComposite{
+					Fields: []types.Si1Field{
+						{HasName: true, Name: "a", Type: typeID(testU32TypeID)},
+						{HasName: true, Name: "b", Type: typeID(testOptionTypeID)},
+					},
+				},
+			},
+		},
+		testFieldlessEnumTypeID: {
+			Def: types.Si1TypeDef{
+				IsVariant: true,
+				Variant: types.Si1TypeDefVariant{
+					Variants: []types.Si1Variant{
+						{Name: "First", Index: 0},
+						{Name: "Second", Index: 1},
+					},
+				},
+			},
+		},
+		testCompactTypeID: {
+			Def: types.Si1TypeDef{
+				IsCompact: true,
+				Compact:   types.Si1TypeDefCompact{Type: typeID(testU32TypeID)},
+			},
+		},
+		testBitSequenceTypeID: {
+			Def: types.Si1TypeDef{
+				IsBitSequence: true,
+				BitSequence: types.Si1TypeDefBitSequence{
+					BitStoreType: typeID(testU8TypeID),
+					BitOrderType: typeID(testEmptyTupleTypeID),
+				},
+			},
+		},
+		testNewTypeTypeID: {
+			Def: types.Si1TypeDef{
+				IsComposite: true,
+				Composite: types.Si1TypeDefComposite{
+					Fields: []types.Si1Field{{Type: typeID(testU32TypeID)}},
+				},
+			},
+		},
+		testRecursiveTypeID: {
+			Def: types.Si1TypeDef{
+				IsVariant: true,
+				Variant: types.Si1TypeDefVariant{
+					Variants: []types.Si1Variant{
+						{Name: "Leaf", Index: 0, Fields: []types.Si1Field{{Type: typeID(testU32TypeID)}}},
+						{Name: "Node", Index: 1, Fields: []types.Si1Field{{Type: typeID(testRecursiveTypeID)}}},
+					},
+				},
+			},
+		},
+		testU8TypeID: {
+			Def: types.Si1TypeDef{
+				IsPrimitive: true,
+				Primitive:   types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: types.IsU8},
+			},
+		},
+	}
+}
+
+type testComposite struct {
+	A types.U32
+	B types.Option[types.U32]
+}
+
+type testCompositeWithIgnoredField struct {
+	A       types.U32
+	B       types.Option[types.U32]
+	Ignored string `scale:"-"`
+}
+
+type testInvalidComposite struct {
+	A types.U64
+	B types.Option[types.U64]
+}
+
+type testRecursive struct {
+	IsLeaf bool
+	AsLeaf types.U32
+	IsNode bool
+	AsNode *testRecursive
+}
+
+type testInvalidRecursive struct {
+	IsLeaf bool
+	AsLeaf types.U64
+	IsNode bool
+	AsNode *testInvalidRecursive
+}
+
+func TestGoTypeChecker_Check(t *testing.T) {
+	var tests = []struct {
+		Name          string
+		Value         any
+		TypeID        int64
+		ExpectedDiffs []string
+	}{
+		{
+			Name:   "primitive",
+			Value:  types.U32(0),
+			TypeID: testU32TypeID,
+		},
+		{
+			Name:   "primitive pointer",
+			Value:  new(types.U32),
+			TypeID: testU32TypeID,
+		},
+		{
+			Name:          "primitive mismatch",
+			Value:         types.U64(0),
+			TypeID:        testU32TypeID,
+			ExpectedDiffs: []string{"expected u32, got types.U64"},
+		},
+		{
+			Name:   "option",
+			Value:  types.NewEmptyOption[types.U32](),
+			TypeID: testOptionTypeID,
+		},
+		{
+			Name:   "option from types package",
+			Value:  types.OptionU32{},
+			TypeID: testOptionTypeID,
+		},
+		{
+			Name:          "option mismatch",
+			Value:         types.NewEmptyOption[types.U64](),
+			TypeID:        testOptionTypeID,
+			ExpectedDiffs: []string{"expected u32, got types.U64"},
+		},
+		{
+			Name:   "empty tuple",
+			Value:  types.Null(0),
+			TypeID: testEmptyTupleTypeID,
+		},
+		{
+			Name:   "empty struct",
+			Value:  struct{}{},
+			TypeID: testEmptyTupleTypeID,
+		},
+		{
+			Name:   "bytes",
+			Value:  types.Bytes{},
+			TypeID: testBytesTypeID,
+		},
+		{
+			Name:   "string",
+			Value:  "",
+			TypeID: testBytesTypeID,
+		},
+		{
+			Name:          "sequence mismatch",
+			Value:         []types.U32{},
+			TypeID:        testBytesTypeID,
+			ExpectedDiffs: []string{"[]: expected u8, got types.U32"},
+		},
+		{
+			Name:   "composite",
+			Value:  testComposite{},
+			TypeID: testCompositeTypeID,
+		},
+		{
+			Name:   "composite with ignored field",
+			Value:  testCompositeWithIgnoredField{},
+			TypeID: testCompositeTypeID,
+		},
+		{
+			Name:   "composite mismatch",
+			Value:  testInvalidComposite{},
+			TypeID: testCompositeTypeID,
+			ExpectedDiffs: []string{
+				"a: expected u32, got types.U64",
+				"b: expected u32, got types.U64",
+			},
+		},
+		{
+			Name:   "fieldless enum",
+			Value:  testProxyType(0),
+			TypeID: testFieldlessEnumTypeID,
+		},
+		{
+			Name:          "enum mismatch",
+			Value:         testProxyType(0),
+			TypeID:        testRecursiveTypeID,
+			ExpectedDiffs: []string{"expected enum with 2 variants, got compat.testProxyType"},
+		},
+		{
+			Name:   "compact",
+			Value:  types.NewUCompactFromUInt(0),
+			TypeID: testCompactTypeID,
+		},
+		{
+			Name:   "compact wrapper",
+			Value:  types.Si1LookupTypeID{},
+			TypeID: testCompactTypeID,
+		},
+		{
+			Name:          "compact mismatch",
+			Value:         types.U32(0),
+			TypeID:        testCompactTypeID,
+			ExpectedDiffs: []string{"expected Compact<u32>, got types.U32"},
+		},
+		{
+			Name:   "bit sequence",
+			Value:  types.BitVec{},
+			TypeID: testBitSequenceTypeID,
+		},
+		{
+			Name:   "new type",
+			Value:  types.U32(0),
+			TypeID: testNewTypeTypeID,
+		},
+		{
+			Name:   "recursive",
+			Value:  testRecursive{},
+			TypeID: testRecursiveTypeID,
+		},
+		{
+			Name:          "recursive mismatch",
+			Value:         testInvalidRecursive{},
+			TypeID:        testRecursiveTypeID,
+			ExpectedDiffs: []string{"Leaf.0: expected u32, got types.U64"},
+		},
+		{
+			Name:   "unexported fields",
+			Value:  big.Int{},
+			TypeID: testCompositeTypeID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			checker := newGoTypeChecker(getTestLookup())
+
+			diffs, err := checker.check(reflect.TypeOf(test.Value), test.TypeID)
+			assert.NoError(t, err)
+
+			if test.ExpectedDiffs == nil {
+				assert.Empty(t, diffs)
+
+				return
+			}
+
+			assert.Equal(t, test.ExpectedDiffs, diffsToStrings(diffs))
+		})
+	}
+}
+
+func TestGoTypeChecker_Check_TypeNotFound(t *testing.T) {
+	checker := newGoTypeChecker(getTestLookup())
+
+	diffs, err := checker.check(reflect.TypeOf(types.U32(0)), 1_000)
+	assert.ErrorIs(t, err, ErrTypeNotFound)
+	assert.Nil(t, diffs)
+}
@@ -0,0 +1,430 @@
+package compat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const pathSeparator = "::"
+
+// typeDiff holds a difference found at the provided path of a portable type, where the path is made of the field
+// and variant names leading to the difference.
+type typeDiff struct {
+	path    string
+	message string
+}
+
+func (d typeDiff) String() string {
+	if d.path == "" {
+		return d.message
+	}
+
+	return d.path + ": " + d.message
+}
+
+// prefixDiffs adds the provided prefix to the paths of the provided diffs.
+func prefixDiffs(prefix string, diffs []typeDiff) []typeDiff {
+	res := make([]typeDiff, 0, len(diffs))
+
+	for _, diff := range diffs {
+		switch {
+		case diff.path == "":
+			diff.path = prefix
+		case strings.HasPrefix(diff.path, "[]"):
+			diff.path = prefix + diff.path
+		default:
+			diff.path = prefix + "." + diff.path
+		}
+
+		res = append(res, diff)
+	}
+
+	return res
+}
+
+func diffsToStrings(diffs []typeDiff) []string {
+	res := make([]string, 0, len(diffs))
+
+	for _, diff := range diffs {
+		res = append(res, diff.String())
+	}
+
+	return res
+}
+
+type typePair struct {
+	oldID int64
+	newID int64
+}
+
+// typeComparator compares the shape of the portable types found in two metadata versions.
+//
+// The type IDs of the two versions are unrelated, which is why the types are compared structurally, down to their
+// primitives.
+type typeComparator struct {
+	oldLookup map[int64]*types.Si1Type
+	newLookup map[int64]*types.Si1Type
+
+	cache      map[typePair][]typeDiff
+	inProgress map[typePair]struct{}
+}
+
+func newTypeComparator(oldLookup, newLookup map[int64]*types.Si1Type) *typeComparator {
+	return &typeComparator{
+		oldLookup:  oldLookup,
+		newLookup:  newLookup,
+		cache:      make(map[typePair][]typeDiff),
+		inProgress: make(map[typePair]struct{}),
+	}
+}
+
+// compare returns the differences between the old and the new type.
+func (c *typeComparator) compare(oldID, newID int64) ([]typeDiff, error) {
+	pair := typePair{oldID, newID}
+
+	if diffs, ok := c.cache[pair]; ok {
+		return diffs, nil
+	}
+
+	// Recursive types are considered equal while they are being compared, any difference is reported by the
+	// outermost comparison.
+	if _, ok := c.inProgress[pair]; ok {
+		return nil, nil
+	}
+
+	c.inProgress[pair] = struct{}{}
+
+	defer delete(c.inProgress, pair)
+
+	oldType, err := getType(c.oldLookup, oldID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	newType, err := getType(c.newLookup, newID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := c.compareTypes(oldType, newType)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache[pair] = diffs
+
+	return diffs, nil
+}
+
+func (c *typeComparator) compareTypes(oldType, newType *types.Si1Type) ([]typeDiff, error) {
+	changed := []typeDiff{
+		{
+			message: fmt.Sprintf(
+				"changed from %s to %s",
+				describeType(c.oldLookup, oldType),
+				describeType(c.newLookup, newType),
+			),
+		},
+	}
+
+	if getDefKind(oldType.Def) != getDefKind(newType.Def) {
+		return changed, nil
+	}
+
+	oldDef, newDef := oldType.Def, newType.Def
+
+	switch {
+	case oldDef.IsComposite:
+		return c.compareFields(oldDef.Composite.Fields, newDef.Composite.Fields)
+	case oldDef.IsVariant:
+		return c.compareVariants(oldDef.Variant.Variants, newDef.Variant.Variants)
+	case oldDef.IsSequence:
+		diffs, err := c.compare(oldDef.Sequence.Type.Int64(), newDef.Sequence.Type.Int64())
+
+		return prefixDiffs("[]", diffs), err
+	case oldDef.IsArray:
+		if oldDef.Array.Len != newDef.Array.Len {
+			return changed, nil
+		}
+
+		diffs, err := c.compare(oldDef.Array.Type.Int64(), newDef.Array.Type.Int64())
+
+		return prefixDiffs("[]", diffs), err
+	case oldDef.IsTuple:
+		if len(oldDef.Tuple) != len(newDef.Tuple) {
+			return changed, nil
+		}
+
+		var res []typeDiff
+
+		for i := range oldDef.Tuple {
+			diffs, err := c.compare(oldDef.Tuple[i].Int64(), newDef.Tuple[i].Int64())
+
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, prefixDiffs(strconv.Itoa(i), diffs)...)
+		}
+
+		return res, nil
+	case oldDef.IsPrimitive:
+		if oldDef.Primitive.Si0TypeDefPrimitive != newDef.Primitive.Si0TypeDefPrimitive {
+			return changed, nil
+		}
+
+		return nil, nil
+	case oldDef.IsCompact:
+		return c.compare(oldDef.Compact.Type.Int64(), newDef.Compact.Type.Int64())
+	case oldDef.IsBitSequence:
+		diffs, err := c.compare(oldDef.BitSequence.BitStoreType.Int64(), newDef.BitSequence.BitStoreType.Int64())
+
+		if err != nil || len(diffs) > 0 {
+			return diffs, err
+		}
+
+		return c.compare(oldDef.BitSequence.BitOrderType.Int64(), newDef.BitSequence.BitOrderType.Int64())
+	default:
+		return nil, nil
+	}
+}
+
+// compareFields returns the differences between the old and the new fields, which are matched by position.
+func (c *typeComparator) compareFields(oldFields, newFields []types.Si1Field) ([]typeDiff, error) {
+	if len(oldFields) != len(newFields) {
+		return []typeDiff{
+			{
+				message: fmt.Sprintf(
+					"fields changed from %s to %s",
+					describeFields(c.oldLookup, oldFields),
+					describeFields(c.newLookup, newFields),
+				),
+			},
+		}, nil
+	}
+
+	var res []typeDiff
+
+	for i := range oldFields {
+		oldName := getFieldName(i, oldFields[i])
+		newName := getFieldName(i, newFields[i])
+
+		if oldName != newName {
+			res = append(res, typeDiff{path: oldName, message: fmt.Sprintf("renamed to %s", newName)})
+		}
+
+		diffs, err := c.compare(oldFields[i].Type.Int64(), newFields[i].Type.Int64())
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, prefixDiffs(newName, diffs)...)
+	}
+
+	return res, nil
+}
+
+// compareVariants returns the differences between the old and the new variants, which are matched by name.
+func (c *typeComparator) compareVariants(oldVariants, newVariants []types.Si1Variant) ([]typeDiff, error) {
+	var res []typeDiff
+
+	newVariantsByName := make(map[string]types.Si1Variant, len(newVariants))
+
+	for _, variant := range newVariants {
+		newVariantsByName[string(variant.Name)] = variant
+	}
+
+	oldVariantNames := make(map[string]struct{}, len(oldVariants))
+
+	for _, oldVariant := range oldVariants {
+		name := string(oldVariant.Name)
+
+		oldVariantNames[name] = struct{}{}
+
+		newVariant, ok := newVariantsByName[name]
+
+		if !ok {
+			res = append(res, typeDiff{path: name, message: "variant removed"})
+
+			continue
+		}
+
+		if oldVariant.Index != newVariant.Index {
+			res = append(res, typeDiff{
+				path:    name,
+				message: fmt.Sprintf("index changed from %d to %d", oldVariant.Index, newVariant.Index),
+			})
+		}
+
+		diffs, err := c.compareFields(oldVariant.Fields, newVariant.Fields)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, prefixDiffs(name, diffs)...)
+	}
+
+	for _, newVariant := range newVariants {
+		if _, ok := oldVariantNames[string(newVariant.Name)]; !ok {
+			res = append(res, typeDiff{path: string(newVariant.Name), message: "variant added"})
+		}
+	}
+
+	return res, nil
+}
+
+func getType(lookup map[int64]*types.Si1Type, typeID int64) (*types.Si1Type, error) {
+	t, ok := lookup[typeID]
+
+	if !ok {
+		return nil, ErrTypeNotFound.WithMsg("type ID %d", typeID)
+	}
+
+	return t, nil
+}
+
+// getLookup returns the types of the provided metadata, mapped by their IDs.
+func getLookup(meta *types.Metadata) (map[int64]*types.Si1Type, error) {
+	if meta.Version != 14 {
+		return nil, ErrMetadataVersionNotSupported.WithMsg("version %d", meta.Version)
+	}
+
+	if meta.AsMetadataV14.EfficientLookup != nil {
+		return meta.AsMetadataV14.EfficientLookup, nil
+	}
+
+	lookup := make(map[int64]*types.Si1Type, len(meta.AsMetadataV14.Lookup.Types))
+
+	for i, portableType := range meta.AsMetadataV14.Lookup.Types {
+		lookup[portableType.ID.Int64()] = &meta.AsMetadataV14.Lookup.Types[i].Type
+	}
+
+	return lookup, nil
+}
+
+func getDefKind(def types.Si1TypeDef) string {
+	switch {
+	case def.IsComposite:
+		return "composite"
+	case def.IsVariant:
+		return "variant"
+	case def.IsSequence:
+		return "sequence"
+	case def.IsArray:
+		return "array"
+	case def.IsTuple:
+		return "tuple"
+	case def.IsPrimitive:
+		return "primitive"
+	case def.IsCompact:
+		return "compact"
+	case def.IsBitSequence:
+		return "bit sequence"
+	default:
+		return "historic meta compat"
+	}
+}
+
+func getPath(t *types.Si1Type) string {
+	segments := make([]string, 0, len(t.Path))
+
+	for _, segment := range t.Path {
+		segments = append(segments, string(segment))
+	}
+
+	return strings.Join(segments, pathSeparator)
+}
+
+func getFieldName(index int, field types.Si1Field) string {
+	if field.HasName {
+		return string(field.Name)
+	}
+
+	return strconv.Itoa(index)
+}
+
+var primitiveNames = map[types.Si0TypeDefPrimitive]string{
+	types.IsBool: "bool",
+	types.IsChar: "char",
+	types.IsStr:  "str",
+	types.IsU8:   "u8",
+	types.IsU16:  "u16",
+	types.IsU32:  "u32",
+	types.IsU64:  "u64",
+	types.IsU128: "u128",
+	types.IsU256: "u256",
+	types.IsI8:   "i8",
+	types.IsI16:  "i16",
+	types.IsI32:  "i32",
+	types.IsI64:  "i64",
+	types.IsI128: "i128",
+	types.IsI256: "i256",
+}
+
+// describeType returns a short, Rust like, description of the provided type.
+func describeType(lookup map[int64]*types.Si1Type, t *types.Si1Type) string {
+	if path := getPath(t); path != "" {
+		return path
+	}
+
+	describeTypeID := func(typeID types.Si1LookupTypeID) string {
+		t, ok := lookup[typeID.Int64()]
+
+		if !ok {
+			return fmt.Sprintf("#%d", typeID.Int64())
+		}
+
+		return describeType(lookup, t)
+	}
+
+	switch def := t.Def; {
+	case def.IsComposite:
+		return describeFields(lookup, def.Composite.Fields)
+	case def.IsVariant:
+		return fmt.Sprintf("enum with %d variants", len(def.Variant.Variants))
+	case def.IsSequence:
+		return fmt.Sprintf("Vec<%s>", describeTypeID(def.Sequence.Type))
+	case def.IsArray:
+		return fmt.Sprintf("[%s; %d]", describeTypeID(def.Array.Type), def.Array.Len)
+	case def.IsTuple:
+		elems := make([]string, 0, len(def.Tuple))
+
+		for _, typeID := range def.Tuple {
+			elems = append(elems, describeTypeID(typeID))
+		}
+
+		return "(" + strings.Join(elems, ", ") + ")"
+	case def.IsPrimitive:
+		return primitiveNames[def.Primitive.Si0TypeDefPrimitive]
+	case def.IsCompact:
+		return fmt.Sprintf("Compact<%s>", describeTypeID(def.Compact.Type))
+	case def.IsBitSequence:
+		return "BitVec"
+	default:
+		return getDefKind(def)
+	}
+}
+
+func describeFields(lookup map[int64]*types.Si1Type, fields []types.Si1Field) string {
+	res := make([]string, 0, len(fields))
+
+	for i, field := range fields {
+		fieldType := fmt.Sprintf("#%d", field.Type.Int64())
+
+		if t, ok := lookup[field.Type.Int64()]; ok {
+			fieldType = describeType(lookup, t)
+		}
+
+		res = append(res, getFieldName(i, field)+": "+fieldType)
+	}
+
+	return "{" + strings.Join(res, ", ") + "}"
+}
@@ -1,9 +1,11 @@
 package registry
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
+	"strings"
 
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
@@ -23,9 +25,10 @@ func (n *NoopDecoder) Decode(_ *scale.Decoder) (any, error) {
 	return nil, nil
 }
 
-// VariantDecoder holds a FieldDecoder for each variant/enum.
+// VariantDecoder holds a FieldDecoder and a name for each variant/enum.
 type VariantDecoder struct {
 	FieldDecoderMap map[byte]FieldDecoder
+	VariantNameMap  map[byte]string
 }
 
 func (v *VariantDecoder) Decode(decoder *scale.Decoder) (any, error) {
@@ -41,11 +44,60 @@ func (v *VariantDecoder) Decode(decoder *scale.Decoder) (any, error) {
 		return nil, ErrVariantFieldDecoderNotFound.WithMsg("variant '%d'", variantByte)
 	}
 
+	decodedVariant := &DecodedVariant{
+		Index: variantByte,
+		Name:  v.VariantNameMap[variantByte],
+	}
+
 	if _, ok := variantDecoder.(*NoopDecoder); ok {
-		return variantByte, nil
+		return decodedVariant, nil
+	}
+
+	scope := decoder.Enter(scale.NameSegment(decodedVariant.Name), "")
+
+	value, err := variantDecoder.Decode(decoder)
+
+	if err = scope.Exit(err); err != nil {
+		return nil, err
+	}
+
+	variantFields, ok := value.(DecodedFields)
+
+	if !ok {
+		return nil, ErrUnexpectedVariantValue.WithMsg("variant '%d', value %T", variantByte, value)
+	}
+
+	decodedVariant.Fields = variantFields
+
+	return decodedVariant, nil
+}
+
+// DecodedVariant is the value returned when a variant/enum is decoded.
+type DecodedVariant struct {
+	Index byte
+	Name  string
+
+	// Fields holds the decoded fields of the variant, it is empty for variants without fields.
+	Fields DecodedFields
+}
+
+// Is returns true if the variant has the provided name.
+func (d *DecodedVariant) Is(variantName string) bool {
+	return d != nil && d.Name == variantName
+}
+
+func (d DecodedVariant) Encode(encoder scale.Encoder) error {
+	if err := encoder.PushByte(d.Index); err != nil {
+		return err
+	}
+
+	for _, field := range d.Fields {
+		if err := field.Encode(encoder); err != nil {
+			return err
+		}
 	}
 
-	return variantDecoder.Decode(decoder)
+	return nil
 }
 
 // ArrayDecoder holds information about the length of the array and the FieldDecoder used for its items.
@@ -62,10 +114,12 @@ func (a *ArrayDecoder) Decode(decoder *scale.Decoder) (any, error) {
 	slice := make([]any, 0, a.Length)
 
 	for i := uint(0); i < a.Length; i++ {
+		scope := decoder.Enter(scale.IndexSegment(int(i)), "")
+
 		item, err := a.ItemDecoder.Decode(decoder)
 
-		if err != nil {
-			return nil, ErrArrayItemDecoding.Wrap(err)
+		if err = scope.Exit(err); err != nil {
+			return nil, WrapDecodeError(ErrArrayItemDecoding, err)
 		}
 
 		slice = append(slice, item)
@@ -93,10 +147,12 @@ func (s *SliceDecoder) Decode(decoder *scale.Decoder) (any, error) {
 	slice := make([]any, 0, sliceLen.Uint64())
 
 	for i := uint64(0); i < sliceLen.Uint64(); i++ {
+		scope := decoder.Enter(scale.IndexSegment(int(i)), "")
+
 		item, err := s.ItemDecoder.Decode(decoder)
 
-		if err != nil {
-			return nil, ErrSliceItemDecoding.Wrap(err)
+		if err = scope.Exit(err); err != nil {
+			return nil, WrapDecodeError(ErrSliceItemDecoding, err)
 		}
 
 		slice = append(slice, item)
@@ -115,10 +171,12 @@ func (e *CompositeDecoder) Decode(decoder *scale.Decoder) (any, error) {
 	var decodedFields DecodedFields
 
 	for _, field := range e.Fields {
+		scope := decoder.Enter(field.pathSegment(), "")
+
 		value, err := field.FieldDecoder.Decode(decoder)
 
-		if err != nil {
-			return nil, ErrCompositeFieldDecoding.Wrap(err)
+		if err = scope.Exit(err); err != nil {
+			return nil, WrapDecodeError(ErrCompositeFieldDecoding, err)
 		}
 
 		decodedFields = append(decodedFields, &DecodedField{
@@ -138,7 +196,7 @@ func (v *ValueDecoder[T]) Decode(decoder *scale.Decoder) (any, error) {
 	var t T
 
 	if err := decoder.Decode(&t); err != nil {
-		return nil, ErrValueDecoding.Wrap(err)
+		return nil, WrapDecodeError(ErrValueDecoding, err)
 	}
 
 	return t, nil
@@ -179,6 +237,7 @@ func (b *BitSequenceDecoder) Decode(decoder *scale.Decoder) (any, error) {
 type TypeDecoder struct {
 	Name   string
 	Fields []*Field
+	Docs   []string
 }
 
 func (t *TypeDecoder) Decode(decoder *scale.Decoder) (DecodedFields, error) {
@@ -186,13 +245,25 @@ func (t *TypeDecoder) Decode(decoder *scale.Decoder) (DecodedFields, error) {
 		return nil, ErrNilTypeDecoder
 	}
 
+	scope := decoder.Enter(scale.NameSegment(t.Name), "")
+
+	decodedFields, err := t.decodeFields(decoder)
+
+	if err = scope.Exit(err); err != nil {
+		return nil, WrapDecodeError(ErrTypeFieldDecoding, err)
+	}
+
+	return decodedFields, nil
+}
+
+func (t *TypeDecoder) decodeFields(decoder *scale.Decoder) (DecodedFields, error) {
 	var decodedFields DecodedFields
 
 	for _, field := range t.Fields {
 		decodedField, err := field.Decode(decoder)
 
 		if err != nil {
-			return nil, ErrTypeFieldDecoding.Wrap(err)
+			return nil, err
 		}
 
 		decodedFields = append(decodedFields, decodedField)
@@ -201,6 +272,20 @@ func (t *TypeDecoder) Decode(decoder *scale.Decoder) (DecodedFields, error) {
 	return decodedFields, nil
 }
 
+// WrapDecodeError wraps err with the provided error. If err holds a scale.DecodeError, the error of the
+// scale.DecodeError is wrapped instead so that the path and offset of the value that couldn't be decoded are kept.
+func WrapDecodeError(wrapper libErr.Error, err error) error {
+	var decodeErr *scale.DecodeError
+
+	if errors.As(err, &decodeErr) {
+		decodeErr.Err = wrapper.Wrap(decodeErr.Err)
+
+		return err
+	}
+
+	return wrapper.Wrap(err)
+}
+
 // getPrimitiveDecoder parses a primitive type definition and returns a ValueDecoder.
 func getPrimitiveDecoder(primitiveTypeDef types.Si0TypeDefPrimitive) (FieldDecoder, error) {
 	switch primitiveTypeDef {
@@ -255,9 +340,11 @@ func (f *Field) Decode(decoder *scale.Decoder) (*DecodedField, error) {
 		return nil, ErrNilFieldDecoder
 	}
 
+	scope := decoder.Enter(f.pathSegment(), "")
+
 	value, err := f.FieldDecoder.Decode(decoder)
 
-	if err != nil {
+	if err = scope.Exit(err); err != nil {
 		return nil, err
 	}
 
@@ -268,6 +355,12 @@ func (f *Field) Decode(decoder *scale.Decoder) (*DecodedField, error) {
 	}, nil
 }
 
+// pathSegment returns the path segment of the field in decoding errors, which is the name of the field without the
+// path of its type.
+func (f *Field) pathSegment() scale.PathSegment {
+	return scale.NameSegment(f.Name[strings.LastIndex(f.Name, ".")+1:])
+}
+
 // DecodedField holds the name, value and lookup index of a field that was decoded.
 type DecodedField struct {
 	Name        string
@@ -288,6 +381,14 @@ type DecodedFields []*DecodedField
 type DecodedFieldPredicateFn func(fieldIndex int, field *DecodedField) bool
 type DecodedValueProcessingFn[T any] func(value any) (T, error)
 
+// FieldNamePredicate returns a DecodedFieldPredicateFn that matches a field either by its exact name
+// or by a name that ends with the field separator followed by the provided name.
+func FieldNamePredicate(fieldName string) DecodedFieldPredicateFn {
+	return func(_ int, field *DecodedField) bool {
+		return field.Name == fieldName || strings.HasSuffix(field.Name, fieldSeparator+fieldName)
+	}
+}
+
 // ProcessDecodedFieldValue applies the processing func to the value of the field
 // that matches the provided predicate func.
 func ProcessDecodedFieldValue[T any](
@@ -337,6 +438,45 @@ func GetDecodedFieldAsType[T any](
 	)
 }
 
+// GetDecodedFieldAsVariant returns the value of the field that matches the provided predicate func
+// as a DecodedVariant.
+func GetDecodedFieldAsVariant(
+	decodedFields DecodedFields,
+	fieldPredicateFn DecodedFieldPredicateFn,
+) (*DecodedVariant, error) {
+	return GetDecodedFieldAsType[*DecodedVariant](decodedFields, fieldPredicateFn)
+}
+
+// GetDecodedFieldAsVariantName returns the variant name of the field that matches the provided predicate func.
+func GetDecodedFieldAsVariantName(
+	decodedFields DecodedFields,
+	fieldPredicateFn DecodedFieldPredicateFn,
+) (string, error) {
+	decodedVariant, err := GetDecodedFieldAsVariant(decodedFields, fieldPredicateFn)
+
+	if err != nil {
+		return "", err
+	}
+
+	return decodedVariant.Name, nil
+}
+
+// GetDecodedVariantFieldAsType returns the value of the variant field that matches the provided
+// predicate func as the provided generic argument, if the variant has the expected name.
+func GetDecodedVariantFieldAsType[T any](
+	decodedVariant *DecodedVariant,
+	variantName string,
+	fieldPredicateFn DecodedFieldPredicateFn,
+) (T, error) {
+	if !decodedVariant.Is(variantName) {
+		var t T
+
+		return t, ErrDecodedVariantNameMismatch.WithMsg("expected '%s'", variantName)
+	}
+
+	return GetDecodedFieldAsType[T](decodedVariant.Fields, fieldPredicateFn)
+}
+
 // GetDecodedFieldAsSliceOfType returns the value of the field that matches the provided predicate func
 // as a slice of the provided generic argument.
 func GetDecodedFieldAsSliceOfType[T any](
@@ -385,6 +525,11 @@ func convertSliceToType[T any](slice []any) ([]T, error) {
 type DecodedExtrinsic struct {
 	Version       byte
 	DecodedFields DecodedFields
+
+	// CallIndex is the index of the call of the extrinsic, which can be used for
+	// retrieving the call name from a CallRegistry. It is taken from the DecodedCall of the
+	// extrinsic, so it is not set if the call is decoded by an overridden FieldDecoder.
+	CallIndex types.CallIndex
 }
 
 // IsSigned returns true if the extrinsic is signed.
@@ -430,7 +575,7 @@ func (d *ExtrinsicDecoder) DecodeHex(hexEncodedExtrinsic string) (*DecodedExtrin
 		return nil, err
 	}
 
-	decoder := scale.NewDecoder(bytes.NewReader(extrinsicBytes))
+	decoder := scale.NewDecoderFromBytes(extrinsicBytes)
 
 	return d.Decode(decoder)
 }
@@ -495,6 +640,10 @@ func (d *ExtrinsicDecoder) Decode(decoder *scale.Decoder) (*DecodedExtrinsic, er
 		return nil, err
 	}
 
+	if call, ok := decodedCall.Value.(*DecodedCall); ok {
+		decodedExtrinsic.CallIndex = call.CallIndex
+	}
+
 	decodedFields = append(decodedFields, decodedCall)
 
 	decodedExtrinsic.DecodedFields = decodedFields
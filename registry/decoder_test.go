@@ -85,7 +85,62 @@ func Test_TypeDecoder_FieldDecodingError(t *testing.T) {
 
 	res, err := typeDecoder.Decode(decoder)
 	assert.ErrorIs(t, err, ErrTypeFieldDecoding)
+	assert.ErrorIs(t, err, ErrValueDecoding)
 	assert.Nil(t, res)
+
+	var decodeErr *scale.DecodeError
+
+	assert.True(t, errors.As(err, &decodeErr))
+	assert.Equal(t, "test_decoder_1.field_1", decodeErr.Path.String())
+	assert.Equal(t, 0, decodeErr.Offset)
+}
+
+func Test_TypeDecoder_DecodeErrorPath(t *testing.T) {
+	typeDecoder := &TypeDecoder{
+		Name: "Balances.Transfer",
+		Fields: []*Field{
+			{
+				Name: "sp_core.crypto.AccountId32.from",
+				FieldDecoder: &SliceDecoder{
+					ItemDecoder: &CompositeDecoder{
+						Fields: []*Field{
+							{
+								Name:         "value",
+								FieldDecoder: &ValueDecoder[types.U16]{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	decoder := scale.NewDecoderFromBytes([]byte{8, 1, 0, 2})
+
+	res, err := typeDecoder.Decode(decoder)
+	assert.ErrorIs(t, err, ErrTypeFieldDecoding)
+	assert.ErrorIs(t, err, ErrSliceItemDecoding)
+	assert.ErrorIs(t, err, ErrCompositeFieldDecoding)
+	assert.Nil(t, res)
+
+	var decodeErr *scale.DecodeError
+
+	assert.True(t, errors.As(err, &decodeErr))
+	assert.Equal(t, "Balances.Transfer.from[1].value", decodeErr.Path.String())
+	assert.Equal(t, 3, decodeErr.Offset)
+	assert.Equal(t, []byte{2}, decodeErr.Remaining)
+}
+
+func Test_WrapDecodeError(t *testing.T) {
+	decodeErr := &scale.DecodeError{Path: scale.Path{scale.NameSegment("field")}, Err: errors.New("error")}
+
+	err := WrapDecodeError(ErrValueDecoding, decodeErr)
+	assert.Equal(t, decodeErr, err)
+	assert.ErrorIs(t, err, ErrValueDecoding)
+	assert.EqualError(t, err, "decoding field at offset 0: value decoding: error")
+
+	err = WrapDecodeError(ErrValueDecoding, errors.New("error"))
+	assert.EqualError(t, err, "value decoding: error")
 }
 
 func Test_ProcessDecodedFieldValue(t *testing.T) {
@@ -258,6 +313,118 @@ func Test_GetDecodedFieldAsType_ValueTypeMismatch(t *testing.T) {
 	assert.Equal(t, types.U8(0), res)
 }
 
+func Test_VariantDecoder(t *testing.T) {
+	variantDecoder := &VariantDecoder{
+		FieldDecoderMap: map[byte]FieldDecoder{
+			0: &NoopDecoder{},
+			1: &CompositeDecoder{
+				FieldName: "Variant1",
+				Fields: []*Field{
+					{
+						Name:         "field",
+						FieldDecoder: &ValueDecoder[types.U16]{},
+						LookupIndex:  1,
+					},
+				},
+			},
+		},
+		VariantNameMap: map[byte]string{
+			0: "Variant0",
+			1: "Variant1",
+		},
+	}
+
+	res, err := variantDecoder.Decode(scale.NewDecoder(bytes.NewReader([]byte{0})))
+	assert.NoError(t, err)
+	assert.Equal(t, &DecodedVariant{Index: 0, Name: "Variant0"}, res)
+
+	res, err = variantDecoder.Decode(scale.NewDecoder(bytes.NewReader([]byte{1, 2, 0})))
+	assert.NoError(t, err)
+	assert.Equal(t, &DecodedVariant{
+		Index: 1,
+		Name:  "Variant1",
+		Fields: DecodedFields{
+			{Name: "field", Value: types.U16(2), LookupIndex: 1},
+		},
+	}, res)
+
+	encodedVariant, err := codec.Encode(res)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 0}, encodedVariant)
+
+	res, err = variantDecoder.Decode(scale.NewDecoder(bytes.NewReader([]byte{2})))
+	assert.ErrorIs(t, err, ErrVariantFieldDecoderNotFound)
+	assert.Nil(t, res)
+
+	res, err = variantDecoder.Decode(scale.NewDecoder(bytes.NewReader(nil)))
+	assert.ErrorIs(t, err, ErrVariantByteDecoding)
+	assert.Nil(t, res)
+
+	variantDecoder.FieldDecoderMap[1] = &ValueDecoder[types.U16]{}
+
+	res, err = variantDecoder.Decode(scale.NewDecoder(bytes.NewReader([]byte{1, 2, 0})))
+	assert.ErrorIs(t, err, ErrUnexpectedVariantValue)
+	assert.Nil(t, res)
+}
+
+func Test_GetDecodedFieldAsVariant(t *testing.T) {
+	decodedVariant := &DecodedVariant{
+		Index: 1,
+		Name:  "Variant1",
+		Fields: DecodedFields{
+			{Name: "field", Value: types.U16(2)},
+		},
+	}
+
+	decodedFields := testDataToDecodedFields([]any{types.U8(1), decodedVariant})
+
+	res, err := GetDecodedFieldAsVariant(decodedFields, func(fieldIndex int, _ *DecodedField) bool {
+		return fieldIndex == 1
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, decodedVariant, res)
+	assert.True(t, res.Is("Variant1"))
+	assert.False(t, res.Is("Variant0"))
+
+	variantName, err := GetDecodedFieldAsVariantName(decodedFields, func(fieldIndex int, _ *DecodedField) bool {
+		return fieldIndex == 1
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Variant1", variantName)
+
+	variantName, err = GetDecodedFieldAsVariantName(decodedFields, func(fieldIndex int, _ *DecodedField) bool {
+		return fieldIndex == 0
+	})
+	assert.ErrorIs(t, err, ErrDecodedFieldValueTypeMismatch)
+	assert.Empty(t, variantName)
+}
+
+func Test_GetDecodedVariantFieldAsType(t *testing.T) {
+	decodedVariant := &DecodedVariant{
+		Index: 1,
+		Name:  "Variant1",
+		Fields: DecodedFields{
+			{Name: "field", Value: types.U16(2)},
+		},
+	}
+
+	fieldPredicateFn := func(_ int, field *DecodedField) bool {
+		return field.Name == "field"
+	}
+
+	res, err := GetDecodedVariantFieldAsType[types.U16](decodedVariant, "Variant1", fieldPredicateFn)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U16(2), res)
+
+	res, err = GetDecodedVariantFieldAsType[types.U16](decodedVariant, "Variant0", fieldPredicateFn)
+	assert.ErrorIs(t, err, ErrDecodedVariantNameMismatch)
+	assert.Equal(t, types.U16(0), res)
+
+	res, err = GetDecodedVariantFieldAsType[types.U16](nil, "Variant1", fieldPredicateFn)
+	assert.ErrorIs(t, err, ErrDecodedVariantNameMismatch)
+	assert.Equal(t, types.U16(0), res)
+}
+
 func Test_GetDecodedFieldAsSliceOfType(t *testing.T) {
 	testData := []any{
 		types.U8(1),
@@ -369,6 +536,24 @@ func Test_ExtrinsicDecoder_DecodeHex(t *testing.T) {
 	res, err := extrinsicDecoder.DecodeHex("0xb10184008eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a480118346322ed93ad7d2583ab3e4b71acd66cc1fce77cb225624c8eb00977681468aec33b933606ed8c2eaa75b84278c42415d491f89c5e79db6910986c1b95f486e401e0000000000431")
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
+
+	remarkCallIndex, err := meta.FindCallIndex("System.remark")
+	assert.NoError(t, err)
+	assert.Equal(t, remarkCallIndex, res.CallIndex)
+
+	decodedCall, ok := res.DecodedFields[len(res.DecodedFields)-1].Value.(*DecodedCall)
+	assert.True(t, ok)
+	assert.True(t, decodedCall.Is("System.remark"))
+	assert.Equal(t, remarkCallIndex, decodedCall.CallIndex)
+
+	// The call is decoded by the provided decoder, which reads the whole extrinsic.
+	extrinsicBytes := codec.MustHexDecodeString("0xb10184008eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a480118346322ed93ad7d2583ab3e4b71acd66cc1fce77cb225624c8eb00977681468aec33b933606ed8c2eaa75b84278c42415d491f89c5e79db6910986c1b95f486e401e0000000000431")
+
+	decoder := scale.NewDecoderFromBytes(extrinsicBytes)
+
+	_, err = extrinsicDecoder.Decode(decoder)
+	assert.NoError(t, err)
+	assert.Equal(t, len(extrinsicBytes), decoder.Offset())
 }
 
 func Test_ExtrinsicDecoder_NilDecoder(t *testing.T) {
@@ -0,0 +1,300 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	dispatchErrorModuleVariantName        = "Module"
+	dispatchErrorTokenVariantName         = "Token"
+	dispatchErrorArithmeticVariantName    = "Arithmetic"
+	dispatchErrorTransactionalVariantName = "Transactional"
+
+	moduleErrorIndexFieldName = "index"
+	moduleErrorErrorFieldName = "error"
+)
+
+// DispatchError holds a types.DispatchError and, if it is a module error, its decoded information.
+//
+// It implements the error interface and unwraps to the ModuleError, if any, which allows
+// using errors.As and errors.Is against specific pallet errors.
+type DispatchError struct {
+	Raw types.DispatchError
+
+	// ModuleError holds the decoded module error, set only if Raw.IsModule is true.
+	ModuleError *ModuleError
+}
+
+// Error returns a description of the dispatch error, for example:
+//
+//	module error: Balances.InsufficientBalance
+//	token error: FundsUnavailable
+//	bad origin
+func (d *DispatchError) Error() string {
+	raw := d.Raw
+
+	switch {
+	case raw.IsModule:
+		if d.ModuleError != nil {
+			return fmt.Sprintf("module error: %s", d.ModuleError.Name)
+		}
+
+		return fmt.Sprintf("module error: module '%d', error '%d'", raw.ModuleError.Index, raw.ModuleError.Error[0])
+	case raw.IsToken:
+		return fmt.Sprintf("token error: %s", getTokenErrorName(raw.TokenError))
+	case raw.IsArithmetic:
+		return fmt.Sprintf("arithmetic error: %s", getArithmeticErrorName(raw.ArithmeticError))
+	case raw.IsTransactional:
+		return fmt.Sprintf("transactional error: %s", getTransactionalErrorName(raw.TransactionalError))
+	case raw.IsOther:
+		return "other"
+	case raw.IsCannotLookup:
+		return "cannot lookup"
+	case raw.IsBadOrigin:
+		return "bad origin"
+	case raw.IsConsumerRemaining:
+		return "consumer remaining"
+	case raw.IsNoProviders:
+		return "no providers"
+	case raw.IsTooManyConsumers:
+		return "too many consumers"
+	case raw.IsExhausted:
+		return "exhausted"
+	case raw.IsCorruption:
+		return "corruption"
+	case raw.IsUnavailable:
+		return "unavailable"
+	case raw.IsRootNotAllowed:
+		return "root not allowed"
+	default:
+		return "unknown dispatch error"
+	}
+}
+
+// Unwrap returns the decoded module error, if any.
+func (d *DispatchError) Unwrap() error {
+	if d.ModuleError == nil {
+		return nil
+	}
+
+	return d.ModuleError
+}
+
+// Is reports whether the target is a DispatchError with the same raw dispatch error, for example:
+//
+//	errors.Is(err, &registry.DispatchError{Raw: types.DispatchError{IsBadOrigin: true}})
+func (d *DispatchError) Is(target error) bool {
+	t, ok := target.(*DispatchError)
+
+	if !ok || t == nil {
+		return false
+	}
+
+	return t.Raw == d.Raw
+}
+
+// DecodeDispatchError returns the DispatchError for the provided types.DispatchError, decoding
+// its module error, if any.
+func (e ErrorRegistry) DecodeDispatchError(dispatchError types.DispatchError) (*DispatchError, error) {
+	return e.decodeDispatchError(dispatchError, e.DecodeModuleError)
+}
+
+// DecodeDispatchErrorWithDecoder is like DecodeDispatchError, the module error is decoded with
+// DecodeModuleErrorWithDecoder.
+func (e ErrorRegistry) DecodeDispatchErrorWithDecoder(
+	dispatchError types.DispatchError,
+	decoder *scale.Decoder,
+) (*DispatchError, error) {
+	return e.decodeDispatchError(dispatchError, func(moduleError types.ModuleError) (*ModuleError, error) {
+		return e.DecodeModuleErrorWithDecoder(moduleError, decoder)
+	})
+}
+
+func (e ErrorRegistry) decodeDispatchError(
+	dispatchError types.DispatchError,
+	decodeModuleError func(moduleError types.ModuleError) (*ModuleError, error),
+) (*DispatchError, error) {
+	res := &DispatchError{
+		Raw: dispatchError,
+	}
+
+	if !dispatchError.IsModule {
+		return res, nil
+	}
+
+	moduleError, err := decodeModuleError(dispatchError.ModuleError)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.ModuleError = moduleError
+
+	return res, nil
+}
+
+// GetDecodedFieldAsDispatchError returns the value of the field that matches the provided predicate func
+// as a types.DispatchError.
+func GetDecodedFieldAsDispatchError(
+	decodedFields DecodedFields,
+	fieldPredicateFn DecodedFieldPredicateFn,
+) (types.DispatchError, error) {
+	return ProcessDecodedFieldValue(decodedFields, fieldPredicateFn, getDispatchErrorFromValue)
+}
+
+func getDispatchErrorFromValue(value any) (types.DispatchError, error) {
+	var dispatchError types.DispatchError
+
+	dispatchErrorVariant, ok := value.(*DecodedVariant)
+
+	if !ok {
+		return dispatchError, ErrUnexpectedDispatchErrorValue.WithMsg("%v", value)
+	}
+
+	if len(dispatchErrorVariant.Fields) == 0 {
+		if err := codec.Decode([]byte{dispatchErrorVariant.Index}, &dispatchError); err != nil {
+			return dispatchError, ErrDispatchErrorDecoding.Wrap(err)
+		}
+
+		return dispatchError, nil
+	}
+
+	if len(dispatchErrorVariant.Fields) != 1 {
+		return dispatchError, ErrUnexpectedDispatchErrorValue.WithMsg("variant '%s'", dispatchErrorVariant.Name)
+	}
+
+	variantField := dispatchErrorVariant.Fields[0]
+
+	if dispatchErrorVariant.Is(dispatchErrorModuleVariantName) {
+		moduleError, err := getModuleErrorFromValue(variantField.Value)
+
+		if err != nil {
+			return dispatchError, err
+		}
+
+		dispatchError.IsModule = true
+		dispatchError.ModuleError = moduleError
+
+		return dispatchError, nil
+	}
+
+	switch dispatchErrorVariant.Name {
+	case dispatchErrorTokenVariantName, dispatchErrorArithmeticVariantName, dispatchErrorTransactionalVariantName:
+	default:
+		return dispatchError, ErrUnexpectedDispatchErrorValue.WithMsg("unsupported variant '%s'", dispatchErrorVariant.Name)
+	}
+
+	// The remaining supported variants hold an inner variant without fields, such as the TokenError.
+	innerVariant, ok := variantField.Value.(*DecodedVariant)
+
+	if !ok || len(innerVariant.Fields) != 0 {
+		return dispatchError, ErrUnexpectedDispatchErrorValue.WithMsg("field '%s'", variantField.Name)
+	}
+
+	if err := codec.Decode([]byte{dispatchErrorVariant.Index, innerVariant.Index}, &dispatchError); err != nil {
+		return dispatchError, ErrDispatchErrorDecoding.Wrap(err)
+	}
+
+	return dispatchError, nil
+}
+
+func getModuleErrorFromValue(value any) (types.ModuleError, error) {
+	var moduleError types.ModuleError
+
+	moduleErrorFields, ok := value.(DecodedFields)
+
+	if !ok {
+		return moduleError, ErrUnexpectedDispatchErrorValue.WithMsg("module error %v", value)
+	}
+
+	moduleIndex, err := GetDecodedFieldAsType[types.U8](
+		moduleErrorFields,
+		FieldNamePredicate(moduleErrorIndexFieldName),
+	)
+
+	if err != nil {
+		return moduleError, err
+	}
+
+	moduleError.Index = moduleIndex
+
+	errorIndex, err := GetDecodedFieldAsSliceOfType[types.U8](
+		moduleErrorFields,
+		FieldNamePredicate(moduleErrorErrorFieldName),
+	)
+
+	if err == nil {
+		copy(moduleError.Error[:], errorIndex)
+
+		return moduleError, nil
+	}
+
+	// Older runtimes use a single byte for the error index.
+	legacyErrorIndex, err := GetDecodedFieldAsType[types.U8](
+		moduleErrorFields,
+		FieldNamePredicate(moduleErrorErrorFieldName),
+	)
+
+	if err != nil {
+		return moduleError, err
+	}
+
+	moduleError.Error[0] = legacyErrorIndex
+
+	return moduleError, nil
+}
+
+func getTokenErrorName(tokenError types.TokenError) string {
+	switch {
+	case tokenError.IsNoFunds:
+		return "FundsUnavailable"
+	case tokenError.IsWouldDie:
+		return "OnlyProvider"
+	case tokenError.IsBelowMinimum:
+		return "BelowMinimum"
+	case tokenError.IsCannotCreate:
+		return "CannotCreate"
+	case tokenError.IsUnknownAsset:
+		return "UnknownAsset"
+	case tokenError.IsFrozen:
+		return "Frozen"
+	case tokenError.IsUnsupported:
+		return "Unsupported"
+	case tokenError.IsCannotCreateHold:
+		return "CannotCreateHold"
+	case tokenError.IsNotExpendable:
+		return "NotExpendable"
+	case tokenError.IsBlocked:
+		return "Blocked"
+	default:
+		return "Unknown"
+	}
+}
+
+func getArithmeticErrorName(arithmeticError types.ArithmeticError) string {
+	switch {
+	case arithmeticError.IsUnderflow:
+		return "Underflow"
+	case arithmeticError.IsOverflow:
+		return "Overflow"
+	case arithmeticError.IsDivisionByZero:
+		return "DivisionByZero"
+	default:
+		return "Unknown"
+	}
+}
+
+func getTransactionalErrorName(transactionalError types.TransactionalError) string {
+	switch {
+	case transactionalError.IsLimitReached:
+		return "LimitReached"
+	case transactionalError.IsNoLayer:
+		return "NoLayer"
+	default:
+		return "Unknown"
+	}
+}
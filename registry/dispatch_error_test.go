@@ -0,0 +1,267 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorRegistry_DecodeDispatchError(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	reg, err := NewFactory().CreateErrorRegistry(&meta)
+	assert.NoError(t, err)
+
+	var balancesIndex types.U8
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Name == "Balances" {
+			balancesIndex = pallet.Index
+		}
+	}
+
+	res, err := reg.DecodeDispatchError(types.DispatchError{
+		IsModule: true,
+		ModuleError: types.ModuleError{
+			Index: balancesIndex,
+			Error: [4]types.U8{2},
+		},
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, res.ModuleError)
+	assert.Equal(t, "module error: Balances.InsufficientBalance", res.Error())
+
+	var wrappedErr error = res
+
+	var moduleError *ModuleError
+
+	assert.True(t, errors.As(wrappedErr, &moduleError))
+	assert.Equal(t, "Balances", moduleError.PalletName)
+	assert.Equal(t, "InsufficientBalance", moduleError.ErrorName)
+	assert.ErrorIs(t, wrappedErr, &ModuleError{PalletName: "Balances", ErrorName: "InsufficientBalance"})
+
+	res, err = reg.DecodeDispatchError(types.DispatchError{IsBadOrigin: true})
+	assert.NoError(t, err)
+	assert.Nil(t, res.ModuleError)
+	assert.Nil(t, res.Unwrap())
+	assert.Equal(t, "bad origin", res.Error())
+	assert.ErrorIs(t, res, &DispatchError{Raw: types.DispatchError{IsBadOrigin: true}})
+	assert.NotErrorIs(t, res, &DispatchError{Raw: types.DispatchError{IsCannotLookup: true}})
+}
+
+func TestErrorRegistry_DecodeDispatchError_ModuleErrorDecodingError(t *testing.T) {
+	reg := ErrorRegistry{}
+
+	res, err := reg.DecodeDispatchError(types.DispatchError{
+		IsModule: true,
+		ModuleError: types.ModuleError{
+			Index: 1,
+			Error: [4]types.U8{2},
+		},
+	})
+	assert.ErrorIs(t, err, ErrErrorDecoderNotFound)
+	assert.Nil(t, res)
+}
+
+func TestDispatchError_Error(t *testing.T) {
+	tests := []struct {
+		dispatchError types.DispatchError
+		expected      string
+	}{
+		{types.DispatchError{IsOther: true}, "other"},
+		{types.DispatchError{IsCannotLookup: true}, "cannot lookup"},
+		{types.DispatchError{IsBadOrigin: true}, "bad origin"},
+		{
+			types.DispatchError{IsModule: true, ModuleError: types.ModuleError{Index: 1, Error: [4]types.U8{2}}},
+			"module error: module '1', error '2'",
+		},
+		{types.DispatchError{IsConsumerRemaining: true}, "consumer remaining"},
+		{types.DispatchError{IsNoProviders: true}, "no providers"},
+		{types.DispatchError{IsTooManyConsumers: true}, "too many consumers"},
+		{
+			types.DispatchError{IsToken: true, TokenError: types.TokenError{IsNoFunds: true}},
+			"token error: FundsUnavailable",
+		},
+		{
+			types.DispatchError{IsToken: true, TokenError: types.TokenError{IsBlocked: true}},
+			"token error: Blocked",
+		},
+		{
+			types.DispatchError{IsArithmetic: true, ArithmeticError: types.ArithmeticError{IsOverflow: true}},
+			"arithmetic error: Overflow",
+		},
+		{
+			types.DispatchError{IsTransactional: true, TransactionalError: types.TransactionalError{IsNoLayer: true}},
+			"transactional error: NoLayer",
+		},
+		{types.DispatchError{IsExhausted: true}, "exhausted"},
+		{types.DispatchError{IsCorruption: true}, "corruption"},
+		{types.DispatchError{IsUnavailable: true}, "unavailable"},
+		{types.DispatchError{IsRootNotAllowed: true}, "root not allowed"},
+		{types.DispatchError{}, "unknown dispatch error"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			dispatchError := &DispatchError{Raw: test.dispatchError}
+
+			assert.Equal(t, test.expected, dispatchError.Error())
+		})
+	}
+}
+
+func TestGetDecodedFieldAsDispatchError(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected types.DispatchError
+	}{
+		{
+			name:     "no fields",
+			value:    &DecodedVariant{Index: 2, Name: "BadOrigin"},
+			expected: types.DispatchError{IsBadOrigin: true},
+		},
+		{
+			name: "module",
+			value: &DecodedVariant{
+				Index: 3,
+				Name:  "Module",
+				Fields: DecodedFields{
+					{
+						Name: "sp_runtime.ModuleError.ModuleError",
+						Value: DecodedFields{
+							{Name: "index", Value: types.U8(5)},
+							{Name: "error", Value: []any{types.U8(2), types.U8(0), types.U8(0), types.U8(0)}},
+						},
+					},
+				},
+			},
+			expected: types.DispatchError{
+				IsModule:    true,
+				ModuleError: types.ModuleError{Index: 5, Error: [4]types.U8{2}},
+			},
+		},
+		{
+			name: "legacy module",
+			value: &DecodedVariant{
+				Index: 3,
+				Name:  "Module",
+				Fields: DecodedFields{
+					{
+						Name: "sp_runtime.ModuleError.ModuleError",
+						Value: DecodedFields{
+							{Name: "index", Value: types.U8(5)},
+							{Name: "error", Value: types.U8(3)},
+						},
+					},
+				},
+			},
+			expected: types.DispatchError{
+				IsModule:    true,
+				ModuleError: types.ModuleError{Index: 5, Error: [4]types.U8{3}},
+			},
+		},
+		{
+			name:     "token",
+			value:    newTestDispatchErrorVariant(7, "Token", "sp_runtime.TokenError.TokenError", 1, "OnlyProvider"),
+			expected: types.DispatchError{IsToken: true, TokenError: types.TokenError{IsWouldDie: true}},
+		},
+		{
+			name: "arithmetic",
+			value: newTestDispatchErrorVariant(
+				8,
+				"Arithmetic",
+				"sp_arithmetic.ArithmeticError.ArithmeticError",
+				2,
+				"DivisionByZero",
+			),
+			expected: types.DispatchError{IsArithmetic: true, ArithmeticError: types.ArithmeticError{IsDivisionByZero: true}},
+		},
+		{
+			name: "transactional",
+			value: newTestDispatchErrorVariant(
+				9,
+				"Transactional",
+				"sp_runtime.TransactionalError.TransactionalError",
+				0,
+				"LimitReached",
+			),
+			expected: types.DispatchError{
+				IsTransactional:    true,
+				TransactionalError: types.TransactionalError{IsLimitReached: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fields := DecodedFields{{Name: "sp_runtime.DispatchError.dispatch_error", Value: test.value}}
+
+			res, err := GetDecodedFieldAsDispatchError(fields, FieldNamePredicate("dispatch_error"))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestGetDecodedFieldAsDispatchError_UnexpectedValue(t *testing.T) {
+	values := []any{
+		"error",
+		byte(2),
+		&DecodedVariant{
+			Index: 7,
+			Name:  "Token",
+			Fields: DecodedFields{
+				{Name: "sp_runtime.TokenError.TokenError", Value: "error"},
+			},
+		},
+		&DecodedVariant{
+			Index: 7,
+			Name:  "Token",
+			Fields: DecodedFields{
+				{Name: "first", Value: &DecodedVariant{}},
+				{Name: "second", Value: &DecodedVariant{}},
+			},
+		},
+		&DecodedVariant{
+			Index:  3,
+			Name:   "Module",
+			Fields: DecodedFields{{Name: "sp_runtime.ModuleError.ModuleError", Value: byte(0)}},
+		},
+		newTestDispatchErrorVariant(14, "Trie", "sp_runtime.proving_trie.TrieError.TrieError", 0, "InvalidStateRoot"),
+	}
+
+	for _, value := range values {
+		fields := DecodedFields{{Name: "dispatch_error", Value: value}}
+
+		_, err := GetDecodedFieldAsDispatchError(fields, FieldNamePredicate("dispatch_error"))
+		assert.ErrorIs(t, err, ErrUnexpectedDispatchErrorValue)
+	}
+
+}
+
+func newTestDispatchErrorVariant(
+	index byte,
+	name string,
+	innerFieldName string,
+	innerIndex byte,
+	innerName string,
+) *DecodedVariant {
+	return &DecodedVariant{
+		Index: index,
+		Name:  name,
+		Fields: DecodedFields{
+			{
+				Name:  innerFieldName,
+				Value: &DecodedVariant{Index: innerIndex, Name: innerName},
+			},
+		},
+	}
+}
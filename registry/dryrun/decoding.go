@@ -0,0 +1,205 @@
+package dryrun
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// unwrapDryRunResult returns the encoded effects of a DryRunApi result,
+// or the according error if the runtime API returned one.
+func unwrapDryRunResult(res []byte) ([]byte, error) {
+	if len(res) == 0 {
+		return nil, ErrDryRunResultDecoding.WithMsg("empty result")
+	}
+
+	switch res[0] {
+	case 0:
+		return res[1:], nil
+	case 1:
+		if len(res) < 2 {
+			return nil, ErrDryRunResultDecoding.WithMsg("missing error variant")
+		}
+
+		switch res[1] {
+		case 0:
+			return nil, ErrDryRunAPIUnimplemented
+		case 1:
+			return nil, ErrDryRunAPIVersionedConversionFailed
+		default:
+			return nil, ErrDryRunAPIErrorUnknown.WithMsg("error variant '%d'", res[1])
+		}
+	default:
+		return nil, ErrDryRunResultDecoding.WithMsg("invalid result variant '%d'", res[0])
+	}
+}
+
+// decodeCallDryRunEffects decodes the effects returned by DryRunApi_dry_run_call.
+func (d *dryRunner) decodeCallDryRunEffects(b []byte) (*CallDryRunEffects, error) {
+	decoder := types.NewDecoderFromBytes(b, d.serDeOptions)
+
+	var executionResult types.DispatchResultWithPostInfo
+
+	if err := decoder.Decode(&executionResult); err != nil {
+		return nil, ErrDryRunResultDecoding.Wrap(fmt.Errorf("execution result: %w", err))
+	}
+
+	emittedEvents, err := d.decodeEvents(decoder)
+
+	if err != nil {
+		return nil, ErrDryRunResultDecoding.Wrap(err)
+	}
+
+	hasLocalXcm, err := decoder.ReadOneByte()
+
+	if err != nil {
+		return nil, ErrDryRunResultDecoding.Wrap(fmt.Errorf("local xcm option: %w", err))
+	}
+
+	var localXcm any
+
+	if hasLocalXcm == 1 {
+		localXcm, err = decodeWithFieldDecoder(d.xcmDecoder, decoder)
+
+		if err != nil {
+			return nil, ErrDryRunResultDecoding.Wrap(fmt.Errorf("local xcm: %w", err))
+		}
+	}
+
+	forwardedXcms, err := d.decodeForwardedXcms(decoder)
+
+	if err != nil {
+		return nil, ErrDryRunResultDecoding.Wrap(err)
+	}
+
+	return &CallDryRunEffects{
+		ExecutionResult: executionResult,
+		EmittedEvents:   emittedEvents,
+		LocalXcm:        localXcm,
+		ForwardedXcms:   forwardedXcms,
+	}, nil
+}
+
+// decodeXcmDryRunEffects decodes the effects returned by DryRunApi_dry_run_xcm.
+func (d *dryRunner) decodeXcmDryRunEffects(b []byte) (*XcmDryRunEffects, error) {
+	decoder := types.NewDecoderFromBytes(b, d.serDeOptions)
+
+	executionResult, err := decodeWithFieldDecoder(d.xcmOutcomeDecoder, decoder)
+
+	if err != nil {
+		return nil, ErrDryRunResultDecoding.Wrap(fmt.Errorf("execution result: %w", err))
+	}
+
+	emittedEvents, err := d.decodeEvents(decoder)
+
+	if err != nil {
+		return nil, ErrDryRunResultDecoding.Wrap(err)
+	}
+
+	forwardedXcms, err := d.decodeForwardedXcms(decoder)
+
+	if err != nil {
+		return nil, ErrDryRunResultDecoding.Wrap(err)
+	}
+
+	return &XcmDryRunEffects{
+		ExecutionResult: executionResult,
+		EmittedEvents:   emittedEvents,
+		ForwardedXcms:   forwardedXcms,
+	}, nil
+}
+
+// decodeEvents decodes a vector of runtime events. Unlike the events found in storage,
+// these do not have a phase or topics.
+func (d *dryRunner) decodeEvents(decoder *scale.Decoder) ([]*parser.Event, error) {
+	eventsCount, err := decoder.DecodeUintCompact()
+
+	if err != nil {
+		return nil, ErrEventsCountDecoding.Wrap(err)
+	}
+
+	var events []*parser.Event
+
+	for i := uint64(0); i < eventsCount.Uint64(); i++ {
+		var eventID types.EventID
+
+		if err := decoder.Decode(&eventID); err != nil {
+			return nil, ErrEventIDDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
+		}
+
+		eventDecoder, ok := d.eventRegistry[eventID]
+
+		if !ok {
+			return nil, ErrEventDecoderNotFound.WithMsg("event #%d with ID: %v", i, eventID)
+		}
+
+		eventFields, err := eventDecoder.Decode(decoder)
+
+		if err != nil {
+			return nil, ErrEventFieldsDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
+		}
+
+		events = append(events, &parser.Event{
+			Name:    eventDecoder.Name,
+			Fields:  eventFields,
+			EventID: eventID,
+		})
+	}
+
+	return events, nil
+}
+
+// decodeForwardedXcms decodes a vector of (VersionedLocation, Vec<VersionedXcm<()>>).
+func (d *dryRunner) decodeForwardedXcms(decoder *scale.Decoder) ([]*ForwardedXcms, error) {
+	destinationsCount, err := decoder.DecodeUintCompact()
+
+	if err != nil {
+		return nil, ErrXcmDecoding.Wrap(fmt.Errorf("forwarded xcms count: %w", err))
+	}
+
+	var forwardedXcms []*ForwardedXcms
+
+	for i := uint64(0); i < destinationsCount.Uint64(); i++ {
+		destination, err := decodeWithFieldDecoder(d.locationDecoder, decoder)
+
+		if err != nil {
+			return nil, ErrXcmDecoding.Wrap(fmt.Errorf("forwarded xcms #%d destination: %w", i, err))
+		}
+
+		messagesCount, err := decoder.DecodeUintCompact()
+
+		if err != nil {
+			return nil, ErrXcmDecoding.Wrap(fmt.Errorf("forwarded xcms #%d messages count: %w", i, err))
+		}
+
+		var messages []any
+
+		for j := uint64(0); j < messagesCount.Uint64(); j++ {
+			message, err := decodeWithFieldDecoder(d.xcmDecoder, decoder)
+
+			if err != nil {
+				return nil, ErrXcmDecoding.Wrap(fmt.Errorf("forwarded xcms #%d message #%d: %w", i, j, err))
+			}
+
+			messages = append(messages, message)
+		}
+
+		forwardedXcms = append(forwardedXcms, &ForwardedXcms{
+			Destination: destination,
+			Messages:    messages,
+		})
+	}
+
+	return forwardedXcms, nil
+}
+
+func decodeWithFieldDecoder(fieldDecoder registry.FieldDecoder, decoder *scale.Decoder) (any, error) {
+	if fieldDecoder == nil {
+		return nil, ErrXcmTypeNotFound
+	}
+
+	return fieldDecoder.Decode(decoder)
+}
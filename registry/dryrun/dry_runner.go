@@ -0,0 +1,543 @@
+package dryrun
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+)
+
+const (
+	dryRunCallRuntimeAPIMethod = "DryRunApi_dry_run_call"
+	dryRunXcmRuntimeAPIMethod  = "DryRunApi_dry_run_xcm"
+)
+
+//go:generate mockery --name DryRunner --structname DryRunnerMock --filename dry_runner_mock.go --inpackage
+
+// DryRunner is the interface used for executing extrinsics, calls and XCMs without
+// including them in a block, in order to check whether they would succeed.
+type DryRunner interface {
+	DryRunExtrinsic(xt extrinsic.Extrinsic, blockHash types.Hash) (*ExtrinsicResult, error)
+	DryRunExtrinsicCtx(ctx context.Context, xt extrinsic.Extrinsic, blockHash types.Hash) (*ExtrinsicResult, error)
+	DryRunExtrinsicLatest(xt extrinsic.Extrinsic) (*ExtrinsicResult, error)
+	DryRunExtrinsicLatestCtx(ctx context.Context, xt extrinsic.Extrinsic) (*ExtrinsicResult, error)
+
+	DryRunCall(origin any, call types.Call, resultXcmsVersion types.U32, blockHash types.Hash) (*CallDryRunEffects, error)
+	DryRunCallCtx(
+		ctx context.Context,
+		origin any,
+		call types.Call,
+		resultXcmsVersion types.U32,
+		blockHash types.Hash,
+	) (*CallDryRunEffects, error)
+	DryRunCallLatest(origin any, call types.Call, resultXcmsVersion types.U32) (*CallDryRunEffects, error)
+	DryRunCallLatestCtx(
+		ctx context.Context,
+		origin any,
+		call types.Call,
+		resultXcmsVersion types.U32,
+	) (*CallDryRunEffects, error)
+
+	DryRunXcm(originLocation any, xcm any, blockHash types.Hash) (*XcmDryRunEffects, error)
+	DryRunXcmCtx(ctx context.Context, originLocation any, xcm any, blockHash types.Hash) (*XcmDryRunEffects, error)
+	DryRunXcmLatest(originLocation any, xcm any) (*XcmDryRunEffects, error)
+	DryRunXcmLatestCtx(ctx context.Context, originLocation any, xcm any) (*XcmDryRunEffects, error)
+}
+
+// ExtrinsicResult holds the result of an extrinsic that was executed via system_dryRun.
+type ExtrinsicResult struct {
+	Result types.ApplyExtrinsicResult
+
+	// DispatchError holds the dispatch error, if the extrinsic dispatch failed.
+	DispatchError *registry.DispatchError
+}
+
+// ForwardedXcms holds the decoded XCMs that were sent to a particular destination.
+type ForwardedXcms struct {
+	Destination any
+	Messages    []any
+}
+
+// CallDryRunEffects holds the effects of a call that was executed via the DryRunApi runtime API.
+type CallDryRunEffects struct {
+	ExecutionResult types.DispatchResultWithPostInfo
+
+	// DispatchError holds the dispatch error, if the call dispatch failed.
+	DispatchError *registry.DispatchError
+
+	EmittedEvents []*parser.Event
+
+	// LocalXcm holds the decoded XCM that was executed locally, if any.
+	LocalXcm any
+
+	ForwardedXcms []*ForwardedXcms
+}
+
+// XcmDryRunEffects holds the effects of an XCM that was executed via the DryRunApi runtime API.
+type XcmDryRunEffects struct {
+	// ExecutionResult holds the decoded XCM outcome.
+	ExecutionResult any
+
+	EmittedEvents []*parser.Event
+
+	ForwardedXcms []*ForwardedXcms
+}
+
+// dryRunner implements the DryRunner interface.
+type dryRunner struct {
+	systemRPC system.System
+	stateRPC  state.State
+
+	registryFactory registry.Factory
+
+	// internalStateMu guards the registries, options and decoders below, which can be updated while
+	// other dry run results are being decoded. It also serializes the use of the registry factory.
+	internalStateMu sync.RWMutex
+
+	eventRegistry registry.EventRegistry
+	errorRegistry registry.ErrorRegistry
+
+	// serDeOptions are the serialise and deserialize options derived from the metadata.
+	serDeOptions types.SerDeOptions
+
+	// The following decoders are nil if the types are not present in the metadata.
+	xcmDecoder        registry.FieldDecoder
+	locationDecoder   registry.FieldDecoder
+	xcmOutcomeDecoder registry.FieldDecoder
+}
+
+// NewDryRunner creates a new DryRunner.
+func NewDryRunner(
+	systemRPC system.System,
+	stateRPC state.State,
+	registryFactory registry.Factory,
+) (DryRunner, error) {
+	runner := &dryRunner{
+		systemRPC:       systemRPC,
+		stateRPC:        stateRPC,
+		registryFactory: registryFactory,
+	}
+
+	if err := runner.updateInternalState(context.Background(), nil); err != nil {
+		return nil, ErrInternalStateUpdate.Wrap(err)
+	}
+
+	return runner, nil
+}
+
+// NewDefaultDryRunner returns a DryRunner with a default registry factory.
+func NewDefaultDryRunner(
+	systemRPC system.System,
+	stateRPC state.State,
+	fieldOverrides ...registry.FieldOverride,
+) (DryRunner, error) {
+	return NewDryRunner(systemRPC, stateRPC, registry.NewFactory(fieldOverrides...))
+}
+
+// DryRunExtrinsic executes the provided extrinsic at the given block via system_dryRun.
+func (d *dryRunner) DryRunExtrinsic(xt extrinsic.Extrinsic, blockHash types.Hash) (*ExtrinsicResult, error) {
+	return d.DryRunExtrinsicCtx(context.Background(), xt, blockHash)
+}
+
+// DryRunExtrinsicCtx is like DryRunExtrinsic, the RPC requests are bound to the provided context.
+func (d *dryRunner) DryRunExtrinsicCtx(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	blockHash types.Hash,
+) (*ExtrinsicResult, error) {
+	return d.dryRunExtrinsic(ctx, xt, &blockHash)
+}
+
+// DryRunExtrinsicLatest executes the provided extrinsic at the latest block via system_dryRun.
+func (d *dryRunner) DryRunExtrinsicLatest(xt extrinsic.Extrinsic) (*ExtrinsicResult, error) {
+	return d.DryRunExtrinsicLatestCtx(context.Background(), xt)
+}
+
+// DryRunExtrinsicLatestCtx is like DryRunExtrinsicLatest, the RPC requests are bound to the provided context.
+func (d *dryRunner) DryRunExtrinsicLatestCtx(ctx context.Context, xt extrinsic.Extrinsic) (*ExtrinsicResult, error) {
+	return d.dryRunExtrinsic(ctx, xt, nil)
+}
+
+func (d *dryRunner) dryRunExtrinsic(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	blockHash *types.Hash,
+) (*ExtrinsicResult, error) {
+	var (
+		res *types.ApplyExtrinsicResult
+		err error
+	)
+
+	if blockHash == nil {
+		res, err = d.systemRPC.DryRunLatestCtx(ctx, xt)
+	} else {
+		res, err = d.systemRPC.DryRunCtx(ctx, xt, *blockHash)
+	}
+
+	if err != nil {
+		return nil, ErrExtrinsicDryRun.Wrap(err)
+	}
+
+	extrinsicResult := &ExtrinsicResult{
+		Result: *res,
+	}
+
+	if !res.IsOk || res.AsOk.Ok {
+		return extrinsicResult, nil
+	}
+
+	dispatchError, err := d.decodeDispatchError(ctx, res.AsOk.Error, blockHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	extrinsicResult.DispatchError = dispatchError
+
+	return extrinsicResult, nil
+}
+
+// DryRunCall executes the provided call at the given block via the DryRunApi runtime API.
+//
+// The origin is encoded as is, and it's expected to be a value that encodes to the OriginCaller of the runtime,
+// such as the ones returned by NewRootOrigin, NewSignedOrigin or NewNoneOrigin.
+//
+// The resultXcmsVersion is the XCM version used for the returned XCMs.
+func (d *dryRunner) DryRunCall(
+	origin any,
+	call types.Call,
+	resultXcmsVersion types.U32,
+	blockHash types.Hash,
+) (*CallDryRunEffects, error) {
+	return d.DryRunCallCtx(context.Background(), origin, call, resultXcmsVersion, blockHash)
+}
+
+// DryRunCallCtx is like DryRunCall, the RPC requests are bound to the provided context.
+func (d *dryRunner) DryRunCallCtx(
+	ctx context.Context,
+	origin any,
+	call types.Call,
+	resultXcmsVersion types.U32,
+	blockHash types.Hash,
+) (*CallDryRunEffects, error) {
+	return d.dryRunCall(ctx, origin, call, resultXcmsVersion, &blockHash)
+}
+
+// DryRunCallLatest executes the provided call at the latest block via the DryRunApi runtime API.
+//
+// See DryRunCall for more details on the arguments.
+func (d *dryRunner) DryRunCallLatest(
+	origin any,
+	call types.Call,
+	resultXcmsVersion types.U32,
+) (*CallDryRunEffects, error) {
+	return d.DryRunCallLatestCtx(context.Background(), origin, call, resultXcmsVersion)
+}
+
+// DryRunCallLatestCtx is like DryRunCallLatest, the RPC requests are bound to the provided context.
+func (d *dryRunner) DryRunCallLatestCtx(
+	ctx context.Context,
+	origin any,
+	call types.Call,
+	resultXcmsVersion types.U32,
+) (*CallDryRunEffects, error) {
+	return d.dryRunCall(ctx, origin, call, resultXcmsVersion, nil)
+}
+
+func (d *dryRunner) dryRunCall(
+	ctx context.Context,
+	origin any,
+	call types.Call,
+	resultXcmsVersion types.U32,
+	blockHash *types.Hash,
+) (*CallDryRunEffects, error) {
+	res, err := d.callRuntimeAPI(ctx, dryRunCallRuntimeAPIMethod, blockHash, origin, call, resultXcmsVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err = unwrapDryRunResult(res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var effects *CallDryRunEffects
+
+	err = d.decodeWithStateUpdate(ctx, blockHash, func() error {
+		effects, err = d.decodeCallDryRunEffects(res)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	executionResult := effects.ExecutionResult
+
+	if !executionResult.IsError {
+		return effects, nil
+	}
+
+	dispatchError, err := d.decodeDispatchError(ctx, executionResult.Error.Error, blockHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	effects.DispatchError = dispatchError
+
+	return effects, nil
+}
+
+// DryRunXcm executes the provided XCM at the given block via the DryRunApi runtime API.
+//
+// Both the origin location and the XCM are encoded as is, and they are expected to be values that encode
+// to a versioned location and a versioned XCM, respectively.
+func (d *dryRunner) DryRunXcm(originLocation any, xcm any, blockHash types.Hash) (*XcmDryRunEffects, error) {
+	return d.DryRunXcmCtx(context.Background(), originLocation, xcm, blockHash)
+}
+
+// DryRunXcmCtx is like DryRunXcm, the RPC requests are bound to the provided context.
+func (d *dryRunner) DryRunXcmCtx(
+	ctx context.Context,
+	originLocation any,
+	xcm any,
+	blockHash types.Hash,
+) (*XcmDryRunEffects, error) {
+	return d.dryRunXcm(ctx, originLocation, xcm, &blockHash)
+}
+
+// DryRunXcmLatest executes the provided XCM at the latest block via the DryRunApi runtime API.
+//
+// See DryRunXcm for more details on the arguments.
+func (d *dryRunner) DryRunXcmLatest(originLocation any, xcm any) (*XcmDryRunEffects, error) {
+	return d.DryRunXcmLatestCtx(context.Background(), originLocation, xcm)
+}
+
+// DryRunXcmLatestCtx is like DryRunXcmLatest, the RPC requests are bound to the provided context.
+func (d *dryRunner) DryRunXcmLatestCtx(ctx context.Context, originLocation any, xcm any) (*XcmDryRunEffects, error) {
+	return d.dryRunXcm(ctx, originLocation, xcm, nil)
+}
+
+func (d *dryRunner) dryRunXcm(
+	ctx context.Context,
+	originLocation any,
+	xcm any,
+	blockHash *types.Hash,
+) (*XcmDryRunEffects, error) {
+	res, err := d.callRuntimeAPI(ctx, dryRunXcmRuntimeAPIMethod, blockHash, originLocation, xcm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err = unwrapDryRunResult(res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var effects *XcmDryRunEffects
+
+	err = d.decodeWithStateUpdate(ctx, blockHash, func() error {
+		effects, err = d.decodeXcmDryRunEffects(res)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return effects, nil
+}
+
+// callRuntimeAPI encodes the provided arguments and calls the runtime API method.
+func (d *dryRunner) callRuntimeAPI(
+	ctx context.Context,
+	method string,
+	blockHash *types.Hash,
+	args ...any,
+) ([]byte, error) {
+	var data []byte
+
+	serDeOptions := d.getSerDeOptions()
+
+	for _, arg := range args {
+		enc, err := types.EncodeWithOptions(arg, serDeOptions)
+
+		if err != nil {
+			return nil, ErrRuntimeAPIArgsEncoding.Wrap(err)
+		}
+
+		data = append(data, enc...)
+	}
+
+	var (
+		res types.Bytes
+		err error
+	)
+
+	if blockHash == nil {
+		res, err = d.stateRPC.CallLatestCtx(ctx, method, data)
+	} else {
+		res, err = d.stateRPC.CallCtx(ctx, method, data, *blockHash)
+	}
+
+	if err != nil {
+		return nil, ErrRuntimeAPICall.Wrap(err)
+	}
+
+	return res, nil
+}
+
+// decodeDispatchError decodes the provided dispatch error, updating the internal state once
+// if its module error is not found in the current error registry.
+func (d *dryRunner) decodeDispatchError(
+	ctx context.Context,
+	dispatchError types.DispatchError,
+	blockHash *types.Hash,
+) (*registry.DispatchError, error) {
+	var res *registry.DispatchError
+
+	err := d.decodeWithStateUpdate(ctx, blockHash, func() error {
+		var err error
+
+		res, err = d.errorRegistry.DecodeDispatchErrorWithDecoder(
+			dispatchError,
+			types.NewDecoderFromBytes(nil, d.serDeOptions),
+		)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, ErrDispatchErrorDecoding.Wrap(err)
+	}
+
+	return res, nil
+}
+
+// decodeWithStateUpdate runs the provided decoding function and, if it fails, updates the internal state
+// using the metadata at the provided block and tries again.
+func (d *dryRunner) decodeWithStateUpdate(ctx context.Context, blockHash *types.Hash, decodeFn func() error) error {
+	if err := d.decodeWithInternalState(decodeFn); err == nil {
+		return nil
+	}
+
+	if err := d.updateInternalState(ctx, blockHash); err != nil {
+		return ErrInternalStateUpdate.Wrap(err)
+	}
+
+	return d.decodeWithInternalState(decodeFn)
+}
+
+// decodeWithInternalState runs the provided decoding function while holding the read lock of the internal state,
+// so that the registries and decoders it uses are not updated while decoding.
+func (d *dryRunner) decodeWithInternalState(decodeFn func() error) error {
+	d.internalStateMu.RLock()
+	defer d.internalStateMu.RUnlock()
+
+	return decodeFn()
+}
+
+// getSerDeOptions returns the current serialise and deserialize options.
+func (d *dryRunner) getSerDeOptions() types.SerDeOptions {
+	d.internalStateMu.RLock()
+	defer d.internalStateMu.RUnlock()
+
+	return d.serDeOptions
+}
+
+// updateInternalState will retrieve the metadata at the provided blockHash, if provided,
+// and create the registries and decoders required for decoding the dry run results.
+func (d *dryRunner) updateInternalState(ctx context.Context, blockHash *types.Hash) error {
+	var (
+		meta *types.Metadata
+		err  error
+	)
+
+	if blockHash == nil {
+		meta, err = d.stateRPC.GetMetadataLatestCtx(ctx)
+	} else {
+		meta, err = d.stateRPC.GetMetadataCtx(ctx, *blockHash)
+	}
+
+	if err != nil {
+		return ErrMetadataRetrieval.Wrap(err)
+	}
+
+	// The registry factory is not safe for concurrent use, so the lock is held while creating the registries
+	// and decoders as well.
+	d.internalStateMu.Lock()
+	defer d.internalStateMu.Unlock()
+
+	eventRegistry, err := d.registryFactory.CreateEventRegistry(meta)
+
+	if err != nil {
+		return ErrEventRegistryCreation.Wrap(err)
+	}
+
+	errorRegistry, err := d.registryFactory.CreateErrorRegistry(meta)
+
+	if err != nil {
+		return ErrErrorRegistryCreation.Wrap(err)
+	}
+
+	xcmDecoder, err := d.createFieldDecoder(meta, isVersionedXcm)
+
+	if err != nil {
+		return err
+	}
+
+	locationDecoder, err := d.createFieldDecoder(meta, isVersionedLocation)
+
+	if err != nil {
+		return err
+	}
+
+	var xcmOutcomeDecoder registry.FieldDecoder
+
+	if lookupIndex, ok := findXcmOutcomeLookupIndex(meta); ok {
+		xcmOutcomeDecoder, err = d.registryFactory.CreateFieldDecoder(meta, lookupIndex)
+
+		if err != nil {
+			return ErrFieldDecoderCreation.Wrap(err)
+		}
+	}
+
+	d.eventRegistry = eventRegistry
+	d.errorRegistry = errorRegistry
+	d.serDeOptions = types.SerDeOptionsFromMetadata(meta)
+	d.xcmDecoder = xcmDecoder
+	d.locationDecoder = locationDecoder
+	d.xcmOutcomeDecoder = xcmOutcomeDecoder
+
+	return nil
+}
+
+// createFieldDecoder creates a FieldDecoder for the first type that matches the provided function,
+// or returns nil if there is no such type.
+func (d *dryRunner) createFieldDecoder(meta *types.Metadata, fn lookupTypeFn) (registry.FieldDecoder, error) {
+	lookupIndex, _, ok := findLookupType(meta, fn)
+
+	if !ok {
+		return nil, nil
+	}
+
+	fieldDecoder, err := d.registryFactory.CreateFieldDecoder(meta, lookupIndex)
+
+	if err != nil {
+		return nil, ErrFieldDecoderCreation.Wrap(err)
+	}
+
+	return fieldDecoder, nil
+}
@@ -0,0 +1,519 @@
+package dryrun
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	systemMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDryRunner_New(t *testing.T) {
+	systemRPCMock := systemMocks.NewSystem(t)
+	stateRPCMock := stateMocks.NewState(t)
+
+	meta := getTestMetadata(t)
+
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
+		Return(meta, nil).
+		Once()
+
+	res, err := NewDefaultDryRunner(systemRPCMock, stateRPCMock)
+	assert.NoError(t, err)
+	assert.IsType(t, &dryRunner{}, res)
+
+	runner := res.(*dryRunner)
+	assert.NotEmpty(t, runner.eventRegistry)
+	assert.NotEmpty(t, runner.errorRegistry)
+	assert.NotNil(t, runner.xcmDecoder)
+	assert.NotNil(t, runner.locationDecoder)
+	assert.NotNil(t, runner.xcmOutcomeDecoder)
+}
+
+func TestDryRunner_New_InternalStateUpdateError(t *testing.T) {
+	systemRPCMock := systemMocks.NewSystem(t)
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err := NewDryRunner(systemRPCMock, stateRPCMock, registryFactoryMock)
+	assert.ErrorIs(t, err, ErrInternalStateUpdate)
+	assert.Nil(t, res)
+
+	meta := &types.Metadata{}
+
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
+		Return(meta, nil).
+		Once()
+
+	registryFactoryMock.On("CreateEventRegistry", meta).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err = NewDryRunner(systemRPCMock, stateRPCMock, registryFactoryMock)
+	assert.ErrorIs(t, err, ErrInternalStateUpdate)
+	assert.Nil(t, res)
+}
+
+func TestDryRunner_DryRunExtrinsicLatest(t *testing.T) {
+	systemRPCMock := systemMocks.NewSystem(t)
+
+	runner := newTestDryRunner(t, systemRPCMock, stateMocks.NewState(t))
+
+	xt := extrinsic.NewExtrinsic(types.Call{})
+
+	applyRes := &types.ApplyExtrinsicResult{
+		IsOk: true,
+		AsOk: types.DispatchResult{Ok: true},
+	}
+
+	systemRPCMock.On("DryRunLatestCtx", mock.Anything, xt).
+		Return(applyRes, nil).
+		Once()
+
+	res, err := runner.DryRunExtrinsicLatest(xt)
+	assert.NoError(t, err)
+	assert.Equal(t, *applyRes, res.Result)
+	assert.Nil(t, res.DispatchError)
+}
+
+func TestDryRunner_DryRunExtrinsic_ModuleError(t *testing.T) {
+	systemRPCMock := systemMocks.NewSystem(t)
+
+	runner := newTestDryRunner(t, systemRPCMock, stateMocks.NewState(t))
+
+	xt := extrinsic.NewExtrinsic(types.Call{})
+	blockHash := types.Hash{1, 2, 3}
+
+	moduleError := types.ModuleError{
+		Index: getPalletIndex(t, "Balances"),
+		Error: [4]types.U8{2},
+	}
+
+	applyRes := &types.ApplyExtrinsicResult{
+		IsOk: true,
+		AsOk: types.DispatchResult{
+			Error: types.DispatchError{
+				IsModule:    true,
+				ModuleError: moduleError,
+			},
+		},
+	}
+
+	systemRPCMock.On("DryRunCtx", mock.Anything, xt, blockHash).
+		Return(applyRes, nil).
+		Once()
+
+	res, err := runner.DryRunExtrinsic(xt, blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, *applyRes, res.Result)
+	assert.Equal(t, applyRes.AsOk.Error, res.DispatchError.Raw)
+	assert.Equal(t, "Balances.InsufficientBalance", res.DispatchError.ModuleError.Name)
+	assert.Equal(t, moduleError.Index, res.DispatchError.ModuleError.ModuleIndex)
+	assert.Equal(t, types.U8(2), res.DispatchError.ModuleError.ErrorIndex)
+}
+
+func TestDryRunner_DryRunExtrinsic_ConcurrentInternalStateUpdate(t *testing.T) {
+	systemRPCMock := systemMocks.NewSystem(t)
+	stateRPCMock := stateMocks.NewState(t)
+
+	runner := newTestDryRunner(t, systemRPCMock, stateRPCMock)
+
+	// The error registry is outdated and gets updated while the dry run results are decoded.
+	runner.errorRegistry = registry.ErrorRegistry{}
+
+	xt := extrinsic.NewExtrinsic(types.Call{})
+	blockHash := types.Hash{1, 2, 3}
+
+	applyRes := &types.ApplyExtrinsicResult{
+		IsOk: true,
+		AsOk: types.DispatchResult{
+			Error: types.DispatchError{
+				IsModule: true,
+				ModuleError: types.ModuleError{
+					Index: getPalletIndex(t, "Balances"),
+					Error: [4]types.U8{2},
+				},
+			},
+		},
+	}
+
+	dryRunCount := 10
+
+	systemRPCMock.On("DryRunCtx", mock.Anything, xt, blockHash).
+		Return(applyRes, nil).
+		Times(dryRunCount)
+
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
+		Return(getTestMetadata(t), nil)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < dryRunCount; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			res, err := runner.DryRunExtrinsicCtx(context.Background(), xt, blockHash)
+			assert.NoError(t, err)
+			assert.Equal(t, "Balances.InsufficientBalance", res.DispatchError.ModuleError.Name)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestDryRunner_DryRunExtrinsic_BadOrigin(t *testing.T) {
+	systemRPCMock := systemMocks.NewSystem(t)
+
+	runner := newTestDryRunner(t, systemRPCMock, stateMocks.NewState(t))
+
+	xt := extrinsic.NewExtrinsic(types.Call{})
+
+	applyRes := &types.ApplyExtrinsicResult{
+		IsOk: true,
+		AsOk: types.DispatchResult{
+			Error: types.DispatchError{
+				IsBadOrigin: true,
+			},
+		},
+	}
+
+	systemRPCMock.On("DryRunLatestCtx", mock.Anything, xt).
+		Return(applyRes, nil).
+		Once()
+
+	res, err := runner.DryRunExtrinsicLatest(xt)
+	assert.NoError(t, err)
+	assert.Nil(t, res.DispatchError.ModuleError)
+	assert.ErrorIs(t, res.DispatchError, &registry.DispatchError{Raw: types.DispatchError{IsBadOrigin: true}})
+}
+
+func TestDryRunner_DryRunExtrinsic_DryRunError(t *testing.T) {
+	systemRPCMock := systemMocks.NewSystem(t)
+
+	runner := newTestDryRunner(t, systemRPCMock, stateMocks.NewState(t))
+
+	xt := extrinsic.NewExtrinsic(types.Call{})
+
+	systemRPCMock.On("DryRunLatestCtx", mock.Anything, xt).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err := runner.DryRunExtrinsicLatest(xt)
+	assert.ErrorIs(t, err, ErrExtrinsicDryRun)
+	assert.Nil(t, res)
+}
+
+func TestDryRunner_DryRunCallLatest(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	runner := newTestDryRunner(t, systemMocks.NewSystem(t), stateRPCMock)
+
+	meta := getTestMetadata(t)
+
+	accountID, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	assert.NoError(t, err)
+
+	origin, err := NewSignedOrigin(meta, *accountID)
+	assert.NoError(t, err)
+
+	call, err := types.NewCall(meta, "System.remark", []byte("test"))
+	assert.NoError(t, err)
+
+	expectedData := append(origin, codec.MustHexDecodeString("0x0000107465737404000000")...)
+
+	remarkedEventID, remarkedEventDecoder := getEventDecoder(t, runner, "System.Remarked")
+
+	effects := []byte{
+		0, // Ok
+		0, // Execution result - Ok
+		0, // Actual weight - None
+		0, // Pays fee - Yes
+		4, // Events count
+	}
+	effects = append(effects, remarkedEventID[:]...)
+	effects = append(effects, signature.TestKeyringPairAlice.PublicKey...)
+	effects = append(effects, make([]byte, 32)...)
+	effects = append(effects, []byte{
+		0, // Local XCM - None
+		4, // Forwarded XCMs count
+		4, // Destination - V4
+		1, // Parents
+		0, // Interior - Here
+		4, // Messages count
+		4, // Message - V4
+		0, // Instructions count
+	}...)
+
+	stateRPCMock.On("CallLatestCtx", mock.Anything, dryRunCallRuntimeAPIMethod, []byte(expectedData)).
+		Return(types.Bytes(effects), nil).
+		Once()
+
+	res, err := runner.DryRunCallLatest(origin, call, 4)
+	assert.NoError(t, err)
+	assert.True(t, res.ExecutionResult.IsOk)
+	assert.Nil(t, res.DispatchError)
+	assert.Nil(t, res.LocalXcm)
+	assert.Len(t, res.EmittedEvents, 1)
+	assert.Equal(t, remarkedEventDecoder.Name, res.EmittedEvents[0].Name)
+	assert.Equal(t, remarkedEventID, res.EmittedEvents[0].EventID)
+	assert.Len(t, res.EmittedEvents[0].Fields, 2)
+	assert.Len(t, res.ForwardedXcms, 1)
+	assert.NotNil(t, res.ForwardedXcms[0].Destination)
+	assert.Len(t, res.ForwardedXcms[0].Messages, 1)
+}
+
+func TestDryRunner_DryRunCall_ModuleError(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	runner := newTestDryRunner(t, systemMocks.NewSystem(t), stateRPCMock)
+
+	meta := getTestMetadata(t)
+
+	origin, err := NewRootOrigin(meta)
+	assert.NoError(t, err)
+
+	call, err := types.NewCall(meta, "System.remark", []byte("test"))
+	assert.NoError(t, err)
+
+	blockHash := types.Hash{1, 2, 3}
+
+	balancesIndex := getPalletIndex(t, "Balances")
+
+	effects := []byte{
+		0,                   // Ok
+		1,                   // Execution result - Error
+		0,                   // Actual weight - None
+		0,                   // Pays fee - Yes
+		3,                   // Dispatch error - Module
+		byte(balancesIndex), // Module index
+		2, 0, 0, 0,          // Error index
+		0, // Events count
+		0, // Local XCM - None
+		0, // Forwarded XCMs count
+	}
+
+	expectedData := append(origin, codec.MustHexDecodeString("0x0000107465737404000000")...)
+
+	stateRPCMock.On("CallCtx", mock.Anything, dryRunCallRuntimeAPIMethod, []byte(expectedData), blockHash).
+		Return(types.Bytes(effects), nil).
+		Once()
+
+	res, err := runner.DryRunCall(origin, call, 4, blockHash)
+	assert.NoError(t, err)
+	assert.True(t, res.ExecutionResult.IsError)
+	assert.Equal(t, "Balances.InsufficientBalance", res.DispatchError.ModuleError.Name)
+	assert.ErrorIs(t, res.DispatchError, &registry.ModuleError{PalletName: "Balances", ErrorName: "InsufficientBalance"})
+	assert.Empty(t, res.EmittedEvents)
+	assert.Empty(t, res.ForwardedXcms)
+}
+
+func TestDryRunner_DryRunCall_DryRunAPIError(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	runner := newTestDryRunner(t, systemMocks.NewSystem(t), stateRPCMock)
+
+	stateRPCMock.On("CallLatestCtx", mock.Anything, dryRunCallRuntimeAPIMethod, []byte{0, 0, 0, 0, 0, 0, 0, 0}).
+		Return(types.Bytes{1, 0}, nil).
+		Once()
+
+	res, err := runner.DryRunCallLatest(types.Data{0, 0}, types.Call{}, 0)
+	assert.ErrorIs(t, err, ErrDryRunAPIUnimplemented)
+	assert.Nil(t, res)
+
+	stateRPCMock.On("CallLatestCtx", mock.Anything, dryRunCallRuntimeAPIMethod, []byte{0, 0, 0, 0, 0, 0, 0, 0}).
+		Return(types.Bytes{1, 1}, nil).
+		Once()
+
+	res, err = runner.DryRunCallLatest(types.Data{0, 0}, types.Call{}, 0)
+	assert.ErrorIs(t, err, ErrDryRunAPIVersionedConversionFailed)
+	assert.Nil(t, res)
+}
+
+func TestDryRunner_DryRunCall_RuntimeAPICallError(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	runner := newTestDryRunner(t, systemMocks.NewSystem(t), stateRPCMock)
+
+	stateRPCMock.On("CallLatestCtx", mock.Anything, dryRunCallRuntimeAPIMethod, []byte{0, 0, 0, 0, 0, 0, 0, 0}).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err := runner.DryRunCallLatest(types.Data{0, 0}, types.Call{}, 0)
+	assert.ErrorIs(t, err, ErrRuntimeAPICall)
+	assert.Nil(t, res)
+}
+
+func TestDryRunner_DryRunCall_DecodingError(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	runner := newTestDryRunner(t, systemMocks.NewSystem(t), stateRPCMock)
+
+	blockHash := types.Hash{1, 2, 3}
+
+	effects := []byte{
+		0,   // Ok
+		0,   // Execution result - Ok
+		0,   // Actual weight - None
+		0,   // Pays fee - Yes
+		4,   // Events count
+		255, // Unknown event
+		255, // Unknown event
+	}
+
+	stateRPCMock.On("CallCtx", mock.Anything, dryRunCallRuntimeAPIMethod, []byte{0, 0, 0, 0, 0, 0, 0, 0}, blockHash).
+		Return(types.Bytes(effects), nil).
+		Once()
+
+	// The internal state is updated using the metadata at the provided block
+	// before attempting to decode the effects again.
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
+		Return(getTestMetadata(t), nil).
+		Once()
+
+	res, err := runner.DryRunCall(types.Data{0, 0}, types.Call{}, 0, blockHash)
+	assert.ErrorIs(t, err, ErrDryRunResultDecoding)
+	assert.ErrorIs(t, err, ErrEventDecoderNotFound)
+	assert.Nil(t, res)
+}
+
+func TestDryRunner_DryRunXcmLatest(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	runner := newTestDryRunner(t, systemMocks.NewSystem(t), stateRPCMock)
+
+	originLocation := types.Data{4, 1, 0}
+	xcm := types.Data{4, 0}
+
+	effects := []byte{
+		0, // Ok
+		0, // Outcome - Complete
+		4, // Used weight - ref time
+		8, // Used weight - proof size
+		0, // Events count
+		0, // Forwarded XCMs count
+	}
+
+	stateRPCMock.On("CallLatestCtx", mock.Anything, dryRunXcmRuntimeAPIMethod, []byte{4, 1, 0, 4, 0}).
+		Return(types.Bytes(effects), nil).
+		Once()
+
+	res, err := runner.DryRunXcmLatest(originLocation, xcm)
+	assert.NoError(t, err)
+	assert.NotNil(t, res.ExecutionResult)
+	assert.Empty(t, res.EmittedEvents)
+	assert.Empty(t, res.ForwardedXcms)
+}
+
+func TestDryRunner_DryRunXcm_XcmTypeNotFound(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	runner := newTestDryRunner(t, systemMocks.NewSystem(t), stateRPCMock)
+	runner.xcmOutcomeDecoder = nil
+
+	blockHash := types.Hash{1, 2, 3}
+
+	stateRPCMock.On("CallCtx", mock.Anything, dryRunXcmRuntimeAPIMethod, []byte{4, 1, 0, 4, 0}, blockHash).
+		Return(types.Bytes{0, 0, 4, 8, 0, 0}, nil).
+		Once()
+
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
+		Return(&types.Metadata{}, nil).
+		Once()
+
+	res, err := runner.DryRunXcm(types.Data{4, 1, 0}, types.Data{4, 0}, blockHash)
+	assert.ErrorIs(t, err, ErrXcmTypeNotFound)
+	assert.Nil(t, res)
+}
+
+func TestNewOrigin(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	_, originCallerType, ok := findLookupType(meta, isOriginCaller)
+	assert.True(t, ok)
+
+	var systemIndex byte
+
+	for _, variant := range originCallerType.Def.Variant.Variants {
+		if variant.Name == originCallerSystemVariantName {
+			systemIndex = byte(variant.Index)
+		}
+	}
+
+	res, err := NewRootOrigin(meta)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Data{systemIndex, rawOriginRoot}, res)
+
+	res, err = NewNoneOrigin(meta)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Data{systemIndex, rawOriginNone}, res)
+
+	accountID, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	assert.NoError(t, err)
+
+	res, err = NewSignedOrigin(meta, *accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, append(types.Data{systemIndex, rawOriginSigned}, accountID.ToBytes()...), res)
+
+	res, err = NewRootOrigin(&types.Metadata{})
+	assert.ErrorIs(t, err, ErrOriginCallerTypeNotFound)
+	assert.Nil(t, res)
+}
+
+func newTestDryRunner(t *testing.T, systemRPC *systemMocks.System, stateRPC *stateMocks.State) *dryRunner {
+	stateRPC.On("GetMetadataLatestCtx", mock.Anything).
+		Return(getTestMetadata(t), nil).
+		Once()
+
+	runner, err := NewDefaultDryRunner(systemRPC, stateRPC)
+	assert.NoError(t, err)
+
+	return runner.(*dryRunner)
+}
+
+func getTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
+
+func getPalletIndex(t *testing.T, palletName string) types.U8 {
+	for _, pallet := range getTestMetadata(t).AsMetadataV14.Pallets {
+		if string(pallet.Name) == palletName {
+			return pallet.Index
+		}
+	}
+
+	t.Fatalf("pallet %s not found", palletName)
+
+	return 0
+}
+
+func getEventDecoder(t *testing.T, runner *dryRunner, eventName string) (types.EventID, *registry.TypeDecoder) {
+	for eventID, eventDecoder := range runner.eventRegistry {
+		if eventDecoder.Name == eventName {
+			return eventID, eventDecoder
+		}
+	}
+
+	t.Fatalf("event %s not found", eventName)
+
+	return types.EventID{}, nil
+}
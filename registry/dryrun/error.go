@@ -0,0 +1,28 @@
+package dryrun
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrInternalStateUpdate                = libErr.Error("internal state update")
+	ErrMetadataRetrieval                  = libErr.Error("metadata retrieval")
+	ErrEventRegistryCreation              = libErr.Error("event registry creation")
+	ErrErrorRegistryCreation              = libErr.Error("error registry creation")
+	ErrFieldDecoderCreation               = libErr.Error("field decoder creation")
+	ErrExtrinsicDryRun                    = libErr.Error("extrinsic dry run")
+	ErrRuntimeAPICall                     = libErr.Error("runtime API call")
+	ErrRuntimeAPIArgsEncoding             = libErr.Error("runtime API args encoding")
+	ErrDryRunResultDecoding               = libErr.Error("dry run result decoding")
+	ErrDryRunAPIUnimplemented             = libErr.Error("dry run API unimplemented")
+	ErrDryRunAPIVersionedConversionFailed = libErr.Error("dry run API versioned conversion failed")
+	ErrDryRunAPIErrorUnknown              = libErr.Error("dry run API error unknown")
+	ErrDispatchErrorDecoding              = libErr.Error("dispatch error decoding")
+	ErrEventsCountDecoding                = libErr.Error("events count decoding")
+	ErrEventIDDecoding                    = libErr.Error("event ID decoding")
+	ErrEventDecoderNotFound               = libErr.Error("event decoder not found")
+	ErrEventFieldsDecoding                = libErr.Error("event fields decoding")
+	ErrXcmTypeNotFound                    = libErr.Error("xcm type not found")
+	ErrXcmDecoding                        = libErr.Error("xcm decoding")
+	ErrOriginCallerTypeNotFound           = libErr.Error("origin caller type not found")
+	ErrOriginCallerSystemVariantNotFound  = libErr.Error("origin caller system variant not found")
+	ErrOriginCallerEncoding               = libErr.Error("origin caller encoding")
+)
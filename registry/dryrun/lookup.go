@@ -0,0 +1,111 @@
+package dryrun
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	pathSeparator = "::"
+
+	originCallerTypeName = "OriginCaller"
+
+	versionedXcmPath           = "xcm::VersionedXcm"
+	versionedLocationPath      = "xcm::VersionedLocation"
+	versionedMultiLocationPath = "xcm::VersionedMultiLocation"
+
+	xcmOutcomePathPrefix      = "xcm"
+	xcmOutcomePathSuffix      = "traits::Outcome"
+	xcmVersionPathEntryPrefix = "v"
+	noXcmVersion              = -1
+)
+
+// lookupTypeFn is used for checking if a type from the metadata lookup matches certain criteria.
+type lookupTypeFn func(meta *types.Metadata, lookupType *types.Si1Type) bool
+
+// findLookupType returns the lookup index and the type of the first type that matches the provided function.
+func findLookupType(meta *types.Metadata, fn lookupTypeFn) (int64, *types.Si1Type, bool) {
+	for _, portableType := range meta.AsMetadataV14.Lookup.Types {
+		lookupType := portableType.Type
+
+		if fn(meta, &lookupType) {
+			return portableType.ID.Int64(), &lookupType, true
+		}
+	}
+
+	return 0, nil, false
+}
+
+// findXcmOutcomeLookupIndex returns the lookup index of the latest XCM outcome type found in the metadata.
+func findXcmOutcomeLookupIndex(meta *types.Metadata) (int64, bool) {
+	var (
+		latestLookupIndex int64
+		latestVersion     = noXcmVersion
+	)
+
+	for _, portableType := range meta.AsMetadataV14.Lookup.Types {
+		path := getPath(portableType.Type.Path)
+
+		if !strings.Contains(path, xcmOutcomePathPrefix) || !strings.HasSuffix(path, xcmOutcomePathSuffix) {
+			continue
+		}
+
+		if version := getXcmVersion(portableType.Type.Path); version > latestVersion {
+			latestVersion = version
+			latestLookupIndex = portableType.ID.Int64()
+		}
+	}
+
+	return latestLookupIndex, latestVersion != noXcmVersion
+}
+
+// getXcmVersion returns the XCM version found in the provided path, e.g. 4 for staging_xcm::v4::traits::Outcome.
+func getXcmVersion(path types.Si1Path) int {
+	for _, pathElement := range path {
+		versionStr, ok := strings.CutPrefix(string(pathElement), xcmVersionPathEntryPrefix)
+
+		if !ok {
+			continue
+		}
+
+		if version, err := strconv.Atoi(versionStr); err == nil {
+			return version
+		}
+	}
+
+	return noXcmVersion
+}
+
+// isOriginCaller checks if the provided type is the OriginCaller of the runtime.
+func isOriginCaller(_ *types.Metadata, lookupType *types.Si1Type) bool {
+	pathLen := len(lookupType.Path)
+
+	return pathLen > 0 && string(lookupType.Path[pathLen-1]) == originCallerTypeName && lookupType.Def.IsVariant
+}
+
+// isVersionedLocation checks if the provided type is the versioned XCM location.
+func isVersionedLocation(_ *types.Metadata, lookupType *types.Si1Type) bool {
+	path := getPath(lookupType.Path)
+
+	return path == versionedLocationPath || path == versionedMultiLocationPath
+}
+
+// isVersionedXcm checks if the provided type is the versioned XCM.
+//
+// The metadata does not hold the generic call type of the XCM, however, calls are always double encoded
+// so the same type can be used for decoding both VersionedXcm<()> and VersionedXcm<RuntimeCall>.
+func isVersionedXcm(_ *types.Metadata, lookupType *types.Si1Type) bool {
+	return getPath(lookupType.Path) == versionedXcmPath
+}
+
+func getPath(path types.Si1Path) string {
+	var pathElements []string
+
+	for _, pathElement := range path {
+		pathElements = append(pathElements, string(pathElement))
+	}
+
+	return strings.Join(pathElements, pathSeparator)
+}
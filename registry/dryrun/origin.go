@@ -0,0 +1,61 @@
+package dryrun
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	originCallerSystemVariantName = "system"
+
+	rawOriginRoot   = 0
+	rawOriginSigned = 1
+	rawOriginNone   = 2
+)
+
+// NewRootOrigin returns the encoded OriginCaller of the runtime for the root origin.
+func NewRootOrigin(meta *types.Metadata) (types.Data, error) {
+	return newSystemOrigin(meta, rawOriginRoot)
+}
+
+// NewSignedOrigin returns the encoded OriginCaller of the runtime for the provided signer.
+func NewSignedOrigin(meta *types.Metadata, signer types.AccountID) (types.Data, error) {
+	return newSystemOrigin(meta, rawOriginSigned, signer)
+}
+
+// NewNoneOrigin returns the encoded OriginCaller of the runtime for the none origin.
+func NewNoneOrigin(meta *types.Metadata) (types.Data, error) {
+	return newSystemOrigin(meta, rawOriginNone)
+}
+
+// newSystemOrigin encodes the system variant of the OriginCaller found in the metadata,
+// which wraps a frame_system::RawOrigin.
+func newSystemOrigin(meta *types.Metadata, rawOrigin byte, args ...any) (types.Data, error) {
+	_, originCallerType, ok := findLookupType(meta, isOriginCaller)
+
+	if !ok {
+		return nil, ErrOriginCallerTypeNotFound
+	}
+
+	for _, variant := range originCallerType.Def.Variant.Variants {
+		if string(variant.Name) != originCallerSystemVariantName {
+			continue
+		}
+
+		origin := types.Data{byte(variant.Index), rawOrigin}
+
+		for _, arg := range args {
+			enc, err := codec.Encode(arg)
+
+			if err != nil {
+				return nil, ErrOriginCallerEncoding.Wrap(err)
+			}
+
+			origin = append(origin, enc...)
+		}
+
+		return origin, nil
+	}
+
+	return nil, ErrOriginCallerSystemVariantNotFound
+}
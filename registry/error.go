@@ -39,6 +39,7 @@ const (
 	ErrTypeFieldDecoding                     = libErr.Error("type field decoding")
 	ErrVariantByteDecoding                   = libErr.Error("variant byte decoding")
 	ErrVariantFieldDecoderNotFound           = libErr.Error("variant field decoder not found")
+	ErrUnexpectedVariantValue                = libErr.Error("unexpected variant value")
 	ErrArrayItemDecoderNotFound              = libErr.Error("array item decoder not found")
 	ErrArrayItemDecoding                     = libErr.Error("array item decoding")
 	ErrSliceItemDecoderNotFound              = libErr.Error("slice item decoder not found")
@@ -55,6 +56,11 @@ const (
 	ErrDecodedFieldValueTypeMismatch         = libErr.Error("decoded field value type mismatch")
 	ErrDecodedFieldValueProcessingError      = libErr.Error("decoded field value processing error")
 	ErrDecodedFieldValueNotAGenericSlice     = libErr.Error("decoded field value is not a generic slice")
+	ErrDecodedVariantNameMismatch            = libErr.Error("decoded variant name mismatch")
+	ErrInvalidUnmarshalTarget                = libErr.Error("invalid unmarshal target")
+	ErrDecodedFieldUnmarshal                 = libErr.Error("decoded field unmarshal")
+	ErrUnmarshalTypeMismatch                 = libErr.Error("unmarshal type mismatch")
+	ErrUnmarshalIntegerOverflow              = libErr.Error("unmarshal integer overflow")
 	ErrExtrinsicFieldRetrieval               = libErr.Error("extrinsic field retrieval")
 	ErrInvalidExtrinsicParams                = libErr.Error("invalid extrinsic params")
 	ErrInvalidExtrinsicType                  = libErr.Error("invalid extrinsic type")
@@ -65,4 +71,10 @@ const (
 	ErrExtrinsicVersionDecoding              = libErr.Error("extrinsic version decoding")
 	ErrUnexpectedExtrinsicParam              = libErr.Error("unexpected extrinsic param")
 	ErrExtrinsicFieldDecoding                = libErr.Error("extrinsic field decoding")
+	ErrErrorDecoderNotFound                  = libErr.Error("error decoder not found")
+	ErrErrorFieldsDecoding                   = libErr.Error("error fields decoding")
+	ErrUnexpectedDispatchErrorValue          = libErr.Error("unexpected dispatch error value")
+	ErrDispatchErrorDecoding                 = libErr.Error("dispatch error decoding")
+	ErrCallVariantDecoderNotFound            = libErr.Error("call variant decoder not found")
+	ErrUnexpectedCallValue                   = libErr.Error("unexpected call value")
 )
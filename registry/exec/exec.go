@@ -1,6 +1,7 @@
 package exec
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -14,6 +15,7 @@ import (
 // The interface is generic over type T which represents the return value of the closure.
 type RetryableExecutor[T any] interface {
 	ExecWithFallback(execFn func() (T, error), fallbackFn func() error) (T, error)
+	ExecWithFallbackCtx(ctx context.Context, execFn func() (T, error), fallbackFn func() error) (T, error)
 }
 
 // retryableExecutor implements RetryableExecutor.
@@ -39,6 +41,15 @@ func NewRetryableExecutor[T any](opts ...OptsFn) RetryableExecutor[T] {
 // ExecWithFallback will attempt to execute the provided execFn and, in the case of failure, it will execute
 // the fallbackFn and retry execution of execFn.
 func (r *retryableExecutor[T]) ExecWithFallback(execFn func() (T, error), fallbackFn func() error) (res T, err error) {
+	return r.ExecWithFallbackCtx(context.Background(), execFn, fallbackFn)
+}
+
+// ExecWithFallbackCtx is like ExecWithFallback, no further retries are done once the provided context is done.
+func (r *retryableExecutor[T]) ExecWithFallbackCtx(
+	ctx context.Context,
+	execFn func() (T, error),
+	fallbackFn func() error,
+) (res T, err error) {
 	if execFn == nil {
 		return res, ErrMissingExecFn
 	}
@@ -64,6 +75,12 @@ func (r *retryableExecutor[T]) ExecWithFallback(execFn func() (T, error), fallba
 			return res, execErr
 		}
 
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			execErr.AddErr(ctxErr)
+
+			return res, execErr
+		}
+
 		if err = fallbackFn(); err != nil && !r.opts.retryOnFallbackError {
 			execErr.AddErr(fmt.Errorf("fallback function error: %w", err))
 
@@ -72,7 +89,25 @@ func (r *retryableExecutor[T]) ExecWithFallback(execFn func() (T, error), fallba
 
 		retryCount++
 
-		time.Sleep(r.opts.retryTimeout)
+		if err = sleepCtx(ctx, r.opts.retryTimeout); err != nil {
+			execErr.AddErr(err)
+
+			return res, execErr
+		}
+	}
+}
+
+// sleepCtx waits for the provided duration or until the context is done, in which case the context error
+// is returned.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
@@ -159,3 +194,9 @@ func (e *Error) Error() string {
 
 	return sb.String()
 }
+
+// Unwrap returns the errors that happened during execution, allowing them to be inspected via errors.Is
+// and errors.As.
+func (e *Error) Unwrap() []error {
+	return e.errs
+}
@@ -2,7 +2,11 @@
 
 package exec
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // RetryableExecutorMock is an autogenerated mock type for the RetryableExecutor type
 type RetryableExecutorMock[T interface{}] struct {
@@ -30,6 +34,27 @@ func (_m *RetryableExecutorMock[T]) ExecWithFallback(execFn func() (T, error), f
 	return r0, r1
 }
 
+// ExecWithFallbackCtx provides a mock function with given fields: ctx, execFn, fallbackFn
+func (_m *RetryableExecutorMock[T]) ExecWithFallbackCtx(ctx context.Context, execFn func() (T, error), fallbackFn func() error) (T, error) {
+	ret := _m.Called(ctx, execFn, fallbackFn)
+
+	var r0 T
+	if rf, ok := ret.Get(0).(func(context.Context, func() (T, error), func() error) T); ok {
+		r0 = rf(ctx, execFn, fallbackFn)
+	} else {
+		r0 = ret.Get(0).(T)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, func() (T, error), func() error) error); ok {
+		r1 = rf(ctx, execFn, fallbackFn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewRetryableExecutorMockT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -1,6 +1,7 @@
 package exec
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -104,6 +105,55 @@ func TestRetryableExecutor_ExecWithFallback_ExecFnError(t *testing.T) {
 	assert.Len(t, execErr.errs, int(retryCount+1))
 }
 
+func TestRetryableExecutor_ExecWithFallbackCtx_ContextDone(t *testing.T) {
+	exec := NewRetryableExecutor[int](
+		WithMaxRetryCount(5),
+		WithRetryTimeout(time.Hour),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	execFnCallCount := 0
+	fallbackFnCallCount := 0
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+
+		cancel()
+	}()
+
+	res, err := exec.ExecWithFallbackCtx(ctx, func() (int, error) {
+		execFnCallCount++
+
+		return 0, errors.New("boom")
+	}, func() error {
+		fallbackFnCallCount++
+
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, res)
+	assert.Equal(t, 1, execFnCallCount)
+	assert.Equal(t, 1, fallbackFnCallCount)
+
+	execErr := err.(*Error)
+	assert.Len(t, execErr.errs, 2)
+
+	execFnCallCount = 0
+
+	res, err = exec.ExecWithFallbackCtx(ctx, func() (int, error) {
+		execFnCallCount++
+
+		return 0, errors.New("boom")
+	}, func() error {
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, res)
+	assert.Equal(t, 1, execFnCallCount)
+}
+
 func TestRetryableExecutor_ExecWithFallback_FallBackFnError(t *testing.T) {
 	exec := NewRetryableExecutor[int]()
 
@@ -16,6 +16,7 @@ type Factory interface {
 	CreateErrorRegistry(meta *types.Metadata) (ErrorRegistry, error)
 	CreateEventRegistry(meta *types.Metadata) (EventRegistry, error)
 	CreateExtrinsicDecoder(meta *types.Metadata) (*ExtrinsicDecoder, error)
+	CreateFieldDecoder(meta *types.Metadata, lookupIndex int64) (FieldDecoder, error)
 }
 
 // CallRegistry maps a call name to its TypeDecoder.
@@ -101,6 +102,7 @@ func (f *factory) CreateErrorRegistry(meta *types.Metadata) (ErrorRegistry, erro
 			errorRegistry[errorID] = &TypeDecoder{
 				Name:   errorName,
 				Fields: errorFields,
+				Docs:   getDocs(errorVariant.Docs),
 			}
 		}
 	}
@@ -151,6 +153,7 @@ func (f *factory) CreateCallRegistry(meta *types.Metadata) (CallRegistry, error)
 			callRegistry[callIndex] = &TypeDecoder{
 				Name:   callName,
 				Fields: callFields,
+				Docs:   getDocs(callVariant.Docs),
 			}
 		}
 	}
@@ -197,6 +200,7 @@ func (f *factory) CreateEventRegistry(meta *types.Metadata) (EventRegistry, erro
 			eventRegistry[eventID] = &TypeDecoder{
 				Name:   eventName,
 				Fields: eventFields,
+				Docs:   getDocs(eventVariant.Docs),
 			}
 		}
 	}
@@ -241,6 +245,30 @@ func (f *factory) CreateExtrinsicDecoder(meta *types.Metadata) (*ExtrinsicDecode
 	}, nil
 }
 
+// CreateFieldDecoder creates a FieldDecoder for the type found at the provided lookup index.
+//
+// This comes in handy for decoding types that are not part of a call, event or error, such as
+// the results of runtime API calls.
+func (f *factory) CreateFieldDecoder(meta *types.Metadata, lookupIndex int64) (FieldDecoder, error) {
+	f.resetStorages()
+
+	fields, err := f.getTypeFields(meta, []types.Si1Field{
+		{
+			Type: types.NewSi1LookupTypeIDFromUInt(uint64(lookupIndex)),
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.resolveRecursiveDecoders(); err != nil {
+		return nil, ErrRecursiveDecodersResolving.Wrap(err)
+	}
+
+	return fields[0].FieldDecoder, nil
+}
+
 const (
 	ExtrinsicAddressName   = "Address"
 	ExtrinsicSignatureName = "Signature"
@@ -449,20 +477,24 @@ func (f *factory) getFieldDecoder(
 	}
 }
 
-// getVariantFieldDecoder parses a variant type definition and returns a VariantDecoder.
+// getVariantFieldDecoder parses a variant type definition and returns a VariantDecoder, or a CallDecoder
+// if the variant is the RuntimeCall type.
 func (f *factory) getVariantFieldDecoder(meta *types.Metadata, typeDef types.Si1TypeDef) (FieldDecoder, error) {
 	variantDecoder := &VariantDecoder{}
 
 	fieldDecoderMap := make(map[byte]FieldDecoder)
+	variantNameMap := make(map[byte]string)
 
 	for _, variant := range typeDef.Variant.Variants {
+		variantName := getVariantName(variant)
+
+		variantNameMap[byte(variant.Index)] = variantName
+
 		if len(variant.Fields) == 0 {
 			fieldDecoderMap[byte(variant.Index)] = &NoopDecoder{}
 			continue
 		}
 
-		variantName := getVariantName(variant)
-
 		compositeDecoder := &CompositeDecoder{
 			FieldName: variantName,
 		}
@@ -479,6 +511,11 @@ func (f *factory) getVariantFieldDecoder(meta *types.Metadata, typeDef types.Si1
 	}
 
 	variantDecoder.FieldDecoderMap = fieldDecoderMap
+	variantDecoder.VariantNameMap = variantNameMap
+
+	if IsRuntimeCallType(meta, typeDef) {
+		return &CallDecoder{VariantDecoder: variantDecoder}, nil
+	}
 
 	return variantDecoder, nil
 }
@@ -794,3 +831,13 @@ func getFieldName(field types.Si1Field) string {
 		return fmt.Sprintf(lookupIndexFormat, field.Type.Int64())
 	}
 }
+
+func getDocs(docs []types.Text) []string {
+	var res []string
+
+	for _, doc := range docs {
+		res = append(res, string(doc))
+	}
+
+	return res
+}
@@ -104,6 +104,29 @@ func (_m *FactoryMock) CreateExtrinsicDecoder(meta *types.Metadata) (*ExtrinsicD
 	return r0, r1
 }
 
+// CreateFieldDecoder provides a mock function with given fields: meta, lookupIndex
+func (_m *FactoryMock) CreateFieldDecoder(meta *types.Metadata, lookupIndex int64) (FieldDecoder, error) {
+	ret := _m.Called(meta, lookupIndex)
+
+	var r0 FieldDecoder
+	if rf, ok := ret.Get(0).(func(*types.Metadata, int64) FieldDecoder); ok {
+		r0 = rf(meta, lookupIndex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(FieldDecoder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Metadata, int64) error); ok {
+		r1 = rf(meta, lookupIndex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewFactoryMockT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -3,6 +3,7 @@ package registry
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
@@ -916,6 +917,57 @@ func TestFactory_CreateExtrinsicDecoder_ExtrinsicFieldRetrievalError(t *testing.
 	assert.Nil(t, res)
 }
 
+func TestFactory_CreateFieldDecoder(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	var versionedLocationLookupIndex int64
+
+	for _, lookupType := range meta.AsMetadataV14.Lookup.Types {
+		if getFieldPath(&lookupType.Type) == "xcm.VersionedLocation" {
+			versionedLocationLookupIndex = lookupType.ID.Int64()
+		}
+	}
+
+	factory := NewFactory()
+
+	fieldDecoder, err := factory.CreateFieldDecoder(&meta, versionedLocationLookupIndex)
+	assert.NoError(t, err)
+
+	// V4 location with 1 parent and no interior junctions.
+	decoder := scale.NewDecoder(bytes.NewReader([]byte{4, 1, 0}))
+
+	res, err := fieldDecoder.Decode(decoder)
+	assert.NoError(t, err)
+
+	versionedLocation, ok := res.(*DecodedVariant)
+	assert.True(t, ok)
+	assert.Equal(t, byte(4), versionedLocation.Index)
+	assert.Equal(t, "V4", versionedLocation.Name)
+	assert.Len(t, versionedLocation.Fields, 1)
+
+	location, ok := versionedLocation.Fields[0].Value.(DecodedFields)
+	assert.True(t, ok)
+	assert.Len(t, location, 2)
+	assert.Equal(t, types.U8(1), location[0].Value)
+	assert.Equal(t, &DecodedVariant{Index: 0, Name: "Here"}, location[1].Value)
+}
+
+func TestFactory_CreateFieldDecoder_FieldTypeNotFound(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+
+	fieldDecoder, err := factory.CreateFieldDecoder(&meta, math.MaxInt32)
+	assert.ErrorIs(t, err, ErrFieldTypeNotFound)
+	assert.Nil(t, fieldDecoder)
+}
+
 func TestFactory_getTypeFields(t *testing.T) {
 	fieldLookUpID := 123
 
@@ -1375,6 +1427,7 @@ func TestFactory_getFieldDecoder_Variant(t *testing.T) {
 	variantFieldType, ok := res.(*VariantDecoder)
 	assert.True(t, ok)
 	assert.Len(t, variantFieldType.FieldDecoderMap, 2)
+	assert.Equal(t, map[byte]string{0: variantName1, 1: variantName2}, variantFieldType.VariantNameMap)
 
 	assert.Equal(t, &NoopDecoder{}, variantFieldType.FieldDecoderMap[0])
 
@@ -2485,9 +2538,14 @@ func (a *testAsserter) assertRegistryItemFieldIsCorrect(t *testing.T, meta types
 	case metaFieldTypeDef.IsVariant:
 		variantRegistryFieldType, ok := registryItemFieldType.(*VariantDecoder)
 
+		// The RuntimeCall type is decoded by a CallDecoder that wraps the VariantDecoder.
+		if callRegistryFieldType, isCall := registryItemFieldType.(*CallDecoder); isCall {
+			variantRegistryFieldType, ok = callRegistryFieldType.VariantDecoder, true
+		}
+
 		if !ok {
 			_, isRecursive := registryItemFieldType.(*RecursiveDecoder)
-			assert.True(t, isRecursive, "expected variant or recursive field")
+			assert.True(t, isRecursive, "expected variant, call or recursive field")
 			return
 		}
 
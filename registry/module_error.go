@@ -0,0 +1,100 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ModuleError holds the decoded information of a types.ModuleError.
+type ModuleError struct {
+	ModuleIndex types.U8
+	ErrorIndex  types.U8
+
+	// Name is the full name of the error, in the format <Module>.<Error>.
+	Name string
+
+	PalletName string
+	ErrorName  string
+	Docs       []string
+	Fields     DecodedFields
+}
+
+// Error returns the name of the module error, in the format <Module>.<Error>.
+func (m *ModuleError) Error() string {
+	return m.Name
+}
+
+// Is reports whether the target is a ModuleError with the same pallet and error names.
+//
+// This allows checking for specific pallet errors, for example:
+//
+//	errors.Is(err, &registry.ModuleError{PalletName: "Balances", ErrorName: "InsufficientBalance"})
+func (m *ModuleError) Is(target error) bool {
+	t, ok := target.(*ModuleError)
+
+	if !ok || t == nil {
+		return false
+	}
+
+	return t.PalletName == m.PalletName && t.ErrorName == m.ErrorName
+}
+
+// DecodeModuleError looks up the TypeDecoder of the provided types.ModuleError and uses it to decode
+// the error fields, if any.
+//
+// The first byte of the error index identifies the error variant, the remaining bytes hold its encoded fields.
+func (e ErrorRegistry) DecodeModuleError(moduleError types.ModuleError) (*ModuleError, error) {
+	return e.decodeModuleError(moduleError, scale.NewDecoderFromBytes)
+}
+
+// DecodeModuleErrorWithDecoder is like DecodeModuleError, the error fields are decoded with a decoder created from
+// the provided one, so that the values it carries, such as the serialise and deserialize options, are used.
+func (e ErrorRegistry) DecodeModuleErrorWithDecoder(
+	moduleError types.ModuleError,
+	decoder *scale.Decoder,
+) (*ModuleError, error) {
+	return e.decodeModuleError(moduleError, decoder.WithBytes)
+}
+
+func (e ErrorRegistry) decodeModuleError(
+	moduleError types.ModuleError,
+	newDecoder func(bz []byte) *scale.Decoder,
+) (*ModuleError, error) {
+	errorID := ErrorID{
+		ModuleIndex: moduleError.Index,
+		ErrorIndex:  [4]types.U8{moduleError.Error[0]},
+	}
+
+	errorDecoder, ok := e[errorID]
+
+	if !ok {
+		return nil, ErrErrorDecoderNotFound.WithMsg("module '%d', error '%d'", moduleError.Index, moduleError.Error[0])
+	}
+
+	var fieldBytes []byte
+
+	for _, b := range moduleError.Error[1:] {
+		fieldBytes = append(fieldBytes, byte(b))
+	}
+
+	errorFields, err := errorDecoder.Decode(newDecoder(fieldBytes))
+
+	if err != nil {
+		return nil, ErrErrorFieldsDecoding.Wrap(fmt.Errorf("error '%s': %w", errorDecoder.Name, err))
+	}
+
+	palletName, errorName, _ := strings.Cut(errorDecoder.Name, fieldSeparator)
+
+	return &ModuleError{
+		ModuleIndex: moduleError.Index,
+		ErrorIndex:  moduleError.Error[0],
+		Name:        errorDecoder.Name,
+		PalletName:  palletName,
+		ErrorName:   errorName,
+		Docs:        errorDecoder.Docs,
+		Fields:      errorFields,
+	}, nil
+}
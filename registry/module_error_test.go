@@ -0,0 +1,149 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorRegistry_DecodeModuleError(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	reg, err := NewFactory().CreateErrorRegistry(&meta)
+	assert.NoError(t, err)
+
+	var balancesIndex types.U8
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Name == "Balances" {
+			balancesIndex = pallet.Index
+		}
+	}
+
+	res, err := reg.DecodeModuleError(types.ModuleError{
+		Index: balancesIndex,
+		Error: [4]types.U8{2},
+	})
+	assert.NoError(t, err)
+
+	metaErr, err := meta.FindError(balancesIndex, [4]types.U8{2})
+	assert.NoError(t, err)
+
+	assert.Equal(t, balancesIndex, res.ModuleIndex)
+	assert.Equal(t, types.U8(2), res.ErrorIndex)
+	assert.Equal(t, "Balances."+metaErr.Name, res.Name)
+	assert.Equal(t, "Balances", res.PalletName)
+	assert.Equal(t, metaErr.Name, res.ErrorName)
+	assert.NotEmpty(t, res.Docs)
+	assert.Equal(t, metaErr.Value, strings.Join(res.Docs, ", "))
+	assert.Equal(t, res.Name, res.Error())
+	assert.Empty(t, res.Fields)
+}
+
+func TestModuleError_Is(t *testing.T) {
+	moduleError := &ModuleError{
+		Name:       "Balances.InsufficientBalance",
+		PalletName: "Balances",
+		ErrorName:  "InsufficientBalance",
+	}
+
+	var err error = moduleError
+
+	assert.ErrorIs(t, err, &ModuleError{PalletName: "Balances", ErrorName: "InsufficientBalance"})
+	assert.NotErrorIs(t, err, &ModuleError{PalletName: "Balances", ErrorName: "ExistentialDeposit"})
+	assert.NotErrorIs(t, err, &ModuleError{PalletName: "Assets", ErrorName: "InsufficientBalance"})
+	assert.NotErrorIs(t, err, ErrErrorDecoderNotFound)
+}
+
+func TestErrorRegistry_DecodeModuleError_DecoderNotFound(t *testing.T) {
+	reg := ErrorRegistry{}
+
+	res, err := reg.DecodeModuleError(types.ModuleError{
+		Index: 1,
+		Error: [4]types.U8{2},
+	})
+	assert.ErrorIs(t, err, ErrErrorDecoderNotFound)
+	assert.Nil(t, res)
+}
+
+func TestErrorRegistry_DecodeModuleError_FieldsDecodingError(t *testing.T) {
+	errorID := ErrorID{
+		ModuleIndex: 1,
+		ErrorIndex:  [4]types.U8{2},
+	}
+
+	reg := ErrorRegistry{
+		errorID: &TypeDecoder{
+			Name: "Module.Error",
+			Fields: []*Field{
+				{
+					Name:         "field",
+					FieldDecoder: &ValueDecoder[types.U64]{},
+				},
+			},
+		},
+	}
+
+	res, err := reg.DecodeModuleError(types.ModuleError{
+		Index: 1,
+		Error: [4]types.U8{2},
+	})
+	assert.ErrorIs(t, err, ErrErrorFieldsDecoding)
+	assert.Nil(t, res)
+}
+
+type testValueKey struct{}
+
+// testValueFieldDecoder returns the value carried by the decoder for testValueKey.
+type testValueFieldDecoder struct{}
+
+func (testValueFieldDecoder) Decode(decoder *scale.Decoder) (any, error) {
+	return decoder.Value(testValueKey{}), nil
+}
+
+func TestErrorRegistry_DecodeModuleErrorWithDecoder(t *testing.T) {
+	errorID := ErrorID{
+		ModuleIndex: 1,
+		ErrorIndex:  [4]types.U8{2},
+	}
+
+	reg := ErrorRegistry{
+		errorID: &TypeDecoder{
+			Name: "Module.Error",
+			Fields: []*Field{
+				{
+					Name:         "field",
+					FieldDecoder: &ValueDecoder[types.U8]{},
+				},
+				{
+					Name:         "value",
+					FieldDecoder: testValueFieldDecoder{},
+				},
+			},
+		},
+	}
+
+	moduleError := types.ModuleError{
+		Index: 1,
+		Error: [4]types.U8{2, 7},
+	}
+
+	res, err := reg.DecodeModuleErrorWithDecoder(moduleError, scale.NewDecoderFromBytes(nil).WithValue(testValueKey{}, 8))
+	assert.NoError(t, err)
+	assert.Equal(t, "Module.Error", res.Name)
+	assert.Equal(t, types.U8(7), res.Fields[0].Value)
+	assert.Equal(t, 8, res.Fields[1].Value)
+
+	res, err = reg.DecodeModuleError(moduleError)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U8(7), res.Fields[0].Value)
+	assert.Nil(t, res.Fields[1].Value)
+}
@@ -9,4 +9,9 @@ const (
 	ErrEventDecoderNotFound = libErr.Error("event decoder not found")
 	ErrEventFieldsDecoding  = libErr.Error("event fields decoding")
 	ErrEventTopicsDecoding  = libErr.Error("event topics decoding")
+
+	ErrExtrinsicOutcomeNotFound = libErr.Error("extrinsic outcome not found")
+	ErrDispatchErrorDecoding    = libErr.Error("dispatch error decoding")
+	ErrModuleErrorDecoding      = libErr.Error("module error decoding")
+	ErrFeePaidDecoding          = libErr.Error("fee paid decoding")
 )
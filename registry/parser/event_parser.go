@@ -1,9 +1,6 @@
 package parser
 
 import (
-	"bytes"
-	"fmt"
-
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -35,10 +32,24 @@ func (f EventParserFn) ParseEvents(eventRegistry registry.EventRegistry, sd *typ
 
 // NewEventParser creates a new EventParser.
 func NewEventParser() EventParser {
+	return newEventParser(nil)
+}
+
+// NewTracingEventParser creates a new EventParser that records the decoded values of all parsed events in the
+// provided trace, see scale.Trace. It is meant for debugging events that can't be decoded.
+func NewTracingEventParser(trace *scale.Trace) EventParser {
+	return newEventParser(trace)
+}
+
+func newEventParser(trace *scale.Trace) EventParser {
 	// The EventParserFn provided here is decoding the total number of events from the storage data then attempts
 	// to decode all the information for each event.
 	return EventParserFn(func(eventRegistry registry.EventRegistry, sd *types.StorageDataRaw) ([]*Event, error) {
-		decoder := scale.NewDecoder(bytes.NewReader(*sd))
+		decoder := scale.NewDecoderFromBytes(*sd)
+
+		if trace != nil {
+			decoder = decoder.WithTrace(trace)
+		}
 
 		eventsCount, err := decoder.DecodeUintCompact()
 
@@ -49,47 +60,63 @@ func NewEventParser() EventParser {
 		var events []*Event
 
 		for i := uint64(0); i < eventsCount.Uint64(); i++ {
-			var phase types.Phase
+			scope := decoder.Enter(scale.PathSegment{Name: "Event", Index: int(i), HasIndex: true}, "")
 
-			if err := decoder.Decode(&phase); err != nil {
-				return nil, ErrEventPhaseDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
+			event, err := decodeEvent(eventRegistry, decoder)
+
+			if err = scope.Exit(err); err != nil {
+				return nil, err
 			}
 
-			var eventID types.EventID
+			events = append(events, event)
+		}
 
-			if err := decoder.Decode(&eventID); err != nil {
-				return nil, ErrEventIDDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
-			}
+		return events, nil
+	})
+}
 
-			eventDecoder, ok := eventRegistry[eventID]
+func decodeEvent(eventRegistry registry.EventRegistry, decoder *scale.Decoder) (*Event, error) {
+	var phase types.Phase
 
-			if !ok {
-				return nil, ErrEventDecoderNotFound.WithMsg("event #%d with ID: %v", i, eventID)
-			}
+	if err := decodeEventField(decoder, "phase", &phase); err != nil {
+		return nil, registry.WrapDecodeError(ErrEventPhaseDecoding, err)
+	}
 
-			eventFields, err := eventDecoder.Decode(decoder)
+	var eventID types.EventID
 
-			if err != nil {
-				return nil, ErrEventFieldsDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
-			}
+	if err := decodeEventField(decoder, "id", &eventID); err != nil {
+		return nil, registry.WrapDecodeError(ErrEventIDDecoding, err)
+	}
 
-			var topics []types.Hash
+	eventDecoder, ok := eventRegistry[eventID]
 
-			if err := decoder.Decode(&topics); err != nil {
-				return nil, ErrEventTopicsDecoding.Wrap(fmt.Errorf("event #%d: %w", i, err))
-			}
+	if !ok {
+		return nil, ErrEventDecoderNotFound.WithMsg("event ID: %v", eventID)
+	}
 
-			event := &Event{
-				Name:    eventDecoder.Name,
-				Fields:  eventFields,
-				EventID: eventID,
-				Phase:   &phase,
-				Topics:  topics,
-			}
+	eventFields, err := eventDecoder.Decode(decoder)
 
-			events = append(events, event)
-		}
+	if err != nil {
+		return nil, registry.WrapDecodeError(ErrEventFieldsDecoding, err)
+	}
 
-		return events, nil
-	})
+	var topics []types.Hash
+
+	if err := decodeEventField(decoder, "topics", &topics); err != nil {
+		return nil, registry.WrapDecodeError(ErrEventTopicsDecoding, err)
+	}
+
+	return &Event{
+		Name:    eventDecoder.Name,
+		Fields:  eventFields,
+		EventID: eventID,
+		Phase:   &phase,
+		Topics:  topics,
+	}, nil
+}
+
+func decodeEventField(decoder *scale.Decoder, name string, target any) error {
+	scope := decoder.Enter(scale.NameSegment(name), "")
+
+	return scope.Exit(decoder.Decode(target))
 }
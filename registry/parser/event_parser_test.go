@@ -2,6 +2,7 @@ package parser
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -131,6 +132,43 @@ func TestEventParserFn_ParseEvents(t *testing.T) {
 	}
 }
 
+func TestNewTracingEventParser(t *testing.T) {
+	testEvents := []testEvent{
+		{
+			Name: "test_event_1",
+			Phase: &types.Phase{
+				IsApplyExtrinsic: true,
+				AsApplyExtrinsic: 1,
+			},
+			EventID: types.EventID([2]byte{0, 1}),
+			EventFields: []testField{
+				{
+					Name:  "bool_value",
+					Value: true,
+				},
+			},
+		},
+	}
+
+	encodedEvents, reg, err := getEventParsingTestData(testEvents)
+	assert.NoError(t, err)
+
+	var trace scale.Trace
+
+	res, err := NewTracingEventParser(&trace).ParseEvents(reg, encodedEvents)
+	assert.NoError(t, err)
+	assert.Len(t, res, len(testEvents))
+
+	assert.Equal(t, ""+
+		"       1  Event[0] (9 bytes)\n"+
+		"       1    phase: types.Phase (5 bytes) 0x00\n"+
+		"       2      uint32 0x01000000\n"+
+		"       6    id: types.EventID 0x0001\n"+
+		"       8    test_event_1 (1 bytes)\n"+
+		"       8      bool_value: bool 0x01\n"+
+		"       9    topics: []types.Hash 0x00\n", trace.String())
+}
+
 func TestEventParserFn_ParseEvents_EventCountDecodeError(t *testing.T) {
 	testEvents := []testEvent{
 		{
@@ -306,6 +344,12 @@ func TestEventParserFn_ParseEvents_EventFieldsDecodeError(t *testing.T) {
 	res, err := eventParser.ParseEvents(reg, &storageData)
 	assert.ErrorIs(t, err, ErrEventFieldsDecoding)
 	assert.Nil(t, res)
+
+	var decodeErr *scale.DecodeError
+
+	assert.True(t, errors.As(err, &decodeErr))
+	assert.Equal(t, "Event[0].test_event_1.bool_value", decodeErr.Path.String())
+	assert.Equal(t, 8, decodeErr.Offset)
 }
 
 func TestEventParserFn_ParseEvents_MissingEventDecoder(t *testing.T) {
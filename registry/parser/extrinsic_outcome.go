@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	extrinsicSuccessEventName   = "System.ExtrinsicSuccess"
+	extrinsicFailedEventName    = "System.ExtrinsicFailed"
+	transactionFeePaidEventName = "TransactionPayment.TransactionFeePaid"
+
+	dispatchErrorFieldName = "dispatch_error"
+	actualFeeFieldName     = "actual_fee"
+)
+
+// ExtrinsicOutcome holds the outcome and the fee paid of an extrinsic, as found in the events
+// that were emitted while applying it.
+type ExtrinsicOutcome struct {
+	// Success is true if the extrinsic emitted a System.ExtrinsicSuccess event.
+	Success bool
+
+	// DispatchError holds the dispatch error, if the extrinsic failed.
+	DispatchError *registry.DispatchError
+
+	// FeePaid is the actual fee paid for the extrinsic, as emitted in the TransactionPayment.TransactionFeePaid
+	// event. It is zero if the event was not emitted, e.g. for unsigned extrinsics.
+	FeePaid types.U128
+}
+
+// GetExtrinsicOutcome determines the outcome and the fee paid of an extrinsic based on its events.
+//
+// The error registry is used for decoding the dispatch error of a failed extrinsic, ErrModuleErrorDecoding
+// is returned if the dispatch error cannot be decoded by it, e.g. if the registry is outdated.
+func GetExtrinsicOutcome(events []*Event, errorRegistry registry.ErrorRegistry) (*ExtrinsicOutcome, error) {
+	outcome := &ExtrinsicOutcome{}
+	outcomeFound := false
+
+	for _, event := range events {
+		switch event.Name {
+		case extrinsicSuccessEventName:
+			outcomeFound = true
+			outcome.Success = true
+		case extrinsicFailedEventName:
+			outcomeFound = true
+
+			dispatchError, err := registry.GetDecodedFieldAsDispatchError(
+				event.Fields,
+				registry.FieldNamePredicate(dispatchErrorFieldName),
+			)
+
+			if err != nil {
+				return nil, ErrDispatchErrorDecoding.Wrap(err)
+			}
+
+			res, err := errorRegistry.DecodeDispatchError(dispatchError)
+
+			if err != nil {
+				return nil, ErrModuleErrorDecoding.Wrap(err)
+			}
+
+			outcome.DispatchError = res
+		case transactionFeePaidEventName:
+			feePaid, err := registry.GetDecodedFieldAsType[types.U128](
+				event.Fields,
+				registry.FieldNamePredicate(actualFeeFieldName),
+			)
+
+			if err != nil {
+				return nil, ErrFeePaidDecoding.Wrap(err)
+			}
+
+			outcome.FeePaid = feePaid
+		}
+	}
+
+	if !outcomeFound {
+		return nil, ErrExtrinsicOutcomeNotFound
+	}
+
+	return outcome, nil
+}
@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExtrinsicOutcome(t *testing.T) {
+	meta := getTestMetadata(t)
+	eventRegistry := getTestEventRegistry(t, meta)
+	errorRegistry := getTestErrorRegistry(t, meta)
+
+	feePaid := types.NewU128(*big.NewInt(1234))
+
+	events := []*Event{
+		newTestEvent(t, eventRegistry, "System.Remarked", make([]byte, 64)),
+		newTestEvent(t, eventRegistry, "TransactionPayment.TransactionFeePaid", getTestFeePaidEventData(t, feePaid)),
+		newTestEvent(t, eventRegistry, "System.ExtrinsicSuccess", []byte{0, 0, 0, 0}),
+	}
+
+	res, err := GetExtrinsicOutcome(events, errorRegistry)
+	assert.NoError(t, err)
+	assert.True(t, res.Success)
+	assert.Nil(t, res.DispatchError)
+	assert.Equal(t, feePaid, res.FeePaid)
+}
+
+func TestGetExtrinsicOutcome_ExtrinsicFailed(t *testing.T) {
+	meta := getTestMetadata(t)
+	eventRegistry := getTestEventRegistry(t, meta)
+	errorRegistry := getTestErrorRegistry(t, meta)
+
+	extrinsicFailedEventData := []byte{
+		3, // Dispatch error - Module
+		byte(getTestPalletIndex(t, meta, "Balances")), // Module index
+		2, 0, 0, 0, // Error index
+		0, 0, // Weight
+		0, // Class
+		0, // Pays fee
+	}
+
+	events := []*Event{
+		newTestEvent(t, eventRegistry, "System.ExtrinsicFailed", extrinsicFailedEventData),
+	}
+
+	res, err := GetExtrinsicOutcome(events, errorRegistry)
+	assert.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.Equal(t, "Balances.InsufficientBalance", res.DispatchError.ModuleError.Name)
+	assert.Equal(t, types.U128{}, res.FeePaid)
+
+	// The error registry does not know about the module error.
+
+	res, err = GetExtrinsicOutcome(events, registry.ErrorRegistry{})
+	assert.ErrorIs(t, err, ErrModuleErrorDecoding)
+	assert.Nil(t, res)
+}
+
+func TestGetExtrinsicOutcome_Errors(t *testing.T) {
+	meta := getTestMetadata(t)
+	eventRegistry := getTestEventRegistry(t, meta)
+	errorRegistry := getTestErrorRegistry(t, meta)
+
+	// Missing outcome.
+
+	events := []*Event{
+		newTestEvent(t, eventRegistry, "System.Remarked", make([]byte, 64)),
+	}
+
+	res, err := GetExtrinsicOutcome(events, errorRegistry)
+	assert.ErrorIs(t, err, ErrExtrinsicOutcomeNotFound)
+	assert.Nil(t, res)
+
+	// Invalid dispatch error.
+
+	events = []*Event{
+		{
+			Name:   "System.ExtrinsicFailed",
+			Fields: registry.DecodedFields{{Name: "dispatch_error", Value: "error"}},
+		},
+	}
+
+	res, err = GetExtrinsicOutcome(events, errorRegistry)
+	assert.ErrorIs(t, err, ErrDispatchErrorDecoding)
+	assert.Nil(t, res)
+
+	// Invalid fee paid.
+
+	feePaidEvent := newTestEvent(
+		t,
+		eventRegistry,
+		"TransactionPayment.TransactionFeePaid",
+		getTestFeePaidEventData(t, types.NewU128(*big.NewInt(1234))),
+	)
+	feePaidEvent.Fields[1].Value = types.U8(0)
+
+	events = []*Event{
+		feePaidEvent,
+		newTestEvent(t, eventRegistry, "System.ExtrinsicSuccess", []byte{0, 0, 0, 0}),
+	}
+
+	res, err = GetExtrinsicOutcome(events, errorRegistry)
+	assert.ErrorIs(t, err, ErrFeePaidDecoding)
+	assert.Nil(t, res)
+}
+
+func newTestEvent(t *testing.T, eventRegistry registry.EventRegistry, eventName string, eventData []byte) *Event {
+	for eventID, eventDecoder := range eventRegistry {
+		if eventDecoder.Name != eventName {
+			continue
+		}
+
+		eventFields, err := eventDecoder.Decode(scale.NewDecoder(bytes.NewReader(eventData)))
+		assert.NoError(t, err)
+
+		return &Event{
+			Name:    eventName,
+			Fields:  eventFields,
+			EventID: eventID,
+		}
+	}
+
+	t.Fatalf("event %s not found", eventName)
+
+	return nil
+}
+
+func getTestFeePaidEventData(t *testing.T, feePaid types.U128) []byte {
+	encodedFeePaid, err := codec.Encode(feePaid)
+	assert.NoError(t, err)
+
+	encodedTip, err := codec.Encode(types.NewU128(*big.NewInt(0)))
+	assert.NoError(t, err)
+
+	eventData := append(make([]byte, 32), encodedFeePaid...)
+
+	return append(eventData, encodedTip...)
+}
+
+func getTestEventRegistry(t *testing.T, meta *types.Metadata) registry.EventRegistry {
+	eventRegistry, err := registry.NewFactory().CreateEventRegistry(meta)
+	assert.NoError(t, err)
+
+	return eventRegistry
+}
+
+func getTestErrorRegistry(t *testing.T, meta *types.Metadata) registry.ErrorRegistry {
+	errorRegistry, err := registry.NewFactory().CreateErrorRegistry(meta)
+	assert.NoError(t, err)
+
+	return errorRegistry
+}
+
+func getTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
+
+func getTestPalletIndex(t *testing.T, meta *types.Metadata, palletName string) types.U8 {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) == palletName {
+			return pallet.Index
+		}
+	}
+
+	t.Fatalf("pallet %s not found", palletName)
+
+	return 0
+}
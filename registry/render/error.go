@@ -0,0 +1,28 @@
+package render
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrNilEvent                = libErr.Error("nil event")
+	ErrNilExtrinsic            = libErr.Error("nil extrinsic")
+	ErrEventFieldsRendering    = libErr.Error("event fields rendering")
+	ErrExtrinsicFieldNotFound  = libErr.Error("extrinsic field not found")
+	ErrExtrinsicFieldRendering = libErr.Error("extrinsic field rendering")
+	ErrUnexpectedCallValue     = libErr.Error("unexpected call value")
+	ErrUnsupportedValue        = libErr.Error("unsupported value")
+
+	ErrPalletNotFound           = libErr.Error("pallet not found")
+	ErrCallNotFound             = libErr.Error("call not found")
+	ErrEventNotFound            = libErr.Error("event not found")
+	ErrStorageEntryNotFound     = libErr.Error("storage entry not found")
+	ErrVariantNotFound          = libErr.Error("variant not found")
+	ErrTypeNotFound             = libErr.Error("type not found")
+	ErrUnsupportedTypeDef       = libErr.Error("unsupported type definition")
+	ErrUnexpectedOptionType     = libErr.Error("unexpected option type")
+	ErrUnexpectedStorageKeyType = libErr.Error("unexpected storage key type")
+	ErrTypeSchema               = libErr.Error("type schema")
+	ErrVariantSchema            = libErr.Error("variant schema")
+	ErrCallSchema               = libErr.Error("call schema")
+	ErrFieldSchema              = libErr.Error("field schema")
+	ErrStorageEntrySchema       = libErr.Error("storage entry schema")
+)
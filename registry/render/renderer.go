@@ -0,0 +1,862 @@
+package render
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+//go:generate mockery --name Renderer --structname RendererMock --filename renderer_mock.go --inpackage
+
+// Renderer is the interface used for rendering decoded registry values into values that can be marshaled
+// into JSON that is compatible with the one produced by polkadot-js.
+//
+// The rendered values consist of map[string]any, []any, string, bool, numbers and nil, where:
+//
+//   - account IDs are rendered as SS58 addresses;
+//   - byte arrays and sequences are rendered as hex strings;
+//   - integers wider than 32 bits and compact integers are rendered as strings;
+//   - variants without fields are rendered as their name, if all the variants of the enum have no fields;
+//   - other variants are rendered as an object holding the camel case variant name and the variant fields;
+//   - options are rendered as null or their inner value.
+type Renderer interface {
+	// RenderFields renders the provided decoded fields into an object keyed by the camel case field names.
+	RenderFields(decodedFields registry.DecodedFields) (map[string]any, error)
+
+	// RenderEvent renders the provided event into an object holding its index, section, method, data,
+	// phase and topics.
+	RenderEvent(event *parser.Event) (map[string]any, error)
+
+	// RenderExtrinsic renders the provided decoded extrinsic into an object holding its signature information,
+	// the values of its signed extensions and its method.
+	RenderExtrinsic(decodedExtrinsic *registry.DecodedExtrinsic) (map[string]any, error)
+}
+
+const (
+	// DefaultSS58Format is the generic Substrate SS58 format.
+	DefaultSS58Format = 42
+
+	accountIDTypeName = "AccountId32"
+	optionTypeName    = "Option"
+
+	accountIDLength = 32
+)
+
+type options struct {
+	ss58Format    uint16
+	tokenDecimals *uint32
+}
+
+// Option is used for configuring a Renderer.
+type Option func(opts *options)
+
+// WithSS58Format sets the SS58 format used for rendering account IDs.
+func WithSS58Format(format uint16) Option {
+	return func(opts *options) {
+		opts.ss58Format = format
+	}
+}
+
+// WithTokenDecimals sets the decimals that are applied when rendering balances.
+func WithTokenDecimals(decimals uint32) Option {
+	return func(opts *options) {
+		opts.tokenDecimals = &decimals
+	}
+}
+
+// WithChainProperties sets the SS58 format and the token decimals found in the provided chain properties,
+// as returned by system_properties.
+func WithChainProperties(props types.ChainProperties) Option {
+	return func(opts *options) {
+		if props.IsSS58Format {
+			opts.ss58Format = uint16(props.AsSS58Format)
+		}
+
+		if props.IsTokenDecimals {
+			decimals := uint32(props.AsTokenDecimals)
+
+			opts.tokenDecimals = &decimals
+		}
+	}
+}
+
+// renderer implements the Renderer interface.
+type renderer struct {
+	meta *types.Metadata
+	opts options
+}
+
+// NewRenderer creates a new Renderer that uses the types found in the provided metadata.
+//
+// Values with types that are not found in the metadata are rendered based on their Go type.
+func NewRenderer(meta *types.Metadata, opts ...Option) Renderer {
+	renderOpts := options{
+		ss58Format: DefaultSS58Format,
+	}
+
+	for _, opt := range opts {
+		opt(&renderOpts)
+	}
+
+	return &renderer{
+		meta: meta,
+		opts: renderOpts,
+	}
+}
+
+// RenderFields renders the provided decoded fields into an object keyed by the camel case field names.
+func (r *renderer) RenderFields(decodedFields registry.DecodedFields) (map[string]any, error) {
+	res := make(map[string]any, len(decodedFields))
+
+	for _, decodedField := range decodedFields {
+		value, err := r.renderValue(decodedField.Value, decodedField.LookupIndex)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res[getFieldKey(decodedField.Name)] = value
+	}
+
+	return res, nil
+}
+
+// RenderEvent renders the provided event into an object holding its index, section, method, data,
+// phase and topics.
+func (r *renderer) RenderEvent(event *parser.Event) (map[string]any, error) {
+	if event == nil {
+		return nil, ErrNilEvent
+	}
+
+	section, method, _ := strings.Cut(event.Name, ".")
+
+	data, err := r.renderEventData(event)
+
+	if err != nil {
+		return nil, ErrEventFieldsRendering.Wrap(err)
+	}
+
+	topics := make([]any, 0, len(event.Topics))
+
+	for _, topic := range event.Topics {
+		topics = append(topics, topic.Hex())
+	}
+
+	return map[string]any{
+		"index":   codec.HexEncodeToString(event.EventID[:]),
+		"section": toCamelCase(section),
+		"method":  method,
+		"data":    data,
+		"phase":   renderPhase(event.Phase),
+		"topics":  topics,
+	}, nil
+}
+
+func (r *renderer) renderEventData(event *parser.Event) (any, error) {
+	eventVariant, ok := r.getEventVariant(event.EventID)
+
+	if !ok || len(eventVariant.Fields) != len(event.Fields) {
+		return r.RenderFields(event.Fields)
+	}
+
+	if len(eventVariant.Fields) == 0 {
+		return map[string]any{}, nil
+	}
+
+	return r.renderFieldsWithTypes(event.Fields, eventVariant.Fields)
+}
+
+// RenderExtrinsic renders the provided decoded extrinsic into an object holding its signature information,
+// the values of its signed extensions and its method.
+func (r *renderer) RenderExtrinsic(decodedExtrinsic *registry.DecodedExtrinsic) (map[string]any, error) {
+	if decodedExtrinsic == nil {
+		return nil, ErrNilExtrinsic
+	}
+
+	res := map[string]any{
+		"isSigned": decodedExtrinsic.IsSigned(),
+		"version":  decodedExtrinsic.Version &^ extrinsic.BitSigned,
+	}
+
+	if decodedExtrinsic.IsSigned() {
+		if err := r.renderSignatureFields(decodedExtrinsic, res); err != nil {
+			return nil, err
+		}
+	}
+
+	callField, err := getExtrinsicField(decodedExtrinsic, registry.ExtrinsicCallName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	call, ok := callField.Value.(*registry.DecodedCall)
+
+	if !ok {
+		return nil, ErrExtrinsicFieldRendering.
+			WithMsg("field '%s'", registry.ExtrinsicCallName).
+			Wrap(ErrUnexpectedCallValue.WithMsg("expected call, got %T", callField.Value))
+	}
+
+	method, err := r.renderCall(call)
+
+	if err != nil {
+		return nil, ErrExtrinsicFieldRendering.WithMsg("field '%s'", registry.ExtrinsicCallName).Wrap(err)
+	}
+
+	res["method"] = method
+
+	return res, nil
+}
+
+func (r *renderer) renderSignatureFields(decodedExtrinsic *registry.DecodedExtrinsic, res map[string]any) error {
+	for _, signatureField := range []struct {
+		fieldName string
+		key       string
+	}{
+		{registry.ExtrinsicAddressName, "signer"},
+		{registry.ExtrinsicSignatureName, "signature"},
+	} {
+		fieldName, key := signatureField.fieldName, signatureField.key
+
+		field, err := getExtrinsicField(decodedExtrinsic, fieldName)
+
+		if err != nil {
+			return err
+		}
+
+		value, err := r.renderValue(field.Value, field.LookupIndex)
+
+		if err != nil {
+			return ErrExtrinsicFieldRendering.WithMsg("field '%s'", fieldName).Wrap(err)
+		}
+
+		res[key] = value
+	}
+
+	extraField, err := getExtrinsicField(decodedExtrinsic, registry.ExtrinsicExtraName)
+
+	if err != nil {
+		return err
+	}
+
+	extra, err := r.renderExtra(extraField)
+
+	if err != nil {
+		return ErrExtrinsicFieldRendering.WithMsg("field '%s'", registry.ExtrinsicExtraName).Wrap(err)
+	}
+
+	res["extra"] = extra
+
+	return nil
+}
+
+// renderExtra renders the values of the signed extensions into an object keyed by the camel case
+// signed extension identifiers. Signed extensions without values are omitted.
+func (r *renderer) renderExtra(extraField *registry.DecodedField) (map[string]any, error) {
+	res := make(map[string]any)
+
+	extraFields, ok := extraField.Value.(registry.DecodedFields)
+
+	if !ok || r.meta == nil || len(extraFields) != len(r.meta.AsMetadataV14.Extrinsic.SignedExtensions) {
+		return r.RenderFields(extraFields)
+	}
+
+	for i, signedExtension := range r.meta.AsMetadataV14.Extrinsic.SignedExtensions {
+		value, err := r.renderValue(extraFields[i].Value, signedExtension.Type.Int64())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if value == nil {
+			continue
+		}
+
+		res[toCamelCase(string(signedExtension.Identifier))] = value
+	}
+
+	return res, nil
+}
+
+// renderCall renders a call into an object holding the call index, section, method and arguments.
+func (r *renderer) renderCall(call *registry.DecodedCall) (map[string]any, error) {
+	args, err := r.renderCallArgs(call)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"callIndex": codec.HexEncodeToString([]byte{call.CallIndex.SectionIndex, call.CallIndex.MethodIndex}),
+		"section":   toCamelCase(call.PalletName),
+		"method":    toCamelCase(call.CallName),
+		"args":      args,
+	}, nil
+}
+
+func (r *renderer) renderCallArgs(call *registry.DecodedCall) (map[string]any, error) {
+	variant, ok := r.getVariant(call.LookupIndex, call.CallIndex.MethodIndex)
+
+	if ok && len(variant.Fields) == len(call.Fields) {
+		res := make(map[string]any, len(call.Fields))
+
+		for i, field := range variant.Fields {
+			value, err := r.renderFieldValue(call.Fields[i].Value, field)
+
+			if err != nil {
+				return nil, err
+			}
+
+			res[getSi1FieldKey(field, i)] = value
+		}
+
+		return res, nil
+	}
+
+	return r.RenderFields(call.Fields)
+}
+
+// renderValue renders the provided value using the type found at the provided lookup index, if any.
+func (r *renderer) renderValue(value any, lookupIndex int64) (any, error) {
+	// Calls, including the ones nested in other calls, are rendered as the call of an extrinsic.
+	if call, ok := value.(*registry.DecodedCall); ok {
+		return r.renderCall(call)
+	}
+
+	lookupType, ok := r.getType(lookupIndex)
+
+	if !ok {
+		return r.renderUntypedValue(value)
+	}
+
+	typeDef := lookupType.Def
+
+	switch {
+	case typeDef.IsComposite:
+		return r.renderComposite(value, lookupType)
+	case typeDef.IsVariant:
+		return r.renderVariant(value, lookupType)
+	case typeDef.IsSequence:
+		return r.renderItems(value, typeDef.Sequence.Type.Int64())
+	case typeDef.IsArray:
+		return r.renderItems(value, typeDef.Array.Type.Int64())
+	case typeDef.IsTuple:
+		return r.renderTuple(value, typeDef.Tuple)
+	default:
+		return r.renderUntypedValue(value)
+	}
+}
+
+func (r *renderer) renderComposite(value any, lookupType *types.Si1Type) (any, error) {
+	fields := lookupType.Def.Composite.Fields
+
+	decodedFields, ok := value.(registry.DecodedFields)
+
+	if !ok || len(decodedFields) != len(fields) {
+		return r.renderUntypedValue(value)
+	}
+
+	if isAccountIDType(lookupType) {
+		if accountID, ok := getBytes(decodedFields[0].Value); ok && len(accountID) == accountIDLength {
+			return subkey.SS58Encode(accountID, r.opts.ss58Format), nil
+		}
+	}
+
+	return r.renderFieldsWithTypes(decodedFields, fields)
+}
+
+func (r *renderer) renderVariant(value any, lookupType *types.Si1Type) (any, error) {
+	decodedVariant, ok := value.(*registry.DecodedVariant)
+
+	if !ok {
+		return r.renderUntypedValue(value)
+	}
+
+	variant, ok := findVariant(lookupType.Def.Variant, decodedVariant.Index)
+
+	if !ok || len(variant.Fields) != len(decodedVariant.Fields) {
+		return r.renderUntypedValue(value)
+	}
+
+	if isOptionType(lookupType) {
+		if len(decodedVariant.Fields) == 0 {
+			return nil, nil
+		}
+
+		return r.renderValue(decodedVariant.Fields[0].Value, variant.Fields[0].Type.Int64())
+	}
+
+	if isBasicVariant(lookupType.Def.Variant) {
+		return decodedVariant.Name, nil
+	}
+
+	variantValue, err := r.renderFieldsWithTypes(decodedVariant.Fields, variant.Fields)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		toCamelCase(decodedVariant.Name): variantValue,
+	}, nil
+}
+
+func (r *renderer) renderItems(value any, itemLookupIndex int64) (any, error) {
+	items, ok := value.([]any)
+
+	if !ok {
+		return r.renderUntypedValue(value)
+	}
+
+	if r.isU8Type(itemLookupIndex) {
+		if b, ok := getBytes(items); ok {
+			return codec.HexEncodeToString(b), nil
+		}
+	}
+
+	res := make([]any, 0, len(items))
+
+	for _, item := range items {
+		renderedItem, err := r.renderValue(item, itemLookupIndex)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, renderedItem)
+	}
+
+	return res, nil
+}
+
+func (r *renderer) renderTuple(value any, tuple types.Si1TypeDefTuple) (any, error) {
+	decodedFields, ok := value.(registry.DecodedFields)
+
+	if !ok || len(decodedFields) != len(tuple) {
+		return r.renderUntypedValue(value)
+	}
+
+	res := make([]any, 0, len(decodedFields))
+
+	for i, decodedField := range decodedFields {
+		renderedItem, err := r.renderValue(decodedField.Value, tuple[i].Int64())
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, renderedItem)
+	}
+
+	return res, nil
+}
+
+// renderFieldsWithTypes renders the provided decoded fields using the types of the provided fields.
+//
+// A single unnamed field is rendered as its value, unnamed fields are rendered as an array and
+// named fields are rendered as an object keyed by the camel case field names.
+func (r *renderer) renderFieldsWithTypes(decodedFields registry.DecodedFields, fields []types.Si1Field) (any, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	if len(fields) == 1 && !fields[0].HasName {
+		return r.renderFieldValue(decodedFields[0].Value, fields[0])
+	}
+
+	if !fields[0].HasName {
+		res := make([]any, 0, len(fields))
+
+		for i, field := range fields {
+			value, err := r.renderFieldValue(decodedFields[i].Value, field)
+
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, value)
+		}
+
+		return res, nil
+	}
+
+	res := make(map[string]any, len(fields))
+
+	for i, field := range fields {
+		value, err := r.renderFieldValue(decodedFields[i].Value, field)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res[getSi1FieldKey(field, i)] = value
+	}
+
+	return res, nil
+}
+
+// renderFieldValue renders the provided value using the type of the field, and applies the token decimals
+// if the field holds a balance.
+func (r *renderer) renderFieldValue(value any, field types.Si1Field) (any, error) {
+	res, err := r.renderValue(value, field.Type.Int64())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.opts.tokenDecimals == nil || !field.HasTypeName || !isBalanceTypeName(string(field.TypeName)) {
+		return res, nil
+	}
+
+	if amount, ok := res.(string); ok {
+		if formattedAmount, ok := applyDecimals(amount, *r.opts.tokenDecimals); ok {
+			return formattedAmount, nil
+		}
+	}
+
+	return res, nil
+}
+
+// renderUntypedValue renders the provided value based on its Go type.
+func (r *renderer) renderUntypedValue(value any) (any, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case registry.DecodedFields:
+		return r.RenderFields(v)
+	case *registry.DecodedCall:
+		return r.renderCall(v)
+	case *registry.DecodedVariant:
+		if len(v.Fields) == 0 {
+			return v.Name, nil
+		}
+
+		variantValue, err := r.RenderFields(v.Fields)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]any{
+			toCamelCase(v.Name): variantValue,
+		}, nil
+	case []any:
+		if b, ok := getBytes(v); ok && len(b) > 0 {
+			return codec.HexEncodeToString(b), nil
+		}
+
+		res := make([]any, 0, len(v))
+
+		for _, item := range v {
+			renderedItem, err := r.renderUntypedValue(item)
+
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, renderedItem)
+		}
+
+		return res, nil
+	case map[string]string:
+		// Bit sequences are decoded as a map holding the bit sequence string.
+		for _, bitSequence := range v {
+			return bitSequence, nil
+		}
+
+		return "", nil
+	default:
+		return renderPrimitive(value)
+	}
+}
+
+//nolint:gocyclo
+func renderPrimitive(value any) (any, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return v, nil
+	case byte:
+		return string(rune(v)), nil
+	case types.U8:
+		return uint8(v), nil
+	case types.U16:
+		return uint16(v), nil
+	case types.U32:
+		return uint32(v), nil
+	case types.U64:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case types.U128:
+		return bigIntString(v.Int), nil
+	case types.U256:
+		return bigIntString(v.Int), nil
+	case types.I8:
+		return int8(v), nil
+	case types.I16:
+		return int16(v), nil
+	case types.I32:
+		return int32(v), nil
+	case types.I64:
+		return strconv.FormatInt(int64(v), 10), nil
+	case types.I128:
+		return bigIntString(v.Int), nil
+	case types.I256:
+		return bigIntString(v.Int), nil
+	case types.UCompact:
+		return bigIntString((*big.Int)(&v)), nil
+	default:
+		return nil, ErrUnsupportedValue.WithMsg("%T", value)
+	}
+}
+
+func renderPhase(phase *types.Phase) any {
+	switch {
+	case phase == nil:
+		return nil
+	case phase.IsApplyExtrinsic:
+		return map[string]any{
+			"applyExtrinsic": phase.AsApplyExtrinsic,
+		}
+	case phase.IsFinalization:
+		return "Finalization"
+	case phase.IsInitialization:
+		return "Initialization"
+	default:
+		return nil
+	}
+}
+
+func (r *renderer) getType(lookupIndex int64) (*types.Si1Type, bool) {
+	if r.meta == nil {
+		return nil, false
+	}
+
+	lookupType, ok := r.meta.AsMetadataV14.EfficientLookup[lookupIndex]
+
+	return lookupType, ok
+}
+
+func (r *renderer) getVariant(lookupIndex int64, variantIndex byte) (types.Si1Variant, bool) {
+	lookupType, ok := r.getType(lookupIndex)
+
+	if !ok || !lookupType.Def.IsVariant {
+		return types.Si1Variant{}, false
+	}
+
+	return findVariant(lookupType.Def.Variant, variantIndex)
+}
+
+func (r *renderer) getEventVariant(eventID types.EventID) (types.Si1Variant, bool) {
+	if r.meta == nil {
+		return types.Si1Variant{}, false
+	}
+
+	for _, pallet := range r.meta.AsMetadataV14.Pallets {
+		if !pallet.HasEvents || byte(pallet.Index) != eventID[0] {
+			continue
+		}
+
+		return r.getVariant(pallet.Events.Type.Int64(), eventID[1])
+	}
+
+	return types.Si1Variant{}, false
+}
+
+func (r *renderer) isU8Type(lookupIndex int64) bool {
+	lookupType, ok := r.getType(lookupIndex)
+
+	if !ok {
+		return false
+	}
+
+	return lookupType.Def.IsPrimitive && lookupType.Def.Primitive.Si0TypeDefPrimitive == types.IsU8
+}
+
+func findVariant(variantDef types.Si1TypeDefVariant, variantIndex byte) (types.Si1Variant, bool) {
+	for _, variant := range variantDef.Variants {
+		if byte(variant.Index) == variantIndex {
+			return variant, true
+		}
+	}
+
+	return types.Si1Variant{}, false
+}
+
+// isBasicVariant returns true if none of the variants have fields.
+func isBasicVariant(variantDef types.Si1TypeDefVariant) bool {
+	for _, variant := range variantDef.Variants {
+		if len(variant.Fields) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isAccountIDType(lookupType *types.Si1Type) bool {
+	pathLen := len(lookupType.Path)
+
+	return pathLen > 0 && string(lookupType.Path[pathLen-1]) == accountIDTypeName
+}
+
+func isOptionType(lookupType *types.Si1Type) bool {
+	return len(lookupType.Path) == 1 && string(lookupType.Path[0]) == optionTypeName
+}
+
+// isBalanceTypeName returns true for field type names such as T::Balance, BalanceOf<T>
+// or Compact<BalanceOf<T, I>>.
+func isBalanceTypeName(typeName string) bool {
+	for {
+		inner, ok := strings.CutPrefix(typeName, "Compact<")
+
+		if !ok {
+			break
+		}
+
+		typeName = strings.TrimSuffix(inner, ">")
+	}
+
+	if i := strings.Index(typeName, "<"); i > 0 {
+		typeName = typeName[:i]
+	}
+
+	if i := strings.LastIndex(typeName, "::"); i >= 0 {
+		typeName = typeName[i+2:]
+	}
+
+	return typeName == "Balance" || typeName == "BalanceOf"
+}
+
+// applyDecimals formats the provided integer amount as a decimal number with the provided decimals,
+// without trailing zeros.
+func applyDecimals(amount string, decimals uint32) (string, bool) {
+	if _, ok := new(big.Int).SetString(amount, 10); !ok {
+		return "", false
+	}
+
+	if decimals == 0 {
+		return amount, true
+	}
+
+	sign, digits := "", amount
+
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+
+	integerPart := digits[:len(digits)-int(decimals)]
+	fractionalPart := strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+
+	if fractionalPart == "" {
+		return sign + integerPart, true
+	}
+
+	return sign + integerPart + "." + fractionalPart, true
+}
+
+func getBytes(value any) ([]byte, bool) {
+	switch v := value.(type) {
+	case []any:
+		b := make([]byte, 0, len(v))
+
+		for _, item := range v {
+			u8, ok := item.(types.U8)
+
+			if !ok {
+				return nil, false
+			}
+
+			b = append(b, byte(u8))
+		}
+
+		return b, true
+	case registry.DecodedFields:
+		if len(v) != 1 {
+			return nil, false
+		}
+
+		return getBytes(v[0].Value)
+	default:
+		return nil, false
+	}
+}
+
+func getExtrinsicField(decodedExtrinsic *registry.DecodedExtrinsic, fieldName string) (*registry.DecodedField, error) {
+	for _, field := range decodedExtrinsic.DecodedFields {
+		if field.Name == fieldName {
+			return field, nil
+		}
+	}
+
+	return nil, ErrExtrinsicFieldNotFound.WithMsg("field '%s'", fieldName)
+}
+
+func bigIntString(i *big.Int) string {
+	if i == nil {
+		return "0"
+	}
+
+	return i.String()
+}
+
+func getSi1FieldKey(field types.Si1Field, fieldIndex int) string {
+	if !field.HasName {
+		return strconv.Itoa(fieldIndex)
+	}
+
+	return toCamelCase(string(field.Name))
+}
+
+// getFieldKey returns the camel case name of a decoded field, which has the format <type path>.<field name>.
+func getFieldKey(fieldName string) string {
+	if i := strings.LastIndex(fieldName, "."); i >= 0 {
+		fieldName = fieldName[i+1:]
+	}
+
+	return toCamelCase(fieldName)
+}
+
+// toCamelCase converts names such as transfer_keep_alive or TransferKeepAlive to transferKeepAlive.
+//
+// Names that are not identifiers, such as type names, are returned unchanged.
+func toCamelCase(name string) string {
+	for _, c := range name {
+		if c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			return name
+		}
+	}
+
+	var sb strings.Builder
+
+	for i, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+
+		runes := []rune(part)
+
+		if i == 0 || sb.Len() == 0 {
+			runes[0] = unicode.ToLower(runes[0])
+		} else {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+
+		sb.WriteString(string(runes))
+	}
+
+	return sb.String()
+}
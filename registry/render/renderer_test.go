@@ -0,0 +1,385 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	aliceAddress                = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	alicePolkadotAddress        = "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5"
+	transferKeepAliveCallIndex  = "0x1403"
+	transferKeepAliveCallAmount = 12345
+)
+
+func TestRenderer_RenderExtrinsic(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	decodedExtrinsic := getTestDecodedExtrinsic(t, meta, true)
+
+	res, err := NewRenderer(meta).RenderExtrinsic(decodedExtrinsic)
+	assert.NoError(t, err)
+
+	assert.Equal(t, true, res["isSigned"])
+	assert.Equal(t, byte(4), res["version"])
+	assert.Equal(t, map[string]any{"id": aliceAddress}, res["signer"])
+
+	sig, ok := res["signature"].(map[string]any)
+	assert.True(t, ok)
+	assert.True(t, strings.HasPrefix(sig["sr25519"].(string), "0x"))
+
+	assert.Equal(t, map[string]any{
+		"checkMortality":           map[string]any{"immortal": nil},
+		"checkNonce":               "1",
+		"chargeTransactionPayment": "100",
+		"checkMetadataHash":        map[string]any{"mode": "Disabled"},
+	}, res["extra"])
+
+	assert.Equal(t, map[string]any{
+		"callIndex": transferKeepAliveCallIndex,
+		"section":   "balances",
+		"method":    "transferKeepAlive",
+		"args": map[string]any{
+			"dest":  map[string]any{"id": aliceAddress},
+			"value": "12345",
+		},
+	}, res["method"])
+
+	_, err = json.Marshal(res)
+	assert.NoError(t, err)
+}
+
+func TestRenderer_RenderExtrinsic_Options(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	decodedExtrinsic := getTestDecodedExtrinsic(t, meta, true)
+
+	res, err := NewRenderer(meta, WithSS58Format(0), WithTokenDecimals(3)).RenderExtrinsic(decodedExtrinsic)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"id": alicePolkadotAddress}, res["signer"])
+	assert.Equal(t, "0.1", res["extra"].(map[string]any)["chargeTransactionPayment"])
+	assert.Equal(t, "12.345", res["method"].(map[string]any)["args"].(map[string]any)["value"])
+}
+
+func TestRenderer_RenderExtrinsic_Unsigned(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	decodedExtrinsic := getTestDecodedExtrinsic(t, meta, false)
+
+	res, err := NewRenderer(meta).RenderExtrinsic(decodedExtrinsic)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"isSigned": false,
+		"version":  byte(4),
+		"method": map[string]any{
+			"callIndex": transferKeepAliveCallIndex,
+			"section":   "balances",
+			"method":    "transferKeepAlive",
+			"args": map[string]any{
+				"dest":  map[string]any{"id": aliceAddress},
+				"value": "12345",
+			},
+		},
+	}, res)
+}
+
+func TestRenderer_RenderExtrinsic_Errors(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	renderer := NewRenderer(meta)
+
+	res, err := renderer.RenderExtrinsic(nil)
+	assert.ErrorIs(t, err, ErrNilExtrinsic)
+	assert.Nil(t, res)
+
+	res, err = renderer.RenderExtrinsic(&registry.DecodedExtrinsic{Version: 4})
+	assert.ErrorIs(t, err, ErrExtrinsicFieldNotFound)
+	assert.Nil(t, res)
+
+	res, err = renderer.RenderExtrinsic(&registry.DecodedExtrinsic{Version: 4 | extrinsic.BitSigned})
+	assert.ErrorIs(t, err, ErrExtrinsicFieldNotFound)
+	assert.Nil(t, res)
+
+	res, err = renderer.RenderExtrinsic(&registry.DecodedExtrinsic{
+		Version: 4,
+		DecodedFields: registry.DecodedFields{
+			{Name: registry.ExtrinsicCallName, Value: types.U8(0)},
+		},
+	})
+	assert.ErrorIs(t, err, ErrUnexpectedCallValue)
+	assert.Nil(t, res)
+
+	res, err = renderer.RenderExtrinsic(&registry.DecodedExtrinsic{
+		Version: 4,
+		DecodedFields: registry.DecodedFields{
+			{
+				Name: registry.ExtrinsicCallName,
+				Value: &registry.DecodedVariant{
+					Name:   "System",
+					Fields: registry.DecodedFields{{Value: types.U8(0)}},
+				},
+			},
+		},
+	})
+	assert.ErrorIs(t, err, ErrUnexpectedCallValue)
+	assert.Nil(t, res)
+}
+
+func TestRenderer_RenderEvent(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	var eventData []byte
+
+	eventData = append(eventData, signature.TestKeyringPairAlice.PublicKey...)
+	eventData = append(eventData, signature.TestKeyringPairAlice.PublicKey...)
+
+	amount, err := codec.Encode(types.NewU128(*big.NewInt(1_500_000)))
+	assert.NoError(t, err)
+
+	eventData = append(eventData, amount...)
+
+	event := getTestEvent(t, meta, "Balances.Transfer", eventData)
+	event.Phase = &types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 2}
+	event.Topics = []types.Hash{{1}}
+
+	res, err := NewRenderer(meta).RenderEvent(event)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"index":   codec.HexEncodeToString(event.EventID[:]),
+		"section": "balances",
+		"method":  "Transfer",
+		"data": map[string]any{
+			"from":   aliceAddress,
+			"to":     aliceAddress,
+			"amount": "1500000",
+		},
+		"phase":  map[string]any{"applyExtrinsic": uint32(2)},
+		"topics": []any{types.Hash{1}.Hex()},
+	}, res)
+
+	res, err = NewRenderer(meta, WithTokenDecimals(6)).RenderEvent(event)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", res["data"].(map[string]any)["amount"])
+}
+
+func TestRenderer_RenderEvent_Errors(t *testing.T) {
+	res, err := NewRenderer(nil).RenderEvent(nil)
+	assert.ErrorIs(t, err, ErrNilEvent)
+	assert.Nil(t, res)
+
+	res, err = NewRenderer(nil).RenderEvent(&parser.Event{
+		Name:   "Test.Event",
+		Fields: registry.DecodedFields{{Name: "field", Value: struct{}{}}},
+	})
+	assert.ErrorIs(t, err, ErrEventFieldsRendering)
+	assert.ErrorIs(t, err, ErrUnsupportedValue)
+	assert.Nil(t, res)
+}
+
+func TestRenderer_RenderFields_WithoutTypes(t *testing.T) {
+	decodedFields := registry.DecodedFields{
+		{Name: "path.bool_value", Value: true},
+		{Name: "path.u32_value", Value: types.U32(1)},
+		{Name: "path.u64_value", Value: types.U64(2)},
+		{Name: "path.u128_value", Value: types.NewU128(*big.NewInt(3))},
+		{Name: "path.i128_value", Value: types.NewI128(*big.NewInt(-4))},
+		{Name: "path.compact_value", Value: types.NewUCompactFromUInt(5)},
+		{Name: "path.bytes", Value: []any{types.U8(1), types.U8(2)}},
+		{Name: "path.items", Value: []any{types.U16(1), types.U16(2)}},
+		{Name: "path.unit_variant", Value: &registry.DecodedVariant{Name: "First"}},
+		{
+			Name: "path.variant",
+			Value: &registry.DecodedVariant{
+				Name:   "Second",
+				Fields: registry.DecodedFields{{Name: "path.value", Value: types.U8(6)}},
+			},
+		},
+		{Name: "path.composite", Value: registry.DecodedFields{{Name: "path.inner", Value: "test"}}},
+		{Name: "path.bit_sequence", Value: map[string]string{"bit_sequence": "0b101"}},
+		{Name: "path.empty", Value: nil},
+	}
+
+	res, err := NewRenderer(nil).RenderFields(decodedFields)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"boolValue":    true,
+		"u32Value":     uint32(1),
+		"u64Value":     "2",
+		"u128Value":    "3",
+		"i128Value":    "-4",
+		"compactValue": "5",
+		"bytes":        "0x0102",
+		"items":        []any{uint16(1), uint16(2)},
+		"unitVariant":  "First",
+		"variant":      map[string]any{"second": map[string]any{"value": uint8(6)}},
+		"composite":    map[string]any{"inner": "test"},
+		"bitSequence":  "0b101",
+		"empty":        nil,
+	}, res)
+}
+
+func TestWithChainProperties(t *testing.T) {
+	var opts options
+
+	WithChainProperties(types.ChainProperties{})(&opts)
+	assert.Equal(t, options{}, opts)
+
+	WithChainProperties(types.ChainProperties{
+		IsSS58Format:    true,
+		AsSS58Format:    36,
+		IsTokenDecimals: true,
+		AsTokenDecimals: 18,
+	})(&opts)
+	assert.Equal(t, uint16(36), opts.ss58Format)
+	assert.Equal(t, uint32(18), *opts.tokenDecimals)
+}
+
+func Test_applyDecimals(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint32
+		expected string
+	}{
+		{"0", 12, "0"},
+		{"1", 0, "1"},
+		{"1", 3, "0.001"},
+		{"1000", 3, "1"},
+		{"1500", 3, "1.5"},
+		{"123456", 3, "123.456"},
+		{"-1500", 3, "-1.5"},
+	}
+
+	for _, test := range tests {
+		res, ok := applyDecimals(test.amount, test.decimals)
+		assert.True(t, ok)
+		assert.Equal(t, test.expected, res, "amount %s, decimals %d", test.amount, test.decimals)
+	}
+
+	_, ok := applyDecimals("test", 3)
+	assert.False(t, ok)
+}
+
+func Test_isBalanceTypeName(t *testing.T) {
+	for _, typeName := range []string{
+		"Balance",
+		"T::Balance",
+		"BalanceOf<T>",
+		"BalanceOf<T, I>",
+		"Compact<BalanceOf<T>>",
+		"<T as Config>::Balance",
+	} {
+		assert.True(t, isBalanceTypeName(typeName), typeName)
+	}
+
+	for _, typeName := range []string{"T::AccountId", "BalanceStatus", "Vec<u8>"} {
+		assert.False(t, isBalanceTypeName(typeName), typeName)
+	}
+}
+
+func Test_toCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"transfer_keep_alive": "transferKeepAlive",
+		"TransferKeepAlive":   "transferKeepAlive",
+		"CheckNonce":          "checkNonce",
+		"V4":                  "v4",
+		"_value":              "value",
+		"T::AccountId":        "T::AccountId",
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, toCamelCase(name))
+	}
+}
+
+func getTestDecodedExtrinsic(t *testing.T, meta *types.Metadata, signed bool) *registry.DecodedExtrinsic {
+	dest, err := types.NewMultiAddressFromAccountID(signature.TestKeyringPairAlice.PublicKey)
+	assert.NoError(t, err)
+
+	call, err := types.NewCall(
+		meta,
+		"Balances.transfer_keep_alive",
+		dest,
+		types.NewUCompactFromUInt(transferKeepAliveCallAmount),
+	)
+	assert.NoError(t, err)
+
+	xt := extrinsic.NewExtrinsic(call)
+
+	if signed {
+		err = xt.Sign(
+			signature.TestKeyringPairAlice,
+			meta,
+			extrinsic.WithEra(types.ExtrinsicEra{IsImmortalEra: true}, types.Hash{}),
+			extrinsic.WithNonce(types.NewUCompactFromUInt(1)),
+			extrinsic.WithTip(types.NewUCompactFromUInt(100)),
+			extrinsic.WithSpecVersion(1),
+			extrinsic.WithTransactionVersion(1),
+			extrinsic.WithGenesisHash(types.Hash{}),
+			extrinsic.WithMetadataMode(
+				extensions.CheckMetadataModeDisabled,
+				extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()},
+			),
+		)
+		assert.NoError(t, err)
+	}
+
+	encodedExtrinsic, err := codec.EncodeToHex(xt)
+	assert.NoError(t, err)
+
+	extrinsicDecoder, err := registry.NewFactory().CreateExtrinsicDecoder(meta)
+	assert.NoError(t, err)
+
+	decodedExtrinsic, err := extrinsicDecoder.DecodeHex(encodedExtrinsic)
+	assert.NoError(t, err)
+
+	return decodedExtrinsic
+}
+
+func getTestEvent(t *testing.T, meta *types.Metadata, eventName string, eventData []byte) *parser.Event {
+	eventRegistry, err := registry.NewFactory().CreateEventRegistry(meta)
+	assert.NoError(t, err)
+
+	for eventID, eventDecoder := range eventRegistry {
+		if eventDecoder.Name != eventName {
+			continue
+		}
+
+		eventFields, err := eventDecoder.Decode(scale.NewDecoder(bytes.NewReader(eventData)))
+		assert.NoError(t, err)
+
+		return &parser.Event{
+			Name:    eventName,
+			Fields:  eventFields,
+			EventID: eventID,
+		}
+	}
+
+	t.Fatalf("event %s not found", eventName)
+
+	return nil
+}
+
+func getTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
@@ -0,0 +1,1018 @@
+package render
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+//go:generate mockery --name SchemaGenerator --structname SchemaGeneratorMock --filename schema_generator_mock.go --inpackage
+
+// SchemaGenerator is the interface used for generating the JSON schemas of the values produced by a Renderer,
+// based on the types found in the metadata.
+//
+// Composite and variant types are emitted as definitions that are referenced by the returned schemas, and
+// are added to the Document returned by Document.
+type SchemaGenerator interface {
+	// CallSchema returns the schema of a rendered call, an object holding its call index, section, method and
+	// arguments.
+	CallSchema(palletName, callName string) (*Schema, error)
+
+	// EventSchema returns the schema of a rendered event, an object holding its index, section, method, data,
+	// phase and topics.
+	EventSchema(palletName, eventName string) (*Schema, error)
+
+	// StorageSchema returns the schemas of the keys and of the value of a storage entry.
+	StorageSchema(palletName, entryName string) (*StorageEntrySchema, error)
+
+	// TypeSchema returns the schema of a value with the type found at the provided lookup index.
+	//
+	// It can be used for the parameters and results of runtime APIs, which are not described by V14 metadata.
+	TypeSchema(lookupIndex int64) (*Schema, error)
+
+	// Document returns a document holding the provided schemas and the definitions referenced by the schemas
+	// returned so far.
+	Document(schemas map[string]*Schema) *Document
+}
+
+// SchemaFormat is the format of the documents returned by a SchemaGenerator.
+type SchemaFormat int
+
+const (
+	// JSONSchemaFormat is used for JSON Schema 2020-12 documents, where the definitions are found under $defs.
+	JSONSchemaFormat SchemaFormat = iota
+	// OpenAPIFormat is used for OpenAPI 3.1 components, where the definitions are found under components.schemas.
+	OpenAPIFormat
+)
+
+const (
+	// JSONSchemaDialect is the dialect of the JSON Schema documents.
+	JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+	jsonSchemaRefPrefix = "#/$defs/"
+	openAPIRefPrefix    = "#/components/schemas/"
+
+	schemaTypeNull    = "null"
+	schemaTypeBoolean = "boolean"
+	schemaTypeInteger = "integer"
+	schemaTypeString  = "string"
+	schemaTypeArray   = "array"
+	schemaTypeObject  = "object"
+
+	ss58SchemaFormat = "ss58"
+
+	hexPattern           = "^0x([0-9a-f]{2})*$"
+	fixedHexPatternFmt   = "^0x[0-9a-f]{%d}$"
+	unsignedPattern      = "^[0-9]+$"
+	signedPattern        = "^-?[0-9]+$"
+	balancePattern       = "^-?[0-9]+(\\.[0-9]+)?$"
+	bitSequencePattern   = "^0b[01]*$"
+	lookupIndexDefFormat = "Lookup%d"
+
+	compactTupleItemKeyFormat = "tupleItem%d"
+	lookupIndexFieldFormat    = "lookup_index_%d"
+)
+
+// Schema is a JSON schema, that can be used both in JSON Schema 2020-12 documents and OpenAPI 3.1 components.
+type Schema struct {
+	Ref         string `json:"$ref,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type    string `json:"type,omitempty"`
+	Format  string `json:"format,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+	Enum    []any  `json:"enum,omitempty"`
+	Const   any    `json:"const,omitempty"`
+
+	Minimum   *int64 `json:"minimum,omitempty"`
+	Maximum   *int64 `json:"maximum,omitempty"`
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+
+	Items       *Schema   `json:"items,omitempty"`
+	PrefixItems []*Schema `json:"prefixItems,omitempty"`
+	MinItems    *int      `json:"minItems,omitempty"`
+	MaxItems    *int      `json:"maxItems,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+
+	OneOf []*Schema `json:"oneOf,omitempty"`
+}
+
+// StorageEntrySchema holds the schemas of a storage entry.
+type StorageEntrySchema struct {
+	// Keys holds the schemas of the keys of a map, one for each hasher. It is empty for plain entries.
+	Keys []*Schema
+
+	// Value holds the schema of the value, which is nullable for optional entries.
+	Value *Schema
+}
+
+// Document is a JSON Schema document or a document holding OpenAPI components, depending on the SchemaFormat.
+type Document struct {
+	Schema     string             `json:"$schema,omitempty"`
+	Defs       map[string]*Schema `json:"$defs,omitempty"`
+	Components *Components        `json:"components,omitempty"`
+}
+
+// Components holds the OpenAPI component schemas.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type schemaOptions struct {
+	format SchemaFormat
+}
+
+// SchemaOption is used for configuring a SchemaGenerator.
+type SchemaOption func(opts *schemaOptions)
+
+// WithSchemaFormat sets the format of the documents, which also determines the references to the definitions.
+func WithSchemaFormat(format SchemaFormat) SchemaOption {
+	return func(opts *schemaOptions) {
+		opts.format = format
+	}
+}
+
+// schemaGenerator implements the SchemaGenerator interface.
+type schemaGenerator struct {
+	meta *types.Metadata
+	opts schemaOptions
+
+	// pathCounts holds the number of types that share a path, the definitions of these types are suffixed
+	// with their lookup index.
+	pathCounts map[string]int
+
+	defs map[string]*Schema
+}
+
+// NewSchemaGenerator creates a new SchemaGenerator that uses the types found in the provided metadata.
+//
+// The schemas match the values rendered by a Renderer with any of its options, integers that are rendered as
+// strings allow decimals if they are balances. The returned SchemaGenerator is not safe for concurrent use.
+func NewSchemaGenerator(meta *types.Metadata, opts ...SchemaOption) SchemaGenerator {
+	var schemaOpts schemaOptions
+
+	for _, opt := range opts {
+		opt(&schemaOpts)
+	}
+
+	pathCounts := make(map[string]int)
+
+	if meta != nil {
+		for _, lookupType := range meta.AsMetadataV14.EfficientLookup {
+			if len(lookupType.Path) > 0 {
+				pathCounts[getTypePath(lookupType)]++
+			}
+		}
+	}
+
+	return &schemaGenerator{
+		meta:       meta,
+		opts:       schemaOpts,
+		pathCounts: pathCounts,
+		defs:       make(map[string]*Schema),
+	}
+}
+
+// CallSchema returns the schema of a rendered call, an object holding its call index, section, method and
+// arguments.
+func (g *schemaGenerator) CallSchema(palletName, callName string) (*Schema, error) {
+	pallet, err := g.getPallet(palletName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !pallet.HasCalls {
+		return nil, ErrCallNotFound.WithMsg("pallet '%s' has no calls", palletName)
+	}
+
+	variant, err := g.getNamedVariant(pallet.Calls.Type.Int64(), callName)
+
+	if err != nil {
+		return nil, ErrCallNotFound.WithMsg("call '%s.%s'", palletName, callName).Wrap(err)
+	}
+
+	return g.callSchema(string(pallet.Name), byte(pallet.Index), variant)
+}
+
+// EventSchema returns the schema of a rendered event, an object holding its index, section, method, data,
+// phase and topics.
+func (g *schemaGenerator) EventSchema(palletName, eventName string) (*Schema, error) {
+	pallet, err := g.getPallet(palletName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !pallet.HasEvents {
+		return nil, ErrEventNotFound.WithMsg("pallet '%s' has no events", palletName)
+	}
+
+	variant, err := g.getNamedVariant(pallet.Events.Type.Int64(), eventName)
+
+	if err != nil {
+		return nil, ErrEventNotFound.WithMsg("event '%s.%s'", palletName, eventName).Wrap(err)
+	}
+
+	data := &Schema{
+		Type:                 schemaTypeObject,
+		AdditionalProperties: boolPtr(false),
+	}
+
+	if len(variant.Fields) > 0 {
+		if data, err = g.fieldsSchema(variant.Fields, false); err != nil {
+			return nil, ErrEventNotFound.WithMsg("event '%s.%s'", palletName, eventName).Wrap(err)
+		}
+	}
+
+	return objectSchema(getDocs(variant.Docs), []string{"index", "section", "method", "data", "phase", "topics"},
+		map[string]*Schema{
+			"index":   constSchema(codec.HexEncodeToString([]byte{byte(pallet.Index), byte(variant.Index)})),
+			"section": constSchema(toCamelCase(string(pallet.Name))),
+			"method":  constSchema(string(variant.Name)),
+			"data":    data,
+			"phase":   phaseSchema(),
+			"topics": {
+				Type:  schemaTypeArray,
+				Items: fixedHexSchema(len(types.Hash{})),
+			},
+		},
+	), nil
+}
+
+// StorageSchema returns the schemas of the keys and of the value of a storage entry.
+func (g *schemaGenerator) StorageSchema(palletName, entryName string) (*StorageEntrySchema, error) {
+	pallet, err := g.getPallet(palletName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !pallet.HasStorage {
+		return nil, ErrStorageEntryNotFound.WithMsg("pallet '%s' has no storage", palletName)
+	}
+
+	for _, entry := range pallet.Storage.Items {
+		if string(entry.Name) != entryName {
+			continue
+		}
+
+		res, err := g.storageEntrySchema(entry)
+
+		if err != nil {
+			return nil, ErrStorageEntrySchema.WithMsg("storage entry '%s.%s'", palletName, entryName).Wrap(err)
+		}
+
+		return res, nil
+	}
+
+	return nil, ErrStorageEntryNotFound.WithMsg("storage entry '%s.%s'", palletName, entryName)
+}
+
+func (g *schemaGenerator) storageEntrySchema(entry types.StorageEntryMetadataV14) (*StorageEntrySchema, error) {
+	if entry.IsPlain() {
+		value, err := g.storageValueSchema(entry, entry.Type.AsPlainType)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &StorageEntrySchema{Value: value}, nil
+	}
+
+	value, err := g.storageValueSchema(entry, entry.Type.AsMap.Value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keyTypes := []types.Si1LookupTypeID{entry.Type.AsMap.Key}
+
+	// The key of a map with multiple hashers is a tuple that holds one key for each hasher.
+	if len(entry.Type.AsMap.Hashers) > 1 {
+		keyType, err := g.getType(entry.Type.AsMap.Key.Int64())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !keyType.Def.IsTuple || len(keyType.Def.Tuple) != len(entry.Type.AsMap.Hashers) {
+			return nil, ErrUnexpectedStorageKeyType.WithMsg("expected tuple of %d items", len(entry.Type.AsMap.Hashers))
+		}
+
+		keyTypes = keyType.Def.Tuple
+	}
+
+	res := &StorageEntrySchema{Value: value}
+
+	for _, keyType := range keyTypes {
+		key, err := g.TypeSchema(keyType.Int64())
+
+		if err != nil {
+			return nil, err
+		}
+
+		res.Keys = append(res.Keys, key)
+	}
+
+	return res, nil
+}
+
+func (g *schemaGenerator) storageValueSchema(
+	entry types.StorageEntryMetadataV14,
+	valueType types.Si1LookupTypeID,
+) (*Schema, error) {
+	value, err := g.TypeSchema(valueType.Int64())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Modifier.IsOptional {
+		return nullableSchema(value), nil
+	}
+
+	return value, nil
+}
+
+// TypeSchema returns the schema of a value with the type found at the provided lookup index.
+//
+// It can be used for the parameters and results of runtime APIs, which are not described by V14 metadata.
+func (g *schemaGenerator) TypeSchema(lookupIndex int64) (*Schema, error) {
+	lookupType, err := g.getType(lookupIndex)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasDefinition(lookupType) {
+		return g.typeDefSchema(lookupIndex, lookupType)
+	}
+
+	defName := g.getDefName(lookupIndex, lookupType)
+
+	if _, ok := g.defs[defName]; !ok {
+		// The definition is added before generating its schema, since the type can be recursive.
+		def := &Schema{}
+
+		g.defs[defName] = def
+
+		schema, err := g.typeDefSchema(lookupIndex, lookupType)
+
+		if err != nil {
+			delete(g.defs, defName)
+
+			return nil, ErrTypeSchema.WithMsg("type '%s'", defName).Wrap(err)
+		}
+
+		*def = *schema
+
+		if def.Description == "" {
+			def.Description = getDocs(lookupType.Docs)
+		}
+	}
+
+	return &Schema{Ref: g.getRefPrefix() + defName}, nil
+}
+
+// Document returns a document holding the provided schemas and the definitions referenced by the schemas
+// returned so far.
+func (g *schemaGenerator) Document(schemas map[string]*Schema) *Document {
+	defs := make(map[string]*Schema, len(g.defs)+len(schemas))
+
+	for name, schema := range g.defs {
+		defs[name] = schema
+	}
+
+	for name, schema := range schemas {
+		defs[name] = schema
+	}
+
+	if g.opts.format == OpenAPIFormat {
+		return &Document{
+			Components: &Components{
+				Schemas: defs,
+			},
+		}
+	}
+
+	return &Document{
+		Schema: JSONSchemaDialect,
+		Defs:   defs,
+	}
+}
+
+//nolint:gocyclo
+func (g *schemaGenerator) typeDefSchema(lookupIndex int64, lookupType *types.Si1Type) (*Schema, error) {
+	typeDef := lookupType.Def
+
+	switch {
+	case typeDef.IsComposite:
+		if isAccountIDType(lookupType) {
+			return &Schema{Type: schemaTypeString, Format: ss58SchemaFormat}, nil
+		}
+
+		return g.fieldsSchema(typeDef.Composite.Fields, false)
+	case typeDef.IsVariant:
+		return g.variantSchema(lookupType)
+	case typeDef.IsSequence:
+		if g.isU8Type(typeDef.Sequence.Type.Int64()) {
+			return &Schema{Type: schemaTypeString, Pattern: hexPattern}, nil
+		}
+
+		return g.itemsSchema(typeDef.Sequence.Type.Int64(), nil)
+	case typeDef.IsArray:
+		arrayLen := int(typeDef.Array.Len)
+
+		if g.isU8Type(typeDef.Array.Type.Int64()) {
+			return fixedHexSchema(arrayLen), nil
+		}
+
+		return g.itemsSchema(typeDef.Array.Type.Int64(), &arrayLen)
+	case typeDef.IsTuple:
+		return g.tupleSchema(typeDef.Tuple, false)
+	case typeDef.IsPrimitive:
+		return primitiveSchema(typeDef.Primitive.Si0TypeDefPrimitive)
+	case typeDef.IsCompact:
+		return g.compactSchema(typeDef.Compact.Type.Int64())
+	case typeDef.IsBitSequence:
+		return &Schema{Type: schemaTypeString, Pattern: bitSequencePattern}, nil
+	default:
+		return nil, ErrUnsupportedTypeDef.WithMsg("lookup index %d", lookupIndex)
+	}
+}
+
+func (g *schemaGenerator) variantSchema(lookupType *types.Si1Type) (*Schema, error) {
+	variantDef := lookupType.Def.Variant
+
+	if isOptionType(lookupType) {
+		for _, variant := range variantDef.Variants {
+			if len(variant.Fields) != 1 {
+				continue
+			}
+
+			inner, err := g.TypeSchema(variant.Fields[0].Type.Int64())
+
+			if err != nil {
+				return nil, err
+			}
+
+			return nullableSchema(inner), nil
+		}
+
+		return nil, ErrUnexpectedOptionType
+	}
+
+	if registry.IsRuntimeCallType(g.meta, lookupType.Def) {
+		return g.runtimeCallSchema(variantDef)
+	}
+
+	if isBasicVariant(variantDef) {
+		res := &Schema{Type: schemaTypeString}
+
+		for _, variant := range variantDef.Variants {
+			res.Enum = append(res.Enum, string(variant.Name))
+		}
+
+		return res, nil
+	}
+
+	res := &Schema{}
+
+	for _, variant := range variantDef.Variants {
+		variantValue, err := g.fieldsSchema(variant.Fields, false)
+
+		if err != nil {
+			return nil, ErrVariantSchema.WithMsg("variant '%s'", variant.Name).Wrap(err)
+		}
+
+		variantKey := toCamelCase(string(variant.Name))
+
+		res.OneOf = append(res.OneOf, objectSchema(getDocs(variant.Docs), []string{variantKey}, map[string]*Schema{
+			variantKey: variantValue,
+		}))
+	}
+
+	return res, nil
+}
+
+// runtimeCallSchema returns the schema of the RuntimeCall type, since calls are rendered as the call of
+// an extrinsic wherever they are found.
+func (g *schemaGenerator) runtimeCallSchema(variantDef types.Si1TypeDefVariant) (*Schema, error) {
+	res := &Schema{}
+
+	for _, palletVariant := range variantDef.Variants {
+		callsType, err := g.getType(palletVariant.Fields[0].Type.Int64())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !callsType.Def.IsVariant {
+			return nil, ErrUnexpectedCallValue.WithMsg("expected call variant for pallet '%s'", palletVariant.Name)
+		}
+
+		for _, callVariant := range callsType.Def.Variant.Variants {
+			callSchema, err := g.callSchema(string(palletVariant.Name), byte(palletVariant.Index), callVariant)
+
+			if err != nil {
+				return nil, err
+			}
+
+			res.OneOf = append(res.OneOf, callSchema)
+		}
+	}
+
+	return res, nil
+}
+
+func (g *schemaGenerator) callSchema(
+	palletName string,
+	palletIndex byte,
+	callVariant types.Si1Variant,
+) (*Schema, error) {
+	args := objectSchema("", nil, make(map[string]*Schema, len(callVariant.Fields)))
+
+	for i, field := range callVariant.Fields {
+		fieldKey := getSi1FieldKey(field, i)
+
+		fieldSchema, err := g.fieldSchema(field, false)
+
+		if err != nil {
+			return nil, ErrCallSchema.
+				WithMsg("call '%s.%s', field '%s'", palletName, callVariant.Name, fieldKey).
+				Wrap(err)
+		}
+
+		args.Properties[fieldKey] = fieldSchema
+		args.Required = append(args.Required, fieldKey)
+	}
+
+	return objectSchema(getDocs(callVariant.Docs), []string{"callIndex", "section", "method", "args"},
+		map[string]*Schema{
+			"callIndex": constSchema(codec.HexEncodeToString([]byte{palletIndex, byte(callVariant.Index)})),
+			"section":   constSchema(toCamelCase(palletName)),
+			"method":    constSchema(toCamelCase(string(callVariant.Name))),
+			"args":      args,
+		},
+	), nil
+}
+
+// fieldsSchema returns the schema of fields rendered by renderFieldsWithTypes.
+//
+// A single unnamed field is rendered as its value, unnamed fields are rendered as an array and
+// named fields are rendered as an object keyed by the camel case field names.
+func (g *schemaGenerator) fieldsSchema(fields []types.Si1Field, compact bool) (*Schema, error) {
+	if len(fields) == 0 {
+		return &Schema{Type: schemaTypeNull}, nil
+	}
+
+	if len(fields) == 1 && !fields[0].HasName {
+		return g.fieldSchema(fields[0], compact)
+	}
+
+	if !fields[0].HasName {
+		res := &Schema{
+			Type:     schemaTypeArray,
+			MinItems: intPtr(len(fields)),
+			MaxItems: intPtr(len(fields)),
+		}
+
+		for _, field := range fields {
+			fieldSchema, err := g.fieldSchema(field, compact)
+
+			if err != nil {
+				return nil, err
+			}
+
+			res.PrefixItems = append(res.PrefixItems, fieldSchema)
+		}
+
+		return res, nil
+	}
+
+	res := objectSchema("", nil, make(map[string]*Schema, len(fields)))
+
+	for i, field := range fields {
+		fieldKey := getSi1FieldKey(field, i)
+
+		fieldSchema, err := g.fieldSchema(field, compact)
+
+		if err != nil {
+			return nil, ErrFieldSchema.WithMsg("field '%s'", fieldKey).Wrap(err)
+		}
+
+		res.Properties[fieldKey] = fieldSchema
+		res.Required = append(res.Required, fieldKey)
+	}
+
+	return res, nil
+}
+
+// fieldSchema returns the schema of a field, where balances that are rendered as integer strings can hold
+// decimals, depending on the options of the Renderer.
+func (g *schemaGenerator) fieldSchema(field types.Si1Field, compact bool) (*Schema, error) {
+	lookupIndex := field.Type.Int64()
+
+	if field.HasTypeName && isBalanceTypeName(string(field.TypeName)) {
+		isIntegerString, err := g.isIntegerStringType(lookupIndex, compact)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if isIntegerString {
+			return &Schema{Type: schemaTypeString, Pattern: balancePattern}, nil
+		}
+	}
+
+	if compact {
+		return g.compactValueSchema(lookupIndex)
+	}
+
+	return g.TypeSchema(lookupIndex)
+}
+
+func (g *schemaGenerator) itemsSchema(itemLookupIndex int64, arrayLen *int) (*Schema, error) {
+	items, err := g.TypeSchema(itemLookupIndex)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Schema{
+		Type:     schemaTypeArray,
+		Items:    items,
+		MinItems: arrayLen,
+		MaxItems: arrayLen,
+	}, nil
+}
+
+func (g *schemaGenerator) tupleSchema(tuple types.Si1TypeDefTuple, compact bool) (*Schema, error) {
+	if len(tuple) == 0 {
+		return &Schema{Type: schemaTypeNull}, nil
+	}
+
+	res := &Schema{
+		Type:     schemaTypeArray,
+		MinItems: intPtr(len(tuple)),
+		MaxItems: intPtr(len(tuple)),
+	}
+
+	for _, item := range tuple {
+		var (
+			itemSchema *Schema
+			err        error
+		)
+
+		if compact {
+			itemSchema, err = g.compactValueSchema(item.Int64())
+		} else {
+			itemSchema, err = g.TypeSchema(item.Int64())
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		res.PrefixItems = append(res.PrefixItems, itemSchema)
+	}
+
+	return res, nil
+}
+
+// compactSchema returns the schema of a compact value, which is rendered based on its Go type.
+//
+// Compact integers are rendered as strings, while compact composites and tuples are rendered as objects
+// keyed by the camel case field names.
+func (g *schemaGenerator) compactSchema(innerLookupIndex int64) (*Schema, error) {
+	innerType, err := g.getType(innerLookupIndex)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case innerType.Def.IsPrimitive:
+		return &Schema{Type: schemaTypeString, Pattern: unsignedPattern}, nil
+	case innerType.Def.IsTuple:
+		if len(innerType.Def.Tuple) == 0 {
+			return &Schema{Type: schemaTypeNull}, nil
+		}
+
+		res := objectSchema("", nil, make(map[string]*Schema, len(innerType.Def.Tuple)))
+
+		for i, item := range innerType.Def.Tuple {
+			itemSchema, err := g.compactValueSchema(item.Int64())
+
+			if err != nil {
+				return nil, err
+			}
+
+			itemKey := fmt.Sprintf(compactTupleItemKeyFormat, i)
+
+			res.Properties[itemKey] = itemSchema
+			res.Required = append(res.Required, itemKey)
+		}
+
+		return res, nil
+	case innerType.Def.IsComposite:
+		res := objectSchema("", nil, make(map[string]*Schema, len(innerType.Def.Composite.Fields)))
+
+		for _, field := range innerType.Def.Composite.Fields {
+			fieldSchema, err := g.compactValueSchema(field.Type.Int64())
+
+			if err != nil {
+				return nil, err
+			}
+
+			fieldKey := getFieldKey(getCompactFieldName(field))
+
+			res.Properties[fieldKey] = fieldSchema
+			res.Required = append(res.Required, fieldKey)
+		}
+
+		return res, nil
+	default:
+		return nil, ErrUnsupportedTypeDef.WithMsg("compact of lookup index %d", innerLookupIndex)
+	}
+}
+
+// compactValueSchema returns the schema of a value that is decoded as part of a compact value, but is rendered
+// using the type found at the provided lookup index.
+func (g *schemaGenerator) compactValueSchema(lookupIndex int64) (*Schema, error) {
+	lookupType, err := g.getType(lookupIndex)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case lookupType.Def.IsPrimitive:
+		return &Schema{Type: schemaTypeString, Pattern: unsignedPattern}, nil
+	case lookupType.Def.IsTuple:
+		return g.tupleSchema(lookupType.Def.Tuple, true)
+	case lookupType.Def.IsComposite:
+		return g.fieldsSchema(lookupType.Def.Composite.Fields, true)
+	default:
+		return nil, ErrUnsupportedTypeDef.WithMsg("compact of lookup index %d", lookupIndex)
+	}
+}
+
+// isIntegerStringType returns true if the type found at the provided lookup index is rendered as an integer
+// string, which is the case for wide integers, compact integers and composites that wrap them.
+func (g *schemaGenerator) isIntegerStringType(lookupIndex int64, compact bool) (bool, error) {
+	lookupType, err := g.getType(lookupIndex)
+
+	if err != nil {
+		return false, err
+	}
+
+	typeDef := lookupType.Def
+
+	switch {
+	case typeDef.IsPrimitive:
+		if compact {
+			return true, nil
+		}
+
+		switch typeDef.Primitive.Si0TypeDefPrimitive {
+		case types.IsU64, types.IsU128, types.IsU256, types.IsI64, types.IsI128, types.IsI256:
+			return true, nil
+		default:
+			return false, nil
+		}
+	case typeDef.IsCompact:
+		innerType, err := g.getType(typeDef.Compact.Type.Int64())
+
+		if err != nil {
+			return false, err
+		}
+
+		return innerType.Def.IsPrimitive, nil
+	case typeDef.IsComposite:
+		fields := typeDef.Composite.Fields
+
+		if isAccountIDType(lookupType) || len(fields) != 1 || fields[0].HasName {
+			return false, nil
+		}
+
+		return g.isIntegerStringType(fields[0].Type.Int64(), compact)
+	default:
+		return false, nil
+	}
+}
+
+func (g *schemaGenerator) isU8Type(lookupIndex int64) bool {
+	lookupType, err := g.getType(lookupIndex)
+
+	if err != nil {
+		return false
+	}
+
+	return lookupType.Def.IsPrimitive && lookupType.Def.Primitive.Si0TypeDefPrimitive == types.IsU8
+}
+
+func (g *schemaGenerator) getType(lookupIndex int64) (*types.Si1Type, error) {
+	if g.meta == nil {
+		return nil, ErrTypeNotFound.WithMsg("lookup index %d", lookupIndex)
+	}
+
+	lookupType, ok := g.meta.AsMetadataV14.EfficientLookup[lookupIndex]
+
+	if !ok {
+		return nil, ErrTypeNotFound.WithMsg("lookup index %d", lookupIndex)
+	}
+
+	return lookupType, nil
+}
+
+func (g *schemaGenerator) getPallet(palletName string) (*types.PalletMetadataV14, error) {
+	if g.meta == nil {
+		return nil, ErrPalletNotFound.WithMsg("pallet '%s'", palletName)
+	}
+
+	for i, pallet := range g.meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) == palletName {
+			return &g.meta.AsMetadataV14.Pallets[i], nil
+		}
+	}
+
+	return nil, ErrPalletNotFound.WithMsg("pallet '%s'", palletName)
+}
+
+func (g *schemaGenerator) getNamedVariant(lookupIndex int64, variantName string) (types.Si1Variant, error) {
+	lookupType, err := g.getType(lookupIndex)
+
+	if err != nil {
+		return types.Si1Variant{}, err
+	}
+
+	for _, variant := range lookupType.Def.Variant.Variants {
+		if string(variant.Name) == variantName {
+			return variant, nil
+		}
+	}
+
+	return types.Si1Variant{}, ErrVariantNotFound.WithMsg("variant '%s'", variantName)
+}
+
+// getDefName returns the name of the definition of a type, which is its path joined by dots. The lookup index is
+// appended to paths that are shared by multiple types, such as the paths of generic types.
+func (g *schemaGenerator) getDefName(lookupIndex int64, lookupType *types.Si1Type) string {
+	if len(lookupType.Path) == 0 {
+		return fmt.Sprintf(lookupIndexDefFormat, lookupIndex)
+	}
+
+	typePath := getTypePath(lookupType)
+
+	if g.pathCounts[typePath] > 1 {
+		return typePath + "_" + strconv.FormatInt(lookupIndex, 10)
+	}
+
+	return typePath
+}
+
+func (g *schemaGenerator) getRefPrefix() string {
+	if g.opts.format == OpenAPIFormat {
+		return openAPIRefPrefix
+	}
+
+	return jsonSchemaRefPrefix
+}
+
+// hasDefinition returns true for composites and variants, except for account IDs and options, which
+// are inlined.
+func hasDefinition(lookupType *types.Si1Type) bool {
+	switch {
+	case lookupType.Def.IsComposite:
+		return !isAccountIDType(lookupType)
+	case lookupType.Def.IsVariant:
+		return !isOptionType(lookupType)
+	default:
+		return false
+	}
+}
+
+//nolint:gocyclo
+func primitiveSchema(primitive types.Si0TypeDefPrimitive) (*Schema, error) {
+	switch primitive {
+	case types.IsBool:
+		return &Schema{Type: schemaTypeBoolean}, nil
+	case types.IsChar:
+		return &Schema{Type: schemaTypeString, MinLength: intPtr(1), MaxLength: intPtr(1)}, nil
+	case types.IsStr:
+		return &Schema{Type: schemaTypeString}, nil
+	case types.IsU8:
+		return integerSchema(0, math.MaxUint8), nil
+	case types.IsU16:
+		return integerSchema(0, math.MaxUint16), nil
+	case types.IsU32:
+		return integerSchema(0, math.MaxUint32), nil
+	case types.IsI8:
+		return integerSchema(math.MinInt8, math.MaxInt8), nil
+	case types.IsI16:
+		return integerSchema(math.MinInt16, math.MaxInt16), nil
+	case types.IsI32:
+		return integerSchema(math.MinInt32, math.MaxInt32), nil
+	case types.IsU64, types.IsU128, types.IsU256:
+		return &Schema{Type: schemaTypeString, Pattern: unsignedPattern}, nil
+	case types.IsI64, types.IsI128, types.IsI256:
+		return &Schema{Type: schemaTypeString, Pattern: signedPattern}, nil
+	default:
+		return nil, ErrUnsupportedTypeDef.WithMsg("primitive %d", primitive)
+	}
+}
+
+// phaseSchema returns the schema of the phase rendered by renderPhase.
+func phaseSchema() *Schema {
+	return &Schema{
+		OneOf: []*Schema{
+			{Type: schemaTypeNull},
+			objectSchema("", []string{"applyExtrinsic"}, map[string]*Schema{
+				"applyExtrinsic": integerSchema(0, math.MaxUint32),
+			}),
+			{Type: schemaTypeString, Enum: []any{"Finalization", "Initialization"}},
+		},
+	}
+}
+
+func objectSchema(description string, required []string, properties map[string]*Schema) *Schema {
+	return &Schema{
+		Description:          description,
+		Type:                 schemaTypeObject,
+		Properties:           properties,
+		Required:             required,
+		AdditionalProperties: boolPtr(false),
+	}
+}
+
+func nullableSchema(schema *Schema) *Schema {
+	return &Schema{
+		OneOf: []*Schema{
+			{Type: schemaTypeNull},
+			schema,
+		},
+	}
+}
+
+func constSchema(value string) *Schema {
+	return &Schema{Type: schemaTypeString, Const: value}
+}
+
+func fixedHexSchema(byteLen int) *Schema {
+	return &Schema{Type: schemaTypeString, Pattern: fmt.Sprintf(fixedHexPatternFmt, byteLen*2)}
+}
+
+func integerSchema(minimum, maximum int64) *Schema {
+	return &Schema{Type: schemaTypeInteger, Minimum: &minimum, Maximum: &maximum}
+}
+
+// getCompactFieldName returns the name of a field that is decoded as part of a compact composite.
+func getCompactFieldName(field types.Si1Field) string {
+	switch {
+	case field.HasName:
+		return string(field.Name)
+	case field.HasTypeName:
+		return string(field.TypeName)
+	default:
+		return fmt.Sprintf(lookupIndexFieldFormat, field.Type.Int64())
+	}
+}
+
+func getTypePath(lookupType *types.Si1Type) string {
+	pathSegments := make([]string, 0, len(lookupType.Path))
+
+	for _, pathSegment := range lookupType.Path {
+		pathSegments = append(pathSegments, string(pathSegment))
+	}
+
+	return strings.Join(pathSegments, ".")
+}
+
+func getDocs(docs []types.Text) string {
+	lines := make([]string, 0, len(docs))
+
+	for _, doc := range docs {
+		lines = append(lines, strings.TrimSpace(string(doc)))
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,773 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaGenerator_CallSchema(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	generator := NewSchemaGenerator(meta)
+
+	schema, err := generator.CallSchema("Balances", "transfer_keep_alive")
+	assert.NoError(t, err)
+
+	assert.Equal(t, constSchema(transferKeepAliveCallIndex), schema.Properties["callIndex"])
+	assert.Equal(t, constSchema("balances"), schema.Properties["section"])
+	assert.Equal(t, constSchema("transferKeepAlive"), schema.Properties["method"])
+
+	args := schema.Properties["args"]
+	assert.Equal(t, []string{"dest", "value"}, args.Required)
+	assert.Equal(t, &Schema{Type: schemaTypeString, Pattern: balancePattern}, args.Properties["value"])
+
+	doc := generator.Document(nil)
+
+	dest := resolveSchema(t, doc, args.Properties["dest"])
+	assert.Contains(t, dest.OneOf, objectSchema("", []string{"id"}, map[string]*Schema{
+		"id": {Type: schemaTypeString, Format: ss58SchemaFormat},
+	}))
+
+	decodedExtrinsic := getTestDecodedExtrinsic(t, meta, true)
+
+	for _, renderer := range []Renderer{NewRenderer(meta), NewRenderer(meta, WithTokenDecimals(3))} {
+		res, err := renderer.RenderExtrinsic(decodedExtrinsic)
+		assert.NoError(t, err)
+
+		assert.NoError(t, validateSchema(doc, schema, toJSONValue(t, res["method"])))
+	}
+
+	invalidMethod := map[string]any{
+		"callIndex": transferKeepAliveCallIndex,
+		"section":   "balances",
+		"method":    "transferKeepAlive",
+		"args":      map[string]any{"dest": map[string]any{"id": aliceAddress}, "value": 12345},
+	}
+
+	assert.Error(t, validateSchema(doc, schema, toJSONValue(t, invalidMethod)))
+}
+
+func TestSchemaGenerator_CallSchema_NestedCalls(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	generator := NewSchemaGenerator(meta)
+
+	schema, err := generator.CallSchema("Utility", "batch")
+	assert.NoError(t, err)
+
+	doc := generator.Document(nil)
+
+	calls := schema.Properties["args"].Properties["calls"]
+	assert.Equal(t, schemaTypeArray, calls.Type)
+
+	runtimeCall := resolveSchema(t, doc, calls.Items)
+	assert.NotEmpty(t, runtimeCall.OneOf)
+
+	dest, err := types.NewMultiAddressFromAccountID(signature.TestKeyringPairAlice.PublicKey)
+	assert.NoError(t, err)
+
+	transferCall, err := types.NewCall(
+		meta,
+		"Balances.transfer_keep_alive",
+		dest,
+		types.NewUCompactFromUInt(transferKeepAliveCallAmount),
+	)
+	assert.NoError(t, err)
+
+	batchCall, err := types.NewCall(meta, "Utility.batch", []types.Call{transferCall})
+	assert.NoError(t, err)
+
+	encodedCall, err := codec.Encode(batchCall)
+	assert.NoError(t, err)
+
+	callRegistry, err := registry.NewFactory().CreateCallRegistry(meta)
+	assert.NoError(t, err)
+
+	callDecoder := callRegistry[batchCall.CallIndex]
+
+	decodedFields, err := callDecoder.Decode(scale.NewDecoder(bytes.NewReader(encodedCall[2:])))
+	assert.NoError(t, err)
+
+	res, err := NewRenderer(meta).RenderFields(decodedFields)
+	assert.NoError(t, err)
+
+	assert.NoError(t, validateSchema(doc, schema.Properties["args"], toJSONValue(t, res)))
+}
+
+func TestSchemaGenerator_EventSchema(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	generator := NewSchemaGenerator(meta)
+
+	schema, err := generator.EventSchema("Balances", "Transfer")
+	assert.NoError(t, err)
+
+	assert.Equal(t, constSchema("balances"), schema.Properties["section"])
+	assert.Equal(t, constSchema("Transfer"), schema.Properties["method"])
+	assert.Equal(t, []string{"from", "to", "amount"}, schema.Properties["data"].Required)
+
+	eventData := append(signature.TestKeyringPairAlice.PublicKey, signature.TestKeyringPairAlice.PublicKey...)
+
+	amount, err := codec.Encode(types.NewU128(*big.NewInt(1_500_000)))
+	assert.NoError(t, err)
+
+	event := getTestEvent(t, meta, "Balances.Transfer", append(eventData, amount...))
+	event.Topics = []types.Hash{{1}}
+
+	doc := generator.Document(nil)
+
+	for _, phase := range []*types.Phase{
+		nil,
+		{IsApplyExtrinsic: true, AsApplyExtrinsic: 2},
+		{IsFinalization: true},
+	} {
+		event.Phase = phase
+
+		res, err := NewRenderer(meta, WithTokenDecimals(6)).RenderEvent(event)
+		assert.NoError(t, err)
+
+		assert.NoError(t, validateSchema(doc, schema, toJSONValue(t, res)))
+	}
+}
+
+func TestSchemaGenerator_StorageSchema(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	generator := NewSchemaGenerator(meta)
+
+	res, err := generator.StorageSchema("System", "Account")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*Schema{{Type: schemaTypeString, Format: ss58SchemaFormat}}, res.Keys)
+
+	accountInfo := resolveSchema(t, generator.Document(nil), res.Value)
+	assert.Equal(t, []string{"nonce", "consumers", "providers", "sufficients", "data"}, accountInfo.Required)
+
+	res, err = generator.StorageSchema("System", "Number")
+	assert.NoError(t, err)
+
+	assert.Empty(t, res.Keys)
+	assert.Equal(t, integerSchema(0, 4294967295), res.Value)
+
+	res, err = generator.StorageSchema("System", "BlockHash")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*Schema{integerSchema(0, 4294967295)}, res.Keys)
+	assert.Equal(t, fixedHexSchema(32), resolveSchema(t, generator.Document(nil), res.Value))
+}
+
+func TestSchemaGenerator_StorageSchema_Optional(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if !pallet.HasStorage {
+			continue
+		}
+
+		for _, entry := range pallet.Storage.Items {
+			if !entry.Modifier.IsOptional {
+				continue
+			}
+
+			res, err := NewSchemaGenerator(meta).StorageSchema(string(pallet.Name), string(entry.Name))
+			assert.NoError(t, err)
+
+			assert.Len(t, res.Value.OneOf, 2)
+			assert.Equal(t, &Schema{Type: schemaTypeNull}, res.Value.OneOf[0])
+
+			return
+		}
+	}
+
+	t.Fatal("no optional storage entry found")
+}
+
+func TestSchemaGenerator_AllItems(t *testing.T) {
+	for _, metaHex := range []string{types.MetadataV14Data, test.PolkadotMetadataHex} {
+		var meta types.Metadata
+
+		assert.NoError(t, codec.DecodeFromHex(metaHex, &meta))
+
+		generator := NewSchemaGenerator(&meta)
+
+		for _, pallet := range meta.AsMetadataV14.Pallets {
+			palletName := string(pallet.Name)
+
+			if pallet.HasCalls {
+				for _, variant := range meta.AsMetadataV14.EfficientLookup[pallet.Calls.Type.Int64()].Def.Variant.Variants {
+					_, err := generator.CallSchema(palletName, string(variant.Name))
+					assert.NoError(t, err)
+				}
+			}
+
+			if pallet.HasEvents {
+				for _, variant := range meta.AsMetadataV14.EfficientLookup[pallet.Events.Type.Int64()].Def.Variant.Variants {
+					_, err := generator.EventSchema(palletName, string(variant.Name))
+					assert.NoError(t, err)
+				}
+			}
+
+			if pallet.HasStorage {
+				for _, entry := range pallet.Storage.Items {
+					_, err := generator.StorageSchema(palletName, string(entry.Name))
+					assert.NoError(t, err)
+				}
+			}
+		}
+
+		_, err := json.Marshal(generator.Document(nil))
+		assert.NoError(t, err)
+	}
+}
+
+func TestSchemaGenerator_TypeSchema(t *testing.T) {
+	meta := getTestSchemaMetadata()
+
+	tests := []struct {
+		name        string
+		lookupIndex int64
+		expected    *Schema
+	}{
+		{
+			name:        "u32",
+			lookupIndex: 2,
+			expected:    integerSchema(0, 4294967295),
+		},
+		{
+			name:        "u128",
+			lookupIndex: 3,
+			expected:    &Schema{Type: schemaTypeString, Pattern: unsignedPattern},
+		},
+		{
+			name:        "i64",
+			lookupIndex: 4,
+			expected:    &Schema{Type: schemaTypeString, Pattern: signedPattern},
+		},
+		{
+			name:        "bytes",
+			lookupIndex: 5,
+			expected:    &Schema{Type: schemaTypeString, Pattern: hexPattern},
+		},
+		{
+			name:        "byte array",
+			lookupIndex: 6,
+			expected:    &Schema{Type: schemaTypeString, Pattern: "^0x[0-9a-f]{8}$"},
+		},
+		{
+			name:        "u32 array",
+			lookupIndex: 7,
+			expected: &Schema{
+				Type:     schemaTypeArray,
+				Items:    integerSchema(0, 4294967295),
+				MinItems: intPtr(2),
+				MaxItems: intPtr(2),
+			},
+		},
+		{
+			name:        "compact",
+			lookupIndex: 8,
+			expected:    &Schema{Type: schemaTypeString, Pattern: unsignedPattern},
+		},
+		{
+			name:        "tuple",
+			lookupIndex: 9,
+			expected: &Schema{
+				Type: schemaTypeArray,
+				PrefixItems: []*Schema{
+					{Type: schemaTypeBoolean},
+					{Type: schemaTypeString},
+				},
+				MinItems: intPtr(2),
+				MaxItems: intPtr(2),
+			},
+		},
+		{
+			name:        "empty tuple",
+			lookupIndex: 10,
+			expected:    &Schema{Type: schemaTypeNull},
+		},
+		{
+			name:        "option",
+			lookupIndex: 11,
+			expected:    nullableSchema(integerSchema(0, 4294967295)),
+		},
+		{
+			name:        "bit sequence",
+			lookupIndex: 12,
+			expected:    &Schema{Type: schemaTypeString, Pattern: bitSequencePattern},
+		},
+		{
+			name:        "compact composite",
+			lookupIndex: 17,
+			expected: objectSchema("", []string{"u32"}, map[string]*Schema{
+				"u32": {Type: schemaTypeString, Pattern: unsignedPattern},
+			}),
+		},
+		{
+			name:        "account ID",
+			lookupIndex: 18,
+			expected:    &Schema{Type: schemaTypeString, Format: ss58SchemaFormat},
+		},
+		{
+			name:        "basic enum",
+			lookupIndex: 13,
+			expected:    &Schema{Ref: "#/$defs/test.BasicEnum"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := NewSchemaGenerator(meta).TypeSchema(test.lookupIndex)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestSchemaGenerator_TypeSchema_Definitions(t *testing.T) {
+	generator := NewSchemaGenerator(getTestSchemaMetadata())
+
+	res, err := generator.TypeSchema(14)
+	assert.NoError(t, err)
+	assert.Equal(t, &Schema{Ref: "#/$defs/test.Enum"}, res)
+
+	doc := generator.Document(nil)
+
+	assert.Equal(t, JSONSchemaDialect, doc.Schema)
+	assert.Nil(t, doc.Components)
+
+	assert.Equal(t, &Schema{Type: schemaTypeString, Enum: []any{"First", "Second"}}, doc.Defs["test.BasicEnum"])
+
+	assert.Equal(t, &Schema{
+		Description: "Test enum.",
+		OneOf: []*Schema{
+			objectSchema("", []string{"unit"}, map[string]*Schema{
+				"unit": {Type: schemaTypeNull},
+			}),
+			objectSchema("", []string{"basic"}, map[string]*Schema{
+				"basic": {Ref: "#/$defs/test.BasicEnum"},
+			}),
+			objectSchema("", []string{"named"}, map[string]*Schema{
+				"named": objectSchema("", []string{"amount", "inner"}, map[string]*Schema{
+					"amount": {Type: schemaTypeString, Pattern: balancePattern},
+					"inner":  {Ref: "#/$defs/test.Enum"},
+				}),
+			}),
+		},
+	}, doc.Defs["test.Enum"])
+
+	for _, value := range []any{
+		map[string]any{"unit": nil},
+		map[string]any{"basic": "Second"},
+		map[string]any{"named": map[string]any{"amount": "1.5", "inner": map[string]any{"unit": nil}}},
+	} {
+		assert.NoError(t, validateSchema(doc, res, value), value)
+	}
+
+	for _, value := range []any{
+		"unit",
+		map[string]any{"basic": "Third"},
+		map[string]any{"named": map[string]any{"amount": 1, "inner": map[string]any{"unit": nil}}},
+	} {
+		assert.Error(t, validateSchema(doc, res, value), value)
+	}
+}
+
+func TestSchemaGenerator_Document_OpenAPI(t *testing.T) {
+	generator := NewSchemaGenerator(getTestSchemaMetadata(), WithSchemaFormat(OpenAPIFormat))
+
+	res, err := generator.TypeSchema(15)
+	assert.NoError(t, err)
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/test.Generic_15"}, res)
+
+	res, err = generator.TypeSchema(16)
+	assert.NoError(t, err)
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/test.Generic_16"}, res)
+
+	doc := generator.Document(map[string]*Schema{"Root": res})
+
+	assert.Empty(t, doc.Schema)
+	assert.Nil(t, doc.Defs)
+	assert.Equal(t, res, doc.Components.Schemas["Root"])
+	assert.Equal(t, integerSchema(0, 4294967295), doc.Components.Schemas["test.Generic_15"])
+	assert.Equal(t, &Schema{Type: schemaTypeBoolean}, doc.Components.Schemas["test.Generic_16"])
+
+	b, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"components":{"schemas":{`)
+}
+
+func TestSchemaGenerator_Errors(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	generator := NewSchemaGenerator(meta)
+
+	_, err := generator.CallSchema("Unknown", "call")
+	assert.ErrorIs(t, err, ErrPalletNotFound)
+
+	_, err = generator.CallSchema("Balances", "unknown")
+	assert.ErrorIs(t, err, ErrCallNotFound)
+	assert.ErrorIs(t, err, ErrVariantNotFound)
+
+	_, err = generator.EventSchema("Balances", "Unknown")
+	assert.ErrorIs(t, err, ErrEventNotFound)
+
+	_, err = generator.StorageSchema("System", "Unknown")
+	assert.ErrorIs(t, err, ErrStorageEntryNotFound)
+
+	_, err = generator.TypeSchema(-1)
+	assert.ErrorIs(t, err, ErrTypeNotFound)
+
+	_, err = NewSchemaGenerator(nil).CallSchema("Balances", "transfer_keep_alive")
+	assert.ErrorIs(t, err, ErrPalletNotFound)
+
+	_, err = NewSchemaGenerator(nil).TypeSchema(0)
+	assert.ErrorIs(t, err, ErrTypeNotFound)
+
+	_, err = NewSchemaGenerator(getTestSchemaMetadata()).TypeSchema(19)
+	assert.ErrorIs(t, err, ErrTypeSchema)
+	assert.ErrorIs(t, err, ErrTypeNotFound)
+}
+
+// getTestSchemaMetadata returns metadata that holds the following types:
+//
+//	0 bool, 1 str, 2 u32, 3 u128, 4 i64, 5 Vec<u8>, 6 [u8; 4], 7 [u32; 2], 8 Compact<u32>, 9 (bool, str), 10 (),
+//	11 Option<u32>, 12 BitVec, 13 test::BasicEnum, 14 test::Enum, 15 test::Generic<u32>, 16 test::Generic<bool>,
+//	17 Compact<test::Perbill>, 18 test::AccountId32, 19 test::Invalid, 20 test::Perbill
+func getTestSchemaMetadata() *types.Metadata {
+	primitive := func(primitive types.Si0TypeDefPrimitive) *types.Si1Type {
+		return &types.Si1Type{
+			Def: types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: primitive}},
+		}
+	}
+
+	path := func(segments ...string) types.Si1Path {
+		var res types.Si1Path
+
+		for _, segment := range segments {
+			res = append(res, types.Text(segment))
+		}
+
+		return res
+	}
+
+	field := func(name, typeName string, lookupIndex int64) types.Si1Field {
+		return types.Si1Field{
+			HasName:     name != "",
+			Name:        types.Text(name),
+			Type:        types.NewSi1LookupTypeIDFromUInt(uint64(lookupIndex)),
+			HasTypeName: typeName != "",
+			TypeName:    types.Text(typeName),
+		}
+	}
+
+	composite := func(fields ...types.Si1Field) types.Si1TypeDef {
+		return types.Si1TypeDef{IsComposite: true, Composite: types.Si1TypeDefComposite{Fields: fields}}
+	}
+
+	lookupID := types.NewSi1LookupTypeIDFromUInt
+
+	lookup := map[int64]*types.Si1Type{
+		0:  primitive(types.IsBool),
+		1:  primitive(types.IsStr),
+		2:  primitive(types.IsU32),
+		3:  primitive(types.IsU128),
+		4:  primitive(types.IsI64),
+		5:  {Def: types.Si1TypeDef{IsSequence: true, Sequence: types.Si1TypeDefSequence{Type: lookupID(21)}}},
+		6:  {Def: types.Si1TypeDef{IsArray: true, Array: types.Si1TypeDefArray{Len: 4, Type: lookupID(21)}}},
+		7:  {Def: types.Si1TypeDef{IsArray: true, Array: types.Si1TypeDefArray{Len: 2, Type: lookupID(2)}}},
+		8:  {Def: types.Si1TypeDef{IsCompact: true, Compact: types.Si1TypeDefCompact{Type: lookupID(2)}}},
+		9:  {Def: types.Si1TypeDef{IsTuple: true, Tuple: types.Si1TypeDefTuple{lookupID(0), lookupID(1)}}},
+		10: {Def: types.Si1TypeDef{IsTuple: true}},
+		11: {
+			Path: path("Option"),
+			Def: types.Si1TypeDef{
+				IsVariant: true,
+				Variant: types.Si1TypeDefVariant{
+					Variants: []types.Si1Variant{
+						{Name: "None", Index: 0},
+						{Name: "Some", Index: 1, Fields: []types.Si1Field{field("", "", 2)}},
+					},
+				},
+			},
+		},
+		12: {Def: types.Si1TypeDef{IsBitSequence: true}},
+		13: {
+			Path: path("test", "BasicEnum"),
+			Def: types.Si1TypeDef{
+				IsVariant: true,
+				Variant: types.Si1TypeDefVariant{
+					Variants: []types.Si1Variant{
+						{Name: "First", Index: 0},
+						{Name: "Second", Index: 1},
+					},
+				},
+			},
+		},
+		14: {
+			Path: path("test", "Enum"),
+			Def: types.Si1TypeDef{
+				IsVariant: true,
+				Variant: types.Si1TypeDefVariant{
+					Variants: []types.Si1Variant{
+						{Name: "Unit", Index: 0},
+						{Name: "Basic", Index: 1, Fields: []types.Si1Field{field("", "", 13)}},
+						{
+							Name:  "Named",
+							Index: 2,
+							Fields: []types.Si1Field{
+								field("amount", "T::Balance", 3),
+								field("inner", "", 14),
+							},
+						},
+					},
+				},
+			},
+			Docs: []types.Text{"Test enum."},
+		},
+		15: {
+			Path: path("test", "Generic"),
+			Def:  composite(field("", "", 2)),
+		},
+		16: {
+			Path: path("test", "Generic"),
+			Def:  composite(field("", "", 0)),
+		},
+		17: {Def: types.Si1TypeDef{IsCompact: true, Compact: types.Si1TypeDefCompact{Type: lookupID(20)}}},
+		18: {
+			Path: path("test", "AccountId32"),
+			Def:  composite(field("", "", 6)),
+		},
+		19: {
+			Path: path("test", "Invalid"),
+			Def:  composite(field("", "", 99)),
+		},
+		20: {
+			Path: path("test", "Perbill"),
+			Def:  composite(field("", "u32", 2)),
+		},
+		21: primitive(types.IsU8),
+	}
+
+	return &types.Metadata{
+		AsMetadataV14: types.MetadataV14{
+			EfficientLookup: lookup,
+		},
+	}
+}
+
+func resolveSchema(t *testing.T, doc *Document, schema *Schema) *Schema {
+	res, err := resolveRef(doc, schema)
+	assert.NoError(t, err)
+
+	return res
+}
+
+func resolveRef(doc *Document, schema *Schema) (*Schema, error) {
+	for schema.Ref != "" {
+		defs := doc.Defs
+		defName := strings.TrimPrefix(schema.Ref, jsonSchemaRefPrefix)
+
+		if doc.Components != nil {
+			defs = doc.Components.Schemas
+			defName = strings.TrimPrefix(schema.Ref, openAPIRefPrefix)
+		}
+
+		def, ok := defs[defName]
+
+		if !ok {
+			return nil, fmt.Errorf("definition '%s' not found", defName)
+		}
+
+		schema = def
+	}
+
+	return schema, nil
+}
+
+// toJSONValue returns the value that is produced when unmarshalling the JSON of the provided value.
+func toJSONValue(t *testing.T, value any) any {
+	b, err := json.Marshal(value)
+	assert.NoError(t, err)
+
+	var res any
+
+	assert.NoError(t, json.Unmarshal(b, &res))
+
+	return res
+}
+
+// validateSchema validates the provided JSON value against the keywords used by the SchemaGenerator.
+//
+//nolint:gocyclo
+func validateSchema(doc *Document, schema *Schema, value any) error {
+	schema, err := resolveRef(doc, schema)
+
+	if err != nil {
+		return err
+	}
+
+	if len(schema.OneOf) > 0 {
+		var matches int
+
+		for _, oneOf := range schema.OneOf {
+			if validateSchema(doc, oneOf, value) == nil {
+				matches++
+			}
+		}
+
+		if matches != 1 {
+			return fmt.Errorf("value %v matches %d schemas of oneOf", value, matches)
+		}
+	}
+
+	if schema.Const != nil && schema.Const != value {
+		return fmt.Errorf("expected %v, got %v", schema.Const, value)
+	}
+
+	if len(schema.Enum) > 0 && !containsValue(schema.Enum, value) {
+		return fmt.Errorf("value %v not in enum %v", value, schema.Enum)
+	}
+
+	switch schema.Type {
+	case "":
+		return nil
+	case schemaTypeNull:
+		if value != nil {
+			return fmt.Errorf("expected null, got %v", value)
+		}
+	case schemaTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected boolean, got %v", value)
+		}
+	case schemaTypeInteger:
+		return validateInteger(schema, value)
+	case schemaTypeString:
+		return validateString(schema, value)
+	case schemaTypeArray:
+		return validateArray(doc, schema, value)
+	case schemaTypeObject:
+		return validateObject(doc, schema, value)
+	}
+
+	return nil
+}
+
+func validateInteger(schema *Schema, value any) error {
+	f, ok := value.(float64)
+
+	if !ok || f != float64(int64(f)) {
+		return fmt.Errorf("expected integer, got %v", value)
+	}
+
+	if (schema.Minimum != nil && int64(f) < *schema.Minimum) || (schema.Maximum != nil && int64(f) > *schema.Maximum) {
+		return fmt.Errorf("integer %v out of range", value)
+	}
+
+	return nil
+}
+
+func validateString(schema *Schema, value any) error {
+	s, ok := value.(string)
+
+	if !ok {
+		return fmt.Errorf("expected string, got %v", value)
+	}
+
+	if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(s) {
+		return fmt.Errorf("string %s doesn't match pattern %s", s, schema.Pattern)
+	}
+
+	if (schema.MinLength != nil && len([]rune(s)) < *schema.MinLength) ||
+		(schema.MaxLength != nil && len([]rune(s)) > *schema.MaxLength) {
+		return fmt.Errorf("string %s has invalid length", s)
+	}
+
+	return nil
+}
+
+func validateArray(doc *Document, schema *Schema, value any) error {
+	items, ok := value.([]any)
+
+	if !ok {
+		return fmt.Errorf("expected array, got %v", value)
+	}
+
+	if (schema.MinItems != nil && len(items) < *schema.MinItems) ||
+		(schema.MaxItems != nil && len(items) > *schema.MaxItems) {
+		return fmt.Errorf("array %v has invalid length", value)
+	}
+
+	for i, item := range items {
+		itemSchema := schema.Items
+
+		if i < len(schema.PrefixItems) {
+			itemSchema = schema.PrefixItems[i]
+		}
+
+		if itemSchema == nil {
+			continue
+		}
+
+		if err := validateSchema(doc, itemSchema, item); err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateObject(doc *Document, schema *Schema, value any) error {
+	object, ok := value.(map[string]any)
+
+	if !ok {
+		return fmt.Errorf("expected object, got %v", value)
+	}
+
+	for _, key := range schema.Required {
+		if _, ok := object[key]; !ok {
+			return fmt.Errorf("missing property %s", key)
+		}
+	}
+
+	for key, propertyValue := range object {
+		propertySchema, ok := schema.Properties[key]
+
+		if !ok {
+			if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				return fmt.Errorf("unexpected property %s", key)
+			}
+
+			continue
+		}
+
+		if err := validateSchema(doc, propertySchema, propertyValue); err != nil {
+			return fmt.Errorf("property %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,481 @@
+package retriever
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/cache"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	regState "github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+)
+
+//nolint:lll
+//go:generate mockery --name BlockRangeRetriever --structname BlockRangeRetrieverMock --filename block_range_retriever_mock.go --inpackage
+
+// BlockData holds a block together with its decoded extrinsics and events.
+type BlockData struct {
+	Number      uint64
+	Hash        types.Hash
+	SpecVersion types.U32
+	Block       *block.SignedBlock
+	Extrinsics  []*registry.DecodedExtrinsic
+	Events      []*parser.Event
+}
+
+// BlockRangeRetriever is the interface used for streaming the data of a range of blocks.
+type BlockRangeRetriever interface {
+	// StreamBlocks retrieves the blocks between fromBlock and toBlock, inclusive, and sends them in block order
+	// on the returned block channel. The finalized blocks are followed indefinitely if toBlock is nil.
+	//
+	// The stream is stopped when a block cannot be retrieved, in which case the error is sent on the returned
+	// error channel. Both channels are closed once the stream is stopped or the context is done.
+	StreamBlocks(ctx context.Context, fromBlock uint64, toBlock *uint64) (<-chan *BlockData, <-chan error)
+}
+
+const (
+	DefaultWorkerCount           = 4
+	DefaultFinalizedPollInterval = 6 * time.Second
+)
+
+// BlockRangeOption is the type used for configuring a BlockRangeRetriever.
+type BlockRangeOption func(r *blockRangeRetriever)
+
+// WithWorkerCount sets the number of blocks that are retrieved concurrently.
+func WithWorkerCount(workerCount uint) BlockRangeOption {
+	return func(r *blockRangeRetriever) {
+		if workerCount == 0 {
+			workerCount = DefaultWorkerCount
+		}
+
+		r.workerCount = workerCount
+	}
+}
+
+// WithMaxPendingBlocks sets the maximum number of blocks that can be retrieved ahead of the block that
+// is waiting to be received from the stream.
+//
+// It defaults to twice the number of workers.
+func WithMaxPendingBlocks(maxPendingBlocks uint) BlockRangeOption {
+	return func(r *blockRangeRetriever) {
+		r.maxPendingBlocks = maxPendingBlocks
+	}
+}
+
+// WithFinalizedPollInterval sets the interval at which the finalized head is checked when following
+// the finalized blocks.
+func WithFinalizedPollInterval(interval time.Duration) BlockRangeOption {
+	return func(r *blockRangeRetriever) {
+		r.finalizedPollInterval = interval
+	}
+}
+
+// WithCheckpoint sets the Checkpoint used for resuming a stream.
+//
+// A stream starts after the stored block if it is not before fromBlock, and each block is saved once it's
+// received from the stream.
+func WithCheckpoint(checkpoint Checkpoint) BlockRangeOption {
+	return func(r *blockRangeRetriever) {
+		r.checkpoint = checkpoint
+	}
+}
+
+// blockRangeRetriever implements the BlockRangeRetriever interface.
+type blockRangeRetriever struct {
+	eventParser parser.EventParser
+
+	eventProvider regState.EventProvider
+	chainRPC      chain.Chain
+
+	metadataCache cache.MetadataCache
+
+	blockDataExecutor exec.RetryableExecutor[*BlockData]
+
+	workerCount           uint
+	maxPendingBlocks      uint
+	finalizedPollInterval time.Duration
+	checkpoint            Checkpoint
+}
+
+// NewBlockRangeRetriever creates a new BlockRangeRetriever.
+//
+// The metadata cache is used for decoding each block with the metadata of its spec version.
+func NewBlockRangeRetriever(
+	eventParser parser.EventParser,
+	eventProvider regState.EventProvider,
+	chainRPC chain.Chain,
+	metadataCache cache.MetadataCache,
+	blockDataExecutor exec.RetryableExecutor[*BlockData],
+	opts ...BlockRangeOption,
+) BlockRangeRetriever {
+	retriever := &blockRangeRetriever{
+		eventParser:           eventParser,
+		eventProvider:         eventProvider,
+		chainRPC:              chainRPC,
+		metadataCache:         metadataCache,
+		blockDataExecutor:     blockDataExecutor,
+		workerCount:           DefaultWorkerCount,
+		finalizedPollInterval: DefaultFinalizedPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt(retriever)
+	}
+
+	if retriever.maxPendingBlocks == 0 {
+		retriever.maxPendingBlocks = 2 * retriever.workerCount
+	}
+
+	return retriever
+}
+
+// NewDefaultBlockRangeRetriever creates a new BlockRangeRetriever using defaults for:
+//
+// - parser.EventParser
+// - exec.RetryableExecutor - used for retrieving the data of a block.
+func NewDefaultBlockRangeRetriever(
+	eventProvider regState.EventProvider,
+	chainRPC chain.Chain,
+	metadataCache cache.MetadataCache,
+	opts ...BlockRangeOption,
+) BlockRangeRetriever {
+	eventParser := parser.NewEventParser()
+
+	blockDataExecutor := exec.NewRetryableExecutor[*BlockData](exec.WithRetryTimeout(1 * time.Second))
+
+	return NewBlockRangeRetriever(
+		eventParser,
+		eventProvider,
+		chainRPC,
+		metadataCache,
+		blockDataExecutor,
+		opts...,
+	)
+}
+
+// StreamBlocks retrieves the blocks using the configured number of workers and sends them in block order.
+func (r *blockRangeRetriever) StreamBlocks(
+	ctx context.Context,
+	fromBlock uint64,
+	toBlock *uint64,
+) (<-chan *BlockData, <-chan error) {
+	blockChan := make(chan *BlockData)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(blockChan)
+		defer close(errChan)
+
+		if err := r.stream(ctx, fromBlock, toBlock, blockChan); err != nil {
+			errChan <- err
+		}
+	}()
+
+	return blockChan, errChan
+}
+
+// blockResult holds the result of retrieving the data of a block.
+type blockResult struct {
+	blockData *BlockData
+	err       error
+}
+
+// blockJob is used for requesting the data of a block from a worker.
+type blockJob struct {
+	blockNumber uint64
+	resChan     chan<- *blockResult
+}
+
+// stream dispatches the block jobs to the workers and sends the results in block order.
+//
+// The result channel of each job is queued, in order, in a channel of size maxPendingBlocks, which blocks
+// the dispatching of new jobs when the stream consumer falls behind.
+func (r *blockRangeRetriever) stream(
+	ctx context.Context,
+	fromBlock uint64,
+	toBlock *uint64,
+	blockChan chan<- *BlockData,
+) error {
+	startBlock, err := r.getStartBlock(fromBlock)
+
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+
+	// The dispatcher and workers are cancelled, and then waited for, once the stream is stopped.
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobChan := make(chan *blockJob)
+	resQueue := make(chan chan *blockResult, r.maxPendingBlocks)
+
+	for i := uint(0); i < r.workerCount; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for job := range jobChan {
+				blockData, err := r.getBlockData(ctx, job.blockNumber)
+
+				job.resChan <- &blockResult{blockData, err}
+			}
+		}()
+	}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		defer close(jobChan)
+		defer close(resQueue)
+
+		r.dispatch(ctx, startBlock, toBlock, jobChan, resQueue)
+	}()
+
+	for resChan := range resQueue {
+		var res *blockResult
+
+		select {
+		case res = <-resChan:
+		case <-ctx.Done():
+			return nil
+		}
+
+		if res.err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return res.err
+		}
+
+		select {
+		case blockChan <- res.blockData:
+		case <-ctx.Done():
+			return nil
+		}
+
+		if r.checkpoint == nil {
+			continue
+		}
+
+		if err := r.checkpoint.Save(res.blockData.Number); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dispatch sends the block jobs until toBlock is reached or the context is done.
+//
+// When following the finalized blocks, the dispatching waits for each block to be finalized.
+func (r *blockRangeRetriever) dispatch(
+	ctx context.Context,
+	startBlock uint64,
+	toBlock *uint64,
+	jobChan chan<- *blockJob,
+	resQueue chan<- chan *blockResult,
+) {
+	var (
+		finalizedBlock    uint64
+		hasFinalizedBlock bool
+	)
+
+	for blockNumber := startBlock; toBlock == nil || blockNumber <= *toBlock; blockNumber++ {
+		resChan := make(chan *blockResult, 1)
+
+		select {
+		case resQueue <- resChan:
+		case <-ctx.Done():
+			return
+		}
+
+		if toBlock == nil && (!hasFinalizedBlock || blockNumber > finalizedBlock) {
+			var err error
+
+			finalizedBlock, err = r.waitForFinalizedBlock(ctx, blockNumber)
+
+			if err != nil {
+				resChan <- &blockResult{err: err}
+
+				return
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			hasFinalizedBlock = true
+		}
+
+		select {
+		case jobChan <- &blockJob{blockNumber, resChan}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// waitForFinalizedBlock polls the finalized head until the provided block is finalized or the context is done,
+// and returns the number of the finalized head.
+func (r *blockRangeRetriever) waitForFinalizedBlock(ctx context.Context, blockNumber uint64) (uint64, error) {
+	for {
+		finalizedBlock, err := r.getFinalizedBlock(ctx)
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return finalizedBlock, nil
+			}
+
+			return 0, err
+		}
+
+		if blockNumber <= finalizedBlock {
+			return finalizedBlock, nil
+		}
+
+		select {
+		case <-time.After(r.finalizedPollInterval):
+		case <-ctx.Done():
+			return finalizedBlock, nil
+		}
+	}
+}
+
+func (r *blockRangeRetriever) getFinalizedBlock(ctx context.Context) (uint64, error) {
+	finalizedHash, err := r.chainRPC.GetFinalizedHeadCtx(ctx)
+
+	if err != nil {
+		return 0, ErrFinalizedHeadRetrieval.Wrap(err)
+	}
+
+	header, err := r.chainRPC.GetHeaderCtx(ctx, finalizedHash)
+
+	if err != nil {
+		return 0, ErrFinalizedHeadRetrieval.Wrap(err)
+	}
+
+	return uint64(header.Number), nil
+}
+
+// getStartBlock returns the block after the one stored in the checkpoint, if it's not before fromBlock.
+func (r *blockRangeRetriever) getStartBlock(fromBlock uint64) (uint64, error) {
+	if r.checkpoint == nil {
+		return fromBlock, nil
+	}
+
+	blockNumber, ok, err := r.checkpoint.Load()
+
+	if err != nil {
+		return 0, err
+	}
+
+	if !ok || blockNumber < fromBlock {
+		return fromBlock, nil
+	}
+
+	return blockNumber + 1, nil
+}
+
+// getBlockData retrieves and decodes the data of a block via the exec.RetryableExecutor in order to
+// ensure retries in case of network errors.
+func (r *blockRangeRetriever) getBlockData(ctx context.Context, blockNumber uint64) (*BlockData, error) {
+	blockData, err := r.blockDataExecutor.ExecWithFallbackCtx(
+		ctx,
+		func() (*BlockData, error) {
+			return r.retrieveBlockData(ctx, blockNumber)
+		},
+		func() error {
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, ErrBlockDataRetrieval.Wrap(err)
+	}
+
+	return blockData, nil
+}
+
+func (r *blockRangeRetriever) retrieveBlockData(ctx context.Context, blockNumber uint64) (*BlockData, error) {
+	blockHash, err := r.chainRPC.GetBlockHashCtx(ctx, blockNumber)
+
+	if err != nil {
+		return nil, ErrBlockHashRetrieval.Wrap(err)
+	}
+
+	signedBlock, err := r.chainRPC.GetBlockCtx(ctx, blockHash)
+
+	if err != nil {
+		return nil, ErrBlockRetrieval.Wrap(err)
+	}
+
+	entry, err := r.metadataCache.GetEntryForBlockCtx(ctx, blockNumber, blockHash)
+
+	if err != nil {
+		return nil, ErrMetadataCacheEntryRetrieval.Wrap(err)
+	}
+
+	return decodeBlockData(ctx, r.eventParser, r.eventProvider, entry, blockHash, signedBlock)
+}
+
+// decodeBlockData decodes the extrinsics of the provided block and retrieves its events using the
+// provided cache.Entry.
+func decodeBlockData(
+	ctx context.Context,
+	eventParser parser.EventParser,
+	eventProvider regState.EventProvider,
+	entry *cache.Entry,
+	blockHash types.Hash,
+	signedBlock *block.SignedBlock,
+) (*BlockData, error) {
+	extrinsicDecoder, err := entry.GetExtrinsicDecoder()
+
+	if err != nil {
+		return nil, ErrExtrinsicDecoderCreation.Wrap(err)
+	}
+
+	extrinsics, err := signedBlock.DecodeExtrinsics(extrinsicDecoder)
+
+	if err != nil {
+		return nil, ErrExtrinsicDecoding.Wrap(err)
+	}
+
+	eventRegistry, err := entry.GetEventRegistry()
+
+	if err != nil {
+		return nil, ErrEventRegistryCreation.Wrap(err)
+	}
+
+	storageEvents, err := eventProvider.GetStorageEventsCtx(ctx, entry.Metadata, blockHash)
+
+	if err != nil {
+		return nil, ErrStorageEventRetrieval.Wrap(err)
+	}
+
+	events, err := eventParser.ParseEvents(eventRegistry, storageEvents)
+
+	if err != nil {
+		return nil, ErrEventParsing.Wrap(err)
+	}
+
+	return &BlockData{
+		Number:      uint64(signedBlock.Block.Header.Number),
+		Hash:        blockHash,
+		SpecVersion: entry.SpecVersion,
+		Block:       signedBlock,
+		Extrinsics:  extrinsics,
+		Events:      events,
+	}, nil
+}
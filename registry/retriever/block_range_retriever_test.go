@@ -0,0 +1,426 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/cache"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// blockRangeTestEnv holds the mocks used by a BlockRangeRetriever, blocks before upgradeBlock are
+// decoded using spec version 1 and the following ones using spec version 2.
+type blockRangeTestEnv struct {
+	chainRPCMock      *chainMocks.Chain
+	eventProviderMock *state.EventProviderMock
+	eventParserMock   *parser.EventParserMock
+	metadataCacheMock *cache.MetadataCacheMock
+
+	entries         map[types.U32]*cache.Entry
+	eventRegistries map[types.U32]registry.EventRegistry
+
+	upgradeBlock uint64
+
+	blockHashCallCount atomic.Int64
+}
+
+func newBlockRangeTestEnv(t *testing.T, upgradeBlock uint64) *blockRangeTestEnv {
+	env := &blockRangeTestEnv{
+		chainRPCMock:      chainMocks.NewChain(t),
+		eventProviderMock: state.NewEventProviderMock(t),
+		eventParserMock:   parser.NewEventParserMock(t),
+		metadataCacheMock: cache.NewMetadataCacheMock(t),
+		entries:           make(map[types.U32]*cache.Entry),
+		eventRegistries:   make(map[types.U32]registry.EventRegistry),
+		upgradeBlock:      upgradeBlock,
+	}
+
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	for _, specVersion := range []types.U32{1, 2} {
+		meta := &types.Metadata{Version: uint8(specVersion)}
+
+		entry, err := cache.NewMetadataCache(stateMocks.NewState(t), registryFactoryMock).AddMetadata(specVersion, meta)
+		assert.NoError(t, err)
+
+		eventRegistry := registry.EventRegistry{
+			types.EventID{byte(specVersion)}: &registry.TypeDecoder{},
+		}
+
+		registryFactoryMock.On("CreateExtrinsicDecoder", meta).
+			Return(&registry.ExtrinsicDecoder{}, nil).
+			Maybe()
+		registryFactoryMock.On("CreateEventRegistry", meta).
+			Return(eventRegistry, nil).
+			Maybe()
+
+		env.entries[specVersion] = entry
+		env.eventRegistries[specVersion] = eventRegistry
+	}
+
+	return env
+}
+
+func (e *blockRangeTestEnv) getSpecVersion(blockNumber uint64) types.U32 {
+	if blockNumber < e.upgradeBlock {
+		return 1
+	}
+
+	return 2
+}
+
+// mockBlock adds the mock calls for retrieving the provided block, which are delayed randomly
+// in order to shuffle the order in which the blocks are retrieved.
+func (e *blockRangeTestEnv) mockBlock(blockNumber uint64) {
+	blockHash := types.NewHash([]byte{byte(blockNumber)})
+
+	e.chainRPCMock.On("GetBlockHashCtx", mock.Anything, blockNumber).
+		Run(func(_ mock.Arguments) {
+			e.blockHashCallCount.Add(1)
+
+			time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond) //nolint:gosec
+		}).
+		Return(blockHash, nil).
+		Maybe()
+
+	e.chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
+		Return(e.getTestBlock(blockNumber), nil).
+		Maybe()
+
+	entry := e.entries[e.getSpecVersion(blockNumber)]
+
+	e.metadataCacheMock.On("GetEntryForBlockCtx", mock.Anything, blockNumber, blockHash).
+		Return(entry, nil).
+		Maybe()
+
+	storageEvents := &types.StorageDataRaw{byte(blockNumber)}
+
+	e.eventProviderMock.On("GetStorageEventsCtx", mock.Anything, entry.Metadata, blockHash).
+		Return(storageEvents, nil).
+		Maybe()
+
+	e.eventParserMock.On("ParseEvents", e.eventRegistries[entry.SpecVersion], storageEvents).
+		Return(e.getTestEvents(blockNumber), nil).
+		Maybe()
+}
+
+func (e *blockRangeTestEnv) getTestBlock(blockNumber uint64) *block.SignedBlock {
+	return &block.SignedBlock{
+		Block: block.Block{
+			Header: types.Header{
+				Number: types.BlockNumber(blockNumber),
+			},
+		},
+	}
+}
+
+func (e *blockRangeTestEnv) getTestEvents(blockNumber uint64) []*parser.Event {
+	return []*parser.Event{
+		{
+			Name:    "System.ExtrinsicSuccess",
+			Phase:   &types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: uint32(blockNumber)},
+			EventID: types.EventID{0, 0},
+		},
+	}
+}
+
+func (e *blockRangeTestEnv) newRetriever(opts ...BlockRangeOption) BlockRangeRetriever {
+	return NewBlockRangeRetriever(
+		e.eventParserMock,
+		e.eventProviderMock,
+		e.chainRPCMock,
+		e.metadataCacheMock,
+		exec.NewRetryableExecutor[*BlockData](exec.WithMaxRetryCount(1), exec.WithRetryTimeout(time.Millisecond)),
+		opts...,
+	)
+}
+
+func (e *blockRangeTestEnv) assertBlockData(t *testing.T, blockNumber uint64, blockData *BlockData) {
+	assert.Equal(t, blockNumber, blockData.Number)
+	assert.Equal(t, types.NewHash([]byte{byte(blockNumber)}), blockData.Hash)
+	assert.Equal(t, e.getSpecVersion(blockNumber), blockData.SpecVersion)
+	assert.Equal(t, e.getTestBlock(blockNumber), blockData.Block)
+	assert.Empty(t, blockData.Extrinsics)
+	assert.Equal(t, e.getTestEvents(blockNumber), blockData.Events)
+}
+
+func TestBlockRangeRetriever_NewDefault(t *testing.T) {
+	eventProviderMock := state.NewEventProviderMock(t)
+	chainRPCMock := chainMocks.NewChain(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+
+	res := NewDefaultBlockRangeRetriever(eventProviderMock, chainRPCMock, metadataCacheMock, WithWorkerCount(8))
+	assert.IsType(t, &blockRangeRetriever{}, res)
+
+	retriever := res.(*blockRangeRetriever)
+	assert.IsType(t, parser.NewEventParser(), retriever.eventParser)
+	assert.IsType(t, exec.NewRetryableExecutor[*BlockData](), retriever.blockDataExecutor)
+	assert.Equal(t, uint(8), retriever.workerCount)
+	assert.Equal(t, uint(16), retriever.maxPendingBlocks)
+	assert.Equal(t, DefaultFinalizedPollInterval, retriever.finalizedPollInterval)
+	assert.Nil(t, retriever.checkpoint)
+}
+
+func TestBlockRangeRetriever_StreamBlocks(t *testing.T) {
+	env := newBlockRangeTestEnv(t, 25)
+
+	for i := uint64(10); i <= 50; i++ {
+		env.mockBlock(i)
+	}
+
+	toBlock := uint64(50)
+
+	blockChan, errChan := env.newRetriever(WithWorkerCount(8)).StreamBlocks(context.Background(), 10, &toBlock)
+
+	expectedBlockNumber := uint64(10)
+
+	for blockData := range blockChan {
+		env.assertBlockData(t, expectedBlockNumber, blockData)
+
+		expectedBlockNumber++
+	}
+
+	assert.Equal(t, uint64(51), expectedBlockNumber)
+
+	for err := range errChan {
+		assert.NoError(t, err)
+	}
+}
+
+func TestBlockRangeRetriever_StreamBlocks_FollowFinalized(t *testing.T) {
+	env := newBlockRangeTestEnv(t, 2)
+
+	for i := uint64(0); i <= 5; i++ {
+		env.mockBlock(i)
+	}
+
+	finalizedHash := types.NewHash([]byte{255})
+
+	env.chainRPCMock.On("GetFinalizedHeadCtx", mock.Anything).
+		Return(finalizedHash, nil)
+	env.chainRPCMock.On("GetHeaderCtx", mock.Anything, finalizedHash).
+		Return(&types.Header{Number: 2}, nil).
+		Once()
+	env.chainRPCMock.On("GetHeaderCtx", mock.Anything, finalizedHash).
+		Return(&types.Header{Number: 2}, nil).
+		Once()
+	env.chainRPCMock.On("GetHeaderCtx", mock.Anything, finalizedHash).
+		Return(&types.Header{Number: 5}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blockChan, errChan := env.newRetriever(WithFinalizedPollInterval(time.Millisecond)).
+		StreamBlocks(ctx, 0, nil)
+
+	for i := uint64(0); i <= 5; i++ {
+		env.assertBlockData(t, i, <-blockChan)
+	}
+
+	// Block 6 is not finalized.
+	select {
+	case blockData := <-blockChan:
+		t.Fatalf("unexpected block %d", blockData.Number)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel()
+
+	_, ok := <-blockChan
+	assert.False(t, ok)
+
+	_, ok = <-errChan
+	assert.False(t, ok)
+}
+
+func TestBlockRangeRetriever_StreamBlocks_FinalizedHeadError(t *testing.T) {
+	env := newBlockRangeTestEnv(t, 0)
+
+	finalizedHeadError := errors.New("error")
+
+	env.chainRPCMock.On("GetFinalizedHeadCtx", mock.Anything).
+		Return(types.Hash{}, finalizedHeadError).
+		Once()
+
+	blockChan, errChan := env.newRetriever().StreamBlocks(context.Background(), 0, nil)
+
+	_, ok := <-blockChan
+	assert.False(t, ok)
+
+	err := <-errChan
+	assert.ErrorIs(t, err, ErrFinalizedHeadRetrieval)
+}
+
+func TestBlockRangeRetriever_StreamBlocks_Checkpoint(t *testing.T) {
+	env := newBlockRangeTestEnv(t, 0)
+
+	for i := uint64(10); i <= 12; i++ {
+		env.mockBlock(i)
+	}
+
+	checkpointMock := NewCheckpointMock(t)
+
+	checkpointMock.On("Load").
+		Return(uint64(9), true, nil).
+		Once()
+
+	for i := uint64(10); i <= 12; i++ {
+		checkpointMock.On("Save", i).
+			Return(nil).
+			Once()
+	}
+
+	toBlock := uint64(12)
+
+	blockChan, errChan := env.newRetriever(WithCheckpoint(checkpointMock)).
+		StreamBlocks(context.Background(), 5, &toBlock)
+
+	expectedBlockNumber := uint64(10)
+
+	for blockData := range blockChan {
+		env.assertBlockData(t, expectedBlockNumber, blockData)
+
+		expectedBlockNumber++
+	}
+
+	assert.Equal(t, uint64(13), expectedBlockNumber)
+	assert.NoError(t, <-errChan)
+
+	// The checkpoint is ignored if it's before the first block.
+	env.mockBlock(20)
+
+	checkpointMock.On("Load").
+		Return(uint64(12), true, nil).
+		Once()
+	checkpointMock.On("Save", uint64(20)).
+		Return(nil).
+		Once()
+
+	toBlock = 20
+
+	blockChan, errChan = env.newRetriever(WithCheckpoint(checkpointMock)).
+		StreamBlocks(context.Background(), 20, &toBlock)
+
+	env.assertBlockData(t, 20, <-blockChan)
+
+	_, ok := <-blockChan
+	assert.False(t, ok)
+	assert.NoError(t, <-errChan)
+}
+
+func TestBlockRangeRetriever_StreamBlocks_CheckpointErrors(t *testing.T) {
+	env := newBlockRangeTestEnv(t, 0)
+
+	env.mockBlock(0)
+
+	checkpointMock := NewCheckpointMock(t)
+
+	checkpointError := errors.New("error")
+
+	checkpointMock.On("Load").
+		Return(uint64(0), false, checkpointError).
+		Once()
+
+	toBlock := uint64(0)
+
+	blockChan, errChan := env.newRetriever(WithCheckpoint(checkpointMock)).
+		StreamBlocks(context.Background(), 0, &toBlock)
+
+	_, ok := <-blockChan
+	assert.False(t, ok)
+	assert.ErrorIs(t, <-errChan, checkpointError)
+
+	checkpointMock.On("Load").
+		Return(uint64(0), false, nil).
+		Once()
+	checkpointMock.On("Save", uint64(0)).
+		Return(checkpointError).
+		Once()
+
+	blockChan, errChan = env.newRetriever(WithCheckpoint(checkpointMock)).
+		StreamBlocks(context.Background(), 0, &toBlock)
+
+	env.assertBlockData(t, 0, <-blockChan)
+
+	_, ok = <-blockChan
+	assert.False(t, ok)
+	assert.ErrorIs(t, <-errChan, checkpointError)
+}
+
+func TestBlockRangeRetriever_StreamBlocks_BlockError(t *testing.T) {
+	env := newBlockRangeTestEnv(t, 0)
+
+	for i := uint64(0); i <= 2; i++ {
+		env.mockBlock(i)
+	}
+
+	for i := uint64(4); i <= 10; i++ {
+		env.mockBlock(i)
+	}
+
+	blockHashError := errors.New("error")
+
+	env.chainRPCMock.On("GetBlockHashCtx", mock.Anything, uint64(3)).
+		Return(types.Hash{}, blockHashError).
+		Twice()
+
+	toBlock := uint64(10)
+
+	blockChan, errChan := env.newRetriever().StreamBlocks(context.Background(), 0, &toBlock)
+
+	expectedBlockNumber := uint64(0)
+
+	for blockData := range blockChan {
+		env.assertBlockData(t, expectedBlockNumber, blockData)
+
+		expectedBlockNumber++
+	}
+
+	assert.Equal(t, uint64(3), expectedBlockNumber)
+
+	err := <-errChan
+	assert.ErrorIs(t, err, ErrBlockDataRetrieval)
+	assert.ErrorIs(t, err, blockHashError)
+}
+
+func TestBlockRangeRetriever_StreamBlocks_Backpressure(t *testing.T) {
+	env := newBlockRangeTestEnv(t, 0)
+
+	for i := uint64(0); i <= 100; i++ {
+		env.mockBlock(i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	toBlock := uint64(100)
+
+	blockChan, errChan := env.newRetriever(WithWorkerCount(2), WithMaxPendingBlocks(3)).
+		StreamBlocks(ctx, 0, &toBlock)
+
+	env.assertBlockData(t, 0, <-blockChan)
+
+	time.Sleep(50 * time.Millisecond)
+
+	// The block that is waiting to be received, the pending blocks and the one that is waiting to be queued.
+	assert.LessOrEqual(t, env.blockHashCallCount.Load(), int64(1+1+3+1))
+
+	cancel()
+
+	for range blockChan {
+	}
+
+	assert.NoError(t, <-errChan)
+}
@@ -0,0 +1,215 @@
+package retriever
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/cache"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	regState "github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+//nolint:lll
+//go:generate mockery --name BlockRetriever --structname BlockRetrieverMock --filename block_retriever_mock.go --inpackage
+
+// BlockRetriever is the interface used for retrieving a BlockView, which joins the extrinsics of a block
+// with their events and outcomes.
+type BlockRetriever interface {
+	// GetBlock retrieves the block with the provided hash and returns its BlockView.
+	GetBlock(blockHash types.Hash) (*BlockView, error)
+	// GetBlockCtx is like GetBlock, the RPC requests and the retries are bound to the provided context.
+	GetBlockCtx(ctx context.Context, blockHash types.Hash) (*BlockView, error)
+
+	// GetBlockView returns the BlockView for the provided BlockData, such as the one received from
+	// a BlockRangeRetriever stream.
+	GetBlockView(blockData *BlockData) (*BlockView, error)
+	// GetBlockViewCtx is like GetBlockView, the RPC requests and the retries are bound to the provided context.
+	GetBlockViewCtx(ctx context.Context, blockData *BlockData) (*BlockView, error)
+}
+
+const (
+	sessionStoragePrefix    = "Session"
+	validatorsStorageMethod = "Validators"
+)
+
+// blockRetriever implements the BlockRetriever interface.
+type blockRetriever struct {
+	eventParser parser.EventParser
+
+	eventProvider regState.EventProvider
+	chainRPC      chain.Chain
+	stateRPC      state.State
+
+	metadataCache cache.MetadataCache
+
+	blockViewExecutor exec.RetryableExecutor[*BlockView]
+}
+
+// NewBlockRetriever creates a new BlockRetriever.
+//
+// The metadata cache is used for decoding each block with the metadata of its spec version.
+func NewBlockRetriever(
+	eventParser parser.EventParser,
+	eventProvider regState.EventProvider,
+	chainRPC chain.Chain,
+	stateRPC state.State,
+	metadataCache cache.MetadataCache,
+	blockViewExecutor exec.RetryableExecutor[*BlockView],
+) BlockRetriever {
+	return &blockRetriever{
+		eventParser:       eventParser,
+		eventProvider:     eventProvider,
+		chainRPC:          chainRPC,
+		stateRPC:          stateRPC,
+		metadataCache:     metadataCache,
+		blockViewExecutor: blockViewExecutor,
+	}
+}
+
+// NewDefaultBlockRetriever creates a new BlockRetriever using defaults for:
+//
+// - parser.EventParser
+// - exec.RetryableExecutor - used for retrieving the block view.
+func NewDefaultBlockRetriever(
+	eventProvider regState.EventProvider,
+	chainRPC chain.Chain,
+	stateRPC state.State,
+	metadataCache cache.MetadataCache,
+) BlockRetriever {
+	eventParser := parser.NewEventParser()
+
+	blockViewExecutor := exec.NewRetryableExecutor[*BlockView](exec.WithRetryTimeout(1 * time.Second))
+
+	return NewBlockRetriever(
+		eventParser,
+		eventProvider,
+		chainRPC,
+		stateRPC,
+		metadataCache,
+		blockViewExecutor,
+	)
+}
+
+// GetBlock retrieves the block with the provided hash, decodes its extrinsics and events and then joins them
+// in a BlockView.
+//
+// The whole process is executed via the exec.RetryableExecutor in order to ensure retries in case of network errors.
+func (r *blockRetriever) GetBlock(blockHash types.Hash) (*BlockView, error) {
+	return r.GetBlockCtx(context.Background(), blockHash)
+}
+
+// GetBlockCtx is like GetBlock, the RPC requests and the retries are bound to the provided context.
+func (r *blockRetriever) GetBlockCtx(ctx context.Context, blockHash types.Hash) (*BlockView, error) {
+	blockView, err := r.blockViewExecutor.ExecWithFallbackCtx(
+		ctx,
+		func() (*BlockView, error) {
+			signedBlock, err := r.chainRPC.GetBlockCtx(ctx, blockHash)
+
+			if err != nil {
+				return nil, ErrBlockRetrieval.Wrap(err)
+			}
+
+			entry, err := r.metadataCache.GetEntryForBlockCtx(ctx, uint64(signedBlock.Block.Header.Number), blockHash)
+
+			if err != nil {
+				return nil, ErrMetadataCacheEntryRetrieval.Wrap(err)
+			}
+
+			blockData, err := decodeBlockData(ctx, r.eventParser, r.eventProvider, entry, blockHash, signedBlock)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return r.getBlockView(ctx, blockData, entry)
+		},
+		func() error {
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, ErrBlockViewRetrieval.Wrap(err)
+	}
+
+	return blockView, nil
+}
+
+// GetBlockView joins the extrinsics and events of the provided BlockData in a BlockView, using the
+// cache.Entry for the spec version of the block.
+func (r *blockRetriever) GetBlockView(blockData *BlockData) (*BlockView, error) {
+	return r.GetBlockViewCtx(context.Background(), blockData)
+}
+
+// GetBlockViewCtx is like GetBlockView, the RPC requests and the retries are bound to the provided context.
+func (r *blockRetriever) GetBlockViewCtx(ctx context.Context, blockData *BlockData) (*BlockView, error) {
+	blockView, err := r.blockViewExecutor.ExecWithFallbackCtx(
+		ctx,
+		func() (*BlockView, error) {
+			entry, err := r.metadataCache.GetEntryBySpecVersion(blockData.SpecVersion)
+
+			if err != nil {
+				return nil, ErrMetadataCacheEntryRetrieval.Wrap(err)
+			}
+
+			return r.getBlockView(ctx, blockData, entry)
+		},
+		func() error {
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, ErrBlockViewRetrieval.Wrap(err)
+	}
+
+	return blockView, nil
+}
+
+func (r *blockRetriever) getBlockView(
+	ctx context.Context,
+	blockData *BlockData,
+	entry *cache.Entry,
+) (*BlockView, error) {
+	errorRegistry, err := entry.GetErrorRegistry()
+
+	if err != nil {
+		return nil, ErrErrorRegistryCreation.Wrap(err)
+	}
+
+	validators, err := r.getValidators(ctx, entry.Metadata, blockData.Block.Block.Header.ParentHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newBlockView(blockData, errorRegistry, validators)
+}
+
+// getValidators returns the validators found in the Session.Validators storage at the provided parent hash,
+// which are the validators that were active while the block was authored.
+//
+// No validators are returned if the runtime has no Session pallet.
+func (r *blockRetriever) getValidators(
+	ctx context.Context,
+	meta *types.Metadata,
+	parentHash types.Hash,
+) ([]types.AccountID, error) {
+	storageKey, err := types.CreateStorageKey(meta, sessionStoragePrefix, validatorsStorageMethod)
+
+	if err != nil {
+		return nil, nil //nolint:nilerr
+	}
+
+	var validators []types.AccountID
+
+	if _, err := r.stateRPC.GetStorageCtx(ctx, storageKey, &validators, parentHash); err != nil {
+		return nil, ErrValidatorsRetrieval.Wrap(err)
+	}
+
+	return validators, nil
+}
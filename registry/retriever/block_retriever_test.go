@@ -0,0 +1,264 @@
+package retriever
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/cache"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBlockRetriever_NewDefault(t *testing.T) {
+	eventProviderMock := state.NewEventProviderMock(t)
+	chainRPCMock := chainMocks.NewChain(t)
+	stateRPCMock := stateMocks.NewState(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+
+	res := NewDefaultBlockRetriever(eventProviderMock, chainRPCMock, stateRPCMock, metadataCacheMock)
+
+	retriever, ok := res.(*blockRetriever)
+	assert.True(t, ok)
+	assert.Equal(t, eventProviderMock, retriever.eventProvider)
+	assert.Equal(t, chainRPCMock, retriever.chainRPC)
+	assert.Equal(t, stateRPCMock, retriever.stateRPC)
+	assert.Equal(t, metadataCacheMock, retriever.metadataCache)
+	assert.NotNil(t, retriever.eventParser)
+	assert.NotNil(t, retriever.blockViewExecutor)
+}
+
+func TestBlockRetriever_GetBlock(t *testing.T) {
+	eventParserMock := parser.NewEventParserMock(t)
+	eventProviderMock := state.NewEventProviderMock(t)
+	chainRPCMock := chainMocks.NewChain(t)
+	stateRPCMock := stateMocks.NewState(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+
+	retriever := NewBlockRetriever(
+		eventParserMock,
+		eventProviderMock,
+		chainRPCMock,
+		stateRPCMock,
+		metadataCacheMock,
+		exec.NewRetryableExecutor[*BlockView](exec.WithMaxRetryCount(1), exec.WithRetryTimeout(time.Millisecond)),
+	)
+
+	entry := getTestCentrifugeEntry(t)
+
+	blockData := getTestBlockData(t, entry)
+
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockData.Hash).
+		Return(blockData.Block, nil).
+		Once()
+
+	metadataCacheMock.On("GetEntryForBlockCtx", mock.Anything, blockData.Number, blockData.Hash).
+		Return(entry, nil).
+		Once()
+
+	storageEvents := &types.StorageDataRaw{1, 2, 3}
+
+	eventProviderMock.On("GetStorageEventsCtx", mock.Anything, entry.Metadata, blockData.Hash).
+		Return(storageEvents, nil).
+		Once()
+
+	eventRegistry, err := entry.GetEventRegistry()
+	assert.NoError(t, err)
+
+	eventParserMock.On("ParseEvents", eventRegistry, storageEvents).
+		Return(blockData.Events, nil).
+		Once()
+
+	mockValidators(t, stateRPCMock, entry, blockData.Block.Block.Header.ParentHash)
+
+	errorRegistry, err := entry.GetErrorRegistry()
+	assert.NoError(t, err)
+
+	expectedBlockView, err := newBlockView(blockData, errorRegistry, testValidators)
+	assert.NoError(t, err)
+
+	res, err := retriever.GetBlock(blockData.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedBlockView, res)
+	assert.Equal(t, &testValidators[2], res.Author)
+}
+
+func TestBlockRetriever_GetBlock_Errors(t *testing.T) {
+	eventParserMock := parser.NewEventParserMock(t)
+	eventProviderMock := state.NewEventProviderMock(t)
+	chainRPCMock := chainMocks.NewChain(t)
+	stateRPCMock := stateMocks.NewState(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+
+	retriever := NewBlockRetriever(
+		eventParserMock,
+		eventProviderMock,
+		chainRPCMock,
+		stateRPCMock,
+		metadataCacheMock,
+		exec.NewRetryableExecutor[*BlockView](exec.WithMaxRetryCount(1), exec.WithRetryTimeout(time.Millisecond)),
+	)
+
+	entry := getTestCentrifugeEntry(t)
+
+	blockData := getTestBlockData(t, entry)
+
+	// Block retrieval error.
+
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockData.Hash).
+		Return(nil, errors.New("boom")).
+		Twice()
+
+	res, err := retriever.GetBlock(blockData.Hash)
+	assert.ErrorIs(t, err, ErrBlockViewRetrieval)
+	assert.ErrorContains(t, err, ErrBlockRetrieval.Error())
+	assert.Nil(t, res)
+
+	// Metadata cache entry retrieval error.
+
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockData.Hash).
+		Return(blockData.Block, nil).
+		Times(4)
+
+	metadataCacheMock.On("GetEntryForBlockCtx", mock.Anything, blockData.Number, blockData.Hash).
+		Return(nil, errors.New("boom")).
+		Twice()
+
+	res, err = retriever.GetBlock(blockData.Hash)
+	assert.ErrorIs(t, err, ErrBlockViewRetrieval)
+	assert.ErrorContains(t, err, ErrMetadataCacheEntryRetrieval.Error())
+	assert.Nil(t, res)
+
+	// Storage event retrieval error.
+
+	metadataCacheMock.On("GetEntryForBlockCtx", mock.Anything, blockData.Number, blockData.Hash).
+		Return(entry, nil).
+		Twice()
+
+	eventProviderMock.On("GetStorageEventsCtx", mock.Anything, entry.Metadata, blockData.Hash).
+		Return(nil, errors.New("boom")).
+		Twice()
+
+	res, err = retriever.GetBlock(blockData.Hash)
+	assert.ErrorIs(t, err, ErrBlockViewRetrieval)
+	assert.ErrorContains(t, err, ErrStorageEventRetrieval.Error())
+	assert.Nil(t, res)
+}
+
+func TestBlockRetriever_GetBlockView(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+
+	retriever := NewBlockRetriever(
+		parser.NewEventParserMock(t),
+		state.NewEventProviderMock(t),
+		chainMocks.NewChain(t),
+		stateRPCMock,
+		metadataCacheMock,
+		exec.NewRetryableExecutor[*BlockView](exec.WithMaxRetryCount(1), exec.WithRetryTimeout(time.Millisecond)),
+	)
+
+	entry := getTestCentrifugeEntry(t)
+
+	blockData := getTestBlockData(t, entry)
+
+	metadataCacheMock.On("GetEntryBySpecVersion", blockData.SpecVersion).
+		Return(entry, nil).
+		Once()
+
+	mockValidators(t, stateRPCMock, entry, blockData.Block.Block.Header.ParentHash)
+
+	res, err := retriever.GetBlockView(blockData)
+	assert.NoError(t, err)
+	assert.Equal(t, &testValidators[2], res.Author)
+	assert.Len(t, res.Extrinsics, 2)
+}
+
+func TestBlockRetriever_GetBlockView_Errors(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	retriever := NewBlockRetriever(
+		parser.NewEventParserMock(t),
+		state.NewEventProviderMock(t),
+		chainMocks.NewChain(t),
+		stateRPCMock,
+		metadataCacheMock,
+		exec.NewRetryableExecutor[*BlockView](exec.WithMaxRetryCount(1), exec.WithRetryTimeout(time.Millisecond)),
+	)
+
+	entry := getTestCentrifugeEntry(t)
+
+	blockData := getTestBlockData(t, entry)
+
+	// Metadata cache entry retrieval error.
+
+	metadataCacheMock.On("GetEntryBySpecVersion", blockData.SpecVersion).
+		Return(nil, errors.New("boom")).
+		Twice()
+
+	res, err := retriever.GetBlockView(blockData)
+	assert.ErrorIs(t, err, ErrBlockViewRetrieval)
+	assert.ErrorContains(t, err, ErrMetadataCacheEntryRetrieval.Error())
+	assert.Nil(t, res)
+
+	// Validators retrieval error.
+
+	metadataCacheMock.On("GetEntryBySpecVersion", blockData.SpecVersion).
+		Return(entry, nil).
+		Twice()
+
+	stateRPCMock.On("GetStorageCtx", mock.Anything, mock.Anything, mock.Anything, blockData.Block.Block.Header.ParentHash).
+		Return(false, errors.New("boom")).
+		Twice()
+
+	res, err = retriever.GetBlockView(blockData)
+	assert.ErrorIs(t, err, ErrBlockViewRetrieval)
+	assert.ErrorContains(t, err, ErrValidatorsRetrieval.Error())
+	assert.Nil(t, res)
+
+	// Error registry creation error.
+
+	meta := &types.Metadata{}
+
+	failingEntry, err := cache.NewMetadataCache(stateMocks.NewState(t), registryFactoryMock).AddMetadata(2, meta)
+	assert.NoError(t, err)
+
+	registryFactoryMock.On("CreateErrorRegistry", meta).
+		Return(nil, errors.New("boom")).
+		Twice()
+
+	metadataCacheMock.On("GetEntryBySpecVersion", types.U32(2)).
+		Return(failingEntry, nil).
+		Twice()
+
+	blockData.SpecVersion = 2
+
+	res, err = retriever.GetBlockView(blockData)
+	assert.ErrorIs(t, err, ErrBlockViewRetrieval)
+	assert.ErrorContains(t, err, ErrErrorRegistryCreation.Error())
+	assert.Nil(t, res)
+}
+
+// mockValidators adds the mock call for retrieving the Session.Validators storage at the provided block.
+func mockValidators(t *testing.T, stateRPCMock *stateMocks.State, entry *cache.Entry, blockHash types.Hash) {
+	storageKey, err := types.CreateStorageKey(entry.Metadata, sessionStoragePrefix, validatorsStorageMethod)
+	assert.NoError(t, err)
+
+	stateRPCMock.On("GetStorageCtx", mock.Anything, storageKey, mock.Anything, blockHash).
+		Run(func(args mock.Arguments) {
+			validators := args.Get(2).(*[]types.AccountID)
+
+			*validators = testValidators
+		}).
+		Return(true, nil).
+		Once()
+}
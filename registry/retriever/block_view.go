@@ -0,0 +1,322 @@
+package retriever
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+// BlockView holds the header of a block together with its extrinsics, which are joined with the events
+// that were emitted while applying them.
+type BlockView struct {
+	Number      uint64
+	Hash        types.Hash
+	SpecVersion types.U32
+	Header      types.Header
+
+	// Author is the account ID of the block author, as found via the pre-runtime digest of the block.
+	// It is nil if the author cannot be determined, for example, if the runtime has no Session pallet.
+	Author *types.AccountID
+
+	// Timestamp is the time that was set by the Timestamp.set inherent, it is zero if there is no such inherent.
+	Timestamp time.Time
+
+	Extrinsics []*ExtrinsicView
+
+	// InitializationEvents holds the events emitted during the initialization of the block.
+	InitializationEvents []*parser.Event
+
+	// FinalizationEvents holds the events emitted during the finalization of the block.
+	FinalizationEvents []*parser.Event
+}
+
+// ExtrinsicView holds a decoded extrinsic together with its events and outcome.
+type ExtrinsicView struct {
+	Index     uint32
+	Hash      types.Hash
+	Extrinsic *registry.DecodedExtrinsic
+
+	// Signer is the account ID of the signer of the extrinsic. It is nil for unsigned extrinsics and for
+	// addresses that are not account IDs.
+	Signer *types.AccountID
+
+	// Call holds the decoded call of the extrinsic, which can be used for walking the calls nested in it.
+	Call *registry.DecodedCall
+
+	// Events holds the events emitted during the application of the extrinsic.
+	Events []*parser.Event
+
+	// Success is true if the extrinsic emitted a System.ExtrinsicSuccess event.
+	Success bool
+
+	// DispatchError holds the dispatch error, if the extrinsic failed.
+	DispatchError *registry.DispatchError
+
+	// FeePaid is the actual fee paid for the extrinsic, as emitted in the TransactionPayment.TransactionFeePaid
+	// event. It is zero if the event was not emitted, e.g. for unsigned extrinsics.
+	FeePaid types.U128
+}
+
+// Name returns the full name of the call, in the format <Pallet>.<Call>.
+func (e *ExtrinsicView) Name() string {
+	return e.Call.Name()
+}
+
+// Err returns nil if the extrinsic was successful, the dispatch error if the extrinsic failed,
+// or ErrExtrinsicFailed if the dispatch error is not available.
+func (e *ExtrinsicView) Err() error {
+	if e.Success {
+		return nil
+	}
+
+	if e.DispatchError != nil {
+		return e.DispatchError
+	}
+
+	return ErrExtrinsicFailed
+}
+
+const (
+	timestampFieldName   = "now"
+	timestampSetCallName = "Timestamp.set"
+)
+
+const (
+	babeEngineID = "BABE"
+	auraEngineID = "aura"
+	powEngineID  = "pow_"
+
+	multiAddressIDVariantName = "Id"
+)
+
+// newBlockView creates a BlockView for the provided block data.
+//
+// The error registry is used for decoding the dispatch errors of failed extrinsics and the validators
+// are used for determining the block author.
+func newBlockView(
+	blockData *BlockData,
+	errorRegistry registry.ErrorRegistry,
+	validators []types.AccountID,
+) (*BlockView, error) {
+	header := blockData.Block.Block.Header
+
+	blockView := &BlockView{
+		Number:      blockData.Number,
+		Hash:        blockData.Hash,
+		SpecVersion: blockData.SpecVersion,
+		Header:      header,
+		Author:      getBlockAuthor(header.Digest, validators),
+	}
+
+	extrinsicEvents := make(map[uint32][]*parser.Event)
+
+	for _, event := range blockData.Events {
+		switch {
+		case event.Phase == nil:
+			continue
+		case event.Phase.IsApplyExtrinsic:
+			extrinsicEvents[event.Phase.AsApplyExtrinsic] = append(extrinsicEvents[event.Phase.AsApplyExtrinsic], event)
+		case event.Phase.IsInitialization:
+			blockView.InitializationEvents = append(blockView.InitializationEvents, event)
+		case event.Phase.IsFinalization:
+			blockView.FinalizationEvents = append(blockView.FinalizationEvents, event)
+		}
+	}
+
+	encodedExtrinsics := blockData.Block.Block.Extrinsics
+
+	if len(encodedExtrinsics) != len(blockData.Extrinsics) {
+		return nil, ErrExtrinsicDecoding.WithMsg(
+			"expected %d decoded extrinsics, got %d",
+			len(encodedExtrinsics),
+			len(blockData.Extrinsics),
+		)
+	}
+
+	for i, decodedExtrinsic := range blockData.Extrinsics {
+		extrinsicIndex := uint32(i)
+
+		extrinsicView, err := newExtrinsicView(
+			extrinsicIndex,
+			encodedExtrinsics[i],
+			decodedExtrinsic,
+			extrinsicEvents[extrinsicIndex],
+			errorRegistry,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if extrinsicView.Name() == timestampSetCallName {
+			timestamp, err := getTimestamp(extrinsicView.Call.Fields)
+
+			if err != nil {
+				return nil, ErrTimestampDecoding.Wrap(err)
+			}
+
+			blockView.Timestamp = timestamp
+		}
+
+		blockView.Extrinsics = append(blockView.Extrinsics, extrinsicView)
+	}
+
+	return blockView, nil
+}
+
+// newExtrinsicView creates an ExtrinsicView and determines the outcome and the fee paid of the extrinsic
+// based on its events.
+func newExtrinsicView(
+	index uint32,
+	encodedExtrinsic string,
+	decodedExtrinsic *registry.DecodedExtrinsic,
+	events []*parser.Event,
+	errorRegistry registry.ErrorRegistry,
+) (*ExtrinsicView, error) {
+	extrinsicBytes, err := codec.HexDecodeString(encodedExtrinsic)
+
+	if err != nil {
+		return nil, ErrExtrinsicHashing.WithMsg("extrinsic #%d", index).Wrap(err)
+	}
+
+	extrinsicView := &ExtrinsicView{
+		Index:     index,
+		Hash:      blake2b.Sum256(extrinsicBytes),
+		Extrinsic: decodedExtrinsic,
+		Events:    events,
+	}
+
+	call, err := decodedExtrinsic.GetCall()
+
+	if err != nil {
+		return nil, ErrUnexpectedCallValue.WithMsg("extrinsic #%d", index).Wrap(err)
+	}
+
+	extrinsicView.Call = call
+
+	if decodedExtrinsic.IsSigned() {
+		extrinsicView.Signer = getSigner(decodedExtrinsic)
+	}
+
+	outcome, err := parser.GetExtrinsicOutcome(events, errorRegistry)
+
+	if err != nil {
+		return nil, ErrExtrinsicOutcomeRetrieval.WithMsg("extrinsic #%d", index).Wrap(err)
+	}
+
+	extrinsicView.Success = outcome.Success
+	extrinsicView.DispatchError = outcome.DispatchError
+	extrinsicView.FeePaid = outcome.FeePaid
+
+	return extrinsicView, nil
+}
+
+// getSigner returns the account ID found in the address of a signed extrinsic, which is either a
+// MultiAddress::Id or an account ID, depending on the runtime.
+func getSigner(decodedExtrinsic *registry.DecodedExtrinsic) *types.AccountID {
+	for _, field := range decodedExtrinsic.DecodedFields {
+		if field.Name != registry.ExtrinsicAddressName {
+			continue
+		}
+
+		value := field.Value
+
+		if addressVariant, ok := value.(*registry.DecodedVariant); ok {
+			if !addressVariant.Is(multiAddressIDVariantName) {
+				return nil
+			}
+
+			value = addressVariant.Fields
+		}
+
+		var accountID types.AccountID
+
+		if err := registry.UnmarshalValue(value, &accountID); err != nil {
+			return nil
+		}
+
+		return &accountID
+	}
+
+	return nil
+}
+
+// getTimestamp returns the time found in the fields of the Timestamp.set call, which holds
+// the number of milliseconds since the Unix epoch.
+func getTimestamp(callFields registry.DecodedFields) (time.Time, error) {
+	var call struct {
+		Now *uint64 `scale:"now"`
+	}
+
+	if err := registry.Unmarshal(callFields, &call); err != nil {
+		return time.Time{}, err
+	}
+
+	if call.Now == nil {
+		return time.Time{}, registry.ErrDecodedFieldNotFound.WithMsg("field '%s'", timestampFieldName)
+	}
+
+	return time.UnixMilli(int64(*call.Now)).UTC(), nil
+}
+
+// getBlockAuthor returns the block author that is found via the pre-runtime digest of the block, as follows:
+//
+//   - BABE - the authority index of the pre-digest is used as the index of the validator;
+//   - Aura - the slot modulo the number of validators is used as the index of the validator;
+//   - PoW - the pre-runtime digest holds the account ID of the author.
+func getBlockAuthor(digest types.Digest, validators []types.AccountID) *types.AccountID {
+	for _, digestItem := range digest {
+		if !digestItem.IsPreRuntime {
+			continue
+		}
+
+		preRuntime := digestItem.AsPreRuntime
+
+		switch getConsensusEngine(preRuntime.ConsensusEngineID) {
+		case babeEngineID:
+			// The BABE pre-digest is a variant where all variants start with the authority index.
+			if len(preRuntime.Bytes) < 5 {
+				continue
+			}
+
+			authorityIndex := binary.LittleEndian.Uint32(preRuntime.Bytes[1:5])
+
+			if int(authorityIndex) >= len(validators) {
+				continue
+			}
+
+			return &validators[authorityIndex]
+		case auraEngineID:
+			if len(preRuntime.Bytes) < 8 || len(validators) == 0 {
+				continue
+			}
+
+			slot := binary.LittleEndian.Uint64(preRuntime.Bytes[:8])
+
+			return &validators[slot%uint64(len(validators))]
+		case powEngineID:
+			accountID, err := types.NewAccountID(preRuntime.Bytes)
+
+			if err != nil {
+				continue
+			}
+
+			return accountID
+		}
+	}
+
+	return nil
+}
+
+func getConsensusEngine(consensusEngineID types.ConsensusEngineID) string {
+	b := make([]byte, 4)
+
+	binary.LittleEndian.PutUint32(b, uint32(consensusEngineID))
+
+	return string(b)
+}
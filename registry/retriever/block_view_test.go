@@ -0,0 +1,317 @@
+package retriever
+
+import (
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/cache"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// testTimestamp is the time set by the Timestamp.set extrinsic of the test block.
+	testTimestamp = 1_700_000_000_000
+
+	// testRemarkExtrinsic is a System.remark extrinsic signed by Bob, taken from a Centrifuge development chain.
+	//nolint:lll
+	testRemarkExtrinsic = "0xb10184008eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a480118346322ed93ad7d2583ab3e4b71acd66cc1fce77cb225624c8eb00977681468aec33b933606ed8c2eaa75b84278c42415d491f89c5e79db6910986c1b95f486e401e0000000000431"
+
+	// testBalancesModuleIndex is the index of the Balances pallet in the Centrifuge metadata.
+	testBalancesModuleIndex = 20
+)
+
+var (
+	testBob = types.AccountID(codec.MustHexDecodeString(
+		"0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48",
+	))
+
+	testValidators = []types.AccountID{{1}, {2}, {3}}
+)
+
+func getTestCentrifugeEntry(t *testing.T) *cache.Entry {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	entry, err := cache.NewMetadataCache(stateMocks.NewState(t), registry.NewFactory()).AddMetadata(1, &meta)
+	assert.NoError(t, err)
+
+	return entry
+}
+
+// getTestTimestampExtrinsic returns an unsigned Timestamp.set extrinsic.
+func getTestTimestampExtrinsic(t *testing.T, entry *cache.Entry) string {
+	callIndex, err := entry.Metadata.FindCallIndex("Timestamp.set")
+	assert.NoError(t, err)
+
+	now, err := codec.Encode(types.NewUCompactFromUInt(testTimestamp))
+	assert.NoError(t, err)
+
+	extrinsicBytes := append([]byte{4, callIndex.SectionIndex, callIndex.MethodIndex}, now...)
+
+	extrinsicLength, err := codec.Encode(types.NewUCompactFromUInt(uint64(len(extrinsicBytes))))
+	assert.NoError(t, err)
+
+	return codec.HexEncodeToString(append(extrinsicLength, extrinsicBytes...))
+}
+
+// getTestAuraDigest returns a digest with an Aura pre-runtime item for the provided slot.
+func getTestAuraDigest(slot uint64) types.Digest {
+	b := make([]byte, 8)
+
+	binary.LittleEndian.PutUint64(b, slot)
+
+	return types.Digest{
+		{
+			IsPreRuntime: true,
+			AsPreRuntime: types.PreRuntime{
+				ConsensusEngineID: getTestConsensusEngineID(auraEngineID),
+				Bytes:             b,
+			},
+		},
+	}
+}
+
+func getTestConsensusEngineID(engine string) types.ConsensusEngineID {
+	return types.ConsensusEngineID(binary.LittleEndian.Uint32([]byte(engine)))
+}
+
+// getTestBlockData returns the data of a block with a successful Timestamp.set extrinsic and
+// a failed System.remark extrinsic.
+func getTestBlockData(t *testing.T, entry *cache.Entry) *BlockData {
+	signedBlock := &block.SignedBlock{
+		Block: block.Block{
+			Header: types.Header{
+				ParentHash: types.Hash{1},
+				Number:     123,
+				Digest:     getTestAuraDigest(5),
+			},
+			Extrinsics: []string{
+				getTestTimestampExtrinsic(t, entry),
+				testRemarkExtrinsic,
+			},
+		},
+	}
+
+	extrinsicDecoder, err := entry.GetExtrinsicDecoder()
+	assert.NoError(t, err)
+
+	extrinsics, err := signedBlock.DecodeExtrinsics(extrinsicDecoder)
+	assert.NoError(t, err)
+
+	return &BlockData{
+		Number:      123,
+		Hash:        types.Hash{2},
+		SpecVersion: entry.SpecVersion,
+		Block:       signedBlock,
+		Extrinsics:  extrinsics,
+		Events:      getTestBlockEvents(),
+	}
+}
+
+func getTestBlockEvents() []*parser.Event {
+	return []*parser.Event{
+		{
+			Name:  "ParachainSystem.ValidationFunctionStored",
+			Phase: &types.Phase{IsInitialization: true},
+		},
+		{
+			Name:  "System.ExtrinsicSuccess",
+			Phase: &types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 0},
+		},
+		{
+			Name:  "Balances.Withdraw",
+			Phase: &types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1},
+		},
+		{
+			Name: "TransactionPayment.TransactionFeePaid",
+			Fields: registry.DecodedFields{
+				{Name: "sp_core.crypto.AccountId32.who", Value: registry.DecodedFields{}},
+				{Name: "actual_fee", Value: types.NewU128(*big.NewInt(1_000))},
+				{Name: "tip", Value: types.NewU128(*big.NewInt(0))},
+			},
+			Phase: &types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1},
+		},
+		{
+			Name: "System.ExtrinsicFailed",
+			Fields: registry.DecodedFields{
+				{
+					Name: "sp_runtime.DispatchError.dispatch_error",
+					Value: &registry.DecodedVariant{
+						Index: 3,
+						Name:  "Module",
+						Fields: registry.DecodedFields{
+							{
+								Name: "sp_runtime.ModuleError",
+								Value: registry.DecodedFields{
+									{Name: "index", Value: types.U8(testBalancesModuleIndex)},
+									{Name: "error", Value: []any{types.U8(2), types.U8(0), types.U8(0), types.U8(0)}},
+								},
+							},
+						},
+					},
+				},
+			},
+			Phase: &types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1},
+		},
+		{
+			Name:  "Collator.Finalized",
+			Phase: &types.Phase{IsFinalization: true},
+		},
+	}
+}
+
+func TestNewBlockView(t *testing.T) {
+	entry := getTestCentrifugeEntry(t)
+
+	blockData := getTestBlockData(t, entry)
+
+	errorRegistry, err := entry.GetErrorRegistry()
+	assert.NoError(t, err)
+
+	res, err := newBlockView(blockData, errorRegistry, testValidators)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(123), res.Number)
+	assert.Equal(t, blockData.Hash, res.Hash)
+	assert.Equal(t, entry.SpecVersion, res.SpecVersion)
+	assert.Equal(t, blockData.Block.Block.Header, res.Header)
+	assert.Equal(t, &testValidators[2], res.Author)
+	assert.Equal(t, time.UnixMilli(testTimestamp).UTC(), res.Timestamp)
+	assert.Equal(t, blockData.Events[:1], res.InitializationEvents)
+	assert.Equal(t, blockData.Events[5:], res.FinalizationEvents)
+	assert.Len(t, res.Extrinsics, 2)
+
+	timestampExtrinsic := res.Extrinsics[0]
+
+	assert.Equal(t, uint32(0), timestampExtrinsic.Index)
+	assert.Equal(t, "Timestamp.set", timestampExtrinsic.Name())
+	assert.Nil(t, timestampExtrinsic.Signer)
+	assert.True(t, timestampExtrinsic.Success)
+	assert.NoError(t, timestampExtrinsic.Err())
+	assert.Equal(t, blockData.Events[1:2], timestampExtrinsic.Events)
+	assert.Zero(t, timestampExtrinsic.FeePaid)
+
+	remarkExtrinsic := res.Extrinsics[1]
+
+	assert.Equal(t, uint32(1), remarkExtrinsic.Index)
+	assert.Equal(t, blake2b.Sum256(codec.MustHexDecodeString(testRemarkExtrinsic)), [32]byte(remarkExtrinsic.Hash))
+	assert.Equal(t, blockData.Extrinsics[1], remarkExtrinsic.Extrinsic)
+	assert.Equal(t, &testBob, remarkExtrinsic.Signer)
+	assert.Equal(t, blockData.Extrinsics[1].CallIndex, remarkExtrinsic.Call.CallIndex)
+	assert.Equal(t, "System", remarkExtrinsic.Call.PalletName)
+	assert.Equal(t, "remark", remarkExtrinsic.Call.CallName)
+	assert.Len(t, remarkExtrinsic.Call.Fields, 1)
+	assert.Equal(t, blockData.Events[2:5], remarkExtrinsic.Events)
+	assert.False(t, remarkExtrinsic.Success)
+	assert.Equal(t, types.NewU128(*big.NewInt(1_000)), remarkExtrinsic.FeePaid)
+	assert.ErrorIs(
+		t,
+		remarkExtrinsic.Err(),
+		&registry.ModuleError{PalletName: "Balances", ErrorName: "InsufficientBalance"},
+	)
+}
+
+func TestNewBlockView_Errors(t *testing.T) {
+	entry := getTestCentrifugeEntry(t)
+
+	errorRegistry, err := entry.GetErrorRegistry()
+	assert.NoError(t, err)
+
+	// Missing outcome.
+
+	blockData := getTestBlockData(t, entry)
+	blockData.Events = blockData.Events[:1]
+
+	res, err := newBlockView(blockData, errorRegistry, testValidators)
+	assert.ErrorIs(t, err, ErrExtrinsicOutcomeNotFound)
+	assert.Nil(t, res)
+
+	// Unknown module error.
+
+	res, err = newBlockView(getTestBlockData(t, entry), registry.ErrorRegistry{}, testValidators)
+	assert.ErrorIs(t, err, ErrExtrinsicOutcomeRetrieval)
+	assert.ErrorIs(t, err, ErrModuleErrorDecoding)
+	assert.Nil(t, res)
+
+	// Invalid fee paid.
+
+	blockData = getTestBlockData(t, entry)
+	blockData.Events[3].Fields[1].Value = types.U8(0)
+
+	res, err = newBlockView(blockData, errorRegistry, testValidators)
+	assert.ErrorIs(t, err, ErrFeePaidDecoding)
+	assert.Nil(t, res)
+
+	// Mismatched extrinsics.
+
+	blockData = getTestBlockData(t, entry)
+	blockData.Extrinsics = blockData.Extrinsics[:1]
+
+	res, err = newBlockView(blockData, errorRegistry, testValidators)
+	assert.ErrorIs(t, err, ErrExtrinsicDecoding)
+	assert.Nil(t, res)
+
+	// Invalid extrinsic hex.
+
+	blockData = getTestBlockData(t, entry)
+	blockData.Block.Block.Extrinsics[1] = "invalid"
+
+	res, err = newBlockView(blockData, errorRegistry, testValidators)
+	assert.ErrorIs(t, err, ErrExtrinsicHashing)
+	assert.Nil(t, res)
+}
+
+func TestGetBlockAuthor(t *testing.T) {
+	babeDigest := types.Digest{
+		{
+			IsPreRuntime: true,
+			AsPreRuntime: types.PreRuntime{
+				ConsensusEngineID: getTestConsensusEngineID(babeEngineID),
+				// SecondaryPlain pre-digest with authority index 1 and slot 2.
+				Bytes: []byte{2, 1, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+
+	powDigest := types.Digest{
+		{
+			IsPreRuntime: true,
+			AsPreRuntime: types.PreRuntime{
+				ConsensusEngineID: getTestConsensusEngineID(powEngineID),
+				Bytes:             testBob[:],
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		digest     types.Digest
+		validators []types.AccountID
+		expected   *types.AccountID
+	}{
+		{"BABE", babeDigest, testValidators, &testValidators[1]},
+		{"BABE without validators", babeDigest, nil, nil},
+		{"Aura", getTestAuraDigest(7), testValidators, &testValidators[1]},
+		{"Aura without validators", getTestAuraDigest(7), nil, nil},
+		{"PoW", powDigest, nil, &testBob},
+		{"no pre-runtime digest", types.Digest{{IsSeal: true}}, testValidators, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getBlockAuthor(tt.digest, tt.validators))
+		})
+	}
+}
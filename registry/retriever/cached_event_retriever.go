@@ -0,0 +1,101 @@
+package retriever
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/cache"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	regState "github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// cachedEventRetriever implements the EventRetriever interface using a cache.MetadataCache.
+//
+// Unlike eventRetriever, it does not hold a single metadata and event registry, the ones that match
+// the spec version of each block are retrieved from the cache, which allows retrieving events from
+// different runtime versions concurrently.
+type cachedEventRetriever struct {
+	eventParser parser.EventParser
+
+	eventProvider regState.EventProvider
+
+	metadataCache cache.MetadataCache
+
+	eventStorageExecutor exec.RetryableExecutor[*types.StorageDataRaw]
+}
+
+// NewCachedEventRetriever creates a new EventRetriever that uses the provided cache.MetadataCache.
+func NewCachedEventRetriever(
+	eventParser parser.EventParser,
+	eventProvider regState.EventProvider,
+	metadataCache cache.MetadataCache,
+	eventStorageExecutor exec.RetryableExecutor[*types.StorageDataRaw],
+) EventRetriever {
+	return &cachedEventRetriever{
+		eventParser:          eventParser,
+		eventProvider:        eventProvider,
+		metadataCache:        metadataCache,
+		eventStorageExecutor: eventStorageExecutor,
+	}
+}
+
+// NewDefaultCachedEventRetriever creates a new EventRetriever that uses the provided cache.MetadataCache
+// and defaults for:
+//
+// - parser.EventParser
+// - exec.RetryableExecutor - used for retrieving event storage data.
+func NewDefaultCachedEventRetriever(
+	eventProvider regState.EventProvider,
+	metadataCache cache.MetadataCache,
+) EventRetriever {
+	eventParser := parser.NewEventParser()
+
+	eventStorageExecutor := exec.NewRetryableExecutor[*types.StorageDataRaw](exec.WithRetryTimeout(1 * time.Second))
+
+	return NewCachedEventRetriever(eventParser, eventProvider, metadataCache, eventStorageExecutor)
+}
+
+// GetEvents retrieves the cache.Entry for the spec version of the block, the storage data for an Event
+// and then parses it.
+func (e *cachedEventRetriever) GetEvents(blockHash types.Hash) ([]*parser.Event, error) {
+	return e.GetEventsCtx(context.Background(), blockHash)
+}
+
+// GetEventsCtx is like GetEvents, the RPC requests and the retries are bound to the provided context.
+func (e *cachedEventRetriever) GetEventsCtx(ctx context.Context, blockHash types.Hash) ([]*parser.Event, error) {
+	entry, err := e.metadataCache.GetEntryCtx(ctx, blockHash)
+
+	if err != nil {
+		return nil, ErrMetadataCacheEntryRetrieval.Wrap(err)
+	}
+
+	eventRegistry, err := entry.GetEventRegistry()
+
+	if err != nil {
+		return nil, ErrEventRegistryCreation.Wrap(err)
+	}
+
+	storageEvents, err := e.eventStorageExecutor.ExecWithFallbackCtx(
+		ctx,
+		func() (*types.StorageDataRaw, error) {
+			return e.eventProvider.GetStorageEventsCtx(ctx, entry.Metadata, blockHash)
+		},
+		func() error {
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, ErrStorageEventRetrieval.Wrap(err)
+	}
+
+	events, err := e.eventParser.ParseEvents(eventRegistry, storageEvents)
+
+	if err != nil {
+		return nil, ErrEventParsing.Wrap(err)
+	}
+
+	return events, nil
+}
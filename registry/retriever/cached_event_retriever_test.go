@@ -0,0 +1,169 @@
+package retriever
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/cache"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCachedEventRetriever_NewDefault(t *testing.T) {
+	eventProviderMock := state.NewEventProviderMock(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+
+	res := NewDefaultCachedEventRetriever(eventProviderMock, metadataCacheMock)
+	assert.IsType(t, &cachedEventRetriever{}, res)
+
+	retriever := res.(*cachedEventRetriever)
+	assert.IsType(t, parser.NewEventParser(), retriever.eventParser)
+	assert.IsType(t, exec.NewRetryableExecutor[*types.StorageDataRaw](), retriever.eventStorageExecutor)
+	assert.Equal(t, metadataCacheMock, retriever.metadataCache)
+}
+
+func TestCachedEventRetriever_GetEvents(t *testing.T) {
+	eventParserMock := parser.NewEventParserMock(t)
+	eventProviderMock := state.NewEventProviderMock(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	retriever := NewCachedEventRetriever(
+		eventParserMock,
+		eventProviderMock,
+		metadataCacheMock,
+		exec.NewRetryableExecutor[*types.StorageDataRaw](exec.WithMaxRetryCount(1), exec.WithRetryTimeout(time.Millisecond)),
+	)
+
+	entries := map[types.U32]*cache.Entry{}
+	eventRegistries := map[types.U32]registry.EventRegistry{}
+
+	for _, specVersion := range []types.U32{1, 2} {
+		meta := &types.Metadata{Version: uint8(specVersion)}
+
+		entry, err := cache.NewMetadataCache(stateMocks.NewState(t), registryFactoryMock).
+			AddMetadata(specVersion, meta)
+		assert.NoError(t, err)
+
+		eventRegistry := registry.EventRegistry{
+			types.EventID{byte(specVersion)}: &registry.TypeDecoder{},
+		}
+
+		registryFactoryMock.On("CreateEventRegistry", meta).
+			Return(eventRegistry, nil).
+			Once()
+
+		entries[specVersion] = entry
+		eventRegistries[specVersion] = eventRegistry
+	}
+
+	// Blocks from different spec versions are decoded using the registry of their spec version.
+	for i, specVersion := range []types.U32{1, 2, 1} {
+		blockHash := types.NewHash([]byte{byte(i)})
+
+		entry := entries[specVersion]
+
+		metadataCacheMock.On("GetEntryCtx", mock.Anything, blockHash).
+			Return(entry, nil).
+			Once()
+
+		storageEvents := &types.StorageDataRaw{byte(i)}
+
+		eventProviderMock.On("GetStorageEventsCtx", mock.Anything, entry.Metadata, blockHash).
+			Return(storageEvents, nil).
+			Once()
+
+		events := []*parser.Event{{Name: "event"}}
+
+		eventParserMock.On("ParseEvents", eventRegistries[specVersion], storageEvents).
+			Return(events, nil).
+			Once()
+
+		res, err := retriever.GetEvents(blockHash)
+		assert.NoError(t, err)
+		assert.Equal(t, events, res)
+	}
+}
+
+func TestCachedEventRetriever_GetEvents_Errors(t *testing.T) {
+	eventParserMock := parser.NewEventParserMock(t)
+	eventProviderMock := state.NewEventProviderMock(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	retriever := NewCachedEventRetriever(
+		eventParserMock,
+		eventProviderMock,
+		metadataCacheMock,
+		exec.NewRetryableExecutor[*types.StorageDataRaw](exec.WithMaxRetryCount(1), exec.WithRetryTimeout(time.Millisecond)),
+	)
+
+	blockHash := types.NewHash([]byte{1})
+
+	metadataCacheError := errors.New("error")
+
+	metadataCacheMock.On("GetEntryCtx", mock.Anything, blockHash).
+		Return(nil, metadataCacheError).
+		Once()
+
+	res, err := retriever.GetEvents(blockHash)
+	assert.ErrorIs(t, err, ErrMetadataCacheEntryRetrieval)
+	assert.Nil(t, res)
+
+	meta := &types.Metadata{}
+
+	entry, err := cache.NewMetadataCache(stateMocks.NewState(t), registryFactoryMock).AddMetadata(1, meta)
+	assert.NoError(t, err)
+
+	metadataCacheMock.On("GetEntryCtx", mock.Anything, blockHash).
+		Return(entry, nil)
+
+	registryFactoryError := errors.New("error")
+
+	registryFactoryMock.On("CreateEventRegistry", meta).
+		Return(nil, registryFactoryError).
+		Once()
+
+	res, err = retriever.GetEvents(blockHash)
+	assert.ErrorIs(t, err, ErrEventRegistryCreation)
+	assert.Nil(t, res)
+
+	eventRegistry := registry.EventRegistry{}
+
+	registryFactoryMock.On("CreateEventRegistry", meta).
+		Return(eventRegistry, nil).
+		Once()
+
+	storageError := errors.New("error")
+
+	eventProviderMock.On("GetStorageEventsCtx", mock.Anything, meta, blockHash).
+		Return(nil, storageError).
+		Twice()
+
+	res, err = retriever.GetEvents(blockHash)
+	assert.ErrorIs(t, err, ErrStorageEventRetrieval)
+	assert.Nil(t, res)
+
+	storageEvents := &types.StorageDataRaw{}
+
+	eventProviderMock.On("GetStorageEventsCtx", mock.Anything, meta, blockHash).
+		Return(storageEvents, nil).
+		Once()
+
+	parsingError := errors.New("error")
+
+	eventParserMock.On("ParseEvents", eventRegistry, storageEvents).
+		Return(nil, parsingError).
+		Once()
+
+	res, err = retriever.GetEvents(blockHash)
+	assert.ErrorIs(t, err, ErrEventParsing)
+	assert.Nil(t, res)
+}
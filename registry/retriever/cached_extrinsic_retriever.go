@@ -0,0 +1,98 @@
+package retriever
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/cache"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+)
+
+// cachedExtrinsicRetriever implements the ExtrinsicRetriever interface using a cache.MetadataCache.
+//
+// The extrinsic decoder that matches the spec version of each block is retrieved from the cache, which allows
+// retrieving extrinsics from different runtime versions concurrently.
+type cachedExtrinsicRetriever struct {
+	chainRPC chain.Chain
+
+	metadataCache cache.MetadataCache
+
+	chainExecutor exec.RetryableExecutor[*block.SignedBlock]
+}
+
+// NewCachedExtrinsicRetriever creates a new ExtrinsicRetriever that uses the provided cache.MetadataCache.
+func NewCachedExtrinsicRetriever(
+	chainRPC chain.Chain,
+	metadataCache cache.MetadataCache,
+	chainExecutor exec.RetryableExecutor[*block.SignedBlock],
+) ExtrinsicRetriever {
+	return &cachedExtrinsicRetriever{
+		chainRPC:      chainRPC,
+		metadataCache: metadataCache,
+		chainExecutor: chainExecutor,
+	}
+}
+
+// NewDefaultCachedExtrinsicRetriever returns an ExtrinsicRetriever that uses the provided cache.MetadataCache
+// and a default executor.
+func NewDefaultCachedExtrinsicRetriever(
+	chainRPC chain.Chain,
+	metadataCache cache.MetadataCache,
+) ExtrinsicRetriever {
+	chainExecutor := exec.NewRetryableExecutor[*block.SignedBlock](exec.WithRetryTimeout(1 * time.Second))
+
+	return NewCachedExtrinsicRetriever(chainRPC, metadataCache, chainExecutor)
+}
+
+// GetExtrinsics retrieves the generic.SignedBlock, the cache.Entry for the spec version of the block
+// and then decodes the extrinsics found in it.
+//
+// The block number is used when retrieving the cache.Entry, which allows the cache to consult its
+// cache.SpecVersionLookup instead of resolving the runtime version of the block.
+func (e *cachedExtrinsicRetriever) GetExtrinsics(blockHash types.Hash) ([]*registry.DecodedExtrinsic, error) {
+	return e.GetExtrinsicsCtx(context.Background(), blockHash)
+}
+
+// GetExtrinsicsCtx is like GetExtrinsics, the RPC requests and the retries are bound to the provided context.
+func (e *cachedExtrinsicRetriever) GetExtrinsicsCtx(
+	ctx context.Context,
+	blockHash types.Hash,
+) ([]*registry.DecodedExtrinsic, error) {
+	block, err := e.chainExecutor.ExecWithFallbackCtx(
+		ctx,
+		func() (*block.SignedBlock, error) {
+			return e.chainRPC.GetBlockCtx(ctx, blockHash)
+		},
+		func() error {
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, ErrBlockRetrieval.Wrap(err)
+	}
+
+	entry, err := e.metadataCache.GetEntryForBlockCtx(ctx, uint64(block.Block.Header.Number), blockHash)
+
+	if err != nil {
+		return nil, ErrMetadataCacheEntryRetrieval.Wrap(err)
+	}
+
+	extrinsicDecoder, err := entry.GetExtrinsicDecoder()
+
+	if err != nil {
+		return nil, ErrExtrinsicDecoderCreation.Wrap(err)
+	}
+
+	calls, err := block.DecodeExtrinsics(extrinsicDecoder)
+
+	if err != nil {
+		return nil, ErrExtrinsicDecoding.Wrap(err)
+	}
+
+	return calls, nil
+}
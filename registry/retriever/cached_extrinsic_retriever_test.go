@@ -0,0 +1,158 @@
+package retriever
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/cache"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCachedExtrinsicRetriever_NewDefault(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+
+	res := NewDefaultCachedExtrinsicRetriever(chainRPCMock, metadataCacheMock)
+	assert.IsType(t, &cachedExtrinsicRetriever{}, res)
+
+	retriever := res.(*cachedExtrinsicRetriever)
+	assert.IsType(t, exec.NewRetryableExecutor[*block.SignedBlock](), retriever.chainExecutor)
+	assert.Equal(t, metadataCacheMock, retriever.metadataCache)
+}
+
+func TestCachedExtrinsicRetriever_GetExtrinsics(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+
+	retriever := NewCachedExtrinsicRetriever(
+		chainRPCMock,
+		metadataCacheMock,
+		exec.NewRetryableExecutor[*block.SignedBlock](exec.WithMaxRetryCount(1), exec.WithRetryTimeout(time.Millisecond)),
+	)
+
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	entry, err := cache.NewMetadataCache(stateMocks.NewState(t), registry.NewFactory()).AddMetadata(1, &meta)
+	assert.NoError(t, err)
+
+	blockHash := types.Hash{1, 2, 3, 4}
+
+	metadataCacheMock.On("GetEntryForBlockCtx", mock.Anything, uint64(480514), blockHash).
+		Return(entry, nil).
+		Once()
+
+	// NOTE - The following test relies on the same Centrifuge development chain data that is used in
+	// TestExtrinsicRetriever_GetExtrinsics.
+	encodedExtrinsic := "0xb10184008eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a480118346322ed93ad7d2583ab3e4b71acd66cc1fce77cb225624c8eb00977681468aec33b933606ed8c2eaa75b84278c42415d491f89c5e79db6910986c1b95f486e401e0000000000431"
+
+	testBlock := &block.SignedBlock{
+		Block: block.Block{
+			Header: types.Header{
+				Number: 480514,
+			},
+			Extrinsics: []string{
+				encodedExtrinsic,
+			},
+		},
+	}
+
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
+		Return(testBlock, nil).
+		Once()
+
+	extrinsicDecoder, err := entry.GetExtrinsicDecoder()
+	assert.NoError(t, err)
+
+	decodedExtrinsics, err := testBlock.DecodeExtrinsics(extrinsicDecoder)
+	assert.NoError(t, err)
+
+	res, err := retriever.GetExtrinsics(blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, decodedExtrinsics, res)
+	assert.Len(t, res, 1)
+}
+
+func TestCachedExtrinsicRetriever_GetExtrinsics_Errors(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	metadataCacheMock := cache.NewMetadataCacheMock(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	retriever := NewCachedExtrinsicRetriever(
+		chainRPCMock,
+		metadataCacheMock,
+		exec.NewRetryableExecutor[*block.SignedBlock](exec.WithMaxRetryCount(1), exec.WithRetryTimeout(time.Millisecond)),
+	)
+
+	blockHash := types.Hash{1, 2, 3, 4}
+
+	blockRetrievalError := errors.New("error")
+
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
+		Return(nil, blockRetrievalError).
+		Twice()
+
+	res, err := retriever.GetExtrinsics(blockHash)
+	assert.ErrorIs(t, err, ErrBlockRetrieval)
+	assert.Nil(t, res)
+
+	testBlock := &block.SignedBlock{
+		Block: block.Block{
+			Header: types.Header{
+				Number: 1,
+			},
+			Extrinsics: []string{"0x00"},
+		},
+	}
+
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
+		Return(testBlock, nil)
+
+	metadataCacheError := errors.New("error")
+
+	metadataCacheMock.On("GetEntryForBlockCtx", mock.Anything, uint64(1), blockHash).
+		Return(nil, metadataCacheError).
+		Once()
+
+	res, err = retriever.GetExtrinsics(blockHash)
+	assert.ErrorIs(t, err, ErrMetadataCacheEntryRetrieval)
+	assert.Nil(t, res)
+
+	meta := &types.Metadata{}
+
+	entry, err := cache.NewMetadataCache(stateMocks.NewState(t), registryFactoryMock).AddMetadata(1, meta)
+	assert.NoError(t, err)
+
+	metadataCacheMock.On("GetEntryForBlockCtx", mock.Anything, uint64(1), blockHash).
+		Return(entry, nil)
+
+	registryFactoryError := errors.New("error")
+
+	registryFactoryMock.On("CreateExtrinsicDecoder", meta).
+		Return(nil, registryFactoryError).
+		Once()
+
+	res, err = retriever.GetExtrinsics(blockHash)
+	assert.ErrorIs(t, err, ErrExtrinsicDecoderCreation)
+	assert.Nil(t, res)
+
+	registryFactoryMock.On("CreateExtrinsicDecoder", meta).
+		Return(&registry.ExtrinsicDecoder{}, nil).
+		Once()
+
+	res, err = retriever.GetExtrinsics(blockHash)
+	assert.ErrorIs(t, err, ErrExtrinsicDecoding)
+	assert.Nil(t, res)
+}
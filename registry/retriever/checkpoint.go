@@ -0,0 +1,79 @@
+package retriever
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//go:generate mockery --name Checkpoint --structname CheckpointMock --filename checkpoint_mock.go --inpackage
+
+// Checkpoint is the interface used for storing the last block that was delivered by a BlockRangeRetriever,
+// which allows resuming a stream.
+type Checkpoint interface {
+	// Load returns the stored block number, if any.
+	Load() (blockNumber uint64, ok bool, err error)
+	// Save stores the provided block number.
+	Save(blockNumber uint64) error
+}
+
+// fileCheckpoint implements the Checkpoint interface by storing the block number in a file.
+type fileCheckpoint struct {
+	path string
+}
+
+// NewFileCheckpoint creates a new Checkpoint that stores the block number in the file found at the provided path.
+func NewFileCheckpoint(path string) Checkpoint {
+	return &fileCheckpoint{path: path}
+}
+
+// Load reads the block number from the file, if it exists.
+func (f *fileCheckpoint) Load() (uint64, bool, error) {
+	b, err := os.ReadFile(f.path)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, false, nil
+		}
+
+		return 0, false, ErrCheckpointLoading.Wrap(err)
+	}
+
+	blockNumber, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+
+	if err != nil {
+		return 0, false, ErrCheckpointLoading.Wrap(err)
+	}
+
+	return blockNumber, true, nil
+}
+
+// Save writes the block number to a temporary file which is then renamed, so that an interrupted save does not
+// corrupt the previous checkpoint.
+func (f *fileCheckpoint) Save(blockNumber uint64) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+
+	if err != nil {
+		return ErrCheckpointSaving.Wrap(err)
+	}
+
+	defer os.Remove(tmpFile.Name()) //nolint:errcheck
+
+	if _, err := tmpFile.WriteString(strconv.FormatUint(blockNumber, 10)); err != nil {
+		_ = tmpFile.Close()
+
+		return ErrCheckpointSaving.Wrap(err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return ErrCheckpointSaving.Wrap(err)
+	}
+
+	if err := os.Rename(tmpFile.Name(), f.path); err != nil {
+		return ErrCheckpointSaving.Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package retriever
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+
+	checkpoint := NewFileCheckpoint(path)
+
+	blockNumber, ok, err := checkpoint.Load()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(0), blockNumber)
+
+	err = checkpoint.Save(12_345_678)
+	assert.NoError(t, err)
+
+	blockNumber, ok, err = checkpoint.Load()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(12_345_678), blockNumber)
+
+	err = checkpoint.Save(12_345_679)
+	assert.NoError(t, err)
+
+	blockNumber, ok, err = NewFileCheckpoint(path).Load()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(12_345_679), blockNumber)
+}
+
+func TestFileCheckpoint_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+
+	err := os.WriteFile(path, []byte("invalid"), 0o600)
+	assert.NoError(t, err)
+
+	_, _, err = NewFileCheckpoint(path).Load()
+	assert.ErrorIs(t, err, ErrCheckpointLoading)
+
+	_, _, err = NewFileCheckpoint(t.TempDir()).Load()
+	assert.ErrorIs(t, err, ErrCheckpointLoading)
+
+	err = NewFileCheckpoint(filepath.Join(t.TempDir(), "missing", "checkpoint")).Save(1)
+	assert.ErrorIs(t, err, ErrCheckpointSaving)
+}
@@ -1,14 +1,36 @@
 package retriever
 
-import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+import (
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+)
 
 const (
-	ErrInternalStateUpdate      = libErr.Error("internal state update")
-	ErrBlockRetrieval           = libErr.Error("block retrieval")
-	ErrExtrinsicDecoding        = libErr.Error("extrinsic parsing")
-	ErrMetadataRetrieval        = libErr.Error("metadata retrieval")
-	ErrExtrinsicDecoderCreation = libErr.Error("extrinsic decoder creation")
-	ErrStorageEventRetrieval    = libErr.Error("storage event retrieval")
-	ErrEventParsing             = libErr.Error("event parsing")
-	ErrEventRegistryCreation    = libErr.Error("event registry creation")
+	ErrInternalStateUpdate         = libErr.Error("internal state update")
+	ErrBlockRetrieval              = libErr.Error("block retrieval")
+	ErrExtrinsicDecoding           = libErr.Error("extrinsic parsing")
+	ErrMetadataRetrieval           = libErr.Error("metadata retrieval")
+	ErrExtrinsicDecoderCreation    = libErr.Error("extrinsic decoder creation")
+	ErrStorageEventRetrieval       = libErr.Error("storage event retrieval")
+	ErrEventParsing                = libErr.Error("event parsing")
+	ErrEventRegistryCreation       = libErr.Error("event registry creation")
+	ErrBlockHashRetrieval          = libErr.Error("block hash retrieval")
+	ErrBlockDataRetrieval          = libErr.Error("block data retrieval")
+	ErrFinalizedHeadRetrieval      = libErr.Error("finalized head retrieval")
+	ErrCheckpointLoading           = libErr.Error("checkpoint loading")
+	ErrCheckpointSaving            = libErr.Error("checkpoint saving")
+	ErrMetadataCacheEntryRetrieval = libErr.Error("metadata cache entry retrieval")
+	ErrErrorRegistryCreation       = libErr.Error("error registry creation")
+	ErrValidatorsRetrieval         = libErr.Error("validators retrieval")
+	ErrExtrinsicHashing            = libErr.Error("extrinsic hashing")
+	ErrUnexpectedCallValue         = libErr.Error("unexpected call value")
+	ErrExtrinsicOutcomeRetrieval   = libErr.Error("extrinsic outcome retrieval")
+	ErrTimestampDecoding           = libErr.Error("timestamp decoding")
+	ErrExtrinsicFailed             = libErr.Error("extrinsic failed")
+	ErrBlockViewRetrieval          = libErr.Error("block view retrieval")
+
+	ErrExtrinsicOutcomeNotFound = parser.ErrExtrinsicOutcomeNotFound
+	ErrDispatchErrorDecoding    = parser.ErrDispatchErrorDecoding
+	ErrModuleErrorDecoding      = parser.ErrModuleErrorDecoding
+	ErrFeePaidDecoding          = parser.ErrFeePaidDecoding
 )
@@ -1,6 +1,7 @@
 package retriever
 
 import (
+	"context"
 	"time"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
@@ -17,6 +18,7 @@ import (
 // EventRetriever is the interface used for retrieving and decoding events.
 type EventRetriever interface {
 	GetEvents(blockHash types.Hash) ([]*parser.Event, error)
+	GetEventsCtx(ctx context.Context, blockHash types.Hash) ([]*parser.Event, error)
 }
 
 // eventRetriever implements the EventRetriever interface.
@@ -53,7 +55,7 @@ func NewEventRetriever(
 		eventParsingExecutor: eventParsingExecutor,
 	}
 
-	if err := retriever.updateInternalState(nil); err != nil {
+	if err := retriever.updateInternalState(context.Background(), nil); err != nil {
 		return nil, ErrInternalStateUpdate.Wrap(err)
 	}
 
@@ -92,12 +94,18 @@ func NewDefaultEventRetriever(
 // Both the event storage data retrieval and the event parsing are handled via the exec.RetryableExecutor
 // in order to ensure retries in case of network errors or parsing errors due to an outdated event registry.
 func (e *eventRetriever) GetEvents(blockHash types.Hash) ([]*parser.Event, error) {
-	storageEvents, err := e.eventStorageExecutor.ExecWithFallback(
+	return e.GetEventsCtx(context.Background(), blockHash)
+}
+
+// GetEventsCtx is like GetEvents, the RPC requests and the retries are bound to the provided context.
+func (e *eventRetriever) GetEventsCtx(ctx context.Context, blockHash types.Hash) ([]*parser.Event, error) {
+	storageEvents, err := e.eventStorageExecutor.ExecWithFallbackCtx(
+		ctx,
 		func() (*types.StorageDataRaw, error) {
-			return e.eventProvider.GetStorageEvents(e.meta, blockHash)
+			return e.eventProvider.GetStorageEventsCtx(ctx, e.meta, blockHash)
 		},
 		func() error {
-			return e.updateInternalState(&blockHash)
+			return e.updateInternalState(ctx, &blockHash)
 		},
 	)
 
@@ -105,12 +113,13 @@ func (e *eventRetriever) GetEvents(blockHash types.Hash) ([]*parser.Event, error
 		return nil, ErrStorageEventRetrieval.Wrap(err)
 	}
 
-	events, err := e.eventParsingExecutor.ExecWithFallback(
+	events, err := e.eventParsingExecutor.ExecWithFallbackCtx(
+		ctx,
 		func() ([]*parser.Event, error) {
 			return e.eventParser.ParseEvents(e.eventRegistry, storageEvents)
 		},
 		func() error {
-			return e.updateInternalState(&blockHash)
+			return e.updateInternalState(ctx, &blockHash)
 		},
 	)
 
@@ -123,16 +132,16 @@ func (e *eventRetriever) GetEvents(blockHash types.Hash) ([]*parser.Event, error
 
 // updateInternalState will retrieve the metadata at the provided blockHash, if provided,
 // create an event registry based on this metadata and store both.
-func (e *eventRetriever) updateInternalState(blockHash *types.Hash) error {
+func (e *eventRetriever) updateInternalState(ctx context.Context, blockHash *types.Hash) error {
 	var (
 		meta *types.Metadata
 		err  error
 	)
 
 	if blockHash == nil {
-		meta, err = e.stateRPC.GetMetadataLatest()
+		meta, err = e.stateRPC.GetMetadataLatestCtx(ctx)
 	} else {
-		meta, err = e.stateRPC.GetMetadata(*blockHash)
+		meta, err = e.stateRPC.GetMetadataCtx(ctx, *blockHash)
 	}
 
 	if err != nil {
@@ -3,6 +3,8 @@
 package retriever
 
 import (
+	context "context"
+
 	parser "github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	mock "github.com/stretchr/testify/mock"
@@ -36,6 +38,29 @@ func (_m *EventRetrieverMock) GetEvents(blockHash types.Hash) ([]*parser.Event,
 	return r0, r1
 }
 
+// GetEventsCtx provides a mock function with given fields: ctx, blockHash
+func (_m *EventRetrieverMock) GetEventsCtx(ctx context.Context, blockHash types.Hash) ([]*parser.Event, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 []*parser.Event
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) []*parser.Event); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*parser.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewEventRetrieverMockT interface {
 	mock.TestingT
 	Cleanup(func())
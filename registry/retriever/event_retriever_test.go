@@ -1,6 +1,7 @@
 package retriever
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -24,7 +25,7 @@ func TestEventRetriever_New(t *testing.T) {
 
 	latestMeta := &types.Metadata{}
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(latestMeta, nil).
 		Once()
 
@@ -56,7 +57,7 @@ func TestEventRetriever_New_InternalStateUpdateError(t *testing.T) {
 
 	metadataRetrievalError := errors.New("error")
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(nil, metadataRetrievalError).
 		Once()
 
@@ -73,7 +74,7 @@ func TestEventRetriever_New_InternalStateUpdateError(t *testing.T) {
 
 	latestMeta := &types.Metadata{}
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(latestMeta, nil).
 		Once()
 
@@ -101,7 +102,7 @@ func TestEventRetriever_NewDefault(t *testing.T) {
 
 	latestMeta := &types.Metadata{}
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(latestMeta, nil).
 		Once()
 
@@ -147,14 +148,14 @@ func TestEventRetriever_GetEvents(t *testing.T) {
 
 	storageEvents := &types.StorageDataRaw{}
 
-	eventProviderMock.On("GetStorageEvents", testMeta, blockHash).
+	eventProviderMock.On("GetStorageEventsCtx", mock.Anything, testMeta, blockHash).
 		Return(storageEvents, nil).
 		Once()
 
-	storageExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+	storageExecMock.On("ExecWithFallbackCtx", mock.Anything, mock.Anything, mock.Anything).
 		Run(
 			func(args mock.Arguments) {
-				execFn, ok := args.Get(0).(func() (*types.StorageDataRaw, error))
+				execFn, ok := args.Get(1).(func() (*types.StorageDataRaw, error))
 				assert.True(t, ok)
 
 				execFnRes, err := execFn()
@@ -169,10 +170,10 @@ func TestEventRetriever_GetEvents(t *testing.T) {
 		Return(parsedEvents, nil).
 		Once()
 
-	parsingExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+	parsingExecMock.On("ExecWithFallbackCtx", mock.Anything, mock.Anything, mock.Anything).
 		Run(
 			func(args mock.Arguments) {
-				execFn, ok := args.Get(0).(func() ([]*parser.Event, error))
+				execFn, ok := args.Get(1).(func() ([]*parser.Event, error))
 				assert.True(t, ok)
 
 				execFnRes, err := execFn()
@@ -215,11 +216,11 @@ func TestEventRetriever_GetEvents_StorageRetrievalError(t *testing.T) {
 
 	storageRetrievalError := errors.New("error")
 
-	eventProviderMock.On("GetStorageEvents", testMeta, blockHash).
+	eventProviderMock.On("GetStorageEventsCtx", mock.Anything, testMeta, blockHash).
 		Return(nil, storageRetrievalError).
 		Once()
 
-	stateRPCMock.On("GetMetadata", blockHash).
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
 		Return(testMeta, nil).
 		Once()
 
@@ -227,17 +228,17 @@ func TestEventRetriever_GetEvents_StorageRetrievalError(t *testing.T) {
 		Return(eventRegistry, nil).
 		Once()
 
-	storageExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+	storageExecMock.On("ExecWithFallbackCtx", mock.Anything, mock.Anything, mock.Anything).
 		Run(
 			func(args mock.Arguments) {
-				execFn, ok := args.Get(0).(func() (*types.StorageDataRaw, error))
+				execFn, ok := args.Get(1).(func() (*types.StorageDataRaw, error))
 				assert.True(t, ok)
 
 				execFnRes, err := execFn()
 				assert.ErrorIs(t, err, storageRetrievalError)
 				assert.Nil(t, execFnRes)
 
-				fallbackFn, ok := args.Get(1).(func() error)
+				fallbackFn, ok := args.Get(2).(func() error)
 				assert.True(t, ok)
 
 				err = fallbackFn()
@@ -279,14 +280,14 @@ func TestEventRetriever_GetEvents_EventParsingError(t *testing.T) {
 
 	storageEvents := &types.StorageDataRaw{}
 
-	eventProviderMock.On("GetStorageEvents", testMeta, blockHash).
+	eventProviderMock.On("GetStorageEventsCtx", mock.Anything, testMeta, blockHash).
 		Return(storageEvents, nil).
 		Once()
 
-	storageExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+	storageExecMock.On("ExecWithFallbackCtx", mock.Anything, mock.Anything, mock.Anything).
 		Run(
 			func(args mock.Arguments) {
-				execFn, ok := args.Get(0).(func() (*types.StorageDataRaw, error))
+				execFn, ok := args.Get(1).(func() (*types.StorageDataRaw, error))
 				assert.True(t, ok)
 
 				execFnRes, err := execFn()
@@ -301,7 +302,7 @@ func TestEventRetriever_GetEvents_EventParsingError(t *testing.T) {
 		Return(nil, eventParsingError).
 		Once()
 
-	stateRPCMock.On("GetMetadata", blockHash).
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
 		Return(testMeta, nil).
 		Once()
 
@@ -309,17 +310,17 @@ func TestEventRetriever_GetEvents_EventParsingError(t *testing.T) {
 		Return(eventRegistry, nil).
 		Once()
 
-	parsingExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+	parsingExecMock.On("ExecWithFallbackCtx", mock.Anything, mock.Anything, mock.Anything).
 		Run(
 			func(args mock.Arguments) {
-				execFn, ok := args.Get(0).(func() ([]*parser.Event, error))
+				execFn, ok := args.Get(1).(func() ([]*parser.Event, error))
 				assert.True(t, ok)
 
 				execFnRes, err := execFn()
 				assert.ErrorIs(t, err, eventParsingError)
 				assert.Nil(t, execFnRes)
 
-				fallbackFn, ok := args.Get(1).(func() error)
+				fallbackFn, ok := args.Get(2).(func() error)
 				assert.True(t, ok)
 
 				err = fallbackFn()
@@ -355,7 +356,7 @@ func TestEventRetriever_updateInternalState(t *testing.T) {
 
 	blockHash := types.NewHash([]byte{0, 1, 2, 3})
 
-	stateRPCMock.On("GetMetadata", blockHash).
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
 		Return(testMeta, nil).
 		Once()
 
@@ -363,14 +364,14 @@ func TestEventRetriever_updateInternalState(t *testing.T) {
 		Return(eventRegistry, nil).
 		Once()
 
-	err := eventRetriever.updateInternalState(&blockHash)
+	err := eventRetriever.updateInternalState(context.Background(), &blockHash)
 	assert.NoError(t, err)
 	assert.Equal(t, testMeta, eventRetriever.meta)
 	assert.Equal(t, eventRegistry, eventRetriever.eventRegistry)
 
 	latestMeta := &types.Metadata{}
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(latestMeta, nil).
 		Once()
 
@@ -378,7 +379,7 @@ func TestEventRetriever_updateInternalState(t *testing.T) {
 		Return(eventRegistry, nil).
 		Once()
 
-	err = eventRetriever.updateInternalState(nil)
+	err = eventRetriever.updateInternalState(context.Background(), nil)
 	assert.NoError(t, err)
 	assert.Equal(t, latestMeta, eventRetriever.meta)
 	assert.Equal(t, eventRegistry, eventRetriever.eventRegistry)
@@ -405,18 +406,18 @@ func TestEventRetriever_updateInternalState_MetadataRetrievalError(t *testing.T)
 
 	metadataRetrievalError := errors.New("error")
 
-	stateRPCMock.On("GetMetadata", blockHash).
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
 		Return(nil, metadataRetrievalError).
 		Once()
 
-	err := eventRetriever.updateInternalState(&blockHash)
+	err := eventRetriever.updateInternalState(context.Background(), &blockHash)
 	assert.ErrorIs(t, err, ErrMetadataRetrieval)
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(nil, metadataRetrievalError).
 		Once()
 
-	err = eventRetriever.updateInternalState(nil)
+	err = eventRetriever.updateInternalState(context.Background(), nil)
 	assert.ErrorIs(t, err, ErrMetadataRetrieval)
 }
 
@@ -441,7 +442,7 @@ func TestEventRetriever_updateInternalState_RegistryFactoryError(t *testing.T) {
 
 	blockHash := types.NewHash([]byte{0, 1, 2, 3})
 
-	stateRPCMock.On("GetMetadata", blockHash).
+	stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
 		Return(testMeta, nil).
 		Once()
 
@@ -451,12 +452,12 @@ func TestEventRetriever_updateInternalState_RegistryFactoryError(t *testing.T) {
 		Return(nil, registryFactoryError).
 		Once()
 
-	err := eventRetriever.updateInternalState(&blockHash)
+	err := eventRetriever.updateInternalState(context.Background(), &blockHash)
 	assert.ErrorIs(t, err, ErrEventRegistryCreation)
 
 	latestMeta := &types.Metadata{}
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(latestMeta, nil).
 		Once()
 
@@ -464,6 +465,6 @@ func TestEventRetriever_updateInternalState_RegistryFactoryError(t *testing.T) {
 		Return(nil, registryFactoryError).
 		Once()
 
-	err = eventRetriever.updateInternalState(nil)
+	err = eventRetriever.updateInternalState(context.Background(), nil)
 	assert.ErrorIs(t, err, ErrEventRegistryCreation)
 }
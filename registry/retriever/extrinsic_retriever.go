@@ -1,6 +1,7 @@
 package retriever
 
 import (
+	"context"
 	"time"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
@@ -18,6 +19,7 @@ import (
 // from a particular block.
 type ExtrinsicRetriever interface {
 	GetExtrinsics(blockHash types.Hash) ([]*registry.DecodedExtrinsic, error)
+	GetExtrinsicsCtx(ctx context.Context, blockHash types.Hash) ([]*registry.DecodedExtrinsic, error)
 }
 
 // extrinsicRetriever implements the ExtrinsicRetriever interface.
@@ -49,7 +51,7 @@ func NewExtrinsicRetriever(
 		extrinsicDecodingExecutor: extrinsicDecodingExecutor,
 	}
 
-	if err := retriever.updateInternalState(nil); err != nil {
+	if err := retriever.updateInternalState(context.Background(), nil); err != nil {
 		return nil, ErrInternalStateUpdate.Wrap(err)
 	}
 
@@ -81,9 +83,18 @@ func NewDefaultExtrinsicRetriever(
 // Both the block retrieval and the extrinsic parsing are handled via the exec.RetryableExecutor
 // in order to ensure retries in case of network errors or parsing errors due to an outdated extrinsic decoder.
 func (e *extrinsicRetriever) GetExtrinsics(blockHash types.Hash) ([]*registry.DecodedExtrinsic, error) {
-	block, err := e.chainExecutor.ExecWithFallback(
+	return e.GetExtrinsicsCtx(context.Background(), blockHash)
+}
+
+// GetExtrinsicsCtx is like GetExtrinsics, the RPC requests and the retries are bound to the provided context.
+func (e *extrinsicRetriever) GetExtrinsicsCtx(
+	ctx context.Context,
+	blockHash types.Hash,
+) ([]*registry.DecodedExtrinsic, error) {
+	block, err := e.chainExecutor.ExecWithFallbackCtx(
+		ctx,
 		func() (*block.SignedBlock, error) {
-			return e.chainRPC.GetBlock(blockHash)
+			return e.chainRPC.GetBlockCtx(ctx, blockHash)
 		},
 		func() error {
 			return nil
@@ -94,12 +105,13 @@ func (e *extrinsicRetriever) GetExtrinsics(blockHash types.Hash) ([]*registry.De
 		return nil, ErrBlockRetrieval.Wrap(err)
 	}
 
-	calls, err := e.extrinsicDecodingExecutor.ExecWithFallback(
+	calls, err := e.extrinsicDecodingExecutor.ExecWithFallbackCtx(
+		ctx,
 		func() ([]*registry.DecodedExtrinsic, error) {
 			return block.DecodeExtrinsics(e.extrinsicDecoder)
 		},
 		func() error {
-			return e.updateInternalState(&blockHash)
+			return e.updateInternalState(ctx, &blockHash)
 		},
 	)
 
@@ -112,16 +124,16 @@ func (e *extrinsicRetriever) GetExtrinsics(blockHash types.Hash) ([]*registry.De
 
 // updateInternalState will retrieve the metadata at the provided blockHash, if provided,
 // create an extrinsic decoder based on this metadata and store both.
-func (e *extrinsicRetriever) updateInternalState(blockHash *types.Hash) error {
+func (e *extrinsicRetriever) updateInternalState(ctx context.Context, blockHash *types.Hash) error {
 	var (
 		meta *types.Metadata
 		err  error
 	)
 
 	if blockHash == nil {
-		meta, err = e.stateRPC.GetMetadataLatest()
+		meta, err = e.stateRPC.GetMetadataLatestCtx(ctx)
 	} else {
-		meta, err = e.stateRPC.GetMetadata(*blockHash)
+		meta, err = e.stateRPC.GetMetadataCtx(ctx, *blockHash)
 	}
 
 	if err != nil {
@@ -24,7 +24,7 @@ func TestExtrinsicRetriever_New(t *testing.T) {
 
 	latestMeta := &types.Metadata{}
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(latestMeta, nil).
 		Once()
 
@@ -54,7 +54,7 @@ func TestExtrinsicRetriever_New_InternalStateUpdateError(t *testing.T) {
 
 	latestMeta := &types.Metadata{}
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(nil, errors.New("error")).
 		Once()
 
@@ -68,7 +68,7 @@ func TestExtrinsicRetriever_New_InternalStateUpdateError(t *testing.T) {
 	assert.ErrorIs(t, err, ErrInternalStateUpdate)
 	assert.Nil(t, res)
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(latestMeta, nil).
 		Once()
 
@@ -96,7 +96,7 @@ func TestExtrinsicRetriever_NewDefault(t *testing.T) {
 	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
 	assert.NoError(t, err)
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(&meta, nil).
 		Once()
 	res, err := NewDefaultExtrinsicRetriever(
@@ -119,7 +119,7 @@ func TestExtrinsicRetriever_GetExtrinsics(t *testing.T) {
 	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
 	assert.NoError(t, err)
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(&meta, nil).
 		Once()
 
@@ -160,14 +160,14 @@ func TestExtrinsicRetriever_GetExtrinsics(t *testing.T) {
 		Justification: nil,
 	}
 
-	chainRPCMock.On("GetBlock", blockHash).
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
 		Return(testBlock, nil).
 		Once()
 
-	chainExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+	chainExecMock.On("ExecWithFallbackCtx", mock.Anything, mock.Anything, mock.Anything).
 		Run(
 			func(args mock.Arguments) {
-				execFn, ok := args.Get(0).(func() (*block.SignedBlock, error))
+				execFn, ok := args.Get(1).(func() (*block.SignedBlock, error))
 				assert.True(t, ok)
 
 				execFnRes, err := execFn()
@@ -181,10 +181,10 @@ func TestExtrinsicRetriever_GetExtrinsics(t *testing.T) {
 
 	decodedExtrinsics := []*registry.DecodedExtrinsic{decodedExtrinsic}
 
-	extrinsicDecodingExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+	extrinsicDecodingExecMock.On("ExecWithFallbackCtx", mock.Anything, mock.Anything, mock.Anything).
 		Run(
 			func(args mock.Arguments) {
-				execFn, ok := args.Get(0).(func() ([]*registry.DecodedExtrinsic, error))
+				execFn, ok := args.Get(1).(func() ([]*registry.DecodedExtrinsic, error))
 				assert.True(t, ok)
 
 				execFnRes, err := execFn()
@@ -210,7 +210,7 @@ func TestExtrinsicRetriever_GetExtrinsics_BlockRetrievalError(t *testing.T) {
 	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
 	assert.NoError(t, err)
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(&meta, nil).
 		Once()
 
@@ -235,16 +235,16 @@ func TestExtrinsicRetriever_GetExtrinsics_BlockRetrievalError(t *testing.T) {
 
 	blockRetrievalError := errors.New("block retrieval error")
 
-	chainRPCMock.On("GetBlock", blockHash).
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
 		Return(nil, blockRetrievalError).
 		Once()
 
 	var signedBlock *block.SignedBlock
 
-	chainExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+	chainExecMock.On("ExecWithFallbackCtx", mock.Anything, mock.Anything, mock.Anything).
 		Run(
 			func(args mock.Arguments) {
-				execFn, ok := args.Get(0).(func() (*block.SignedBlock, error))
+				execFn, ok := args.Get(1).(func() (*block.SignedBlock, error))
 				assert.True(t, ok)
 
 				execFnRes, err := execFn()
@@ -270,7 +270,7 @@ func TestExtrinsicRetriever_GetExtrinsics_ExtrinsicDecodeError(t *testing.T) {
 	err := codec.DecodeFromHex(test.CentrifugeMetadataHex, &meta)
 	assert.NoError(t, err)
 
-	stateRPCMock.On("GetMetadataLatest").
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
 		Return(&meta, nil).
 		Once()
 
@@ -306,14 +306,14 @@ func TestExtrinsicRetriever_GetExtrinsics_ExtrinsicDecodeError(t *testing.T) {
 		Justification: nil,
 	}
 
-	chainRPCMock.On("GetBlock", blockHash).
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
 		Return(testBlock, nil).
 		Once()
 
-	chainExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+	chainExecMock.On("ExecWithFallbackCtx", mock.Anything, mock.Anything, mock.Anything).
 		Run(
 			func(args mock.Arguments) {
-				execFn, ok := args.Get(0).(func() (*block.SignedBlock, error))
+				execFn, ok := args.Get(1).(func() (*block.SignedBlock, error))
 				assert.True(t, ok)
 
 				execFnRes, err := execFn()
@@ -326,10 +326,10 @@ func TestExtrinsicRetriever_GetExtrinsics_ExtrinsicDecodeError(t *testing.T) {
 
 	var decodedExtrinsics []*registry.DecodedExtrinsic
 
-	extrinsicDecodingExecMock.On("ExecWithFallback", mock.Anything, mock.Anything).
+	extrinsicDecodingExecMock.On("ExecWithFallbackCtx", mock.Anything, mock.Anything, mock.Anything).
 		Run(
 			func(args mock.Arguments) {
-				execFn, ok := args.Get(0).(func() ([]*registry.DecodedExtrinsic, error))
+				execFn, ok := args.Get(1).(func() ([]*registry.DecodedExtrinsic, error))
 				assert.True(t, ok)
 
 				execFnRes, err := execFn()
@@ -1,6 +1,8 @@
 package state
 
 import (
+	"context"
+
 	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -16,6 +18,7 @@ const (
 // EventProvider is the interface used for retrieving event data from the storage.
 type EventProvider interface {
 	GetStorageEvents(meta *types.Metadata, blockHash types.Hash) (*types.StorageDataRaw, error)
+	GetStorageEventsCtx(ctx context.Context, meta *types.Metadata, blockHash types.Hash) (*types.StorageDataRaw, error)
 }
 
 // eventProvider implements the EventProvider interface.
@@ -35,13 +38,22 @@ const (
 
 // GetStorageEvents returns the event storage data found at the provided blockHash.
 func (p *eventProvider) GetStorageEvents(meta *types.Metadata, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	return p.GetStorageEventsCtx(context.Background(), meta, blockHash)
+}
+
+// GetStorageEventsCtx is like GetStorageEvents, the storage request is bound to the provided context.
+func (p *eventProvider) GetStorageEventsCtx(
+	ctx context.Context,
+	meta *types.Metadata,
+	blockHash types.Hash,
+) (*types.StorageDataRaw, error) {
 	key, err := types.CreateStorageKey(meta, storagePrefix, storageMethod, nil)
 
 	if err != nil {
 		return nil, ErrEventStorageKeyCreation.Wrap(err)
 	}
 
-	storageData, err := p.stateRPC.GetStorageRaw(key, blockHash)
+	storageData, err := p.stateRPC.GetStorageRawCtx(ctx, key, blockHash)
 
 	if err != nil {
 		return nil, ErrEventStorageRetrieval.Wrap(err)
@@ -3,6 +3,8 @@
 package state
 
 import (
+	context "context"
+
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -35,6 +37,29 @@ func (_m *EventProviderMock) GetStorageEvents(meta *types.Metadata, blockHash ty
 	return r0, r1
 }
 
+// GetStorageEventsCtx provides a mock function with given fields: ctx, meta, blockHash
+func (_m *EventProviderMock) GetStorageEventsCtx(ctx context.Context, meta *types.Metadata, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, meta, blockHash)
+
+	var r0 *types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(context.Context, *types.Metadata, types.Hash) *types.StorageDataRaw); ok {
+		r0 = rf(ctx, meta, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StorageDataRaw)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.Metadata, types.Hash) error); ok {
+		r1 = rf(ctx, meta, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewEventProviderMockT interface {
 	mock.TestingT
 	Cleanup(func())
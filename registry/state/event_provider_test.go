@@ -8,6 +8,7 @@ import (
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestProvider_GetStorageEvents(t *testing.T) {
@@ -32,7 +33,7 @@ func TestProvider_GetStorageEvents(t *testing.T) {
 
 	storageData := &types.StorageDataRaw{}
 
-	stateRPCMock.On("GetStorageRaw", storageKey, testHash).
+	stateRPCMock.On("GetStorageRawCtx", mock.Anything, storageKey, testHash).
 		Return(storageData, nil).
 		Once()
 
@@ -42,7 +43,7 @@ func TestProvider_GetStorageEvents(t *testing.T) {
 
 	stateRPCError := errors.New("error")
 
-	stateRPCMock.On("GetStorageRaw", storageKey, testHash).
+	stateRPCMock.On("GetStorageRawCtx", mock.Anything, storageKey, testHash).
 		Return(nil, stateRPCError).
 		Once()
 
@@ -0,0 +1,30 @@
+package submitter
+
+import (
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+)
+
+const (
+	ErrInternalStateUpdate        = libErr.Error("internal state update")
+	ErrMetadataRetrieval          = libErr.Error("metadata retrieval")
+	ErrErrorRegistryCreation      = libErr.Error("error registry creation")
+	ErrExtrinsicEncoding          = libErr.Error("extrinsic encoding")
+	ErrExtrinsicSubmission        = libErr.Error("extrinsic submission")
+	ErrExtrinsicSubscription      = libErr.Error("extrinsic subscription")
+	ErrExtrinsicSubscriptionEnded = libErr.Error("extrinsic subscription ended")
+	ErrExtrinsicDropped           = libErr.Error("extrinsic dropped")
+	ErrExtrinsicInvalid           = libErr.Error("extrinsic invalid")
+	ErrExtrinsicUsurped           = libErr.Error("extrinsic usurped")
+	ErrExtrinsicFinalityTimeout   = libErr.Error("extrinsic finality timeout")
+	ErrContextDone                = libErr.Error("context done")
+	ErrBlockRetrieval             = libErr.Error("block retrieval")
+	ErrExtrinsicNotFoundInBlock   = libErr.Error("extrinsic not found in block")
+	ErrEventsRetrieval            = libErr.Error("events retrieval")
+	ErrExtrinsicFailed            = libErr.Error("extrinsic failed")
+
+	ErrExtrinsicOutcomeNotFound = parser.ErrExtrinsicOutcomeNotFound
+	ErrDispatchErrorDecoding    = parser.ErrDispatchErrorDecoding
+	ErrModuleErrorDecoding      = parser.ErrModuleErrorDecoding
+	ErrFeePaidDecoding          = parser.ErrFeePaidDecoding
+)
@@ -0,0 +1,337 @@
+package submitter
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	regState "github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"golang.org/x/crypto/blake2b"
+)
+
+// WaitMode defines the extrinsic status that the ExtrinsicSubmitter waits for before returning.
+type WaitMode int
+
+const (
+	// WaitForInBlock returns once the extrinsic is included in a block.
+	WaitForInBlock WaitMode = iota
+	// WaitForFinalized returns once the block that includes the extrinsic is finalized.
+	WaitForFinalized
+)
+
+//nolint:lll
+//go:generate mockery --name ExtrinsicSubmitter --structname ExtrinsicSubmitterMock --filename extrinsic_submitter_mock.go --inpackage
+
+// ExtrinsicSubmitter is the interface used for submitting an extrinsic and waiting for its result.
+type ExtrinsicSubmitter interface {
+	SubmitAndWait(ctx context.Context, xt extrinsic.Extrinsic, waitMode WaitMode) (*ExtrinsicResult, error)
+}
+
+// ExtrinsicResult holds all the information of an extrinsic that was included in a block.
+type ExtrinsicResult struct {
+	BlockHash      types.Hash
+	BlockNumber    types.BlockNumber
+	ExtrinsicHash  types.Hash
+	ExtrinsicIndex uint32
+
+	// Events holds the events emitted during the application of the extrinsic.
+	Events []*parser.Event
+
+	// Success is true if the extrinsic emitted a System.ExtrinsicSuccess event.
+	Success bool
+
+	// DispatchError holds the dispatch error, if the extrinsic failed.
+	DispatchError *registry.DispatchError
+
+	// FeePaid is the actual fee paid for the extrinsic, as emitted in the TransactionPayment.TransactionFeePaid
+	// event. It is zero if the event was not emitted, e.g. for unsigned extrinsics.
+	FeePaid types.U128
+}
+
+// Err returns nil if the extrinsic was successful, the dispatch error if the extrinsic failed,
+// or ErrExtrinsicFailed if the dispatch error is not available.
+func (e *ExtrinsicResult) Err() error {
+	if e.Success {
+		return nil
+	}
+
+	if e.DispatchError != nil {
+		return e.DispatchError
+	}
+
+	return ErrExtrinsicFailed
+}
+
+// extrinsicStatusSubscription is implemented by author.ExtrinsicStatusSubscription.
+type extrinsicStatusSubscription interface {
+	Chan() <-chan types.ExtrinsicStatus
+	Err() <-chan error
+	Unsubscribe()
+}
+
+type submitAndWatchFn func(ctx context.Context, xt extrinsic.Extrinsic) (extrinsicStatusSubscription, error)
+
+// extrinsicSubmitter implements the ExtrinsicSubmitter interface.
+type extrinsicSubmitter struct {
+	chainRPC chain.Chain
+	stateRPC state.State
+
+	eventRetriever  retriever.EventRetriever
+	registryFactory registry.Factory
+
+	submitAndWatch submitAndWatchFn
+
+	// errorRegistryMu guards the error registry, which can be updated while other extrinsics
+	// are being processed.
+	errorRegistryMu sync.RWMutex
+	errorRegistry   registry.ErrorRegistry
+}
+
+// NewExtrinsicSubmitter creates a new ExtrinsicSubmitter.
+func NewExtrinsicSubmitter(
+	authorRPC author.Author,
+	chainRPC chain.Chain,
+	stateRPC state.State,
+	eventRetriever retriever.EventRetriever,
+	registryFactory registry.Factory,
+) (ExtrinsicSubmitter, error) {
+	submitter := &extrinsicSubmitter{
+		chainRPC:        chainRPC,
+		stateRPC:        stateRPC,
+		eventRetriever:  eventRetriever,
+		registryFactory: registryFactory,
+		submitAndWatch: func(ctx context.Context, xt extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+			return authorRPC.SubmitAndWatchExtrinsicCtx(ctx, xt)
+		},
+	}
+
+	if err := submitter.updateInternalState(context.Background(), nil); err != nil {
+		return nil, ErrInternalStateUpdate.Wrap(err)
+	}
+
+	return submitter, nil
+}
+
+// NewDefaultExtrinsicSubmitter returns an ExtrinsicSubmitter with a default event retriever and registry factory.
+func NewDefaultExtrinsicSubmitter(
+	authorRPC author.Author,
+	chainRPC chain.Chain,
+	stateRPC state.State,
+	fieldOverrides ...registry.FieldOverride,
+) (ExtrinsicSubmitter, error) {
+	eventRetriever, err := retriever.NewDefaultEventRetriever(
+		regState.NewEventProvider(stateRPC),
+		stateRPC,
+		fieldOverrides...,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewExtrinsicSubmitter(
+		authorRPC,
+		chainRPC,
+		stateRPC,
+		eventRetriever,
+		registry.NewFactory(fieldOverrides...),
+	)
+}
+
+// SubmitAndWait submits the provided extrinsic and waits until it is either included in a block or finalized,
+// depending on the provided WaitMode. It then retrieves the block and the events of the extrinsic in order
+// to determine its outcome.
+//
+// The provided context can be used for setting a timeout for the whole process, the RPC requests and the
+// extrinsic status subscription are bound to it.
+func (e *extrinsicSubmitter) SubmitAndWait(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	waitMode WaitMode,
+) (*ExtrinsicResult, error) {
+	encodedExtrinsic, err := codec.Encode(xt)
+
+	if err != nil {
+		return nil, ErrExtrinsicEncoding.Wrap(err)
+	}
+
+	sub, err := e.submitAndWatch(ctx, xt)
+
+	if err != nil {
+		return nil, ErrExtrinsicSubmission.Wrap(err)
+	}
+
+	defer sub.Unsubscribe()
+
+	blockHash, err := waitForBlock(ctx, sub, waitMode)
+
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := e.chainRPC.GetBlockCtx(ctx, blockHash)
+
+	if err != nil {
+		return nil, ErrBlockRetrieval.Wrap(err)
+	}
+
+	hexEncodedExtrinsic := codec.HexEncodeToString(encodedExtrinsic)
+
+	extrinsicIndex := -1
+
+	for i, blockExtrinsic := range block.Block.Extrinsics {
+		if blockExtrinsic == hexEncodedExtrinsic {
+			extrinsicIndex = i
+			break
+		}
+	}
+
+	if extrinsicIndex == -1 {
+		return nil, ErrExtrinsicNotFoundInBlock.WithMsg("block hash '%s'", blockHash.Hex())
+	}
+
+	events, err := e.eventRetriever.GetEventsCtx(ctx, blockHash)
+
+	if err != nil {
+		return nil, ErrEventsRetrieval.Wrap(err)
+	}
+
+	res := &ExtrinsicResult{
+		BlockHash:      blockHash,
+		BlockNumber:    block.Block.Header.Number,
+		ExtrinsicHash:  blake2b.Sum256(encodedExtrinsic),
+		ExtrinsicIndex: uint32(extrinsicIndex),
+		Events:         filterExtrinsicEvents(events, uint32(extrinsicIndex)),
+	}
+
+	if err := e.processEvents(ctx, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// waitForBlock waits for the extrinsic status that corresponds to the provided WaitMode
+// and returns the hash of the block that includes the extrinsic.
+func waitForBlock(ctx context.Context, sub extrinsicStatusSubscription, waitMode WaitMode) (types.Hash, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return types.Hash{}, ErrContextDone.Wrap(ctx.Err())
+		case err := <-sub.Err():
+			if err == nil {
+				return types.Hash{}, ErrExtrinsicSubscriptionEnded
+			}
+
+			return types.Hash{}, ErrExtrinsicSubscription.Wrap(err)
+		case status, ok := <-sub.Chan():
+			if !ok {
+				return types.Hash{}, ErrExtrinsicSubscriptionEnded
+			}
+
+			switch {
+			case status.IsInBlock && waitMode == WaitForInBlock:
+				return status.AsInBlock, nil
+			case status.IsFinalized:
+				return status.AsFinalized, nil
+			case status.IsDropped:
+				return types.Hash{}, ErrExtrinsicDropped
+			case status.IsInvalid:
+				return types.Hash{}, ErrExtrinsicInvalid
+			case status.IsUsurped:
+				return types.Hash{}, ErrExtrinsicUsurped.WithMsg("usurped by '%s'", status.AsUsurped.Hex())
+			case status.IsFinalityTimeout:
+				return types.Hash{}, ErrExtrinsicFinalityTimeout.WithMsg("block hash '%s'", status.AsFinalityTimeout.Hex())
+			}
+		}
+	}
+}
+
+// filterExtrinsicEvents returns the events that were emitted while applying the extrinsic with the provided index.
+func filterExtrinsicEvents(events []*parser.Event, extrinsicIndex uint32) []*parser.Event {
+	var extrinsicEvents []*parser.Event
+
+	for _, event := range events {
+		if event.Phase == nil || !event.Phase.IsApplyExtrinsic || event.Phase.AsApplyExtrinsic != extrinsicIndex {
+			continue
+		}
+
+		extrinsicEvents = append(extrinsicEvents, event)
+	}
+
+	return extrinsicEvents
+}
+
+// processEvents determines the outcome and the fee paid of the extrinsic based on its events.
+func (e *extrinsicSubmitter) processEvents(ctx context.Context, res *ExtrinsicResult) error {
+	outcome, err := parser.GetExtrinsicOutcome(res.Events, e.getErrorRegistry())
+
+	if errors.Is(err, parser.ErrModuleErrorDecoding) {
+		// The error registry might be outdated, update it using the metadata at the block
+		// that includes the extrinsic and try again.
+		if err := e.updateInternalState(ctx, &res.BlockHash); err != nil {
+			return ErrInternalStateUpdate.Wrap(err)
+		}
+
+		outcome, err = parser.GetExtrinsicOutcome(res.Events, e.getErrorRegistry())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	res.Success = outcome.Success
+	res.DispatchError = outcome.DispatchError
+	res.FeePaid = outcome.FeePaid
+
+	return nil
+}
+
+// updateInternalState will retrieve the metadata at the provided blockHash, if provided,
+// create an error registry based on this metadata and store it.
+func (e *extrinsicSubmitter) updateInternalState(ctx context.Context, blockHash *types.Hash) error {
+	var (
+		meta *types.Metadata
+		err  error
+	)
+
+	if blockHash == nil {
+		meta, err = e.stateRPC.GetMetadataLatestCtx(ctx)
+	} else {
+		meta, err = e.stateRPC.GetMetadataCtx(ctx, *blockHash)
+	}
+
+	if err != nil {
+		return ErrMetadataRetrieval.Wrap(err)
+	}
+
+	errorRegistry, err := e.registryFactory.CreateErrorRegistry(meta)
+
+	if err != nil {
+		return ErrErrorRegistryCreation.Wrap(err)
+	}
+
+	e.errorRegistryMu.Lock()
+	defer e.errorRegistryMu.Unlock()
+
+	e.errorRegistry = errorRegistry
+
+	return nil
+}
+
+// getErrorRegistry returns the current error registry.
+func (e *extrinsicSubmitter) getErrorRegistry() registry.ErrorRegistry {
+	e.errorRegistryMu.RLock()
+	defer e.errorRegistryMu.RUnlock()
+
+	return e.errorRegistry
+}
@@ -0,0 +1,651 @@
+package submitter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	authorMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author/mocks"
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestExtrinsicSubmitter_New(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
+		Return(getTestMetadata(t), nil).
+		Once()
+
+	res, err := NewExtrinsicSubmitter(
+		authorMocks.NewAuthor(t),
+		chainMocks.NewChain(t),
+		stateRPCMock,
+		retriever.NewEventRetrieverMock(t),
+		registry.NewFactory(),
+	)
+	assert.NoError(t, err)
+	assert.IsType(t, &extrinsicSubmitter{}, res)
+}
+
+func TestExtrinsicSubmitter_New_InternalStateUpdateError(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err := NewExtrinsicSubmitter(
+		authorMocks.NewAuthor(t),
+		chainMocks.NewChain(t),
+		stateRPCMock,
+		retriever.NewEventRetrieverMock(t),
+		registryFactoryMock,
+	)
+	assert.ErrorIs(t, err, ErrInternalStateUpdate)
+	assert.Nil(t, res)
+
+	meta := &types.Metadata{}
+
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
+		Return(meta, nil).
+		Once()
+
+	registryFactoryMock.On("CreateErrorRegistry", meta).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err = NewExtrinsicSubmitter(
+		authorMocks.NewAuthor(t),
+		chainMocks.NewChain(t),
+		stateRPCMock,
+		retriever.NewEventRetrieverMock(t),
+		registryFactoryMock,
+	)
+	assert.ErrorIs(t, err, ErrInternalStateUpdate)
+	assert.Nil(t, res)
+}
+
+func TestExtrinsicSubmitter_SubmitAndWait(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	submitter := newTestSubmitter(t, chainRPCMock, eventRetrieverMock)
+
+	xt := newTestExtrinsic(t)
+
+	blockHash := types.Hash{1, 2, 3}
+
+	sub := newTestSubscription(
+		types.ExtrinsicStatus{IsReady: true},
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: blockHash},
+	)
+
+	// The RPC requests and the subscription are bound to the provided context.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	submitter.submitAndWatch = func(subCtx context.Context, _ extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+		assert.Equal(t, ctx, subCtx)
+
+		return sub, nil
+	}
+
+	encodedExtrinsic, err := codec.Encode(xt)
+	assert.NoError(t, err)
+
+	chainRPCMock.On("GetBlockCtx", ctx, blockHash).
+		Return(newTestBlock(t, 11, xt), nil).
+		Once()
+
+	eventRegistry := getTestEventRegistry(t)
+
+	feePaid := types.NewU128(*big.NewInt(1234))
+
+	feePaidEventData := append(signature.TestKeyringPairAlice.PublicKey, mustEncode(t, feePaid)...)
+	feePaidEventData = append(feePaidEventData, mustEncode(t, types.NewU128(*big.NewInt(0)))...)
+
+	events := []*parser.Event{
+		newTestEvent(t, eventRegistry, "System.ExtrinsicSuccess", []byte{0, 0, 0, 0}, 0),
+		newTestEvent(t, eventRegistry, "TransactionPayment.TransactionFeePaid", feePaidEventData, 1),
+		newTestEvent(t, eventRegistry, "System.ExtrinsicSuccess", []byte{0, 0, 0, 0}, 1),
+	}
+
+	eventRetrieverMock.On("GetEventsCtx", ctx, blockHash).
+		Return(events, nil).
+		Once()
+
+	res, err := submitter.SubmitAndWait(ctx, xt, WaitForInBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, blockHash, res.BlockHash)
+	assert.Equal(t, types.BlockNumber(11), res.BlockNumber)
+	assert.Equal(t, types.Hash(blake2b.Sum256(encodedExtrinsic)), res.ExtrinsicHash)
+	assert.Equal(t, uint32(1), res.ExtrinsicIndex)
+	assert.Equal(t, events[1:], res.Events)
+	assert.True(t, res.Success)
+	assert.Nil(t, res.DispatchError)
+	assert.Equal(t, feePaid, res.FeePaid)
+	assert.NoError(t, res.Err())
+	assert.True(t, sub.unsubscribed)
+}
+
+func TestExtrinsicSubmitter_SubmitAndWait_ModuleError(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	submitter := newTestSubmitter(t, chainRPCMock, eventRetrieverMock)
+
+	xt := newTestExtrinsic(t)
+
+	inBlockHash := types.Hash{1, 2, 3}
+	finalizedBlockHash := types.Hash{3, 2, 1}
+
+	sub := newTestSubscription(
+		types.ExtrinsicStatus{IsInBlock: true, AsInBlock: inBlockHash},
+		types.ExtrinsicStatus{IsFinalized: true, AsFinalized: finalizedBlockHash},
+	)
+
+	submitter.submitAndWatch = func(_ context.Context, _ extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+		return sub, nil
+	}
+
+	chainRPCMock.On("GetBlockCtx", mock.Anything, finalizedBlockHash).
+		Return(newTestBlock(t, 11, xt), nil).
+		Once()
+
+	balancesIndex := getPalletIndex(t, "Balances")
+
+	extrinsicFailedEventData := []byte{
+		3,                   // Dispatch error - Module
+		byte(balancesIndex), // Module index
+		2, 0, 0, 0,          // Error index
+		0, 0, // Weight
+		0, // Class
+		0, // Pays fee
+	}
+
+	events := []*parser.Event{
+		newTestEvent(t, getTestEventRegistry(t), "System.ExtrinsicFailed", extrinsicFailedEventData, 1),
+	}
+
+	eventRetrieverMock.On("GetEventsCtx", mock.Anything, finalizedBlockHash).
+		Return(events, nil).
+		Once()
+
+	res, err := submitter.SubmitAndWait(context.Background(), xt, WaitForFinalized)
+	assert.NoError(t, err)
+	assert.Equal(t, finalizedBlockHash, res.BlockHash)
+	assert.False(t, res.Success)
+	assert.True(t, res.DispatchError.Raw.IsModule)
+	assert.Equal(t, "Balances.InsufficientBalance", res.DispatchError.ModuleError.Name)
+	assert.Equal(t, balancesIndex, res.DispatchError.ModuleError.ModuleIndex)
+	assert.Equal(t, types.U8(2), res.DispatchError.ModuleError.ErrorIndex)
+	assert.Equal(t, res.DispatchError, res.Err())
+
+	var moduleError *registry.ModuleError
+
+	assert.ErrorAs(t, res.Err(), &moduleError)
+	assert.Equal(t, "Balances", moduleError.PalletName)
+	assert.Equal(t, "InsufficientBalance", moduleError.ErrorName)
+	assert.Equal(t, types.U128{}, res.FeePaid)
+}
+
+func TestExtrinsicSubmitter_SubmitAndWait_OtherDispatchError(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	submitter := newTestSubmitter(t, chainRPCMock, eventRetrieverMock)
+
+	xt := newTestExtrinsic(t)
+
+	blockHash := types.Hash{1, 2, 3}
+
+	submitter.submitAndWatch = func(_ context.Context, _ extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+		return newTestSubscription(types.ExtrinsicStatus{IsInBlock: true, AsInBlock: blockHash}), nil
+	}
+
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
+		Return(newTestBlock(t, 11, xt), nil).
+		Once()
+
+	extrinsicFailedEventData := []byte{
+		2,    // Dispatch error - BadOrigin
+		0, 0, // Weight
+		0, // Class
+		0, // Pays fee
+	}
+
+	events := []*parser.Event{
+		newTestEvent(t, getTestEventRegistry(t), "System.ExtrinsicFailed", extrinsicFailedEventData, 1),
+	}
+
+	eventRetrieverMock.On("GetEventsCtx", mock.Anything, blockHash).
+		Return(events, nil).
+		Once()
+
+	res, err := submitter.SubmitAndWait(context.Background(), xt, WaitForInBlock)
+	assert.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.Nil(t, res.DispatchError.ModuleError)
+	assert.ErrorIs(t, res.Err(), &registry.DispatchError{Raw: types.DispatchError{IsBadOrigin: true}})
+}
+
+func TestExtrinsicSubmitter_SubmitAndWait_DispatchErrorVariants(t *testing.T) {
+	tests := []struct {
+		name                  string
+		dispatchErrorData     []byte
+		expectedDispatchError types.DispatchError
+	}{
+		{
+			name:                  "token",
+			dispatchErrorData:     []byte{7, 9},
+			expectedDispatchError: types.DispatchError{IsToken: true, TokenError: types.TokenError{IsBlocked: true}},
+		},
+		{
+			name:                  "arithmetic",
+			dispatchErrorData:     []byte{8, 1},
+			expectedDispatchError: types.DispatchError{IsArithmetic: true, ArithmeticError: types.ArithmeticError{IsOverflow: true}},
+		},
+		{
+			name:                  "transactional",
+			dispatchErrorData:     []byte{9, 1},
+			expectedDispatchError: types.DispatchError{IsTransactional: true, TransactionalError: types.TransactionalError{IsNoLayer: true}},
+		},
+		{
+			name:                  "root not allowed",
+			dispatchErrorData:     []byte{13},
+			expectedDispatchError: types.DispatchError{IsRootNotAllowed: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chainRPCMock := chainMocks.NewChain(t)
+			eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+			submitter := newTestSubmitter(t, chainRPCMock, eventRetrieverMock)
+
+			xt := newTestExtrinsic(t)
+
+			blockHash := types.Hash{1, 2, 3}
+
+			submitter.submitAndWatch = func(_ context.Context, _ extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+				return newTestSubscription(types.ExtrinsicStatus{IsInBlock: true, AsInBlock: blockHash}), nil
+			}
+
+			chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
+				Return(newTestBlock(t, 11, xt), nil).
+				Once()
+
+			extrinsicFailedEventData := append(
+				test.dispatchErrorData,
+				0, 0, // Weight
+				0, // Class
+				0, // Pays fee
+			)
+
+			events := []*parser.Event{
+				newTestEvent(t, getTestEventRegistry(t), "System.ExtrinsicFailed", extrinsicFailedEventData, 1),
+			}
+
+			eventRetrieverMock.On("GetEventsCtx", mock.Anything, blockHash).
+				Return(events, nil).
+				Once()
+
+			res, err := submitter.SubmitAndWait(context.Background(), xt, WaitForInBlock)
+			assert.NoError(t, err)
+			assert.False(t, res.Success)
+			assert.Equal(t, test.expectedDispatchError, res.DispatchError.Raw)
+			assert.Nil(t, res.DispatchError.ModuleError)
+		})
+	}
+}
+
+func TestExtrinsicSubmitter_SubmitAndWait_SubmissionError(t *testing.T) {
+	submitter := newTestSubmitter(t, chainMocks.NewChain(t), retriever.NewEventRetrieverMock(t))
+
+	submitter.submitAndWatch = func(_ context.Context, _ extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+		return nil, errors.New("error")
+	}
+
+	res, err := submitter.SubmitAndWait(context.Background(), newTestExtrinsic(t), WaitForInBlock)
+	assert.ErrorIs(t, err, ErrExtrinsicSubmission)
+	assert.Nil(t, res)
+}
+
+func TestExtrinsicSubmitter_SubmitAndWait_StatusErrors(t *testing.T) {
+	tests := []struct {
+		Status        types.ExtrinsicStatus
+		ExpectedError error
+	}{
+		{
+			Status:        types.ExtrinsicStatus{IsDropped: true},
+			ExpectedError: ErrExtrinsicDropped,
+		},
+		{
+			Status:        types.ExtrinsicStatus{IsInvalid: true},
+			ExpectedError: ErrExtrinsicInvalid,
+		},
+		{
+			Status:        types.ExtrinsicStatus{IsUsurped: true},
+			ExpectedError: ErrExtrinsicUsurped,
+		},
+		{
+			Status:        types.ExtrinsicStatus{IsFinalityTimeout: true},
+			ExpectedError: ErrExtrinsicFinalityTimeout,
+		},
+	}
+
+	for _, test := range tests {
+		submitter := newTestSubmitter(t, chainMocks.NewChain(t), retriever.NewEventRetrieverMock(t))
+
+		submitter.submitAndWatch = func(_ context.Context, _ extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+			return newTestSubscription(test.Status), nil
+		}
+
+		res, err := submitter.SubmitAndWait(context.Background(), newTestExtrinsic(t), WaitForFinalized)
+		assert.ErrorIs(t, err, test.ExpectedError)
+		assert.Nil(t, res)
+	}
+}
+
+func TestExtrinsicSubmitter_SubmitAndWait_ContextDone(t *testing.T) {
+	submitter := newTestSubmitter(t, chainMocks.NewChain(t), retriever.NewEventRetrieverMock(t))
+
+	sub := newTestSubscription(types.ExtrinsicStatus{IsInBlock: true})
+
+	submitter.submitAndWatch = func(_ context.Context, _ extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+		return sub, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	res, err := submitter.SubmitAndWait(ctx, newTestExtrinsic(t), WaitForFinalized)
+	assert.ErrorIs(t, err, ErrContextDone)
+	assert.Nil(t, res)
+	assert.True(t, sub.unsubscribed)
+}
+
+func TestExtrinsicSubmitter_SubmitAndWait_SubscriptionError(t *testing.T) {
+	submitter := newTestSubmitter(t, chainMocks.NewChain(t), retriever.NewEventRetrieverMock(t))
+
+	sub := newTestSubscription()
+	sub.errCh <- errors.New("error")
+
+	submitter.submitAndWatch = func(_ context.Context, _ extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+		return sub, nil
+	}
+
+	res, err := submitter.SubmitAndWait(context.Background(), newTestExtrinsic(t), WaitForFinalized)
+	assert.ErrorIs(t, err, ErrExtrinsicSubscription)
+	assert.Nil(t, res)
+}
+
+func TestExtrinsicSubmitter_SubmitAndWait_ExtrinsicNotFoundInBlock(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+
+	submitter := newTestSubmitter(t, chainRPCMock, retriever.NewEventRetrieverMock(t))
+
+	blockHash := types.Hash{1, 2, 3}
+
+	submitter.submitAndWatch = func(_ context.Context, _ extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+		return newTestSubscription(types.ExtrinsicStatus{IsInBlock: true, AsInBlock: blockHash}), nil
+	}
+
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
+		Return(&block.SignedBlock{}, nil).
+		Once()
+
+	res, err := submitter.SubmitAndWait(context.Background(), newTestExtrinsic(t), WaitForInBlock)
+	assert.ErrorIs(t, err, ErrExtrinsicNotFoundInBlock)
+	assert.Nil(t, res)
+}
+
+func TestExtrinsicSubmitter_SubmitAndWait_OutcomeNotFound(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	submitter := newTestSubmitter(t, chainRPCMock, eventRetrieverMock)
+
+	xt := newTestExtrinsic(t)
+
+	blockHash := types.Hash{1, 2, 3}
+
+	submitter.submitAndWatch = func(_ context.Context, _ extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+		return newTestSubscription(types.ExtrinsicStatus{IsInBlock: true, AsInBlock: blockHash}), nil
+	}
+
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
+		Return(newTestBlock(t, 11, xt), nil).
+		Once()
+
+	eventRetrieverMock.On("GetEventsCtx", mock.Anything, blockHash).
+		Return(nil, nil).
+		Once()
+
+	res, err := submitter.SubmitAndWait(context.Background(), xt, WaitForInBlock)
+	assert.ErrorIs(t, err, ErrExtrinsicOutcomeNotFound)
+	assert.Nil(t, res)
+}
+
+func TestExtrinsicSubmitter_SubmitAndWait_ConcurrentErrorRegistryUpdate(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	submitter := newTestSubmitter(t, chainRPCMock, eventRetrieverMock)
+
+	// The error registry is outdated and gets updated while the extrinsics are processed.
+	submitter.errorRegistry = registry.ErrorRegistry{}
+
+	xt := newTestExtrinsic(t)
+
+	blockHash := types.Hash{1, 2, 3}
+
+	submitter.submitAndWatch = func(_ context.Context, _ extrinsic.Extrinsic) (extrinsicStatusSubscription, error) {
+		return newTestSubscription(types.ExtrinsicStatus{IsInBlock: true, AsInBlock: blockHash}), nil
+	}
+
+	submitter.stateRPC.(*stateMocks.State).On("GetMetadataCtx", mock.Anything, blockHash).
+		Return(getTestMetadata(t), nil)
+
+	extrinsicFailedEventData := []byte{
+		3,                                   // Dispatch error - Module
+		byte(getPalletIndex(t, "Balances")), // Module index
+		2, 0, 0, 0,                          // Error index
+		0, 0, // Weight
+		0, // Class
+		0, // Pays fee
+	}
+
+	events := []*parser.Event{
+		newTestEvent(t, getTestEventRegistry(t), "System.ExtrinsicFailed", extrinsicFailedEventData, 1),
+	}
+
+	submissionCount := 10
+
+	chainRPCMock.On("GetBlockCtx", mock.Anything, blockHash).
+		Return(newTestBlock(t, 11, xt), nil).
+		Times(submissionCount)
+
+	eventRetrieverMock.On("GetEventsCtx", mock.Anything, blockHash).
+		Return(events, nil).
+		Times(submissionCount)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < submissionCount; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			res, err := submitter.SubmitAndWait(context.Background(), xt, WaitForInBlock)
+			assert.NoError(t, err)
+			assert.Equal(t, "Balances.InsufficientBalance", res.DispatchError.ModuleError.Name)
+		}()
+	}
+
+	wg.Wait()
+}
+
+type testSubscription struct {
+	statusCh     chan types.ExtrinsicStatus
+	errCh        chan error
+	unsubscribed bool
+}
+
+func newTestSubscription(statuses ...types.ExtrinsicStatus) *testSubscription {
+	sub := &testSubscription{
+		statusCh: make(chan types.ExtrinsicStatus, len(statuses)),
+		errCh:    make(chan error, 1),
+	}
+
+	for _, status := range statuses {
+		sub.statusCh <- status
+	}
+
+	return sub
+}
+
+func (s *testSubscription) Chan() <-chan types.ExtrinsicStatus {
+	return s.statusCh
+}
+
+func (s *testSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+func (s *testSubscription) Unsubscribe() {
+	s.unsubscribed = true
+}
+
+func newTestSubmitter(
+	t *testing.T,
+	chainRPC *chainMocks.Chain,
+	eventRetriever *retriever.EventRetrieverMock,
+) *extrinsicSubmitter {
+	stateRPCMock := stateMocks.NewState(t)
+
+	stateRPCMock.On("GetMetadataLatestCtx", mock.Anything).
+		Return(getTestMetadata(t), nil).
+		Once()
+
+	submitter, err := NewExtrinsicSubmitter(
+		authorMocks.NewAuthor(t),
+		chainRPC,
+		stateRPCMock,
+		eventRetriever,
+		registry.NewFactory(),
+	)
+	assert.NoError(t, err)
+
+	return submitter.(*extrinsicSubmitter)
+}
+
+func newTestExtrinsic(t *testing.T) extrinsic.Extrinsic {
+	call, err := types.NewCall(getTestMetadata(t), "System.remark", []byte("test"))
+	assert.NoError(t, err)
+
+	return extrinsic.NewExtrinsic(call)
+}
+
+func newTestBlock(t *testing.T, blockNumber types.BlockNumber, xt extrinsic.Extrinsic) *block.SignedBlock {
+	encodedExtrinsic, err := codec.EncodeToHex(xt)
+	assert.NoError(t, err)
+
+	return &block.SignedBlock{
+		Block: block.Block{
+			Header: types.Header{
+				Number: blockNumber,
+			},
+			Extrinsics: []string{
+				"0x1234",
+				encodedExtrinsic,
+			},
+		},
+	}
+}
+
+func newTestEvent(
+	t *testing.T,
+	eventRegistry registry.EventRegistry,
+	eventName string,
+	eventData []byte,
+	extrinsicIndex uint32,
+) *parser.Event {
+	for eventID, eventDecoder := range eventRegistry {
+		if eventDecoder.Name != eventName {
+			continue
+		}
+
+		eventFields, err := eventDecoder.Decode(scale.NewDecoder(bytes.NewReader(eventData)))
+		assert.NoError(t, err)
+
+		return &parser.Event{
+			Name:    eventName,
+			Fields:  eventFields,
+			EventID: eventID,
+			Phase: &types.Phase{
+				IsApplyExtrinsic: true,
+				AsApplyExtrinsic: extrinsicIndex,
+			},
+		}
+	}
+
+	t.Fatalf("event %s not found", eventName)
+
+	return nil
+}
+
+func getTestEventRegistry(t *testing.T) registry.EventRegistry {
+	eventRegistry, err := registry.NewFactory().CreateEventRegistry(getTestMetadata(t))
+	assert.NoError(t, err)
+
+	return eventRegistry
+}
+
+func getTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
+
+func getPalletIndex(t *testing.T, palletName string) types.U8 {
+	for _, pallet := range getTestMetadata(t).AsMetadataV14.Pallets {
+		if string(pallet.Name) == palletName {
+			return pallet.Index
+		}
+	}
+
+	t.Fatalf("pallet %s not found", palletName)
+
+	return 0
+}
+
+func mustEncode(t *testing.T, value any) []byte {
+	enc, err := codec.Encode(value)
+	assert.NoError(t, err)
+
+	return enc
+}
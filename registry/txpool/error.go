@@ -0,0 +1,14 @@
+package txpool
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrInternalStateUpdate        = libErr.Error("internal state update")
+	ErrMetadataRetrieval          = libErr.Error("metadata retrieval")
+	ErrExtrinsicDecoderCreation   = libErr.Error("extrinsic decoder creation")
+	ErrCallRegistryCreation       = libErr.Error("call registry creation")
+	ErrPendingExtrinsicsRetrieval = libErr.Error("pending extrinsics retrieval")
+	ErrPendingExtrinsicDecoding   = libErr.Error("pending extrinsic decoding")
+	ErrExtrinsicFieldNotFound     = libErr.Error("extrinsic field not found")
+	ErrSignedExtensionsMismatch   = libErr.Error("signed extensions mismatch")
+)
@@ -0,0 +1,172 @@
+package txpool
+
+import (
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	checkNonceSignedExtension               = "CheckNonce"
+	chargeTransactionPaymentSignedExtension = "ChargeTransactionPayment"
+	chargeAssetTxPaymentSignedExtension     = "ChargeAssetTxPayment"
+
+	accountIDLength = 32
+)
+
+// PoolEntry holds the information of an extrinsic found in the transaction pool.
+type PoolEntry struct {
+	Hash      types.Hash
+	Extrinsic *registry.DecodedExtrinsic
+
+	// CallName is the name of the call of the extrinsic, in the format <Pallet>.<Call>.
+	CallName string
+
+	// Signer is the account that signed the extrinsic. It is nil for unsigned extrinsics or
+	// if the address of the extrinsic is not an account ID.
+	Signer *types.AccountID
+
+	Nonce types.UCompact
+	Tip   types.UCompact
+
+	// FirstSeen is the time at which the extrinsic was first found in the pool.
+	FirstSeen time.Time
+}
+
+// IsSigned returns true if the extrinsic of the entry is signed.
+func (p *PoolEntry) IsSigned() bool {
+	return p.Extrinsic != nil && p.Extrinsic.IsSigned()
+}
+
+// newPoolEntry creates a PoolEntry using the fields of the decoded extrinsic.
+//
+// The values of the signed extensions are matched by their position in the extra field, which follows
+// the order of the signed extensions found in the metadata.
+func newPoolEntry(
+	hash types.Hash,
+	decodedExtrinsic *registry.DecodedExtrinsic,
+	meta *types.Metadata,
+	callRegistry registry.CallRegistry,
+) (*PoolEntry, error) {
+	entry := &PoolEntry{
+		Hash:      hash,
+		Extrinsic: decodedExtrinsic,
+	}
+
+	if callDecoder, ok := callRegistry[decodedExtrinsic.CallIndex]; ok {
+		entry.CallName = callDecoder.Name
+	}
+
+	if !decodedExtrinsic.IsSigned() {
+		return entry, nil
+	}
+
+	address, err := getExtrinsicField(decodedExtrinsic, registry.ExtrinsicAddressName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Signer = findAccountID(address.Value)
+
+	extra, err := getExtrinsicField(decodedExtrinsic, registry.ExtrinsicExtraName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	extraFields, ok := extra.Value.(registry.DecodedFields)
+
+	if !ok {
+		// Chains without signed extensions have an empty extra.
+		return entry, nil
+	}
+
+	signedExtensions := meta.AsMetadataV14.Extrinsic.SignedExtensions
+
+	if len(extraFields) != len(signedExtensions) {
+		return nil, ErrSignedExtensionsMismatch.WithMsg(
+			"expected %d signed extensions, got %d",
+			len(signedExtensions),
+			len(extraFields),
+		)
+	}
+
+	for i, signedExtension := range signedExtensions {
+		switch string(signedExtension.Identifier) {
+		case checkNonceSignedExtension:
+			if nonce, ok := findUCompact(extraFields[i].Value); ok {
+				entry.Nonce = nonce
+			}
+		case chargeTransactionPaymentSignedExtension, chargeAssetTxPaymentSignedExtension:
+			if tip, ok := findUCompact(extraFields[i].Value); ok {
+				entry.Tip = tip
+			}
+		}
+	}
+
+	return entry, nil
+}
+
+func getExtrinsicField(decodedExtrinsic *registry.DecodedExtrinsic, fieldName string) (*registry.DecodedField, error) {
+	for _, field := range decodedExtrinsic.DecodedFields {
+		if field.Name == fieldName {
+			return field, nil
+		}
+	}
+
+	return nil, ErrExtrinsicFieldNotFound.WithMsg("field name '%s'", fieldName)
+}
+
+// findAccountID returns the first 32 bytes array found in the provided value.
+func findAccountID(value any) *types.AccountID {
+	switch v := value.(type) {
+	case *registry.DecodedVariant:
+		return findAccountID(v.Fields)
+	case registry.DecodedFields:
+		for _, field := range v {
+			if accountID := findAccountID(field.Value); accountID != nil {
+				return accountID
+			}
+		}
+	case []any:
+		if len(v) != accountIDLength {
+			return nil
+		}
+
+		var accountID types.AccountID
+
+		for i, item := range v {
+			b, ok := item.(types.U8)
+
+			if !ok {
+				return nil
+			}
+
+			accountID[i] = byte(b)
+		}
+
+		return &accountID
+	}
+
+	return nil
+}
+
+// findUCompact returns the first types.UCompact found in the provided value.
+func findUCompact(value any) (types.UCompact, bool) {
+	switch v := value.(type) {
+	case types.UCompact:
+		return v, true
+	case *registry.DecodedVariant:
+		return findUCompact(v.Fields)
+	case registry.DecodedFields:
+		for _, field := range v {
+			if res, ok := findUCompact(field.Value); ok {
+				return res, true
+			}
+		}
+	}
+
+	return types.UCompact{}, false
+}
@@ -0,0 +1,289 @@
+package txpool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+//nolint:lll
+//go:generate mockery --name PoolWatcher --structname PoolWatcherMock --filename pool_watcher_mock.go --inpackage
+
+// PoolEventType is the type of change of a pool entry.
+type PoolEventType int
+
+const (
+	// PoolEntryAdded is used for extrinsics that were added to the pool.
+	PoolEntryAdded PoolEventType = iota
+	// PoolEntryRemoved is used for extrinsics that were removed from the pool, either because
+	// they were included in a block, or because they were dropped or became invalid.
+	PoolEntryRemoved
+)
+
+// PoolEvent holds a change of the transaction pool.
+type PoolEvent struct {
+	Type  PoolEventType
+	Entry *PoolEntry
+}
+
+// PoolWatcher is the interface used for monitoring the transaction pool of a node.
+type PoolWatcher interface {
+	// Poll retrieves the pending extrinsics and returns the changes since the previous poll.
+	Poll() ([]*PoolEvent, error)
+
+	// Entries returns the entries that were found in the pool during the last poll.
+	Entries() []*PoolEntry
+
+	// Watch polls the transaction pool at the provided interval until the context is done.
+	//
+	// The changes and the polling errors are sent on the returned channels, which are closed
+	// once the context is done.
+	Watch(ctx context.Context, interval time.Duration) (<-chan *PoolEvent, <-chan error)
+}
+
+// poolWatcher implements the PoolWatcher interface.
+type poolWatcher struct {
+	authorRPC author.Author
+	stateRPC  state.State
+
+	registryFactory registry.Factory
+
+	// now is used for setting the time at which entries were first seen.
+	now func() time.Time
+
+	mu sync.Mutex
+
+	meta             *types.Metadata
+	extrinsicDecoder *registry.ExtrinsicDecoder
+	callRegistry     registry.CallRegistry
+
+	entries map[types.Hash]*PoolEntry
+	order   []types.Hash
+}
+
+// NewPoolWatcher creates a new PoolWatcher.
+func NewPoolWatcher(
+	authorRPC author.Author,
+	stateRPC state.State,
+	registryFactory registry.Factory,
+) (PoolWatcher, error) {
+	watcher := &poolWatcher{
+		authorRPC:       authorRPC,
+		stateRPC:        stateRPC,
+		registryFactory: registryFactory,
+		now:             time.Now,
+		entries:         make(map[types.Hash]*PoolEntry),
+	}
+
+	if err := watcher.updateInternalState(); err != nil {
+		return nil, ErrInternalStateUpdate.Wrap(err)
+	}
+
+	return watcher, nil
+}
+
+// NewDefaultPoolWatcher returns a PoolWatcher with a default registry factory.
+func NewDefaultPoolWatcher(
+	authorRPC author.Author,
+	stateRPC state.State,
+	fieldOverrides ...registry.FieldOverride,
+) (PoolWatcher, error) {
+	registryFactory := registry.NewFactory(fieldOverrides...)
+
+	return NewPoolWatcher(authorRPC, stateRPC, registryFactory)
+}
+
+// Poll retrieves the pending extrinsics, decodes the new ones and returns the entries
+// that were added or removed since the previous poll.
+//
+// Entries that were added are returned in the order found in the pool, followed by the entries
+// that were removed, in the order in which they were added.
+func (p *poolWatcher) Poll() ([]*PoolEvent, error) {
+	pendingExtrinsics, err := p.authorRPC.PendingExtrinsics()
+
+	if err != nil {
+		return nil, ErrPendingExtrinsicsRetrieval.Wrap(err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var (
+		events     []*PoolEvent
+		newOrder   []types.Hash
+		newEntries = make(map[types.Hash]*PoolEntry)
+	)
+
+	for _, pendingExtrinsic := range pendingExtrinsics {
+		extrinsicBytes, err := codec.HexDecodeString(pendingExtrinsic)
+
+		if err != nil {
+			return nil, ErrPendingExtrinsicDecoding.Wrap(err)
+		}
+
+		hash := types.NewHash(getExtrinsicHash(extrinsicBytes))
+
+		if _, ok := newEntries[hash]; ok {
+			continue
+		}
+
+		entry, ok := p.entries[hash]
+
+		if !ok {
+			entry, err = p.newPoolEntry(hash, pendingExtrinsic)
+
+			if err != nil {
+				return nil, err
+			}
+
+			events = append(events, &PoolEvent{
+				Type:  PoolEntryAdded,
+				Entry: entry,
+			})
+		}
+
+		newEntries[hash] = entry
+		newOrder = append(newOrder, hash)
+	}
+
+	for _, hash := range p.order {
+		if _, ok := newEntries[hash]; ok {
+			continue
+		}
+
+		events = append(events, &PoolEvent{
+			Type:  PoolEntryRemoved,
+			Entry: p.entries[hash],
+		})
+	}
+
+	p.entries = newEntries
+	p.order = newOrder
+
+	return events, nil
+}
+
+// Entries returns the entries that were found in the pool during the last poll, in the order found in the pool.
+func (p *poolWatcher) Entries() []*PoolEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entries := make([]*PoolEntry, 0, len(p.order))
+
+	for _, hash := range p.order {
+		entries = append(entries, p.entries[hash])
+	}
+
+	return entries
+}
+
+// Watch polls the transaction pool at the provided interval until the context is done.
+func (p *poolWatcher) Watch(ctx context.Context, interval time.Duration) (<-chan *PoolEvent, <-chan error) {
+	eventsChan := make(chan *PoolEvent)
+	errChan := make(chan error)
+
+	go func() {
+		defer close(eventsChan)
+		defer close(errChan)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			events, err := p.Poll()
+
+			if err != nil {
+				select {
+				case errChan <- err:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			for _, event := range events {
+				select {
+				case eventsChan <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return eventsChan, errChan
+}
+
+// newPoolEntry decodes the provided extrinsic and creates its pool entry. The internal state is updated
+// once if the decoding fails, since the runtime might have been upgraded.
+func (p *poolWatcher) newPoolEntry(hash types.Hash, pendingExtrinsic string) (*PoolEntry, error) {
+	decodedExtrinsic, err := p.extrinsicDecoder.DecodeHex(pendingExtrinsic)
+
+	if err != nil {
+		if err := p.updateInternalState(); err != nil {
+			return nil, ErrInternalStateUpdate.Wrap(err)
+		}
+
+		decodedExtrinsic, err = p.extrinsicDecoder.DecodeHex(pendingExtrinsic)
+
+		if err != nil {
+			return nil, ErrPendingExtrinsicDecoding.Wrap(err)
+		}
+	}
+
+	entry, err := newPoolEntry(hash, decodedExtrinsic, p.meta, p.callRegistry)
+
+	if err != nil {
+		return nil, ErrPendingExtrinsicDecoding.Wrap(err)
+	}
+
+	entry.FirstSeen = p.now()
+
+	return entry, nil
+}
+
+// updateInternalState will retrieve the latest metadata and use it to create the extrinsic decoder
+// and the call registry.
+func (p *poolWatcher) updateInternalState() error {
+	meta, err := p.stateRPC.GetMetadataLatest()
+
+	if err != nil {
+		return ErrMetadataRetrieval.Wrap(err)
+	}
+
+	extrinsicDecoder, err := p.registryFactory.CreateExtrinsicDecoder(meta)
+
+	if err != nil {
+		return ErrExtrinsicDecoderCreation.Wrap(err)
+	}
+
+	callRegistry, err := p.registryFactory.CreateCallRegistry(meta)
+
+	if err != nil {
+		return ErrCallRegistryCreation.Wrap(err)
+	}
+
+	p.meta = meta
+	p.extrinsicDecoder = extrinsicDecoder
+	p.callRegistry = callRegistry
+
+	return nil
+}
+
+func getExtrinsicHash(extrinsicBytes []byte) []byte {
+	hash := blake2b.Sum256(extrinsicBytes)
+
+	return hash[:]
+}
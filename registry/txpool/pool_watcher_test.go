@@ -0,0 +1,300 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	authorMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author/mocks"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestPoolWatcher_New(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	stateRPCMock.On("GetMetadataLatest").
+		Return(getTestMetadata(t), nil).
+		Once()
+
+	res, err := NewDefaultPoolWatcher(authorMocks.NewAuthor(t), stateRPCMock)
+	assert.NoError(t, err)
+	assert.IsType(t, &poolWatcher{}, res)
+}
+
+func TestPoolWatcher_New_InternalStateUpdateError(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+
+	stateRPCMock.On("GetMetadataLatest").
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err := NewDefaultPoolWatcher(authorMocks.NewAuthor(t), stateRPCMock)
+	assert.ErrorIs(t, err, ErrInternalStateUpdate)
+	assert.Nil(t, res)
+
+	meta := getTestMetadata(t)
+
+	registryFactoryMock := registry.NewFactoryMock(t)
+
+	stateRPCMock.On("GetMetadataLatest").
+		Return(meta, nil).
+		Once()
+
+	registryFactoryMock.On("CreateExtrinsicDecoder", meta).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err = NewPoolWatcher(authorMocks.NewAuthor(t), stateRPCMock, registryFactoryMock)
+	assert.ErrorIs(t, err, ErrInternalStateUpdate)
+	assert.Nil(t, res)
+}
+
+func TestPoolWatcher_Poll(t *testing.T) {
+	authorRPCMock := authorMocks.NewAuthor(t)
+
+	watcher := newTestPoolWatcher(t, authorRPCMock)
+
+	now := time.Now()
+
+	watcher.now = func() time.Time {
+		return now
+	}
+
+	xt1 := newTestExtrinsic(t, 1, 0)
+	xt2 := newTestExtrinsic(t, 2, 100)
+	xt3 := newTestExtrinsic(t, 3, 0)
+
+	authorRPCMock.On("PendingExtrinsics").
+		Return([]string{xt1, xt2}, nil).
+		Once()
+
+	events, err := watcher.Poll()
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	for _, event := range events {
+		assert.Equal(t, PoolEntryAdded, event.Type)
+	}
+
+	entry := events[1].Entry
+
+	assert.Equal(t, getTestExtrinsicHash(t, xt2), entry.Hash)
+	assert.Equal(t, "System.remark", entry.CallName)
+	assert.True(t, entry.IsSigned())
+
+	expectedSigner, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSigner, entry.Signer)
+
+	assert.Equal(t, types.NewUCompactFromUInt(2), entry.Nonce)
+	assert.Equal(t, types.NewUCompactFromUInt(100), entry.Tip)
+	assert.Equal(t, now, entry.FirstSeen)
+	assert.Equal(t, []*PoolEntry{events[0].Entry, events[1].Entry}, watcher.Entries())
+
+	authorRPCMock.On("PendingExtrinsics").
+		Return([]string{xt2, xt3}, nil).
+		Once()
+
+	events, err = watcher.Poll()
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, PoolEntryAdded, events[0].Type)
+	assert.Equal(t, getTestExtrinsicHash(t, xt3), events[0].Entry.Hash)
+	assert.Equal(t, PoolEntryRemoved, events[1].Type)
+	assert.Equal(t, getTestExtrinsicHash(t, xt1), events[1].Entry.Hash)
+
+	entries := watcher.Entries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, entry, entries[0])
+
+	authorRPCMock.On("PendingExtrinsics").
+		Return([]string{xt2, xt3}, nil).
+		Once()
+
+	events, err = watcher.Poll()
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestPoolWatcher_Poll_UnsignedExtrinsic(t *testing.T) {
+	authorRPCMock := authorMocks.NewAuthor(t)
+
+	watcher := newTestPoolWatcher(t, authorRPCMock)
+
+	call, err := types.NewCall(getTestMetadata(t), "System.remark", []byte("test"))
+	assert.NoError(t, err)
+
+	xt, err := codec.EncodeToHex(extrinsic.NewExtrinsic(call))
+	assert.NoError(t, err)
+
+	authorRPCMock.On("PendingExtrinsics").
+		Return([]string{xt}, nil).
+		Once()
+
+	events, err := watcher.Poll()
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.False(t, events[0].Entry.IsSigned())
+	assert.Nil(t, events[0].Entry.Signer)
+	assert.Equal(t, "System.remark", events[0].Entry.CallName)
+}
+
+func TestPoolWatcher_Poll_Errors(t *testing.T) {
+	authorRPCMock := authorMocks.NewAuthor(t)
+	stateRPCMock := stateMocks.NewState(t)
+
+	watcher := newTestPoolWatcherWithState(t, authorRPCMock, stateRPCMock)
+
+	authorRPCMock.On("PendingExtrinsics").
+		Return(nil, errors.New("error")).
+		Once()
+
+	events, err := watcher.Poll()
+	assert.ErrorIs(t, err, ErrPendingExtrinsicsRetrieval)
+	assert.Nil(t, events)
+
+	authorRPCMock.On("PendingExtrinsics").
+		Return([]string{"invalid"}, nil).
+		Once()
+
+	events, err = watcher.Poll()
+	assert.ErrorIs(t, err, ErrPendingExtrinsicDecoding)
+	assert.Nil(t, events)
+
+	// The decoding failure triggers an internal state update before retrying.
+	authorRPCMock.On("PendingExtrinsics").
+		Return([]string{"0x0102"}, nil).
+		Twice()
+
+	stateRPCMock.On("GetMetadataLatest").
+		Return(getTestMetadata(t), nil).
+		Once()
+
+	events, err = watcher.Poll()
+	assert.ErrorIs(t, err, ErrPendingExtrinsicDecoding)
+	assert.Nil(t, events)
+
+	stateRPCMock.On("GetMetadataLatest").
+		Return(nil, errors.New("error")).
+		Once()
+
+	events, err = watcher.Poll()
+	assert.ErrorIs(t, err, ErrInternalStateUpdate)
+	assert.Nil(t, events)
+}
+
+func TestPoolWatcher_Watch(t *testing.T) {
+	authorRPCMock := authorMocks.NewAuthor(t)
+
+	watcher := newTestPoolWatcher(t, authorRPCMock)
+
+	xt := newTestExtrinsic(t, 1, 0)
+
+	authorRPCMock.On("PendingExtrinsics").
+		Return([]string{xt}, nil).
+		Once()
+
+	authorRPCMock.On("PendingExtrinsics").
+		Return(nil, errors.New("error")).
+		Once()
+
+	authorRPCMock.On("PendingExtrinsics").
+		Return([]string{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	eventsChan, errChan := watcher.Watch(ctx, time.Millisecond)
+
+	event := <-eventsChan
+	assert.Equal(t, PoolEntryAdded, event.Type)
+	assert.Equal(t, getTestExtrinsicHash(t, xt), event.Entry.Hash)
+
+	err := <-errChan
+	assert.ErrorIs(t, err, ErrPendingExtrinsicsRetrieval)
+
+	event = <-eventsChan
+	assert.Equal(t, PoolEntryRemoved, event.Type)
+	assert.Equal(t, getTestExtrinsicHash(t, xt), event.Entry.Hash)
+
+	cancel()
+
+	for range eventsChan {
+	}
+
+	_, ok := <-errChan
+	assert.False(t, ok)
+}
+
+func newTestPoolWatcher(t *testing.T, authorRPCMock *authorMocks.Author) *poolWatcher {
+	return newTestPoolWatcherWithState(t, authorRPCMock, stateMocks.NewState(t))
+}
+
+func newTestPoolWatcherWithState(
+	t *testing.T,
+	authorRPCMock *authorMocks.Author,
+	stateRPCMock *stateMocks.State,
+) *poolWatcher {
+	stateRPCMock.On("GetMetadataLatest").
+		Return(getTestMetadata(t), nil).
+		Once()
+
+	res, err := NewDefaultPoolWatcher(authorRPCMock, stateRPCMock)
+	assert.NoError(t, err)
+
+	return res.(*poolWatcher)
+}
+
+func newTestExtrinsic(t *testing.T, nonce uint64, tip uint64) string {
+	meta := getTestMetadata(t)
+
+	call, err := types.NewCall(meta, "System.remark", []byte("test"))
+	assert.NoError(t, err)
+
+	xt := extrinsic.NewExtrinsic(call)
+
+	err = xt.Sign(
+		signature.TestKeyringPairAlice,
+		meta,
+		extrinsic.WithEra(types.ExtrinsicEra{IsImmortalEra: true}, types.Hash{}),
+		extrinsic.WithNonce(types.NewUCompactFromUInt(nonce)),
+		extrinsic.WithTip(types.NewUCompactFromUInt(tip)),
+		extrinsic.WithSpecVersion(1),
+		extrinsic.WithTransactionVersion(1),
+		extrinsic.WithGenesisHash(types.Hash{}),
+		extrinsic.WithMetadataMode(
+			extensions.CheckMetadataModeDisabled,
+			extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()},
+		),
+	)
+	assert.NoError(t, err)
+
+	enc, err := codec.EncodeToHex(xt)
+	assert.NoError(t, err)
+
+	return enc
+}
+
+func getTestExtrinsicHash(t *testing.T, xt string) types.Hash {
+	b, err := codec.HexDecodeString(xt)
+	assert.NoError(t, err)
+
+	return blake2b.Sum256(b)
+}
+
+func getTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
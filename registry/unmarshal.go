@@ -0,0 +1,734 @@
+package registry
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	unmarshalTagName = "scale"
+
+	variantIsFieldPrefix = "Is"
+	variantAsFieldPrefix = "As"
+
+	optionNoneVariantName = "None"
+	optionSomeVariantName = "Some"
+
+	resultOkVariantName  = "Ok"
+	resultErrVariantName = "Err"
+
+	setSomeMethodName  = "SetSome"
+	setNoneMethodName  = "SetNone"
+	setOkMethodName    = "SetOk"
+	setErrMethodName   = "SetErr"
+	setValueMethodName = "SetValue"
+)
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	u128Type     = reflect.TypeOf(types.U128{})
+	u256Type     = reflect.TypeOf(types.U256{})
+	i128Type     = reflect.TypeOf(types.I128{})
+	i256Type     = reflect.TypeOf(types.I256{})
+	uCompactType = reflect.TypeOf(types.UCompact{})
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Unmarshal stores the provided decoded fields in the struct pointed to by target.
+//
+// Decoded fields are matched with the exported struct fields by name, ignoring case and underscores,
+// where the name of a struct field can be overridden with a `scale:"name"` tag and a struct field
+// can be skipped with a `scale:"-"` tag. Decoded fields of tuples are matched by position.
+// Struct fields that have no matching decoded field are left unchanged.
+//
+// See UnmarshalValue for the conversion of the decoded values.
+func Unmarshal(decodedFields DecodedFields, target any) error {
+	targetValue := reflect.ValueOf(target)
+
+	if targetValue.Kind() != reflect.Pointer || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return ErrInvalidUnmarshalTarget.WithMsg("expected non-nil struct pointer, got %T", target)
+	}
+
+	return unmarshalFields(decodedFields, targetValue.Elem())
+}
+
+// UnmarshalValue stores the provided decoded value in the value pointed to by target.
+//
+// Decoded values are converted as follows:
+//
+//   - DecodedFields are stored in structs as described in Unmarshal;
+//   - integers are stored in any Go integer, *big.Int or big integer type from the types package,
+//     as long as the value fits;
+//   - byte sequences and arrays are stored in byte slices and arrays, such as types.AccountID;
+//   - composites with a single field, such as AccountId32, are stored as their inner value;
+//   - options are stored in pointers, which are set to nil for None, in types that have
+//     SetSome and SetNone methods, such as types.Option, or as their inner value;
+//   - results are stored in types that have SetOk and SetErr methods, such as types.Result;
+//   - values are stored in types that have a SetValue method, such as types.Compact, as the argument
+//     of SetValue. Composites of a compact length and a value, such as WrapperKeepOpaque, are stored as
+//     their value, e.g. in types.WrapperKeepOpaque;
+//   - sequences are stored in maps, such as types.BTreeMap, as key-value tuples, or in maps with
+//     struct{} values, such as types.BTreeSet, as keys;
+//   - variants are stored in strings as their name, or in structs that follow the IsX/AsX convention
+//     of the types package, where the bool field Is<Name> is set and the field As<Name> holds the variant
+//     fields. The Is and As fields can be overridden with `scale:"is:<Name>"` and `scale:"as:<Name>"` tags;
+//   - calls are stored in strings as their full name, in the format <Pallet>.<Call>, or in structs as their
+//     arguments.
+func UnmarshalValue(value any, target any) error {
+	targetValue := reflect.ValueOf(target)
+
+	if targetValue.Kind() != reflect.Pointer || targetValue.IsNil() {
+		return ErrInvalidUnmarshalTarget.WithMsg("expected non-nil pointer, got %T", target)
+	}
+
+	return unmarshalValue(value, targetValue.Elem())
+}
+
+func unmarshalFields(decodedFields DecodedFields, target reflect.Value) error {
+	targetType := target.Type()
+
+	isTuple := isTupleFields(decodedFields)
+
+	for i := 0; i < targetType.NumField(); i++ {
+		structField := targetType.Field(i)
+
+		if !structField.IsExported() {
+			continue
+		}
+
+		fieldName, ok := getUnmarshalFieldName(structField)
+
+		if !ok {
+			continue
+		}
+
+		var decodedField *DecodedField
+
+		if isTuple {
+			if i < len(decodedFields) {
+				decodedField = decodedFields[i]
+			}
+		} else {
+			decodedField = findDecodedField(decodedFields, fieldName)
+		}
+
+		if decodedField == nil {
+			continue
+		}
+
+		if err := unmarshalValue(decodedField.Value, target.Field(i)); err != nil {
+			return ErrDecodedFieldUnmarshal.WithMsg("field '%s'", structField.Name).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+//nolint:gocyclo,funlen
+func unmarshalValue(value any, target reflect.Value) error {
+	if value == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+
+	if v.Type().AssignableTo(target.Type()) {
+		target.Set(v)
+
+		return nil
+	}
+
+	if decodedVariant, ok := value.(*DecodedVariant); ok {
+		return unmarshalVariant(decodedVariant, target)
+	}
+
+	if decodedCall, ok := value.(*DecodedCall); ok {
+		return unmarshalCall(decodedCall, target)
+	}
+
+	if target.Kind() == reflect.Pointer {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+
+		return unmarshalValue(value, target.Elem())
+	}
+
+	if ok, err := unmarshalSetValue(value, target); ok {
+		return err
+	}
+
+	if bigInt, ok := getBigInt(value); ok {
+		return setBigInt(bigInt, target)
+	}
+
+	// Composites with a single field, such as AccountId32, are stored as their inner value.
+	if decodedFields, ok := value.(DecodedFields); ok && len(decodedFields) == 1 {
+		if target.Kind() != reflect.Struct || isBigIntType(target.Type()) {
+			return unmarshalValue(decodedFields[0].Value, target)
+		}
+	}
+
+	switch target.Kind() {
+	case reflect.Bool:
+		b, ok := value.(bool)
+
+		if !ok {
+			return newTypeMismatchError(value, target)
+		}
+
+		target.SetBool(b)
+
+		return nil
+	case reflect.String:
+		s, ok := value.(string)
+
+		if !ok {
+			return newTypeMismatchError(value, target)
+		}
+
+		target.SetString(s)
+
+		return nil
+	case reflect.Struct:
+		decodedFields, ok := value.(DecodedFields)
+
+		if !ok {
+			return newTypeMismatchError(value, target)
+		}
+
+		return unmarshalFields(decodedFields, target)
+	case reflect.Slice:
+		items, ok := getItems(value)
+
+		if !ok {
+			return newTypeMismatchError(value, target)
+		}
+
+		slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+
+		for i, item := range items {
+			if err := unmarshalValue(item, slice.Index(i)); err != nil {
+				return ErrDecodedFieldUnmarshal.WithMsg("item %d", i).Wrap(err)
+			}
+		}
+
+		target.Set(slice)
+
+		return nil
+	case reflect.Array:
+		items, ok := getItems(value)
+
+		if !ok {
+			return newTypeMismatchError(value, target)
+		}
+
+		if len(items) != target.Len() {
+			return ErrUnmarshalTypeMismatch.WithMsg("expected %d items, got %d", target.Len(), len(items))
+		}
+
+		for i, item := range items {
+			if err := unmarshalValue(item, target.Index(i)); err != nil {
+				return ErrDecodedFieldUnmarshal.WithMsg("item %d", i).Wrap(err)
+			}
+		}
+
+		return nil
+	case reflect.Map:
+		return unmarshalMap(value, target)
+	default:
+		return newTypeMismatchError(value, target)
+	}
+}
+
+// unmarshalMap stores the items of the decoded sequence in the provided map. Items are stored as keys if the
+// values of the map are empty structs, or as key-value tuples otherwise.
+func unmarshalMap(value any, target reflect.Value) error {
+	items, ok := getItems(value)
+
+	if !ok {
+		return newTypeMismatchError(value, target)
+	}
+
+	mapType := target.Type()
+	isSet := mapType.Elem().Kind() == reflect.Struct && mapType.Elem().NumField() == 0
+
+	res := reflect.MakeMapWithSize(mapType, len(items))
+
+	for i, item := range items {
+		key := reflect.New(mapType.Key()).Elem()
+		elem := reflect.New(mapType.Elem()).Elem()
+
+		if isSet {
+			if err := unmarshalValue(item, key); err != nil {
+				return ErrDecodedFieldUnmarshal.WithMsg("item %d", i).Wrap(err)
+			}
+
+			res.SetMapIndex(key, elem)
+
+			continue
+		}
+
+		entry, ok := getTupleValues(item)
+
+		if !ok || len(entry) != 2 {
+			return ErrUnmarshalTypeMismatch.WithMsg("expected key-value tuple for item %d, got %T", i, item)
+		}
+
+		if err := unmarshalValue(entry[0], key); err != nil {
+			return ErrDecodedFieldUnmarshal.WithMsg("key of item %d", i).Wrap(err)
+		}
+
+		if err := unmarshalValue(entry[1], elem); err != nil {
+			return ErrDecodedFieldUnmarshal.WithMsg("value of item %d", i).Wrap(err)
+		}
+
+		res.SetMapIndex(key, elem)
+	}
+
+	target.Set(res)
+
+	return nil
+}
+
+// unmarshalSetValue stores the value in a type with a SetValue method, and returns false for other targets.
+func unmarshalSetValue(value any, target reflect.Value) (bool, error) {
+	if !target.CanAddr() {
+		return false, nil
+	}
+
+	setValue := target.Addr().MethodByName(setValueMethodName)
+
+	if !setValue.IsValid() || setValue.Type().NumIn() != 1 {
+		return false, nil
+	}
+
+	setValueType := setValue.Type()
+
+	if setValueType.NumOut() > 1 || (setValueType.NumOut() == 1 && setValueType.Out(0) != errorType) {
+		return false, nil
+	}
+
+	if decodedFields, ok := value.(DecodedFields); ok && isKeepOpaqueFields(decodedFields) {
+		value = decodedFields[1].Value
+	}
+
+	arg := reflect.New(setValueType.In(0)).Elem()
+
+	if err := unmarshalValue(value, arg); err != nil {
+		return true, err
+	}
+
+	if out := setValue.Call([]reflect.Value{arg}); len(out) == 1 && !out[0].IsNil() {
+		return true, out[0].Interface().(error)
+	}
+
+	return true, nil
+}
+
+// unmarshalVariant stores the decoded variant in the provided target, which can be an option, a string or
+// a struct that follows the IsX/AsX convention.
+func unmarshalVariant(decodedVariant *DecodedVariant, target reflect.Value) error {
+	if isOptionVariant(decodedVariant) {
+		if ok, err := unmarshalOption(decodedVariant, target); ok {
+			return err
+		}
+
+		// Other targets are left unchanged for None and hold the inner value for Some.
+		if decodedVariant.Name == optionNoneVariantName {
+			return nil
+		}
+
+		return unmarshalValue(decodedVariant.Fields[0].Value, target)
+	}
+
+	if isResultVariant(decodedVariant) {
+		if ok, err := unmarshalResult(decodedVariant, target); ok {
+			return err
+		}
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(decodedVariant.Name)
+
+		return nil
+	case reflect.Pointer:
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+
+		return unmarshalVariant(decodedVariant, target.Elem())
+	case reflect.Struct:
+		return unmarshalVariantIntoStruct(decodedVariant, target)
+	default:
+		return newTypeMismatchError(decodedVariant, target)
+	}
+}
+
+// unmarshalCall stores the decoded call in the provided target, which can be a string or a struct.
+func unmarshalCall(decodedCall *DecodedCall, target reflect.Value) error {
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(decodedCall.Name())
+
+		return nil
+	case reflect.Pointer:
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+
+		return unmarshalCall(decodedCall, target.Elem())
+	case reflect.Struct:
+		return unmarshalFields(decodedCall.Fields, target)
+	default:
+		return newTypeMismatchError(decodedCall, target)
+	}
+}
+
+// unmarshalOption stores the option in a pointer or in a type with SetSome and SetNone methods,
+// and returns false for other targets.
+func unmarshalOption(decodedVariant *DecodedVariant, target reflect.Value) (bool, error) {
+	if target.Kind() == reflect.Pointer {
+		if decodedVariant.Name == optionNoneVariantName {
+			target.Set(reflect.Zero(target.Type()))
+
+			return true, nil
+		}
+
+		value := reflect.New(target.Type().Elem())
+
+		if err := unmarshalValue(decodedVariant.Fields[0].Value, value.Elem()); err != nil {
+			return true, err
+		}
+
+		target.Set(value)
+
+		return true, nil
+	}
+
+	if !target.CanAddr() {
+		return false, nil
+	}
+
+	setSome := target.Addr().MethodByName(setSomeMethodName)
+	setNone := target.Addr().MethodByName(setNoneMethodName)
+
+	if !setSome.IsValid() || !setNone.IsValid() || setSome.Type().NumIn() != 1 {
+		return false, nil
+	}
+
+	if decodedVariant.Name == optionNoneVariantName {
+		setNone.Call(nil)
+
+		return true, nil
+	}
+
+	value := reflect.New(setSome.Type().In(0)).Elem()
+
+	if err := unmarshalValue(decodedVariant.Fields[0].Value, value); err != nil {
+		return true, err
+	}
+
+	setSome.Call([]reflect.Value{value})
+
+	return true, nil
+}
+
+// unmarshalResult stores the result in a type with SetOk and SetErr methods, and returns false for other targets.
+func unmarshalResult(decodedVariant *DecodedVariant, target reflect.Value) (bool, error) {
+	if !target.CanAddr() {
+		return false, nil
+	}
+
+	methodName := setOkMethodName
+
+	if decodedVariant.Name == resultErrVariantName {
+		methodName = setErrMethodName
+	}
+
+	set := target.Addr().MethodByName(methodName)
+
+	if !set.IsValid() || set.Type().NumIn() != 1 {
+		return false, nil
+	}
+
+	value := reflect.New(set.Type().In(0)).Elem()
+
+	if err := unmarshalValue(decodedVariant.Fields[0].Value, value); err != nil {
+		return true, err
+	}
+
+	set.Call([]reflect.Value{value})
+
+	return true, nil
+}
+
+func unmarshalVariantIntoStruct(decodedVariant *DecodedVariant, target reflect.Value) error {
+	targetType := target.Type()
+
+	var isField, asField reflect.Value
+
+	for i := 0; i < targetType.NumField(); i++ {
+		structField := targetType.Field(i)
+
+		if !structField.IsExported() {
+			continue
+		}
+
+		tag := structField.Tag.Get(unmarshalTagName)
+
+		switch {
+		case tag == "is:"+decodedVariant.Name,
+			tag == "" && structField.Name == variantIsFieldPrefix+decodedVariant.Name:
+			isField = target.Field(i)
+		case tag == "as:"+decodedVariant.Name,
+			tag == "" && structField.Name == variantAsFieldPrefix+decodedVariant.Name:
+			asField = target.Field(i)
+		}
+	}
+
+	if !isField.IsValid() || isField.Kind() != reflect.Bool {
+		return ErrUnmarshalTypeMismatch.WithMsg("variant '%s' not found in %s", decodedVariant.Name, targetType)
+	}
+
+	isField.SetBool(true)
+
+	if len(decodedVariant.Fields) == 0 || !asField.IsValid() {
+		return nil
+	}
+
+	if len(decodedVariant.Fields) == 1 {
+		return unmarshalValue(decodedVariant.Fields[0].Value, asField)
+	}
+
+	return unmarshalValue(decodedVariant.Fields, asField)
+}
+
+func setBigInt(bigInt *big.Int, target reflect.Value) error {
+	switch {
+	case target.Type() == bigIntType:
+		target.Set(reflect.ValueOf(*new(big.Int).Set(bigInt)))
+
+		return nil
+	case target.Type() == u128Type:
+		target.Set(reflect.ValueOf(types.NewU128(*bigInt)))
+
+		return nil
+	case target.Type() == u256Type:
+		target.Set(reflect.ValueOf(types.NewU256(*bigInt)))
+
+		return nil
+	case target.Type() == i128Type:
+		target.Set(reflect.ValueOf(types.NewI128(*bigInt)))
+
+		return nil
+	case target.Type() == i256Type:
+		target.Set(reflect.ValueOf(types.NewI256(*bigInt)))
+
+		return nil
+	case target.Type() == uCompactType:
+		target.Set(reflect.ValueOf(types.NewUCompact(bigInt)))
+
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if bigInt.Sign() < 0 || !bigInt.IsUint64() || target.OverflowUint(bigInt.Uint64()) {
+			return ErrUnmarshalIntegerOverflow.WithMsg("value %s, type %s", bigInt, target.Type())
+		}
+
+		target.SetUint(bigInt.Uint64())
+
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !bigInt.IsInt64() || target.OverflowInt(bigInt.Int64()) {
+			return ErrUnmarshalIntegerOverflow.WithMsg("value %s, type %s", bigInt, target.Type())
+		}
+
+		target.SetInt(bigInt.Int64())
+
+		return nil
+	}
+
+	return ErrUnmarshalTypeMismatch.WithMsg("integer %s, type %s", bigInt, target.Type())
+}
+
+func isBigIntType(t reflect.Type) bool {
+	switch t {
+	case bigIntType, u128Type, u256Type, i128Type, i256Type, uCompactType:
+		return true
+	default:
+		return false
+	}
+}
+
+//nolint:gocyclo
+func getBigInt(value any) (*big.Int, bool) {
+	switch v := value.(type) {
+	case types.U8:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case types.U16:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case types.U32:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case types.U64:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case types.I8:
+		return big.NewInt(int64(v)), true
+	case types.I16:
+		return big.NewInt(int64(v)), true
+	case types.I32:
+		return big.NewInt(int64(v)), true
+	case types.I64:
+		return big.NewInt(int64(v)), true
+	case types.U128:
+		return getNonNilBigInt(v.Int), true
+	case types.U256:
+		return getNonNilBigInt(v.Int), true
+	case types.I128:
+		return getNonNilBigInt(v.Int), true
+	case types.I256:
+		return getNonNilBigInt(v.Int), true
+	case types.UCompact:
+		return new(big.Int).Set((*big.Int)(&v)), true
+	default:
+		return nil, false
+	}
+}
+
+func getNonNilBigInt(bigInt *big.Int) *big.Int {
+	if bigInt == nil {
+		return big.NewInt(0)
+	}
+
+	return bigInt
+}
+
+// getItems returns the items of a decoded sequence or array, unwrapping composites with a single field.
+func getItems(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case DecodedFields:
+		if len(v) != 1 {
+			return nil, false
+		}
+
+		return getItems(v[0].Value)
+	default:
+		return nil, false
+	}
+}
+
+// getTupleValues returns the values of a decoded tuple.
+func getTupleValues(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case DecodedFields:
+		if !isTupleFields(v) {
+			return nil, false
+		}
+
+		values := make([]any, 0, len(v))
+
+		for _, decodedField := range v {
+			values = append(values, decodedField.Value)
+		}
+
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
+func getUnmarshalFieldName(structField reflect.StructField) (string, bool) {
+	tag := structField.Tag.Get(unmarshalTagName)
+
+	switch {
+	case tag == "-":
+		return "", false
+	case tag != "" && !strings.Contains(tag, ":"):
+		return tag, true
+	default:
+		return structField.Name, true
+	}
+}
+
+func findDecodedField(decodedFields DecodedFields, fieldName string) *DecodedField {
+	normalizedFieldName := normalizeFieldName(fieldName)
+
+	for _, decodedField := range decodedFields {
+		name := decodedField.Name
+
+		if i := strings.LastIndex(name, fieldSeparator); i >= 0 {
+			name = name[i+len(fieldSeparator):]
+		}
+
+		if normalizeFieldName(name) == normalizedFieldName {
+			return decodedField
+		}
+	}
+
+	return nil
+}
+
+func normalizeFieldName(fieldName string) string {
+	return strings.ToLower(strings.ReplaceAll(fieldName, "_", ""))
+}
+
+func isTupleFields(decodedFields DecodedFields) bool {
+	if len(decodedFields) == 0 {
+		return false
+	}
+
+	for i, decodedField := range decodedFields {
+		if !strings.HasSuffix(decodedField.Name, fmt.Sprintf(tupleItemFieldNameFormat, i)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isOptionVariant(decodedVariant *DecodedVariant) bool {
+	switch decodedVariant.Name {
+	case optionNoneVariantName:
+		return len(decodedVariant.Fields) == 0
+	case optionSomeVariantName:
+		return len(decodedVariant.Fields) == 1
+	default:
+		return false
+	}
+}
+
+func isResultVariant(decodedVariant *DecodedVariant) bool {
+	switch decodedVariant.Name {
+	case resultOkVariantName, resultErrVariantName:
+		return len(decodedVariant.Fields) == 1
+	default:
+		return false
+	}
+}
+
+// isKeepOpaqueFields returns true for the fields of a composite that holds a compact length and a value, such as
+// WrapperKeepOpaque.
+func isKeepOpaqueFields(decodedFields DecodedFields) bool {
+	if len(decodedFields) != 2 {
+		return false
+	}
+
+	_, ok := decodedFields[0].Value.(types.UCompact)
+
+	return ok
+}
+
+func newTypeMismatchError(value any, target reflect.Value) error {
+	return ErrUnmarshalTypeMismatch.WithMsg("cannot unmarshal %T into %s", value, target.Type())
+}
@@ -0,0 +1,418 @@
+package registry
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshal_Event(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	eventRegistry, err := NewFactory().CreateEventRegistry(&meta)
+	assert.NoError(t, err)
+
+	from := types.AccountID{1}
+	to := types.AccountID{2}
+
+	encodedAmount, err := codec.Encode(types.NewU128(*big.NewInt(1_000)))
+	assert.NoError(t, err)
+
+	eventData := append(append(from[:], to[:]...), encodedAmount...)
+
+	var decodedFields DecodedFields
+
+	for _, eventDecoder := range eventRegistry {
+		if eventDecoder.Name != "Balances.Transfer" {
+			continue
+		}
+
+		decodedFields, err = eventDecoder.Decode(scale.NewDecoder(bytes.NewReader(eventData)))
+		assert.NoError(t, err)
+	}
+
+	var transfer struct {
+		From   types.AccountID
+		To     types.AccountID
+		Amount types.U128
+	}
+
+	err = Unmarshal(decodedFields, &transfer)
+	assert.NoError(t, err)
+	assert.Equal(t, from, transfer.From)
+	assert.Equal(t, to, transfer.To)
+	assert.Equal(t, types.NewU128(*big.NewInt(1_000)), transfer.Amount)
+
+	var taggedTransfer struct {
+		Sender    []byte   `scale:"from"`
+		Recipient [32]byte `scale:"to"`
+		Value     *big.Int `scale:"amount"`
+		Amount    uint16
+		Ignored   uint8 `scale:"-"`
+	}
+
+	err = Unmarshal(decodedFields, &taggedTransfer)
+	assert.NoError(t, err)
+	assert.Equal(t, from[:], taggedTransfer.Sender)
+	assert.Equal(t, [32]byte(to), taggedTransfer.Recipient)
+	assert.Equal(t, big.NewInt(1_000), taggedTransfer.Value)
+	assert.Equal(t, uint16(1_000), taggedTransfer.Amount)
+	assert.Zero(t, taggedTransfer.Ignored)
+}
+
+type testDispatchError struct {
+	IsBadOrigin bool
+	IsToken     bool
+	AsToken     string
+	IsModule    bool `scale:"is:Module"`
+	ModuleError struct {
+		Index types.U8
+		Error [4]uint8
+	} `scale:"as:Module"`
+}
+
+func TestUnmarshal_Variants(t *testing.T) {
+	moduleError := &DecodedVariant{
+		Index: 3,
+		Name:  "Module",
+		Fields: DecodedFields{
+			{
+				Name: "sp_runtime.ModuleError.ModuleError",
+				Value: DecodedFields{
+					{Name: "index", Value: types.U8(5)},
+					{Name: "error", Value: []any{types.U8(2), types.U8(0), types.U8(0), types.U8(0)}},
+				},
+			},
+		},
+	}
+
+	tokenError := &DecodedVariant{
+		Index: 7,
+		Name:  "Token",
+		Fields: DecodedFields{
+			{Name: "sp_runtime.TokenError.TokenError", Value: &DecodedVariant{Index: 0, Name: "FundsUnavailable"}},
+		},
+	}
+
+	var res struct {
+		BadOrigin testDispatchError
+		Module    testDispatchError
+		Token     *testDispatchError
+		Name      string `scale:"module"`
+	}
+
+	err := Unmarshal(DecodedFields{
+		{Name: "bad_origin", Value: &DecodedVariant{Index: 2, Name: "BadOrigin"}},
+		{Name: "module", Value: moduleError},
+		{Name: "token", Value: tokenError},
+	}, &res)
+	assert.NoError(t, err)
+
+	assert.True(t, res.BadOrigin.IsBadOrigin)
+	assert.False(t, res.BadOrigin.IsModule)
+
+	assert.True(t, res.Module.IsModule)
+	assert.Equal(t, types.U8(5), res.Module.ModuleError.Index)
+	assert.Equal(t, [4]uint8{2}, res.Module.ModuleError.Error)
+
+	assert.True(t, res.Token.IsToken)
+	assert.Equal(t, "FundsUnavailable", res.Token.AsToken)
+
+	assert.Equal(t, "Module", res.Name)
+
+	var decodedVariant *DecodedVariant
+
+	err = UnmarshalValue(moduleError, &decodedVariant)
+	assert.NoError(t, err)
+	assert.Equal(t, moduleError, decodedVariant)
+
+	var dispatchError testDispatchError
+
+	err = UnmarshalValue(&DecodedVariant{Index: 0, Name: "Other"}, &dispatchError)
+	assert.ErrorIs(t, err, ErrUnmarshalTypeMismatch)
+}
+
+func TestUnmarshal_Options(t *testing.T) {
+	some := &DecodedVariant{
+		Index:  1,
+		Name:   "Some",
+		Fields: DecodedFields{{Name: "u32", Value: types.U32(7)}},
+	}
+	none := &DecodedVariant{Index: 0, Name: "None"}
+
+	var res struct {
+		SomePointer  *uint32
+		NonePointer  *uint32
+		SomeOption   types.Option[types.U32]
+		NoneOption   types.Option[types.U32]
+		SomeValue    uint64
+		NoneValue    uint64
+		NestedOption **uint32
+	}
+
+	res.NonePointer = new(uint32)
+	res.NoneOption = types.NewOption[types.U32](1)
+	res.NoneValue = 1
+
+	err := Unmarshal(DecodedFields{
+		{Name: "some_pointer", Value: some},
+		{Name: "none_pointer", Value: none},
+		{Name: "some_option", Value: some},
+		{Name: "none_option", Value: none},
+		{Name: "some_value", Value: some},
+		{Name: "none_value", Value: none},
+		{Name: "nested_option", Value: some},
+	}, &res)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint32(7), *res.SomePointer)
+	assert.Nil(t, res.NonePointer)
+	assert.Equal(t, types.NewOption[types.U32](7), res.SomeOption)
+	assert.Equal(t, types.NewEmptyOption[types.U32](), res.NoneOption)
+	assert.Equal(t, uint64(7), res.SomeValue)
+	assert.Equal(t, uint64(1), res.NoneValue)
+	assert.Equal(t, uint32(7), **res.NestedOption)
+}
+
+func TestUnmarshal_CollectionsAndTuples(t *testing.T) {
+	type item struct {
+		Key   string
+		Value types.UCompact
+	}
+
+	var res struct {
+		Items  []item
+		Tuples [][2]uint8
+		Pair   struct {
+			First  types.U16
+			Second bool
+		}
+		Hash  types.Hash
+		Empty []types.U8
+		Any   any
+	}
+
+	hashBytes := make([]any, 32)
+
+	for i := range hashBytes {
+		hashBytes[i] = types.U8(i)
+	}
+
+	err := Unmarshal(DecodedFields{
+		{
+			Name: "items",
+			Value: []any{
+				DecodedFields{
+					{Name: "path.key", Value: "first"},
+					{Name: "path.value", Value: types.NewUCompactFromUInt(1)},
+				},
+			},
+		},
+		{
+			Name: "tuples",
+			Value: []any{
+				[]any{types.U8(1), types.U8(2)},
+			},
+		},
+		{
+			Name: "pair",
+			Value: DecodedFields{
+				{Name: "tuple_item_0", Value: types.U16(3)},
+				{Name: "tuple_item_1", Value: true},
+			},
+		},
+		{Name: "hash", Value: DecodedFields{{Name: "[u8; 32]", Value: hashBytes}}},
+		{Name: "empty", Value: []any{}},
+		{Name: "any", Value: types.U64(4)},
+	}, &res)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []item{{Key: "first", Value: types.NewUCompactFromUInt(1)}}, res.Items)
+	assert.Equal(t, [][2]uint8{{1, 2}}, res.Tuples)
+	assert.Equal(t, types.U16(3), res.Pair.First)
+	assert.True(t, res.Pair.Second)
+	assert.Equal(t, types.Hash{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}, res.Hash)
+	assert.Empty(t, res.Empty)
+	assert.Equal(t, types.U64(4), res.Any)
+}
+
+type testUnmarshalBound struct{}
+
+func (testUnmarshalBound) Bound() int { return 2 }
+
+func TestUnmarshal_GenericContainers(t *testing.T) {
+	tupleDecoder := func(key, value FieldDecoder) FieldDecoder {
+		return &CompositeDecoder{
+			Fields: []*Field{
+				{Name: "tuple_item_0", FieldDecoder: key},
+				{Name: "tuple_item_1", FieldDecoder: value},
+			},
+		}
+	}
+
+	// The decoders are built like the ones of the factory for the metadata types.
+	typeDecoder := &TypeDecoder{
+		Name: "test",
+		Fields: []*Field{
+			{
+				Name: "map",
+				FieldDecoder: &CompositeDecoder{
+					Fields: []*Field{
+						{
+							Name: "lookup_index_1",
+							FieldDecoder: &SliceDecoder{
+								ItemDecoder: tupleDecoder(&ValueDecoder[types.U32]{}, &ValueDecoder[string]{}),
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "set",
+				FieldDecoder: &CompositeDecoder{
+					Fields: []*Field{
+						{Name: "lookup_index_2", FieldDecoder: &SliceDecoder{ItemDecoder: &ValueDecoder[types.U8]{}}},
+					},
+				},
+			},
+			{
+				Name: "ok",
+				FieldDecoder: &VariantDecoder{
+					FieldDecoderMap: map[byte]FieldDecoder{
+						0: &CompositeDecoder{Fields: []*Field{{Name: "u16", FieldDecoder: &ValueDecoder[types.U16]{}}}},
+					},
+					VariantNameMap: map[byte]string{0: "Ok", 1: "Err"},
+				},
+			},
+			{
+				Name: "err",
+				FieldDecoder: &VariantDecoder{
+					FieldDecoderMap: map[byte]FieldDecoder{
+						1: &CompositeDecoder{Fields: []*Field{{Name: "text", FieldDecoder: &ValueDecoder[string]{}}}},
+					},
+					VariantNameMap: map[byte]string{0: "Ok", 1: "Err"},
+				},
+			},
+			{
+				Name:         "compact",
+				FieldDecoder: &ValueDecoder[types.UCompact]{},
+			},
+			{
+				Name: "opaque",
+				FieldDecoder: &CompositeDecoder{
+					Fields: []*Field{
+						{Name: "lookup_index_3", FieldDecoder: &ValueDecoder[types.UCompact]{}},
+						{Name: "T", FieldDecoder: &ValueDecoder[types.U32]{}},
+					},
+				},
+			},
+			{
+				Name: "bounded",
+				FieldDecoder: &CompositeDecoder{
+					Fields: []*Field{
+						{Name: "Vec<T>", FieldDecoder: &SliceDecoder{ItemDecoder: &ValueDecoder[types.U8]{}}},
+					},
+				},
+			},
+		},
+	}
+
+	type testTarget struct {
+		Map     types.BTreeMap[uint32, string]
+		Set     types.BTreeSet[types.U8]
+		Ok      types.Result[types.U16, types.Text]
+		Err     types.Result[types.U16, types.Text]
+		Compact types.Compact[types.U64]
+		Opaque  types.WrapperKeepOpaque[types.U32]
+		Bounded types.BoundedVec[types.U8, testUnmarshalBound]
+	}
+
+	opaque, err := types.NewWrapperKeepOpaque[types.U32](7)
+	assert.NoError(t, err)
+
+	expected := testTarget{
+		Map:     types.BTreeMap[uint32, string]{1: "a", 2: "b"},
+		Set:     types.NewBTreeSet[types.U8](3, 4),
+		Ok:      types.NewOkResult[types.U16, types.Text](5),
+		Err:     types.NewErrResult[types.U16, types.Text]("error"),
+		Compact: types.NewCompact[types.U64](6),
+		Opaque:  opaque,
+		Bounded: types.BoundedVec[types.U8, testUnmarshalBound]{8},
+	}
+
+	encoded, err := codec.Encode(struct {
+		Map     types.BTreeMap[types.U32, types.Text]
+		Set     types.BTreeSet[types.U8]
+		Ok      types.Result[types.U16, types.Text]
+		Err     types.Result[types.U16, types.Text]
+		Compact types.Compact[types.U64]
+		Opaque  types.WrapperKeepOpaque[types.U32]
+		Bounded []types.U8
+	}{
+		Map:     types.BTreeMap[types.U32, types.Text]{1: "a", 2: "b"},
+		Set:     expected.Set,
+		Ok:      expected.Ok,
+		Err:     expected.Err,
+		Compact: expected.Compact,
+		Opaque:  expected.Opaque,
+		Bounded: expected.Bounded,
+	})
+	assert.NoError(t, err)
+
+	decodedFields, err := typeDecoder.Decode(scale.NewDecoderFromBytes(encoded))
+	assert.NoError(t, err)
+
+	var res testTarget
+
+	err = Unmarshal(decodedFields, &res)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+
+	var overflow types.Compact[types.U8]
+
+	err = UnmarshalValue(types.NewUCompactFromUInt(256), &overflow)
+	assert.ErrorIs(t, err, ErrUnmarshalIntegerOverflow)
+
+	var invalidMap map[uint32]string
+
+	err = UnmarshalValue([]any{types.U32(1)}, &invalidMap)
+	assert.ErrorIs(t, err, ErrUnmarshalTypeMismatch)
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	var target struct {
+		Value uint8
+	}
+
+	err := Unmarshal(nil, target)
+	assert.ErrorIs(t, err, ErrInvalidUnmarshalTarget)
+
+	err = Unmarshal(nil, new(uint8))
+	assert.ErrorIs(t, err, ErrInvalidUnmarshalTarget)
+
+	err = UnmarshalValue(types.U8(1), nil)
+	assert.ErrorIs(t, err, ErrInvalidUnmarshalTarget)
+
+	err = Unmarshal(DecodedFields{{Name: "value", Value: types.U16(256)}}, &target)
+	assert.ErrorIs(t, err, ErrDecodedFieldUnmarshal)
+	assert.ErrorIs(t, err, ErrUnmarshalIntegerOverflow)
+
+	err = Unmarshal(DecodedFields{{Name: "value", Value: types.I8(-1)}}, &target)
+	assert.ErrorIs(t, err, ErrUnmarshalIntegerOverflow)
+
+	err = Unmarshal(DecodedFields{{Name: "value", Value: "test"}}, &target)
+	assert.ErrorIs(t, err, ErrUnmarshalTypeMismatch)
+
+	var arrayTarget [2]uint8
+
+	err = UnmarshalValue([]any{types.U8(1)}, &arrayTarget)
+	assert.ErrorIs(t, err, ErrUnmarshalTypeMismatch)
+}
@@ -0,0 +1,168 @@
+package upgrade
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+//go:generate mockery --name BoundaryDetector --structname BoundaryDetectorMock --filename boundary_detector_mock.go --inpackage
+
+// BoundaryDetector is the interface used for finding the blocks at which the runtime spec version changed.
+type BoundaryDetector interface {
+	// DetectRanges returns the spec version ranges found between the provided blocks, inclusive.
+	DetectRanges(fromBlock, toBlock uint64) ([]SpecVersionRange, error)
+	// DetectRangesCtx is like DetectRanges, the spec version lookups are bound to the provided context.
+	DetectRangesCtx(ctx context.Context, fromBlock, toBlock uint64) ([]SpecVersionRange, error)
+	// UpdateMap extends the provided map, starting with its last block, up to toBlock.
+	UpdateMap(specVersionMap *SpecVersionMap, toBlock uint64) error
+	// UpdateMapCtx is like UpdateMap, the spec version lookups are bound to the provided context.
+	UpdateMapCtx(ctx context.Context, specVersionMap *SpecVersionMap, toBlock uint64) error
+}
+
+// boundaryDetector implements the BoundaryDetector interface.
+//
+// The spec version change points are found using a binary search, which relies on spec versions never decreasing.
+// This is enforced by frame_system when setting new runtime code.
+type boundaryDetector struct {
+	specVersionProvider SpecVersionProvider
+}
+
+// NewBoundaryDetector creates a new BoundaryDetector.
+func NewBoundaryDetector(specVersionProvider SpecVersionProvider) BoundaryDetector {
+	return &boundaryDetector{
+		specVersionProvider: specVersionProvider,
+	}
+}
+
+// NewDefaultBoundaryDetector creates a new BoundaryDetector that uses state_getRuntimeVersion.
+func NewDefaultBoundaryDetector(chainRPC chain.Chain, stateRPC state.State) BoundaryDetector {
+	return NewBoundaryDetector(NewRuntimeVersionProvider(chainRPC, stateRPC))
+}
+
+// DetectRanges returns the spec version ranges found between the provided blocks, inclusive.
+//
+// The number of spec version lookups is logarithmic in the size of the block range for each runtime upgrade.
+func (b *boundaryDetector) DetectRanges(fromBlock, toBlock uint64) ([]SpecVersionRange, error) {
+	return b.DetectRangesCtx(context.Background(), fromBlock, toBlock)
+}
+
+// DetectRangesCtx is like DetectRanges, the spec version lookups are bound to the provided context.
+func (b *boundaryDetector) DetectRangesCtx(ctx context.Context, fromBlock, toBlock uint64) ([]SpecVersionRange, error) {
+	if fromBlock > toBlock {
+		return nil, ErrInvalidBlockRange
+	}
+
+	fromSpecVersion, err := b.getSpecVersion(ctx, fromBlock)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toSpecVersion, err := b.getSpecVersion(ctx, toBlock)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := []SpecVersionRange{
+		{
+			SpecVersion: fromSpecVersion,
+			FromBlock:   fromBlock,
+			ToBlock:     toBlock,
+		},
+	}
+
+	if err := b.findBoundaries(ctx, fromBlock, fromSpecVersion, toBlock, toSpecVersion, &ranges); err != nil {
+		return nil, err
+	}
+
+	return ranges, nil
+}
+
+// UpdateMap detects the ranges between the last block of the map and toBlock, and adds them to the map.
+//
+// The detection starts at block 0 if the map is empty.
+func (b *boundaryDetector) UpdateMap(specVersionMap *SpecVersionMap, toBlock uint64) error {
+	return b.UpdateMapCtx(context.Background(), specVersionMap, toBlock)
+}
+
+// UpdateMapCtx is like UpdateMap, the spec version lookups are bound to the provided context.
+func (b *boundaryDetector) UpdateMapCtx(ctx context.Context, specVersionMap *SpecVersionMap, toBlock uint64) error {
+	fromBlock, ok := specVersionMap.LastBlock()
+
+	if ok && fromBlock >= toBlock {
+		return nil
+	}
+
+	ranges, err := b.DetectRangesCtx(ctx, fromBlock, toBlock)
+
+	if err != nil {
+		return err
+	}
+
+	if err := specVersionMap.Add(ranges...); err != nil {
+		return ErrSpecVersionMapUpdate.Wrap(err)
+	}
+
+	return nil
+}
+
+// findBoundaries looks for spec version changes between the provided blocks and splits the last range in ranges
+// at each one of them.
+//
+// The blocks are processed in order, which means that the last range in ranges always ends with highBlock.
+func (b *boundaryDetector) findBoundaries(
+	ctx context.Context,
+	lowBlock uint64,
+	lowSpecVersion types.U32,
+	highBlock uint64,
+	highSpecVersion types.U32,
+	ranges *[]SpecVersionRange,
+) error {
+	if lowSpecVersion == highSpecVersion {
+		return nil
+	}
+
+	if highBlock-lowBlock == 1 {
+		last := &(*ranges)[len(*ranges)-1]
+
+		toBlock := last.ToBlock
+
+		last.ToBlock = lowBlock
+
+		*ranges = append(*ranges, SpecVersionRange{
+			SpecVersion: highSpecVersion,
+			FromBlock:   highBlock,
+			ToBlock:     toBlock,
+		})
+
+		return nil
+	}
+
+	midBlock := lowBlock + (highBlock-lowBlock)/2
+
+	midSpecVersion, err := b.getSpecVersion(ctx, midBlock)
+
+	if err != nil {
+		return err
+	}
+
+	if err := b.findBoundaries(ctx, lowBlock, lowSpecVersion, midBlock, midSpecVersion, ranges); err != nil {
+		return err
+	}
+
+	return b.findBoundaries(ctx, midBlock, midSpecVersion, highBlock, highSpecVersion, ranges)
+}
+
+func (b *boundaryDetector) getSpecVersion(ctx context.Context, blockNumber uint64) (types.U32, error) {
+	specVersion, err := b.specVersionProvider.GetSpecVersionCtx(ctx, blockNumber)
+
+	if err != nil {
+		return 0, ErrSpecVersionRetrieval.Wrap(err)
+	}
+
+	return specVersion, nil
+}
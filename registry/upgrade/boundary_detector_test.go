@@ -0,0 +1,221 @@
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testSpecVersionProvider returns the spec version of a block based on a list of upgrade blocks and
+// records the number of lookups.
+type testSpecVersionProvider struct {
+	upgradeBlocks []uint64
+	lookupCount   int
+}
+
+func (p *testSpecVersionProvider) GetSpecVersion(blockNumber uint64) (types.U32, error) {
+	return p.GetSpecVersionCtx(context.Background(), blockNumber)
+}
+
+func (p *testSpecVersionProvider) GetSpecVersionCtx(_ context.Context, blockNumber uint64) (types.U32, error) {
+	p.lookupCount++
+
+	specVersion := types.U32(1)
+
+	for _, upgradeBlock := range p.upgradeBlocks {
+		if blockNumber >= upgradeBlock {
+			specVersion++
+		}
+	}
+
+	return specVersion, nil
+}
+
+func TestBoundaryDetector_DetectRanges(t *testing.T) {
+	testCases := []struct {
+		upgradeBlocks []uint64
+		fromBlock     uint64
+		toBlock       uint64
+		expected      []SpecVersionRange
+	}{
+		{
+			upgradeBlocks: nil,
+			fromBlock:     0,
+			toBlock:       1_000_000,
+			expected: []SpecVersionRange{
+				{SpecVersion: 1, FromBlock: 0, ToBlock: 1_000_000},
+			},
+		},
+		{
+			upgradeBlocks: []uint64{1, 2, 3},
+			fromBlock:     0,
+			toBlock:       3,
+			expected: []SpecVersionRange{
+				{SpecVersion: 1, FromBlock: 0, ToBlock: 0},
+				{SpecVersion: 2, FromBlock: 1, ToBlock: 1},
+				{SpecVersion: 3, FromBlock: 2, ToBlock: 2},
+				{SpecVersion: 4, FromBlock: 3, ToBlock: 3},
+			},
+		},
+		{
+			upgradeBlocks: []uint64{12_345, 500_000, 500_001, 999_999},
+			fromBlock:     100,
+			toBlock:       1_000_000,
+			expected: []SpecVersionRange{
+				{SpecVersion: 1, FromBlock: 100, ToBlock: 12_344},
+				{SpecVersion: 2, FromBlock: 12_345, ToBlock: 499_999},
+				{SpecVersion: 3, FromBlock: 500_000, ToBlock: 500_000},
+				{SpecVersion: 4, FromBlock: 500_001, ToBlock: 999_998},
+				{SpecVersion: 5, FromBlock: 999_999, ToBlock: 1_000_000},
+			},
+		},
+		{
+			upgradeBlocks: []uint64{10},
+			fromBlock:     10,
+			toBlock:       10,
+			expected: []SpecVersionRange{
+				{SpecVersion: 2, FromBlock: 10, ToBlock: 10},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		specVersionProvider := &testSpecVersionProvider{upgradeBlocks: testCase.upgradeBlocks}
+
+		res, err := NewBoundaryDetector(specVersionProvider).DetectRanges(testCase.fromBlock, testCase.toBlock)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, res)
+
+		// Each upgrade should require a number of lookups that is logarithmic in the size of the range.
+		assert.LessOrEqual(t, specVersionProvider.lookupCount, 2+len(testCase.upgradeBlocks)*2*20)
+	}
+}
+
+func TestBoundaryDetector_DetectRanges_Errors(t *testing.T) {
+	specVersionProviderMock := NewSpecVersionProviderMock(t)
+
+	boundaryDetector := NewBoundaryDetector(specVersionProviderMock)
+
+	res, err := boundaryDetector.DetectRanges(2, 1)
+	assert.ErrorIs(t, err, ErrInvalidBlockRange)
+	assert.Nil(t, res)
+
+	specVersionError := errors.New("error")
+
+	specVersionProviderMock.On("GetSpecVersionCtx", mock.Anything, uint64(0)).
+		Return(types.U32(0), specVersionError).
+		Once()
+
+	res, err = boundaryDetector.DetectRanges(0, 10)
+	assert.ErrorIs(t, err, ErrSpecVersionRetrieval)
+	assert.Nil(t, res)
+
+	specVersionProviderMock.On("GetSpecVersionCtx", mock.Anything, uint64(0)).
+		Return(types.U32(1), nil).
+		Twice()
+	specVersionProviderMock.On("GetSpecVersionCtx", mock.Anything, uint64(10)).
+		Return(types.U32(0), specVersionError).
+		Once()
+
+	res, err = boundaryDetector.DetectRanges(0, 10)
+	assert.ErrorIs(t, err, ErrSpecVersionRetrieval)
+	assert.Nil(t, res)
+
+	specVersionProviderMock.On("GetSpecVersionCtx", mock.Anything, uint64(10)).
+		Return(types.U32(2), nil).
+		Once()
+	specVersionProviderMock.On("GetSpecVersionCtx", mock.Anything, uint64(5)).
+		Return(types.U32(0), specVersionError).
+		Once()
+
+	res, err = boundaryDetector.DetectRanges(0, 10)
+	assert.ErrorIs(t, err, ErrSpecVersionRetrieval)
+	assert.Nil(t, res)
+}
+
+func TestBoundaryDetector_DetectRangesCtx(t *testing.T) {
+	specVersionProviderMock := NewSpecVersionProviderMock(t)
+
+	boundaryDetector := NewBoundaryDetector(specVersionProviderMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	specVersionProviderMock.On("GetSpecVersionCtx", ctx, uint64(0)).
+		Return(types.U32(0), ctx.Err()).
+		Once()
+
+	res, err := boundaryDetector.DetectRangesCtx(ctx, 0, 10)
+	assert.ErrorIs(t, err, ErrSpecVersionRetrieval)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, res)
+}
+
+func TestBoundaryDetector_UpdateMap(t *testing.T) {
+	specVersionProvider := &testSpecVersionProvider{upgradeBlocks: []uint64{5, 15}}
+
+	boundaryDetector := NewBoundaryDetector(specVersionProvider)
+
+	specVersionMap, err := NewSpecVersionMap()
+	assert.NoError(t, err)
+
+	err = boundaryDetector.UpdateMap(specVersionMap, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []SpecVersionRange{
+		{SpecVersion: 1, FromBlock: 0, ToBlock: 4},
+		{SpecVersion: 2, FromBlock: 5, ToBlock: 10},
+	}, specVersionMap.GetRanges())
+
+	err = boundaryDetector.UpdateMap(specVersionMap, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, []SpecVersionRange{
+		{SpecVersion: 1, FromBlock: 0, ToBlock: 4},
+		{SpecVersion: 2, FromBlock: 5, ToBlock: 14},
+		{SpecVersion: 3, FromBlock: 15, ToBlock: 20},
+	}, specVersionMap.GetRanges())
+
+	lookupCount := specVersionProvider.lookupCount
+
+	err = boundaryDetector.UpdateMap(specVersionMap, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, lookupCount, specVersionProvider.lookupCount)
+
+	// The last block of the map no longer has the same spec version, e.g. the map was built for a different chain.
+	specVersionMap, err = NewSpecVersionMap(SpecVersionRange{SpecVersion: 1, FromBlock: 0, ToBlock: 10})
+	assert.NoError(t, err)
+
+	err = boundaryDetector.UpdateMap(specVersionMap, 20)
+	assert.ErrorIs(t, err, ErrSpecVersionMapUpdate)
+	assert.Equal(t, []SpecVersionRange{{SpecVersion: 1, FromBlock: 0, ToBlock: 10}}, specVersionMap.GetRanges())
+}
+
+func TestBoundaryDetector_UpdateMap_DetectionError(t *testing.T) {
+	specVersionProviderMock := NewSpecVersionProviderMock(t)
+
+	specVersionMap, err := NewSpecVersionMap()
+	assert.NoError(t, err)
+
+	specVersionError := errors.New("error")
+
+	specVersionProviderMock.On("GetSpecVersionCtx", mock.Anything, mock.Anything).
+		Return(types.U32(0), specVersionError).
+		Once()
+
+	err = NewBoundaryDetector(specVersionProviderMock).UpdateMap(specVersionMap, 10)
+	assert.ErrorIs(t, err, ErrSpecVersionRetrieval)
+}
+
+func TestBoundaryDetector_NewDefault(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	stateRPCMock := stateMocks.NewState(t)
+
+	res := NewDefaultBoundaryDetector(chainRPCMock, stateRPCMock)
+	assert.IsType(t, &boundaryDetector{}, res)
+	assert.IsType(t, &runtimeVersionProvider{}, res.(*boundaryDetector).specVersionProvider)
+}
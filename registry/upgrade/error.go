@@ -0,0 +1,16 @@
+package upgrade
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrBlockHashRetrieval            = libErr.Error("block hash retrieval")
+	ErrRuntimeVersionRetrieval       = libErr.Error("runtime version retrieval")
+	ErrStorageKeyCreation            = libErr.Error("storage key creation")
+	ErrLastRuntimeUpgradeRetrieval   = libErr.Error("last runtime upgrade retrieval")
+	ErrSpecVersionRetrieval          = libErr.Error("spec version retrieval")
+	ErrInvalidBlockRange             = libErr.Error("invalid block range")
+	ErrNonContiguousSpecVersionRange = libErr.Error("non-contiguous spec version range")
+	ErrSpecVersionMapUpdate          = libErr.Error("spec version map update")
+	ErrSpecVersionMapLoading         = libErr.Error("spec version map loading")
+	ErrSpecVersionMapSaving          = libErr.Error("spec version map saving")
+)
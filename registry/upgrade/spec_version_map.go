@@ -0,0 +1,208 @@
+package upgrade
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// SpecVersionRange holds the inclusive block range in which a spec version was active.
+type SpecVersionRange struct {
+	SpecVersion types.U32 `json:"specVersion"`
+	FromBlock   uint64    `json:"fromBlock"`
+	ToBlock     uint64    `json:"toBlock"`
+}
+
+// SpecVersionMap maps block numbers to spec versions using contiguous, ordered, SpecVersionRange(s).
+//
+// It is safe for concurrent use and it can be consulted while being updated by a BoundaryDetector.
+type SpecVersionMap struct {
+	mu     sync.RWMutex
+	ranges []SpecVersionRange
+}
+
+// NewSpecVersionMap creates a new SpecVersionMap that contains the provided ranges.
+func NewSpecVersionMap(ranges ...SpecVersionRange) (*SpecVersionMap, error) {
+	m := &SpecVersionMap{}
+
+	if err := m.Add(ranges...); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetSpecVersion returns the spec version that was active at the provided block, if the block is covered
+// by the map.
+func (m *SpecVersionMap) GetSpecVersion(blockNumber uint64) (types.U32, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	i := sort.Search(len(m.ranges), func(i int) bool {
+		return m.ranges[i].ToBlock >= blockNumber
+	})
+
+	if i == len(m.ranges) || m.ranges[i].FromBlock > blockNumber {
+		return 0, false
+	}
+
+	return m.ranges[i].SpecVersion, true
+}
+
+// GetRange returns the block range of the provided spec version.
+func (m *SpecVersionMap) GetRange(specVersion types.U32) (SpecVersionRange, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, r := range m.ranges {
+		if r.SpecVersion == specVersion {
+			return r, true
+		}
+	}
+
+	return SpecVersionRange{}, false
+}
+
+// GetRanges returns a copy of the ranges of the map.
+func (m *SpecVersionMap) GetRanges() []SpecVersionRange {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]SpecVersionRange(nil), m.ranges...)
+}
+
+// LastBlock returns the last block that is covered by the map.
+func (m *SpecVersionMap) LastBlock() (uint64, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.ranges) == 0 {
+		return 0, false
+	}
+
+	return m.ranges[len(m.ranges)-1].ToBlock, true
+}
+
+// Add appends the provided ranges to the map.
+//
+// A range must either start right after the last block of the map or, if it has the same spec version as the
+// last range of the map, overlap with it, in which case the last range is extended.
+func (m *SpecVersionMap) Add(ranges ...SpecVersionRange) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	newRanges := append([]SpecVersionRange(nil), m.ranges...)
+
+	for _, r := range ranges {
+		if r.FromBlock > r.ToBlock {
+			return ErrInvalidBlockRange
+		}
+
+		if len(newRanges) == 0 {
+			newRanges = append(newRanges, r)
+
+			continue
+		}
+
+		last := &newRanges[len(newRanges)-1]
+
+		switch {
+		case r.SpecVersion == last.SpecVersion && r.FromBlock >= last.FromBlock && r.FromBlock <= last.ToBlock+1:
+			if r.ToBlock > last.ToBlock {
+				last.ToBlock = r.ToBlock
+			}
+		case r.FromBlock == last.ToBlock+1:
+			newRanges = append(newRanges, r)
+		default:
+			return ErrNonContiguousSpecVersionRange
+		}
+	}
+
+	m.ranges = newRanges
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoded ranges of the map.
+func (m *SpecVersionMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.GetRanges())
+}
+
+// UnmarshalJSON replaces the ranges of the map with the JSON encoded ones.
+func (m *SpecVersionMap) UnmarshalJSON(b []byte) error {
+	var ranges []SpecVersionRange
+
+	if err := json.Unmarshal(b, &ranges); err != nil {
+		return err
+	}
+
+	res, err := NewSpecVersionMap(ranges...)
+
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ranges = res.ranges
+
+	return nil
+}
+
+// Save stores the JSON encoded map at the provided path.
+//
+// The map is first written to a temporary file which is then renamed, so that an interrupted save does not
+// corrupt a previously stored map.
+func (m *SpecVersionMap) Save(path string) error {
+	b, err := json.Marshal(m)
+
+	if err != nil {
+		return ErrSpecVersionMapSaving.Wrap(err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+
+	if err != nil {
+		return ErrSpecVersionMapSaving.Wrap(err)
+	}
+
+	defer os.Remove(tmpFile.Name()) //nolint:errcheck
+
+	if _, err := tmpFile.Write(b); err != nil {
+		_ = tmpFile.Close()
+
+		return ErrSpecVersionMapSaving.Wrap(err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return ErrSpecVersionMapSaving.Wrap(err)
+	}
+
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return ErrSpecVersionMapSaving.Wrap(err)
+	}
+
+	return nil
+}
+
+// LoadSpecVersionMap loads a SpecVersionMap that was stored at the provided path.
+func LoadSpecVersionMap(path string) (*SpecVersionMap, error) {
+	b, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, ErrSpecVersionMapLoading.Wrap(err)
+	}
+
+	m := &SpecVersionMap{}
+
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, ErrSpecVersionMapLoading.Wrap(err)
+	}
+
+	return m, nil
+}
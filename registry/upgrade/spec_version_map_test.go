@@ -0,0 +1,164 @@
+package upgrade
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecVersionMap(t *testing.T) {
+	specVersionMap, err := NewSpecVersionMap(
+		SpecVersionRange{SpecVersion: 1, FromBlock: 0, ToBlock: 9},
+		SpecVersionRange{SpecVersion: 2, FromBlock: 10, ToBlock: 10},
+		SpecVersionRange{SpecVersion: 3, FromBlock: 11, ToBlock: 20},
+	)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		blockNumber uint64
+		specVersion types.U32
+		ok          bool
+	}{
+		{0, 1, true},
+		{9, 1, true},
+		{10, 2, true},
+		{11, 3, true},
+		{20, 3, true},
+		{21, 0, false},
+	}
+
+	for _, testCase := range testCases {
+		specVersion, ok := specVersionMap.GetSpecVersion(testCase.blockNumber)
+		assert.Equal(t, testCase.ok, ok)
+		assert.Equal(t, testCase.specVersion, specVersion)
+	}
+
+	r, ok := specVersionMap.GetRange(2)
+	assert.True(t, ok)
+	assert.Equal(t, SpecVersionRange{SpecVersion: 2, FromBlock: 10, ToBlock: 10}, r)
+
+	_, ok = specVersionMap.GetRange(4)
+	assert.False(t, ok)
+
+	lastBlock, ok := specVersionMap.LastBlock()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(20), lastBlock)
+
+	// Overlapping ranges with the same spec version extend the last range.
+	err = specVersionMap.Add(
+		SpecVersionRange{SpecVersion: 3, FromBlock: 20, ToBlock: 30},
+		SpecVersionRange{SpecVersion: 4, FromBlock: 31, ToBlock: 40},
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []SpecVersionRange{
+		{SpecVersion: 1, FromBlock: 0, ToBlock: 9},
+		{SpecVersion: 2, FromBlock: 10, ToBlock: 10},
+		{SpecVersion: 3, FromBlock: 11, ToBlock: 30},
+		{SpecVersion: 4, FromBlock: 31, ToBlock: 40},
+	}, specVersionMap.GetRanges())
+
+	err = specVersionMap.Add(SpecVersionRange{SpecVersion: 5, FromBlock: 42, ToBlock: 50})
+	assert.ErrorIs(t, err, ErrNonContiguousSpecVersionRange)
+
+	err = specVersionMap.Add(SpecVersionRange{SpecVersion: 5, FromBlock: 40, ToBlock: 50})
+	assert.ErrorIs(t, err, ErrNonContiguousSpecVersionRange)
+
+	err = specVersionMap.Add(SpecVersionRange{SpecVersion: 5, FromBlock: 42, ToBlock: 41})
+	assert.ErrorIs(t, err, ErrInvalidBlockRange)
+
+	// Failed additions do not modify the map.
+	err = specVersionMap.Add(
+		SpecVersionRange{SpecVersion: 5, FromBlock: 41, ToBlock: 50},
+		SpecVersionRange{SpecVersion: 6, FromBlock: 52, ToBlock: 60},
+	)
+	assert.ErrorIs(t, err, ErrNonContiguousSpecVersionRange)
+
+	lastBlock, ok = specVersionMap.LastBlock()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(40), lastBlock)
+
+	emptyMap, err := NewSpecVersionMap()
+	assert.NoError(t, err)
+
+	_, ok = emptyMap.LastBlock()
+	assert.False(t, ok)
+
+	_, ok = emptyMap.GetSpecVersion(0)
+	assert.False(t, ok)
+}
+
+func TestSpecVersionMap_Concurrency(t *testing.T) {
+	specVersionMap, err := NewSpecVersionMap(SpecVersionRange{SpecVersion: 1, FromBlock: 0, ToBlock: 0})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for i := uint64(1); i <= 100; i++ {
+			assert.NoError(t, specVersionMap.Add(SpecVersionRange{SpecVersion: types.U32(i + 1), FromBlock: i, ToBlock: i}))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for i := uint64(0); i <= 100; i++ {
+			if specVersion, ok := specVersionMap.GetSpecVersion(i); ok {
+				assert.Equal(t, types.U32(i+1), specVersion)
+			}
+		}
+	}()
+
+	wg.Wait()
+}
+
+func TestSpecVersionMap_SaveLoad(t *testing.T) {
+	specVersionMap, err := NewSpecVersionMap(
+		SpecVersionRange{SpecVersion: 1, FromBlock: 0, ToBlock: 9},
+		SpecVersionRange{SpecVersion: 2, FromBlock: 10, ToBlock: 20},
+	)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "spec_versions.json")
+
+	err = specVersionMap.Save(path)
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`[{"specVersion":1,"fromBlock":0,"toBlock":9},{"specVersion":2,"fromBlock":10,"toBlock":20}]`,
+		string(b),
+	)
+
+	res, err := LoadSpecVersionMap(path)
+	assert.NoError(t, err)
+	assert.Equal(t, specVersionMap.GetRanges(), res.GetRanges())
+
+	res, err = LoadSpecVersionMap(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, ErrSpecVersionMapLoading)
+	assert.Nil(t, res)
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.json")
+
+	err = os.WriteFile(invalidPath, []byte(`[{"specVersion":1,"fromBlock":0,"toBlock":9},{"specVersion":2,"fromBlock":11,"toBlock":20}]`), 0o600)
+	assert.NoError(t, err)
+
+	res, err = LoadSpecVersionMap(invalidPath)
+	assert.ErrorIs(t, err, ErrSpecVersionMapLoading)
+	assert.ErrorIs(t, err, ErrNonContiguousSpecVersionRange)
+	assert.Nil(t, res)
+
+	err = specVersionMap.Save(filepath.Join(t.TempDir(), "missing", "spec_versions.json"))
+	assert.ErrorIs(t, err, ErrSpecVersionMapSaving)
+}
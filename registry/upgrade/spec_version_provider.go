@@ -0,0 +1,137 @@
+package upgrade
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+//go:generate mockery --name SpecVersionProvider --structname SpecVersionProviderMock --filename spec_version_provider_mock.go --inpackage
+
+// SpecVersionProvider is the interface used for retrieving the runtime spec version at a particular block.
+type SpecVersionProvider interface {
+	GetSpecVersion(blockNumber uint64) (types.U32, error)
+	GetSpecVersionCtx(ctx context.Context, blockNumber uint64) (types.U32, error)
+}
+
+// runtimeVersionProvider implements the SpecVersionProvider interface using state_getRuntimeVersion.
+type runtimeVersionProvider struct {
+	chainRPC chain.Chain
+	stateRPC state.State
+}
+
+// NewRuntimeVersionProvider creates a new SpecVersionProvider that uses state_getRuntimeVersion.
+//
+// The returned spec version is the one of the runtime found in the state of the block, which is the same one
+// that is used by the cache.MetadataCache when resolving the metadata of a block hash.
+func NewRuntimeVersionProvider(chainRPC chain.Chain, stateRPC state.State) SpecVersionProvider {
+	return &runtimeVersionProvider{
+		chainRPC: chainRPC,
+		stateRPC: stateRPC,
+	}
+}
+
+// GetSpecVersion returns the spec version of the runtime version at the provided block.
+func (p *runtimeVersionProvider) GetSpecVersion(blockNumber uint64) (types.U32, error) {
+	return p.GetSpecVersionCtx(context.Background(), blockNumber)
+}
+
+// GetSpecVersionCtx is like GetSpecVersion, the RPC requests are bound to the provided context.
+func (p *runtimeVersionProvider) GetSpecVersionCtx(ctx context.Context, blockNumber uint64) (types.U32, error) {
+	blockHash, err := p.chainRPC.GetBlockHashCtx(ctx, blockNumber)
+
+	if err != nil {
+		return 0, ErrBlockHashRetrieval.Wrap(err)
+	}
+
+	return p.getSpecVersion(ctx, blockHash)
+}
+
+func (p *runtimeVersionProvider) getSpecVersion(ctx context.Context, blockHash types.Hash) (types.U32, error) {
+	runtimeVersion, err := p.stateRPC.GetRuntimeVersionCtx(ctx, blockHash)
+
+	if err != nil {
+		return 0, ErrRuntimeVersionRetrieval.Wrap(err)
+	}
+
+	return runtimeVersion.SpecVersion, nil
+}
+
+const (
+	lastRuntimeUpgradeStoragePrefix = "System"
+	lastRuntimeUpgradeStorageMethod = "LastRuntimeUpgrade"
+)
+
+// lastRuntimeUpgradeProvider implements the SpecVersionProvider interface using the System.LastRuntimeUpgrade
+// storage.
+type lastRuntimeUpgradeProvider struct {
+	*runtimeVersionProvider
+
+	storageKey types.StorageKey
+}
+
+// NewLastRuntimeUpgradeProvider creates a new SpecVersionProvider that reads the System.LastRuntimeUpgrade storage.
+//
+// A storage read is cheaper than state_getRuntimeVersion on archive nodes, since the latter can require
+// instantiating the runtime of historic blocks. The storage is only updated when a block is executed by the new
+// runtime, which is one block after the one in which the code was set, so the storage is read at the next block
+// in order to return the spec version of the runtime found in the state of the provided block.
+//
+// state_getRuntimeVersion is used if the next block is not available or if the storage is empty.
+func NewLastRuntimeUpgradeProvider(
+	chainRPC chain.Chain,
+	stateRPC state.State,
+	meta *types.Metadata,
+) (SpecVersionProvider, error) {
+	storageKey, err := types.CreateStorageKey(meta, lastRuntimeUpgradeStoragePrefix, lastRuntimeUpgradeStorageMethod)
+
+	if err != nil {
+		return nil, ErrStorageKeyCreation.Wrap(err)
+	}
+
+	return &lastRuntimeUpgradeProvider{
+		runtimeVersionProvider: &runtimeVersionProvider{
+			chainRPC: chainRPC,
+			stateRPC: stateRPC,
+		},
+		storageKey: storageKey,
+	}, nil
+}
+
+// GetSpecVersion returns the spec version of the runtime version at the provided block, which is the one stored
+// in System.LastRuntimeUpgrade at the next block.
+func (p *lastRuntimeUpgradeProvider) GetSpecVersion(blockNumber uint64) (types.U32, error) {
+	return p.GetSpecVersionCtx(context.Background(), blockNumber)
+}
+
+// GetSpecVersionCtx is like GetSpecVersion, the RPC requests are bound to the provided context.
+func (p *lastRuntimeUpgradeProvider) GetSpecVersionCtx(ctx context.Context, blockNumber uint64) (types.U32, error) {
+	blockHash, err := p.chainRPC.GetBlockHashCtx(ctx, blockNumber)
+
+	if err != nil {
+		return 0, ErrBlockHashRetrieval.Wrap(err)
+	}
+
+	// The next block is not available if the provided one is the latest block.
+	nextBlockHash, err := p.chainRPC.GetBlockHashCtx(ctx, blockNumber+1)
+
+	if err != nil || nextBlockHash == (types.Hash{}) {
+		return p.getSpecVersion(ctx, blockHash)
+	}
+
+	var lastRuntimeUpgrade types.LastRuntimeUpgradeInfo
+
+	ok, err := p.stateRPC.GetStorageCtx(ctx, p.storageKey, &lastRuntimeUpgrade, nextBlockHash)
+
+	if err != nil {
+		return 0, ErrLastRuntimeUpgradeRetrieval.Wrap(err)
+	}
+
+	if !ok {
+		return p.getSpecVersion(ctx, blockHash)
+	}
+
+	return types.U32(lastRuntimeUpgrade.SpecVersion.Int64()), nil
+}
@@ -0,0 +1,230 @@
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/cache"
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRuntimeVersionProvider_GetSpecVersion(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	stateRPCMock := stateMocks.NewState(t)
+
+	provider := NewRuntimeVersionProvider(chainRPCMock, stateRPCMock)
+
+	blockHash := types.NewHash([]byte{1})
+
+	chainRPCMock.On("GetBlockHashCtx", mock.Anything, uint64(1)).
+		Return(blockHash, nil).
+		Twice()
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash).
+		Return(&types.RuntimeVersion{SpecVersion: 9430}, nil).
+		Once()
+
+	res, err := provider.GetSpecVersion(1)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(9430), res)
+
+	runtimeVersionError := errors.New("error")
+
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash).
+		Return(nil, runtimeVersionError).
+		Once()
+
+	res, err = provider.GetSpecVersion(1)
+	assert.ErrorIs(t, err, ErrRuntimeVersionRetrieval)
+	assert.Equal(t, types.U32(0), res)
+
+	blockHashError := errors.New("error")
+
+	chainRPCMock.On("GetBlockHashCtx", mock.Anything, uint64(2)).
+		Return(types.Hash{}, blockHashError).
+		Once()
+
+	res, err = provider.GetSpecVersion(2)
+	assert.ErrorIs(t, err, ErrBlockHashRetrieval)
+	assert.Equal(t, types.U32(0), res)
+}
+
+func TestLastRuntimeUpgradeProvider_GetSpecVersion(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	stateRPCMock := stateMocks.NewState(t)
+
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	provider, err := NewLastRuntimeUpgradeProvider(chainRPCMock, stateRPCMock, &meta)
+	assert.NoError(t, err)
+
+	storageKey, err := types.CreateStorageKey(&meta, "System", "LastRuntimeUpgrade")
+	assert.NoError(t, err)
+
+	blockHash := types.NewHash([]byte{1})
+	nextBlockHash := types.NewHash([]byte{2})
+
+	chainRPCMock.On("GetBlockHashCtx", mock.Anything, uint64(1)).
+		Return(blockHash, nil)
+	chainRPCMock.On("GetBlockHashCtx", mock.Anything, uint64(2)).
+		Return(nextBlockHash, nil).
+		Times(3)
+
+	stateRPCMock.On("GetStorageCtx", mock.Anything, storageKey, mock.Anything, nextBlockHash).
+		Run(func(args mock.Arguments) {
+			target := args.Get(2).(*types.LastRuntimeUpgradeInfo)
+
+			target.SpecVersion = types.NewUCompactFromUInt(9430)
+			target.SpecName = "polkadot"
+		}).
+		Return(true, nil).
+		Once()
+
+	res, err := provider.GetSpecVersion(1)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(9430), res)
+
+	// The runtime version is used if the storage is empty.
+	stateRPCMock.On("GetStorageCtx", mock.Anything, storageKey, mock.Anything, nextBlockHash).
+		Return(false, nil).
+		Once()
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash).
+		Return(&types.RuntimeVersion{SpecVersion: 0}, nil).
+		Once()
+
+	res, err = provider.GetSpecVersion(1)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(0), res)
+
+	storageError := errors.New("error")
+
+	stateRPCMock.On("GetStorageCtx", mock.Anything, storageKey, mock.Anything, nextBlockHash).
+		Return(false, storageError).
+		Once()
+
+	res, err = provider.GetSpecVersion(1)
+	assert.ErrorIs(t, err, ErrLastRuntimeUpgradeRetrieval)
+	assert.Equal(t, types.U32(0), res)
+
+	// The runtime version is used if the next block is not available.
+	chainRPCMock.On("GetBlockHashCtx", mock.Anything, uint64(2)).
+		Return(types.Hash{}, errors.New("error")).
+		Once()
+	stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash).
+		Return(&types.RuntimeVersion{SpecVersion: 9431}, nil).
+		Once()
+
+	res, err = provider.GetSpecVersion(1)
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(9431), res)
+
+	provider, err = NewLastRuntimeUpgradeProvider(chainRPCMock, stateRPCMock, &types.Metadata{Version: 14})
+	assert.ErrorIs(t, err, ErrStorageKeyCreation)
+	assert.Nil(t, provider)
+}
+
+func TestLastRuntimeUpgradeProvider_GetSpecVersion_UpgradeBlock(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	stateRPCMock := stateMocks.NewState(t)
+
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	provider, err := NewLastRuntimeUpgradeProvider(chainRPCMock, stateRPCMock, &meta)
+	assert.NoError(t, err)
+
+	storageKey, err := types.CreateStorageKey(&meta, "System", "LastRuntimeUpgrade")
+	assert.NoError(t, err)
+
+	// The code is set in the upgrade block, which means that the runtime version and the metadata found in
+	// its state are the ones of the new runtime, while the storage is only updated in the next block.
+	upgradeBlock := uint64(10)
+
+	oldMeta := &types.Metadata{Version: 14}
+	newMeta := &types.Metadata{Version: 14}
+
+	for blockNumber := uint64(8); blockNumber <= 12; blockNumber++ {
+		blockHash := types.NewHash([]byte{byte(blockNumber)})
+
+		specVersion := types.U32(1)
+		blockMeta := oldMeta
+
+		if blockNumber >= upgradeBlock {
+			specVersion = 2
+			blockMeta = newMeta
+		}
+
+		storedSpecVersion := uint64(1)
+
+		if blockNumber > upgradeBlock {
+			storedSpecVersion = 2
+		}
+
+		chainRPCMock.On("GetBlockHashCtx", mock.Anything, blockNumber).
+			Return(blockHash, nil).
+			Maybe()
+		stateRPCMock.On("GetStorageCtx", mock.Anything, storageKey, mock.Anything, blockHash).
+			Run(func(args mock.Arguments) {
+				target := args.Get(2).(*types.LastRuntimeUpgradeInfo)
+
+				target.SpecVersion = types.NewUCompactFromUInt(storedSpecVersion)
+			}).
+			Return(true, nil).
+			Maybe()
+		stateRPCMock.On("GetRuntimeVersionCtx", mock.Anything, blockHash).
+			Return(&types.RuntimeVersion{SpecVersion: specVersion}, nil).
+			Maybe()
+		stateRPCMock.On("GetMetadataCtx", mock.Anything, blockHash).
+			Return(blockMeta, nil).
+			Maybe()
+	}
+
+	for blockNumber := uint64(8); blockNumber <= 11; blockNumber++ {
+		blockHash := types.NewHash([]byte{byte(blockNumber)})
+
+		specVersion, err := provider.GetSpecVersion(blockNumber)
+		assert.NoError(t, err)
+
+		runtimeVersion, err := stateRPCMock.GetRuntimeVersionCtx(context.Background(), blockHash)
+		assert.NoError(t, err)
+		assert.Equal(t, runtimeVersion.SpecVersion, specVersion)
+	}
+
+	// The metadata cache stores the metadata of each block under the spec version returned by the provider.
+	ranges, err := NewBoundaryDetector(provider).DetectRanges(8, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, []SpecVersionRange{
+		{SpecVersion: 1, FromBlock: 8, ToBlock: upgradeBlock - 1},
+		{SpecVersion: 2, FromBlock: upgradeBlock, ToBlock: 11},
+	}, ranges)
+
+	specVersionMap, err := NewSpecVersionMap(ranges...)
+	assert.NoError(t, err)
+
+	metadataCache := cache.NewMetadataCache(
+		stateRPCMock,
+		registry.NewFactory(),
+		cache.WithSpecVersionLookup(specVersionMap),
+	)
+
+	entry, err := metadataCache.GetEntryForBlock(upgradeBlock, types.NewHash([]byte{byte(upgradeBlock)}))
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(2), entry.SpecVersion)
+	assert.Same(t, newMeta, entry.Metadata)
+
+	entry, err = metadataCache.GetEntryForBlock(upgradeBlock-1, types.NewHash([]byte{byte(upgradeBlock - 1)}))
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(1), entry.SpecVersion)
+	assert.Same(t, oldMeta, entry.Metadata)
+}
@@ -19,23 +19,69 @@
 package author
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
 )
 
 type Author interface {
 	SubmitAndWatchExtrinsic(xt extrinsic.Extrinsic) (*ExtrinsicStatusSubscription, error)
+	SubmitAndWatchExtrinsicCtx(ctx context.Context, xt extrinsic.Extrinsic) (*ExtrinsicStatusSubscription, error)
 	SubmitExtrinsic(xt extrinsic.Extrinsic) (types.Hash, error)
+	SubmitExtrinsicCtx(ctx context.Context, xt extrinsic.Extrinsic) (types.Hash, error)
 	PendingExtrinsics() ([]string, error)
+	PendingExtrinsicsCtx(ctx context.Context) ([]string, error)
+	PendingExtrinsicsDecoded(decoder *registry.ExtrinsicDecoder) ([]*registry.DecodedExtrinsic, error)
+	PendingExtrinsicsDecodedCtx(
+		ctx context.Context,
+		decoder *registry.ExtrinsicDecoder,
+	) ([]*registry.DecodedExtrinsic, error)
+	RemoveExtrinsic(extrinsics []types.ExtrinsicOrHash) ([]types.Hash, error)
+	RemoveExtrinsicCtx(ctx context.Context, extrinsics []types.ExtrinsicOrHash) ([]types.Hash, error)
+	HasKey(publicKey []byte, keyType string) (bool, error)
+	HasKeyCtx(ctx context.Context, publicKey []byte, keyType string) (bool, error)
+	RotateKeys() (types.Bytes, error)
+	RotateKeysCtx(ctx context.Context) (types.Bytes, error)
+	InsertKey(keyType string, suri string, publicKey []byte) error
+	InsertKeyCtx(ctx context.Context, keyType string, suri string, publicKey []byte) error
 }
 
 // author exposes methods for authoring of network items
 type author struct {
-	client client.Client
+	client       client.Client
+	serDeOptions *types.SerDeOptions
 }
 
-// NewAuthor creates a new author struct
-func NewAuthor(cl client.Client) Author {
-	return &author{cl}
+// Option is the type used for configuring an Author
+type Option func(a *author)
+
+// WithSerDeOptions sets the options used for encoding the extrinsics instead of the default ones, see
+// types.SerDeOptionsFromMetadata
+func WithSerDeOptions(opts types.SerDeOptions) Option {
+	return func(a *author) {
+		a.serDeOptions = &opts
+	}
+}
+
+// NewAuthor creates a new author struct, configured with the provided options
+func NewAuthor(cl client.Client, opts ...Option) Author {
+	a := &author{client: cl}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+func (a *author) encodeToHex(value interface{}) (string, error) {
+	if a.serDeOptions == nil {
+		return codec.EncodeToHex(value)
+	}
+
+	return types.EncodeToHexWithOptions(value, *a.serDeOptions)
 }
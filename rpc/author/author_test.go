@@ -0,0 +1,153 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
+)
+
+var (
+	testClient client.Client
+	testAuthor author.Author
+)
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+
+	if err := s.RegisterName("author", &mockSrv); err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+
+	testClient = cl
+	testAuthor = author.NewAuthor(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	pendingExtrinsics   []string
+	submittedExtrinsics []string
+	removedExtrinsics   []json.RawMessage
+	sessionKeys         string
+	insertedKeys        [][]string
+}
+
+func (s *MockSrv) PendingExtrinsics() []string {
+	return s.pendingExtrinsics
+}
+
+func (s *MockSrv) SubmitExtrinsic(extrinsic string) string {
+	s.submittedExtrinsics = append(s.submittedExtrinsics, extrinsic)
+
+	return types.Hash{4, 5, 6}.Hex()
+}
+
+func (s *MockSrv) RemoveExtrinsic(extrinsics []json.RawMessage) []string {
+	s.removedExtrinsics = extrinsics
+
+	var res []string
+
+	for range extrinsics {
+		res = append(res, types.Hash{1, 2, 3}.Hex())
+	}
+
+	return res
+}
+
+func (s *MockSrv) HasKey(publicKey string, keyType string) bool {
+	return publicKey == codec.HexEncodeToString(signature.TestKeyringPairAlice.PublicKey) && keyType == "aura"
+}
+
+func (s *MockSrv) RotateKeys() string {
+	return s.sessionKeys
+}
+
+func (s *MockSrv) InsertKey(keyType string, suri string, publicKey string) {
+	s.insertedKeys = append(s.insertedKeys, []string{keyType, suri, publicKey})
+}
+
+var mockSrv = MockSrv{
+	pendingExtrinsics: []string{mustEncodeSignedRemark(7)},
+	sessionKeys:       "0x0102030405",
+}
+
+func mustGetMetadata() *types.Metadata {
+	var meta types.Metadata
+
+	if err := codec.DecodeFromHex(types.MetadataV14Data, &meta); err != nil {
+		panic(err)
+	}
+
+	return &meta
+}
+
+func mustEncodeSignedRemark(nonce uint64) string {
+	enc, err := codec.EncodeToHex(mustSignRemark(nonce))
+	if err != nil {
+		panic(err)
+	}
+
+	return enc
+}
+
+func mustSignRemark(nonce uint64) extrinsic.Extrinsic {
+	meta := mustGetMetadata()
+
+	call, err := types.NewCall(meta, "System.remark", []byte("test"))
+	if err != nil {
+		panic(err)
+	}
+
+	xt := extrinsic.NewExtrinsic(call)
+
+	err = xt.Sign(
+		signature.TestKeyringPairAlice,
+		meta,
+		extrinsic.WithEra(types.ExtrinsicEra{IsImmortalEra: true}, types.Hash{}),
+		extrinsic.WithNonce(types.NewUCompactFromUInt(nonce)),
+		extrinsic.WithTip(types.NewUCompactFromUInt(0)),
+		extrinsic.WithSpecVersion(1),
+		extrinsic.WithTransactionVersion(1),
+		extrinsic.WithGenesisHash(types.Hash{}),
+		extrinsic.WithMetadataMode(
+			extensions.CheckMetadataModeDisabled,
+			extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()},
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return xt
+}
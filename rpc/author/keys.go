@@ -0,0 +1,66 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// HasKey checks if the keystore of the node has the private key for the provided public key and key type.
+func (a *author) HasKey(publicKey []byte, keyType string) (bool, error) {
+	return a.HasKeyCtx(context.Background(), publicKey, keyType)
+}
+
+// HasKeyCtx is like HasKey, the request is bound to the provided context.
+func (a *author) HasKeyCtx(ctx context.Context, publicKey []byte, keyType string) (bool, error) {
+	var res bool
+	err := a.client.CallContext(ctx, &res, "author_hasKey", codec.HexEncodeToString(publicKey), keyType)
+	if err != nil {
+		return false, err
+	}
+
+	return res, nil
+}
+
+// RotateKeys generates new session keys in the keystore of the node and returns their public keys.
+func (a *author) RotateKeys() (types.Bytes, error) {
+	return a.RotateKeysCtx(context.Background())
+}
+
+// RotateKeysCtx is like RotateKeys, the request is bound to the provided context.
+func (a *author) RotateKeysCtx(ctx context.Context) (types.Bytes, error) {
+	var res string
+	err := a.client.CallContext(ctx, &res, "author_rotateKeys")
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.HexDecodeString(res)
+}
+
+// InsertKey inserts the key for the provided key type, secret URI and public key into the keystore of the node.
+func (a *author) InsertKey(keyType string, suri string, publicKey []byte) error {
+	return a.InsertKeyCtx(context.Background(), keyType, suri, publicKey)
+}
+
+// InsertKeyCtx is like InsertKey, the request is bound to the provided context.
+func (a *author) InsertKeyCtx(ctx context.Context, keyType string, suri string, publicKey []byte) error {
+	return a.client.CallContext(ctx, nil, "author_insertKey", keyType, suri, codec.HexEncodeToString(publicKey))
+}
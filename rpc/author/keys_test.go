@@ -0,0 +1,50 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthor_HasKey(t *testing.T) {
+	res, err := testAuthor.HasKey(signature.TestKeyringPairAlice.PublicKey, "aura")
+	assert.NoError(t, err)
+	assert.True(t, res)
+
+	res, err = testAuthor.HasKey(signature.TestKeyringPairAlice.PublicKey, "babe")
+	assert.NoError(t, err)
+	assert.False(t, res)
+}
+
+func TestAuthor_RotateKeys(t *testing.T) {
+	res, err := testAuthor.RotateKeys()
+	assert.NoError(t, err)
+	assert.Equal(t, types.Bytes{1, 2, 3, 4, 5}, res)
+}
+
+func TestAuthor_InsertKey(t *testing.T) {
+	publicKey := signature.TestKeyringPairAlice.PublicKey
+
+	err := testAuthor.InsertKey("aura", signature.TestKeyringPairAlice.URI, publicKey)
+	assert.NoError(t, err)
+	assert.Contains(t, mockSrv.insertedKeys, []string{"aura", signature.TestKeyringPairAlice.URI, codec.HexEncodeToString(publicKey)})
+}
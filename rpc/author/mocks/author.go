@@ -3,11 +3,15 @@
 package mocks
 
 import (
+	context "context"
+
 	author "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
 	extrinsic "github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
 
 	mock "github.com/stretchr/testify/mock"
 
+	registry "github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
@@ -16,6 +20,76 @@ type Author struct {
 	mock.Mock
 }
 
+// HasKey provides a mock function with given fields: publicKey, keyType
+func (_m *Author) HasKey(publicKey []byte, keyType string) (bool, error) {
+	ret := _m.Called(publicKey, keyType)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func([]byte, string) bool); ok {
+		r0 = rf(publicKey, keyType)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, string) error); ok {
+		r1 = rf(publicKey, keyType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasKeyCtx provides a mock function with given fields: ctx, publicKey, keyType
+func (_m *Author) HasKeyCtx(ctx context.Context, publicKey []byte, keyType string) (bool, error) {
+	ret := _m.Called(ctx, publicKey, keyType)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string) bool); ok {
+		r0 = rf(ctx, publicKey, keyType)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, string) error); ok {
+		r1 = rf(ctx, publicKey, keyType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertKey provides a mock function with given fields: keyType, suri, publicKey
+func (_m *Author) InsertKey(keyType string, suri string, publicKey []byte) error {
+	ret := _m.Called(keyType, suri, publicKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []byte) error); ok {
+		r0 = rf(keyType, suri, publicKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertKeyCtx provides a mock function with given fields: ctx, keyType, suri, publicKey
+func (_m *Author) InsertKeyCtx(ctx context.Context, keyType string, suri string, publicKey []byte) error {
+	ret := _m.Called(ctx, keyType, suri, publicKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = rf(ctx, keyType, suri, publicKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PendingExtrinsics provides a mock function with given fields:
 func (_m *Author) PendingExtrinsics() ([]string, error) {
 	ret := _m.Called()
@@ -39,6 +113,167 @@ func (_m *Author) PendingExtrinsics() ([]string, error) {
 	return r0, r1
 }
 
+// PendingExtrinsicsCtx provides a mock function with given fields: ctx
+func (_m *Author) PendingExtrinsicsCtx(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PendingExtrinsicsDecoded provides a mock function with given fields: decoder
+func (_m *Author) PendingExtrinsicsDecoded(decoder *registry.ExtrinsicDecoder) ([]*registry.DecodedExtrinsic, error) {
+	ret := _m.Called(decoder)
+
+	var r0 []*registry.DecodedExtrinsic
+	if rf, ok := ret.Get(0).(func(*registry.ExtrinsicDecoder) []*registry.DecodedExtrinsic); ok {
+		r0 = rf(decoder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*registry.DecodedExtrinsic)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*registry.ExtrinsicDecoder) error); ok {
+		r1 = rf(decoder)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PendingExtrinsicsDecodedCtx provides a mock function with given fields: ctx, decoder
+func (_m *Author) PendingExtrinsicsDecodedCtx(ctx context.Context, decoder *registry.ExtrinsicDecoder) ([]*registry.DecodedExtrinsic, error) {
+	ret := _m.Called(ctx, decoder)
+
+	var r0 []*registry.DecodedExtrinsic
+	if rf, ok := ret.Get(0).(func(context.Context, *registry.ExtrinsicDecoder) []*registry.DecodedExtrinsic); ok {
+		r0 = rf(ctx, decoder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*registry.DecodedExtrinsic)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *registry.ExtrinsicDecoder) error); ok {
+		r1 = rf(ctx, decoder)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveExtrinsic provides a mock function with given fields: extrinsics
+func (_m *Author) RemoveExtrinsic(extrinsics []types.ExtrinsicOrHash) ([]types.Hash, error) {
+	ret := _m.Called(extrinsics)
+
+	var r0 []types.Hash
+	if rf, ok := ret.Get(0).(func([]types.ExtrinsicOrHash) []types.Hash); ok {
+		r0 = rf(extrinsics)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]types.ExtrinsicOrHash) error); ok {
+		r1 = rf(extrinsics)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveExtrinsicCtx provides a mock function with given fields: ctx, extrinsics
+func (_m *Author) RemoveExtrinsicCtx(ctx context.Context, extrinsics []types.ExtrinsicOrHash) ([]types.Hash, error) {
+	ret := _m.Called(ctx, extrinsics)
+
+	var r0 []types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, []types.ExtrinsicOrHash) []types.Hash); ok {
+		r0 = rf(ctx, extrinsics)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.ExtrinsicOrHash) error); ok {
+		r1 = rf(ctx, extrinsics)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateKeys provides a mock function with given fields:
+func (_m *Author) RotateKeys() (types.Bytes, error) {
+	ret := _m.Called()
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func() types.Bytes); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateKeysCtx provides a mock function with given fields: ctx
+func (_m *Author) RotateKeysCtx(ctx context.Context) (types.Bytes, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func(context.Context) types.Bytes); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitAndWatchExtrinsic provides a mock function with given fields: xt
 func (_m *Author) SubmitAndWatchExtrinsic(xt extrinsic.Extrinsic) (*author.ExtrinsicStatusSubscription, error) {
 	ret := _m.Called(xt)
@@ -62,6 +297,29 @@ func (_m *Author) SubmitAndWatchExtrinsic(xt extrinsic.Extrinsic) (*author.Extri
 	return r0, r1
 }
 
+// SubmitAndWatchExtrinsicCtx provides a mock function with given fields: ctx, xt
+func (_m *Author) SubmitAndWatchExtrinsicCtx(ctx context.Context, xt extrinsic.Extrinsic) (*author.ExtrinsicStatusSubscription, error) {
+	ret := _m.Called(ctx, xt)
+
+	var r0 *author.ExtrinsicStatusSubscription
+	if rf, ok := ret.Get(0).(func(context.Context, extrinsic.Extrinsic) *author.ExtrinsicStatusSubscription); ok {
+		r0 = rf(ctx, xt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*author.ExtrinsicStatusSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, extrinsic.Extrinsic) error); ok {
+		r1 = rf(ctx, xt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitExtrinsic provides a mock function with given fields: xt
 func (_m *Author) SubmitExtrinsic(xt extrinsic.Extrinsic) (types.Hash, error) {
 	ret := _m.Called(xt)
@@ -85,6 +343,29 @@ func (_m *Author) SubmitExtrinsic(xt extrinsic.Extrinsic) (types.Hash, error) {
 	return r0, r1
 }
 
+// SubmitExtrinsicCtx provides a mock function with given fields: ctx, xt
+func (_m *Author) SubmitExtrinsicCtx(ctx context.Context, xt extrinsic.Extrinsic) (types.Hash, error) {
+	ret := _m.Called(ctx, xt)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, extrinsic.Extrinsic) types.Hash); ok {
+		r0 = rf(ctx, xt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, extrinsic.Extrinsic) error); ok {
+		r1 = rf(ctx, xt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewAuthorT interface {
 	mock.TestingT
 	Cleanup(func())
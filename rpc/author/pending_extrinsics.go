@@ -16,13 +16,54 @@
 
 package author
 
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+)
+
 // PendingExtrinsics returns all pending extrinsics.
 func (a *author) PendingExtrinsics() ([]string, error) {
+	return a.PendingExtrinsicsCtx(context.Background())
+}
+
+// PendingExtrinsicsCtx is like PendingExtrinsics, the request is bound to the provided context.
+func (a *author) PendingExtrinsicsCtx(ctx context.Context) ([]string, error) {
 	var extrinsics []string
-	err := a.client.Call(&extrinsics, "author_pendingExtrinsics")
+	err := a.client.CallContext(ctx, &extrinsics, "author_pendingExtrinsics")
 	if err != nil {
 		return nil, err
 	}
 
 	return extrinsics, nil
 }
+
+// PendingExtrinsicsDecoded returns all pending extrinsics, decoded using the provided extrinsic decoder.
+func (a *author) PendingExtrinsicsDecoded(decoder *registry.ExtrinsicDecoder) ([]*registry.DecodedExtrinsic, error) {
+	return a.PendingExtrinsicsDecodedCtx(context.Background(), decoder)
+}
+
+// PendingExtrinsicsDecodedCtx is like PendingExtrinsicsDecoded, the request is bound to the provided context.
+func (a *author) PendingExtrinsicsDecodedCtx(
+	ctx context.Context,
+	decoder *registry.ExtrinsicDecoder,
+) ([]*registry.DecodedExtrinsic, error) {
+	extrinsics, err := a.PendingExtrinsicsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*registry.DecodedExtrinsic, 0, len(extrinsics))
+
+	for i, extrinsic := range extrinsics {
+		decodedExtrinsic, err := decoder.DecodeHex(extrinsic)
+		if err != nil {
+			return nil, fmt.Errorf("pending extrinsic #%d decoding: %w", i, err)
+		}
+
+		res = append(res, decodedExtrinsic)
+	}
+
+	return res, nil
+}
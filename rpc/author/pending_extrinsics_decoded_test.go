@@ -0,0 +1,46 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthor_PendingExtrinsicsDecoded(t *testing.T) {
+	meta := mustGetMetadata()
+
+	extrinsicDecoder, err := registry.NewFactory().CreateExtrinsicDecoder(meta)
+	assert.NoError(t, err)
+
+	res, err := testAuthor.PendingExtrinsicsDecoded(extrinsicDecoder)
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.True(t, res[0].IsSigned())
+
+	remarkCallIndex, err := meta.FindCallIndex("System.remark")
+	assert.NoError(t, err)
+	assert.Equal(t, remarkCallIndex, res[0].CallIndex)
+}
+
+func TestAuthor_PendingExtrinsicsDecoded_DecodingError(t *testing.T) {
+	res, err := testAuthor.PendingExtrinsicsDecoded(&registry.ExtrinsicDecoder{})
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
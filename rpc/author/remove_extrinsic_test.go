@@ -0,0 +1,43 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthor_RemoveExtrinsic(t *testing.T) {
+	extrinsics := []types.ExtrinsicOrHash{
+		types.NewExtrinsicOrHashFromHash(types.Hash{3, 2, 1}),
+		types.NewExtrinsicOrHashFromBytes([]byte{1, 2}),
+	}
+
+	res, err := testAuthor.RemoveExtrinsic(extrinsics)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{{1, 2, 3}, {1, 2, 3}}, res)
+
+	expected, err := json.Marshal(extrinsics)
+	assert.NoError(t, err)
+
+	actual, err := json.Marshal(mockSrv.removedExtrinsics)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
@@ -20,10 +20,10 @@ import (
 	"context"
 	"sync"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
 )
 
@@ -65,25 +65,41 @@ func (s *ExtrinsicStatusSubscription) Unsubscribe() {
 // SubmitAndWatchExtrinsic will submit and subscribe to watch an extrinsic until unsubscribed, returning a subscription
 // that will receive server notifications containing the extrinsic status updates.
 func (a *author) SubmitAndWatchExtrinsic(xt extrinsic.Extrinsic) (*ExtrinsicStatusSubscription, error) { //nolint:lll
-	hexEncodedExtrinsic, err := codec.EncodeToHex(xt)
+	return a.SubmitAndWatchExtrinsicCtx(context.Background(), xt)
+}
+
+// SubmitAndWatchExtrinsicCtx is like SubmitAndWatchExtrinsic, the subscription is unsubscribed once the provided
+// context is done
+func (a *author) SubmitAndWatchExtrinsicCtx(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+) (*ExtrinsicStatusSubscription, error) {
+	hexEncodedExtrinsic, err := a.encodeToHex(xt)
 	if err != nil {
 		return nil, err
 	}
 
-	return a.submitAndWatchExtrinsic(hexEncodedExtrinsic)
+	return a.submitAndWatchExtrinsic(ctx, hexEncodedExtrinsic)
 }
 
-func (a *author) submitAndWatchExtrinsic(hexEncodedExtrinsic string) (*ExtrinsicStatusSubscription, error) { //nolint:lll
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+func (a *author) submitAndWatchExtrinsic(
+	ctx context.Context,
+	hexEncodedExtrinsic string,
+) (*ExtrinsicStatusSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
 	defer cancel()
 
 	c := make(chan types.ExtrinsicStatus)
 
-	sub, err := a.client.Subscribe(ctx, "author", "submitAndWatchExtrinsic", "unwatchExtrinsic", "extrinsicUpdate",
-		c, hexEncodedExtrinsic)
+	sub, err := a.client.Subscribe(subscribeCtx, "author", "submitAndWatchExtrinsic", "unwatchExtrinsic",
+		"extrinsicUpdate", c, hexEncodedExtrinsic)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ExtrinsicStatusSubscription{sub: sub, channel: c}, nil
+	s := &ExtrinsicStatusSubscription{sub: sub, channel: c}
+
+	client.UnsubscribeOnDone(ctx, sub, s.Unsubscribe)
+
+	return s, nil
 }
@@ -17,20 +17,26 @@
 package author
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
 )
 
 // SubmitExtrinsic will submit a fully formatted extrinsic for block inclusion
 func (a *author) SubmitExtrinsic(xt extrinsic.Extrinsic) (types.Hash, error) {
-	enc, err := codec.EncodeToHex(xt)
+	return a.SubmitExtrinsicCtx(context.Background(), xt)
+}
+
+// SubmitExtrinsicCtx is like SubmitExtrinsic, the request is bound to the provided context
+func (a *author) SubmitExtrinsicCtx(ctx context.Context, xt extrinsic.Extrinsic) (types.Hash, error) {
+	enc, err := a.encodeToHex(xt)
 	if err != nil {
 		return types.Hash{}, err
 	}
 
 	var res string
-	err = a.client.Call(&res, "author_submitExtrinsic", enc)
+	err = a.client.CallContext(ctx, &res, "author_submitExtrinsic", enc)
 	if err != nil {
 		return types.Hash{}, err
 	}
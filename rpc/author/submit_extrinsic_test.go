@@ -23,6 +23,7 @@ import (
 
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
@@ -89,3 +90,17 @@ func TestAuthor_SubmitExtrinsic(t *testing.T) {
 		break
 	}
 }
+
+func TestAuthor_SubmitExtrinsicWithSerDeOptions(t *testing.T) {
+	xt := mustSignRemark(8)
+
+	a := author.NewAuthor(testClient, author.WithSerDeOptions(types.SerDeOptions{NoPalletIndices: true}))
+
+	res, err := a.SubmitExtrinsic(xt)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Hash{4, 5, 6}, res)
+
+	expected, err := types.EncodeToHexWithOptions(xt, types.SerDeOptions{NoPalletIndices: true})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, mockSrv.submittedExtrinsics[len(mockSrv.submittedExtrinsics)-1])
+}
@@ -17,14 +17,21 @@
 package beefy
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // GetFinalizedHead returns the hash of the latest BEEFY block
 func (b *beefy) GetFinalizedHead() (types.Hash, error) {
+	return b.GetFinalizedHeadCtx(context.Background())
+}
+
+// GetFinalizedHeadCtx returns the hash of the latest BEEFY block, the request is bound to the provided context
+func (b *beefy) GetFinalizedHeadCtx(ctx context.Context) (types.Hash, error) {
 	var res string
 
-	err := b.client.Call(&res, "beefy_getFinalizedHead")
+	err := b.client.CallContext(ctx, &res, "beefy_getFinalizedHead")
 	if err != nil {
 		return types.Hash{}, err
 	}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -63,16 +64,26 @@ func (s *JustificationsSubscription) Unsubscribe() {
 // SubscribeJustifications subscribes beefy justifications, returning a subscription that will
 // receive server notifications containing the Header.
 func (b *beefy) SubscribeJustifications() (*JustificationsSubscription, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	return b.SubscribeJustificationsCtx(context.Background())
+}
+
+// SubscribeJustificationsCtx is like SubscribeJustifications, the subscription is unsubscribed once the provided
+// context is done
+func (b *beefy) SubscribeJustificationsCtx(ctx context.Context) (*JustificationsSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
 	defer cancel()
 
 	ch := make(chan types.SignedCommitment)
 
-	sub, err := b.client.Subscribe(ctx, "beefy", "subscribeJustifications", "unsubscribeJustifications",
+	sub, err := b.client.Subscribe(subscribeCtx, "beefy", "subscribeJustifications", "unsubscribeJustifications",
 		"justifications", ch)
 	if err != nil {
 		return nil, err
 	}
 
-	return &JustificationsSubscription{sub: sub, channel: ch}, nil
+	s := &JustificationsSubscription{sub: sub, channel: ch}
+
+	client.UnsubscribeOnDone(ctx, sub, s.Unsubscribe)
+
+	return s, nil
 }
@@ -19,7 +19,10 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
 )
@@ -28,22 +31,55 @@ import (
 
 type Chain interface {
 	SubscribeFinalizedHeads() (*FinalizedHeadsSubscription, error)
+	SubscribeFinalizedHeadsCtx(ctx context.Context) (*FinalizedHeadsSubscription, error)
 	SubscribeNewHeads() (*NewHeadsSubscription, error)
+	SubscribeNewHeadsCtx(ctx context.Context) (*NewHeadsSubscription, error)
 	GetBlockHash(blockNumber uint64) (types.Hash, error)
+	GetBlockHashCtx(ctx context.Context, blockNumber uint64) (types.Hash, error)
 	GetBlockHashLatest() (types.Hash, error)
+	GetBlockHashLatestCtx(ctx context.Context) (types.Hash, error)
+	GetBlockHashBatch(blockNumbers []uint64) ([]types.Hash, error)
+	GetBlockHashBatchCtx(ctx context.Context, blockNumbers []uint64) ([]types.Hash, error)
 	GetFinalizedHead() (types.Hash, error)
+	GetFinalizedHeadCtx(ctx context.Context) (types.Hash, error)
 	GetBlock(blockHash types.Hash) (*block.SignedBlock, error)
+	GetBlockCtx(ctx context.Context, blockHash types.Hash) (*block.SignedBlock, error)
 	GetBlockLatest() (*block.SignedBlock, error)
+	GetBlockLatestCtx(ctx context.Context) (*block.SignedBlock, error)
+	GetBlockBatch(blockHashes []types.Hash) ([]*block.SignedBlock, error)
+	GetBlockBatchCtx(ctx context.Context, blockHashes []types.Hash) ([]*block.SignedBlock, error)
 	GetHeader(blockHash types.Hash) (*types.Header, error)
+	GetHeaderCtx(ctx context.Context, blockHash types.Hash) (*types.Header, error)
 	GetHeaderLatest() (*types.Header, error)
+	GetHeaderLatestCtx(ctx context.Context) (*types.Header, error)
+	GetHeaderBatch(blockHashes []types.Hash) ([]*types.Header, error)
+	GetHeaderBatchCtx(ctx context.Context, blockHashes []types.Hash) ([]*types.Header, error)
 }
 
 // chain exposes methods for retrieval of chain data
 type chain struct {
-	client client.Client
+	client    client.Client
+	batchSize int
+}
+
+// Option is the type used for configuring a Chain
+type Option func(c *chain)
+
+// WithBatchSize sets the maximum number of requests that are sent in a single JSON-RPC batch request, all requests
+// are sent in a single batch request if batchSize is not positive. The default is the BatchSize of config.Default
+func WithBatchSize(batchSize int) Option {
+	return func(c *chain) {
+		c.batchSize = batchSize
+	}
 }
 
-// NewChain creates a new chain struct
-func NewChain(cl client.Client) Chain {
-	return &chain{cl}
+// NewChain creates a new chain struct, configured with the provided options
+func NewChain(cl client.Client, opts ...Option) Chain {
+	c := &chain{client: cl, batchSize: config.Default().BatchSize}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
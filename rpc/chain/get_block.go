@@ -17,27 +17,93 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
 
 // GetBlock returns the header and body of the relay chain block with the given hash
 func (c *chain) GetBlock(blockHash types.Hash) (*block.SignedBlock, error) {
-	return c.getBlock(&blockHash)
+	return c.GetBlockCtx(context.Background(), blockHash)
+}
+
+// GetBlockCtx returns the header and body of the relay chain block with the given hash, the request is bound to the
+// provided context
+func (c *chain) GetBlockCtx(ctx context.Context, blockHash types.Hash) (*block.SignedBlock, error) {
+	return c.getBlock(ctx, &blockHash)
 }
 
 // GetBlockLatest returns the header and body of the latest relay chain block
 func (c *chain) GetBlockLatest() (*block.SignedBlock, error) {
-	return c.getBlock(nil)
+	return c.GetBlockLatestCtx(context.Background())
+}
+
+// GetBlockLatestCtx returns the header and body of the latest relay chain block, the request is bound to the provided
+// context
+func (c *chain) GetBlockLatestCtx(ctx context.Context) (*block.SignedBlock, error) {
+	return c.getBlock(ctx, nil)
+}
+
+// GetBlockBatch returns the headers and bodies of the relay chain blocks with the given hashes, using JSON-RPC batch
+// requests. Blocks that are not found are nil. If some of the requests fail, the other blocks are returned along with
+// a *client.BatchError that holds the errors of the failed requests, keyed by their index.
+func (c *chain) GetBlockBatch(blockHashes []types.Hash) ([]*block.SignedBlock, error) {
+	return c.GetBlockBatchCtx(context.Background(), blockHashes)
 }
 
-func (c *chain) getBlock(blockHash *types.Hash) (*block.SignedBlock, error) {
+// GetBlockBatchCtx is like GetBlockBatch, the batch requests are bound to the provided context
+func (c *chain) GetBlockBatchCtx(ctx context.Context, blockHashes []types.Hash) ([]*block.SignedBlock, error) {
+	res := make([]*block.SignedBlock, len(blockHashes))
+
+	batch, err := newBlockHashBatch("chain_getBlock", blockHashes, func(i int) interface{} {
+		return &res[i]
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.BatchCallWithSizeContext(ctx, c.client, batch, c.batchSize); err != nil {
+		return nil, err
+	}
+
+	return res, client.NewBatchError(batch)
+}
+
+func (c *chain) getBlock(ctx context.Context, blockHash *types.Hash) (*block.SignedBlock, error) {
 	var res block.SignedBlock
-	err := client.CallWithBlockHash(c.client, &res, "chain_getBlock", blockHash)
+	err := client.CallWithBlockHashContext(ctx, c.client, &res, "chain_getBlock", blockHash)
 	if err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
+
+// newBlockHashBatch returns the batch requests of the provided method for each block hash, where result returns the
+// result of the request with the provided index.
+func newBlockHashBatch(
+	method string,
+	blockHashes []types.Hash,
+	result func(i int) interface{},
+) ([]gethrpc.BatchElem, error) {
+	batch := make([]gethrpc.BatchElem, len(blockHashes))
+
+	for i, blockHash := range blockHashes {
+		hexHash, err := codec.Hex(blockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		batch[i] = gethrpc.BatchElem{
+			Method: method,
+			Args:   []interface{}{hexHash},
+			Result: result(i),
+		}
+	}
+
+	return batch, nil
+}
@@ -17,27 +17,78 @@
 package chain
 
 import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // GetBlockHash returns the block hash for a specific block height
 func (c *chain) GetBlockHash(blockNumber uint64) (types.Hash, error) {
-	return c.getBlockHash(&blockNumber)
+	return c.GetBlockHashCtx(context.Background(), blockNumber)
+}
+
+// GetBlockHashCtx returns the block hash for a specific block height, the request is bound to the provided context
+func (c *chain) GetBlockHashCtx(ctx context.Context, blockNumber uint64) (types.Hash, error) {
+	return c.getBlockHash(ctx, &blockNumber)
 }
 
 // GetBlockHashLatest returns the latest block hash
 func (c *chain) GetBlockHashLatest() (types.Hash, error) {
-	return c.getBlockHash(nil)
+	return c.GetBlockHashLatestCtx(context.Background())
+}
+
+// GetBlockHashLatestCtx returns the latest block hash, the request is bound to the provided context
+func (c *chain) GetBlockHashLatestCtx(ctx context.Context) (types.Hash, error) {
+	return c.getBlockHash(ctx, nil)
+}
+
+// GetBlockHashBatch returns the block hashes for the provided block heights, using JSON-RPC batch requests. If some of
+// the requests fail, the hashes of the other blocks are returned along with a *client.BatchError that holds the
+// errors of the failed requests, keyed by their index.
+func (c *chain) GetBlockHashBatch(blockNumbers []uint64) ([]types.Hash, error) {
+	return c.GetBlockHashBatchCtx(context.Background(), blockNumbers)
+}
+
+// GetBlockHashBatchCtx is like GetBlockHashBatch, the batch requests are bound to the provided context
+func (c *chain) GetBlockHashBatchCtx(ctx context.Context, blockNumbers []uint64) ([]types.Hash, error) {
+	res := make([]string, len(blockNumbers))
+	batch := make([]gethrpc.BatchElem, len(blockNumbers))
+
+	for i, blockNumber := range blockNumbers {
+		batch[i] = gethrpc.BatchElem{
+			Method: "chain_getBlockHash",
+			Args:   []interface{}{blockNumber},
+			Result: &res[i],
+		}
+	}
+
+	if err := client.BatchCallWithSizeContext(ctx, c.client, batch, c.batchSize); err != nil {
+		return nil, err
+	}
+
+	hashes := make([]types.Hash, len(blockNumbers))
+
+	for i := range batch {
+		if batch[i].Error != nil {
+			continue
+		}
+
+		hashes[i], batch[i].Error = types.NewHashFromHexString(res[i])
+	}
+
+	return hashes, client.NewBatchError(batch)
 }
 
-func (c *chain) getBlockHash(blockNumber *uint64) (types.Hash, error) {
+func (c *chain) getBlockHash(ctx context.Context, blockNumber *uint64) (types.Hash, error) {
 	var res string
 	var err error
 
 	if blockNumber == nil {
-		err = c.client.Call(&res, "chain_getBlockHash")
+		err = c.client.CallContext(ctx, &res, "chain_getBlockHash")
 	} else {
-		err = c.client.Call(&res, "chain_getBlockHash", *blockNumber)
+		err = c.client.CallContext(ctx, &res, "chain_getBlockHash", *blockNumber)
 	}
 
 	if err != nil {
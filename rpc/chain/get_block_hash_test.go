@@ -40,3 +40,15 @@ func TestChain_GetBlockHashLatest(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, blk.Block.Header.Number > 0)
 }
+
+func TestChain_GetBlockHashBatch(t *testing.T) {
+	res, err := NewChain(testChain.(*chain).client, WithBatchSize(2)).GetBlockHashBatch([]uint64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Len(t, res, 3)
+
+	for i, hash := range res {
+		expected, err := testChain.GetBlockHash(uint64(i + 1))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, hash)
+	}
+}
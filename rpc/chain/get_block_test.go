@@ -19,6 +19,7 @@ package chain
 import (
 	"testing"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -38,3 +39,14 @@ func TestChain_GetBlock(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, latest-1, rv.Block.Header.Number)
 }
+
+func TestChain_GetBlockBatch(t *testing.T) {
+	rv, err := testChain.GetBlockLatest()
+	assert.NoError(t, err)
+
+	res, err := testChain.GetBlockBatch([]types.Hash{rv.Block.Header.ParentHash, {}})
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, rv.Block.Header.Number-1, res[0].Block.Header.Number)
+	assert.Nil(t, res[1])
+}
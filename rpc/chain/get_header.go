@@ -17,23 +17,60 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // GetHeader retrieves the header for the specific block
 func (c *chain) GetHeader(blockHash types.Hash) (*types.Header, error) {
-	return c.getHeader(&blockHash)
+	return c.GetHeaderCtx(context.Background(), blockHash)
+}
+
+// GetHeaderCtx retrieves the header for the specific block, the request is bound to the provided context
+func (c *chain) GetHeaderCtx(ctx context.Context, blockHash types.Hash) (*types.Header, error) {
+	return c.getHeader(ctx, &blockHash)
 }
 
 // GetHeaderLatest retrieves the header of the latest block
 func (c *chain) GetHeaderLatest() (*types.Header, error) {
-	return c.getHeader(nil)
+	return c.GetHeaderLatestCtx(context.Background())
+}
+
+// GetHeaderLatestCtx retrieves the header of the latest block, the request is bound to the provided context
+func (c *chain) GetHeaderLatestCtx(ctx context.Context) (*types.Header, error) {
+	return c.getHeader(ctx, nil)
+}
+
+// GetHeaderBatch retrieves the headers of the blocks with the given hashes, using JSON-RPC batch requests. Headers
+// that are not found are nil. If some of the requests fail, the other headers are returned along with a
+// *client.BatchError that holds the errors of the failed requests, keyed by their index.
+func (c *chain) GetHeaderBatch(blockHashes []types.Hash) ([]*types.Header, error) {
+	return c.GetHeaderBatchCtx(context.Background(), blockHashes)
+}
+
+// GetHeaderBatchCtx is like GetHeaderBatch, the batch requests are bound to the provided context
+func (c *chain) GetHeaderBatchCtx(ctx context.Context, blockHashes []types.Hash) ([]*types.Header, error) {
+	res := make([]*types.Header, len(blockHashes))
+
+	batch, err := newBlockHashBatch("chain_getHeader", blockHashes, func(i int) interface{} {
+		return &res[i]
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.BatchCallWithSizeContext(ctx, c.client, batch, c.batchSize); err != nil {
+		return nil, err
+	}
+
+	return res, client.NewBatchError(batch)
 }
 
-func (c *chain) getHeader(blockHash *types.Hash) (*types.Header, error) {
+func (c *chain) getHeader(ctx context.Context, blockHash *types.Hash) (*types.Header, error) {
 	var Header types.Header
-	err := client.CallWithBlockHash(c.client, &Header, "chain_getHeader", blockHash)
+	err := client.CallWithBlockHashContext(ctx, c.client, &Header, "chain_getHeader", blockHash)
 	if err != nil {
 		return nil, err
 	}
@@ -19,6 +19,7 @@ package chain
 import (
 	"testing"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,3 +37,14 @@ func TestChain_GetHeader(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, header)
 }
+
+func TestChain_GetHeaderBatch(t *testing.T) {
+	res, err := testChain.GetFinalizedHead()
+	assert.NoError(t, err)
+
+	headers, err := testChain.GetHeaderBatch([]types.Hash{res, {}})
+	assert.NoError(t, err)
+	assert.Len(t, headers, 2)
+	assert.NotEmpty(t, headers[0].Number)
+	assert.Nil(t, headers[1])
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	chain "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
 	block "github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
 
@@ -39,6 +41,75 @@ func (_m *Chain) GetBlock(blockHash types.Hash) (*block.SignedBlock, error) {
 	return r0, r1
 }
 
+// GetBlockBatch provides a mock function with given fields: blockHashes
+func (_m *Chain) GetBlockBatch(blockHashes []types.Hash) ([]*block.SignedBlock, error) {
+	ret := _m.Called(blockHashes)
+
+	var r0 []*block.SignedBlock
+	if rf, ok := ret.Get(0).(func([]types.Hash) []*block.SignedBlock); ok {
+		r0 = rf(blockHashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*block.SignedBlock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]types.Hash) error); ok {
+		r1 = rf(blockHashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockBatchCtx provides a mock function with given fields: ctx, blockHashes
+func (_m *Chain) GetBlockBatchCtx(ctx context.Context, blockHashes []types.Hash) ([]*block.SignedBlock, error) {
+	ret := _m.Called(ctx, blockHashes)
+
+	var r0 []*block.SignedBlock
+	if rf, ok := ret.Get(0).(func(context.Context, []types.Hash) []*block.SignedBlock); ok {
+		r0 = rf(ctx, blockHashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*block.SignedBlock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.Hash) error); ok {
+		r1 = rf(ctx, blockHashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockCtx provides a mock function with given fields: ctx, blockHash
+func (_m *Chain) GetBlockCtx(ctx context.Context, blockHash types.Hash) (*block.SignedBlock, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *block.SignedBlock
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) *block.SignedBlock); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*block.SignedBlock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockHash provides a mock function with given fields: blockNumber
 func (_m *Chain) GetBlockHash(blockNumber uint64) (types.Hash, error) {
 	ret := _m.Called(blockNumber)
@@ -62,6 +133,75 @@ func (_m *Chain) GetBlockHash(blockNumber uint64) (types.Hash, error) {
 	return r0, r1
 }
 
+// GetBlockHashBatch provides a mock function with given fields: blockNumbers
+func (_m *Chain) GetBlockHashBatch(blockNumbers []uint64) ([]types.Hash, error) {
+	ret := _m.Called(blockNumbers)
+
+	var r0 []types.Hash
+	if rf, ok := ret.Get(0).(func([]uint64) []types.Hash); ok {
+		r0 = rf(blockNumbers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]uint64) error); ok {
+		r1 = rf(blockNumbers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockHashBatchCtx provides a mock function with given fields: ctx, blockNumbers
+func (_m *Chain) GetBlockHashBatchCtx(ctx context.Context, blockNumbers []uint64) ([]types.Hash, error) {
+	ret := _m.Called(ctx, blockNumbers)
+
+	var r0 []types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, []uint64) []types.Hash); ok {
+		r0 = rf(ctx, blockNumbers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []uint64) error); ok {
+		r1 = rf(ctx, blockNumbers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockHashCtx provides a mock function with given fields: ctx, blockNumber
+func (_m *Chain) GetBlockHashCtx(ctx context.Context, blockNumber uint64) (types.Hash, error) {
+	ret := _m.Called(ctx, blockNumber)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) types.Hash); ok {
+		r0 = rf(ctx, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockHashLatest provides a mock function with given fields:
 func (_m *Chain) GetBlockHashLatest() (types.Hash, error) {
 	ret := _m.Called()
@@ -85,6 +225,29 @@ func (_m *Chain) GetBlockHashLatest() (types.Hash, error) {
 	return r0, r1
 }
 
+// GetBlockHashLatestCtx provides a mock function with given fields: ctx
+func (_m *Chain) GetBlockHashLatestCtx(ctx context.Context) (types.Hash, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context) types.Hash); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockLatest provides a mock function with given fields:
 func (_m *Chain) GetBlockLatest() (*block.SignedBlock, error) {
 	ret := _m.Called()
@@ -108,6 +271,29 @@ func (_m *Chain) GetBlockLatest() (*block.SignedBlock, error) {
 	return r0, r1
 }
 
+// GetBlockLatestCtx provides a mock function with given fields: ctx
+func (_m *Chain) GetBlockLatestCtx(ctx context.Context) (*block.SignedBlock, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *block.SignedBlock
+	if rf, ok := ret.Get(0).(func(context.Context) *block.SignedBlock); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*block.SignedBlock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFinalizedHead provides a mock function with given fields:
 func (_m *Chain) GetFinalizedHead() (types.Hash, error) {
 	ret := _m.Called()
@@ -131,6 +317,29 @@ func (_m *Chain) GetFinalizedHead() (types.Hash, error) {
 	return r0, r1
 }
 
+// GetFinalizedHeadCtx provides a mock function with given fields: ctx
+func (_m *Chain) GetFinalizedHeadCtx(ctx context.Context) (types.Hash, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context) types.Hash); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetHeader provides a mock function with given fields: blockHash
 func (_m *Chain) GetHeader(blockHash types.Hash) (*types.Header, error) {
 	ret := _m.Called(blockHash)
@@ -154,6 +363,75 @@ func (_m *Chain) GetHeader(blockHash types.Hash) (*types.Header, error) {
 	return r0, r1
 }
 
+// GetHeaderBatch provides a mock function with given fields: blockHashes
+func (_m *Chain) GetHeaderBatch(blockHashes []types.Hash) ([]*types.Header, error) {
+	ret := _m.Called(blockHashes)
+
+	var r0 []*types.Header
+	if rf, ok := ret.Get(0).(func([]types.Hash) []*types.Header); ok {
+		r0 = rf(blockHashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Header)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]types.Hash) error); ok {
+		r1 = rf(blockHashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHeaderBatchCtx provides a mock function with given fields: ctx, blockHashes
+func (_m *Chain) GetHeaderBatchCtx(ctx context.Context, blockHashes []types.Hash) ([]*types.Header, error) {
+	ret := _m.Called(ctx, blockHashes)
+
+	var r0 []*types.Header
+	if rf, ok := ret.Get(0).(func(context.Context, []types.Hash) []*types.Header); ok {
+		r0 = rf(ctx, blockHashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Header)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.Hash) error); ok {
+		r1 = rf(ctx, blockHashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHeaderCtx provides a mock function with given fields: ctx, blockHash
+func (_m *Chain) GetHeaderCtx(ctx context.Context, blockHash types.Hash) (*types.Header, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *types.Header
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) *types.Header); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Header)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetHeaderLatest provides a mock function with given fields:
 func (_m *Chain) GetHeaderLatest() (*types.Header, error) {
 	ret := _m.Called()
@@ -177,6 +455,29 @@ func (_m *Chain) GetHeaderLatest() (*types.Header, error) {
 	return r0, r1
 }
 
+// GetHeaderLatestCtx provides a mock function with given fields: ctx
+func (_m *Chain) GetHeaderLatestCtx(ctx context.Context) (*types.Header, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *types.Header
+	if rf, ok := ret.Get(0).(func(context.Context) *types.Header); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Header)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeFinalizedHeads provides a mock function with given fields:
 func (_m *Chain) SubscribeFinalizedHeads() (*chain.FinalizedHeadsSubscription, error) {
 	ret := _m.Called()
@@ -200,6 +501,29 @@ func (_m *Chain) SubscribeFinalizedHeads() (*chain.FinalizedHeadsSubscription, e
 	return r0, r1
 }
 
+// SubscribeFinalizedHeadsCtx provides a mock function with given fields: ctx
+func (_m *Chain) SubscribeFinalizedHeadsCtx(ctx context.Context) (*chain.FinalizedHeadsSubscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *chain.FinalizedHeadsSubscription
+	if rf, ok := ret.Get(0).(func(context.Context) *chain.FinalizedHeadsSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*chain.FinalizedHeadsSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeNewHeads provides a mock function with given fields:
 func (_m *Chain) SubscribeNewHeads() (*chain.NewHeadsSubscription, error) {
 	ret := _m.Called()
@@ -223,6 +547,29 @@ func (_m *Chain) SubscribeNewHeads() (*chain.NewHeadsSubscription, error) {
 	return r0, r1
 }
 
+// SubscribeNewHeadsCtx provides a mock function with given fields: ctx
+func (_m *Chain) SubscribeNewHeadsCtx(ctx context.Context) (*chain.NewHeadsSubscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *chain.NewHeadsSubscription
+	if rf, ok := ret.Get(0).(func(context.Context) *chain.NewHeadsSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*chain.NewHeadsSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewChainT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -20,6 +20,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -63,16 +64,26 @@ func (s *FinalizedHeadsSubscription) Unsubscribe() {
 // SubscribeFinalizedHeads subscribes the best finalized headers, returning a subscription that will
 // receive server notifications containing the Header.
 func (c *chain) SubscribeFinalizedHeads() (*FinalizedHeadsSubscription, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	return c.SubscribeFinalizedHeadsCtx(context.Background())
+}
+
+// SubscribeFinalizedHeadsCtx is like SubscribeFinalizedHeads, the subscription is unsubscribed once the provided
+// context is done
+func (c *chain) SubscribeFinalizedHeadsCtx(ctx context.Context) (*FinalizedHeadsSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
 	defer cancel()
 
 	ch := make(chan types.Header)
 
-	sub, err := c.client.Subscribe(ctx, "chain", "subscribeFinalizedHeads", "unsubscribeFinalizedHeads",
+	sub, err := c.client.Subscribe(subscribeCtx, "chain", "subscribeFinalizedHeads", "unsubscribeFinalizedHeads",
 		"finalizedHead", ch)
 	if err != nil {
 		return nil, err
 	}
 
-	return &FinalizedHeadsSubscription{sub: sub, channel: ch}, nil
+	s := &FinalizedHeadsSubscription{sub: sub, channel: ch}
+
+	client.UnsubscribeOnDone(ctx, sub, s.Unsubscribe)
+
+	return s, nil
 }
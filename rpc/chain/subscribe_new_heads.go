@@ -20,6 +20,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -63,15 +64,25 @@ func (s *NewHeadsSubscription) Unsubscribe() {
 // SubscribeNewHeads subscribes the best headers, returning a subscription that will
 // receive server notifications containing the Header.
 func (c *chain) SubscribeNewHeads() (*NewHeadsSubscription, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	return c.SubscribeNewHeadsCtx(context.Background())
+}
+
+// SubscribeNewHeadsCtx is like SubscribeNewHeads, the subscription is unsubscribed once the provided
+// context is done
+func (c *chain) SubscribeNewHeadsCtx(ctx context.Context) (*NewHeadsSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
 	defer cancel()
 
 	ch := make(chan types.Header)
 
-	sub, err := c.client.Subscribe(ctx, "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	sub, err := c.client.Subscribe(subscribeCtx, "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
 	if err != nil {
 		return nil, err
 	}
 
-	return &NewHeadsSubscription{sub: sub, channel: ch}, nil
+	s := &NewHeadsSubscription{sub: sub, channel: ch}
+
+	client.UnsubscribeOnDone(ctx, sub, s.Unsubscribe)
+
+	return s, nil
 }
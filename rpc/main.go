@@ -18,11 +18,13 @@ package rpc
 
 import (
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/beefy"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/mmr"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/offchain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/payment"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -34,29 +36,62 @@ type RPC struct {
 	Chain    chain.Chain
 	MMR      mmr.MMR
 	Offchain offchain.Offchain
+	Payment  payment.Payment
 	State    state.State
 	System   system.System
-	client   client.Client
+	// SerDeOptions are the serialise and deserialize options of the connected chain, derived from its metadata. They
+	// are used by State, Author and Payment, and can be used with types.EncodeWithOptions or
+	// types.DecodeWithOptions to encode or decode other data of the chain.
+	SerDeOptions types.SerDeOptions
+	client       client.Client
 }
 
-func NewRPC(cl client.Client) (*RPC, error) {
+// Option is the type used for configuring the RPC wrappers created by NewRPC.
+type Option func(o *options)
+
+type options struct {
+	batchSize int
+}
+
+// WithBatchSize sets the maximum number of requests that State and Chain send in a single JSON-RPC batch request,
+// such as the BatchSize of a config.Config. The default is the BatchSize of config.Default.
+func WithBatchSize(batchSize int) Option {
+	return func(o *options) {
+		o.batchSize = batchSize
+	}
+}
+
+// NewRPC creates the RPC wrappers for the provided client, the serialise and deserialize options of the chain are
+// derived from its latest metadata and passed to the wrappers that encode or decode chain data.
+//
+// The process-wide default options are left untouched, so that multiple RPC instances connected to chains that need
+// different options can be used side by side. Use RPC.SerDeOptions with types.EncodeWithOptions or
+// types.DecodeWithOptions to encode or decode other data of the chain.
+func NewRPC(cl client.Client, opts ...Option) (*RPC, error) {
+	o := options{batchSize: config.Default().BatchSize}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	st := state.NewState(cl)
 	meta, err := st.GetMetadataLatest()
 	if err != nil {
 		return nil, err
 	}
 
-	opts := types.SerDeOptionsFromMetadata(meta)
-	types.SetSerDeOptions(opts)
+	serDeOpts := types.SerDeOptionsFromMetadata(meta)
 
 	return &RPC{
-		Author:   author.NewAuthor(cl),
-		Beefy:    beefy.NewBeefy(cl),
-		Chain:    chain.NewChain(cl),
-		MMR:      mmr.NewMMR(cl),
-		Offchain: offchain.NewOffchain(cl),
-		State:    st,
-		System:   system.NewSystem(cl),
-		client:   cl,
+		Author:       author.NewAuthor(cl, author.WithSerDeOptions(serDeOpts)),
+		Beefy:        beefy.NewBeefy(cl),
+		Chain:        chain.NewChain(cl, chain.WithBatchSize(o.batchSize)),
+		MMR:          mmr.NewMMR(cl),
+		Offchain:     offchain.NewOffchain(cl),
+		Payment:      payment.NewPayment(cl, payment.WithSerDeOptions(serDeOpts)),
+		State:        state.NewState(cl, state.WithSerDeOptions(serDeOpts), state.WithBatchSize(o.batchSize)),
+		System:       system.NewSystem(cl),
+		SerDeOptions: serDeOpts,
+		client:       cl,
 	}, nil
 }
@@ -19,6 +19,8 @@
 package mmr
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
@@ -26,7 +28,9 @@ import (
 // MMR exposes methods for retrieval of MMR data
 type MMR interface {
 	GenerateProof(leafIndex uint64, blockHash types.Hash) (types.GenerateMMRProofResponse, error)
+	GenerateProofCtx(ctx context.Context, leafIndex uint64, blockHash types.Hash) (types.GenerateMMRProofResponse, error)
 	GenerateProofLatest(leafIndex uint64) (types.GenerateMMRProofResponse, error)
+	GenerateProofLatestCtx(ctx context.Context, leafIndex uint64) (types.GenerateMMRProofResponse, error)
 }
 
 type mmr struct {
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -33,6 +35,27 @@ func (_m *MMR) GenerateProof(leafIndex uint64, blockHash types.Hash) (types.Gene
 	return r0, r1
 }
 
+// GenerateProofCtx provides a mock function with given fields: ctx, leafIndex, blockHash
+func (_m *MMR) GenerateProofCtx(ctx context.Context, leafIndex uint64, blockHash types.Hash) (types.GenerateMMRProofResponse, error) {
+	ret := _m.Called(ctx, leafIndex, blockHash)
+
+	var r0 types.GenerateMMRProofResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, types.Hash) types.GenerateMMRProofResponse); ok {
+		r0 = rf(ctx, leafIndex, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.GenerateMMRProofResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, types.Hash) error); ok {
+		r1 = rf(ctx, leafIndex, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GenerateProofLatest provides a mock function with given fields: leafIndex
 func (_m *MMR) GenerateProofLatest(leafIndex uint64) (types.GenerateMMRProofResponse, error) {
 	ret := _m.Called(leafIndex)
@@ -54,6 +77,27 @@ func (_m *MMR) GenerateProofLatest(leafIndex uint64) (types.GenerateMMRProofResp
 	return r0, r1
 }
 
+// GenerateProofLatestCtx provides a mock function with given fields: ctx, leafIndex
+func (_m *MMR) GenerateProofLatestCtx(ctx context.Context, leafIndex uint64) (types.GenerateMMRProofResponse, error) {
+	ret := _m.Called(ctx, leafIndex)
+
+	var r0 types.GenerateMMRProofResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) types.GenerateMMRProofResponse); ok {
+		r0 = rf(ctx, leafIndex)
+	} else {
+		r0 = ret.Get(0).(types.GenerateMMRProofResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, leafIndex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewMMRT interface {
 	mock.TestingT
 	Cleanup(func())
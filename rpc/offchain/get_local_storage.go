@@ -17,6 +17,7 @@
 package offchain
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -35,9 +36,18 @@ const (
 
 // LocalStorageGet retrieves the stored data
 func (c *offchain) LocalStorageGet(kind StorageKind, key []byte) (*types.StorageDataRaw, error) {
+	return c.LocalStorageGetCtx(context.Background(), kind, key)
+}
+
+// LocalStorageGetCtx retrieves the stored data, the request is bound to the provided context
+func (c *offchain) LocalStorageGetCtx(
+	ctx context.Context,
+	kind StorageKind,
+	key []byte,
+) (*types.StorageDataRaw, error) {
 	var res string
 
-	err := c.client.Call(&res, "offchain_localStorageGet", kind, fmt.Sprintf("%#x", key))
+	err := c.client.CallContext(ctx, &res, "offchain_localStorageGet", kind, fmt.Sprintf("%#x", key))
 	if err != nil {
 		return nil, err
 	}
@@ -57,9 +67,21 @@ func (c *offchain) LocalStorageGet(kind StorageKind, key []byte) (*types.Storage
 
 // LocalStorageSet saves the data
 func (c *offchain) LocalStorageSet(kind StorageKind, key []byte, value []byte) error {
+	return c.LocalStorageSetCtx(context.Background(), kind, key, value)
+}
+
+// LocalStorageSetCtx saves the data, the request is bound to the provided context
+func (c *offchain) LocalStorageSetCtx(ctx context.Context, kind StorageKind, key []byte, value []byte) error {
 	var res string
 
-	err := c.client.Call(&res, "offchain_localStorageSet", kind, fmt.Sprintf("%#x", key), fmt.Sprintf("%#x", value))
+	err := c.client.CallContext(
+		ctx,
+		&res,
+		"offchain_localStorageSet",
+		kind,
+		fmt.Sprintf("%#x", key),
+		fmt.Sprintf("%#x", value),
+	)
 	if err != nil {
 		return err
 	}
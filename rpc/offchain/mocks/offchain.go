@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	offchain "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/offchain"
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	mock "github.com/stretchr/testify/mock"
@@ -36,6 +38,29 @@ func (_m *Offchain) LocalStorageGet(kind offchain.StorageKind, key []byte) (*typ
 	return r0, r1
 }
 
+// LocalStorageGetCtx provides a mock function with given fields: ctx, kind, key
+func (_m *Offchain) LocalStorageGetCtx(ctx context.Context, kind offchain.StorageKind, key []byte) (*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, kind, key)
+
+	var r0 *types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(context.Context, offchain.StorageKind, []byte) *types.StorageDataRaw); ok {
+		r0 = rf(ctx, kind, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StorageDataRaw)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, offchain.StorageKind, []byte) error); ok {
+		r1 = rf(ctx, kind, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LocalStorageSet provides a mock function with given fields: kind, key, value
 func (_m *Offchain) LocalStorageSet(kind offchain.StorageKind, key []byte, value []byte) error {
 	ret := _m.Called(kind, key, value)
@@ -50,6 +75,20 @@ func (_m *Offchain) LocalStorageSet(kind offchain.StorageKind, key []byte, value
 	return r0
 }
 
+// LocalStorageSetCtx provides a mock function with given fields: ctx, kind, key, value
+func (_m *Offchain) LocalStorageSetCtx(ctx context.Context, kind offchain.StorageKind, key []byte, value []byte) error {
+	ret := _m.Called(ctx, kind, key, value)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, offchain.StorageKind, []byte, []byte) error); ok {
+		r0 = rf(ctx, kind, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type NewOffchainT interface {
 	mock.TestingT
 	Cleanup(func())
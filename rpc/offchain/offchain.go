@@ -19,13 +19,17 @@
 package offchain
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 type Offchain interface {
 	LocalStorageGet(kind StorageKind, key []byte) (*types.StorageDataRaw, error)
+	LocalStorageGetCtx(ctx context.Context, kind StorageKind, key []byte) (*types.StorageDataRaw, error)
 	LocalStorageSet(kind StorageKind, key []byte, value []byte) error
+	LocalStorageSetCtx(ctx context.Context, kind StorageKind, key []byte, value []byte) error
 }
 
 // offchain exposes methods for retrieval of off-chain data
@@ -0,0 +1,84 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+)
+
+// FeeEstimate holds the dispatch information and the fee breakdown of an extrinsic.
+type FeeEstimate struct {
+	// Weight of the extrinsic
+	Weight types.Weight
+	// Class of the extrinsic
+	Class types.DispatchClass
+	// PartialFee is the total inclusion fee, excluding the tip
+	PartialFee types.U128
+	// InclusionFee holds the base, length and adjusted weight fees. It is empty for unsigned extrinsics
+	InclusionFee types.Option[types.InclusionFee]
+}
+
+// EstimateFee estimates the fees of the provided extrinsic at the latest block, using the
+// TransactionPaymentApi runtime API.
+//
+// If the extrinsic is not signed, a fake signature is added before querying the fees, using the provided
+// signer and signing options. The signing options should match the ones used when actually signing the extrinsic
+// since they affect its encoded length, and thus, the length fee. Signers with ECDSA keys must pass
+// extrinsic.WithSignatureType(extrinsic.SignatureTypeEcdsa), the fake signature is an Sr25519 one otherwise.
+func (p *payment) EstimateFee(
+	xt extrinsic.Extrinsic,
+	meta *types.Metadata,
+	signer types.MultiAddress,
+	opts ...extrinsic.SigningOption,
+) (*FeeEstimate, error) {
+	return p.EstimateFeeCtx(context.Background(), xt, meta, signer, opts...)
+}
+
+// EstimateFeeCtx is like EstimateFee, the requests are bound to the provided context
+func (p *payment) EstimateFeeCtx(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	meta *types.Metadata,
+	signer types.MultiAddress,
+	opts ...extrinsic.SigningOption,
+) (*FeeEstimate, error) {
+	if !xt.IsSigned() {
+		if err := xt.SignFake(signer, meta, opts...); err != nil {
+			return nil, err
+		}
+	}
+
+	info, err := p.RuntimeQueryInfoLatestCtx(ctx, xt)
+	if err != nil {
+		return nil, err
+	}
+
+	feeDetails, err := p.RuntimeQueryFeeDetailsLatestCtx(ctx, xt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FeeEstimate{
+		Weight:       info.Weight,
+		Class:        info.Class,
+		PartialFee:   info.PartialFee,
+		InclusionFee: feeDetails.InclusionFee,
+	}, nil
+}
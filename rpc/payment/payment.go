@@ -0,0 +1,130 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Payment --filename payment.go
+
+package payment
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+)
+
+// Payment exposes methods for querying the fees of extrinsics
+type Payment interface {
+	QueryInfo(xt extrinsic.Extrinsic, blockHash types.Hash) (*types.RuntimeDispatchInfo, error)
+	QueryInfoCtx(ctx context.Context, xt extrinsic.Extrinsic, blockHash types.Hash) (*types.RuntimeDispatchInfo, error)
+	QueryInfoLatest(xt extrinsic.Extrinsic) (*types.RuntimeDispatchInfo, error)
+	QueryInfoLatestCtx(ctx context.Context, xt extrinsic.Extrinsic) (*types.RuntimeDispatchInfo, error)
+
+	QueryFeeDetails(xt extrinsic.Extrinsic, blockHash types.Hash) (*types.FeeDetails, error)
+	QueryFeeDetailsCtx(ctx context.Context, xt extrinsic.Extrinsic, blockHash types.Hash) (*types.FeeDetails, error)
+	QueryFeeDetailsLatest(xt extrinsic.Extrinsic) (*types.FeeDetails, error)
+	QueryFeeDetailsLatestCtx(ctx context.Context, xt extrinsic.Extrinsic) (*types.FeeDetails, error)
+
+	RuntimeQueryInfo(xt extrinsic.Extrinsic, blockHash types.Hash) (*types.RuntimeDispatchInfo, error)
+	RuntimeQueryInfoCtx(
+		ctx context.Context,
+		xt extrinsic.Extrinsic,
+		blockHash types.Hash,
+	) (*types.RuntimeDispatchInfo, error)
+	RuntimeQueryInfoLatest(xt extrinsic.Extrinsic) (*types.RuntimeDispatchInfo, error)
+	RuntimeQueryInfoLatestCtx(ctx context.Context, xt extrinsic.Extrinsic) (*types.RuntimeDispatchInfo, error)
+
+	RuntimeQueryFeeDetails(xt extrinsic.Extrinsic, blockHash types.Hash) (*types.FeeDetails, error)
+	RuntimeQueryFeeDetailsCtx(ctx context.Context, xt extrinsic.Extrinsic, blockHash types.Hash) (*types.FeeDetails, error)
+	RuntimeQueryFeeDetailsLatest(xt extrinsic.Extrinsic) (*types.FeeDetails, error)
+	RuntimeQueryFeeDetailsLatestCtx(ctx context.Context, xt extrinsic.Extrinsic) (*types.FeeDetails, error)
+
+	EstimateFee(
+		xt extrinsic.Extrinsic,
+		meta *types.Metadata,
+		signer types.MultiAddress,
+		opts ...extrinsic.SigningOption,
+	) (*FeeEstimate, error)
+	EstimateFeeCtx(
+		ctx context.Context,
+		xt extrinsic.Extrinsic,
+		meta *types.Metadata,
+		signer types.MultiAddress,
+		opts ...extrinsic.SigningOption,
+	) (*FeeEstimate, error)
+}
+
+// payment exposes methods for querying the fees of extrinsics
+type payment struct {
+	client       client.Client
+	state        state.State
+	serDeOptions *types.SerDeOptions
+}
+
+// Option is the type used for configuring a Payment
+type Option func(p *payment)
+
+// WithSerDeOptions sets the options used for encoding the extrinsics and decoding the runtime API results instead of
+// the default ones, see types.SerDeOptionsFromMetadata
+func WithSerDeOptions(opts types.SerDeOptions) Option {
+	return func(p *payment) {
+		p.serDeOptions = &opts
+	}
+}
+
+// NewPayment creates a new payment struct, configured with the provided options
+func NewPayment(cl client.Client, opts ...Option) Payment {
+	p := &payment{client: cl}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	var stateOpts []state.Option
+	if p.serDeOptions != nil {
+		stateOpts = append(stateOpts, state.WithSerDeOptions(*p.serDeOptions))
+	}
+
+	p.state = state.NewState(cl, stateOpts...)
+
+	return p
+}
+
+func (p *payment) encode(value interface{}) ([]byte, error) {
+	if p.serDeOptions == nil {
+		return codec.Encode(value)
+	}
+
+	return types.EncodeWithOptions(value, *p.serDeOptions)
+}
+
+func (p *payment) encodeToHex(value interface{}) (string, error) {
+	if p.serDeOptions == nil {
+		return codec.EncodeToHex(value)
+	}
+
+	return types.EncodeToHexWithOptions(value, *p.serDeOptions)
+}
+
+func (p *payment) decode(bz []byte, target interface{}) error {
+	if p.serDeOptions == nil {
+		return codec.Decode(bz, target)
+	}
+
+	return types.DecodeWithOptions(bz, target, *p.serDeOptions)
+}
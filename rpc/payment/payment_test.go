@@ -0,0 +1,115 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var testPayment Payment
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+
+	if err := s.RegisterName("payment", &mockSrv); err != nil {
+		panic(err)
+	}
+
+	if err := s.RegisterName("state", &mockStateSrv); err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+
+	testPayment = NewPayment(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	blockHashLatest types.Hash
+	queryInfo       json.RawMessage
+	feeDetails      json.RawMessage
+	dispatchInfo    types.RuntimeDispatchInfo
+	inclusionFee    types.InclusionFee
+}
+
+func (s *MockSrv) QueryInfo(xt string, hash *string) json.RawMessage {
+	return mockSrv.queryInfo
+}
+
+func (s *MockSrv) QueryFeeDetails(xt string, hash *string) json.RawMessage {
+	return mockSrv.feeDetails
+}
+
+// MockStateSrv exposes the state_call method used for calling runtime APIs.
+type MockStateSrv struct {
+	// lastCallData holds the data of the last runtime API call.
+	lastCallData string
+}
+
+func (s *MockStateSrv) Call(method string, data string, hash *string) string {
+	s.lastCallData = data
+
+	switch method {
+	case queryInfoRuntimeAPIMethod:
+		return mustEncodeToHex(mockSrv.dispatchInfo)
+	case queryFeeDetailsRuntimeAPIMethod:
+		return mustEncodeToHex(types.FeeDetails{InclusionFee: types.NewOption(mockSrv.inclusionFee)})
+	default:
+		panic("method not found")
+	}
+}
+
+func mustEncodeToHex(value interface{}) string {
+	res, err := codec.EncodeToHex(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+var mockSrv = MockSrv{
+	blockHashLatest: types.Hash{1, 2, 3},
+	queryInfo:       json.RawMessage(`{"weight":{"ref_time":154407000,"proof_size":3593},"class":"normal","partialFee":"15488191544"}`),  //nolint:lll
+	feeDetails:      json.RawMessage(`{"inclusionFee":{"baseFee":"0x3b9aca00","lenFee":"0x2de660880","adjustedWeightFee":"0x5c52f5d"}}`), //nolint:lll
+	dispatchInfo: types.RuntimeDispatchInfo{
+		Weight:     types.NewWeight(types.NewUCompactFromUInt(154407000), types.NewUCompactFromUInt(3593)),
+		Class:      types.DispatchClass{IsNormal: true},
+		PartialFee: types.NewU128(*big.NewInt(15488191544)),
+	},
+	inclusionFee: types.InclusionFee{
+		BaseFee:           types.NewU128(*big.NewInt(1000000000)),
+		LenFee:            types.NewU128(*big.NewInt(12321163392)),
+		AdjustedWeightFee: types.NewU128(*big.NewInt(96808797)),
+	},
+}
+
+var mockStateSrv = MockStateSrv{}
@@ -0,0 +1,68 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+)
+
+// QueryFeeDetails retrieves the fee breakdown for the provided extrinsic at the given block
+func (p *payment) QueryFeeDetails(xt extrinsic.Extrinsic, blockHash types.Hash) (*types.FeeDetails, error) {
+	return p.QueryFeeDetailsCtx(context.Background(), xt, blockHash)
+}
+
+// QueryFeeDetailsCtx is like QueryFeeDetails, the request is bound to the provided context
+func (p *payment) QueryFeeDetailsCtx(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	blockHash types.Hash,
+) (*types.FeeDetails, error) {
+	return p.queryFeeDetails(ctx, xt, &blockHash)
+}
+
+// QueryFeeDetailsLatest retrieves the fee breakdown for the provided extrinsic at the latest block
+func (p *payment) QueryFeeDetailsLatest(xt extrinsic.Extrinsic) (*types.FeeDetails, error) {
+	return p.QueryFeeDetailsLatestCtx(context.Background(), xt)
+}
+
+// QueryFeeDetailsLatestCtx is like QueryFeeDetailsLatest, the request is bound to the provided context
+func (p *payment) QueryFeeDetailsLatestCtx(ctx context.Context, xt extrinsic.Extrinsic) (*types.FeeDetails, error) {
+	return p.queryFeeDetails(ctx, xt, nil)
+}
+
+func (p *payment) queryFeeDetails(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	blockHash *types.Hash,
+) (*types.FeeDetails, error) {
+	enc, err := p.encodeToHex(xt)
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.FeeDetails
+	err = client.CallWithBlockHashContext(ctx, p.client, &res, "payment_queryFeeDetails", blockHash, enc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,68 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+)
+
+// QueryInfo retrieves the fee information for the provided extrinsic at the given block
+func (p *payment) QueryInfo(xt extrinsic.Extrinsic, blockHash types.Hash) (*types.RuntimeDispatchInfo, error) {
+	return p.QueryInfoCtx(context.Background(), xt, blockHash)
+}
+
+// QueryInfoCtx is like QueryInfo, the request is bound to the provided context
+func (p *payment) QueryInfoCtx(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	blockHash types.Hash,
+) (*types.RuntimeDispatchInfo, error) {
+	return p.queryInfo(ctx, xt, &blockHash)
+}
+
+// QueryInfoLatest retrieves the fee information for the provided extrinsic at the latest block
+func (p *payment) QueryInfoLatest(xt extrinsic.Extrinsic) (*types.RuntimeDispatchInfo, error) {
+	return p.QueryInfoLatestCtx(context.Background(), xt)
+}
+
+// QueryInfoLatestCtx is like QueryInfoLatest, the request is bound to the provided context
+func (p *payment) QueryInfoLatestCtx(ctx context.Context, xt extrinsic.Extrinsic) (*types.RuntimeDispatchInfo, error) {
+	return p.queryInfo(ctx, xt, nil)
+}
+
+func (p *payment) queryInfo(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	blockHash *types.Hash,
+) (*types.RuntimeDispatchInfo, error) {
+	enc, err := p.encodeToHex(xt)
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.RuntimeDispatchInfo
+	err = client.CallWithBlockHashContext(ctx, p.client, &res, "payment_queryInfo", blockHash, enc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
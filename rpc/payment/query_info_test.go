@@ -0,0 +1,37 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayment_QueryInfoLatest(t *testing.T) {
+	res, err := testPayment.QueryInfoLatest(extrinsic.NewExtrinsic(types.Call{}))
+	assert.NoError(t, err)
+	assert.Equal(t, &mockSrv.dispatchInfo, res)
+}
+
+func TestPayment_QueryInfo(t *testing.T) {
+	res, err := testPayment.QueryInfo(extrinsic.NewExtrinsic(types.Call{}), mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assert.Equal(t, &mockSrv.dispatchInfo, res)
+}
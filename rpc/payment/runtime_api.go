@@ -0,0 +1,142 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+)
+
+const (
+	queryInfoRuntimeAPIMethod       = "TransactionPaymentApi_query_info"
+	queryFeeDetailsRuntimeAPIMethod = "TransactionPaymentApi_query_fee_details"
+)
+
+// RuntimeQueryInfo retrieves the fee information for the provided extrinsic at the given block, using the
+// TransactionPaymentApi runtime API
+func (p *payment) RuntimeQueryInfo(xt extrinsic.Extrinsic, blockHash types.Hash) (*types.RuntimeDispatchInfo, error) {
+	return p.RuntimeQueryInfoCtx(context.Background(), xt, blockHash)
+}
+
+// RuntimeQueryInfoCtx is like RuntimeQueryInfo, the request is bound to the provided context
+func (p *payment) RuntimeQueryInfoCtx(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	blockHash types.Hash,
+) (*types.RuntimeDispatchInfo, error) {
+	var res types.RuntimeDispatchInfo
+	if err := p.callRuntimeAPI(ctx, queryInfoRuntimeAPIMethod, xt, &res, &blockHash); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RuntimeQueryInfoLatest retrieves the fee information for the provided extrinsic at the latest block, using the
+// TransactionPaymentApi runtime API
+func (p *payment) RuntimeQueryInfoLatest(xt extrinsic.Extrinsic) (*types.RuntimeDispatchInfo, error) {
+	return p.RuntimeQueryInfoLatestCtx(context.Background(), xt)
+}
+
+// RuntimeQueryInfoLatestCtx is like RuntimeQueryInfoLatest, the request is bound to the provided context
+func (p *payment) RuntimeQueryInfoLatestCtx(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+) (*types.RuntimeDispatchInfo, error) {
+	var res types.RuntimeDispatchInfo
+	if err := p.callRuntimeAPI(ctx, queryInfoRuntimeAPIMethod, xt, &res, nil); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RuntimeQueryFeeDetails retrieves the fee breakdown for the provided extrinsic at the given block, using the
+// TransactionPaymentApi runtime API
+func (p *payment) RuntimeQueryFeeDetails(xt extrinsic.Extrinsic, blockHash types.Hash) (*types.FeeDetails, error) {
+	return p.RuntimeQueryFeeDetailsCtx(context.Background(), xt, blockHash)
+}
+
+// RuntimeQueryFeeDetailsCtx is like RuntimeQueryFeeDetails, the request is bound to the provided context
+func (p *payment) RuntimeQueryFeeDetailsCtx(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	blockHash types.Hash,
+) (*types.FeeDetails, error) {
+	var res types.FeeDetails
+	if err := p.callRuntimeAPI(ctx, queryFeeDetailsRuntimeAPIMethod, xt, &res, &blockHash); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// RuntimeQueryFeeDetailsLatest retrieves the fee breakdown for the provided extrinsic at the latest block, using the
+// TransactionPaymentApi runtime API
+func (p *payment) RuntimeQueryFeeDetailsLatest(xt extrinsic.Extrinsic) (*types.FeeDetails, error) {
+	return p.RuntimeQueryFeeDetailsLatestCtx(context.Background(), xt)
+}
+
+// RuntimeQueryFeeDetailsLatestCtx is like RuntimeQueryFeeDetailsLatest, the request is bound to the provided context
+func (p *payment) RuntimeQueryFeeDetailsLatestCtx(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+) (*types.FeeDetails, error) {
+	var res types.FeeDetails
+	if err := p.callRuntimeAPI(ctx, queryFeeDetailsRuntimeAPIMethod, xt, &res, nil); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// callRuntimeAPI calls a TransactionPaymentApi method via state_call. Both methods expect the encoded
+// extrinsic followed by its encoded length as arguments.
+func (p *payment) callRuntimeAPI(
+	ctx context.Context,
+	method string,
+	xt extrinsic.Extrinsic,
+	target interface{},
+	blockHash *types.Hash,
+) error {
+	encodedExtrinsic, err := p.encode(xt)
+	if err != nil {
+		return err
+	}
+
+	encodedLen, err := p.encode(types.NewU32(uint32(len(encodedExtrinsic))))
+	if err != nil {
+		return err
+	}
+
+	args := append(encodedExtrinsic, encodedLen...)
+
+	var res types.Bytes
+	if blockHash == nil {
+		res, err = p.state.CallLatestCtx(ctx, method, args)
+	} else {
+		res, err = p.state.CallCtx(ctx, method, args, *blockHash)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return p.decode(res, target)
+}
@@ -0,0 +1,118 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayment_RuntimeQueryInfoLatest(t *testing.T) {
+	xt := extrinsic.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 1, MethodIndex: 2}})
+
+	res, err := testPayment.RuntimeQueryInfoLatest(xt)
+	assert.NoError(t, err)
+	assert.Equal(t, &mockSrv.dispatchInfo, res)
+
+	// The encoded extrinsic is followed by its length as u32.
+	assert.Equal(t, "0x"+"0c"+"04"+"0102"+"04000000", mockStateSrv.lastCallData)
+}
+
+func TestPayment_RuntimeQueryInfoLatestWithSerDeOptions(t *testing.T) {
+	opts := types.SerDeOptions{NoPalletIndices: true}
+
+	p := NewPayment(testPayment.(*payment).client, WithSerDeOptions(opts))
+	assert.Equal(t, &opts, p.(*payment).serDeOptions)
+
+	xt := extrinsic.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 1, MethodIndex: 2}})
+
+	res, err := p.RuntimeQueryInfoLatest(xt)
+	assert.NoError(t, err)
+	assert.Equal(t, &mockSrv.dispatchInfo, res)
+	assert.Equal(t, "0x"+"0c"+"04"+"0102"+"04000000", mockStateSrv.lastCallData)
+}
+
+func TestPayment_RuntimeQueryInfo(t *testing.T) {
+	res, err := testPayment.RuntimeQueryInfo(extrinsic.NewExtrinsic(types.Call{}), mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assert.Equal(t, &mockSrv.dispatchInfo, res)
+}
+
+func TestPayment_RuntimeQueryFeeDetailsLatest(t *testing.T) {
+	res, err := testPayment.RuntimeQueryFeeDetailsLatest(extrinsic.NewExtrinsic(types.Call{}))
+	assert.NoError(t, err)
+
+	ok, inclusionFee := res.InclusionFee.Unwrap()
+	assert.True(t, ok)
+	assert.Equal(t, mockSrv.inclusionFee, inclusionFee)
+}
+
+func TestPayment_RuntimeQueryFeeDetails(t *testing.T) {
+	res, err := testPayment.RuntimeQueryFeeDetails(extrinsic.NewExtrinsic(types.Call{}), mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+
+	ok, inclusionFee := res.InclusionFee.Unwrap()
+	assert.True(t, ok)
+	assert.Equal(t, mockSrv.inclusionFee, inclusionFee)
+}
+
+func TestPayment_EstimateFee(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	c, err := types.NewCall(&meta, "System.remark", []byte("test"))
+	assert.NoError(t, err)
+
+	signer, err := types.NewMultiAddressFromHexAccountID(
+		"0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+	)
+	assert.NoError(t, err)
+
+	res, err := testPayment.EstimateFee(
+		extrinsic.NewExtrinsic(c),
+		&meta,
+		signer,
+		extrinsic.WithEra(types.ExtrinsicEra{IsImmortalEra: true}, types.Hash{}),
+		extrinsic.WithNonce(types.NewUCompactFromUInt(1)),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, mockSrv.dispatchInfo.Weight, res.Weight)
+	assert.Equal(t, mockSrv.dispatchInfo.Class, res.Class)
+	assert.Equal(t, mockSrv.dispatchInfo.PartialFee, res.PartialFee)
+	assert.Equal(t, types.NewOption(mockSrv.inclusionFee), res.InclusionFee)
+
+	// The extrinsic sent to the runtime API should have the fake signature.
+	encodedXt, err := codec.HexDecodeString(mockStateSrv.lastCallData)
+	assert.NoError(t, err)
+
+	decoder := scale.NewDecoder(bytes.NewReader(encodedXt))
+
+	_, err = decoder.DecodeUintCompact()
+	assert.NoError(t, err)
+
+	version, err := decoder.ReadOneByte()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(extrinsic.BitSigned), version&extrinsic.BitSigned)
+}
@@ -17,6 +17,8 @@
 package state
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
@@ -26,45 +28,81 @@ import (
 // value is not empty.
 func (s *state) GetChildStorage(childStorageKey, key types.StorageKey, target interface{}, blockHash types.Hash) (
 	ok bool, err error) {
-	raw, err := s.getChildStorageRaw(childStorageKey, key, &blockHash)
+	return s.GetChildStorageCtx(context.Background(), childStorageKey, key, target, blockHash)
+}
+
+// GetChildStorageCtx is like GetChildStorage, the request is bound to the provided context
+func (s *state) GetChildStorageCtx(
+	ctx context.Context,
+	childStorageKey, key types.StorageKey,
+	target interface{},
+	blockHash types.Hash,
+) (ok bool, err error) {
+	raw, err := s.getChildStorageRaw(ctx, childStorageKey, key, &blockHash)
 	if err != nil {
 		return false, err
 	}
 	if len(*raw) == 0 {
 		return false, nil
 	}
-	return true, codec.Decode(*raw, target)
+	return true, s.decode(*raw, target)
 }
 
 // GetChildStorageLatest retreives the child storage for a key for the latest block height and decodes them into the
 // provided interface. Ok is true if the value is not empty.
 func (s *state) GetChildStorageLatest(childStorageKey, key types.StorageKey, target interface{}) (ok bool, err error) {
-	raw, err := s.getChildStorageRaw(childStorageKey, key, nil)
+	return s.GetChildStorageLatestCtx(context.Background(), childStorageKey, key, target)
+}
+
+// GetChildStorageLatestCtx is like GetChildStorageLatest, the request is bound to the provided context
+func (s *state) GetChildStorageLatestCtx(
+	ctx context.Context,
+	childStorageKey, key types.StorageKey,
+	target interface{},
+) (ok bool, err error) {
+	raw, err := s.getChildStorageRaw(ctx, childStorageKey, key, nil)
 	if err != nil {
 		return false, err
 	}
 	if len(*raw) == 0 {
 		return false, nil
 	}
-	return true, codec.Decode(*raw, target)
+	return true, s.decode(*raw, target)
 }
 
 // GetChildStorageRaw retreives the child storage for a key as raw bytes, without decoding them
 func (s *state) GetChildStorageRaw(childStorageKey, key types.StorageKey, blockHash types.Hash) (
 	*types.StorageDataRaw, error) {
-	return s.getChildStorageRaw(childStorageKey, key, &blockHash)
+	return s.GetChildStorageRawCtx(context.Background(), childStorageKey, key, blockHash)
+}
+
+// GetChildStorageRawCtx is like GetChildStorageRaw, the request is bound to the provided context
+func (s *state) GetChildStorageRawCtx(
+	ctx context.Context,
+	childStorageKey, key types.StorageKey,
+	blockHash types.Hash,
+) (*types.StorageDataRaw, error) {
+	return s.getChildStorageRaw(ctx, childStorageKey, key, &blockHash)
 }
 
 // GetChildStorageRawLatest retreives the child storage for a key for the latest block height as raw bytes,
 // without decoding them
 func (s *state) GetChildStorageRawLatest(childStorageKey, key types.StorageKey) (*types.StorageDataRaw, error) {
-	return s.getChildStorageRaw(childStorageKey, key, nil)
+	return s.GetChildStorageRawLatestCtx(context.Background(), childStorageKey, key)
+}
+
+// GetChildStorageRawLatestCtx is like GetChildStorageRawLatest, the request is bound to the provided context
+func (s *state) GetChildStorageRawLatestCtx(
+	ctx context.Context,
+	childStorageKey, key types.StorageKey,
+) (*types.StorageDataRaw, error) {
+	return s.getChildStorageRaw(ctx, childStorageKey, key, nil)
 }
 
-func (s *state) getChildStorageRaw(childStorageKey, key types.StorageKey, blockHash *types.Hash) (
+func (s *state) getChildStorageRaw(ctx context.Context, childStorageKey, key types.StorageKey, blockHash *types.Hash) (
 	*types.StorageDataRaw, error) {
 	var res string
-	err := client.CallWithBlockHash(s.client, &res, "state_getChildStorage", blockHash, childStorageKey.Hex(),
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getChildStorage", blockHash, childStorageKey.Hex(),
 		key.Hex())
 	if err != nil {
 		return nil, err
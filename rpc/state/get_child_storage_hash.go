@@ -17,24 +17,47 @@
 package state
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // GetChildStorageHash retreives the child storage hash for the given key
 func (s *state) GetChildStorageHash(childStorageKey, key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
-	return s.getChildStorageHash(childStorageKey, key, &blockHash)
+	return s.GetChildStorageHashCtx(context.Background(), childStorageKey, key, blockHash)
+}
+
+// GetChildStorageHashCtx is like GetChildStorageHash, the request is bound to the provided context
+func (s *state) GetChildStorageHashCtx(
+	ctx context.Context,
+	childStorageKey, key types.StorageKey,
+	blockHash types.Hash,
+) (types.Hash, error) {
+	return s.getChildStorageHash(ctx, childStorageKey, key, &blockHash)
 }
 
 // GetChildStorageHashLatest retreives the child storage hash for the given key for the latest block height
 func (s *state) GetChildStorageHashLatest(childStorageKey, key types.StorageKey) (types.Hash, error) {
-	return s.getChildStorageHash(childStorageKey, key, nil)
+	return s.GetChildStorageHashLatestCtx(context.Background(), childStorageKey, key)
+}
+
+// GetChildStorageHashLatestCtx is like GetChildStorageHashLatest, the request is bound to the provided context
+func (s *state) GetChildStorageHashLatestCtx(
+	ctx context.Context,
+	childStorageKey, key types.StorageKey,
+) (types.Hash, error) {
+	return s.getChildStorageHash(ctx, childStorageKey, key, nil)
 }
 
-func (s *state) getChildStorageHash(childStorageKey, key types.StorageKey, blockHash *types.Hash) (types.Hash, error) {
+func (s *state) getChildStorageHash(
+	ctx context.Context,
+	childStorageKey, key types.StorageKey,
+	blockHash *types.Hash,
+) (types.Hash, error) {
 	var res string
-	err := client.CallWithBlockHash(s.client, &res, "state_getChildStorageHash", blockHash, childStorageKey.Hex(),
-		key.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getChildStorageHash", blockHash,
+		childStorageKey.Hex(), key.Hex())
 	if err != nil {
 		return types.Hash{}, err
 	}
@@ -17,6 +17,8 @@
 package state
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"testing"
@@ -45,3 +47,21 @@ func TestState_GetMetadata(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, meta, *md)
 }
+
+func TestState_GetMetadataCtx(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	md, err := testState.GetMetadataCtx(context.Background(), mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assert.Equal(t, meta, *md)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	md, err = testState.GetMetadataCtx(ctx, mockSrv.blockHashLatest)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, md)
+}
@@ -17,7 +17,12 @@
 package state
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
@@ -25,42 +30,79 @@ import (
 // GetStorage retreives the stored data and decodes them into the provided interface. Ok is true if the value is not
 // empty.
 func (s *state) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) (ok bool, err error) {
-	raw, err := s.getStorageRaw(key, &blockHash)
+	return s.GetStorageCtx(context.Background(), key, target, blockHash)
+}
+
+// GetStorageCtx is like GetStorage, the request is bound to the provided context
+func (s *state) GetStorageCtx(
+	ctx context.Context,
+	key types.StorageKey,
+	target interface{},
+	blockHash types.Hash,
+) (ok bool, err error) {
+	raw, err := s.getStorageRaw(ctx, key, &blockHash)
 	if err != nil {
 		return false, err
 	}
 	if len(*raw) == 0 {
 		return false, nil
 	}
-	return true, codec.Decode(*raw, target)
+	return true, s.decode(*raw, target)
 }
 
 // GetStorageLatest retreives the stored data for the latest block height and decodes them into the provided interface.
 // Ok is true if the value is not empty.
 func (s *state) GetStorageLatest(key types.StorageKey, target interface{}) (ok bool, err error) {
-	raw, err := s.getStorageRaw(key, nil)
+	return s.GetStorageLatestCtx(context.Background(), key, target)
+}
+
+// GetStorageLatestCtx is like GetStorageLatest, the request is bound to the provided context
+func (s *state) GetStorageLatestCtx(
+	ctx context.Context,
+	key types.StorageKey,
+	target interface{},
+) (ok bool, err error) {
+	raw, err := s.getStorageRaw(ctx, key, nil)
 	if err != nil {
 		return false, err
 	}
 	if len(*raw) == 0 {
 		return false, nil
 	}
-	return true, codec.Decode(*raw, target)
+	return true, s.decode(*raw, target)
 }
 
 // GetStorageRaw retreives the stored data as raw bytes, without decoding them
 func (s *state) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
-	return s.getStorageRaw(key, &blockHash)
+	return s.GetStorageRawCtx(context.Background(), key, blockHash)
+}
+
+// GetStorageRawCtx is like GetStorageRaw, the request is bound to the provided context
+func (s *state) GetStorageRawCtx(
+	ctx context.Context,
+	key types.StorageKey,
+	blockHash types.Hash,
+) (*types.StorageDataRaw, error) {
+	return s.getStorageRaw(ctx, key, &blockHash)
 }
 
 // GetStorageRawLatest retreives the stored data for the latest block height as raw bytes, without decoding them
 func (s *state) GetStorageRawLatest(key types.StorageKey) (*types.StorageDataRaw, error) {
-	return s.getStorageRaw(key, nil)
+	return s.GetStorageRawLatestCtx(context.Background(), key)
+}
+
+// GetStorageRawLatestCtx is like GetStorageRawLatest, the request is bound to the provided context
+func (s *state) GetStorageRawLatestCtx(ctx context.Context, key types.StorageKey) (*types.StorageDataRaw, error) {
+	return s.getStorageRaw(ctx, key, nil)
 }
 
-func (s *state) getStorageRaw(key types.StorageKey, blockHash *types.Hash) (*types.StorageDataRaw, error) {
+func (s *state) getStorageRaw(
+	ctx context.Context,
+	key types.StorageKey,
+	blockHash *types.Hash,
+) (*types.StorageDataRaw, error) {
 	var res string
-	err := client.CallWithBlockHash(s.client, &res, "state_getStorage", blockHash, key.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getStorage", blockHash, key.Hex())
 	if err != nil {
 		return nil, err
 	}
@@ -73,3 +115,164 @@ func (s *state) getStorageRaw(key types.StorageKey, blockHash *types.Hash) (*typ
 	data := types.NewStorageDataRaw(bz)
 	return &data, nil
 }
+
+// GetStorageBatch retreives the stored data of the provided keys using JSON-RPC batch requests, and decodes them into
+// the target with the same index. Ok is true for the values that are not empty. If some of the requests fail, the
+// other values are decoded and a *client.BatchError that holds the errors of the failed requests, keyed by their
+// index, is returned.
+func (s *state) GetStorageBatch(keys []types.StorageKey, targets []interface{}, blockHash types.Hash) ([]bool, error) {
+	return s.GetStorageBatchCtx(context.Background(), keys, targets, blockHash)
+}
+
+// GetStorageBatchCtx is like GetStorageBatch, the batch requests are bound to the provided context
+func (s *state) GetStorageBatchCtx(
+	ctx context.Context,
+	keys []types.StorageKey,
+	targets []interface{},
+	blockHash types.Hash,
+) ([]bool, error) {
+	return s.getStorageBatch(ctx, keys, targets, &blockHash)
+}
+
+// GetStorageBatchLatest retreives the stored data of the provided keys for the latest block height using JSON-RPC
+// batch requests, see GetStorageBatch.
+func (s *state) GetStorageBatchLatest(keys []types.StorageKey, targets []interface{}) ([]bool, error) {
+	return s.GetStorageBatchLatestCtx(context.Background(), keys, targets)
+}
+
+// GetStorageBatchLatestCtx is like GetStorageBatchLatest, the batch requests are bound to the provided context
+func (s *state) GetStorageBatchLatestCtx(
+	ctx context.Context,
+	keys []types.StorageKey,
+	targets []interface{},
+) ([]bool, error) {
+	return s.getStorageBatch(ctx, keys, targets, nil)
+}
+
+// GetStorageRawBatch retreives the stored data of the provided keys as raw bytes using JSON-RPC batch requests. If
+// some of the requests fail, the other values are returned along with a *client.BatchError that holds the errors of
+// the failed requests, keyed by their index.
+func (s *state) GetStorageRawBatch(keys []types.StorageKey, blockHash types.Hash) ([]*types.StorageDataRaw, error) {
+	return s.GetStorageRawBatchCtx(context.Background(), keys, blockHash)
+}
+
+// GetStorageRawBatchCtx is like GetStorageRawBatch, the batch requests are bound to the provided context
+func (s *state) GetStorageRawBatchCtx(
+	ctx context.Context,
+	keys []types.StorageKey,
+	blockHash types.Hash,
+) ([]*types.StorageDataRaw, error) {
+	return s.getStorageRawBatch(ctx, keys, &blockHash)
+}
+
+// GetStorageRawBatchLatest retreives the stored data of the provided keys for the latest block height as raw bytes
+// using JSON-RPC batch requests, see GetStorageRawBatch.
+func (s *state) GetStorageRawBatchLatest(keys []types.StorageKey) ([]*types.StorageDataRaw, error) {
+	return s.GetStorageRawBatchLatestCtx(context.Background(), keys)
+}
+
+// GetStorageRawBatchLatestCtx is like GetStorageRawBatchLatest, the batch requests are bound to the provided context
+func (s *state) GetStorageRawBatchLatestCtx(
+	ctx context.Context,
+	keys []types.StorageKey,
+) ([]*types.StorageDataRaw, error) {
+	return s.getStorageRawBatch(ctx, keys, nil)
+}
+
+func (s *state) getStorageBatch(
+	ctx context.Context,
+	keys []types.StorageKey,
+	targets []interface{},
+	blockHash *types.Hash,
+) ([]bool, error) {
+	if len(keys) != len(targets) {
+		return nil, fmt.Errorf("expected %d targets, got %d", len(keys), len(targets))
+	}
+
+	raws, err := s.getStorageRawBatch(ctx, keys, blockHash)
+
+	var batchErr *client.BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return nil, err
+	}
+
+	if batchErr == nil {
+		batchErr = &client.BatchError{Errors: make(map[int]error)}
+	}
+
+	res := make([]bool, len(keys))
+
+	for i, raw := range raws {
+		if _, failed := batchErr.Errors[i]; failed || len(*raw) == 0 {
+			continue
+		}
+
+		res[i] = true
+
+		if err := s.decode(*raw, targets[i]); err != nil {
+			batchErr.Errors[i] = err
+		}
+	}
+
+	if len(batchErr.Errors) == 0 {
+		return res, nil
+	}
+
+	return res, batchErr
+}
+
+func (s *state) getStorageRawBatch(
+	ctx context.Context,
+	keys []types.StorageKey,
+	blockHash *types.Hash,
+) ([]*types.StorageDataRaw, error) {
+	var hexHash string
+
+	if blockHash != nil {
+		var err error
+
+		if hexHash, err = codec.Hex(*blockHash); err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]string, len(keys))
+	batch := make([]gethrpc.BatchElem, len(keys))
+
+	for i, key := range keys {
+		args := []interface{}{key.Hex()}
+
+		if blockHash != nil {
+			args = append(args, hexHash)
+		}
+
+		batch[i] = gethrpc.BatchElem{
+			Method: "state_getStorage",
+			Args:   args,
+			Result: &res[i],
+		}
+	}
+
+	if err := client.BatchCallWithSizeContext(ctx, s.client, batch, s.batchSize); err != nil {
+		return nil, err
+	}
+
+	raws := make([]*types.StorageDataRaw, len(keys))
+
+	for i := range batch {
+		if batch[i].Error != nil {
+			continue
+		}
+
+		bz, err := codec.HexDecodeString(res[i])
+		if err != nil {
+			batch[i].Error = err
+			continue
+		}
+
+		data := types.NewStorageDataRaw(bz)
+		raws[i] = &data
+	}
+
+	return raws, client.NewBatchError(batch)
+}
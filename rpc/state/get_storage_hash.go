@@ -17,23 +17,35 @@
 package state
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // GetStorageHash retreives the storage hash for the given key
 func (s *state) GetStorageHash(key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
-	return s.getStorageHash(key, &blockHash)
+	return s.GetStorageHashCtx(context.Background(), key, blockHash)
+}
+
+// GetStorageHashCtx is like GetStorageHash, the request is bound to the provided context
+func (s *state) GetStorageHashCtx(ctx context.Context, key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
+	return s.getStorageHash(ctx, key, &blockHash)
 }
 
 // GetStorageHashLatest retreives the storage hash for the given key for the latest block height
 func (s *state) GetStorageHashLatest(key types.StorageKey) (types.Hash, error) {
-	return s.getStorageHash(key, nil)
+	return s.GetStorageHashLatestCtx(context.Background(), key)
+}
+
+// GetStorageHashLatestCtx is like GetStorageHashLatest, the request is bound to the provided context
+func (s *state) GetStorageHashLatestCtx(ctx context.Context, key types.StorageKey) (types.Hash, error) {
+	return s.getStorageHash(ctx, key, nil)
 }
 
-func (s *state) getStorageHash(key types.StorageKey, blockHash *types.Hash) (types.Hash, error) {
+func (s *state) getStorageHash(ctx context.Context, key types.StorageKey, blockHash *types.Hash) (types.Hash, error) {
 	var res string
-	err := client.CallWithBlockHash(s.client, &res, "state_getStorageHash", blockHash, key.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getStorageHash", blockHash, key.Hex())
 	if err != nil {
 		return types.Hash{}, err
 	}
@@ -19,6 +19,8 @@ package state
 import (
 	"testing"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/stretchr/testify/assert"
@@ -40,6 +42,24 @@ func TestState_GetStorage(t *testing.T) {
 	assert.Equal(t, types.U64(0x5d892db8), decoded)
 }
 
+func TestState_GetStorageWithSerDeOptions(t *testing.T) {
+	key := codec.MustHexDecodeString(mockSrv.storageKeyHex)
+
+	var withIndices types.Address
+	ok, err := NewState(testState.(*state).client, WithSerDeOptions(types.SerDeOptions{NoPalletIndices: false})).
+		GetStorageLatest(key, &withIndices)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.NewAddressFromAccountIndex(0xb8), withIndices)
+
+	// Without the indices pallet the address is decoded as an account ID, for which there aren't enough bytes.
+	var noIndices types.Address
+	ok, err = NewState(testState.(*state).client, WithSerDeOptions(types.SerDeOptions{NoPalletIndices: true})).
+		GetStorageLatest(key, &noIndices)
+	assert.Error(t, err)
+	assert.True(t, ok)
+}
+
 func TestState_GetStorageEmpty(t *testing.T) {
 	var decoded types.U64
 	ok, err := testState.GetStorage([]byte{0xab}, &decoded, mockSrv.blockHashLatest)
@@ -58,3 +78,64 @@ func TestState_GetStorageRaw(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, mockSrv.storageDataHex, data.Hex())
 }
+
+func TestState_DefaultBatchSize(t *testing.T) {
+	assert.Equal(t, config.Default().BatchSize, testState.(*state).batchSize)
+}
+
+func TestState_GetStorageBatch(t *testing.T) {
+	keys := []types.StorageKey{codec.MustHexDecodeString(mockSrv.storageKeyHex), {0xab}}
+
+	for _, st := range []State{testState, NewState(testState.(*state).client, WithBatchSize(1))} {
+		var decoded, empty types.U64
+		ok, err := st.GetStorageBatch(keys, []interface{}{&decoded, &empty}, mockSrv.blockHashLatest)
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{true, false}, ok)
+		assert.Equal(t, types.U64(0x5d892db8), decoded)
+
+		decoded = 0
+		ok, err = st.GetStorageBatchLatest(keys, []interface{}{&decoded, &empty})
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{true, false}, ok)
+		assert.Equal(t, types.U64(0x5d892db8), decoded)
+	}
+}
+
+func TestState_GetStorageBatch_Errors(t *testing.T) {
+	key := codec.MustHexDecodeString(mockSrv.storageKeyHex)
+
+	var decoded types.U64
+	var noIndices types.Address
+	st := NewState(testState.(*state).client, WithSerDeOptions(types.SerDeOptions{NoPalletIndices: true}), WithBatchSize(1))
+	ok, err := st.GetStorageBatchLatest([]types.StorageKey{key, key}, []interface{}{&decoded, &noIndices})
+	assert.Equal(t, []bool{true, true}, ok)
+	assert.Equal(t, types.U64(0x5d892db8), decoded)
+
+	var batchErr *client.BatchError
+	assert.ErrorAs(t, err, &batchErr)
+	assert.Len(t, batchErr.Errors, 1)
+	assert.Error(t, batchErr.Errors[1])
+
+	ok, err = testState.GetStorageBatchLatest([]types.StorageKey{key}, nil)
+	assert.Error(t, err)
+	assert.Nil(t, ok)
+}
+
+func TestState_GetStorageRawBatch(t *testing.T) {
+	keys := []types.StorageKey{codec.MustHexDecodeString(mockSrv.storageKeyHex), {0xab}}
+
+	data, err := testState.GetStorageRawBatch(keys, mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assert.Len(t, data, 2)
+	assert.Equal(t, mockSrv.storageDataHex, data[0].Hex())
+	assert.Empty(t, *data[1])
+
+	data, err = testState.GetStorageRawBatchLatest(keys)
+	assert.NoError(t, err)
+	assert.Len(t, data, 2)
+	assert.Equal(t, mockSrv.storageDataHex, data[0].Hex())
+
+	data, err = testState.GetStorageRawBatchLatest(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+}
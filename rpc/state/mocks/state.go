@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	state "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	mock "github.com/stretchr/testify/mock"
@@ -13,6 +15,98 @@ type State struct {
 	mock.Mock
 }
 
+// Call provides a mock function with given fields: method, data, blockHash
+func (_m *State) Call(method string, data []byte, blockHash types.Hash) (types.Bytes, error) {
+	ret := _m.Called(method, data, blockHash)
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func(string, []byte, types.Hash) types.Bytes); ok {
+		r0 = rf(method, data, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte, types.Hash) error); ok {
+		r1 = rf(method, data, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallCtx provides a mock function with given fields: ctx, method, data, blockHash
+func (_m *State) CallCtx(ctx context.Context, method string, data []byte, blockHash types.Hash) (types.Bytes, error) {
+	ret := _m.Called(ctx, method, data, blockHash)
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, types.Hash) types.Bytes); ok {
+		r0 = rf(ctx, method, data, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte, types.Hash) error); ok {
+		r1 = rf(ctx, method, data, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallLatest provides a mock function with given fields: method, data
+func (_m *State) CallLatest(method string, data []byte) (types.Bytes, error) {
+	ret := _m.Called(method, data)
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func(string, []byte) types.Bytes); ok {
+		r0 = rf(method, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
+		r1 = rf(method, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallLatestCtx provides a mock function with given fields: ctx, method, data
+func (_m *State) CallLatestCtx(ctx context.Context, method string, data []byte) (types.Bytes, error) {
+	ret := _m.Called(ctx, method, data)
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) types.Bytes); ok {
+		r0 = rf(ctx, method, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte) error); ok {
+		r1 = rf(ctx, method, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildKeys provides a mock function with given fields: childStorageKey, prefix, blockHash
 func (_m *State) GetChildKeys(childStorageKey types.StorageKey, prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
 	ret := _m.Called(childStorageKey, prefix, blockHash)
@@ -36,6 +130,29 @@ func (_m *State) GetChildKeys(childStorageKey types.StorageKey, prefix types.Sto
 	return r0, r1
 }
 
+// GetChildKeysCtx provides a mock function with given fields: ctx, childStorageKey, prefix, blockHash
+func (_m *State) GetChildKeysCtx(ctx context.Context, childStorageKey types.StorageKey, prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
+	ret := _m.Called(ctx, childStorageKey, prefix, blockHash)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) []types.StorageKey); ok {
+		r0 = rf(ctx, childStorageKey, prefix, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, childStorageKey, prefix, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildKeysLatest provides a mock function with given fields: childStorageKey, prefix
 func (_m *State) GetChildKeysLatest(childStorageKey types.StorageKey, prefix types.StorageKey) ([]types.StorageKey, error) {
 	ret := _m.Called(childStorageKey, prefix)
@@ -59,6 +176,29 @@ func (_m *State) GetChildKeysLatest(childStorageKey types.StorageKey, prefix typ
 	return r0, r1
 }
 
+// GetChildKeysLatestCtx provides a mock function with given fields: ctx, childStorageKey, prefix
+func (_m *State) GetChildKeysLatestCtx(ctx context.Context, childStorageKey types.StorageKey, prefix types.StorageKey) ([]types.StorageKey, error) {
+	ret := _m.Called(ctx, childStorageKey, prefix)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey) []types.StorageKey); ok {
+		r0 = rf(ctx, childStorageKey, prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey) error); ok {
+		r1 = rf(ctx, childStorageKey, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorage provides a mock function with given fields: childStorageKey, key, target, blockHash
 func (_m *State) GetChildStorage(childStorageKey types.StorageKey, key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
 	ret := _m.Called(childStorageKey, key, target, blockHash)
@@ -80,6 +220,27 @@ func (_m *State) GetChildStorage(childStorageKey types.StorageKey, key types.Sto
 	return r0, r1
 }
 
+// GetChildStorageCtx provides a mock function with given fields: ctx, childStorageKey, key, target, blockHash
+func (_m *State) GetChildStorageCtx(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
+	ret := _m.Called(ctx, childStorageKey, key, target, blockHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, interface{}, types.Hash) bool); ok {
+		r0 = rf(ctx, childStorageKey, key, target, blockHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, interface{}, types.Hash) error); ok {
+		r1 = rf(ctx, childStorageKey, key, target, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageHash provides a mock function with given fields: childStorageKey, key, blockHash
 func (_m *State) GetChildStorageHash(childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
 	ret := _m.Called(childStorageKey, key, blockHash)
@@ -103,6 +264,29 @@ func (_m *State) GetChildStorageHash(childStorageKey types.StorageKey, key types
 	return r0, r1
 }
 
+// GetChildStorageHashCtx provides a mock function with given fields: ctx, childStorageKey, key, blockHash
+func (_m *State) GetChildStorageHashCtx(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
+	ret := _m.Called(ctx, childStorageKey, key, blockHash)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) types.Hash); ok {
+		r0 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageHashLatest provides a mock function with given fields: childStorageKey, key
 func (_m *State) GetChildStorageHashLatest(childStorageKey types.StorageKey, key types.StorageKey) (types.Hash, error) {
 	ret := _m.Called(childStorageKey, key)
@@ -126,6 +310,29 @@ func (_m *State) GetChildStorageHashLatest(childStorageKey types.StorageKey, key
 	return r0, r1
 }
 
+// GetChildStorageHashLatestCtx provides a mock function with given fields: ctx, childStorageKey, key
+func (_m *State) GetChildStorageHashLatestCtx(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey) (types.Hash, error) {
+	ret := _m.Called(ctx, childStorageKey, key)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey) types.Hash); ok {
+		r0 = rf(ctx, childStorageKey, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey) error); ok {
+		r1 = rf(ctx, childStorageKey, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageLatest provides a mock function with given fields: childStorageKey, key, target
 func (_m *State) GetChildStorageLatest(childStorageKey types.StorageKey, key types.StorageKey, target interface{}) (bool, error) {
 	ret := _m.Called(childStorageKey, key, target)
@@ -147,6 +354,27 @@ func (_m *State) GetChildStorageLatest(childStorageKey types.StorageKey, key typ
 	return r0, r1
 }
 
+// GetChildStorageLatestCtx provides a mock function with given fields: ctx, childStorageKey, key, target
+func (_m *State) GetChildStorageLatestCtx(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey, target interface{}) (bool, error) {
+	ret := _m.Called(ctx, childStorageKey, key, target)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, interface{}) bool); ok {
+		r0 = rf(ctx, childStorageKey, key, target)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, interface{}) error); ok {
+		r1 = rf(ctx, childStorageKey, key, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageRaw provides a mock function with given fields: childStorageKey, key, blockHash
 func (_m *State) GetChildStorageRaw(childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
 	ret := _m.Called(childStorageKey, key, blockHash)
@@ -170,6 +398,29 @@ func (_m *State) GetChildStorageRaw(childStorageKey types.StorageKey, key types.
 	return r0, r1
 }
 
+// GetChildStorageRawCtx provides a mock function with given fields: ctx, childStorageKey, key, blockHash
+func (_m *State) GetChildStorageRawCtx(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, childStorageKey, key, blockHash)
+
+	var r0 *types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) *types.StorageDataRaw); ok {
+		r0 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StorageDataRaw)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageRawLatest provides a mock function with given fields: childStorageKey, key
 func (_m *State) GetChildStorageRawLatest(childStorageKey types.StorageKey, key types.StorageKey) (*types.StorageDataRaw, error) {
 	ret := _m.Called(childStorageKey, key)
@@ -193,6 +444,29 @@ func (_m *State) GetChildStorageRawLatest(childStorageKey types.StorageKey, key
 	return r0, r1
 }
 
+// GetChildStorageRawLatestCtx provides a mock function with given fields: ctx, childStorageKey, key
+func (_m *State) GetChildStorageRawLatestCtx(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey) (*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, childStorageKey, key)
+
+	var r0 *types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey) *types.StorageDataRaw); ok {
+		r0 = rf(ctx, childStorageKey, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StorageDataRaw)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey) error); ok {
+		r1 = rf(ctx, childStorageKey, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageSize provides a mock function with given fields: childStorageKey, key, blockHash
 func (_m *State) GetChildStorageSize(childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (types.U64, error) {
 	ret := _m.Called(childStorageKey, key, blockHash)
@@ -214,6 +488,27 @@ func (_m *State) GetChildStorageSize(childStorageKey types.StorageKey, key types
 	return r0, r1
 }
 
+// GetChildStorageSizeCtx provides a mock function with given fields: ctx, childStorageKey, key, blockHash
+func (_m *State) GetChildStorageSizeCtx(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey, blockHash types.Hash) (types.U64, error) {
+	ret := _m.Called(ctx, childStorageKey, key, blockHash)
+
+	var r0 types.U64
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) types.U64); ok {
+		r0 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.U64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, childStorageKey, key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorageSizeLatest provides a mock function with given fields: childStorageKey, key
 func (_m *State) GetChildStorageSizeLatest(childStorageKey types.StorageKey, key types.StorageKey) (types.U64, error) {
 	ret := _m.Called(childStorageKey, key)
@@ -235,6 +530,27 @@ func (_m *State) GetChildStorageSizeLatest(childStorageKey types.StorageKey, key
 	return r0, r1
 }
 
+// GetChildStorageSizeLatestCtx provides a mock function with given fields: ctx, childStorageKey, key
+func (_m *State) GetChildStorageSizeLatestCtx(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey) (types.U64, error) {
+	ret := _m.Called(ctx, childStorageKey, key)
+
+	var r0 types.U64
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.StorageKey) types.U64); ok {
+		r0 = rf(ctx, childStorageKey, key)
+	} else {
+		r0 = ret.Get(0).(types.U64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.StorageKey) error); ok {
+		r1 = rf(ctx, childStorageKey, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetKeys provides a mock function with given fields: prefix, blockHash
 func (_m *State) GetKeys(prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
 	ret := _m.Called(prefix, blockHash)
@@ -258,6 +574,29 @@ func (_m *State) GetKeys(prefix types.StorageKey, blockHash types.Hash) ([]types
 	return r0, r1
 }
 
+// GetKeysCtx provides a mock function with given fields: ctx, prefix, blockHash
+func (_m *State) GetKeysCtx(ctx context.Context, prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
+	ret := _m.Called(ctx, prefix, blockHash)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.Hash) []types.StorageKey); ok {
+		r0 = rf(ctx, prefix, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, prefix, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetKeysLatest provides a mock function with given fields: prefix
 func (_m *State) GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, error) {
 	ret := _m.Called(prefix)
@@ -273,7 +612,417 @@ func (_m *State) GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, err
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(types.StorageKey) error); ok {
-		r1 = rf(prefix)
+		r1 = rf(prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeysLatestCtx provides a mock function with given fields: ctx, prefix
+func (_m *State) GetKeysLatestCtx(ctx context.Context, prefix types.StorageKey) ([]types.StorageKey, error) {
+	ret := _m.Called(ctx, prefix)
+
+	var r0 []types.StorageKey
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey) []types.StorageKey); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey) error); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadata provides a mock function with given fields: blockHash
+func (_m *State) GetMetadata(blockHash types.Hash) (*types.Metadata, error) {
+	ret := _m.Called(blockHash)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(types.Hash) *types.Metadata); ok {
+		r0 = rf(blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
+		r1 = rf(blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataCtx provides a mock function with given fields: ctx, blockHash
+func (_m *State) GetMetadataCtx(ctx context.Context, blockHash types.Hash) (*types.Metadata, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) *types.Metadata); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataLatest provides a mock function with given fields:
+func (_m *State) GetMetadataLatest() (*types.Metadata, error) {
+	ret := _m.Called()
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func() *types.Metadata); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMetadataLatestCtx provides a mock function with given fields: ctx
+func (_m *State) GetMetadataLatestCtx(ctx context.Context) (*types.Metadata, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *types.Metadata
+	if rf, ok := ret.Get(0).(func(context.Context) *types.Metadata); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Metadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRuntimeVersion provides a mock function with given fields: blockHash
+func (_m *State) GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error) {
+	ret := _m.Called(blockHash)
+
+	var r0 *types.RuntimeVersion
+	if rf, ok := ret.Get(0).(func(types.Hash) *types.RuntimeVersion); ok {
+		r0 = rf(blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RuntimeVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
+		r1 = rf(blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRuntimeVersionCtx provides a mock function with given fields: ctx, blockHash
+func (_m *State) GetRuntimeVersionCtx(ctx context.Context, blockHash types.Hash) (*types.RuntimeVersion, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	var r0 *types.RuntimeVersion
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) *types.RuntimeVersion); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RuntimeVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRuntimeVersionLatest provides a mock function with given fields:
+func (_m *State) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
+	ret := _m.Called()
+
+	var r0 *types.RuntimeVersion
+	if rf, ok := ret.Get(0).(func() *types.RuntimeVersion); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RuntimeVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRuntimeVersionLatestCtx provides a mock function with given fields: ctx
+func (_m *State) GetRuntimeVersionLatestCtx(ctx context.Context) (*types.RuntimeVersion, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *types.RuntimeVersion
+	if rf, ok := ret.Get(0).(func(context.Context) *types.RuntimeVersion); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RuntimeVersion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorage provides a mock function with given fields: key, target, blockHash
+func (_m *State) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
+	ret := _m.Called(key, target, blockHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(types.StorageKey, interface{}, types.Hash) bool); ok {
+		r0 = rf(key, target, blockHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.StorageKey, interface{}, types.Hash) error); ok {
+		r1 = rf(key, target, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageBatch provides a mock function with given fields: keys, targets, blockHash
+func (_m *State) GetStorageBatch(keys []types.StorageKey, targets []interface{}, blockHash types.Hash) ([]bool, error) {
+	ret := _m.Called(keys, targets, blockHash)
+
+	var r0 []bool
+	if rf, ok := ret.Get(0).(func([]types.StorageKey, []interface{}, types.Hash) []bool); ok {
+		r0 = rf(keys, targets, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]types.StorageKey, []interface{}, types.Hash) error); ok {
+		r1 = rf(keys, targets, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageBatchCtx provides a mock function with given fields: ctx, keys, targets, blockHash
+func (_m *State) GetStorageBatchCtx(ctx context.Context, keys []types.StorageKey, targets []interface{}, blockHash types.Hash) ([]bool, error) {
+	ret := _m.Called(ctx, keys, targets, blockHash)
+
+	var r0 []bool
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, []interface{}, types.Hash) []bool); ok {
+		r0 = rf(ctx, keys, targets, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, []interface{}, types.Hash) error); ok {
+		r1 = rf(ctx, keys, targets, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageBatchLatest provides a mock function with given fields: keys, targets
+func (_m *State) GetStorageBatchLatest(keys []types.StorageKey, targets []interface{}) ([]bool, error) {
+	ret := _m.Called(keys, targets)
+
+	var r0 []bool
+	if rf, ok := ret.Get(0).(func([]types.StorageKey, []interface{}) []bool); ok {
+		r0 = rf(keys, targets)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]types.StorageKey, []interface{}) error); ok {
+		r1 = rf(keys, targets)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageBatchLatestCtx provides a mock function with given fields: ctx, keys, targets
+func (_m *State) GetStorageBatchLatestCtx(ctx context.Context, keys []types.StorageKey, targets []interface{}) ([]bool, error) {
+	ret := _m.Called(ctx, keys, targets)
+
+	var r0 []bool
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, []interface{}) []bool); ok {
+		r0 = rf(ctx, keys, targets)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, []interface{}) error); ok {
+		r1 = rf(ctx, keys, targets)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageCtx provides a mock function with given fields: ctx, key, target, blockHash
+func (_m *State) GetStorageCtx(ctx context.Context, key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
+	ret := _m.Called(ctx, key, target, blockHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, interface{}, types.Hash) bool); ok {
+		r0 = rf(ctx, key, target, blockHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, interface{}, types.Hash) error); ok {
+		r1 = rf(ctx, key, target, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageHash provides a mock function with given fields: key, blockHash
+func (_m *State) GetStorageHash(key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
+	ret := _m.Called(key, blockHash)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(types.StorageKey, types.Hash) types.Hash); ok {
+		r0 = rf(key, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.StorageKey, types.Hash) error); ok {
+		r1 = rf(key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageHashCtx provides a mock function with given fields: ctx, key, blockHash
+func (_m *State) GetStorageHashCtx(ctx context.Context, key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
+	ret := _m.Called(ctx, key, blockHash)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.Hash) types.Hash); ok {
+		r0 = rf(ctx, key, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageHashLatest provides a mock function with given fields: key
+func (_m *State) GetStorageHashLatest(key types.StorageKey) (types.Hash, error) {
+	ret := _m.Called(key)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(types.StorageKey) types.Hash); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.StorageKey) error); ok {
+		r1 = rf(key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -281,22 +1030,22 @@ func (_m *State) GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, err
 	return r0, r1
 }
 
-// GetMetadata provides a mock function with given fields: blockHash
-func (_m *State) GetMetadata(blockHash types.Hash) (*types.Metadata, error) {
-	ret := _m.Called(blockHash)
+// GetStorageHashLatestCtx provides a mock function with given fields: ctx, key
+func (_m *State) GetStorageHashLatestCtx(ctx context.Context, key types.StorageKey) (types.Hash, error) {
+	ret := _m.Called(ctx, key)
 
-	var r0 *types.Metadata
-	if rf, ok := ret.Get(0).(func(types.Hash) *types.Metadata); ok {
-		r0 = rf(blockHash)
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey) types.Hash); ok {
+		r0 = rf(ctx, key)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.Metadata)
+			r0 = ret.Get(0).(types.Hash)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
-		r1 = rf(blockHash)
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey) error); ok {
+		r1 = rf(ctx, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -304,22 +1053,20 @@ func (_m *State) GetMetadata(blockHash types.Hash) (*types.Metadata, error) {
 	return r0, r1
 }
 
-// GetMetadataLatest provides a mock function with given fields:
-func (_m *State) GetMetadataLatest() (*types.Metadata, error) {
-	ret := _m.Called()
+// GetStorageLatest provides a mock function with given fields: key, target
+func (_m *State) GetStorageLatest(key types.StorageKey, target interface{}) (bool, error) {
+	ret := _m.Called(key, target)
 
-	var r0 *types.Metadata
-	if rf, ok := ret.Get(0).(func() *types.Metadata); ok {
-		r0 = rf()
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(types.StorageKey, interface{}) bool); ok {
+		r0 = rf(key, target)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.Metadata)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(types.StorageKey, interface{}) error); ok {
+		r1 = rf(key, target)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -327,22 +1074,20 @@ func (_m *State) GetMetadataLatest() (*types.Metadata, error) {
 	return r0, r1
 }
 
-// GetRuntimeVersion provides a mock function with given fields: blockHash
-func (_m *State) GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error) {
-	ret := _m.Called(blockHash)
+// GetStorageLatestCtx provides a mock function with given fields: ctx, key, target
+func (_m *State) GetStorageLatestCtx(ctx context.Context, key types.StorageKey, target interface{}) (bool, error) {
+	ret := _m.Called(ctx, key, target)
 
-	var r0 *types.RuntimeVersion
-	if rf, ok := ret.Get(0).(func(types.Hash) *types.RuntimeVersion); ok {
-		r0 = rf(blockHash)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, interface{}) bool); ok {
+		r0 = rf(ctx, key, target)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.RuntimeVersion)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
-		r1 = rf(blockHash)
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, interface{}) error); ok {
+		r1 = rf(ctx, key, target)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -350,22 +1095,22 @@ func (_m *State) GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion,
 	return r0, r1
 }
 
-// GetRuntimeVersionLatest provides a mock function with given fields:
-func (_m *State) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
-	ret := _m.Called()
+// GetStorageRaw provides a mock function with given fields: key, blockHash
+func (_m *State) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	ret := _m.Called(key, blockHash)
 
-	var r0 *types.RuntimeVersion
-	if rf, ok := ret.Get(0).(func() *types.RuntimeVersion); ok {
-		r0 = rf()
+	var r0 *types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(types.StorageKey, types.Hash) *types.StorageDataRaw); ok {
+		r0 = rf(key, blockHash)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.RuntimeVersion)
+			r0 = ret.Get(0).(*types.StorageDataRaw)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(types.StorageKey, types.Hash) error); ok {
+		r1 = rf(key, blockHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -373,20 +1118,22 @@ func (_m *State) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
 	return r0, r1
 }
 
-// GetStorage provides a mock function with given fields: key, target, blockHash
-func (_m *State) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
-	ret := _m.Called(key, target, blockHash)
+// GetStorageRawBatch provides a mock function with given fields: keys, blockHash
+func (_m *State) GetStorageRawBatch(keys []types.StorageKey, blockHash types.Hash) ([]*types.StorageDataRaw, error) {
+	ret := _m.Called(keys, blockHash)
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(types.StorageKey, interface{}, types.Hash) bool); ok {
-		r0 = rf(key, target, blockHash)
+	var r0 []*types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func([]types.StorageKey, types.Hash) []*types.StorageDataRaw); ok {
+		r0 = rf(keys, blockHash)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.StorageDataRaw)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.StorageKey, interface{}, types.Hash) error); ok {
-		r1 = rf(key, target, blockHash)
+	if rf, ok := ret.Get(1).(func([]types.StorageKey, types.Hash) error); ok {
+		r1 = rf(keys, blockHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -394,22 +1141,22 @@ func (_m *State) GetStorage(key types.StorageKey, target interface{}, blockHash
 	return r0, r1
 }
 
-// GetStorageHash provides a mock function with given fields: key, blockHash
-func (_m *State) GetStorageHash(key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
-	ret := _m.Called(key, blockHash)
+// GetStorageRawBatchCtx provides a mock function with given fields: ctx, keys, blockHash
+func (_m *State) GetStorageRawBatchCtx(ctx context.Context, keys []types.StorageKey, blockHash types.Hash) ([]*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, keys, blockHash)
 
-	var r0 types.Hash
-	if rf, ok := ret.Get(0).(func(types.StorageKey, types.Hash) types.Hash); ok {
-		r0 = rf(key, blockHash)
+	var r0 []*types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash) []*types.StorageDataRaw); ok {
+		r0 = rf(ctx, keys, blockHash)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(types.Hash)
+			r0 = ret.Get(0).([]*types.StorageDataRaw)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.StorageKey, types.Hash) error); ok {
-		r1 = rf(key, blockHash)
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, keys, blockHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -417,22 +1164,22 @@ func (_m *State) GetStorageHash(key types.StorageKey, blockHash types.Hash) (typ
 	return r0, r1
 }
 
-// GetStorageHashLatest provides a mock function with given fields: key
-func (_m *State) GetStorageHashLatest(key types.StorageKey) (types.Hash, error) {
-	ret := _m.Called(key)
+// GetStorageRawBatchLatest provides a mock function with given fields: keys
+func (_m *State) GetStorageRawBatchLatest(keys []types.StorageKey) ([]*types.StorageDataRaw, error) {
+	ret := _m.Called(keys)
 
-	var r0 types.Hash
-	if rf, ok := ret.Get(0).(func(types.StorageKey) types.Hash); ok {
-		r0 = rf(key)
+	var r0 []*types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func([]types.StorageKey) []*types.StorageDataRaw); ok {
+		r0 = rf(keys)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(types.Hash)
+			r0 = ret.Get(0).([]*types.StorageDataRaw)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.StorageKey) error); ok {
-		r1 = rf(key)
+	if rf, ok := ret.Get(1).(func([]types.StorageKey) error); ok {
+		r1 = rf(keys)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -440,20 +1187,22 @@ func (_m *State) GetStorageHashLatest(key types.StorageKey) (types.Hash, error)
 	return r0, r1
 }
 
-// GetStorageLatest provides a mock function with given fields: key, target
-func (_m *State) GetStorageLatest(key types.StorageKey, target interface{}) (bool, error) {
-	ret := _m.Called(key, target)
+// GetStorageRawBatchLatestCtx provides a mock function with given fields: ctx, keys
+func (_m *State) GetStorageRawBatchLatestCtx(ctx context.Context, keys []types.StorageKey) ([]*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, keys)
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(types.StorageKey, interface{}) bool); ok {
-		r0 = rf(key, target)
+	var r0 []*types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey) []*types.StorageDataRaw); ok {
+		r0 = rf(ctx, keys)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.StorageDataRaw)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.StorageKey, interface{}) error); ok {
-		r1 = rf(key, target)
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey) error); ok {
+		r1 = rf(ctx, keys)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -461,13 +1210,13 @@ func (_m *State) GetStorageLatest(key types.StorageKey, target interface{}) (boo
 	return r0, r1
 }
 
-// GetStorageRaw provides a mock function with given fields: key, blockHash
-func (_m *State) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
-	ret := _m.Called(key, blockHash)
+// GetStorageRawCtx provides a mock function with given fields: ctx, key, blockHash
+func (_m *State) GetStorageRawCtx(ctx context.Context, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, key, blockHash)
 
 	var r0 *types.StorageDataRaw
-	if rf, ok := ret.Get(0).(func(types.StorageKey, types.Hash) *types.StorageDataRaw); ok {
-		r0 = rf(key, blockHash)
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.Hash) *types.StorageDataRaw); ok {
+		r0 = rf(ctx, key, blockHash)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.StorageDataRaw)
@@ -475,8 +1224,8 @@ func (_m *State) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*typ
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.StorageKey, types.Hash) error); ok {
-		r1 = rf(key, blockHash)
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, key, blockHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -507,6 +1256,29 @@ func (_m *State) GetStorageRawLatest(key types.StorageKey) (*types.StorageDataRa
 	return r0, r1
 }
 
+// GetStorageRawLatestCtx provides a mock function with given fields: ctx, key
+func (_m *State) GetStorageRawLatestCtx(ctx context.Context, key types.StorageKey) (*types.StorageDataRaw, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *types.StorageDataRaw
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey) *types.StorageDataRaw); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StorageDataRaw)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageSize provides a mock function with given fields: key, blockHash
 func (_m *State) GetStorageSize(key types.StorageKey, blockHash types.Hash) (types.U64, error) {
 	ret := _m.Called(key, blockHash)
@@ -528,6 +1300,27 @@ func (_m *State) GetStorageSize(key types.StorageKey, blockHash types.Hash) (typ
 	return r0, r1
 }
 
+// GetStorageSizeCtx provides a mock function with given fields: ctx, key, blockHash
+func (_m *State) GetStorageSizeCtx(ctx context.Context, key types.StorageKey, blockHash types.Hash) (types.U64, error) {
+	ret := _m.Called(ctx, key, blockHash)
+
+	var r0 types.U64
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, types.Hash) types.U64); ok {
+		r0 = rf(ctx, key, blockHash)
+	} else {
+		r0 = ret.Get(0).(types.U64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, key, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageSizeLatest provides a mock function with given fields: key
 func (_m *State) GetStorageSizeLatest(key types.StorageKey) (types.U64, error) {
 	ret := _m.Called(key)
@@ -549,6 +1342,27 @@ func (_m *State) GetStorageSizeLatest(key types.StorageKey) (types.U64, error) {
 	return r0, r1
 }
 
+// GetStorageSizeLatestCtx provides a mock function with given fields: ctx, key
+func (_m *State) GetStorageSizeLatestCtx(ctx context.Context, key types.StorageKey) (types.U64, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 types.U64
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey) types.U64); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(types.U64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // QueryStorage provides a mock function with given fields: keys, startBlock, block
 func (_m *State) QueryStorage(keys []types.StorageKey, startBlock types.Hash, block types.Hash) ([]types.StorageChangeSet, error) {
 	ret := _m.Called(keys, startBlock, block)
@@ -595,6 +1409,29 @@ func (_m *State) QueryStorageAt(keys []types.StorageKey, block types.Hash) ([]ty
 	return r0, r1
 }
 
+// QueryStorageAtCtx provides a mock function with given fields: ctx, keys, block
+func (_m *State) QueryStorageAtCtx(ctx context.Context, keys []types.StorageKey, block types.Hash) ([]types.StorageChangeSet, error) {
+	ret := _m.Called(ctx, keys, block)
+
+	var r0 []types.StorageChangeSet
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash) []types.StorageChangeSet); ok {
+		r0 = rf(ctx, keys, block)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageChangeSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, keys, block)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // QueryStorageAtLatest provides a mock function with given fields: keys
 func (_m *State) QueryStorageAtLatest(keys []types.StorageKey) ([]types.StorageChangeSet, error) {
 	ret := _m.Called(keys)
@@ -618,6 +1455,52 @@ func (_m *State) QueryStorageAtLatest(keys []types.StorageKey) ([]types.StorageC
 	return r0, r1
 }
 
+// QueryStorageAtLatestCtx provides a mock function with given fields: ctx, keys
+func (_m *State) QueryStorageAtLatestCtx(ctx context.Context, keys []types.StorageKey) ([]types.StorageChangeSet, error) {
+	ret := _m.Called(ctx, keys)
+
+	var r0 []types.StorageChangeSet
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey) []types.StorageChangeSet); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageChangeSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryStorageCtx provides a mock function with given fields: ctx, keys, startBlock, block
+func (_m *State) QueryStorageCtx(ctx context.Context, keys []types.StorageKey, startBlock types.Hash, block types.Hash) ([]types.StorageChangeSet, error) {
+	ret := _m.Called(ctx, keys, startBlock, block)
+
+	var r0 []types.StorageChangeSet
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash, types.Hash) []types.StorageChangeSet); ok {
+		r0 = rf(ctx, keys, startBlock, block)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageChangeSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, types.Hash, types.Hash) error); ok {
+		r1 = rf(ctx, keys, startBlock, block)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // QueryStorageLatest provides a mock function with given fields: keys, startBlock
 func (_m *State) QueryStorageLatest(keys []types.StorageKey, startBlock types.Hash) ([]types.StorageChangeSet, error) {
 	ret := _m.Called(keys, startBlock)
@@ -641,6 +1524,29 @@ func (_m *State) QueryStorageLatest(keys []types.StorageKey, startBlock types.Ha
 	return r0, r1
 }
 
+// QueryStorageLatestCtx provides a mock function with given fields: ctx, keys, startBlock
+func (_m *State) QueryStorageLatestCtx(ctx context.Context, keys []types.StorageKey, startBlock types.Hash) ([]types.StorageChangeSet, error) {
+	ret := _m.Called(ctx, keys, startBlock)
+
+	var r0 []types.StorageChangeSet
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash) []types.StorageChangeSet); ok {
+		r0 = rf(ctx, keys, startBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.StorageChangeSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, keys, startBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeRuntimeVersion provides a mock function with given fields:
 func (_m *State) SubscribeRuntimeVersion() (*state.RuntimeVersionSubscription, error) {
 	ret := _m.Called()
@@ -664,6 +1570,29 @@ func (_m *State) SubscribeRuntimeVersion() (*state.RuntimeVersionSubscription, e
 	return r0, r1
 }
 
+// SubscribeRuntimeVersionCtx provides a mock function with given fields: ctx
+func (_m *State) SubscribeRuntimeVersionCtx(ctx context.Context) (*state.RuntimeVersionSubscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *state.RuntimeVersionSubscription
+	if rf, ok := ret.Get(0).(func(context.Context) *state.RuntimeVersionSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.RuntimeVersionSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeStorageRaw provides a mock function with given fields: keys
 func (_m *State) SubscribeStorageRaw(keys []types.StorageKey) (*state.StorageSubscription, error) {
 	ret := _m.Called(keys)
@@ -687,6 +1616,29 @@ func (_m *State) SubscribeStorageRaw(keys []types.StorageKey) (*state.StorageSub
 	return r0, r1
 }
 
+// SubscribeStorageRawCtx provides a mock function with given fields: ctx, keys
+func (_m *State) SubscribeStorageRawCtx(ctx context.Context, keys []types.StorageKey) (*state.StorageSubscription, error) {
+	ret := _m.Called(ctx, keys)
+
+	var r0 *state.StorageSubscription
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey) *state.StorageSubscription); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.StorageSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewStateT interface {
 	mock.TestingT
 	Cleanup(func())
@@ -19,61 +19,186 @@
 package state
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
 
 type State interface {
 	GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) (ok bool, err error)
+	GetStorageCtx(ctx context.Context, key types.StorageKey, target interface{}, blockHash types.Hash) (ok bool, err error)
 	GetStorageLatest(key types.StorageKey, target interface{}) (ok bool, err error)
+	GetStorageLatestCtx(ctx context.Context, key types.StorageKey, target interface{}) (ok bool, err error)
 	GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error)
+	GetStorageRawCtx(ctx context.Context, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error)
 	GetStorageRawLatest(key types.StorageKey) (*types.StorageDataRaw, error)
+	GetStorageRawLatestCtx(ctx context.Context, key types.StorageKey) (*types.StorageDataRaw, error)
+	GetStorageBatch(keys []types.StorageKey, targets []interface{}, blockHash types.Hash) ([]bool, error)
+	GetStorageBatchCtx(
+		ctx context.Context,
+		keys []types.StorageKey,
+		targets []interface{},
+		blockHash types.Hash,
+	) ([]bool, error)
+	GetStorageBatchLatest(keys []types.StorageKey, targets []interface{}) ([]bool, error)
+	GetStorageBatchLatestCtx(ctx context.Context, keys []types.StorageKey, targets []interface{}) ([]bool, error)
+	GetStorageRawBatch(keys []types.StorageKey, blockHash types.Hash) ([]*types.StorageDataRaw, error)
+	GetStorageRawBatchCtx(
+		ctx context.Context,
+		keys []types.StorageKey,
+		blockHash types.Hash,
+	) ([]*types.StorageDataRaw, error)
+	GetStorageRawBatchLatest(keys []types.StorageKey) ([]*types.StorageDataRaw, error)
+	GetStorageRawBatchLatestCtx(ctx context.Context, keys []types.StorageKey) ([]*types.StorageDataRaw, error)
 
 	GetChildStorageSize(childStorageKey, key types.StorageKey, blockHash types.Hash) (types.U64, error)
+	GetChildStorageSizeCtx(
+		ctx context.Context,
+		childStorageKey, key types.StorageKey,
+		blockHash types.Hash,
+	) (types.U64, error)
 	GetChildStorageSizeLatest(childStorageKey, key types.StorageKey) (types.U64, error)
+	GetChildStorageSizeLatestCtx(ctx context.Context, childStorageKey, key types.StorageKey) (types.U64, error)
 	GetChildStorage(childStorageKey, key types.StorageKey, target interface{}, blockHash types.Hash) (ok bool, err error)
+	GetChildStorageCtx(
+		ctx context.Context,
+		childStorageKey, key types.StorageKey,
+		target interface{},
+		blockHash types.Hash,
+	) (ok bool, err error)
 	GetChildStorageLatest(childStorageKey, key types.StorageKey, target interface{}) (ok bool, err error)
+	GetChildStorageLatestCtx(
+		ctx context.Context,
+		childStorageKey, key types.StorageKey,
+		target interface{},
+	) (ok bool, err error)
 	GetChildStorageRaw(childStorageKey, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error)
+	GetChildStorageRawCtx(
+		ctx context.Context,
+		childStorageKey, key types.StorageKey,
+		blockHash types.Hash,
+	) (*types.StorageDataRaw, error)
 	GetChildStorageRawLatest(childStorageKey, key types.StorageKey) (*types.StorageDataRaw, error)
+	GetChildStorageRawLatestCtx(ctx context.Context, childStorageKey, key types.StorageKey) (*types.StorageDataRaw, error)
 
 	GetMetadata(blockHash types.Hash) (*types.Metadata, error)
+	GetMetadataCtx(ctx context.Context, blockHash types.Hash) (*types.Metadata, error)
 	GetMetadataLatest() (*types.Metadata, error)
+	GetMetadataLatestCtx(ctx context.Context) (*types.Metadata, error)
 
 	GetStorageHash(key types.StorageKey, blockHash types.Hash) (types.Hash, error)
+	GetStorageHashCtx(ctx context.Context, key types.StorageKey, blockHash types.Hash) (types.Hash, error)
 	GetStorageHashLatest(key types.StorageKey) (types.Hash, error)
+	GetStorageHashLatestCtx(ctx context.Context, key types.StorageKey) (types.Hash, error)
 
 	SubscribeStorageRaw(keys []types.StorageKey) (*StorageSubscription, error)
+	SubscribeStorageRawCtx(ctx context.Context, keys []types.StorageKey) (*StorageSubscription, error)
 
 	GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error)
+	GetRuntimeVersionCtx(ctx context.Context, blockHash types.Hash) (*types.RuntimeVersion, error)
 	GetRuntimeVersionLatest() (*types.RuntimeVersion, error)
+	GetRuntimeVersionLatestCtx(ctx context.Context) (*types.RuntimeVersion, error)
 
 	GetChildKeys(childStorageKey, prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error)
+	GetChildKeysCtx(
+		ctx context.Context,
+		childStorageKey, prefix types.StorageKey,
+		blockHash types.Hash,
+	) ([]types.StorageKey, error)
 	GetChildKeysLatest(childStorageKey, prefix types.StorageKey) ([]types.StorageKey, error)
+	GetChildKeysLatestCtx(ctx context.Context, childStorageKey, prefix types.StorageKey) ([]types.StorageKey, error)
 
 	SubscribeRuntimeVersion() (*RuntimeVersionSubscription, error)
+	SubscribeRuntimeVersionCtx(ctx context.Context) (*RuntimeVersionSubscription, error)
 
 	QueryStorage(keys []types.StorageKey, startBlock types.Hash, block types.Hash) ([]types.StorageChangeSet, error)
+	QueryStorageCtx(
+		ctx context.Context,
+		keys []types.StorageKey,
+		startBlock types.Hash,
+		block types.Hash,
+	) ([]types.StorageChangeSet, error)
 	QueryStorageLatest(keys []types.StorageKey, startBlock types.Hash) ([]types.StorageChangeSet, error)
+	QueryStorageLatestCtx(
+		ctx context.Context,
+		keys []types.StorageKey,
+		startBlock types.Hash,
+	) ([]types.StorageChangeSet, error)
 
 	QueryStorageAt(keys []types.StorageKey, block types.Hash) ([]types.StorageChangeSet, error)
+	QueryStorageAtCtx(ctx context.Context, keys []types.StorageKey, block types.Hash) ([]types.StorageChangeSet, error)
 	QueryStorageAtLatest(keys []types.StorageKey) ([]types.StorageChangeSet, error)
+	QueryStorageAtLatestCtx(ctx context.Context, keys []types.StorageKey) ([]types.StorageChangeSet, error)
 
 	GetKeys(prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error)
+	GetKeysCtx(ctx context.Context, prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error)
 	GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, error)
+	GetKeysLatestCtx(ctx context.Context, prefix types.StorageKey) ([]types.StorageKey, error)
 
 	GetStorageSize(key types.StorageKey, blockHash types.Hash) (types.U64, error)
+	GetStorageSizeCtx(ctx context.Context, key types.StorageKey, blockHash types.Hash) (types.U64, error)
 	GetStorageSizeLatest(key types.StorageKey) (types.U64, error)
+	GetStorageSizeLatestCtx(ctx context.Context, key types.StorageKey) (types.U64, error)
 
 	GetChildStorageHash(childStorageKey, key types.StorageKey, blockHash types.Hash) (types.Hash, error)
+	GetChildStorageHashCtx(
+		ctx context.Context,
+		childStorageKey, key types.StorageKey,
+		blockHash types.Hash,
+	) (types.Hash, error)
 	GetChildStorageHashLatest(childStorageKey, key types.StorageKey) (types.Hash, error)
+	GetChildStorageHashLatestCtx(ctx context.Context, childStorageKey, key types.StorageKey) (types.Hash, error)
+
+	Call(method string, data []byte, blockHash types.Hash) (types.Bytes, error)
+	CallCtx(ctx context.Context, method string, data []byte, blockHash types.Hash) (types.Bytes, error)
+	CallLatest(method string, data []byte) (types.Bytes, error)
+	CallLatestCtx(ctx context.Context, method string, data []byte) (types.Bytes, error)
 }
 
 // state exposes methods for querying state
 type state struct {
-	client client.Client
+	client       client.Client
+	serDeOptions *types.SerDeOptions
+	batchSize    int
+}
+
+// Option is the type used for configuring a State
+type Option func(s *state)
+
+// WithSerDeOptions sets the options used for decoding the storage instead of the default ones, see
+// types.SerDeOptionsFromMetadata
+func WithSerDeOptions(opts types.SerDeOptions) Option {
+	return func(s *state) {
+		s.serDeOptions = &opts
+	}
+}
+
+// WithBatchSize sets the maximum number of requests that are sent in a single JSON-RPC batch request, all requests
+// are sent in a single batch request if batchSize is not positive. The default is the BatchSize of config.Default
+func WithBatchSize(batchSize int) Option {
+	return func(s *state) {
+		s.batchSize = batchSize
+	}
+}
+
+// NewState creates a new state struct, configured with the provided options
+func NewState(c client.Client, opts ...Option) State {
+	s := &state{client: c, batchSize: config.Default().BatchSize}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
-// NewState creates a new state struct
-func NewState(c client.Client) State {
-	return &state{client: c}
+func (s *state) decode(bz []byte, target interface{}) error {
+	if s.serDeOptions == nil {
+		return codec.Decode(bz, target)
+	}
+
+	return types.DecodeWithOptions(bz, target, *s.serDeOptions)
 }
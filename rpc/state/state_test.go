@@ -64,6 +64,9 @@ type MockSrv struct {
 	childStorageTrieValue    ChildStorageTrieTestVal
 	childStorageTrieSize     types.U64
 	childStorageTrieHashHex  string
+	callMethod               string
+	callDataHex              string
+	callResultHex            string
 }
 
 func (s *MockSrv) GetMetadata(hash *string) string {
@@ -161,6 +164,24 @@ func (s *MockSrv) QueryStorageAt(keys []string, hash *string) []types.StorageCha
 	return mockSrv.storageChangeSets
 }
 
+func (s *MockSrv) Call(method string, data string, hash *string) string {
+	if method != mockSrv.callMethod {
+		panic("method not found")
+	}
+	if data != mockSrv.callDataHex {
+		panic("unexpected call data")
+	}
+	return mockSrv.callResultHex
+}
+
+func (s *MockSrv) SubscribeRuntimeVersion() string {
+	return "0x1"
+}
+
+func (s *MockSrv) UnsubscribeRuntimeVersion(id string) bool {
+	return true
+}
+
 // func (s *MockSrv) SubscribeStorage(args []string) {
 // 	fmt.Println("Hit")
 // }
@@ -194,4 +215,7 @@ var mockSrv = MockSrv{
 	},
 	childStorageTrieSize:    68,
 	childStorageTrieHashHex: "0x20e3fc48a91087d091c17de08a5c470de53ccdaebd361025b0e5b7c65b9a0d30", //nolint:lll
+	callMethod:              "Core_version",
+	callDataHex:             "0x0102",
+	callResultHex:           "0x0a0b0c",
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -64,16 +65,27 @@ func (s *RuntimeVersionSubscription) Unsubscribe() {
 // receive server notifications containing the RuntimeVersion.
 func (s *state) SubscribeRuntimeVersion() (
 	*RuntimeVersionSubscription, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	return s.SubscribeRuntimeVersionCtx(context.Background())
+}
+
+// SubscribeRuntimeVersionCtx is like SubscribeRuntimeVersion, the subscription is unsubscribed once the provided
+// context is done
+func (s *state) SubscribeRuntimeVersionCtx(ctx context.Context) (
+	*RuntimeVersionSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
 	defer cancel()
 
 	c := make(chan types.RuntimeVersion)
 
-	sub, err := s.client.Subscribe(ctx, "state", "subscribeRuntimeVersion", "unsubscribeRuntimeVersion",
+	sub, err := s.client.Subscribe(subscribeCtx, "state", "subscribeRuntimeVersion", "unsubscribeRuntimeVersion",
 		"runtimeVersion", c)
 	if err != nil {
 		return nil, err
 	}
 
-	return &RuntimeVersionSubscription{sub: sub, channel: c}, nil
+	res := &RuntimeVersionSubscription{sub: sub, channel: c}
+
+	client.UnsubscribeOnDone(ctx, sub, res.Unsubscribe)
+
+	return res, nil
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -68,7 +69,14 @@ func (s *StorageSubscription) Unsubscribe() {
 // large buffer on the channel or ensure that the channel usually has at least one reader to prevent this issue.
 func (s *state) SubscribeStorageRaw(keys []types.StorageKey) (
 	*StorageSubscription, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	return s.SubscribeStorageRawCtx(context.Background(), keys)
+}
+
+// SubscribeStorageRawCtx is like SubscribeStorageRaw, the subscription is unsubscribed once the provided
+// context is done
+func (s *state) SubscribeStorageRawCtx(ctx context.Context, keys []types.StorageKey) (
+	*StorageSubscription, error) {
+	subscribeCtx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
 	defer cancel()
 
 	c := make(chan types.StorageChangeSet)
@@ -78,10 +86,14 @@ func (s *state) SubscribeStorageRaw(keys []types.StorageKey) (
 		keyss[i] = keys[i].Hex()
 	}
 
-	sub, err := s.client.Subscribe(ctx, "state", "subscribeStorage", "unsubscribeStorage", "storage", c, keyss)
+	sub, err := s.client.Subscribe(subscribeCtx, "state", "subscribeStorage", "unsubscribeStorage", "storage", c, keyss)
 	if err != nil {
 		return nil, err
 	}
 
-	return &StorageSubscription{sub: sub, channel: c}, nil
+	res := &StorageSubscription{sub: sub, channel: c}
+
+	client.UnsubscribeOnDone(ctx, sub, res.Unsubscribe)
+
+	return res, nil
 }
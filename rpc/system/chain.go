@@ -17,12 +17,19 @@
 package system
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // Chain retrieves the chain
 func (c *system) Chain() (types.Text, error) {
+	return c.ChainCtx(context.Background())
+}
+
+// ChainCtx is like Chain, the request is bound to the provided context
+func (c *system) ChainCtx(ctx context.Context) (types.Text, error) {
 	var t types.Text
-	err := c.client.Call(&t, "system_chain")
+	err := c.client.CallContext(ctx, &t, "system_chain")
 	return t, err
 }
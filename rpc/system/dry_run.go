@@ -0,0 +1,76 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+)
+
+// DryRun executes the provided extrinsic at the given block without including it in a block, and returns the
+// result of its application
+func (c *system) DryRun(xt extrinsic.Extrinsic, blockHash types.Hash) (*types.ApplyExtrinsicResult, error) {
+	return c.DryRunCtx(context.Background(), xt, blockHash)
+}
+
+// DryRunCtx is like DryRun, the request is bound to the provided context
+func (c *system) DryRunCtx(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	blockHash types.Hash,
+) (*types.ApplyExtrinsicResult, error) {
+	return c.dryRun(ctx, xt, &blockHash)
+}
+
+// DryRunLatest executes the provided extrinsic at the latest block without including it in a block, and returns the
+// result of its application
+func (c *system) DryRunLatest(xt extrinsic.Extrinsic) (*types.ApplyExtrinsicResult, error) {
+	return c.DryRunLatestCtx(context.Background(), xt)
+}
+
+// DryRunLatestCtx is like DryRunLatest, the request is bound to the provided context
+func (c *system) DryRunLatestCtx(ctx context.Context, xt extrinsic.Extrinsic) (*types.ApplyExtrinsicResult, error) {
+	return c.dryRun(ctx, xt, nil)
+}
+
+func (c *system) dryRun(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	blockHash *types.Hash,
+) (*types.ApplyExtrinsicResult, error) {
+	enc, err := codec.EncodeToHex(xt)
+	if err != nil {
+		return nil, err
+	}
+
+	var res string
+	err = client.CallWithBlockHashContext(ctx, c.client, &res, "system_dryRun", blockHash, enc)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.ApplyExtrinsicResult
+	if err := codec.DecodeFromHex(res, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
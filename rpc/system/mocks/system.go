@@ -3,6 +3,9 @@
 package mocks
 
 import (
+	context "context"
+
+	extrinsic "github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
 	mock "github.com/stretchr/testify/mock"
 
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -34,6 +37,119 @@ func (_m *System) Chain() (types.Text, error) {
 	return r0, r1
 }
 
+// ChainCtx provides a mock function with given fields: ctx
+func (_m *System) ChainCtx(ctx context.Context) (types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Text)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DryRun provides a mock function with given fields: xt, blockHash
+func (_m *System) DryRun(xt extrinsic.Extrinsic, blockHash types.Hash) (*types.ApplyExtrinsicResult, error) {
+	ret := _m.Called(xt, blockHash)
+
+	var r0 *types.ApplyExtrinsicResult
+	if rf, ok := ret.Get(0).(func(extrinsic.Extrinsic, types.Hash) *types.ApplyExtrinsicResult); ok {
+		r0 = rf(xt, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ApplyExtrinsicResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(extrinsic.Extrinsic, types.Hash) error); ok {
+		r1 = rf(xt, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DryRunCtx provides a mock function with given fields: ctx, xt, blockHash
+func (_m *System) DryRunCtx(ctx context.Context, xt extrinsic.Extrinsic, blockHash types.Hash) (*types.ApplyExtrinsicResult, error) {
+	ret := _m.Called(ctx, xt, blockHash)
+
+	var r0 *types.ApplyExtrinsicResult
+	if rf, ok := ret.Get(0).(func(context.Context, extrinsic.Extrinsic, types.Hash) *types.ApplyExtrinsicResult); ok {
+		r0 = rf(ctx, xt, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ApplyExtrinsicResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, extrinsic.Extrinsic, types.Hash) error); ok {
+		r1 = rf(ctx, xt, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DryRunLatest provides a mock function with given fields: xt
+func (_m *System) DryRunLatest(xt extrinsic.Extrinsic) (*types.ApplyExtrinsicResult, error) {
+	ret := _m.Called(xt)
+
+	var r0 *types.ApplyExtrinsicResult
+	if rf, ok := ret.Get(0).(func(extrinsic.Extrinsic) *types.ApplyExtrinsicResult); ok {
+		r0 = rf(xt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ApplyExtrinsicResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(extrinsic.Extrinsic) error); ok {
+		r1 = rf(xt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DryRunLatestCtx provides a mock function with given fields: ctx, xt
+func (_m *System) DryRunLatestCtx(ctx context.Context, xt extrinsic.Extrinsic) (*types.ApplyExtrinsicResult, error) {
+	ret := _m.Called(ctx, xt)
+
+	var r0 *types.ApplyExtrinsicResult
+	if rf, ok := ret.Get(0).(func(context.Context, extrinsic.Extrinsic) *types.ApplyExtrinsicResult); ok {
+		r0 = rf(ctx, xt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ApplyExtrinsicResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, extrinsic.Extrinsic) error); ok {
+		r1 = rf(ctx, xt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Health provides a mock function with given fields:
 func (_m *System) Health() (types.Health, error) {
 	ret := _m.Called()
@@ -55,6 +171,27 @@ func (_m *System) Health() (types.Health, error) {
 	return r0, r1
 }
 
+// HealthCtx provides a mock function with given fields: ctx
+func (_m *System) HealthCtx(ctx context.Context) (types.Health, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Health
+	if rf, ok := ret.Get(0).(func(context.Context) types.Health); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Health)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Name provides a mock function with given fields:
 func (_m *System) Name() (types.Text, error) {
 	ret := _m.Called()
@@ -76,6 +213,27 @@ func (_m *System) Name() (types.Text, error) {
 	return r0, r1
 }
 
+// NameCtx provides a mock function with given fields: ctx
+func (_m *System) NameCtx(ctx context.Context) (types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Text)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NetworkState provides a mock function with given fields:
 func (_m *System) NetworkState() (types.NetworkState, error) {
 	ret := _m.Called()
@@ -97,6 +255,27 @@ func (_m *System) NetworkState() (types.NetworkState, error) {
 	return r0, r1
 }
 
+// NetworkStateCtx provides a mock function with given fields: ctx
+func (_m *System) NetworkStateCtx(ctx context.Context) (types.NetworkState, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.NetworkState
+	if rf, ok := ret.Get(0).(func(context.Context) types.NetworkState); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.NetworkState)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Peers provides a mock function with given fields:
 func (_m *System) Peers() ([]types.PeerInfo, error) {
 	ret := _m.Called()
@@ -120,6 +299,29 @@ func (_m *System) Peers() ([]types.PeerInfo, error) {
 	return r0, r1
 }
 
+// PeersCtx provides a mock function with given fields: ctx
+func (_m *System) PeersCtx(ctx context.Context) ([]types.PeerInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []types.PeerInfo
+	if rf, ok := ret.Get(0).(func(context.Context) []types.PeerInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.PeerInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Properties provides a mock function with given fields:
 func (_m *System) Properties() (types.ChainProperties, error) {
 	ret := _m.Called()
@@ -141,6 +343,27 @@ func (_m *System) Properties() (types.ChainProperties, error) {
 	return r0, r1
 }
 
+// PropertiesCtx provides a mock function with given fields: ctx
+func (_m *System) PropertiesCtx(ctx context.Context) (types.ChainProperties, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.ChainProperties
+	if rf, ok := ret.Get(0).(func(context.Context) types.ChainProperties); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.ChainProperties)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Version provides a mock function with given fields:
 func (_m *System) Version() (types.Text, error) {
 	ret := _m.Called()
@@ -162,6 +385,27 @@ func (_m *System) Version() (types.Text, error) {
 	return r0, r1
 }
 
+// VersionCtx provides a mock function with given fields: ctx
+func (_m *System) VersionCtx(ctx context.Context) (types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Text)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewSystemT interface {
 	mock.TestingT
 	Cleanup(func())
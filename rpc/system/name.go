@@ -17,12 +17,19 @@
 package system
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // Name retrieves the node name
 func (c *system) Name() (types.Text, error) {
+	return c.NameCtx(context.Background())
+}
+
+// NameCtx is like Name, the request is bound to the provided context
+func (c *system) NameCtx(ctx context.Context) (types.Text, error) {
 	var t types.Text
-	err := c.client.Call(&t, "system_name")
+	err := c.client.CallContext(ctx, &t, "system_name")
 	return t, err
 }
@@ -19,18 +19,32 @@
 package system
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
 )
 
 type System interface {
 	Properties() (types.ChainProperties, error)
+	PropertiesCtx(ctx context.Context) (types.ChainProperties, error)
 	Health() (types.Health, error)
+	HealthCtx(ctx context.Context) (types.Health, error)
 	Peers() ([]types.PeerInfo, error)
+	PeersCtx(ctx context.Context) ([]types.PeerInfo, error)
 	Name() (types.Text, error)
+	NameCtx(ctx context.Context) (types.Text, error)
 	Chain() (types.Text, error)
+	ChainCtx(ctx context.Context) (types.Text, error)
 	Version() (types.Text, error)
+	VersionCtx(ctx context.Context) (types.Text, error)
 	NetworkState() (types.NetworkState, error)
+	NetworkStateCtx(ctx context.Context) (types.NetworkState, error)
+	DryRun(xt extrinsic.Extrinsic, blockHash types.Hash) (*types.ApplyExtrinsicResult, error)
+	DryRunCtx(ctx context.Context, xt extrinsic.Extrinsic, blockHash types.Hash) (*types.ApplyExtrinsicResult, error)
+	DryRunLatest(xt extrinsic.Extrinsic) (*types.ApplyExtrinsicResult, error)
+	DryRunLatestCtx(ctx context.Context, xt extrinsic.Extrinsic) (*types.ApplyExtrinsicResult, error)
 }
 
 // system exposes methods for retrieval of system data
@@ -55,6 +55,7 @@ type MockSrv struct {
 	peers        []types.PeerInfo
 	properties   types.ChainProperties
 	version      types.Text
+	dryRunResult string
 }
 
 func (s *MockSrv) Chain() types.Text {
@@ -85,6 +86,10 @@ func (s *MockSrv) Version() types.Text {
 	return mockSrv.version
 }
 
+func (s *MockSrv) DryRun(xt string, hash *string) string {
+	return mockSrv.dryRunResult
+}
+
 // mockSrv sets default data used in tests. This data might become stale when substrate is updated – just run the tests
 // against real servers and update the values stored here. To do that, replace s.URL with
 // config.Default().RPCURL
@@ -97,5 +102,6 @@ var mockSrv = MockSrv{
 		BestHash: types.NewHash(codec.MustHexDecodeString("0xabcd")), BestNumber: 420}},
 	properties: types.ChainProperties{IsTokenDecimals: true, AsTokenDecimals: 18,
 		IsTokenSymbol: true, AsTokenSymbol: "GSRPCCOIN"},
-	version: "My version",
+	version:      "My version",
+	dryRunResult: "0x0000",
 }
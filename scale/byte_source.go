@@ -0,0 +1,89 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import "io"
+
+// byteSource is a reader over a byte slice that is shared by the copies of a Decoder, so that the bytes can be read
+// directly from the slice instead of through the io.Reader interface.
+type byteSource struct {
+	buf []byte
+	pos int
+}
+
+// Read implements io.Reader with the same semantics as bytes.Reader.
+func (s *byteSource) Read(p []byte) (int, error) {
+	if s.pos >= len(s.buf) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, s.buf[s.pos:])
+	s.pos += n
+
+	return n, nil
+}
+
+// ReadByte implements io.ByteReader.
+func (s *byteSource) ReadByte() (byte, error) {
+	if s.pos >= len(s.buf) {
+		return 0, io.EOF
+	}
+
+	b := s.buf[s.pos]
+	s.pos++
+
+	return b, nil
+}
+
+// next returns the next n bytes without copying them. It returns io.EOF if no bytes are left and
+// io.ErrUnexpectedEOF if less than n bytes are left.
+func (s *byteSource) next(n int) ([]byte, error) {
+	if n == 0 {
+		return nil, nil
+	}
+
+	remaining := s.remaining()
+
+	if remaining == 0 {
+		return nil, io.EOF
+	}
+
+	if remaining < n {
+		s.pos = len(s.buf)
+
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	b := s.buf[s.pos : s.pos+n : s.pos+n]
+	s.pos += n
+
+	return b, nil
+}
+
+// readFull copies the next len(p) bytes into p, with the same errors as next.
+func (s *byteSource) readFull(p []byte) error {
+	b, err := s.next(len(p))
+	if err != nil {
+		return err
+	}
+
+	copy(p, b)
+
+	return nil
+}
+
+func (s *byteSource) remaining() int {
+	return len(s.buf) - s.pos
+}
@@ -16,12 +16,9 @@ package scale
 
 import (
 	"bytes"
-	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
-	"log"
-	"math"
 	"math/big"
 	"reflect"
 )
@@ -36,13 +33,18 @@ const maxUint = ^uint(0)
 const maxInt = int(maxUint >> 1)
 
 // Encoder is a wrapper around a Writer that allows encoding data items to a stream.
-// Allows passing encoding options
+// Allows passing encoding options, see WithValue.
 type Encoder struct {
 	writer io.Writer
+	// buffer is set if the writer is a bytes.Buffer, in which case small writes are done on it directly.
+	buffer *bytes.Buffer
+	values *values
 }
 
 func NewEncoder(writer io.Writer) *Encoder {
-	return &Encoder{writer: writer}
+	buffer, _ := writer.(*bytes.Buffer)
+
+	return &Encoder{writer: writer, buffer: buffer}
 }
 
 // Write several bytes to the encoder.
@@ -59,9 +61,23 @@ func (pe Encoder) Write(bytes []byte) error {
 
 // PushByte writes a single byte to an encoder.
 func (pe Encoder) PushByte(b byte) error {
+	if pe.buffer != nil {
+		return pe.buffer.WriteByte(b)
+	}
+
 	return pe.Write([]byte{b})
 }
 
+// writeScratch writes bytes that are not retained by the caller, such as the bytes of a buffer on the stack.
+func (pe Encoder) writeScratch(bytes []byte) error {
+	if pe.buffer != nil {
+		_, err := pe.buffer.Write(bytes)
+		return err
+	}
+
+	return pe.Write(append([]byte(nil), bytes...))
+}
+
 // EncodeUintCompact writes an unsigned integer to the stream using the compact encoding.
 // A typical usage is storing the length of a collection.
 // Definition of compact encoding:
@@ -77,25 +93,7 @@ func (pe Encoder) EncodeUintCompact(v big.Int) error {
 	}
 
 	if v.IsUint64() {
-		if v.Uint64() < 1<<30 {
-			if v.Uint64() < 1<<6 {
-				err := pe.PushByte(byte(v.Uint64()) << 2)
-				if err != nil {
-					return err
-				}
-			} else if v.Uint64() < 1<<14 {
-				err := binary.Write(pe.writer, binary.LittleEndian, uint16(v.Uint64()<<2)+1)
-				if err != nil {
-					return err
-				}
-			} else {
-				err := binary.Write(pe.writer, binary.LittleEndian, uint32(v.Uint64()<<2)+2)
-				if err != nil {
-					return err
-				}
-			}
-			return nil
-		}
+		return pe.encodeUint64Compact(v.Uint64())
 	}
 
 	numBytes := len(v.Bytes())
@@ -122,142 +120,16 @@ func (pe Encoder) EncodeUintCompact(v big.Int) error {
 }
 
 // Encode a value to the stream.
+//
+// The value is encoded using the Encodeable implementation of its type if there is one, otherwise using an encode
+// plan that is derived once per type using reflection and cached for subsequent calls.
 func (pe Encoder) Encode(value interface{}) error {
-	t := reflect.TypeOf(value)
-
-	// If the type implements encodeable, use that implementation
-	encodeable := reflect.TypeOf((*Encodeable)(nil)).Elem()
-	if t.Implements(encodeable) {
-		err := value.(Encodeable).Encode(pe)
-		if err != nil {
-			return err
-		}
-		return nil
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return errors.New("Encoding nil values not supported; consider using Option type")
 	}
 
-	tk := t.Kind()
-	switch tk {
-
-	// Boolean and numbers are trivially encoded via binary.Write
-	// It will use reflection again and take a performance hit
-	// TODO: consider handling every case directly
-	case reflect.Bool:
-		fallthrough
-	case reflect.Int8:
-		fallthrough
-	case reflect.Uint8:
-		fallthrough
-	case reflect.Int:
-		fallthrough
-	case reflect.Int16:
-		fallthrough
-	case reflect.Int32:
-		fallthrough
-	case reflect.Int64:
-		fallthrough
-	case reflect.Uint:
-		fallthrough
-	case reflect.Uint16:
-		fallthrough
-	case reflect.Uint32:
-		fallthrough
-	case reflect.Uint64:
-		fallthrough
-	case reflect.Uintptr:
-		fallthrough
-	case reflect.Float32:
-		fallthrough
-	case reflect.Float64:
-		err := binary.Write(pe.writer, binary.LittleEndian, value)
-		if err != nil {
-			return err
-		}
-	case reflect.Ptr:
-		rv := reflect.ValueOf(value)
-		if rv.IsNil() {
-			return errors.New("Encoding null pointers not supported; consider using Option type")
-		} else {
-			dereferenced := rv.Elem()
-			err := pe.Encode(dereferenced.Interface())
-			if err != nil {
-				return err
-			}
-		}
-
-	// Arrays: no compact-encoded length prefix
-	case reflect.Array:
-		rv := reflect.ValueOf(value)
-		l := rv.Len()
-		for i := 0; i < l; i++ {
-			err := pe.Encode(rv.Index(i).Interface())
-			if err != nil {
-				return err
-			}
-		}
-
-	// Slices: first compact-encode length, then each item individually
-	case reflect.Slice:
-		rv := reflect.ValueOf(value)
-		l := rv.Len()
-		len64 := uint64(l)
-		if len64 > math.MaxUint32 {
-			return errors.New("Attempted to serialize a collection with too many elements.")
-		}
-		err := pe.EncodeUintCompact(*big.NewInt(0).SetUint64(len64))
-		if err != nil {
-			return err
-		}
-		for i := 0; i < l; i++ {
-			err = pe.Encode(rv.Index(i).Interface())
-			if err != nil {
-				return err
-			}
-		}
-
-	// Strings are encoded as UTF-8 byte slices, just as in Rust
-	case reflect.String:
-		s := reflect.ValueOf(value).String()
-		err := pe.Encode([]byte(s))
-		if err != nil {
-			return err
-		}
-
-	case reflect.Struct:
-		rv := reflect.ValueOf(value)
-		for i := 0; i < rv.NumField(); i++ {
-			ft := rv.Type().Field(i)
-			tv, ok := ft.Tag.Lookup("scale")
-			if ok && tv == "-" {
-				continue
-			}
-			err := pe.Encode(rv.Field(i).Interface())
-			if err != nil {
-				return fmt.Errorf("type %s does not support Encodeable interface and could not be "+
-					"encoded field by field, error: %v", t, err)
-			}
-		}
-
-	// Currently unsupported types
-	case reflect.Complex64:
-		fallthrough
-	case reflect.Complex128:
-		fallthrough
-	case reflect.Chan:
-		fallthrough
-	case reflect.Func:
-		fallthrough
-	case reflect.Interface:
-		fallthrough
-	case reflect.Map:
-		fallthrough
-	case reflect.UnsafePointer:
-		fallthrough
-	case reflect.Invalid:
-		return fmt.Errorf("Type %s cannot be encoded", t.Kind())
-	default:
-		log.Println("not captured")
-	}
-	return nil
+	return getEncodePlan(rv.Type())(pe, rv)
 }
 
 // EncodeOption stores optionally present value to the stream.
@@ -281,17 +153,88 @@ func (pe Encoder) EncodeOption(hasValue bool, value interface{}) error {
 }
 
 // Decoder is a wraper around a Reader that allows decoding data items from a stream.
+// Allows passing decoding options, see WithValue.
+// Allows tracking the decoded bytes, see Offset and WithTrace.
 type Decoder struct {
 	reader io.Reader
+	// source is set if the decoder reads from a byte slice, in which case the bytes are read from it directly.
+	source *byteSource
+	// stream is set if the decoder reads from a reader, to track the number of bytes read from it.
+	stream *stream
+	trace  *Trace
+	values *values
 }
 
 func NewDecoder(reader io.Reader) *Decoder {
-	return &Decoder{reader: reader}
+	return &Decoder{reader: reader, stream: &stream{}}
+}
+
+// NewDecoderFromBytes creates a decoder that reads from the provided bytes. It is faster than a decoder that reads
+// from a bytes.Reader, since the bytes are read from the slice directly instead of through the io.Reader interface.
+func NewDecoderFromBytes(bz []byte) *Decoder {
+	source := &byteSource{buf: bz}
+
+	return &Decoder{reader: source, source: source}
+}
+
+// stream holds the number of bytes read by the copies of a Decoder that reads from a reader.
+type stream struct {
+	offset int
+}
+
+// Offset returns the number of bytes read by the decoder so far.
+func (pd Decoder) Offset() int {
+	switch {
+	case pd.source != nil:
+		return pd.source.pos
+	case pd.stream != nil:
+		return pd.stream.offset
+	default:
+		return 0
+	}
+}
+
+// Remaining returns the bytes that were not read yet if the decoder reads from a byte slice, see NewDecoderFromBytes.
+// It returns nil if the decoder reads from a reader.
+func (pd Decoder) Remaining() []byte {
+	return pd.remainingFrom(pd.Offset())
+}
+
+func (pd Decoder) remainingFrom(offset int) []byte {
+	if pd.source == nil || offset > len(pd.source.buf) {
+		return nil
+	}
+
+	return pd.source.buf[offset:]
+}
+
+// advance keeps track of the bytes that were read from the reader.
+func (pd Decoder) advance(bytes []byte) {
+	if pd.stream == nil {
+		return
+	}
+
+	pd.stream.offset += len(bytes)
+
+	if pd.trace != nil {
+		pd.trace.data = append(pd.trace.data, bytes...)
+	}
 }
 
 // Read reads bytes from a stream into a buffer
 func (pd Decoder) Read(bytes []byte) error {
-	c, err := pd.reader.Read(bytes)
+	var (
+		c   int
+		err error
+	)
+
+	if pd.source != nil {
+		c, err = pd.source.Read(bytes)
+	} else {
+		c, err = pd.reader.Read(bytes)
+		pd.advance(bytes[:c])
+	}
+
 	if err != nil {
 		return err
 	}
@@ -304,6 +247,18 @@ func (pd Decoder) Read(bytes []byte) error {
 // ReadOneByte reads a next byte from the stream.
 // Named so to avoid a linter warning about a clash with io.ByteReader.ReadByte
 func (pd Decoder) ReadOneByte() (byte, error) {
+	if pd.source != nil {
+		return pd.source.ReadByte()
+	}
+
+	if byteReader, ok := pd.reader.(io.ByteReader); ok {
+		b, err := byteReader.ReadByte()
+		if err == nil {
+			pd.advance([]byte{b})
+		}
+		return b, err
+	}
+
 	buf := []byte{0}
 	err := pd.Read(buf)
 	if err != nil {
@@ -312,6 +267,31 @@ func (pd Decoder) ReadOneByte() (byte, error) {
 	return buf[0], nil
 }
 
+// readFull reads exactly len(buf) bytes into buf. Similar to io.ReadFull, it returns io.EOF if no bytes could be read
+// and io.ErrUnexpectedEOF if only some of the bytes could be read.
+func (pd Decoder) readFull(buf []byte) error {
+	if pd.source != nil {
+		return pd.source.readFull(buf)
+	}
+
+	n, err := io.ReadFull(pd.reader, buf)
+	pd.advance(buf[:n])
+
+	return err
+}
+
+// next returns the next n bytes of the stream with the same errors as readFull. If the decoder reads from a byte
+// slice, the returned bytes point into it and must not be modified.
+func (pd Decoder) next(n int) ([]byte, error) {
+	if pd.source != nil {
+		return pd.source.next(n)
+	}
+
+	buf := make([]byte, n)
+
+	return buf, pd.readFull(buf)
+}
+
 // Decode takes a pointer to a decodable value and populates it from the stream.
 func (pd Decoder) Decode(target interface{}) error {
 	t0 := reflect.TypeOf(target)
@@ -326,168 +306,28 @@ func (pd Decoder) Decode(target interface{}) error {
 }
 
 // DecodeIntoReflectValue populates a writable reflect.Value from the stream
+//
+// The value is decoded using the Decodeable implementation of its pointer type if there is one, otherwise using a
+// decode plan that is derived once per type using reflection and cached for subsequent calls.
+//
+// Errors that occur while decoding a field or item of the value are returned as a DecodeError.
 func (pd Decoder) DecodeIntoReflectValue(target reflect.Value) error {
+	if !target.IsValid() {
+		return errors.New("Target is not a valid value")
+	}
+
 	t := target.Type()
 	if !target.CanSet() {
 		return fmt.Errorf("Unsettable value %v", t)
 	}
 
-	// If the type implements decodeable, use that implementation
-	decodeable := reflect.TypeOf((*Decodeable)(nil)).Elem()
-	ptrType := reflect.PtrTo(t)
-	if ptrType.Implements(decodeable) {
-		var holder reflect.Value
-		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
-			slice := reflect.MakeSlice(t, target.Len(), target.Len())
-			holder = reflect.New(t)
-			holder.Elem().Set(slice)
-		} else {
-			holder = reflect.New(t)
-		}
+	if pd.trace != nil {
+		scope := pd.Enter(PathSegment{}, t.String())
 
-		err := holder.Interface().(Decodeable).Decode(pd)
-		if err != nil {
-			return err
-		}
-		target.Set(holder.Elem())
-		return nil
+		return scope.Exit(getDecodePlan(t)(pd, target))
 	}
 
-	switch t.Kind() {
-
-	// Boolean and numbers are trivially decoded via binary.Read
-	// It will use reflection again and take a performance hit
-	// TODO: consider handling every case directly
-	case reflect.Bool:
-		fallthrough
-	case reflect.Int8:
-		fallthrough
-	case reflect.Uint8:
-		fallthrough
-	case reflect.Int:
-		fallthrough
-	case reflect.Int16:
-		fallthrough
-	case reflect.Int32:
-		fallthrough
-	case reflect.Int64:
-		fallthrough
-	case reflect.Uint:
-		fallthrough
-	case reflect.Uint16:
-		fallthrough
-	case reflect.Uint32:
-		fallthrough
-	case reflect.Uint64:
-		fallthrough
-	case reflect.Uintptr:
-		fallthrough
-	case reflect.Float32:
-		fallthrough
-	case reflect.Float64:
-		intHolder := reflect.New(t)
-		intPointer := intHolder.Interface()
-		err := binary.Read(pd.reader, binary.LittleEndian, intPointer)
-		if err == io.EOF {
-			return errors.New("expected more bytes, but could not decode any more")
-		}
-		if err != nil {
-			return err
-		}
-		target.Set(intHolder.Elem())
-
-	// If you want to replicate Option<T> behavior in Rust, see OptionBool and an
-	// example type OptionInt8 in tests.
-	case reflect.Ptr:
-		isNil := target.IsNil()
-		if isNil {
-			// target.set
-			// return nil
-		}
-		ptr := target.Elem()
-		err := pd.DecodeIntoReflectValue(ptr)
-		if err != nil {
-			return err
-		}
-
-	// Arrays: derive the length from the array length
-	case reflect.Array:
-		targetLen := target.Len()
-		for i := 0; i < targetLen; i++ {
-			err := pd.DecodeIntoReflectValue(target.Index(i))
-			if err != nil {
-				return err
-			}
-		}
-
-	// Slices: first compact-encode length, then each item individually
-	case reflect.Slice:
-		codedLen64, _ := pd.DecodeUintCompact()
-		if codedLen64.Uint64() > math.MaxUint32 {
-			return errors.New("Encoded array length is higher than allowed by the protocol (32-bit unsigned integer)")
-		}
-		if codedLen64.Uint64() > uint64(maxInt) {
-			return errors.New("Encoded array length is higher than allowed by the platform")
-		}
-		codedLen := int(codedLen64.Uint64())
-		targetLen := target.Len()
-		if codedLen != targetLen {
-			if int(codedLen) > target.Cap() {
-				newSlice := reflect.MakeSlice(t, int(codedLen), int(codedLen))
-				target.Set(newSlice)
-			} else {
-				target.SetLen(int(codedLen))
-			}
-		}
-		for i := 0; i < codedLen; i++ {
-			err := pd.DecodeIntoReflectValue(target.Index(i))
-			if err != nil {
-				return err
-			}
-		}
-
-	// Strings are encoded as UTF-8 byte slices, just as in Rust
-	case reflect.String:
-		var b []byte
-		err := pd.Decode(&b)
-		if err != nil {
-			return err
-		}
-		target.SetString(string(b))
-
-	case reflect.Struct:
-		for i := 0; i < target.NumField(); i++ {
-			ft := target.Type().Field(i)
-			tv, ok := ft.Tag.Lookup("scale")
-			if ok && tv == "-" {
-				continue
-			}
-			err := pd.DecodeIntoReflectValue(target.Field(i))
-			if err != nil {
-				return fmt.Errorf("type %s does not support Decodeable interface and could not be "+
-					"decoded field by field, error: %v", ptrType, err)
-			}
-		}
-
-	// Currently unsupported types
-	case reflect.Complex64:
-		fallthrough
-	case reflect.Complex128:
-		fallthrough
-	case reflect.Chan:
-		fallthrough
-	case reflect.Func:
-		fallthrough
-	case reflect.Interface:
-		fallthrough
-	case reflect.Map:
-		fallthrough
-	case reflect.UnsafePointer:
-		fallthrough
-	case reflect.Invalid:
-		return fmt.Errorf("Type %s cannot be decoded", t.Kind())
-	}
-	return nil
+	return getDecodePlan(t)(pd, target)
 }
 
 // DecodeUintCompact decodes a compact-encoded integer. See EncodeUintCompact method.
@@ -500,33 +340,12 @@ func (pd Decoder) DecodeUintCompact() (*big.Int, error) {
 
 	mode := b & 3
 	switch mode {
-	case 0:
-		// right shift to remove mode bits
-		return big.NewInt(0).SetUint64(uint64(b >> 2)), nil
-	case 1:
-		bb, err := pd.ReadOneByte()
-		if err != nil {
-			return nil, err
-		}
-		r := uint64(bb)
-		// * 2^6
-		r <<= 6
-		// right shift to remove mode bits and add to prev
-		r += uint64(b >> 2)
-		return big.NewInt(0).SetUint64(r), nil
-	case 2:
-		// value = 32 bits + mode
-		buf := make([]byte, 4)
-		buf[0] = b
-		err := pd.Read(buf[1:4])
+	case 0, 1, 2:
+		r, err := pd.decodeSmallUintCompact(b)
 		if err != nil {
 			return nil, err
 		}
-		// set the buffer in little endian order
-		r := binary.LittleEndian.Uint32(buf)
-		// remove the last 2 mode bits
-		r >>= 2
-		return big.NewInt(0).SetUint64(uint64(r)), nil
+		return new(big.Int).SetUint64(r), nil
 	case 3:
 		// remove mode bits
 		l := b >> 2
@@ -644,7 +463,7 @@ func (o *OptionBool) Decode(decoder Decoder) error {
 // ToKeyedVec replicates the behaviour of Rust's to_keyed_vec helper.
 func ToKeyedVec(value interface{}, prependKey []byte) ([]byte, error) {
 	var buffer = bytes.NewBuffer(prependKey)
-	err := Encoder{buffer}.Encode(value)
+	err := NewEncoder(buffer).Encode(value)
 	if err != nil {
 		return nil, err
 	}
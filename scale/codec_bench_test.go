@@ -0,0 +1,129 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale_test
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+type benchmarkFixture struct {
+	Name  string
+	Value any
+}
+
+func getBenchmarkFixtures(b *testing.B) []benchmarkFixture {
+	var meta types.Metadata
+
+	if err := codec.DecodeFromHex(types.MetadataV14Data, &meta); err != nil {
+		b.Fatalf("Couldn't decode metadata: %s", err)
+	}
+
+	events := make([]types.EventBalancesTransfer, 100)
+
+	for i := range events {
+		events[i] = types.EventBalancesTransfer{
+			Phase:  types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: uint32(i)},
+			From:   types.AccountID{byte(i)},
+			To:     types.AccountID{byte(i + 1)},
+			Value:  types.NewU128(*big.NewInt(int64(i) * 1_000_000_000_000)),
+			Topics: []types.Hash{{byte(i)}},
+		}
+	}
+
+	header := types.Header{
+		ParentHash: types.Hash{1},
+		Number:     1_234_567,
+		StateRoot:  types.Hash{2},
+		Digest: types.Digest{
+			{IsPreRuntime: true, AsPreRuntime: types.PreRuntime{ConsensusEngineID: 1, Bytes: make([]byte, 32)}},
+			{IsSeal: true, AsSeal: types.Seal{ConsensusEngineID: 1, Bytes: make([]byte, 64)}},
+		},
+	}
+
+	return []benchmarkFixture{
+		{Name: "metadata", Value: meta},
+		{Name: "events", Value: events},
+		{Name: "header", Value: header},
+	}
+}
+
+var benchmarkDecoders = []struct {
+	Name       string
+	NewDecoder func(bz []byte) *scale.Decoder
+}{
+	{
+		Name: "reader",
+		NewDecoder: func(bz []byte) *scale.Decoder {
+			return scale.NewDecoder(bytes.NewReader(bz))
+		},
+	},
+	{
+		Name:       "bytes",
+		NewDecoder: scale.NewDecoderFromBytes,
+	},
+}
+
+func BenchmarkDecoder_Decode(b *testing.B) {
+	for _, fixture := range getBenchmarkFixtures(b) {
+		encoded, err := codec.Encode(fixture.Value)
+		if err != nil {
+			b.Fatalf("Couldn't encode %s: %s", fixture.Name, err)
+		}
+
+		for _, decoder := range benchmarkDecoders {
+			b.Run(fixture.Name+"/"+decoder.Name, func(b *testing.B) {
+				targetType := reflect.TypeOf(fixture.Value)
+
+				b.SetBytes(int64(len(encoded)))
+				b.ReportAllocs()
+
+				for i := 0; i < b.N; i++ {
+					target := reflect.New(targetType)
+
+					if err := decoder.NewDecoder(encoded).Decode(target.Interface()); err != nil {
+						b.Fatalf("Couldn't decode %s: %s", fixture.Name, err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkEncoder_Encode(b *testing.B) {
+	for _, fixture := range getBenchmarkFixtures(b) {
+		b.Run(fixture.Name, func(b *testing.B) {
+			var buf bytes.Buffer
+
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+
+				if err := scale.NewEncoder(&buf).Encode(fixture.Value); err != nil {
+					b.Fatalf("Couldn't encode %s: %s", fixture.Name, err)
+				}
+			}
+
+			b.SetBytes(int64(buf.Len()))
+		})
+	}
+}
@@ -0,0 +1,364 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The functions below are the previous reflective implementation of Encoder.Encode and Decoder.Decode, which re-ran
+// the reflection type switches for every value and read every byte through the io.Reader. They are kept for comparing
+// the current implementation against it, see BenchmarkDecoder_DecodeLegacy and BenchmarkEncoder_EncodeLegacy.
+//
+// Types implementing Encodeable or Decodeable call back into the current implementation, so the comparison uses
+// values that are encoded and decoded by reflection only.
+
+func legacyEncode(pe Encoder, value interface{}) error {
+	t := reflect.TypeOf(value)
+
+	// If the type implements encodeable, use that implementation
+	encodeable := reflect.TypeOf((*Encodeable)(nil)).Elem()
+	if t.Implements(encodeable) {
+		return value.(Encodeable).Encode(pe)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int8, reflect.Uint8, reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32,
+		reflect.Float64:
+		return binary.Write(pe.writer, binary.LittleEndian, value)
+	case reflect.Ptr:
+		rv := reflect.ValueOf(value)
+		if rv.IsNil() {
+			return errors.New("Encoding null pointers not supported; consider using Option type")
+		}
+
+		return legacyEncode(pe, rv.Elem().Interface())
+
+	// Arrays: no compact-encoded length prefix
+	case reflect.Array:
+		rv := reflect.ValueOf(value)
+		for i := 0; i < rv.Len(); i++ {
+			if err := legacyEncode(pe, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+
+	// Slices: first compact-encode length, then each item individually
+	case reflect.Slice:
+		rv := reflect.ValueOf(value)
+		l := rv.Len()
+		if uint64(l) > math.MaxUint32 {
+			return errors.New("Attempted to serialize a collection with too many elements.")
+		}
+
+		if err := pe.EncodeUintCompact(*big.NewInt(0).SetUint64(uint64(l))); err != nil {
+			return err
+		}
+
+		for i := 0; i < l; i++ {
+			if err := legacyEncode(pe, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+
+	// Strings are encoded as UTF-8 byte slices, just as in Rust
+	case reflect.String:
+		return legacyEncode(pe, []byte(reflect.ValueOf(value).String()))
+
+	case reflect.Struct:
+		rv := reflect.ValueOf(value)
+		for i := 0; i < rv.NumField(); i++ {
+			if tv, ok := rv.Type().Field(i).Tag.Lookup("scale"); ok && tv == "-" {
+				continue
+			}
+
+			if err := legacyEncode(pe, rv.Field(i).Interface()); err != nil {
+				return fmt.Errorf("type %s does not support Encodeable interface and could not be "+
+					"encoded field by field, error: %v", t, err)
+			}
+		}
+
+	// Currently unsupported types
+	default:
+		return fmt.Errorf("Type %s cannot be encoded", t.Kind())
+	}
+
+	return nil
+}
+
+func legacyDecode(pd Decoder, target interface{}) error {
+	t0 := reflect.TypeOf(target)
+	if t0.Kind() != reflect.Ptr {
+		return errors.New("Target must be a pointer, but was " + fmt.Sprint(t0))
+	}
+
+	val := reflect.ValueOf(target)
+	if val.IsNil() {
+		return errors.New("Target is a nil pointer")
+	}
+
+	return legacyDecodeIntoReflectValue(pd, val.Elem())
+}
+
+// nolint:gocyclo
+func legacyDecodeIntoReflectValue(pd Decoder, target reflect.Value) error {
+	t := target.Type()
+	if !target.CanSet() {
+		return fmt.Errorf("Unsettable value %v", t)
+	}
+
+	// If the type implements decodeable, use that implementation
+	decodeable := reflect.TypeOf((*Decodeable)(nil)).Elem()
+	ptrType := reflect.PtrTo(t)
+	if ptrType.Implements(decodeable) {
+		holder := reflect.New(t)
+		if t.Kind() == reflect.Slice {
+			holder.Elem().Set(reflect.MakeSlice(t, target.Len(), target.Len()))
+		}
+
+		if err := holder.Interface().(Decodeable).Decode(pd); err != nil {
+			return err
+		}
+
+		target.Set(holder.Elem())
+
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int8, reflect.Uint8, reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32,
+		reflect.Float64:
+		intHolder := reflect.New(t)
+
+		err := binary.Read(pd.reader, binary.LittleEndian, intHolder.Interface())
+		if err == io.EOF {
+			return errors.New("expected more bytes, but could not decode any more")
+		}
+
+		if err != nil {
+			return err
+		}
+
+		target.Set(intHolder.Elem())
+
+	case reflect.Ptr:
+		return legacyDecodeIntoReflectValue(pd, target.Elem())
+
+	// Arrays: derive the length from the array length
+	case reflect.Array:
+		for i := 0; i < target.Len(); i++ {
+			if err := legacyDecodeIntoReflectValue(pd, target.Index(i)); err != nil {
+				return err
+			}
+		}
+
+	// Slices: first compact-encode length, then each item individually
+	case reflect.Slice:
+		codedLen64, _ := pd.DecodeUintCompact()
+		if codedLen64.Uint64() > math.MaxUint32 {
+			return errors.New("Encoded array length is higher than allowed by the protocol (32-bit unsigned integer)")
+		}
+
+		if codedLen64.Uint64() > uint64(maxInt) {
+			return errors.New("Encoded array length is higher than allowed by the platform")
+		}
+
+		codedLen := int(codedLen64.Uint64())
+		if codedLen != target.Len() {
+			if codedLen > target.Cap() {
+				target.Set(reflect.MakeSlice(t, codedLen, codedLen))
+			} else {
+				target.SetLen(codedLen)
+			}
+		}
+
+		for i := 0; i < codedLen; i++ {
+			if err := legacyDecodeIntoReflectValue(pd, target.Index(i)); err != nil {
+				return err
+			}
+		}
+
+	// Strings are encoded as UTF-8 byte slices, just as in Rust
+	case reflect.String:
+		var b []byte
+		if err := legacyDecode(pd, &b); err != nil {
+			return err
+		}
+
+		target.SetString(string(b))
+
+	case reflect.Struct:
+		for i := 0; i < target.NumField(); i++ {
+			if tv, ok := target.Type().Field(i).Tag.Lookup("scale"); ok && tv == "-" {
+				continue
+			}
+
+			if err := legacyDecodeIntoReflectValue(pd, target.Field(i)); err != nil {
+				return fmt.Errorf("type %s does not support Decodeable interface and could not be "+
+					"decoded field by field, error: %v", ptrType, err)
+			}
+		}
+
+	// Currently unsupported types
+	default:
+		return fmt.Errorf("Type %s cannot be decoded", t.Kind())
+	}
+
+	return nil
+}
+
+type legacyBenchmarkTransfer struct {
+	From   [32]byte
+	To     [32]byte
+	Amount uint64
+	Nonce  uint32
+	Memo   string
+	Tags   []uint16
+	Keep   bool
+}
+
+func getLegacyBenchmarkTransfers() []legacyBenchmarkTransfer {
+	transfers := make([]legacyBenchmarkTransfer, 100)
+
+	for i := range transfers {
+		transfers[i] = legacyBenchmarkTransfer{
+			From:   [32]byte{byte(i)},
+			To:     [32]byte{byte(i + 1)},
+			Amount: uint64(i) * 1_000_000_000_000,
+			Nonce:  uint32(i),
+			Memo:   fmt.Sprintf("transfer #%d", i),
+			Tags:   []uint16{uint16(i), 300},
+			Keep:   i%2 == 0,
+		}
+	}
+
+	return transfers
+}
+
+func TestLegacyCodec_MatchesCurrent(t *testing.T) {
+	transfers := getLegacyBenchmarkTransfers()
+
+	var current, legacy bytes.Buffer
+
+	assert.NoError(t, NewEncoder(&current).Encode(transfers))
+	assert.NoError(t, legacyEncode(*NewEncoder(&legacy), transfers))
+	assert.Equal(t, current.Bytes(), legacy.Bytes())
+
+	var decoded []legacyBenchmarkTransfer
+
+	assert.NoError(t, legacyDecode(*NewDecoder(bytes.NewReader(current.Bytes())), &decoded))
+	assert.Equal(t, transfers, decoded)
+}
+
+func BenchmarkDecoder_DecodeLegacy(b *testing.B) {
+	var buf bytes.Buffer
+
+	if err := NewEncoder(&buf).Encode(getLegacyBenchmarkTransfers()); err != nil {
+		b.Fatalf("Couldn't encode transfers: %s", err)
+	}
+
+	encoded := buf.Bytes()
+
+	decoders := []struct {
+		Name   string
+		Decode func(bz []byte, target any) error
+	}{
+		{
+			Name: "legacy/reader",
+			Decode: func(bz []byte, target any) error {
+				return legacyDecode(*NewDecoder(bytes.NewReader(bz)), target)
+			},
+		},
+		{
+			Name: "current/reader",
+			Decode: func(bz []byte, target any) error {
+				return NewDecoder(bytes.NewReader(bz)).Decode(target)
+			},
+		},
+		{
+			Name: "current/bytes",
+			Decode: func(bz []byte, target any) error {
+				return NewDecoderFromBytes(bz).Decode(target)
+			},
+		},
+	}
+
+	for _, decoder := range decoders {
+		b.Run(decoder.Name, func(b *testing.B) {
+			b.SetBytes(int64(len(encoded)))
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				var target []legacyBenchmarkTransfer
+
+				if err := decoder.Decode(encoded, &target); err != nil {
+					b.Fatalf("Couldn't decode transfers: %s", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEncoder_EncodeLegacy(b *testing.B) {
+	transfers := getLegacyBenchmarkTransfers()
+
+	encoders := []struct {
+		Name   string
+		Encode func(encoder *Encoder, value any) error
+	}{
+		{
+			Name: "legacy",
+			Encode: func(encoder *Encoder, value any) error {
+				return legacyEncode(*encoder, value)
+			},
+		},
+		{
+			Name: "current",
+			Encode: func(encoder *Encoder, value any) error {
+				return encoder.Encode(value)
+			},
+		},
+	}
+
+	for _, encoder := range encoders {
+		b.Run(encoder.Name, func(b *testing.B) {
+			var buf bytes.Buffer
+
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+
+				if err := encoder.Encode(NewEncoder(&buf), transfers); err != nil {
+					b.Fatalf("Couldn't encode transfers: %s", err)
+				}
+			}
+
+			b.SetBytes(int64(buf.Len()))
+		})
+	}
+}
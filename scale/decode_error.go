@@ -0,0 +1,205 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maxErrorBytes is the maximum number of remaining bytes that are included in the message of a DecodeError.
+const maxErrorBytes = 32
+
+// PathSegment is a segment of the path to a decoded value, such as the name of a field, the index of an item or both,
+// e.g. Event[3].
+type PathSegment struct {
+	Name     string
+	Index    int
+	HasIndex bool
+}
+
+// NameSegment returns a PathSegment for a field or variant with the provided name.
+func NameSegment(name string) PathSegment {
+	return PathSegment{Name: name}
+}
+
+// IndexSegment returns a PathSegment for the item of a collection at the provided index.
+func IndexSegment(index int) PathSegment {
+	return PathSegment{Index: index, HasIndex: true}
+}
+
+// IsEmpty returns true if the segment has neither a name nor an index.
+func (s PathSegment) IsEmpty() bool {
+	return s.Name == "" && !s.HasIndex
+}
+
+func (s PathSegment) String() string {
+	if !s.HasIndex {
+		return s.Name
+	}
+
+	return s.Name + "[" + strconv.Itoa(s.Index) + "]"
+}
+
+// Path is the path to a decoded value, e.g. Event[3].Balances.Transfer.amount.
+type Path []PathSegment
+
+func (p Path) String() string {
+	var sb strings.Builder
+
+	for i, segment := range p {
+		if i > 0 && segment.Name != "" {
+			sb.WriteString(".")
+		}
+
+		sb.WriteString(segment.String())
+	}
+
+	return sb.String()
+}
+
+// DecodeError is returned when a field or item of a value couldn't be decoded. It holds the path to the value that
+// couldn't be decoded, the offset at which its bytes start and the bytes that remain from that offset.
+type DecodeError struct {
+	Path   Path
+	Offset int
+	// Remaining holds the bytes from Offset onwards if the decoder reads from a byte slice, nil otherwise.
+	Remaining []byte
+	Err       error
+}
+
+func (e *DecodeError) Error() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "decoding %s at offset %d", e.Path, e.Offset)
+
+	switch {
+	case e.Remaining == nil:
+	case len(e.Remaining) == 0:
+		sb.WriteString(" (no remaining bytes)")
+	default:
+		fmt.Fprintf(&sb, " (%d remaining bytes: %#x", len(e.Remaining), truncateBytes(e.Remaining, maxErrorBytes))
+
+		if len(e.Remaining) > maxErrorBytes {
+			sb.WriteString("...")
+		}
+
+		sb.WriteString(")")
+	}
+
+	fmt.Fprintf(&sb, ": %s", e.Err)
+
+	return sb.String()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Scope is returned by Decoder.Enter and marks the decoding of a value at a path segment.
+type Scope struct {
+	decoder  Decoder
+	segment  PathSegment
+	start    int
+	traceIdx int
+}
+
+// Enter marks the start of the decoding of the value at the provided path segment, which must be ended by calling
+// Exit on the returned Scope with the decoding error, if any. The type name is only used for the trace of the
+// decoder, see WithTrace.
+//
+// Types that implement Decodeable can use it to add the path of their fields to the decoding errors:
+//
+//	scope := decoder.Enter(scale.NameSegment("amount"), "u128")
+//	err := decoder.Decode(&t.Amount)
+//	if err = scope.Exit(err); err != nil {
+//		return err
+//	}
+func (pd Decoder) Enter(segment PathSegment, typeName string) Scope {
+	scope := Scope{
+		decoder: pd,
+		segment: segment,
+		start:   pd.Offset(),
+	}
+
+	if pd.trace != nil {
+		scope.traceIdx = pd.trace.enter(segment, typeName, scope.start)
+	}
+
+	return scope
+}
+
+// Exit ends the decoding of the value of the scope. If err is not nil, it returns a DecodeError that includes the
+// path segment of the scope.
+func (s Scope) Exit(err error) error {
+	wrapped := s.decoder.wrapError(err, s.segment, s.start)
+
+	if s.decoder.trace != nil {
+		s.decoder.trace.exit(s, err)
+	}
+
+	return wrapped
+}
+
+// wrapError adds the segment to the path of the DecodeError in err, or returns a new DecodeError for the segment that
+// starts at the provided offset.
+func (pd Decoder) wrapError(err error, segment PathSegment, start int) error {
+	if err == nil || segment.IsEmpty() {
+		return err
+	}
+
+	var decodeErr *DecodeError
+
+	if errors.As(err, &decodeErr) {
+		decodeErr.Path = append(Path{segment}, decodeErr.Path...)
+
+		return err
+	}
+
+	return &DecodeError{
+		Path:      Path{segment},
+		Offset:    start,
+		Remaining: pd.remainingFrom(start),
+		Err:       err,
+	}
+}
+
+// decodeSegment decodes the target at the path segment using the plan.
+func (pd Decoder) decodeSegment(segment PathSegment, typeName string, plan decodePlan, target reflect.Value) error {
+	if pd.trace != nil {
+		scope := pd.Enter(segment, typeName)
+
+		return scope.Exit(plan(pd, target))
+	}
+
+	start := pd.Offset()
+
+	if err := plan(pd, target); err != nil {
+		return pd.wrapError(err, segment, start)
+	}
+
+	return nil
+}
+
+func truncateBytes(b []byte, maxLen int) []byte {
+	if len(b) > maxLen {
+		return b[:maxLen]
+	}
+
+	return b
+}
@@ -0,0 +1,230 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTransfer struct {
+	From   [4]uint8
+	Amount uint64
+}
+
+type testEvent struct {
+	Index     uint32
+	Transfers []testTransfer
+}
+
+// testDecodeableAmount decodes its fields with scopes to add them to the path of decoding errors.
+type testDecodeableAmount struct {
+	Value uint32
+}
+
+func (a *testDecodeableAmount) Decode(decoder Decoder) error {
+	scope := decoder.Enter(NameSegment("value"), "uint32")
+
+	return scope.Exit(decoder.Decode(&a.Value))
+}
+
+func getTestEventBytes(t *testing.T) []byte {
+	var buffer bytes.Buffer
+
+	err := NewEncoder(&buffer).Encode(testEvent{
+		Index: 3,
+		Transfers: []testTransfer{
+			{From: [4]uint8{1, 2, 3, 4}, Amount: 5},
+			{From: [4]uint8{6, 7, 8, 9}, Amount: 10},
+		},
+	})
+	assert.NoError(t, err)
+
+	return buffer.Bytes()
+}
+
+func TestPath_String(t *testing.T) {
+	var tests = []struct {
+		Name     string
+		Path     Path
+		Expected string
+	}{
+		{
+			Name:     "empty",
+			Path:     nil,
+			Expected: "",
+		},
+		{
+			Name:     "names",
+			Path:     Path{NameSegment("Balances"), NameSegment("Transfer"), NameSegment("amount")},
+			Expected: "Balances.Transfer.amount",
+		},
+		{
+			Name:     "indices",
+			Path:     Path{NameSegment("items"), IndexSegment(1), IndexSegment(2)},
+			Expected: "items[1][2]",
+		},
+		{
+			Name: "named index",
+			Path: Path{
+				{Name: "Event", Index: 3, HasIndex: true},
+				NameSegment("Balances"),
+				NameSegment("Transfer"),
+				NameSegment("amount"),
+			},
+			Expected: "Event[3].Balances.Transfer.amount",
+		},
+		{
+			Name:     "leading index",
+			Path:     Path{IndexSegment(0), NameSegment("amount")},
+			Expected: "[0].amount",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, test.Path.String())
+		})
+	}
+}
+
+func TestDecodeError_Error(t *testing.T) {
+	var tests = []struct {
+		Name     string
+		Err      *DecodeError
+		Expected string
+	}{
+		{
+			Name:     "reader",
+			Err:      &DecodeError{Path: Path{NameSegment("amount")}, Offset: 4, Err: io.EOF},
+			Expected: "decoding amount at offset 4: EOF",
+		},
+		{
+			Name:     "no remaining bytes",
+			Err:      &DecodeError{Path: Path{NameSegment("amount")}, Offset: 4, Remaining: []byte{}, Err: io.EOF},
+			Expected: "decoding amount at offset 4 (no remaining bytes): EOF",
+		},
+		{
+			Name:     "remaining bytes",
+			Err:      &DecodeError{Path: Path{IndexSegment(1)}, Offset: 0, Remaining: []byte{1, 2}, Err: io.EOF},
+			Expected: "decoding [1] at offset 0 (2 remaining bytes: 0x0102): EOF",
+		},
+		{
+			Name:     "truncated remaining bytes",
+			Err:      &DecodeError{Path: Path{IndexSegment(1)}, Offset: 0, Remaining: make([]byte, 33), Err: io.EOF},
+			Expected: "decoding [1] at offset 0 (33 remaining bytes: 0x" + string(bytes.Repeat([]byte("00"), 32)) + "...): EOF",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.EqualError(t, test.Err, test.Expected)
+			assert.ErrorIs(t, test.Err, io.EOF)
+		})
+	}
+}
+
+func TestDecoder_Decode_DecodeError(t *testing.T) {
+	encoded := getTestEventBytes(t)
+	truncated := encoded[:len(encoded)-3]
+
+	var tests = []struct {
+		Name              string
+		Decoder           *Decoder
+		ExpectedRemaining []byte
+	}{
+		{
+			Name:              "bytes",
+			Decoder:           NewDecoderFromBytes(truncated),
+			ExpectedRemaining: truncated[21:],
+		},
+		{
+			Name:    "reader",
+			Decoder: NewDecoder(bytes.NewReader(truncated)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var event testEvent
+
+			err := test.Decoder.Decode(&event)
+
+			var decodeErr *DecodeError
+
+			assert.True(t, errors.As(err, &decodeErr))
+			assert.Equal(t, "Transfers[1].Amount", decodeErr.Path.String())
+			assert.Equal(t, 21, decodeErr.Offset)
+			assert.Equal(t, test.ExpectedRemaining, decodeErr.Remaining)
+			assert.EqualError(t, decodeErr.Err, "expected more bytes, but could not decode any more")
+		})
+	}
+}
+
+func TestDecoder_Offset(t *testing.T) {
+	encoded := getTestEventBytes(t)
+
+	var tests = []struct {
+		Name              string
+		Decoder           *Decoder
+		ExpectedRemaining []byte
+	}{
+		{
+			Name:              "bytes",
+			Decoder:           NewDecoderFromBytes(encoded),
+			ExpectedRemaining: encoded[4:],
+		},
+		{
+			Name:    "reader",
+			Decoder: NewDecoder(bytes.NewReader(encoded)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var index uint32
+
+			assert.NoError(t, test.Decoder.Decode(&index))
+			assert.Equal(t, 4, test.Decoder.Offset())
+			assert.Equal(t, test.ExpectedRemaining, test.Decoder.Remaining())
+
+			var transfers []testTransfer
+
+			assert.NoError(t, test.Decoder.WithValue("key", "value").Decode(&transfers))
+			assert.Equal(t, len(encoded), test.Decoder.Offset())
+		})
+	}
+}
+
+func TestDecoder_Enter(t *testing.T) {
+	var amounts []testDecodeableAmount
+
+	err := NewDecoderFromBytes([]byte{8, 1, 0, 0, 0, 2, 0}).Decode(&amounts)
+
+	var decodeErr *DecodeError
+
+	assert.True(t, errors.As(err, &decodeErr))
+	assert.Equal(t, "[1].value", decodeErr.Path.String())
+	assert.Equal(t, 5, decodeErr.Offset)
+	assert.Equal(t, []byte{2, 0}, decodeErr.Remaining)
+
+	scope := NewDecoderFromBytes(nil).Enter(PathSegment{}, "")
+	assert.Equal(t, io.EOF, scope.Exit(io.EOF))
+	assert.NoError(t, scope.Exit(nil))
+}
@@ -0,0 +1,441 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sync"
+)
+
+var (
+	decodeableType = reflect.TypeOf((*Decodeable)(nil)).Elem()
+
+	// decodePlans holds the decodePlan of every type that was decoded so far.
+	decodePlans sync.Map
+)
+
+// decodePlan decodes a value of a specific type into the settable target. Plans are derived once per type,
+// so that the reflection type switches are not repeated for every decoded value.
+type decodePlan func(pd Decoder, target reflect.Value) error
+
+func getDecodePlan(t reflect.Type) decodePlan {
+	if plan, ok := decodePlans.Load(t); ok {
+		return plan.(decodePlan)
+	}
+
+	// Recursive types need their own plan while it is being built, so a plan that waits for the final one is stored
+	// first, similar to encoding/json.
+	var (
+		wg   sync.WaitGroup
+		plan decodePlan
+	)
+
+	wg.Add(1)
+
+	stored, loaded := decodePlans.LoadOrStore(t, decodePlan(func(pd Decoder, target reflect.Value) error {
+		wg.Wait()
+
+		return plan(pd, target)
+	}))
+
+	if loaded {
+		return stored.(decodePlan)
+	}
+
+	plan = newDecodePlan(t)
+
+	wg.Done()
+
+	decodePlans.Store(t, plan)
+
+	return plan
+}
+
+func newDecodePlan(t reflect.Type) decodePlan {
+	// If the type implements decodeable, use that implementation
+	if reflect.PointerTo(t).Implements(decodeableType) {
+		return newDecodeablePlan(t)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return decodeBool
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return newDecodeIntPlan(int(t.Size()))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return newDecodeUintPlan(int(t.Size()))
+	case reflect.Float32:
+		return decodeFloat32
+	case reflect.Float64:
+		return decodeFloat64
+	case reflect.Int, reflect.Uint, reflect.Uintptr:
+		// Not fixed-size, binary.Read returns the appropriate error.
+		return decodeBinary
+
+	// If you want to replicate Option<T> behavior in Rust, see OptionBool and an
+	// example type OptionInt8 in tests.
+	case reflect.Ptr:
+		return newDecodePointerPlan(t)
+
+	// Arrays: derive the length from the array length
+	case reflect.Array:
+		return newDecodeArrayPlan(t)
+
+	// Slices: first compact-encode length, then each item individually
+	case reflect.Slice:
+		return newDecodeSlicePlan(t)
+
+	// Strings are encoded as UTF-8 byte slices, just as in Rust
+	case reflect.String:
+		return decodeString
+
+	case reflect.Struct:
+		return newDecodeStructPlan(t)
+
+	// Currently unsupported types
+	default:
+		return func(Decoder, reflect.Value) error {
+			return fmt.Errorf("Type %s cannot be decoded", t.Kind())
+		}
+	}
+}
+
+func newDecodeablePlan(t reflect.Type) decodePlan {
+	isSlice := t.Kind() == reflect.Slice
+
+	return func(pd Decoder, target reflect.Value) error {
+		// Decodeable implementations start from an empty value, or from a slice with the length of the target. They
+		// decode into a temporary value, so that the target is left untouched if decoding fails.
+		holder := reflect.New(t)
+
+		if isSlice {
+			holder.Elem().Set(reflect.MakeSlice(t, target.Len(), target.Len()))
+		}
+
+		if err := holder.Interface().(Decodeable).Decode(pd); err != nil {
+			return err
+		}
+
+		target.Set(holder.Elem())
+
+		return nil
+	}
+}
+
+func decodeBool(pd Decoder, target reflect.Value) error {
+	b, err := pd.next(1)
+	if err != nil {
+		return eofError(err)
+	}
+
+	target.SetBool(b[0] != 0)
+
+	return nil
+}
+
+func newDecodeIntPlan(size int) decodePlan {
+	return func(pd Decoder, target reflect.Value) error {
+		b, err := pd.next(size)
+		if err != nil {
+			return eofError(err)
+		}
+
+		switch size {
+		case 1:
+			target.SetInt(int64(int8(b[0])))
+		case 2:
+			target.SetInt(int64(int16(binary.LittleEndian.Uint16(b))))
+		case 4:
+			target.SetInt(int64(int32(binary.LittleEndian.Uint32(b))))
+		default:
+			target.SetInt(int64(binary.LittleEndian.Uint64(b)))
+		}
+
+		return nil
+	}
+}
+
+func newDecodeUintPlan(size int) decodePlan {
+	return func(pd Decoder, target reflect.Value) error {
+		b, err := pd.next(size)
+		if err != nil {
+			return eofError(err)
+		}
+
+		switch size {
+		case 1:
+			target.SetUint(uint64(b[0]))
+		case 2:
+			target.SetUint(uint64(binary.LittleEndian.Uint16(b)))
+		case 4:
+			target.SetUint(uint64(binary.LittleEndian.Uint32(b)))
+		default:
+			target.SetUint(binary.LittleEndian.Uint64(b))
+		}
+
+		return nil
+	}
+}
+
+func decodeFloat32(pd Decoder, target reflect.Value) error {
+	b, err := pd.next(4)
+	if err != nil {
+		return eofError(err)
+	}
+
+	target.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(b))))
+
+	return nil
+}
+
+func decodeFloat64(pd Decoder, target reflect.Value) error {
+	b, err := pd.next(8)
+	if err != nil {
+		return eofError(err)
+	}
+
+	target.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+
+	return nil
+}
+
+func decodeBinary(pd Decoder, target reflect.Value) error {
+	holder := reflect.New(target.Type())
+
+	if err := binary.Read(pd.reader, binary.LittleEndian, holder.Interface()); err != nil {
+		return eofError(err)
+	}
+
+	target.Set(holder.Elem())
+
+	return nil
+}
+
+func newDecodePointerPlan(t reflect.Type) decodePlan {
+	elemType := t.Elem()
+	elemPlan := getDecodePlan(elemType)
+
+	return func(pd Decoder, target reflect.Value) error {
+		if target.IsNil() {
+			target.Set(reflect.New(elemType))
+		}
+
+		return elemPlan(pd, target.Elem())
+	}
+}
+
+func newDecodeArrayPlan(t reflect.Type) decodePlan {
+	elemPlan := getDecodePlan(t.Elem())
+	elemTypeName := t.Elem().String()
+	isBytes := isPlainByteType(t.Elem())
+	targetLen := t.Len()
+
+	return func(pd Decoder, target reflect.Value) error {
+		if isBytes && target.CanAddr() {
+			return eofError(pd.readFull(target.Bytes()))
+		}
+
+		for i := 0; i < targetLen; i++ {
+			if err := pd.decodeSegment(IndexSegment(i), elemTypeName, elemPlan, target.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func newDecodeSlicePlan(t reflect.Type) decodePlan {
+	elemPlan := getDecodePlan(t.Elem())
+	elemTypeName := t.Elem().String()
+	isBytes := isPlainByteType(t.Elem())
+
+	return func(pd Decoder, target reflect.Value) error {
+		codedLen, err := pd.decodeLength()
+		if err != nil {
+			return err
+		}
+
+		if isBytes && pd.source != nil && codedLen > pd.source.remaining() {
+			return errors.New("expected more bytes, but could not decode any more")
+		}
+
+		if codedLen != target.Len() {
+			if codedLen > target.Cap() {
+				target.Set(reflect.MakeSlice(t, codedLen, codedLen))
+			} else {
+				target.SetLen(codedLen)
+			}
+		}
+
+		if isBytes {
+			return eofError(pd.readFull(target.Bytes()))
+		}
+
+		for i := 0; i < codedLen; i++ {
+			if err := pd.decodeSegment(IndexSegment(i), elemTypeName, elemPlan, target.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func decodeString(pd Decoder, target reflect.Value) error {
+	codedLen, err := pd.decodeLength()
+	if err != nil {
+		return err
+	}
+
+	b, err := pd.next(codedLen)
+	if err != nil {
+		return eofError(err)
+	}
+
+	target.SetString(string(b))
+
+	return nil
+}
+
+type structFieldDecodePlan struct {
+	index    int
+	segment  PathSegment
+	typeName string
+	plan     decodePlan
+}
+
+func newDecodeStructPlan(t reflect.Type) decodePlan {
+	var fields []structFieldDecodePlan
+
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+
+		if tv, ok := ft.Tag.Lookup("scale"); ok && tv == "-" {
+			continue
+		}
+
+		field := structFieldDecodePlan{index: i, segment: NameSegment(ft.Name), typeName: ft.Type.String()}
+
+		if ft.IsExported() {
+			field.plan = getDecodePlan(ft.Type)
+		} else {
+			field.plan = decodeUnexported
+		}
+
+		fields = append(fields, field)
+	}
+
+	return func(pd Decoder, target reflect.Value) error {
+		for _, field := range fields {
+			err := pd.decodeSegment(field.segment, field.typeName, field.plan, target.Field(field.index))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func decodeUnexported(_ Decoder, target reflect.Value) error {
+	return fmt.Errorf("Unsettable value %v", target.Type())
+}
+
+// decodeLength decodes the compact-encoded length of a collection.
+func (pd Decoder) decodeLength() (int, error) {
+	b, err := pd.ReadOneByte()
+	if err != nil {
+		return 0, err
+	}
+
+	var codedLen uint64
+
+	if b&3 != 3 {
+		codedLen, err = pd.decodeSmallUintCompact(b)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		buf, err := pd.next(int(b>>2) + 4)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, high := range buf[4:] {
+			if high != 0 {
+				return 0, errors.New("Encoded array length is higher than allowed by the protocol (32-bit unsigned integer)")
+			}
+		}
+
+		codedLen = uint64(binary.LittleEndian.Uint32(buf))
+	}
+
+	if codedLen > uint64(maxInt) {
+		return 0, errors.New("Encoded array length is higher than allowed by the platform")
+	}
+
+	return int(codedLen), nil
+}
+
+// decodeSmallUintCompact decodes the compact-encoded integer that starts with b, for the modes that fit into a u32.
+func (pd Decoder) decodeSmallUintCompact(b byte) (uint64, error) {
+	switch b & 3 {
+	case 0:
+		// right shift to remove mode bits
+		return uint64(b >> 2), nil
+	case 1:
+		bb, err := pd.ReadOneByte()
+		if err != nil {
+			return 0, err
+		}
+
+		return uint64(bb)<<6 + uint64(b>>2), nil
+	case 2:
+		buf, err := pd.next(3)
+		if err != nil {
+			return 0, err
+		}
+
+		// value = 32 bits + mode, in little endian order
+		r := uint32(b) | uint32(buf[0])<<8 | uint32(buf[1])<<16 | uint32(buf[2])<<24
+
+		// remove the last 2 mode bits
+		return uint64(r >> 2), nil
+	default:
+		return 0, errors.New("Code should be unreachable")
+	}
+}
+
+// isPlainByteType returns true if the type is a byte that doesn't implement Encodeable or Decodeable, so that
+// collections of it can be read and written at once.
+func isPlainByteType(t reflect.Type) bool {
+	return t.Kind() == reflect.Uint8 &&
+		!t.Implements(encodeableType) &&
+		!reflect.PointerTo(t).Implements(decodeableType)
+}
+
+func eofError(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return errors.New("expected more bytes, but could not decode any more")
+	}
+
+	return err
+}
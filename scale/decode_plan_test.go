@@ -0,0 +1,271 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testNamedByte uint8
+
+type testTree struct {
+	Value    uint16
+	Children []testTree
+	Parent   *testTree `scale:"-"`
+}
+
+type testList struct {
+	Value uint32
+	Next  []*testList
+}
+
+type testUnexported struct {
+	Exported   bool
+	unexported bool
+}
+
+// testPartialDecodeable decodes its fields one by one, so that a failure leaves it half-written.
+type testPartialDecodeable struct {
+	A uint8
+	B uint8
+}
+
+func (p *testPartialDecodeable) Decode(decoder Decoder) error {
+	if err := decoder.Decode(&p.A); err != nil {
+		return err
+	}
+
+	return decoder.Decode(&p.B)
+}
+
+type testPlanStruct struct {
+	Bool    bool
+	I8      int8
+	I16     int16
+	I32     int32
+	I64     int64
+	U8      uint8
+	U16     uint16
+	U32     uint32
+	U64     uint64
+	F32     float32
+	F64     float64
+	Str     string
+	Bytes   []byte
+	Named   [4]testNamedByte
+	Custom  CustomBool
+	Ptr     *uint32
+	Tree    testTree
+	List    []*testList
+	Ignored string `scale:"-"`
+}
+
+func getTestPlanStruct() testPlanStruct {
+	u32 := uint32(7)
+
+	return testPlanStruct{
+		Bool:   true,
+		I8:     math.MinInt8,
+		I16:    -2,
+		I32:    math.MinInt32,
+		I64:    math.MaxInt64,
+		U8:     math.MaxUint8,
+		U16:    1_000,
+		U32:    math.MaxUint32,
+		U64:    math.MaxUint64,
+		F32:    1.5,
+		F64:    -2.25,
+		Str:    "scale",
+		Bytes:  bytes.Repeat([]byte{1}, 100),
+		Named:  [4]testNamedByte{1, 2, 3, 4},
+		Custom: true,
+		Ptr:    &u32,
+		Tree: testTree{
+			Value: 1,
+			Children: []testTree{
+				{Value: 2},
+				{Value: 3, Children: []testTree{{Value: 4}}},
+			},
+		},
+		List: []*testList{{Value: 1, Next: []*testList{{Value: 2}}}},
+	}
+}
+
+func TestDecoder_Decode_Plans(t *testing.T) {
+	value := getTestPlanStruct()
+
+	encoded := encodeToBytes(t, value)
+
+	var decoders = []struct {
+		Name    string
+		Decoder *Decoder
+	}{
+		{Name: "reader", Decoder: NewDecoder(bytes.NewReader(encoded))},
+		{Name: "byte reader", Decoder: NewDecoder(bytes.NewBuffer(encoded))},
+		{Name: "bytes", Decoder: NewDecoderFromBytes(encoded)},
+	}
+
+	for _, test := range decoders {
+		t.Run(test.Name, func(t *testing.T) {
+			var decoded testPlanStruct
+
+			assert.NoError(t, test.Decoder.Decode(&decoded))
+			assert.Equal(t, value, decoded)
+
+			_, err := test.Decoder.ReadOneByte()
+			assert.Error(t, err, "all bytes should be read")
+		})
+	}
+}
+
+func TestDecoder_Decode_ConcurrentPlans(t *testing.T) {
+	type concurrentTree struct {
+		Value    uint8
+		Children []concurrentTree
+	}
+
+	value := concurrentTree{Value: 1, Children: []concurrentTree{{Value: 2}, {Value: 3}}}
+
+	encoded := encodeToBytes(t, value)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			var decoded concurrentTree
+
+			assert.NoError(t, NewDecoderFromBytes(encoded).Decode(&decoded))
+			assert.Equal(t, value, decoded)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestDecoder_Decode_NilPointer(t *testing.T) {
+	var decoded struct {
+		A *uint16
+		B **uint8
+	}
+
+	assert.NoError(t, NewDecoderFromBytes([]byte{1, 2, 3}).Decode(&decoded))
+	assert.Equal(t, uint16(0x0201), *decoded.A)
+	assert.Equal(t, uint8(3), **decoded.B)
+}
+
+func TestDecoder_Decode_ReusesSlice(t *testing.T) {
+	decoded := make([]uint8, 1, 10)
+	backing := decoded[:10]
+
+	assert.NoError(t, NewDecoderFromBytes([]byte{8, 1, 2}).Decode(&decoded))
+	assert.Equal(t, []uint8{1, 2}, decoded)
+	assert.Equal(t, []uint8{1, 2}, backing[:2])
+}
+
+func TestDecoder_Decode_DecodeableError(t *testing.T) {
+	decoded := struct {
+		Partial testPartialDecodeable
+	}{testPartialDecodeable{A: 9, B: 9}}
+
+	// The target is left untouched if decoding fails.
+	assert.Error(t, NewDecoderFromBytes([]byte{1}).Decode(&decoded))
+	assert.Equal(t, testPartialDecodeable{A: 9, B: 9}, decoded.Partial)
+
+	assert.NoError(t, NewDecoderFromBytes([]byte{1, 2}).Decode(&decoded))
+	assert.Equal(t, testPartialDecodeable{A: 1, B: 2}, decoded.Partial)
+}
+
+func TestDecoder_Decode_Errors(t *testing.T) {
+	var tests = []struct {
+		Name          string
+		Input         []byte
+		Target        any
+		ExpectedError string
+	}{
+		{
+			Name:          "missing int bytes",
+			Input:         []byte{1},
+			Target:        new(uint32),
+			ExpectedError: "expected more bytes, but could not decode any more",
+		},
+		{
+			Name:          "missing byte slice bytes",
+			Input:         []byte{0xfe, 0xff, 0xff, 0xff, 1},
+			Target:        new([]byte),
+			ExpectedError: "expected more bytes, but could not decode any more",
+		},
+		{
+			Name:          "missing string bytes",
+			Input:         []byte{8, 1},
+			Target:        new(string),
+			ExpectedError: "expected more bytes, but could not decode any more",
+		},
+		{
+			Name:          "length higher than u32",
+			Input:         []byte{0x07, 0, 0, 0, 0, 1},
+			Target:        new([]uint16),
+			ExpectedError: "Encoded array length is higher than allowed by the protocol (32-bit unsigned integer)",
+		},
+		{
+			Name:          "missing length",
+			Input:         []byte{},
+			Target:        new([]uint16),
+			ExpectedError: "EOF",
+		},
+		{
+			Name:          "unsupported kind",
+			Input:         []byte{0},
+			Target:        new(map[string]string),
+			ExpectedError: "Type map cannot be decoded",
+		},
+		{
+			Name:          "unexported field",
+			Input:         []byte{0},
+			Target:        new(testUnexported),
+			ExpectedError: "decoding unexported at offset 1 (no remaining bytes): Unsettable value bool",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			target := reflect.New(reflect.TypeOf(test.Target).Elem())
+
+			err := NewDecoderFromBytes(test.Input).Decode(target.Interface())
+			assert.EqualError(t, err, test.ExpectedError)
+		})
+	}
+}
+
+func TestDecoder_DecodeUintCompact_Bytes(t *testing.T) {
+	for _, value := range []uint64{0, 63, 64, 16_383, 16_384, 1<<30 - 1, 1 << 30, math.MaxUint32, math.MaxUint64} {
+		var buffer bytes.Buffer
+
+		assert.NoError(t, NewEncoder(&buffer).encodeUint64Compact(value))
+
+		decoded, err := NewDecoderFromBytes(buffer.Bytes()).DecodeUintCompact()
+		assert.NoError(t, err)
+		assert.Equal(t, value, decoded.Uint64())
+	}
+}
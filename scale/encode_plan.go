@@ -0,0 +1,334 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+)
+
+var (
+	encodeableType = reflect.TypeOf((*Encodeable)(nil)).Elem()
+
+	// encodePlans holds the encodePlan of every type that was encoded so far.
+	encodePlans sync.Map
+)
+
+// encodePlan encodes a value of a specific type. Plans are derived once per type, so that the reflection type
+// switches are not repeated for every encoded value.
+type encodePlan func(pe Encoder, value reflect.Value) error
+
+func getEncodePlan(t reflect.Type) encodePlan {
+	if plan, ok := encodePlans.Load(t); ok {
+		return plan.(encodePlan)
+	}
+
+	// Recursive types need their own plan while it is being built, so a plan that waits for the final one is stored
+	// first, similar to encoding/json.
+	var (
+		wg   sync.WaitGroup
+		plan encodePlan
+	)
+
+	wg.Add(1)
+
+	stored, loaded := encodePlans.LoadOrStore(t, encodePlan(func(pe Encoder, value reflect.Value) error {
+		wg.Wait()
+
+		return plan(pe, value)
+	}))
+
+	if loaded {
+		return stored.(encodePlan)
+	}
+
+	plan = newEncodePlan(t)
+
+	wg.Done()
+
+	encodePlans.Store(t, plan)
+
+	return plan
+}
+
+func newEncodePlan(t reflect.Type) encodePlan {
+	// If the type implements encodeable, use that implementation
+	if t.Implements(encodeableType) {
+		return encodeEncodeable
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return encodeBool
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return newEncodeIntPlan(int(t.Size()))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return newEncodeUintPlan(int(t.Size()))
+	case reflect.Float32:
+		return encodeFloat32
+	case reflect.Float64:
+		return encodeFloat64
+	case reflect.Int, reflect.Uint, reflect.Uintptr:
+		// Not fixed-size, binary.Write returns the appropriate error.
+		return encodeBinary
+
+	case reflect.Ptr:
+		return newEncodePointerPlan(t)
+
+	// Arrays: no compact-encoded length prefix
+	case reflect.Array:
+		return newEncodeArrayPlan(t)
+
+	// Slices: first compact-encode length, then each item individually
+	case reflect.Slice:
+		return newEncodeSlicePlan(t)
+
+	// Strings are encoded as UTF-8 byte slices, just as in Rust
+	case reflect.String:
+		return encodeString
+
+	case reflect.Struct:
+		return newEncodeStructPlan(t)
+
+	// Interfaces are encoded using their dynamic value
+	case reflect.Interface:
+		return encodeInterface
+
+	// Currently unsupported types
+	default:
+		return func(Encoder, reflect.Value) error {
+			return fmt.Errorf("Type %s cannot be encoded", t.Kind())
+		}
+	}
+}
+
+func encodeEncodeable(pe Encoder, value reflect.Value) error {
+	return value.Interface().(Encodeable).Encode(pe)
+}
+
+func encodeInterface(pe Encoder, value reflect.Value) error {
+	if value.IsNil() {
+		return errors.New("Encoding nil values not supported; consider using Option type")
+	}
+
+	elem := value.Elem()
+
+	return getEncodePlan(elem.Type())(pe, elem)
+}
+
+func encodeBool(pe Encoder, value reflect.Value) error {
+	if value.Bool() {
+		return pe.PushByte(1)
+	}
+
+	return pe.PushByte(0)
+}
+
+func newEncodeIntPlan(size int) encodePlan {
+	return func(pe Encoder, value reflect.Value) error {
+		return pe.encodeFixedUint(uint64(value.Int()), size)
+	}
+}
+
+func newEncodeUintPlan(size int) encodePlan {
+	return func(pe Encoder, value reflect.Value) error {
+		return pe.encodeFixedUint(value.Uint(), size)
+	}
+}
+
+func encodeFloat32(pe Encoder, value reflect.Value) error {
+	return pe.encodeFixedUint(uint64(math.Float32bits(float32(value.Float()))), 4)
+}
+
+func encodeFloat64(pe Encoder, value reflect.Value) error {
+	return pe.encodeFixedUint(math.Float64bits(value.Float()), 8)
+}
+
+func encodeBinary(pe Encoder, value reflect.Value) error {
+	return binary.Write(pe.writer, binary.LittleEndian, value.Interface())
+}
+
+func newEncodePointerPlan(t reflect.Type) encodePlan {
+	elemPlan := getEncodePlan(t.Elem())
+
+	return func(pe Encoder, value reflect.Value) error {
+		if value.IsNil() {
+			return errors.New("Encoding null pointers not supported; consider using Option type")
+		}
+
+		return elemPlan(pe, value.Elem())
+	}
+}
+
+func newEncodeArrayPlan(t reflect.Type) encodePlan {
+	elemPlan := getEncodePlan(t.Elem())
+	isBytes := isPlainByteType(t.Elem())
+	valueLen := t.Len()
+
+	return func(pe Encoder, value reflect.Value) error {
+		if isBytes {
+			if value.CanAddr() {
+				return pe.Write(value.Bytes())
+			}
+
+			addressable := reflect.New(t).Elem()
+			addressable.Set(value)
+
+			return pe.Write(addressable.Bytes())
+		}
+
+		for i := 0; i < valueLen; i++ {
+			if err := elemPlan(pe, value.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func newEncodeSlicePlan(t reflect.Type) encodePlan {
+	elemPlan := getEncodePlan(t.Elem())
+	isBytes := isPlainByteType(t.Elem())
+
+	return func(pe Encoder, value reflect.Value) error {
+		l := value.Len()
+
+		if err := pe.encodeLength(l); err != nil {
+			return err
+		}
+
+		if isBytes {
+			return pe.Write(value.Bytes())
+		}
+
+		for i := 0; i < l; i++ {
+			if err := elemPlan(pe, value.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func encodeString(pe Encoder, value reflect.Value) error {
+	s := value.String()
+
+	if err := pe.encodeLength(len(s)); err != nil {
+		return err
+	}
+
+	if pe.buffer != nil {
+		_, err := pe.buffer.WriteString(s)
+		return err
+	}
+
+	return pe.Write([]byte(s))
+}
+
+type structFieldEncodePlan struct {
+	index    int
+	exported bool
+	plan     encodePlan
+}
+
+func newEncodeStructPlan(t reflect.Type) encodePlan {
+	var fields []structFieldEncodePlan
+
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+
+		if tv, ok := ft.Tag.Lookup("scale"); ok && tv == "-" {
+			continue
+		}
+
+		field := structFieldEncodePlan{index: i, exported: ft.IsExported()}
+
+		if field.exported {
+			field.plan = getEncodePlan(ft.Type)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return func(pe Encoder, value reflect.Value) error {
+		for _, field := range fields {
+			fieldValue := value.Field(field.index)
+
+			var err error
+
+			if field.exported {
+				err = field.plan(pe, fieldValue)
+			} else {
+				err = fmt.Errorf("Unexported value %v", fieldValue.Type())
+			}
+
+			if err != nil {
+				return fmt.Errorf("type %s does not support Encodeable interface and could not be "+
+					"encoded field by field, error: %v", t, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// encodeFixedUint writes the lowest size bytes of v in little endian order.
+func (pe Encoder) encodeFixedUint(v uint64, size int) error {
+	var buf [8]byte
+
+	binary.LittleEndian.PutUint64(buf[:], v)
+
+	return pe.writeScratch(buf[:size])
+}
+
+// encodeLength writes the compact-encoded length of a collection.
+func (pe Encoder) encodeLength(l int) error {
+	len64 := uint64(l)
+	if len64 > math.MaxUint32 {
+		return errors.New("Attempted to serialize a collection with too many elements.")
+	}
+
+	return pe.encodeUint64Compact(len64)
+}
+
+// encodeUint64Compact writes v using the compact encoding, see EncodeUintCompact.
+func (pe Encoder) encodeUint64Compact(v uint64) error {
+	switch {
+	case v < 1<<6:
+		return pe.PushByte(byte(v) << 2)
+	case v < 1<<14:
+		return pe.encodeFixedUint(v<<2+1, 2)
+	case v < 1<<30:
+		return pe.encodeFixedUint(v<<2+2, 4)
+	}
+
+	var buf [9]byte
+
+	numBytes := 8
+	for numBytes > 4 && v>>(8*(numBytes-1)) == 0 {
+		numBytes--
+	}
+
+	buf[0] = byte(numBytes-4)<<2 + 3
+	binary.LittleEndian.PutUint64(buf[1:], v)
+
+	return pe.writeScratch(buf[:numBytes+1])
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoder_Encode_Plans(t *testing.T) {
+	value := getTestPlanStruct()
+
+	var buffer bytes.Buffer
+
+	// Encoders that don't write to a bytes.Buffer take the generic path.
+	assert.NoError(t, Encoder{writer: &buffer}.Encode(value))
+
+	assert.Equal(t, buffer.Bytes(), encodeToBytes(t, value))
+	assert.Equal(t, buffer.Bytes(), encodeToBytes(t, &value))
+}
+
+func TestEncoder_Encode_Interface(t *testing.T) {
+	value := []any{uint8(1), uint16(2), CustomBool(true), []any{"a"}}
+
+	assert.Equal(t, []byte{16, 1, 2, 0, 0x05, 4, 4, 'a'}, encodeToBytes(t, value))
+}
+
+func TestEncoder_Encode_Errors(t *testing.T) {
+	var tests = []struct {
+		Name          string
+		Value         any
+		ExpectedError string
+	}{
+		{
+			Name:          "nil",
+			Value:         nil,
+			ExpectedError: "Encoding nil values not supported; consider using Option type",
+		},
+		{
+			Name:          "nil interface",
+			Value:         []any{nil},
+			ExpectedError: "Encoding nil values not supported; consider using Option type",
+		},
+		{
+			Name:          "nil pointer",
+			Value:         (*uint8)(nil),
+			ExpectedError: "Encoding null pointers not supported; consider using Option type",
+		},
+		{
+			Name:          "unsupported kind",
+			Value:         map[string]string{},
+			ExpectedError: "Type map cannot be encoded",
+		},
+		{
+			Name:  "unexported field",
+			Value: testUnexported{Exported: true, unexported: true},
+			ExpectedError: "type scale.testUnexported does not support Encodeable interface and could not be " +
+				"encoded field by field, error: Unexported value bool",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := NewEncoder(&bytes.Buffer{}).Encode(test.Value)
+			assert.EqualError(t, err, test.ExpectedError)
+		})
+	}
+}
+
+func TestEncoder_encodeUint64Compact(t *testing.T) {
+	for _, value := range []uint64{0, 63, 64, 16_383, 16_384, 1<<30 - 1, 1 << 30, math.MaxUint32, math.MaxUint64} {
+		var expected, actual bytes.Buffer
+
+		// EncodeUintCompact uses encodeUint64Compact for all uint64 values, so the expected encoding is derived
+		// from the big.Int bytes instead.
+		if value < 1<<30 {
+			assert.NoError(t, NewEncoder(&expected).EncodeUintCompact(*new(big.Int).SetUint64(value)))
+		} else {
+			b := new(big.Int).SetUint64(value).Bytes()
+			Reverse(b)
+			expected.WriteByte(byte(len(b)-4)<<2 + 3)
+			expected.Write(b)
+		}
+
+		assert.NoError(t, NewEncoder(&actual).encodeUint64Compact(value))
+		assert.Equal(t, expected.Bytes(), actual.Bytes(), "value %d", value)
+	}
+}
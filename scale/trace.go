@@ -0,0 +1,195 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Trace records the values that are decoded by a Decoder, see WithTrace. Its String method returns an annotated hex
+// dump of the decoded bytes that helps to find out where the decoding of a value went wrong.
+type Trace struct {
+	// Entries holds the decoded values in the order in which their decoding started.
+	Entries []TraceEntry
+
+	// open holds the indices of the entries that are being decoded.
+	open []int
+	// data holds the bytes read by a decoder that reads from a reader, starting at base.
+	data []byte
+	base int
+}
+
+// TraceEntry is a value that was decoded by a traced Decoder.
+type TraceEntry struct {
+	// Path is the path to the value, it doesn't end with a segment of the value if the value has none.
+	Path   Path
+	Type   string
+	Depth  int
+	Offset int
+	// Bytes holds the bytes of the value, including the bytes of its fields or items.
+	Bytes []byte
+	// Err is the error that occurred while decoding the value. It is only set for the innermost value that failed.
+	Err error
+
+	named bool
+}
+
+// WithTrace returns a copy of the decoder that records the values it decodes in the provided trace.
+//
+// Tracing slows down decoding considerably and should only be used for debugging.
+func (pd Decoder) WithTrace(trace *Trace) *Decoder {
+	trace.base = pd.Offset()
+	pd.trace = trace
+
+	return &pd
+}
+
+func (t *Trace) enter(segment PathSegment, typeName string, start int) int {
+	var parent *TraceEntry
+
+	if len(t.open) > 0 {
+		parentIdx := t.open[len(t.open)-1]
+		parent = &t.Entries[parentIdx]
+
+		// A value without a path segment that starts together with its parent and has the same type is the parent
+		// itself, e.g. when a Decodeable field decodes itself by calling Decode. Parents without a type, such as the
+		// fields of the registry decoders, take the type of the value.
+		if segment.IsEmpty() && parentIdx == len(t.Entries)-1 && parent.Offset == start &&
+			(parent.Type == typeName || parent.Type == "") {
+			parent.Type = typeName
+
+			return -1
+		}
+	}
+
+	var path Path
+
+	if parent != nil {
+		path = append(path, parent.Path...)
+	}
+
+	if !segment.IsEmpty() {
+		path = append(path, segment)
+	}
+
+	t.Entries = append(t.Entries, TraceEntry{
+		Path:   path,
+		Type:   typeName,
+		Depth:  len(t.open),
+		Offset: start,
+		named:  !segment.IsEmpty(),
+	})
+
+	idx := len(t.Entries) - 1
+	t.open = append(t.open, idx)
+
+	return idx
+}
+
+func (t *Trace) exit(scope Scope, err error) {
+	if scope.traceIdx < 0 {
+		return
+	}
+
+	t.open = t.open[:len(t.open)-1]
+
+	entry := &t.Entries[scope.traceIdx]
+	entry.Bytes = t.bytes(scope.decoder, entry.Offset, scope.decoder.Offset())
+
+	if err == nil {
+		return
+	}
+
+	for _, child := range t.Entries[scope.traceIdx+1:] {
+		if child.Err != nil {
+			return
+		}
+	}
+
+	var decodeErr *DecodeError
+
+	if errors.As(err, &decodeErr) {
+		err = decodeErr.Err
+	}
+
+	entry.Err = err
+}
+
+func (t *Trace) bytes(decoder Decoder, start, end int) []byte {
+	if decoder.source != nil {
+		return decoder.source.buf[start:end]
+	}
+
+	start -= t.base
+	end -= t.base
+
+	if start < 0 || end > len(t.data) || start > end {
+		return nil
+	}
+
+	return t.data[start:end]
+}
+
+// String returns an annotated hex dump of the decoded values, one value per line and indented by depth. Values with
+// fields or items are shown with their size and the bytes that precede their first field or item, such as the length
+// of a slice. Other values are shown with their bytes.
+func (t *Trace) String() string {
+	var sb strings.Builder
+
+	for i, entry := range t.Entries {
+		own := entry.Bytes
+		composite := i+1 < len(t.Entries) && t.Entries[i+1].Depth > entry.Depth
+
+		if composite {
+			own = own[:min(len(own), t.Entries[i+1].Offset-entry.Offset)]
+		}
+
+		fmt.Fprintf(&sb, "%8d  %s", entry.Offset, strings.Repeat("  ", entry.Depth))
+
+		label := entry.Type
+
+		if entry.named {
+			label = entry.Path[len(entry.Path)-1].String()
+
+			if entry.Type != "" {
+				label += ": " + entry.Type
+			}
+		}
+
+		sb.WriteString(label)
+
+		if composite {
+			fmt.Fprintf(&sb, " (%d bytes)", len(entry.Bytes))
+		}
+
+		if len(own) > 0 {
+			fmt.Fprintf(&sb, " %#x", truncateBytes(own, maxErrorBytes))
+
+			if len(own) > maxErrorBytes {
+				fmt.Fprintf(&sb, "... (%d bytes)", len(own))
+			}
+		}
+
+		if entry.Err != nil {
+			fmt.Fprintf(&sb, " <- error: %s", entry.Err)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoder_WithTrace(t *testing.T) {
+	encoded := getTestEventBytes(t)
+	truncated := encoded[:len(encoded)-3]
+
+	expected := "" +
+		"       0  scale.testEvent (26 bytes)\n" +
+		"       0    Index: uint32 0x03000000\n" +
+		"       4    Transfers: []scale.testTransfer (22 bytes) 0x08\n" +
+		"       5      [0]: scale.testTransfer (12 bytes)\n" +
+		"       5        From: [4]uint8 0x01020304\n" +
+		"       9        Amount: uint64 0x0500000000000000\n" +
+		"      17      [1]: scale.testTransfer (9 bytes)\n" +
+		"      17        From: [4]uint8 0x06070809\n" +
+		"      21        Amount: uint64 0x0a00000000 <- error: expected more bytes, but could not decode any more\n"
+
+	var tests = []struct {
+		Name    string
+		Decoder *Decoder
+	}{
+		{
+			Name:    "bytes",
+			Decoder: NewDecoderFromBytes(truncated),
+		},
+		{
+			Name:    "reader",
+			Decoder: NewDecoder(bytes.NewReader(truncated)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				trace Trace
+				event testEvent
+			)
+
+			assert.Error(t, test.Decoder.WithTrace(&trace).Decode(&event))
+			assert.Equal(t, expected, trace.String())
+
+			assert.Len(t, trace.Entries, 9)
+			assert.Equal(t, "Transfers[1].Amount", trace.Entries[8].Path.String())
+			assert.Equal(t, 3, trace.Entries[8].Depth)
+			assert.Nil(t, trace.Entries[7].Err)
+			assert.Equal(t, []byte{6, 7, 8, 9}, trace.Entries[7].Bytes)
+		})
+	}
+}
+
+func TestDecoder_WithTrace_Decodeable(t *testing.T) {
+	var (
+		trace   Trace
+		amounts []testDecodeableAmount
+	)
+
+	decoder := NewDecoderFromBytes([]byte{0xff, 4, 1, 0, 0, 0})
+
+	_, err := decoder.ReadOneByte()
+	assert.NoError(t, err)
+
+	assert.NoError(t, decoder.WithTrace(&trace).Decode(&amounts))
+	assert.Equal(t, ""+
+		"       1  []scale.testDecodeableAmount (5 bytes) 0x04\n"+
+		"       2    [0]: scale.testDecodeableAmount (4 bytes)\n"+
+		"       2      value: uint32 0x01000000\n", trace.String())
+}
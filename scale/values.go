@@ -0,0 +1,85 @@
+// Copyright 2018 Jsgenesis
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import "io"
+
+// values is an immutable list of key-value pairs that is carried by an Encoder or Decoder and passed along to the
+// Encodeable and Decodeable types, so that their encoding can depend on options that are specific to a chain.
+type values struct {
+	parent *values
+	key    any
+	value  any
+}
+
+func (v *values) with(key, value any) *values {
+	return &values{parent: v, key: key, value: value}
+}
+
+func (v *values) get(key any) any {
+	for ; v != nil; v = v.parent {
+		if v.key == key {
+			return v.value
+		}
+	}
+
+	return nil
+}
+
+// WithValue returns a copy of the encoder that carries the provided value for the provided key. The value can be
+// retrieved by the Encodeable types using Value. Similar to context.WithValue, the key should be of an unexported
+// type to avoid collisions between packages.
+func (pe Encoder) WithValue(key, value any) *Encoder {
+	pe.values = pe.values.with(key, value)
+
+	return &pe
+}
+
+// Value returns the value carried by the encoder for the provided key, or nil if there is none.
+func (pe Encoder) Value(key any) any {
+	return pe.values.get(key)
+}
+
+// WithValue returns a copy of the decoder that carries the provided value for the provided key. The value can be
+// retrieved by the Decodeable types using Value. Similar to context.WithValue, the key should be of an unexported
+// type to avoid collisions between packages.
+func (pd Decoder) WithValue(key, value any) *Decoder {
+	pd.values = pd.values.with(key, value)
+
+	return &pd
+}
+
+// Value returns the value carried by the decoder for the provided key, or nil if there is none.
+func (pd Decoder) Value(key any) any {
+	return pd.values.get(key)
+}
+
+// WithWriter returns a new encoder that writes to the provided writer and carries the values of the encoder. It is
+// used by the Encodeable types that need to encode a part of their data separately, e.g. to prefix it with its length.
+func (pe Encoder) WithWriter(writer io.Writer) *Encoder {
+	enc := NewEncoder(writer)
+	enc.values = pe.values
+
+	return enc
+}
+
+// WithBytes returns a new decoder that reads from the provided bytes and carries the values of the decoder. It is
+// used by the Decodeable types that need to decode a part of their data separately.
+func (pd Decoder) WithBytes(bz []byte) *Decoder {
+	dec := NewDecoderFromBytes(bz)
+	dec.values = pd.values
+
+	return dec
+}
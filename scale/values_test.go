@@ -0,0 +1,127 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testValueKey struct{}
+
+type testValueKeyOther struct{}
+
+// valueAwareByte is encoded as 1 byte, or as 2 bytes if the testValueKey value is true.
+type valueAwareByte byte
+
+func (v valueAwareByte) Encode(encoder Encoder) error {
+	if wide, _ := encoder.Value(testValueKey{}).(bool); wide {
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+	}
+
+	return encoder.PushByte(byte(v))
+}
+
+func (v *valueAwareByte) Decode(decoder Decoder) error {
+	if wide, _ := decoder.Value(testValueKey{}).(bool); wide {
+		if _, err := decoder.ReadOneByte(); err != nil {
+			return err
+		}
+	}
+
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	*v = valueAwareByte(b)
+
+	return nil
+}
+
+type valueAwareStruct struct {
+	A valueAwareByte
+	B []valueAwareByte
+}
+
+func TestEncoder_WithValue(t *testing.T) {
+	value := valueAwareStruct{A: 1, B: []valueAwareByte{2, 3}}
+
+	var buf bytes.Buffer
+
+	assert.NoError(t, NewEncoder(&buf).Encode(value))
+	assert.Equal(t, []byte{1, 8, 2, 3}, buf.Bytes())
+
+	buf.Reset()
+
+	encoder := NewEncoder(&buf).WithValue(testValueKey{}, true)
+
+	assert.NoError(t, encoder.Encode(value))
+	assert.Equal(t, []byte{0, 1, 8, 0, 2, 0, 3}, buf.Bytes())
+}
+
+func TestDecoder_WithValue(t *testing.T) {
+	var value valueAwareStruct
+
+	decoder := NewDecoder(bytes.NewReader([]byte{0, 1, 8, 0, 2, 0, 3})).WithValue(testValueKey{}, true)
+
+	assert.NoError(t, decoder.Decode(&value))
+	assert.Equal(t, valueAwareStruct{A: 1, B: []valueAwareByte{2, 3}}, value)
+}
+
+func TestEncoder_Value(t *testing.T) {
+	encoder := NewEncoder(&bytes.Buffer{})
+	assert.Nil(t, encoder.Value(testValueKey{}))
+
+	withValue := encoder.WithValue(testValueKey{}, 1)
+	withOverride := withValue.WithValue(testValueKey{}, 2).WithValue(testValueKeyOther{}, 3)
+
+	assert.Nil(t, encoder.Value(testValueKey{}))
+	assert.Equal(t, 1, withValue.Value(testValueKey{}))
+	assert.Nil(t, withValue.Value(testValueKeyOther{}))
+	assert.Equal(t, 2, withOverride.Value(testValueKey{}))
+	assert.Equal(t, 3, withOverride.Value(testValueKeyOther{}))
+}
+
+func TestEncoder_WithWriter(t *testing.T) {
+	encoder := NewEncoder(&bytes.Buffer{}).WithValue(testValueKey{}, true)
+
+	var buf bytes.Buffer
+
+	child := encoder.WithWriter(&buf)
+
+	assert.NoError(t, child.Encode(valueAwareByte(1)))
+	assert.Equal(t, []byte{0, 1}, buf.Bytes())
+	assert.Equal(t, true, child.Value(testValueKey{}))
+}
+
+func TestDecoder_WithBytes(t *testing.T) {
+	decoder := NewDecoderFromBytes(nil).WithValue(testValueKey{}, true)
+
+	child := decoder.WithBytes([]byte{0, 1})
+
+	var value valueAwareByte
+
+	assert.NoError(t, child.Decode(&value))
+	assert.Equal(t, valueAwareByte(1), value)
+	assert.Equal(t, 2, child.Offset())
+	assert.Equal(t, true, child.Value(testValueKey{}))
+}
@@ -69,7 +69,7 @@ func (a *Address) Decode(decoder scale.Decoder) error {
 		return err
 	}
 
-	if defaultOptions.NoPalletIndices {
+	if getSerDeOptions(decoder).NoPalletIndices {
 		var sm [31]byte // Reading Address[32] minus b already read
 		err = decoder.Decode(&sm)
 		if err != nil {
@@ -116,7 +116,7 @@ func (a *Address) Decode(decoder scale.Decoder) error {
 func (a Address) Encode(encoder scale.Encoder) error {
 	// type of address - public key
 	if a.IsAccountID {
-		if !defaultOptions.NoPalletIndices { // Skip in case target chain doesn't include indices pallet
+		if !getSerDeOptions(encoder).NoPalletIndices { // Skip in case target chain doesn't include indices pallet
 			err := encoder.PushByte(255)
 			if err != nil {
 				return err
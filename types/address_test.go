@@ -112,6 +112,20 @@ func TestAddress_EncodeWithOptions(t *testing.T) {
 	})
 }
 
+func TestAddress_EncodeWithSerDeOptions(t *testing.T) {
+	// The options of the encoder take precedence over the default ones.
+	SetSerDeOptions(SerDeOptions{NoPalletIndices: true})
+	defer SetSerDeOptions(SerDeOptions{NoPalletIndices: false})
+
+	enc, err := EncodeWithOptions(newTestAddress(), SerDeOptions{NoPalletIndices: false})
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecodeString("0xff0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f0b"), enc)
+
+	enc, err = EncodeWithOptions(newTestAddress(), SerDeOptions{NoPalletIndices: true})
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecodeString("0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f0b"), enc)
+}
+
 func TestAddress_Decode(t *testing.T) {
 	AssertDecode(t, []DecodingAssert{
 		{MustHexDecodeString("0xff0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f0b"),
@@ -147,3 +161,21 @@ func TestAddress_DecodeWithOptions(t *testing.T) {
 		},
 	})
 }
+
+func TestAddress_DecodeWithSerDeOptions(t *testing.T) {
+	var noIndices Address
+
+	err := DecodeWithOptions(
+		MustHexDecodeString("0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f0b"),
+		&noIndices,
+		SerDeOptions{NoPalletIndices: true},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, newTestAddress(), noIndices)
+
+	var withIndices Address
+
+	err = DecodeWithOptions([]byte{23}, &withIndices, SerDeOptions{NoPalletIndices: false})
+	assert.NoError(t, err)
+	assert.Equal(t, NewAddressFromAccountIndex(uint32(23)), withIndices)
+}
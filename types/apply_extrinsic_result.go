@@ -0,0 +1,263 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// InvalidTransaction describes the reason for which a transaction is invalid.
+type InvalidTransaction struct {
+	// The call of the transaction is not expected
+	IsCall bool
+	// General error to do with the inability to pay some fees (e.g. account balance too low)
+	IsPayment bool
+	// General error to do with the transaction not yet being valid (e.g. nonce too high)
+	IsFuture bool
+	// General error to do with the transaction being outdated (e.g. nonce too low)
+	IsStale bool
+	// General error to do with the transaction's proofs (e.g. signature)
+	IsBadProof bool
+	// The transaction birth block is ancient
+	IsAncientBirthBlock bool
+	// The transaction would exhaust the resources of current block
+	IsExhaustsResources bool
+	// Any other custom invalid validity that is not covered by this enum
+	IsCustom bool
+	AsCustom U8
+	// An extrinsic with a Mandatory dispatch resulted in Error
+	IsBadMandatory bool
+	// An extrinsic with a mandatory dispatch tried to be validated
+	IsMandatoryValidation bool
+	// The sending address is disabled or known to be invalid
+	IsBadSigner bool
+}
+
+func (i *InvalidTransaction) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		i.IsCall = true
+	case 1:
+		i.IsPayment = true
+	case 2:
+		i.IsFuture = true
+	case 3:
+		i.IsStale = true
+	case 4:
+		i.IsBadProof = true
+	case 5:
+		i.IsAncientBirthBlock = true
+	case 6:
+		i.IsExhaustsResources = true
+	case 7:
+		i.IsCustom = true
+
+		return decoder.Decode(&i.AsCustom)
+	case 8:
+		i.IsBadMandatory = true
+	case 9:
+		i.IsMandatoryValidation = true
+	case 10:
+		i.IsBadSigner = true
+	}
+
+	return nil
+}
+
+func (i InvalidTransaction) Encode(encoder scale.Encoder) error { //nolint:funlen
+	switch {
+	case i.IsCall:
+		return encoder.PushByte(0)
+	case i.IsPayment:
+		return encoder.PushByte(1)
+	case i.IsFuture:
+		return encoder.PushByte(2)
+	case i.IsStale:
+		return encoder.PushByte(3)
+	case i.IsBadProof:
+		return encoder.PushByte(4)
+	case i.IsAncientBirthBlock:
+		return encoder.PushByte(5)
+	case i.IsExhaustsResources:
+		return encoder.PushByte(6)
+	case i.IsCustom:
+		if err := encoder.PushByte(7); err != nil {
+			return err
+		}
+
+		return encoder.Encode(i.AsCustom)
+	case i.IsBadMandatory:
+		return encoder.PushByte(8)
+	case i.IsMandatoryValidation:
+		return encoder.PushByte(9)
+	case i.IsBadSigner:
+		return encoder.PushByte(10)
+	}
+
+	return nil
+}
+
+// UnknownTransaction describes the reason for which the validity of a transaction could not be determined.
+type UnknownTransaction struct {
+	// Could not lookup some information that is required to validate the transaction
+	IsCannotLookup bool
+	// No validator found for the given unsigned transaction
+	IsNoUnsignedValidator bool
+	// Any other custom unknown validity that is not covered by this enum
+	IsCustom bool
+	AsCustom U8
+}
+
+func (u *UnknownTransaction) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		u.IsCannotLookup = true
+	case 1:
+		u.IsNoUnsignedValidator = true
+	case 2:
+		u.IsCustom = true
+
+		return decoder.Decode(&u.AsCustom)
+	}
+
+	return nil
+}
+
+func (u UnknownTransaction) Encode(encoder scale.Encoder) error {
+	switch {
+	case u.IsCannotLookup:
+		return encoder.PushByte(0)
+	case u.IsNoUnsignedValidator:
+		return encoder.PushByte(1)
+	case u.IsCustom:
+		if err := encoder.PushByte(2); err != nil {
+			return err
+		}
+
+		return encoder.Encode(u.AsCustom)
+	}
+
+	return nil
+}
+
+// TransactionValidityError is the error that is returned when a transaction is not valid.
+type TransactionValidityError struct {
+	IsInvalid bool
+	AsInvalid InvalidTransaction
+
+	IsUnknown bool
+	AsUnknown UnknownTransaction
+}
+
+func (t *TransactionValidityError) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		t.IsInvalid = true
+
+		return decoder.Decode(&t.AsInvalid)
+	case 1:
+		t.IsUnknown = true
+
+		return decoder.Decode(&t.AsUnknown)
+	}
+
+	return nil
+}
+
+func (t TransactionValidityError) Encode(encoder scale.Encoder) error {
+	switch {
+	case t.IsInvalid:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(t.AsInvalid)
+	case t.IsUnknown:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(t.AsUnknown)
+	}
+
+	return nil
+}
+
+// ApplyExtrinsicResult is the result of applying an extrinsic, as returned by system_dryRun.
+//
+// The outer result holds the validity of the extrinsic, while the inner one holds its dispatch outcome.
+type ApplyExtrinsicResult struct {
+	IsOk bool
+	AsOk DispatchResult
+
+	IsErr bool
+	AsErr TransactionValidityError
+}
+
+func (a *ApplyExtrinsicResult) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		a.IsOk = true
+
+		return decoder.Decode(&a.AsOk)
+	case 1:
+		a.IsErr = true
+
+		return decoder.Decode(&a.AsErr)
+	}
+
+	return nil
+}
+
+func (a ApplyExtrinsicResult) Encode(encoder scale.Encoder) error {
+	switch {
+	case a.IsOk:
+		if err := encoder.PushByte(0); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.AsOk)
+	case a.IsErr:
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(a.AsErr)
+	}
+
+	return nil
+}
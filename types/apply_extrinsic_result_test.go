@@ -0,0 +1,173 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	fuzz "github.com/google/gofuzz"
+)
+
+var (
+	invalidTransactionFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(i *InvalidTransaction, c fuzz.Continue) {
+			switch c.Intn(11) {
+			case 0:
+				i.IsCall = true
+			case 1:
+				i.IsPayment = true
+			case 2:
+				i.IsFuture = true
+			case 3:
+				i.IsStale = true
+			case 4:
+				i.IsBadProof = true
+			case 5:
+				i.IsAncientBirthBlock = true
+			case 6:
+				i.IsExhaustsResources = true
+			case 7:
+				i.IsCustom = true
+
+				c.Fuzz(&i.AsCustom)
+			case 8:
+				i.IsBadMandatory = true
+			case 9:
+				i.IsMandatoryValidation = true
+			case 10:
+				i.IsBadSigner = true
+			}
+		}),
+	}
+
+	unknownTransactionFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(u *UnknownTransaction, c fuzz.Continue) {
+			switch c.Intn(3) {
+			case 0:
+				u.IsCannotLookup = true
+			case 1:
+				u.IsNoUnsignedValidator = true
+			case 2:
+				u.IsCustom = true
+
+				c.Fuzz(&u.AsCustom)
+			}
+		}),
+	}
+
+	transactionValidityErrorFuzzOpts = CombineFuzzOpts(
+		invalidTransactionFuzzOpts,
+		unknownTransactionFuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(t *TransactionValidityError, c fuzz.Continue) {
+				if c.RandBool() {
+					t.IsInvalid = true
+
+					c.Fuzz(&t.AsInvalid)
+
+					return
+				}
+
+				t.IsUnknown = true
+
+				c.Fuzz(&t.AsUnknown)
+			}),
+		},
+	)
+
+	applyExtrinsicResultFuzzOpts = CombineFuzzOpts(
+		dispatchResultFuzzOpts,
+		transactionValidityErrorFuzzOpts,
+		[]FuzzOpt{
+			WithFuzzFuncs(func(a *ApplyExtrinsicResult, c fuzz.Continue) {
+				if c.RandBool() {
+					a.IsOk = true
+
+					c.Fuzz(&a.AsOk)
+
+					return
+				}
+
+				a.IsErr = true
+
+				c.Fuzz(&a.AsErr)
+			}),
+		},
+	)
+)
+
+func TestInvalidTransaction_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[InvalidTransaction](t, 100, invalidTransactionFuzzOpts...)
+	AssertDecodeNilData[InvalidTransaction](t)
+	AssertEncodeEmptyObj[InvalidTransaction](t, 0)
+}
+
+func TestUnknownTransaction_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[UnknownTransaction](t, 100, unknownTransactionFuzzOpts...)
+	AssertDecodeNilData[UnknownTransaction](t)
+	AssertEncodeEmptyObj[UnknownTransaction](t, 0)
+}
+
+func TestTransactionValidityError_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[TransactionValidityError](t, 100, transactionValidityErrorFuzzOpts...)
+	AssertDecodeNilData[TransactionValidityError](t)
+	AssertEncodeEmptyObj[TransactionValidityError](t, 0)
+}
+
+func TestApplyExtrinsicResult_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[ApplyExtrinsicResult](t, 1000, applyExtrinsicResultFuzzOpts...)
+	AssertDecodeNilData[ApplyExtrinsicResult](t)
+	AssertEncodeEmptyObj[ApplyExtrinsicResult](t, 0)
+}
+
+func TestApplyExtrinsicResult_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{
+			Input:    MustHexDecodeString("0x0000"),
+			Expected: ApplyExtrinsicResult{IsOk: true, AsOk: DispatchResult{Ok: true}},
+		},
+		{
+			Input: MustHexDecodeString("0x0001030a0b000000"),
+			Expected: ApplyExtrinsicResult{
+				IsOk: true,
+				AsOk: DispatchResult{
+					Error: DispatchError{
+						IsModule:    true,
+						ModuleError: ModuleError{Index: 10, Error: [4]U8{11, 0, 0, 0}},
+					},
+				},
+			},
+		},
+		{
+			Input: MustHexDecodeString("0x010001"),
+			Expected: ApplyExtrinsicResult{
+				IsErr: true,
+				AsErr: TransactionValidityError{IsInvalid: true, AsInvalid: InvalidTransaction{IsPayment: true}},
+			},
+		},
+		{
+			Input: MustHexDecodeString("0x01010205"),
+			Expected: ApplyExtrinsicResult{
+				IsErr: true,
+				AsErr: TransactionValidityError{IsUnknown: true, AsUnknown: UnknownTransaction{IsCustom: true, AsCustom: 5}},
+			},
+		},
+	})
+}
@@ -0,0 +1,55 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// WeightsPerClass holds the weight limits of a particular dispatch class.
+type WeightsPerClass struct {
+	// BaseExtrinsic is the base weight of a single extrinsic of this class.
+	BaseExtrinsic Weight
+	// MaxExtrinsic is the maximum weight of a single extrinsic of this class.
+	MaxExtrinsic Option[Weight]
+	// MaxTotal is the maximum total weight of the extrinsics of this class in a block.
+	MaxTotal Option[Weight]
+	// Reserved is the block weight that is reserved for the extrinsics of this class.
+	Reserved Option[Weight]
+}
+
+// PerDispatchClassWeightsPerClass holds the WeightsPerClass of each dispatch class.
+type PerDispatchClassWeightsPerClass struct {
+	Normal      WeightsPerClass
+	Operational WeightsPerClass
+	Mandatory   WeightsPerClass
+}
+
+// BlockWeights is the frame_system::limits::BlockWeights that is exposed via the System.BlockWeights constant.
+type BlockWeights struct {
+	BaseBlock Weight
+	MaxBlock  Weight
+	PerClass  PerDispatchClassWeightsPerClass
+}
+
+// PerDispatchClassU32 holds an U32 for each dispatch class.
+type PerDispatchClassU32 struct {
+	Normal      U32
+	Operational U32
+	Mandatory   U32
+}
+
+// BlockLength is the frame_system::limits::BlockLength that is exposed via the System.BlockLength constant.
+type BlockLength struct {
+	Max PerDispatchClassU32
+}
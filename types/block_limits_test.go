@@ -0,0 +1,73 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockWeights_DecodeFromMetadata(t *testing.T) {
+	var metadata Metadata
+
+	err := DecodeFromHex(MetadataV14Data, &metadata)
+	assert.NoError(t, err)
+
+	b, err := metadata.FindConstantValue("System", "BlockWeights")
+	assert.NoError(t, err)
+
+	var blockWeights BlockWeights
+
+	err = Decode(b, &blockWeights)
+	assert.NoError(t, err)
+
+	assert.NotZero(t, blockWeights.MaxBlock.RefTime.Int64())
+
+	ok, maxExtrinsic := blockWeights.PerClass.Normal.MaxExtrinsic.Unwrap()
+	assert.True(t, ok)
+	assert.NotZero(t, maxExtrinsic.RefTime.Int64())
+	assert.LessOrEqual(t, maxExtrinsic.RefTime.Int64(), blockWeights.MaxBlock.RefTime.Int64())
+
+	enc, err := Encode(blockWeights)
+	assert.NoError(t, err)
+	assert.Equal(t, b, enc)
+}
+
+func TestBlockLength_DecodeFromMetadata(t *testing.T) {
+	var metadata Metadata
+
+	err := DecodeFromHex(MetadataV14Data, &metadata)
+	assert.NoError(t, err)
+
+	b, err := metadata.FindConstantValue("System", "BlockLength")
+	assert.NoError(t, err)
+
+	var blockLength BlockLength
+
+	err = Decode(b, &blockLength)
+	assert.NoError(t, err)
+
+	assert.NotZero(t, blockLength.Max.Normal)
+	assert.LessOrEqual(t, blockLength.Max.Normal, blockLength.Max.Mandatory)
+
+	enc, err := Encode(blockLength)
+	assert.NoError(t, err)
+	assert.Equal(t, b, enc)
+}
@@ -0,0 +1,103 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+var (
+	ErrBoundedVecBoundExceeded = errors.New("bounded vec bound exceeded")
+)
+
+// Bound is implemented by the types that define the maximum length of a BoundedVec, similar to the Get<u32> type
+// parameter of the BoundedVec of Substrate, for example:
+//
+//	type MaxMembers struct{}
+//
+//	func (MaxMembers) Bound() int { return 100 }
+type Bound interface {
+	Bound() int
+}
+
+// BoundedVec is the Go representation of the Substrate BoundedVec type, which is encoded as a vector of T that
+// holds at most the number of items defined by B. The bound is enforced when encoding and decoding.
+type BoundedVec[T any, B Bound] []T
+
+// NewBoundedVec creates a BoundedVec that holds the provided items, it returns an error if there are more items
+// than allowed by B.
+func NewBoundedVec[T any, B Bound](items ...T) (BoundedVec[T, B], error) {
+	v := BoundedVec[T, B](items)
+
+	if err := v.checkBound(len(items)); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// MaxLen returns the maximum number of items of the vector.
+func (v BoundedVec[T, B]) MaxLen() int {
+	var b B
+
+	return b.Bound()
+}
+
+func (v BoundedVec[T, B]) checkBound(length int) error {
+	if maxLen := v.MaxLen(); length > maxLen {
+		return fmt.Errorf("%w: length %d, bound %d", ErrBoundedVecBoundExceeded, length, maxLen)
+	}
+
+	return nil
+}
+
+func (v *BoundedVec[T, B]) Decode(decoder scale.Decoder) error {
+	length, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return err
+	}
+
+	// The bound is checked before allocating the items, so that invalid lengths can't exhaust the memory.
+	if !length.IsInt64() || v.checkBound(int(length.Int64())) != nil {
+		return fmt.Errorf("%w: length %v, bound %d", ErrBoundedVecBoundExceeded, length, v.MaxLen())
+	}
+
+	items := make([]T, length.Int64())
+
+	for i := range items {
+		scope := decoder.Enter(scale.IndexSegment(i), "")
+
+		if err := scope.Exit(decoder.Decode(&items[i])); err != nil {
+			return err
+		}
+	}
+
+	*v = items
+
+	return nil
+}
+
+func (v BoundedVec[T, B]) Encode(encoder scale.Encoder) error {
+	if err := v.checkBound(len(v)); err != nil {
+		return err
+	}
+
+	return encoder.Encode([]T(v))
+}
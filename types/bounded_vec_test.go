@@ -0,0 +1,65 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type testMaxLen struct{}
+
+func (testMaxLen) Bound() int { return 2 }
+
+func TestBoundedVec_EncodeDecode(t *testing.T) {
+	v, err := NewBoundedVec[U16, testMaxLen](1, 2)
+	assert.NoError(t, err)
+
+	AssertRoundtrip(t, v)
+	AssertEncode(t, []EncodingAssert{
+		{v, MustHexDecodeString("0x0801000200")},
+	})
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x0801000200"), v},
+	})
+}
+
+func TestBoundedVec_BoundExceeded(t *testing.T) {
+	_, err := NewBoundedVec[U16, testMaxLen](1, 2, 3)
+	assert.ErrorIs(t, err, ErrBoundedVecBoundExceeded)
+
+	_, err = Encode(BoundedVec[U16, testMaxLen]{1, 2, 3})
+	assert.ErrorIs(t, err, ErrBoundedVecBoundExceeded)
+
+	var v BoundedVec[U16, testMaxLen]
+
+	err = Decode(MustHexDecodeString("0x0c010002000300"), &v)
+	assert.ErrorIs(t, err, ErrBoundedVecBoundExceeded)
+	assert.EqualError(t, err, "bounded vec bound exceeded: length 3, bound 2")
+
+	// The length is checked before decoding the items.
+	err = Decode(MustHexDecodeString("0x13ffffffffffffffff"), &v)
+	assert.ErrorIs(t, err, ErrBoundedVecBoundExceeded)
+}
+
+func TestBoundedVec_MaxLen(t *testing.T) {
+	assert.Equal(t, 2, BoundedVec[U8, testMaxLen]{}.MaxLen())
+}
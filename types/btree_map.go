@@ -0,0 +1,249 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"cmp"
+	"math/big"
+	"reflect"
+	"slices"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// BTreeMap is the Go representation of the Rust BTreeMap type, which is encoded as a vector of key-value tuples
+// that is sorted by key.
+//
+// Keys are sorted in the order that Rust derives for their type: integers by value, strings, arrays and slices
+// lexicographically, structs field by field, and options, such as Option, with None first.
+type BTreeMap[K comparable, V any] map[K]V
+
+func (m *BTreeMap[K, V]) Decode(decoder scale.Decoder) error {
+	length, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return err
+	}
+
+	res := make(BTreeMap[K, V])
+
+	for i := uint64(0); i < length.Uint64(); i++ {
+		var (
+			key   K
+			value V
+		)
+
+		scope := decoder.Enter(scale.IndexSegment(int(i)), "")
+
+		err := decoder.Decode(&key)
+
+		if err == nil {
+			err = decoder.Decode(&value)
+		}
+
+		if err := scope.Exit(err); err != nil {
+			return err
+		}
+
+		res[key] = value
+	}
+
+	*m = res
+
+	return nil
+}
+
+func (m BTreeMap[K, V]) Encode(encoder scale.Encoder) error {
+	if err := encoder.EncodeUintCompact(*big.NewInt(int64(len(m)))); err != nil {
+		return err
+	}
+
+	keys, err := sortKeys(getMapKeys(m), newKeyEncoder[K](encoder))
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := encoder.Write(key.encoded); err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(m[key.key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Keys returns the sorted keys of the map.
+//
+// Keys that can only be sorted by their encoding are encoded with the default options, if that fails their order is
+// unspecified. Encode returns the encoding error instead.
+func (m BTreeMap[K, V]) Keys() []K {
+	return getSortedKeys(getMapKeys(m))
+}
+
+func getMapKeys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// sortedKey holds a key together with its encoding.
+type sortedKey[K comparable] struct {
+	key     K
+	encoded []byte
+}
+
+// sortKeys encodes the keys using the provided function and sorts them in the order that Rust derives for their
+// type, see BTreeMap. The keys that cannot be compared by their type, are compared by their encoding.
+func sortKeys[K comparable](keys []K, encodeKey func(key K) ([]byte, error)) ([]sortedKey[K], error) {
+	res := make([]sortedKey[K], 0, len(keys))
+
+	for _, key := range keys {
+		encoded, err := encodeKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, sortedKey[K]{key: key, encoded: encoded})
+	}
+
+	slices.SortFunc(res, func(a, b sortedKey[K]) int {
+		if c, ok := compareKeys(reflect.ValueOf(a.key), reflect.ValueOf(b.key)); ok {
+			return c
+		}
+
+		return bytes.Compare(a.encoded, b.encoded)
+	})
+
+	return res, nil
+}
+
+// getSortedKeys returns the keys sorted by sortKeys, encoding them with the default options.
+func getSortedKeys[K comparable](keys []K) []K {
+	sorted, _ := sortKeys(keys, func(key K) ([]byte, error) {
+		encoded, _ := codec.Encode(key)
+
+		return encoded, nil
+	})
+
+	res := make([]K, 0, len(sorted))
+
+	for _, key := range sorted {
+		res = append(res, key.key)
+	}
+
+	return res
+}
+
+// newKeyEncoder returns a function that encodes a key using an encoder created from the provided one, so that the
+// values that it carries are used.
+func newKeyEncoder[K comparable](encoder scale.Encoder) func(key K) ([]byte, error) {
+	return func(key K) ([]byte, error) {
+		var buf bytes.Buffer
+
+		if err := encoder.WithWriter(&buf).Encode(key); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+}
+
+// compareKeys compares the keys by their type, it returns false if they can only be compared by their encoding.
+//
+// nolint:gocyclo
+func compareKeys(a, b reflect.Value) (int, bool) {
+	if aInt, ok := getKeyBigInt(a); ok {
+		bInt, _ := getKeyBigInt(b)
+
+		return aInt.Cmp(bInt), true
+	}
+
+	switch a.Kind() {
+	case reflect.Bool:
+		return cmp.Compare(boolToInt(a.Bool()), boolToInt(b.Bool())), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float()), true
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String()), true
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			if c, ok := compareKeys(a.Index(i), b.Index(i)); !ok || c != 0 {
+				return c, ok
+			}
+		}
+
+		return cmp.Compare(a.Len(), b.Len()), true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if c, ok := compareKeys(a.Field(i), b.Field(i)); !ok || c != 0 {
+				return c, ok
+			}
+		}
+
+		return 0, true
+	case reflect.Pointer, reflect.Interface:
+		switch {
+		case a.IsNil() || b.IsNil():
+			return cmp.Compare(boolToInt(!a.IsNil()), boolToInt(!b.IsNil())), true
+		default:
+			return compareKeys(a.Elem(), b.Elem())
+		}
+	default:
+		// Keys of other kinds are sorted by their encoding.
+		return 0, false
+	}
+}
+
+func getKeyBigInt(v reflect.Value) (*big.Int, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+
+	switch i := v.Interface().(type) {
+	case U128:
+		return newNonNilBigInt(i.Int), true
+	case U256:
+		return newNonNilBigInt(i.Int), true
+	case I128:
+		return newNonNilBigInt(i.Int), true
+	case I256:
+		return newNonNilBigInt(i.Int), true
+	default:
+		return nil, false
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
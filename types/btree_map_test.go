@@ -0,0 +1,123 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type testBTreeKey struct {
+	Major U16
+	Minor U16
+}
+
+// testEncodedBTreeKey can only be sorted by its encoding.
+type testEncodedBTreeKey complex64
+
+func (k testEncodedBTreeKey) Encode(encoder scale.Encoder) error {
+	return encoder.PushByte(byte(real(k)))
+}
+
+func TestBTreeMap_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, BTreeMap[U32, Text]{1: "a", 300: "b", 2: "c"})
+	AssertRoundtrip(t, BTreeMap[AccountID, U128]{
+		newTestAccountID(): NewU128(*big.NewInt(5)),
+	})
+	AssertRoundtrip(t, BTreeMap[U8, U8]{})
+}
+
+func TestBTreeMap_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		// Integer keys are sorted by value, not by their little-endian encoding.
+		{BTreeMap[U16, U8]{256: 2, 1: 1}, MustHexDecodeString("0x08010001000102")},
+		{BTreeMap[Text, U8]{"b": 2, "a": 1, "": 0}, MustHexDecodeString("0x0c0000046101046202")},
+		{BTreeMap[testEncodedBTreeKey, U8]{2: 2, 1: 1}, MustHexDecodeString("0x0801010202")},
+	})
+}
+
+func TestBTreeMap_EncodeKeyError(t *testing.T) {
+	_, err := Encode(BTreeMap[complex64, U8]{1: 1, 2: 2})
+	assert.Error(t, err)
+}
+
+func TestBTreeMap_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x08010001000102"), BTreeMap[U16, U8]{256: 2, 1: 1}},
+		{MustHexDecodeString("0x00"), BTreeMap[U16, U8]{}},
+	})
+}
+
+func TestBTreeMap_Keys(t *testing.T) {
+	var tests = []struct {
+		Name     string
+		Map      any
+		Expected any
+	}{
+		{
+			Name:     "integers",
+			Map:      BTreeMap[I32, bool]{-1: true, 5: true, -300: true, 0: true},
+			Expected: []I32{-300, -1, 0, 5},
+		},
+		{
+			Name: "big integers",
+			Map: BTreeMap[U128, bool]{
+				NewU128(*new(big.Int).Lsh(big.NewInt(1), 100)): true,
+				NewU128(*big.NewInt(2)):                        true,
+			},
+			Expected: []U128{NewU128(*big.NewInt(2)), NewU128(*new(big.Int).Lsh(big.NewInt(1), 100))},
+		},
+		{
+			Name:     "arrays",
+			Map:      BTreeMap[[2]U8, bool]{{2, 0}: true, {1, 9}: true, {1, 2}: true},
+			Expected: [][2]U8{{1, 2}, {1, 9}, {2, 0}},
+		},
+		{
+			Name:     "structs",
+			Map:      BTreeMap[testBTreeKey, bool]{{2, 1}: true, {1, 300}: true, {1, 2}: true},
+			Expected: []testBTreeKey{{1, 2}, {1, 300}, {2, 1}},
+		},
+		{
+			Name:     "options",
+			Map:      BTreeMap[Option[U8], bool]{NewOption[U8](0): true, NewEmptyOption[U8](): true},
+			Expected: []Option[U8]{NewEmptyOption[U8](), NewOption[U8](0)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			switch m := test.Map.(type) {
+			case BTreeMap[I32, bool]:
+				assert.Equal(t, test.Expected, m.Keys())
+			case BTreeMap[U128, bool]:
+				assert.Equal(t, test.Expected, m.Keys())
+			case BTreeMap[[2]U8, bool]:
+				assert.Equal(t, test.Expected, m.Keys())
+			case BTreeMap[testBTreeKey, bool]:
+				assert.Equal(t, test.Expected, m.Keys())
+			case BTreeMap[Option[U8], bool]:
+				assert.Equal(t, test.Expected, m.Keys())
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// BTreeSet is the Go representation of the Rust BTreeSet type, which is encoded as a sorted vector of items. Items
+// are sorted like the keys of a BTreeMap.
+type BTreeSet[T comparable] map[T]struct{}
+
+// NewBTreeSet creates a BTreeSet that holds the provided items.
+func NewBTreeSet[T comparable](items ...T) BTreeSet[T] {
+	s := make(BTreeSet[T], len(items))
+
+	for _, item := range items {
+		s.Add(item)
+	}
+
+	return s
+}
+
+// Add adds the item to the set.
+func (s BTreeSet[T]) Add(item T) {
+	s[item] = struct{}{}
+}
+
+// Has returns true if the set holds the item.
+func (s BTreeSet[T]) Has(item T) bool {
+	_, ok := s[item]
+
+	return ok
+}
+
+// Items returns the sorted items of the set.
+//
+// Items that can only be sorted by their encoding are encoded with the default options, if that fails their order is
+// unspecified. Encode returns the encoding error instead.
+func (s BTreeSet[T]) Items() []T {
+	return getSortedKeys(getMapKeys(s))
+}
+
+func (s *BTreeSet[T]) Decode(decoder scale.Decoder) error {
+	length, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return err
+	}
+
+	res := make(BTreeSet[T])
+
+	for i := uint64(0); i < length.Uint64(); i++ {
+		var item T
+
+		scope := decoder.Enter(scale.IndexSegment(int(i)), "")
+
+		if err := scope.Exit(decoder.Decode(&item)); err != nil {
+			return err
+		}
+
+		res.Add(item)
+	}
+
+	*s = res
+
+	return nil
+}
+
+func (s BTreeSet[T]) Encode(encoder scale.Encoder) error {
+	if err := encoder.EncodeUintCompact(*big.NewInt(int64(len(s)))); err != nil {
+		return err
+	}
+
+	items, err := sortKeys(getMapKeys(s), newKeyEncoder[T](encoder))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if err := encoder.Write(item.encoded); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s BTreeSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Items())
+}
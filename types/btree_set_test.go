@@ -0,0 +1,68 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBTreeSet_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, NewBTreeSet[U32](3, 1, 2))
+	AssertRoundtrip(t, NewBTreeSet[AccountID](newTestAccountID()))
+	AssertRoundtrip(t, NewBTreeSet[U8]())
+}
+
+func TestBTreeSet_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{NewBTreeSet[U16](256, 1), MustHexDecodeString("0x0801000001")},
+		{NewBTreeSet[testEncodedBTreeKey](2, 1), MustHexDecodeString("0x080102")},
+	})
+}
+
+func TestBTreeSet_EncodeItemError(t *testing.T) {
+	_, err := Encode(NewBTreeSet[complex64](1, 2))
+	assert.Error(t, err)
+}
+
+func TestBTreeSet_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x0801000001"), NewBTreeSet[U16](256, 1)},
+	})
+}
+
+func TestBTreeSet_SetMethods(t *testing.T) {
+	s := NewBTreeSet[U8](2)
+
+	assert.True(t, s.Has(2))
+	assert.False(t, s.Has(1))
+
+	s.Add(1)
+
+	assert.True(t, s.Has(1))
+	assert.Equal(t, []U8{1, 2}, s.Items())
+}
+
+func TestBTreeSet_MarshalJSON(t *testing.T) {
+	b, err := NewBTreeSet[U8](2, 1).MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2]", string(b))
+}
@@ -53,7 +53,7 @@ func EncodeToHex(value interface{}) (string, error) {
 
 // Decode decodes `bz` with the scale codec into `target`. `target` should be a pointer.
 func Decode(bz []byte, target interface{}) error {
-	return scale.NewDecoder(bytes.NewReader(bz)).Decode(target)
+	return scale.NewDecoderFromBytes(bz).Decode(target)
 }
 
 // DecodeFromHex decodes `str` with the scale codec into `target`. `target` should be a pointer.
@@ -0,0 +1,113 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// CompactUnsigned is the constraint for the unsigned integer types that can be held by a Compact.
+type CompactUnsigned interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64 | U128 | U256
+}
+
+// Compact is the Go representation of the Rust Compact type, which holds an unsigned integer of type T that is
+// encoded using the compact encoding. Unlike UCompact, it keeps the width of the integer, which is enforced when
+// decoding.
+type Compact[T CompactUnsigned] struct {
+	value T
+}
+
+// NewCompact creates a Compact that holds the provided value.
+func NewCompact[T CompactUnsigned](value T) Compact[T] {
+	return Compact[T]{value: value}
+}
+
+// Value returns the value of the compact.
+func (c Compact[T]) Value() T {
+	return c.value
+}
+
+// SetValue sets the value of the compact.
+func (c *Compact[T]) SetValue(value T) {
+	c.value = value
+}
+
+// BigInt returns the value of the compact as a big.Int.
+func (c Compact[T]) BigInt() *big.Int {
+	switch v := any(c.value).(type) {
+	case U128:
+		return newNonNilBigInt(v.Int)
+	case U256:
+		return newNonNilBigInt(v.Int)
+	default:
+		return new(big.Int).SetUint64(reflect.ValueOf(v).Uint())
+	}
+}
+
+func (c *Compact[T]) Decode(decoder scale.Decoder) error {
+	value, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return err
+	}
+
+	switch target := any(&c.value).(type) {
+	case *U128:
+		if value.BitLen() > 128 {
+			return fmt.Errorf("compact value %v overflows U128", value)
+		}
+
+		*target = U128{value}
+	case *U256:
+		if value.BitLen() > 256 {
+			return fmt.Errorf("compact value %v overflows U256", value)
+		}
+
+		*target = U256{value}
+	default:
+		v := reflect.ValueOf(target).Elem()
+
+		if value.BitLen() > v.Type().Bits() {
+			return fmt.Errorf("compact value %v overflows %v", value, v.Type())
+		}
+
+		v.SetUint(value.Uint64())
+	}
+
+	return nil
+}
+
+func (c Compact[T]) Encode(encoder scale.Encoder) error {
+	return encoder.EncodeUintCompact(*c.BigInt())
+}
+
+func (c Compact[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.value)
+}
+
+func newNonNilBigInt(i *big.Int) *big.Int {
+	if i == nil {
+		return big.NewInt(0)
+	}
+
+	return new(big.Int).Set(i)
+}
@@ -0,0 +1,86 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompact_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, NewCompact[U8](math.MaxUint8))
+	AssertRoundtrip(t, NewCompact[U16](math.MaxUint16))
+	AssertRoundtrip(t, NewCompact[U32](math.MaxUint32))
+	AssertRoundtrip(t, NewCompact[U64](math.MaxUint64))
+	AssertRoundtrip(t, NewCompact[uint64](1<<40))
+	AssertRoundtrip(t, NewCompact(NewU128(*new(big.Int).Lsh(big.NewInt(1), 127))))
+	AssertRoundtrip(t, NewCompact(NewU256(*new(big.Int).Lsh(big.NewInt(1), 255))))
+}
+
+func TestCompact_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{NewCompact[U8](63), MustHexDecodeString("0xfc")},
+		{NewCompact[U32](64), MustHexDecodeString("0x0101")},
+		{NewCompact[U64](1 << 30), MustHexDecodeString("0x0300000040")},
+		{NewCompact(NewU128(*big.NewInt(1))), MustHexDecodeString("0x04")},
+		{NewCompact(U128{}), MustHexDecodeString("0x00")},
+	})
+}
+
+func TestCompact_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0xfc"), NewCompact[U8](63)},
+		{MustHexDecodeString("0x0101"), NewCompact[U32](64)},
+		{MustHexDecodeString("0x0300000040"), NewCompact[U64](1 << 30)},
+		{MustHexDecodeString("0x04"), NewCompact(NewU128(*big.NewInt(1)))},
+	})
+}
+
+func TestCompact_DecodeOverflow(t *testing.T) {
+	var u8 Compact[U8]
+
+	err := Decode(MustHexDecodeString("0x0104"), &u8)
+	assert.EqualError(t, err, "compact value 256 overflows types.U8")
+
+	var u128 Compact[U128]
+
+	encoded, err := Encode(NewCompact(NewU256(*new(big.Int).Lsh(big.NewInt(1), 128))))
+	assert.NoError(t, err)
+
+	err = Decode(encoded, &u128)
+	assert.EqualError(t, err, "compact value 340282366920938463463374607431768211456 overflows U128")
+}
+
+func TestCompact_BigInt(t *testing.T) {
+	assert.Equal(t, big.NewInt(5), NewCompact[U16](5).BigInt())
+	assert.Equal(t, big.NewInt(6), NewCompact(NewU128(*big.NewInt(6))).BigInt())
+	assert.Equal(t, big.NewInt(0), NewCompact(U256{}).BigInt())
+}
+
+func TestCompact_MarshalJSON(t *testing.T) {
+	c := NewCompact[U32](7)
+
+	b, err := c.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "7", string(b))
+}
@@ -54,6 +54,12 @@ type TokenError struct {
 	IsFrozen bool
 
 	IsUnsupported bool
+
+	IsCannotCreateHold bool
+
+	IsNotExpendable bool
+
+	IsBlocked bool
 }
 
 func (t *TokenError) Decode(decoder scale.Decoder) error {
@@ -78,6 +84,12 @@ func (t *TokenError) Decode(decoder scale.Decoder) error {
 		t.IsFrozen = true
 	case 6:
 		t.IsUnsupported = true
+	case 7:
+		t.IsCannotCreateHold = true
+	case 8:
+		t.IsNotExpendable = true
+	case 9:
+		t.IsBlocked = true
 	}
 
 	return nil
@@ -99,6 +111,12 @@ func (t TokenError) Encode(encoder scale.Encoder) error {
 		return encoder.PushByte(5)
 	case t.IsUnsupported:
 		return encoder.PushByte(6)
+	case t.IsCannotCreateHold:
+		return encoder.PushByte(7)
+	case t.IsNotExpendable:
+		return encoder.PushByte(8)
+	case t.IsBlocked:
+		return encoder.PushByte(9)
 	}
 
 	return nil
@@ -205,6 +223,14 @@ type DispatchError struct {
 
 	IsTransactional    bool
 	TransactionalError TransactionalError
+
+	IsExhausted bool
+
+	IsCorruption bool
+
+	IsUnavailable bool
+
+	IsRootNotAllowed bool
 }
 
 func (d *DispatchError) Decode(decoder scale.Decoder) error {
@@ -242,6 +268,14 @@ func (d *DispatchError) Decode(decoder scale.Decoder) error {
 		d.IsTransactional = true
 
 		return decoder.Decode(&d.TransactionalError)
+	case 10:
+		d.IsExhausted = true
+	case 11:
+		d.IsCorruption = true
+	case 12:
+		d.IsUnavailable = true
+	case 13:
+		d.IsRootNotAllowed = true
 	}
 
 	return nil
@@ -285,6 +319,14 @@ func (d DispatchError) Encode(encoder scale.Encoder) error {
 		}
 
 		return encoder.Encode(d.TransactionalError)
+	case d.IsExhausted:
+		return encoder.PushByte(10)
+	case d.IsCorruption:
+		return encoder.PushByte(11)
+	case d.IsUnavailable:
+		return encoder.PushByte(12)
+	case d.IsRootNotAllowed:
+		return encoder.PushByte(13)
 	}
 
 	return nil
@@ -69,10 +69,28 @@ var (
 			IsLimitReached: true,
 		},
 	}
+	testDispatchError11 = DispatchError{
+		IsExhausted: true,
+	}
+	testDispatchError12 = DispatchError{
+		IsCorruption: true,
+	}
+	testDispatchError13 = DispatchError{
+		IsUnavailable: true,
+	}
+	testDispatchError14 = DispatchError{
+		IsRootNotAllowed: true,
+	}
+	testDispatchError15 = DispatchError{
+		IsToken: true,
+		TokenError: TokenError{
+			IsBlocked: true,
+		},
+	}
 
 	tokenErrorFuzzOpts = []FuzzOpt{
 		WithFuzzFuncs(func(t *TokenError, c fuzz.Continue) {
-			switch c.Intn(7) {
+			switch c.Intn(10) {
 			case 0:
 				t.IsNoFunds = true
 			case 1:
@@ -87,6 +105,12 @@ var (
 				t.IsFrozen = true
 			case 6:
 				t.IsUnsupported = true
+			case 7:
+				t.IsCannotCreateHold = true
+			case 8:
+				t.IsNotExpendable = true
+			case 9:
+				t.IsBlocked = true
 			}
 		}),
 	}
@@ -118,7 +142,7 @@ var (
 		transactionalErrorFuzzOpts,
 		[]FuzzOpt{
 			WithFuzzFuncs(func(d *DispatchError, c fuzz.Continue) {
-				switch c.Intn(10) {
+				switch c.Intn(14) {
 				case 0:
 					d.IsOther = true
 				case 1:
@@ -147,6 +171,14 @@ var (
 					d.IsTransactional = true
 
 					c.Fuzz(&d.TransactionalError)
+				case 10:
+					d.IsExhausted = true
+				case 11:
+					d.IsCorruption = true
+				case 12:
+					d.IsUnavailable = true
+				case 13:
+					d.IsRootNotAllowed = true
 				}
 			}),
 		},
@@ -171,6 +203,11 @@ func TestDispatchError_Encode(t *testing.T) {
 		{testDispatchError8, MustHexDecodeString("0x0706")},
 		{testDispatchError9, MustHexDecodeString("0x0802")},
 		{testDispatchError10, MustHexDecodeString("0x0900")},
+		{testDispatchError11, MustHexDecodeString("0x0a")},
+		{testDispatchError12, MustHexDecodeString("0x0b")},
+		{testDispatchError13, MustHexDecodeString("0x0c")},
+		{testDispatchError14, MustHexDecodeString("0x0d")},
+		{testDispatchError15, MustHexDecodeString("0x0709")},
 	})
 }
 
@@ -186,5 +223,10 @@ func TestDispatchError_Decode(t *testing.T) {
 		{MustHexDecodeString("0x0706"), testDispatchError8},
 		{MustHexDecodeString("0x0802"), testDispatchError9},
 		{MustHexDecodeString("0x0900"), testDispatchError10},
+		{MustHexDecodeString("0x0a"), testDispatchError11},
+		{MustHexDecodeString("0x0b"), testDispatchError12},
+		{MustHexDecodeString("0x0c"), testDispatchError13},
+		{MustHexDecodeString("0x0d"), testDispatchError14},
+		{MustHexDecodeString("0x0709"), testDispatchError15},
 	})
 }
@@ -17,7 +17,6 @@
 package types
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -504,7 +503,7 @@ func (e EventRecordsRaw) DecodeEventRecords(m *Metadata, t interface{}) error {
 		return fmt.Errorf("target must point to a struct, but is " + fmt.Sprint(typ))
 	}
 
-	decoder := scale.NewDecoder(bytes.NewReader(e))
+	decoder := NewDecoderFromBytes(e, SerDeOptionsFromMetadata(m))
 
 	// determine number of events
 	n, err := decoder.DecodeUintCompact()
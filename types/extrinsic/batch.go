@@ -0,0 +1,306 @@
+package extrinsic
+
+import (
+	"math/big"
+
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	ErrBatchEmpty                 = libErr.Error("batch is empty")
+	ErrBatchCallCreation          = libErr.Error("batch call creation")
+	ErrBatchCallEncoding          = libErr.Error("batch call encoding")
+	ErrBatchWeightLimitExceeded   = libErr.Error("batch weight limit exceeded")
+	ErrBatchLengthLimitExceeded   = libErr.Error("batch length limit exceeded")
+	ErrBatchItemExceedsLimits     = libErr.Error("batch item exceeds limits")
+	ErrBlockWeightsRetrieval      = libErr.Error("block weights retrieval")
+	ErrBlockLengthRetrieval       = libErr.Error("block length retrieval")
+	ErrMaxExtrinsicWeightNotFound = libErr.Error("max extrinsic weight not found")
+)
+
+// BatchMode is the Utility call that is used for batching calls.
+type BatchMode string
+
+const (
+	// BatchModeBatch dispatches the calls until the first one that fails.
+	BatchModeBatch BatchMode = "Utility.batch"
+	// BatchModeBatchAll dispatches all the calls atomically, reverting all of them if one fails.
+	BatchModeBatchAll BatchMode = "Utility.batch_all"
+	// BatchModeForceBatch dispatches all the calls, regardless of their outcome.
+	BatchModeForceBatch BatchMode = "Utility.force_batch"
+)
+
+const (
+	systemModuleName           = "System"
+	blockWeightsConstantName   = "BlockWeights"
+	blockLengthConstantName    = "BlockLength"
+	batchCallIndexEncodedBytes = 2
+)
+
+// BatchItem is a call that is included in a batch.
+type BatchItem struct {
+	Call types.Call
+
+	// Weight is the weight of the call, as returned by the TransactionPaymentCallApi for example.
+	Weight types.Weight
+}
+
+// BatchLimits holds the maximum weight and length of a batch call.
+type BatchLimits struct {
+	MaxWeight types.Weight
+	MaxLength uint32
+}
+
+// GetBatchLimits returns the limits of an extrinsic of the normal dispatch class, as found in the
+// System.BlockWeights and System.BlockLength constants.
+func GetBatchLimits(meta *types.Metadata) (BatchLimits, error) {
+	var limits BatchLimits
+
+	blockWeightsBytes, err := meta.FindConstantValue(systemModuleName, blockWeightsConstantName)
+
+	if err != nil {
+		return limits, ErrBlockWeightsRetrieval.Wrap(err)
+	}
+
+	var blockWeights types.BlockWeights
+
+	if err := codec.Decode(blockWeightsBytes, &blockWeights); err != nil {
+		return limits, ErrBlockWeightsRetrieval.Wrap(err)
+	}
+
+	ok, maxWeight := blockWeights.PerClass.Normal.MaxExtrinsic.Unwrap()
+
+	if !ok {
+		return limits, ErrMaxExtrinsicWeightNotFound
+	}
+
+	blockLengthBytes, err := meta.FindConstantValue(systemModuleName, blockLengthConstantName)
+
+	if err != nil {
+		return limits, ErrBlockLengthRetrieval.Wrap(err)
+	}
+
+	var blockLength types.BlockLength
+
+	if err := codec.Decode(blockLengthBytes, &blockLength); err != nil {
+		return limits, ErrBlockLengthRetrieval.Wrap(err)
+	}
+
+	limits.MaxWeight = maxWeight
+	limits.MaxLength = uint32(blockLength.Max.Normal)
+
+	return limits, nil
+}
+
+// Batch is a Utility batch call along with the indices of the items that it includes.
+type Batch struct {
+	Mode BatchMode
+	Call types.Call
+
+	// ItemIndices holds the index of each batched call in the items that were used for creating the batch.
+	ItemIndices []int
+
+	// Weight is the sum of the weights of the batched calls.
+	Weight types.Weight
+
+	// Length is the length of the encoded batch call.
+	Length uint32
+}
+
+// NewBatch creates a batch that includes all the provided items, and returns an error
+// if the batch exceeds the provided limits.
+func NewBatch(meta *types.Metadata, mode BatchMode, limits BatchLimits, items ...BatchItem) (*Batch, error) {
+	if len(items) == 0 {
+		return nil, ErrBatchEmpty
+	}
+
+	var (
+		itemIndices []int
+		calls       []types.Call
+		weight      = newBatchWeight()
+	)
+
+	for i, item := range items {
+		itemIndices = append(itemIndices, i)
+		calls = append(calls, item.Call)
+		weight.add(item.Weight)
+	}
+
+	if weight.exceeds(limits.MaxWeight) {
+		return nil, ErrBatchWeightLimitExceeded
+	}
+
+	batch, err := newBatch(meta, mode, calls, itemIndices, weight)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if batch.Length > limits.MaxLength {
+		return nil, ErrBatchLengthLimitExceeded.WithMsg("length '%d', limit '%d'", batch.Length, limits.MaxLength)
+	}
+
+	return batch, nil
+}
+
+// NewBatches splits the provided items into consecutive batches that do not exceed the provided limits.
+//
+// An error is returned if an item exceeds the limits on its own.
+func NewBatches(meta *types.Metadata, mode BatchMode, limits BatchLimits, items ...BatchItem) ([]*Batch, error) {
+	if len(items) == 0 {
+		return nil, ErrBatchEmpty
+	}
+
+	var (
+		batches     []*Batch
+		itemIndices []int
+		calls       []types.Call
+		callsLength uint32
+		weight      = newBatchWeight()
+	)
+
+	for i, item := range items {
+		encodedCall, err := codec.Encode(item.Call)
+
+		if err != nil {
+			return nil, ErrBatchCallEncoding.Wrap(err)
+		}
+
+		callLength := uint32(len(encodedCall))
+
+		if !fitsInBatch(newBatchWeight(), 0, 0, item.Weight, callLength, limits) {
+			return nil, ErrBatchItemExceedsLimits.WithMsg("item '%d'", i)
+		}
+
+		if !fitsInBatch(weight, len(calls), callsLength, item.Weight, callLength, limits) {
+			batch, err := newBatch(meta, mode, calls, itemIndices, weight)
+
+			if err != nil {
+				return nil, err
+			}
+
+			batches = append(batches, batch)
+
+			itemIndices = nil
+			calls = nil
+			callsLength = 0
+			weight = newBatchWeight()
+		}
+
+		itemIndices = append(itemIndices, i)
+		calls = append(calls, item.Call)
+		callsLength += callLength
+		weight.add(item.Weight)
+	}
+
+	batch, err := newBatch(meta, mode, calls, itemIndices, weight)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(batches, batch), nil
+}
+
+func newBatch(
+	meta *types.Metadata,
+	mode BatchMode,
+	calls []types.Call,
+	itemIndices []int,
+	weight *batchWeight,
+) (*Batch, error) {
+	call, err := types.NewCall(meta, string(mode), calls)
+
+	if err != nil {
+		return nil, ErrBatchCallCreation.Wrap(err)
+	}
+
+	encodedCall, err := codec.Encode(call)
+
+	if err != nil {
+		return nil, ErrBatchCallEncoding.Wrap(err)
+	}
+
+	return &Batch{
+		Mode:        mode,
+		Call:        call,
+		ItemIndices: itemIndices,
+		Weight:      weight.toWeight(),
+		Length:      uint32(len(encodedCall)),
+	}, nil
+}
+
+// fitsInBatch checks if a call with the provided weight and length can be added to a batch that
+// has the provided weight, number of calls and length of the encoded calls.
+func fitsInBatch(
+	weight *batchWeight,
+	callsCount int,
+	callsLength uint32,
+	callWeight types.Weight,
+	callLength uint32,
+	limits BatchLimits,
+) bool {
+	newWeight := weight.clone()
+	newWeight.add(callWeight)
+
+	if newWeight.exceeds(limits.MaxWeight) {
+		return false
+	}
+
+	return getBatchLength(callsCount+1, callsLength+callLength) <= limits.MaxLength
+}
+
+// getBatchLength returns the length of an encoded batch call, which consists of the call index,
+// the compact encoded number of calls and the encoded calls.
+func getBatchLength(callsCount int, callsLength uint32) uint32 {
+	return batchCallIndexEncodedBytes + getCompactLength(uint64(callsCount)) + callsLength
+}
+
+func getCompactLength(value uint64) uint32 {
+	switch {
+	case value < 1<<6:
+		return 1
+	case value < 1<<14:
+		return 2
+	case value < 1<<30:
+		return 4
+	default:
+		// Big integer mode, one byte for the length prefix and the minimum amount of bytes for the value.
+		return 1 + uint32((big.NewInt(0).SetUint64(value).BitLen()+7)/8)
+	}
+}
+
+// batchWeight is used for adding up the weights of the batched calls.
+type batchWeight struct {
+	refTime   *big.Int
+	proofSize *big.Int
+}
+
+func newBatchWeight() *batchWeight {
+	return &batchWeight{
+		refTime:   big.NewInt(0),
+		proofSize: big.NewInt(0),
+	}
+}
+
+func (b *batchWeight) add(weight types.Weight) {
+	b.refTime.Add(b.refTime, (*big.Int)(&weight.RefTime))
+	b.proofSize.Add(b.proofSize, (*big.Int)(&weight.ProofSize))
+}
+
+func (b *batchWeight) exceeds(weight types.Weight) bool {
+	return b.refTime.Cmp((*big.Int)(&weight.RefTime)) > 0 || b.proofSize.Cmp((*big.Int)(&weight.ProofSize)) > 0
+}
+
+func (b *batchWeight) clone() *batchWeight {
+	return &batchWeight{
+		refTime:   big.NewInt(0).Set(b.refTime),
+		proofSize: big.NewInt(0).Set(b.proofSize),
+	}
+}
+
+func (b *batchWeight) toWeight() types.Weight {
+	return types.NewWeight(types.NewUCompact(b.refTime), types.NewUCompact(b.proofSize))
+}
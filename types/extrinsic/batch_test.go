@@ -0,0 +1,196 @@
+package extrinsic
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBatchLimits(t *testing.T) {
+	meta := getBatchTestMetadata(t)
+
+	limits, err := GetBatchLimits(meta)
+	assert.NoError(t, err)
+	assert.NotZero(t, limits.MaxLength)
+	assert.NotZero(t, limits.MaxWeight.RefTime.Int64())
+	assert.NotZero(t, limits.MaxWeight.ProofSize.Int64())
+}
+
+func TestGetBatchLimits_ConstantNotFound(t *testing.T) {
+	meta := getBatchTestMetadata(t)
+
+	for i, pallet := range meta.AsMetadataV14.Pallets {
+		if pallet.Name == "System" {
+			meta.AsMetadataV14.Pallets[i].Constants = nil
+		}
+	}
+
+	_, err := GetBatchLimits(meta)
+	assert.ErrorIs(t, err, ErrBlockWeightsRetrieval)
+}
+
+func TestNewBatch(t *testing.T) {
+	meta := getBatchTestMetadata(t)
+
+	items := getBatchTestItems(t, meta, 3, 10)
+
+	limits := BatchLimits{
+		MaxWeight: newTestWeight(100, 100),
+		MaxLength: 1000,
+	}
+
+	for _, mode := range []BatchMode{BatchModeBatch, BatchModeBatchAll, BatchModeForceBatch} {
+		t.Run(string(mode), func(t *testing.T) {
+			batch, err := NewBatch(meta, mode, limits, items...)
+			assert.NoError(t, err)
+			assert.Equal(t, mode, batch.Mode)
+			assert.Equal(t, []int{0, 1, 2}, batch.ItemIndices)
+			assert.Equal(t, newTestWeight(30, 30), batch.Weight)
+
+			expectedCallIndex, err := meta.FindCallIndex(string(mode))
+			assert.NoError(t, err)
+			assert.Equal(t, expectedCallIndex, batch.Call.CallIndex)
+
+			var calls []types.Call
+
+			for _, item := range items {
+				calls = append(calls, item.Call)
+			}
+
+			expectedArgs, err := codec.Encode(calls)
+			assert.NoError(t, err)
+			assert.Equal(t, types.Args(expectedArgs), batch.Call.Args)
+
+			encodedCall, err := codec.Encode(batch.Call)
+			assert.NoError(t, err)
+			assert.Equal(t, uint32(len(encodedCall)), batch.Length)
+		})
+	}
+}
+
+func TestNewBatch_Errors(t *testing.T) {
+	meta := getBatchTestMetadata(t)
+
+	items := getBatchTestItems(t, meta, 3, 10)
+
+	_, err := NewBatch(meta, BatchModeBatch, BatchLimits{})
+	assert.ErrorIs(t, err, ErrBatchEmpty)
+
+	_, err = NewBatch(meta, BatchModeBatch, BatchLimits{MaxWeight: newTestWeight(29, 100), MaxLength: 1000}, items...)
+	assert.ErrorIs(t, err, ErrBatchWeightLimitExceeded)
+
+	_, err = NewBatch(meta, BatchModeBatch, BatchLimits{MaxWeight: newTestWeight(100, 29), MaxLength: 1000}, items...)
+	assert.ErrorIs(t, err, ErrBatchWeightLimitExceeded)
+
+	_, err = NewBatch(meta, BatchModeBatch, BatchLimits{MaxWeight: newTestWeight(100, 100), MaxLength: 10}, items...)
+	assert.ErrorIs(t, err, ErrBatchLengthLimitExceeded)
+
+	_, err = NewBatch(meta, "Utility.unknown", BatchLimits{MaxWeight: newTestWeight(100, 100), MaxLength: 1000}, items...)
+	assert.ErrorIs(t, err, ErrBatchCallCreation)
+}
+
+func TestNewBatches_SplitByWeight(t *testing.T) {
+	meta := getBatchTestMetadata(t)
+
+	items := getBatchTestItems(t, meta, 5, 10)
+
+	limits := BatchLimits{
+		MaxWeight: newTestWeight(20, 100),
+		MaxLength: 1000,
+	}
+
+	batches, err := NewBatches(meta, BatchModeBatchAll, limits, items...)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 3)
+	assert.Equal(t, []int{0, 1}, batches[0].ItemIndices)
+	assert.Equal(t, []int{2, 3}, batches[1].ItemIndices)
+	assert.Equal(t, []int{4}, batches[2].ItemIndices)
+
+	for _, batch := range batches {
+		assert.False(t, newBatchWeightFrom(batch.Weight).exceeds(limits.MaxWeight))
+		assert.LessOrEqual(t, batch.Length, limits.MaxLength)
+	}
+}
+
+func TestNewBatches_SplitByLength(t *testing.T) {
+	meta := getBatchTestMetadata(t)
+
+	items := getBatchTestItems(t, meta, 4, 1)
+
+	singleBatch, err := NewBatch(meta, BatchModeBatch, BatchLimits{MaxWeight: newTestWeight(100, 100), MaxLength: 1000}, items[:2]...)
+	assert.NoError(t, err)
+
+	limits := BatchLimits{
+		MaxWeight: newTestWeight(100, 100),
+		MaxLength: singleBatch.Length,
+	}
+
+	batches, err := NewBatches(meta, BatchModeBatch, limits, items...)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Equal(t, []int{0, 1}, batches[0].ItemIndices)
+	assert.Equal(t, []int{2, 3}, batches[1].ItemIndices)
+	assert.Equal(t, singleBatch.Length, batches[0].Length)
+	assert.Equal(t, singleBatch.Length, batches[1].Length)
+}
+
+func TestNewBatches_Errors(t *testing.T) {
+	meta := getBatchTestMetadata(t)
+
+	items := getBatchTestItems(t, meta, 3, 10)
+
+	_, err := NewBatches(meta, BatchModeBatch, BatchLimits{})
+	assert.ErrorIs(t, err, ErrBatchEmpty)
+
+	_, err = NewBatches(meta, BatchModeBatch, BatchLimits{MaxWeight: newTestWeight(9, 100), MaxLength: 1000}, items...)
+	assert.ErrorIs(t, err, ErrBatchItemExceedsLimits)
+
+	_, err = NewBatches(meta, BatchModeBatch, BatchLimits{MaxWeight: newTestWeight(100, 100), MaxLength: 5}, items...)
+	assert.ErrorIs(t, err, ErrBatchItemExceedsLimits)
+}
+
+func Test_getCompactLength(t *testing.T) {
+	for _, value := range []uint64{0, 63, 64, 16383, 16384, 1<<30 - 1, 1 << 30, 1 << 40} {
+		encoded, err := codec.Encode(types.NewUCompactFromUInt(value))
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(len(encoded)), getCompactLength(value), "value %d", value)
+	}
+}
+
+func getBatchTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
+
+func getBatchTestItems(t *testing.T, meta *types.Metadata, count int, weight uint64) []BatchItem {
+	var items []BatchItem
+
+	for i := 0; i < count; i++ {
+		call, err := types.NewCall(meta, "System.remark", []byte{byte(i)})
+		assert.NoError(t, err)
+
+		items = append(items, BatchItem{
+			Call:   call,
+			Weight: newTestWeight(weight, weight),
+		})
+	}
+
+	return items
+}
+
+func newTestWeight(refTime, proofSize uint64) types.Weight {
+	return types.NewWeight(types.NewUCompactFromUInt(refTime), types.NewUCompactFromUInt(proofSize))
+}
+
+func newBatchWeightFrom(weight types.Weight) *batchWeight {
+	b := newBatchWeight()
+	b.add(weight)
+
+	return b
+}
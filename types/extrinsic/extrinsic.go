@@ -129,6 +129,65 @@ func (e *Extrinsic) Sign(signer signature.KeyringPair, meta *types.Metadata, opt
 	return nil
 }
 
+// SignFake adds a fake signature of the correct size to the extrinsic, along with the signed fields
+// provided via the SigningOption(s).
+//
+// The fake signature is an Sr25519 one, unless another type is provided via WithSignatureType, which must match the
+// key type of the signer since ECDSA signatures are longer than Ed25519 and Sr25519 ones.
+//
+// The resulting extrinsic has the same encoded length as a properly signed one, which makes it suitable for
+// fee estimation, however, it will be rejected by the node if submitted.
+func (e *Extrinsic) SignFake(signer types.MultiAddress, meta *types.Metadata, opts ...SigningOption) error {
+	if e.Type() != Version4 {
+		//nolint:lll
+		return ErrInvalidVersion.WithMsg("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(), e.Type())
+	}
+
+	encodedMethod, err := codec.Encode(e.Method)
+	if err != nil {
+		return ErrScaleEncode.Wrap(err)
+	}
+
+	fieldValues := SignedFieldValues{
+		signatureTypeOption: SignatureTypeSr25519,
+	}
+
+	for _, opt := range opts {
+		opt(fieldValues)
+	}
+
+	signatureType, ok := fieldValues[signatureTypeOption].(SignatureType)
+	if !ok {
+		return ErrInvalidSignatureType.WithMsg("unexpected signature type: %T", fieldValues[signatureTypeOption])
+	}
+
+	fakeSignature, err := newFakeSignature(signatureType)
+	if err != nil {
+		return err
+	}
+
+	payload, err := createPayload(meta, encodedMethod)
+
+	if err != nil {
+		return ErrPayloadCreation.Wrap(err)
+	}
+
+	if err := payload.MutateSignedFields(fieldValues); err != nil {
+		return ErrPayloadMutation.Wrap(err)
+	}
+
+	e.Signature = &Signature{
+		Signer:       signer,
+		Signature:    fakeSignature,
+		SignedFields: payload.SignedFields,
+	}
+
+	// mark the extrinsic as signed
+	e.Version |= BitSigned
+
+	return nil
+}
+
 func (e Extrinsic) Encode(encoder scale.Encoder) error {
 	if e.Type() != Version4 {
 		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(),
@@ -136,7 +195,7 @@ func (e Extrinsic) Encode(encoder scale.Encoder) error {
 	}
 
 	var bb = bytes.Buffer{}
-	tempEnc := scale.NewEncoder(&bb)
+	tempEnc := encoder.WithWriter(&bb)
 
 	err := tempEnc.Encode(e.Version)
 	if err != nil {
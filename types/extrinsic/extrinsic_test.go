@@ -205,3 +205,110 @@ func TestExtrinsic_Sign_MultiAddressCreationError(t *testing.T) {
 	)
 	assert.ErrorIs(t, err, ErrMultiAddressCreation)
 }
+
+func TestExtrinsic_SignFake(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	c, err := types.NewCall(&meta, "System.remark", []byte("test"))
+	assert.NoError(t, err)
+
+	opts := []SigningOption{
+		WithEra(types.ExtrinsicEra{IsImmortalEra: true}, types.Hash{}),
+		WithNonce(types.NewUCompactFromUInt(uint64(1))),
+		WithTip(types.NewUCompactFromUInt(0)),
+		WithSpecVersion(123),
+		WithTransactionVersion(456),
+		WithGenesisHash(types.Hash{}),
+		WithMetadataMode(extensions.CheckMetadataModeDisabled, extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()}),
+	}
+
+	signedExt := NewExtrinsic(c)
+
+	err = signedExt.Sign(signature.TestKeyringPairAlice, &meta, opts...)
+	assert.NoError(t, err)
+
+	signer, err := types.NewMultiAddressFromAccountID(signature.TestKeyringPairAlice.PublicKey)
+	assert.NoError(t, err)
+
+	fakeSignedExt := NewExtrinsic(c)
+
+	err = fakeSignedExt.SignFake(signer, &meta, opts...)
+	assert.NoError(t, err)
+	assert.True(t, fakeSignedExt.IsSigned())
+	assert.Equal(t, types.SignatureHash{}, fakeSignedExt.Signature.Signature.AsSr25519)
+
+	signedEnc, err := codec.Encode(signedExt)
+	assert.NoError(t, err)
+
+	fakeSignedEnc, err := codec.Encode(fakeSignedExt)
+	assert.NoError(t, err)
+
+	assert.Equal(t, len(signedEnc), len(fakeSignedEnc))
+}
+
+func TestExtrinsic_SignFake_SignatureType(t *testing.T) {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	c, err := types.NewCall(&meta, "System.remark", []byte("test"))
+	assert.NoError(t, err)
+
+	signer, err := types.NewMultiAddressFromAccountID(signature.TestKeyringPairAlice.PublicKey)
+	assert.NoError(t, err)
+
+	opts := []SigningOption{
+		WithEra(types.ExtrinsicEra{IsImmortalEra: true}, types.Hash{}),
+		WithNonce(types.NewUCompactFromUInt(uint64(1))),
+		WithTip(types.NewUCompactFromUInt(0)),
+		WithSpecVersion(123),
+		WithTransactionVersion(456),
+		WithGenesisHash(types.Hash{}),
+		WithMetadataMode(extensions.CheckMetadataModeDisabled, extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()}),
+	}
+
+	getFakeSignedLen := func(signatureOpts ...SigningOption) (int, types.MultiSignature) {
+		ext := NewExtrinsic(c)
+
+		err := ext.SignFake(signer, &meta, append(opts, signatureOpts...)...)
+		assert.NoError(t, err)
+
+		enc, err := codec.Encode(ext)
+		assert.NoError(t, err)
+
+		return len(enc), ext.Signature.Signature
+	}
+
+	sr25519Len, sig := getFakeSignedLen()
+	assert.True(t, sig.IsSr25519)
+
+	ed25519Len, sig := getFakeSignedLen(WithSignatureType(SignatureTypeEd25519))
+	assert.True(t, sig.IsEd25519)
+	assert.Equal(t, sr25519Len, ed25519Len)
+
+	// ECDSA signatures are 65 bytes long, one byte more than Ed25519 and Sr25519 ones.
+	ecdsaLen, sig := getFakeSignedLen(WithSignatureType(SignatureTypeEcdsa))
+	assert.True(t, sig.IsEcdsa)
+	assert.Equal(t, sr25519Len+1, ecdsaLen)
+
+	invalidExt := NewExtrinsic(c)
+
+	err = invalidExt.SignFake(signer, &meta, append(opts, WithSignatureType(SignatureType(3)))...)
+	assert.ErrorIs(t, err, ErrInvalidSignatureType)
+}
+
+func TestExtrinsic_SignFake_InvalidVersionError(t *testing.T) {
+	extrinsic := &Extrinsic{}
+
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	err = extrinsic.SignFake(types.MultiAddress{}, &meta)
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+}
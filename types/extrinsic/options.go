@@ -11,6 +11,17 @@ type SignedFieldValues map[SignedFieldName]any
 // SigningOption is the type used for providing values to a SignedFieldValues map.
 type SigningOption func(vals SignedFieldValues)
 
+// signatureTypeOption holds the SignatureType used by Extrinsic.SignFake, it is not a signed field.
+const signatureTypeOption SignedFieldName = "signature_type"
+
+// WithSignatureType returns a SigningOption that is used to set the type of the fake signature added by
+// Extrinsic.SignFake. The default is SignatureTypeSr25519.
+func WithSignatureType(signatureType SignatureType) SigningOption {
+	return func(vals SignedFieldValues) {
+		vals[signatureTypeOption] = signatureType
+	}
+}
+
 // WithEra returns a SigningOption that is used to add the era and block hash to a Payload.
 func WithEra(era types.ExtrinsicEra, blockHash types.Hash) SigningOption {
 	return func(vals SignedFieldValues) {
@@ -8,8 +8,32 @@ import (
 
 const (
 	ErrSignatureFieldEncoding = libErr.Error("signature field encoding failed")
+	ErrInvalidSignatureType   = libErr.Error("invalid signature type")
 )
 
+// SignatureType is the type of the signature held by a types.MultiSignature.
+type SignatureType uint8
+
+const (
+	SignatureTypeEd25519 SignatureType = iota
+	SignatureTypeSr25519
+	SignatureTypeEcdsa
+)
+
+// newFakeSignature returns an empty types.MultiSignature of the provided type.
+func newFakeSignature(signatureType SignatureType) (types.MultiSignature, error) {
+	switch signatureType {
+	case SignatureTypeEd25519:
+		return types.MultiSignature{IsEd25519: true}, nil
+	case SignatureTypeSr25519:
+		return types.MultiSignature{IsSr25519: true}, nil
+	case SignatureTypeEcdsa:
+		return types.MultiSignature{IsEcdsa: true}, nil
+	default:
+		return types.MultiSignature{}, ErrInvalidSignatureType.WithMsg("unsupported signature type: %d", signatureType)
+	}
+}
+
 // Signature holds all the relevant fields for an extrinsic signature.
 type Signature struct {
 	Signer       types.MultiAddress
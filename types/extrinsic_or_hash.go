@@ -0,0 +1,59 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var (
+	ErrInvalidExtrinsicOrHash = errors.New("extrinsic or hash is not set")
+)
+
+// ExtrinsicOrHash identifies an extrinsic in the transaction pool, either by its encoded bytes or by its hash.
+type ExtrinsicOrHash struct {
+	IsHash bool
+	AsHash Hash
+
+	IsExtrinsic bool
+	AsExtrinsic Bytes
+}
+
+// NewExtrinsicOrHashFromHash creates an ExtrinsicOrHash from an extrinsic hash.
+func NewExtrinsicOrHashFromHash(hash Hash) ExtrinsicOrHash {
+	return ExtrinsicOrHash{IsHash: true, AsHash: hash}
+}
+
+// NewExtrinsicOrHashFromBytes creates an ExtrinsicOrHash from the encoded extrinsic.
+func NewExtrinsicOrHashFromBytes(extrinsic []byte) ExtrinsicOrHash {
+	return ExtrinsicOrHash{IsExtrinsic: true, AsExtrinsic: extrinsic}
+}
+
+// MarshalJSON returns the JSON representation expected by author_removeExtrinsic.
+func (e ExtrinsicOrHash) MarshalJSON() ([]byte, error) {
+	switch {
+	case e.IsHash:
+		return json.Marshal(map[string]string{"hash": e.AsHash.Hex()})
+	case e.IsExtrinsic:
+		return json.Marshal(map[string]string{"extrinsic": codec.HexEncodeToString(e.AsExtrinsic)})
+	default:
+		return nil, ErrInvalidExtrinsicOrHash
+	}
+}
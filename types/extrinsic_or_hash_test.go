@@ -0,0 +1,38 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtrinsicOrHash_MarshalJSON(t *testing.T) {
+	res, err := json.Marshal(NewExtrinsicOrHashFromHash(Hash{1, 2}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"hash":"0x0102000000000000000000000000000000000000000000000000000000000000"}`, string(res))
+
+	res, err = json.Marshal(NewExtrinsicOrHashFromBytes([]byte{0xab, 0xcd}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"extrinsic":"0xabcd"}`, string(res))
+
+	_, err = json.Marshal(ExtrinsicOrHash{})
+	assert.ErrorIs(t, err, ErrInvalidExtrinsicOrHash)
+}
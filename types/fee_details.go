@@ -0,0 +1,146 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// InclusionFee is the base fee and adjusted weight and length fees that constitute the inclusion fee
+// of an extrinsic.
+type InclusionFee struct {
+	// BaseFee is the minimum amount a user pays for a transaction.
+	BaseFee U128
+	// LenFee is the amount paid for the encoded length (in bytes) of the transaction.
+	LenFee U128
+	// AdjustedWeightFee is the weight fee multiplied by the fee multiplier.
+	AdjustedWeightFee U128
+}
+
+func (i *InclusionFee) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&i.BaseFee); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&i.LenFee); err != nil {
+		return err
+	}
+
+	return decoder.Decode(&i.AdjustedWeightFee)
+}
+
+func (i InclusionFee) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(i.BaseFee); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(i.LenFee); err != nil {
+		return err
+	}
+
+	return encoder.Encode(i.AdjustedWeightFee)
+}
+
+// UnmarshalJSON fills i with the JSON encoded byte array given by b.
+func (i *InclusionFee) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		BaseFee           json.RawMessage `json:"baseFee"`
+		LenFee            json.RawMessage `json:"lenFee"`
+		AdjustedWeightFee json.RawMessage `json:"adjustedWeightFee"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	baseFee, err := balanceFromJSON(tmp.BaseFee)
+	if err != nil {
+		return err
+	}
+
+	lenFee, err := balanceFromJSON(tmp.LenFee)
+	if err != nil {
+		return err
+	}
+
+	adjustedWeightFee, err := balanceFromJSON(tmp.AdjustedWeightFee)
+	if err != nil {
+		return err
+	}
+
+	i.BaseFee = baseFee
+	i.LenFee = lenFee
+	i.AdjustedWeightFee = adjustedWeightFee
+
+	return nil
+}
+
+// FeeDetails holds the fee breakdown of an extrinsic, as returned by payment_queryFeeDetails or
+// the TransactionPaymentApi_query_fee_details runtime API.
+//
+// Note - the tip is not part of the SCALE encoding and it is only populated when known.
+type FeeDetails struct {
+	// InclusionFee is the minimum fee for a transaction to be included in a block.
+	// It is empty for unsigned extrinsics.
+	InclusionFee Option[InclusionFee]
+	// Tip is the optional tip that a user can add to a transaction.
+	Tip U128
+}
+
+func (f *FeeDetails) Decode(decoder scale.Decoder) error {
+	return decoder.Decode(&f.InclusionFee)
+}
+
+func (f FeeDetails) Encode(encoder scale.Encoder) error {
+	return encoder.Encode(f.InclusionFee)
+}
+
+// UnmarshalJSON fills f with the JSON encoded byte array given by b.
+func (f *FeeDetails) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		InclusionFee *InclusionFee   `json:"inclusionFee"`
+		Tip          json.RawMessage `json:"tip"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	if tmp.InclusionFee != nil {
+		f.InclusionFee = NewOption(*tmp.InclusionFee)
+	} else {
+		f.InclusionFee = NewEmptyOption[InclusionFee]()
+	}
+
+	if len(tmp.Tip) == 0 {
+		f.Tip = NewU128(*big.NewInt(0))
+
+		return nil
+	}
+
+	tip, err := balanceFromJSON(tmp.Tip)
+	if err != nil {
+		return err
+	}
+
+	f.Tip = tip
+
+	return nil
+}
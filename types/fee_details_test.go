@@ -0,0 +1,95 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testInclusionFee = InclusionFee{
+		BaseFee:           NewU128(*big.NewInt(1)),
+		LenFee:            NewU128(*big.NewInt(2)),
+		AdjustedWeightFee: NewU128(*big.NewInt(3)),
+	}
+
+	testFeeDetails = FeeDetails{
+		InclusionFee: NewOption(testInclusionFee),
+	}
+)
+
+func TestInclusionFee_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[InclusionFee](t, 1000)
+	AssertDecodeNilData[InclusionFee](t)
+	AssertEncodeEmptyObj[InclusionFee](t, 48)
+}
+
+func TestFeeDetails_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{
+			testFeeDetails,
+			MustHexDecodeString(
+				"0x01" +
+					"01000000000000000000000000000000" +
+					"02000000000000000000000000000000" +
+					"03000000000000000000000000000000",
+			),
+		},
+		{FeeDetails{InclusionFee: NewEmptyOption[InclusionFee]()}, MustHexDecodeString("0x00")},
+	})
+}
+
+func TestFeeDetails_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{
+			MustHexDecodeString(
+				"0x01" +
+					"01000000000000000000000000000000" +
+					"02000000000000000000000000000000" +
+					"03000000000000000000000000000000",
+			),
+			testFeeDetails,
+		},
+		{MustHexDecodeString("0x00"), FeeDetails{InclusionFee: NewEmptyOption[InclusionFee]()}},
+	})
+}
+
+func TestFeeDetails_UnmarshalJSON(t *testing.T) {
+	var res FeeDetails
+
+	err := json.Unmarshal(
+		[]byte(`{"inclusionFee":{"baseFee":"0x1","lenFee":"0x2","adjustedWeightFee":"0x3"},"tip":"0x5"}`),
+		&res,
+	)
+	assert.NoError(t, err)
+
+	expected := testFeeDetails
+	expected.Tip = NewU128(*big.NewInt(5))
+
+	assert.Equal(t, expected, res)
+
+	err = json.Unmarshal([]byte(`{"inclusionFee":null}`), &res)
+	assert.NoError(t, err)
+	assert.Equal(t, FeeDetails{InclusionFee: NewEmptyOption[InclusionFee](), Tip: NewU128(*big.NewInt(0))}, res)
+}
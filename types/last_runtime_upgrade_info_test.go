@@ -0,0 +1,42 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+)
+
+var testLastRuntimeUpgradeInfo = LastRuntimeUpgradeInfo{
+	SpecVersion: NewUCompactFromUInt(1020),
+	SpecName:    NewText("polkadot"),
+}
+
+func TestLastRuntimeUpgradeInfo_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testLastRuntimeUpgradeInfo, MustHexDecodeString("0xf10f20706f6c6b61646f74")},
+	})
+}
+
+func TestLastRuntimeUpgradeInfo_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0xf10f20706f6c6b61646f74"), testLastRuntimeUpgradeInfo},
+	})
+}
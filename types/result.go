@@ -0,0 +1,127 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// Result is the Go representation of the Rust Result type, which is encoded as a variant that holds the ok value at
+// index 0 and the error value at index 1.
+type Result[T, E any] struct {
+	isErr bool
+	ok    T
+	err   E
+}
+
+// NewOkResult creates a Result that holds the provided ok value.
+func NewOkResult[T, E any](value T) Result[T, E] {
+	return Result[T, E]{ok: value}
+}
+
+// NewErrResult creates a Result that holds the provided error value.
+func NewErrResult[T, E any](err E) Result[T, E] {
+	return Result[T, E]{isErr: true, err: err}
+}
+
+func (r *Result[T, E]) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		var ok T
+
+		if err := decoder.Decode(&ok); err != nil {
+			return err
+		}
+
+		r.SetOk(ok)
+
+		return nil
+	case 1:
+		var e E
+
+		if err := decoder.Decode(&e); err != nil {
+			return err
+		}
+
+		r.SetErr(e)
+
+		return nil
+	default:
+		return fmt.Errorf("unknown Result variant: %v", b)
+	}
+}
+
+func (r Result[T, E]) Encode(encoder scale.Encoder) error {
+	if r.isErr {
+		if err := encoder.PushByte(1); err != nil {
+			return err
+		}
+
+		return encoder.Encode(r.err)
+	}
+
+	if err := encoder.PushByte(0); err != nil {
+		return err
+	}
+
+	return encoder.Encode(r.ok)
+}
+
+// SetOk sets the ok value and removes the error value.
+func (r *Result[T, E]) SetOk(value T) {
+	var err E
+
+	r.isErr = false
+	r.ok = value
+	r.err = err
+}
+
+// SetErr sets the error value and removes the ok value.
+func (r *Result[T, E]) SetErr(err E) {
+	var ok T
+
+	r.isErr = true
+	r.ok = ok
+	r.err = err
+}
+
+// IsOk returns true if the result holds an ok value.
+func (r *Result[T, E]) IsOk() bool {
+	return !r.isErr
+}
+
+// IsErr returns true if the result holds an error value.
+func (r *Result[T, E]) IsErr() bool {
+	return r.isErr
+}
+
+// Ok returns the ok value and a flag that indicates whether the result holds it.
+func (r *Result[T, E]) Ok() (value T, ok bool) {
+	return r.ok, !r.isErr
+}
+
+// Err returns the error value and a flag that indicates whether the result holds it.
+func (r *Result[T, E]) Err() (err E, ok bool) {
+	return r.err, r.isErr
+}
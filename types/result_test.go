@@ -0,0 +1,79 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, NewOkResult[U32, Text](5))
+	AssertRoundtrip(t, NewErrResult[U32, Text]("error"))
+	AssertRoundtrip(t, NewOkResult[Option[U8], DispatchError](NewOption[U8](1)))
+}
+
+func TestResult_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{NewOkResult[U16, U8](1), MustHexDecodeString("0x000100")},
+		{NewErrResult[U16, U8](2), MustHexDecodeString("0x0102")},
+	})
+}
+
+func TestResult_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x000100"), NewOkResult[U16, U8](1)},
+		{MustHexDecodeString("0x0102"), NewErrResult[U16, U8](2)},
+	})
+
+	var r Result[U16, U8]
+
+	assert.EqualError(t, Decode(MustHexDecodeString("0x0201"), &r), "unknown Result variant: 2")
+}
+
+func TestResult_ResultMethods(t *testing.T) {
+	r := NewOkResult[U16, Text](1)
+
+	value, ok := r.Ok()
+	assert.True(t, ok)
+	assert.True(t, r.IsOk())
+	assert.Equal(t, U16(1), value)
+
+	_, ok = r.Err()
+	assert.False(t, ok)
+
+	r.SetErr("error")
+
+	err, ok := r.Err()
+	assert.True(t, ok)
+	assert.True(t, r.IsErr())
+	assert.Equal(t, Text("error"), err)
+
+	value, ok = r.Ok()
+	assert.False(t, ok)
+	assert.Equal(t, U16(0), value)
+
+	r.SetOk(2)
+
+	value, ok = r.Ok()
+	assert.True(t, ok)
+	assert.Equal(t, U16(2), value)
+}
@@ -0,0 +1,180 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// RuntimeDispatchInfo holds the information that is returned when querying the fees of an extrinsic
+// via payment_queryInfo or the TransactionPaymentApi_query_info runtime API.
+type RuntimeDispatchInfo struct {
+	// Weight of this dispatch
+	Weight Weight
+	// Class of this dispatch
+	Class DispatchClass
+	// PartialFee is the inclusion fee of this dispatch, it does not include the tip or anything else
+	// that depends on the signature (i.e. depends on a `SignedExtension`).
+	PartialFee U128
+}
+
+func (r *RuntimeDispatchInfo) Decode(decoder scale.Decoder) error {
+	if err := decoder.Decode(&r.Weight); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&r.Class); err != nil {
+		return err
+	}
+
+	return decoder.Decode(&r.PartialFee)
+}
+
+func (r RuntimeDispatchInfo) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(r.Weight); err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(r.Class); err != nil {
+		return err
+	}
+
+	return encoder.Encode(r.PartialFee)
+}
+
+// UnmarshalJSON fills r with the JSON encoded byte array given by b.
+//
+// Both the legacy format, where the weight is a plain number, and the current format,
+// where the weight holds the ref time and proof size, are supported.
+func (r *RuntimeDispatchInfo) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		Weight     json.RawMessage `json:"weight"`
+		Class      string          `json:"class"`
+		PartialFee json.RawMessage `json:"partialFee"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	weight, err := weightFromJSON(tmp.Weight)
+	if err != nil {
+		return err
+	}
+
+	class, err := dispatchClassFromJSON(tmp.Class)
+	if err != nil {
+		return err
+	}
+
+	partialFee, err := balanceFromJSON(tmp.PartialFee)
+	if err != nil {
+		return err
+	}
+
+	r.Weight = weight
+	r.Class = class
+	r.PartialFee = partialFee
+
+	return nil
+}
+
+func weightFromJSON(b json.RawMessage) (Weight, error) {
+	var refTime uint64
+
+	if err := json.Unmarshal(b, &refTime); err == nil {
+		return NewWeight(NewUCompactFromUInt(refTime), NewUCompactFromUInt(0)), nil
+	}
+
+	var tmp struct {
+		RefTime        *uint64 `json:"refTime"`
+		RefTimeSnake   *uint64 `json:"ref_time"`
+		ProofSize      *uint64 `json:"proofSize"`
+		ProofSizeSnake *uint64 `json:"proof_size"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return Weight{}, err
+	}
+
+	var weight Weight
+
+	switch {
+	case tmp.RefTime != nil:
+		weight.RefTime = NewUCompactFromUInt(*tmp.RefTime)
+	case tmp.RefTimeSnake != nil:
+		weight.RefTime = NewUCompactFromUInt(*tmp.RefTimeSnake)
+	default:
+		return Weight{}, fmt.Errorf("weight ref time not found in %s", string(b))
+	}
+
+	switch {
+	case tmp.ProofSize != nil:
+		weight.ProofSize = NewUCompactFromUInt(*tmp.ProofSize)
+	case tmp.ProofSizeSnake != nil:
+		weight.ProofSize = NewUCompactFromUInt(*tmp.ProofSizeSnake)
+	default:
+		weight.ProofSize = NewUCompactFromUInt(0)
+	}
+
+	return weight, nil
+}
+
+func dispatchClassFromJSON(s string) (DispatchClass, error) {
+	switch strings.ToLower(s) {
+	case "normal":
+		return DispatchClass{IsNormal: true}, nil
+	case "operational":
+		return DispatchClass{IsOperational: true}, nil
+	case "mandatory":
+		return DispatchClass{IsMandatory: true}, nil
+	default:
+		return DispatchClass{}, fmt.Errorf("unknown dispatch class %s", s)
+	}
+}
+
+// balanceFromJSON parses a balance that is either a JSON number, a decimal string or
+// a hex encoded string, as returned by the RPC for `NumberOrHex` values.
+func balanceFromJSON(b json.RawMessage) (U128, error) {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		// Not a string, the value should be a plain number.
+		s = string(b)
+	}
+
+	i := new(big.Int)
+
+	var ok bool
+
+	if strings.HasPrefix(s, "0x") {
+		_, ok = i.SetString(strings.TrimPrefix(s, "0x"), 16)
+	} else {
+		_, ok = i.SetString(s, 10)
+	}
+
+	if !ok {
+		return U128{}, fmt.Errorf("invalid balance %s", string(b))
+	}
+
+	return NewU128(*i), nil
+}
@@ -0,0 +1,105 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testRuntimeDispatchInfo = RuntimeDispatchInfo{
+		Weight:     testWeight,
+		Class:      DispatchClass{IsOperational: true},
+		PartialFee: NewU128(*big.NewInt(123)),
+	}
+)
+
+func TestRuntimeDispatchInfo_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[RuntimeDispatchInfo](t, 1000, dispatchClassFuzzOpts...)
+	AssertDecodeNilData[RuntimeDispatchInfo](t)
+	AssertEncodeEmptyObj[RuntimeDispatchInfo](t, 18)
+}
+
+func TestRuntimeDispatchInfo_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testRuntimeDispatchInfo, MustHexDecodeString("0x2ce909017b000000000000000000000000000000")},
+	})
+}
+
+func TestRuntimeDispatchInfo_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x2ce909017b000000000000000000000000000000"), testRuntimeDispatchInfo},
+	})
+}
+
+func TestRuntimeDispatchInfo_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected RuntimeDispatchInfo
+	}{
+		{
+			name:     "weight v2",
+			input:    `{"weight":{"ref_time":11,"proof_size":634},"class":"operational","partialFee":"123"}`,
+			expected: testRuntimeDispatchInfo,
+		},
+		{
+			name:     "weight v2 camel case",
+			input:    `{"weight":{"refTime":11,"proofSize":634},"class":"operational","partialFee":"0x7b"}`,
+			expected: testRuntimeDispatchInfo,
+		},
+		{
+			name:  "weight v1",
+			input: `{"weight":11,"class":"normal","partialFee":123}`,
+			expected: RuntimeDispatchInfo{
+				Weight:     NewWeight(NewUCompactFromUInt(11), NewUCompactFromUInt(0)),
+				Class:      DispatchClass{IsNormal: true},
+				PartialFee: NewU128(*big.NewInt(123)),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res RuntimeDispatchInfo
+
+			err := json.Unmarshal([]byte(test.input), &res)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestRuntimeDispatchInfo_UnmarshalJSON_Errors(t *testing.T) {
+	var res RuntimeDispatchInfo
+
+	err := json.Unmarshal([]byte(`{"weight":{"proof_size":634},"class":"normal","partialFee":"1"}`), &res)
+	assert.Error(t, err)
+
+	err = json.Unmarshal([]byte(`{"weight":1,"class":"unknown","partialFee":"1"}`), &res)
+	assert.Error(t, err)
+
+	err = json.Unmarshal([]byte(`{"weight":1,"class":"normal","partialFee":"abc"}`), &res)
+	assert.Error(t, err)
+}
@@ -16,7 +16,15 @@
 
 package types
 
-import "sync"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
 
 // SerDeOptions are serialise and deserialize options for types
 type SerDeOptions struct {
@@ -27,13 +35,92 @@ type SerDeOptions struct {
 var defaultOptions = SerDeOptions{}
 var mu sync.RWMutex
 
-// SetSerDeOptions overrides default serialise and deserialize options
+// SetSerDeOptions overrides default serialise and deserialize options, which are used by the encoders and decoders
+// that don't carry their own options.
+//
+// Deprecated: the default options are shared by the whole process, use NewEncoder, NewDecoder, EncodeWithOptions or
+// DecodeWithOptions to work with multiple chains that need different options.
 func SetSerDeOptions(so SerDeOptions) {
 	defer mu.Unlock()
 	mu.Lock()
 	defaultOptions = so
 }
 
+func getDefaultSerDeOptions() SerDeOptions {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return defaultOptions
+}
+
+type serDeOptionsKey struct{}
+
+type valueCarrier interface {
+	Value(key any) any
+}
+
+// getSerDeOptions returns the options carried by the encoder or decoder, or the default options if there are none.
+func getSerDeOptions(carrier valueCarrier) SerDeOptions {
+	if opts, ok := carrier.Value(serDeOptionsKey{}).(SerDeOptions); ok {
+		return opts
+	}
+
+	return getDefaultSerDeOptions()
+}
+
+// NewEncoder returns a scale.Encoder that encodes the types using the provided options.
+func NewEncoder(writer io.Writer, opts SerDeOptions) *scale.Encoder {
+	return scale.NewEncoder(writer).WithValue(serDeOptionsKey{}, opts)
+}
+
+// NewDecoder returns a scale.Decoder that decodes the types using the provided options.
+func NewDecoder(reader io.Reader, opts SerDeOptions) *scale.Decoder {
+	return scale.NewDecoder(reader).WithValue(serDeOptionsKey{}, opts)
+}
+
+// NewDecoderFromBytes returns a scale.Decoder that decodes the types from the provided bytes using the provided
+// options.
+func NewDecoderFromBytes(bz []byte, opts SerDeOptions) *scale.Decoder {
+	return scale.NewDecoderFromBytes(bz).WithValue(serDeOptionsKey{}, opts)
+}
+
+// EncodeWithOptions encodes the value using the provided options, see codec.Encode.
+func EncodeWithOptions(value interface{}, opts SerDeOptions) ([]byte, error) {
+	var buffer = bytes.Buffer{}
+
+	if err := NewEncoder(&buffer, opts).Encode(value); err != nil {
+		return buffer.Bytes(), err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// EncodeToHexWithOptions encodes the value to a hex string using the provided options, see codec.EncodeToHex.
+func EncodeToHexWithOptions(value interface{}, opts SerDeOptions) (string, error) {
+	bz, err := EncodeWithOptions(value, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%#x", bz), nil
+}
+
+// DecodeWithOptions decodes the bytes into the target using the provided options, see codec.Decode.
+func DecodeWithOptions(bz []byte, target interface{}, opts SerDeOptions) error {
+	return NewDecoderFromBytes(bz, opts).Decode(target)
+}
+
+// DecodeFromHexWithOptions decodes the hex string into the target using the provided options,
+// see codec.DecodeFromHex.
+func DecodeFromHexWithOptions(str string, target interface{}, opts SerDeOptions) error {
+	bz, err := codec.HexDecodeString(str)
+	if err != nil {
+		return err
+	}
+
+	return DecodeWithOptions(bz, target, opts)
+}
+
 // SerDeOptionsFromMetadata returns Serialise and deserialize options from metadata
 func SerDeOptionsFromMetadata(meta *Metadata) SerDeOptions {
 	var opts SerDeOptions
@@ -17,6 +17,8 @@
 package types_test
 
 import (
+	"bytes"
+	"sync"
 	"testing"
 
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -29,3 +31,58 @@ func TestSerDeOptionsFromMetadata(t *testing.T) {
 	opts := SerDeOptionsFromMetadata(meta)
 	assert.True(t, opts.NoPalletIndices)
 }
+
+type testSerDeStruct struct {
+	Addresses []Address
+	Other     U8
+}
+
+func TestSerDeOptions_Concurrent(t *testing.T) {
+	emptyAddress, err := NewAddressFromAccountID(make([]byte, 32))
+	assert.NoError(t, err)
+
+	value := testSerDeStruct{
+		Addresses: []Address{newTestAddress(), emptyAddress},
+		Other:     5,
+	}
+
+	var wg sync.WaitGroup
+
+	for _, opts := range []SerDeOptions{{NoPalletIndices: true}, {NoPalletIndices: false}} {
+		opts := opts
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				enc, err := EncodeToHexWithOptions(value, opts)
+				assert.NoError(t, err)
+
+				var res testSerDeStruct
+
+				assert.NoError(t, DecodeFromHexWithOptions(enc, &res, opts))
+				assert.Equal(t, value, res)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestSerDeOptions_DecoderOptions(t *testing.T) {
+	enc, err := EncodeWithOptions(newTestAddress(), SerDeOptions{NoPalletIndices: true})
+	assert.NoError(t, err)
+
+	var res Address
+
+	decoder := NewDecoder(bytes.NewReader(enc), SerDeOptions{NoPalletIndices: true})
+	assert.NoError(t, decoder.Decode(&res))
+	assert.Equal(t, newTestAddress(), res)
+
+	var buf bytes.Buffer
+
+	assert.NoError(t, NewEncoder(&buf, SerDeOptions{NoPalletIndices: false}).Encode(res))
+	assert.Equal(t, append([]byte{0xff}, enc...), buf.Bytes())
+}
@@ -0,0 +1,75 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// WrapperKeepOpaque is the Go representation of the Substrate WrapperKeepOpaque type, which holds a value of type T
+// that is encoded as bytes, i.e. its encoding is prefixed with its compact length. The encoded value is kept as is
+// when decoding, so that values that can't be decoded don't prevent decoding the type that holds them. The value
+// is decoded when it is accessed with Value.
+type WrapperKeepOpaque[T any] struct {
+	data []byte
+}
+
+// NewWrapperKeepOpaque creates a WrapperKeepOpaque that holds the encoded value.
+func NewWrapperKeepOpaque[T any](value T) (WrapperKeepOpaque[T], error) {
+	var w WrapperKeepOpaque[T]
+
+	if err := w.SetValue(value); err != nil {
+		return w, err
+	}
+
+	return w, nil
+}
+
+// Bytes returns the encoded value.
+func (w WrapperKeepOpaque[T]) Bytes() []byte {
+	return w.data
+}
+
+// Value decodes and returns the value.
+func (w WrapperKeepOpaque[T]) Value() (T, error) {
+	var value T
+
+	err := codec.Decode(w.data, &value)
+
+	return value, err
+}
+
+// SetValue encodes and stores the value.
+func (w *WrapperKeepOpaque[T]) SetValue(value T) error {
+	data, err := codec.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	w.data = data
+
+	return nil
+}
+
+func (w *WrapperKeepOpaque[T]) Decode(decoder scale.Decoder) error {
+	return decoder.Decode(&w.data)
+}
+
+func (w WrapperKeepOpaque[T]) Encode(encoder scale.Encoder) error {
+	return encoder.Encode(w.data)
+}
@@ -0,0 +1,57 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapperKeepOpaque_EncodeDecode(t *testing.T) {
+	w, err := NewWrapperKeepOpaque[U32](5)
+	assert.NoError(t, err)
+
+	AssertRoundtrip(t, w)
+	AssertEncode(t, []EncodingAssert{
+		{w, MustHexDecodeString("0x1005000000")},
+	})
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x1005000000"), w},
+	})
+}
+
+func TestWrapperKeepOpaque_Value(t *testing.T) {
+	var w WrapperKeepOpaque[Text]
+
+	// The value is only decoded when it is accessed.
+	err := Decode(MustHexDecodeString("0x0408"), &w)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{8}, w.Bytes())
+
+	_, err = w.Value()
+	assert.Error(t, err)
+
+	assert.NoError(t, w.SetValue("abc"))
+
+	value, err := w.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, Text("abc"), value)
+}